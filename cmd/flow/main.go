@@ -30,20 +30,27 @@ import (
 	"github.com/onflow/flow-cli/internal/collections"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/config"
+	"github.com/onflow/flow-cli/internal/daemon"
 	"github.com/onflow/flow-cli/internal/emulator"
 	"github.com/onflow/flow-cli/internal/events"
 	"github.com/onflow/flow-cli/internal/keys"
+	"github.com/onflow/flow-cli/internal/network"
+	"github.com/onflow/flow-cli/internal/payers"
 	"github.com/onflow/flow-cli/internal/project"
 	"github.com/onflow/flow-cli/internal/quick"
+	"github.com/onflow/flow-cli/internal/record"
 	"github.com/onflow/flow-cli/internal/scripts"
 	"github.com/onflow/flow-cli/internal/settings"
 	"github.com/onflow/flow-cli/internal/signatures"
 	"github.com/onflow/flow-cli/internal/snapshot"
+	"github.com/onflow/flow-cli/internal/staking"
 	"github.com/onflow/flow-cli/internal/status"
 	"github.com/onflow/flow-cli/internal/super"
+	"github.com/onflow/flow-cli/internal/templates"
 	"github.com/onflow/flow-cli/internal/test"
 	"github.com/onflow/flow-cli/internal/tools"
 	"github.com/onflow/flow-cli/internal/transactions"
+	"github.com/onflow/flow-cli/internal/tutorial"
 	"github.com/onflow/flow-cli/internal/util"
 	"github.com/onflow/flow-cli/internal/version"
 )
@@ -64,6 +71,9 @@ func main() {
 	tools.DevWallet.AddToParent(cmd)
 	tools.Flowser.AddToParent(cmd)
 	test.TestCommand.AddToParent(cmd)
+	transactions.TransferCommand.AddToParent(cmd)
+	daemon.Command.AddToParent(cmd)
+	tutorial.Command.AddToParent(cmd)
 
 	// super commands
 	super.SetupCommand.AddToParent(cmd)
@@ -85,6 +95,11 @@ func main() {
 	cmd.AddCommand(config.Cmd)
 	cmd.AddCommand(signatures.Cmd)
 	cmd.AddCommand(snapshot.Cmd)
+	cmd.AddCommand(staking.Cmd)
+	cmd.AddCommand(payers.Cmd)
+	cmd.AddCommand(network.Cmd)
+	cmd.AddCommand(templates.Cmd)
+	cmd.AddCommand(record.Cmd)
 
 	command.InitFlags(cmd)
 	cmd.AddGroup(&cobra.Group{
@@ -121,4 +136,7 @@ func main() {
 	if status := *test.TestCommand.Status; status > 0 {
 		os.Exit(int(status))
 	}
+	if status := *cadence.CheckCommand.Status; status > 0 {
+		os.Exit(status)
+	}
 }
@@ -65,6 +65,10 @@ func fromConfig(account config.Account) (*Account, error) {
 		return nil, err
 	}
 
+	if execKey, ok := key.(*ExecKey); ok {
+		execKey.address = account.Address // the external signer is told which account it signs for
+	}
+
 	return &Account{
 		Name:    account.Name,
 		Address: account.Address,
@@ -165,7 +169,7 @@ func (a Accounts) ByName(name string) (*Account, error) {
 func (a *Accounts) AddOrUpdate(account *Account) {
 	for i, acc := range *a {
 		if acc.Name == account.Name {
-			(*a)[i] = acc
+			(*a)[i] = *account
 			return
 		}
 	}
@@ -0,0 +1,129 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/awskms"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Key = &AWSKMSKey{}
+
+// AWSKMSKey implements AWS KMS system for signing.
+//
+// The credentials are resolved with the standard AWS SDK chain, from the environment, the shared configuration and
+// credentials files or the instance role. The key is always used in the region of its ARN.
+type AWSKMSKey struct {
+	*baseKey
+	arn    string
+	kmsKey awskms.Key
+}
+
+// NewAWSKMSKey creates a new account key that signs with the AWS KMS key of the provided ARN.
+func NewAWSKMSKey(
+	arn string,
+	index int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*AWSKMSKey, error) {
+	kmsKey, err := awsKMSKeyFromARN(arn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AWSKMSKey{
+		baseKey: &baseKey{
+			keyType:  config.KeyTypeKMS,
+			index:    index,
+			sigAlgo:  sigAlgo,
+			hashAlgo: hashAlgo,
+		},
+		arn:    arn,
+		kmsKey: kmsKey,
+	}, nil
+}
+
+func awsKMSKeyFromConfig(key config.AccountKey) (Key, error) {
+	if key.KMS.Provider != config.KMSProviderAWS {
+		return nil, fmt.Errorf("unsupported kms provider: %s", key.KMS.Provider)
+	}
+
+	kmsKey, err := awsKMSKeyFromARN(key.KMS.ARN)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AWSKMSKey{
+		baseKey: baseKeyFromConfig(key),
+		arn:     key.KMS.ARN,
+		kmsKey:  kmsKey,
+	}, nil
+}
+
+// awsKMSKeyFromARN parses the ARN of a key in the format "arn:aws:kms:<region>:<account>:key/<key ID>".
+func awsKMSKeyFromARN(arn string) (awskms.Key, error) {
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "kms" || !strings.HasPrefix(parts[5], "key/") {
+		return awskms.Key{}, fmt.Errorf("invalid AWS KMS key ARN %s, expected format arn:aws:kms:<region>:<account>:key/<key ID>", arn)
+	}
+
+	return awskms.KeyFromResourceARN(arn)
+}
+
+// ToConfig convert account key to configuration.
+func (a *AWSKMSKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:     a.keyType,
+		Index:    a.index,
+		SigAlgo:  a.sigAlgo,
+		HashAlgo: a.hashAlgo,
+		KMS: config.KMSKey{
+			Provider: config.KMSProviderAWS,
+			ARN:      a.arn,
+		},
+	}
+}
+
+func (a *AWSKMSKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if a.kmsKey.Region != "" {
+		cfg.Region = a.kmsKey.Region
+	}
+
+	signer, err := awskms.NewClient(cfg).SignerForKey(ctx, a.kmsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+func (a *AWSKMSKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible")
+}
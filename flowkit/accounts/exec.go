@@ -0,0 +1,216 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// ExecSignerRequestVersion is the version of the external signer protocol.
+const ExecSignerRequestVersion = 1
+
+// ExecSignerRequest is written as JSON to the standard input of the external signer command.
+//
+// For the "sign" type the signer must hash the hex encoded message with the hash algorithm
+// and sign it with the signature algorithm, for the "publicKey" type it returns the public key.
+type ExecSignerRequest struct {
+	Version  int    `json:"version"`
+	Type     string `json:"type"`
+	Address  string `json:"address"`
+	KeyIndex int    `json:"keyIndex"`
+	SigAlgo  string `json:"signatureAlgorithm"`
+	HashAlgo string `json:"hashAlgorithm"`
+	Message  string `json:"message,omitempty"`
+}
+
+// ExecSignerResponse is read as JSON from the standard output of the external signer command.
+type ExecSignerResponse struct {
+	Signature string `json:"signature,omitempty"`
+	PublicKey string `json:"publicKey,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ExecKey delegates signing to an external command, like an HSM or custody service client.
+//
+// The command is run for each request, it receives an ExecSignerRequest on the standard input
+// and must write an ExecSignerResponse to the standard output. The command is the program
+// followed by its arguments, it isn't run by a shell.
+type ExecKey struct {
+	*baseKey
+	command []string
+	address flow.Address
+}
+
+func execKeyFromConfig(accountKey config.AccountKey) (*ExecKey, error) {
+	return &ExecKey{
+		baseKey: baseKeyFromConfig(accountKey),
+		command: accountKey.Exec,
+	}, nil
+}
+
+// NewExecKey creates a new account key that signs using the external signer command.
+func NewExecKey(
+	command []string,
+	address flow.Address,
+	index int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) *ExecKey {
+	return &ExecKey{
+		baseKey: &baseKey{
+			keyType:  config.KeyTypeExec,
+			index:    index,
+			sigAlgo:  sigAlgo,
+			hashAlgo: hashAlgo,
+		},
+		command: command,
+		address: address,
+	}
+}
+
+func (e *ExecKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	return &execSigner{ctx: ctx, key: e}, nil
+}
+
+func (e *ExecKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key is not available for the exec key type, signing is done by %s", e.program())
+}
+
+func (e *ExecKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:     config.KeyTypeExec,
+		Index:    e.index,
+		SigAlgo:  e.sigAlgo,
+		HashAlgo: e.hashAlgo,
+		Exec:     e.command,
+	}
+}
+
+func (e *ExecKey) Validate() error {
+	if e.program() == "" {
+		return fmt.Errorf("missing external signer command")
+	}
+
+	_, err := exec.LookPath(e.program())
+	if err != nil {
+		return fmt.Errorf("external signer command %s not found: %w", e.program(), err)
+	}
+
+	return nil
+}
+
+// program returns the external signer program, the first element of the command.
+func (e *ExecKey) program() string {
+	if len(e.command) == 0 {
+		return ""
+	}
+	return e.command[0]
+}
+
+// request runs the external signer command with the request and returns its response.
+func (e *ExecKey) request(ctx context.Context, request ExecSignerRequest) (*ExecSignerResponse, error) {
+	err := e.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	request.Version = ExecSignerRequestVersion
+	request.Address = fmt.Sprintf("0x%s", e.address.Hex())
+	request.KeyIndex = e.Index()
+	request.SigAlgo = e.SigAlgo().String()
+	request.HashAlgo = e.HashAlgo().String()
+
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, e.command[0], e.command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("external signer %s failed: %w %s", e.program(), err, strings.TrimSpace(stderr.String()))
+	}
+
+	var response ExecSignerResponse
+	err = json.Unmarshal(stdout.Bytes(), &response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid external signer response: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("external signer %s failed: %s", e.program(), response.Error)
+	}
+
+	return &response, nil
+}
+
+var _ crypto.Signer = &execSigner{}
+
+type execSigner struct {
+	ctx context.Context
+	key *ExecKey
+}
+
+func (s *execSigner) Sign(message []byte) ([]byte, error) {
+	response, err := s.key.request(s.ctx, ExecSignerRequest{
+		Type:    "sign",
+		Message: hex.EncodeToString(message),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(response.Signature, "0x"))
+	if err != nil || len(signature) == 0 {
+		return nil, fmt.Errorf("invalid signature returned by external signer")
+	}
+
+	return signature, nil
+}
+
+// PublicKey asks the external signer for the public key, it returns nil if that fails
+// since the signer interface doesn't allow returning errors.
+func (s *execSigner) PublicKey() crypto.PublicKey {
+	response, err := s.key.request(s.ctx, ExecSignerRequest{Type: "publicKey"})
+	if err != nil {
+		return nil
+	}
+
+	key, err := crypto.DecodePublicKeyHex(s.key.SigAlgo(), strings.TrimPrefix(response.PublicKey, "0x"))
+	if err != nil {
+		return nil
+	}
+
+	return key
+}
@@ -0,0 +1,139 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/zalando/go-keyring"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Key = &KeychainKey{}
+
+// KeychainService is the service name of the private keys stored in the operating system keychain.
+const KeychainService = "flow-cli"
+
+// StoreKeychainKey stores the private key in hex in the operating system keychain under the item name,
+// replacing the private key stored under the same name.
+func StoreKeychainKey(name string, privateKey crypto.PrivateKey) error {
+	err := keyring.Set(KeychainService, name, strings.TrimPrefix(privateKey.String(), "0x"))
+	if err != nil {
+		return fmt.Errorf("could not store the key %s in the keychain: %w", name, err)
+	}
+
+	return nil
+}
+
+// KeychainKeyExists checks whether a private key is stored in the operating system keychain under the item name,
+// so callers can avoid silently replacing it with StoreKeychainKey.
+func KeychainKeyExists(name string) (bool, error) {
+	_, err := keyring.Get(KeychainService, name)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not read the key %s from the keychain: %w", name, err)
+	}
+
+	return true, nil
+}
+
+// KeychainKey is a private key stored in the operating system keychain and lazy-loaded when used.
+//
+// The keychain is the macOS Keychain, the Windows Credential Manager or the Secret Service on Linux,
+// the configuration only includes the name of the keychain item.
+type KeychainKey struct {
+	*baseKey
+	privateKey crypto.PrivateKey
+	name       string
+}
+
+// NewKeychainKey creates a new account key stored in the operating system keychain under the item name.
+func NewKeychainKey(
+	name string,
+	index int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) *KeychainKey {
+	return &KeychainKey{
+		baseKey: &baseKey{
+			keyType:  config.KeyTypeKeychain,
+			index:    index,
+			sigAlgo:  sigAlgo,
+			hashAlgo: hashAlgo,
+		},
+		name: name,
+	}
+}
+
+func keychainKeyFromConfig(key config.AccountKey) (*KeychainKey, error) {
+	return &KeychainKey{
+		baseKey: baseKeyFromConfig(key),
+		name:    key.Keychain,
+	}, nil
+}
+
+// Name returns the name of the keychain item.
+func (k *KeychainKey) Name() string {
+	return k.name
+}
+
+func (k *KeychainKey) Signer(_ context.Context) (crypto.Signer, error) {
+	key, err := k.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewInMemorySigner(*key, k.HashAlgo())
+}
+
+func (k *KeychainKey) PrivateKey() (*crypto.PrivateKey, error) {
+	if k.privateKey == nil { // lazy load the key
+		secret, err := keyring.Get(KeychainService, k.name)
+		if errors.Is(err, keyring.ErrNotFound) {
+			return nil, fmt.Errorf("the key %s is not stored in the keychain", k.name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not load the key %s from the keychain: %w", k.name, err)
+		}
+
+		pkey, err := crypto.DecodePrivateKeyHex(k.sigAlgo, secret)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode the key %s from the keychain: %w", k.name, err)
+		}
+		k.privateKey = pkey
+	}
+	return &k.privateKey, nil
+}
+
+func (k *KeychainKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:     config.KeyTypeKeychain,
+		Index:    k.index,
+		SigAlgo:  k.sigAlgo,
+		HashAlgo: k.hashAlgo,
+		Keychain: k.name,
+	}
+}
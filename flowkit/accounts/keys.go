@@ -24,7 +24,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 
 	goeth "github.com/ethereum/go-ethereum/accounts"
@@ -71,6 +73,8 @@ var _ Key = &KMSKey{}
 
 var _ Key = &BIP44Key{}
 
+var _ Key = &ExecKey{}
+
 func keyFromConfig(accountKeyConf config.AccountKey) (Key, error) {
 	switch accountKeyConf.Type {
 	case config.KeyTypeHex:
@@ -81,6 +85,18 @@ func keyFromConfig(accountKeyConf config.AccountKey) (Key, error) {
 		return kmsKeyFromConfig(accountKeyConf)
 	case config.KeyTypeFile:
 		return fileKeyFromConfig(accountKeyConf)
+	case config.KeyTypeExec:
+		return execKeyFromConfig(accountKeyConf)
+	case config.KeyTypeKMS:
+		return awsKMSKeyFromConfig(accountKeyConf)
+	case config.KeyTypeVault:
+		return vaultKeyFromConfig(accountKeyConf)
+	case config.KeyTypeDevice:
+		return ledgerKeyFromConfig(accountKeyConf)
+	case config.KeyTypeKeystore:
+		return keystoreKeyFromConfig(accountKeyConf)
+	case config.KeyTypeKeychain:
+		return keychainKeyFromConfig(accountKeyConf)
 	}
 
 	return nil, fmt.Errorf(`invalid key type: "%s"`, accountKeyConf.Type)
@@ -173,11 +189,16 @@ func (a *KMSKey) PrivateKey() (*crypto.PrivateKey, error) {
 // gcloudApplicationSignin signs in as an application user using gcloud command line tool
 // currently assumes gcloud is already installed on the machine
 // will by default pop a browser window to sign in
+//
+// Signing in is skipped if a service account is provided or the application default credentials already exist.
 func gcloudApplicationSignin(resourceID string) error {
 	googleAppCreds := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
 	if len(googleAppCreds) > 0 {
 		return nil
 	}
+	if _, err := os.Stat(applicationDefaultCredentialsPath()); err == nil {
+		return nil
+	}
 
 	kms, err := cloudkms.KeyFromResourceID(resourceID)
 	if err != nil {
@@ -191,6 +212,12 @@ func gcloudApplicationSignin(resourceID string) error {
 		)
 	}
 
+	if _, err := exec.LookPath("gcloud"); err != nil {
+		return fmt.Errorf(
+			"no google application credentials found for KMS key, set GOOGLE_APPLICATION_CREDENTIALS to a service account JSON or install gcloud to sign in",
+		)
+	}
+
 	loginCmd := exec.Command("gcloud", "auth", "application-default", "login", fmt.Sprintf("--project=%s", proj))
 
 	output, err := loginCmd.CombinedOutput()
@@ -200,6 +227,10 @@ func gcloudApplicationSignin(resourceID string) error {
 
 	squareBracketRegex := regexp.MustCompile(`(?s)\[(.*)\]`)
 	regexResult := squareBracketRegex.FindAllStringSubmatch(string(output), -1)
+	if len(regexResult) == 0 {
+		// credentials were saved to the well known location used by the KMS client
+		return nil
+	}
 	// Should only be one value. Second index since first index contains the square brackets
 	googleApplicationCreds := regexResult[0][1]
 
@@ -208,6 +239,19 @@ func gcloudApplicationSignin(resourceID string) error {
 	return nil
 }
 
+// applicationDefaultCredentialsPath returns the location gcloud saves the application default credentials to.
+func applicationDefaultCredentialsPath() string {
+	if dir := os.Getenv("CLOUDSDK_CONFIG"); dir != "" {
+		return filepath.Join(dir, "application_default_credentials.json")
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "gcloud", "application_default_credentials.json")
+	}
+
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gcloud", "application_default_credentials.json")
+}
+
 func kmsKeyFromConfig(key config.AccountKey) (Key, error) {
 	accountKMSKey, err := cloudkms.KeyFromResourceID(key.ResourceID)
 	if err != nil {
@@ -225,6 +269,31 @@ func kmsKeyFromConfig(key config.AccountKey) (Key, error) {
 	}, nil
 }
 
+// NewKMSKey creates a new account key that signs with the Google Cloud KMS key of the provided resource ID.
+//
+// The resource ID has the format "projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>".
+func NewKMSKey(
+	resourceID string,
+	index int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*KMSKey, error) {
+	kmsKey, err := cloudkms.KeyFromResourceID(resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KMS key resource ID: %w", err)
+	}
+
+	return &KMSKey{
+		baseKey: &baseKey{
+			keyType:  config.KeyTypeGoogleKMS,
+			index:    index,
+			sigAlgo:  sigAlgo,
+			hashAlgo: hashAlgo,
+		},
+		kmsKey: kmsKey,
+	}, nil
+}
+
 // HexKey implements account key in hex representation.
 type HexKey struct {
 	*baseKey
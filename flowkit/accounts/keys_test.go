@@ -19,10 +19,29 @@
 package accounts
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
 
 	"github.com/onflow/flow-cli/flowkit/config"
 )
@@ -42,6 +61,268 @@ func Test_KMS_Keys(t *testing.T) {
 	_, err = kmsKey.PrivateKey()
 	assert.EqualError(t, err, "private key not accessible")
 	assert.Equal(t, confKey, kmsKey.ToConfig())
+
+	newKey, err := NewKMSKey(confKey.ResourceID, 0, config.DefaultSigAlgo, config.DefaultHashAlgo)
+	require.NoError(t, err)
+	assert.Equal(t, confKey, newKey.ToConfig())
+
+	_, err = NewKMSKey("projects/my-project/keyRings/flow", 0, config.DefaultSigAlgo, config.DefaultHashAlgo)
+	assert.ErrorContains(t, err, "invalid KMS key resource ID")
+}
+
+func Test_AWS_KMS_Keys(t *testing.T) {
+	confKey := config.AccountKey{
+		Type:     config.KeyTypeKMS,
+		Index:    1,
+		SigAlgo:  config.DefaultSigAlgo,
+		HashAlgo: crypto.SHA2_256,
+		KMS: config.KMSKey{
+			Provider: config.KMSProviderAWS,
+			ARN:      "arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+	}
+
+	key, err := keyFromConfig(confKey)
+	require.NoError(t, err)
+	assert.Equal(t, confKey, key.ToConfig())
+	assert.Equal(t, "us-west-2", key.(*AWSKMSKey).kmsKey.Region)
+
+	_, err = key.PrivateKey()
+	assert.EqualError(t, err, "private key not accessible")
+
+	newKey, err := NewAWSKMSKey(confKey.KMS.ARN, 1, config.DefaultSigAlgo, crypto.SHA2_256)
+	require.NoError(t, err)
+	assert.Equal(t, confKey, newKey.ToConfig())
+
+	_, err = NewAWSKMSKey("arn:aws:kms:us-west-2:111122223333:alias/flow", 0, config.DefaultSigAlgo, crypto.SHA2_256)
+	assert.EqualError(t, err, "invalid AWS KMS key ARN arn:aws:kms:us-west-2:111122223333:alias/flow, expected format arn:aws:kms:<region>:<account>:key/<key ID>")
+}
+
+func Test_KMS_ApplicationDefaultCredentials(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	t.Setenv("CLOUDSDK_CONFIG", dir)
+	assert.Equal(t, filepath.Join(dir, "application_default_credentials.json"), applicationDefaultCredentialsPath())
+
+	require.NoError(t, os.WriteFile(applicationDefaultCredentialsPath(), []byte("{}"), 0600))
+	assert.NoError(t, gcloudApplicationSignin("projects/my-project/locations/global/keyRings/flow/cryptoKeys/my-account/cryptoKeyVersions/1"))
+}
+
+func Test_Vault_Key(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+	publicPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/approle/login" {
+			var login map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&login))
+			if login["role_id"] != "role" || login["secret_id"] != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"approle-token"}}`))
+			return
+		}
+
+		if token := r.Header.Get("X-Vault-Token"); token != "root" && token != "approle-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/transit/keys/flow-admin":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+				"type":           "ecdsa-p256",
+				"latest_version": 2,
+				"keys":           map[string]any{"2": map[string]any{"public_key": publicPEM}},
+			}})
+		case "/v1/transit/sign/flow-admin":
+			var sign struct {
+				Input     string `json:"input"`
+				Prehashed bool   `json:"prehashed"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sign))
+			assert.True(t, sign.Prehashed)
+			digest, err := base64.StdEncoding.DecodeString(sign.Input)
+			require.NoError(t, err)
+			signature, err := ecdsa.SignASN1(rand.Reader, private, digest)
+			require.NoError(t, err)
+			_, _ = w.Write([]byte(`{"data":{"signature":"vault:v2:` + base64.StdEncoding.EncodeToString(signature) + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	verify := func(t *testing.T, signer crypto.Signer) {
+		message := []byte("transaction payload")
+		signature, err := signer.Sign(message)
+		require.NoError(t, err)
+		require.Len(t, signature, 64)
+
+		digest := crypto.NewSHA3_256().ComputeHash(message)
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		assert.True(t, ecdsa.Verify(&private.PublicKey, digest, r, s))
+		assert.Equal(t, elliptic.Marshal(elliptic.P256(), private.X, private.Y)[1:], signer.PublicKey().Encode())
+	}
+
+	t.Run("Token", func(t *testing.T) {
+		t.Setenv("VAULT_ADDR", server.URL)
+		t.Setenv("VAULT_TOKEN", "root")
+
+		confKey := config.AccountKey{
+			Type:     config.KeyTypeVault,
+			SigAlgo:  crypto.ECDSA_P256,
+			HashAlgo: crypto.SHA3_256,
+			Vault:    config.VaultKey{Key: "flow-admin"},
+		}
+		key, err := keyFromConfig(confKey)
+		require.NoError(t, err)
+		assert.Equal(t, confKey, key.ToConfig())
+		require.NoError(t, key.Validate())
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+		verify(t, signer)
+	})
+
+	t.Run("AppRole", func(t *testing.T) {
+		t.Setenv("VAULT_SECRET_ID", "secret")
+
+		key, err := NewVaultKey(config.VaultKey{
+			Address:  server.URL,
+			Key:      "flow-admin",
+			RoleID:   "role",
+			SecretID: "$VAULT_SECRET_ID",
+		}, 0, crypto.SHA3_256)
+		require.NoError(t, err)
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+		verify(t, signer)
+	})
+
+	t.Run("Fail permission denied", func(t *testing.T) {
+		key, err := NewVaultKey(config.VaultKey{Address: server.URL, Key: "flow-admin", Token: "invalid"}, 0, crypto.SHA3_256)
+		require.NoError(t, err)
+
+		_, err = key.Signer(context.Background())
+		assert.EqualError(t, err, "failed to read transit key flow-admin: vault responded with status 403: permission denied")
+	})
+
+	t.Run("Fail missing secret ID", func(t *testing.T) {
+		_, err := NewVaultKey(config.VaultKey{Key: "flow-admin", RoleID: "role"}, 0, crypto.SHA3_256)
+		assert.EqualError(t, err, "AppRole authentication requires both the role ID and the secret ID")
+	})
+}
+
+type fakeLedger struct {
+	private  *ecdsa.PrivateKey
+	commands [][]byte
+	payload  []byte
+}
+
+func (f *fakeLedger) Exchange(command []byte) ([]byte, error) {
+	f.commands = append(f.commands, command)
+	if command[0] != ledgerCLA || int(command[4]) != len(command)-5 {
+		return nil, fmt.Errorf("[APDU_CODE_CLA_NOT_SUPPORTED] Class not supported")
+	}
+
+	data := command[5:]
+	switch {
+	case command[1] == ledgerInsPublicKey:
+		return append(elliptic.Marshal(elliptic.P256(), f.private.X, f.private.Y), []byte("address")...), nil
+	case command[1] == ledgerInsSign && command[2] == ledgerSignInit:
+		f.payload = nil
+		return nil, nil
+	case command[1] == ledgerInsSign && command[2] == ledgerSignAdd:
+		f.payload = append(f.payload, data...)
+		return nil, nil
+	case command[1] == ledgerInsSign && command[2] == ledgerSignLast:
+		f.payload = append(f.payload, data...)
+		digest := crypto.NewSHA3_256().ComputeHash(append(flow.TransactionDomainTag[:], f.payload...))
+		r, s, err := ecdsa.Sign(rand.Reader, f.private, digest)
+		if err != nil {
+			return nil, err
+		}
+		signature := make([]byte, 65)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:64])
+		return signature, nil
+	}
+
+	return nil, fmt.Errorf("[APDU_CODE_INS_NOT_SUPPORTED] Instruction not supported")
+}
+
+func (f *fakeLedger) Close() error {
+	return nil
+}
+
+func Test_Ledger_Key(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	device := &fakeLedger{private: private}
+
+	open := openLedger
+	openLedger = func() (ledgerDevice, error) { return device, nil }
+	defer func() { openLedger = open }()
+
+	confKey := config.AccountKey{
+		Type:           config.KeyTypeDevice,
+		SigAlgo:        crypto.ECDSA_P256,
+		HashAlgo:       crypto.SHA3_256,
+		Device:         config.DeviceLedger,
+		DerivationPath: "m/44'/539'/0'/0/1",
+	}
+	key, err := keyFromConfig(confKey)
+	require.NoError(t, err)
+	assert.Equal(t, confKey, key.ToConfig())
+	require.NoError(t, key.Validate())
+
+	signer, err := key.Signer(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, elliptic.Marshal(elliptic.P256(), private.X, private.Y)[1:], signer.PublicKey().Encode())
+	assert.Equal(t, []byte{
+		0x33, 0x01, 0x00, 0x00, 22,
+		44, 0, 0, 0x80, 0x1b, 0x02, 0, 0x80, 0, 0, 0, 0x80, 0, 0, 0, 0, 1, 0, 0, 0,
+		0x03, 0x02,
+	}, device.commands[0])
+
+	t.Run("Sign transaction", func(t *testing.T) {
+		device.commands = nil
+		payload := bytes.Repeat([]byte{0xab}, 600)
+		signature, err := signer.Sign(append(flow.TransactionDomainTag[:], payload...))
+		require.NoError(t, err)
+		require.Len(t, signature, 64)
+
+		assert.Len(t, device.commands, 4)
+		assert.Equal(t, payload, device.payload)
+
+		digest := crypto.NewSHA3_256().ComputeHash(append(flow.TransactionDomainTag[:], payload...))
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		assert.True(t, ecdsa.Verify(&private.PublicKey, digest, r, s))
+	})
+
+	t.Run("Fail not a transaction", func(t *testing.T) {
+		_, err := signer.Sign([]byte("message"))
+		assert.EqualError(t, err, "the Ledger can only sign transactions")
+	})
+
+	t.Run("Fail invalid derivation path", func(t *testing.T) {
+		_, err := NewLedgerKey("m/44'/539'", 0, crypto.ECDSA_P256, crypto.SHA3_256)
+		assert.EqualError(t, err, "invalid derivation path m/44'/539', expected a path like m/44'/539'/0'/0/0")
+	})
+
+	t.Run("Fail unsupported hash algorithm", func(t *testing.T) {
+		key, err := NewLedgerKey(config.DefaultDerivationPath, 0, crypto.ECDSA_P256, crypto.SHA3_384)
+		require.NoError(t, err)
+		assert.EqualError(t, key.Validate(), "the Ledger doesn't support the SHA3_384 hash algorithm")
+	})
 }
 
 func Test_File_key(t *testing.T) {
@@ -63,6 +344,139 @@ func Test_File_key(t *testing.T) {
 	assert.Equal(t, confKey, key.ToConfig())
 }
 
+func Test_Keystore_Key(t *testing.T) {
+	scryptN := keystoreScryptN
+	keystoreScryptN = 1 << 10
+	defer func() { keystoreScryptN = scryptN }()
+
+	pkey, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+	require.NoError(t, err)
+
+	data, err := EncryptKeystore(pkey, "secret")
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), hex.EncodeToString(pkey.Encode()))
+
+	dir := t.TempDir()
+	location := filepath.Join(dir, "alice.keystore.json")
+	require.NoError(t, os.WriteFile(location, data, 0600))
+	passwordFile := filepath.Join(dir, "alice.password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("secret\n"), 0600))
+
+	t.Run("Success password file", func(t *testing.T) {
+		key := NewKeystoreKey(location, passwordFile, 1, crypto.ECDSA_P256, crypto.SHA3_256)
+		require.NoError(t, key.Validate())
+
+		privateKey, err := key.PrivateKey()
+		require.NoError(t, err)
+		assert.Equal(t, pkey.String(), (*privateKey).String())
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, pkey.PublicKey().String(), signer.PublicKey().String())
+
+		fromConfig, err := keyFromConfig(key.ToConfig())
+		require.NoError(t, err)
+		assert.Equal(t, config.AccountKey{
+			Type:         config.KeyTypeKeystore,
+			Index:        1,
+			SigAlgo:      crypto.ECDSA_P256,
+			HashAlgo:     crypto.SHA3_256,
+			Location:     location,
+			PasswordFile: passwordFile,
+		}, fromConfig.ToConfig())
+	})
+
+	t.Run("Success environment password", func(t *testing.T) {
+		t.Setenv(KeystorePasswordEnv, "secret")
+		key := NewKeystoreKey(location, "", 0, crypto.ECDSA_P256, crypto.SHA3_256)
+
+		privateKey, err := key.PrivateKey()
+		require.NoError(t, err)
+		assert.Equal(t, pkey.String(), (*privateKey).String())
+	})
+
+	t.Run("Success set password", func(t *testing.T) {
+		key := NewKeystoreKey(location, "", 0, crypto.ECDSA_P256, crypto.SHA3_256)
+		key.SetPassword("secret")
+
+		_, err := key.PrivateKey()
+		require.NoError(t, err)
+		assert.Empty(t, key.ToConfig().PasswordFile)
+	})
+
+	t.Run("Fail wrong password", func(t *testing.T) {
+		key := NewKeystoreKey(location, "", 0, crypto.ECDSA_P256, crypto.SHA3_256)
+		key.SetPassword("wrong")
+
+		_, err := key.PrivateKey()
+		assert.EqualError(t, err, fmt.Sprintf(
+			"could not decrypt the keystore %s: could not decrypt the keystore, the passphrase is wrong or the keystore is corrupted",
+			location,
+		))
+	})
+
+	t.Run("Fail missing password", func(t *testing.T) {
+		key := NewKeystoreKey(location, "", 0, crypto.ECDSA_P256, crypto.SHA3_256)
+
+		_, err := key.PrivateKey()
+		assert.EqualError(t, err, fmt.Sprintf(
+			"missing the passphrase of the keystore %s, configure a password file or set FLOW_KEYSTORE_PASSWORD",
+			location,
+		))
+	})
+
+	t.Run("Fail empty password", func(t *testing.T) {
+		_, err := EncryptKeystore(pkey, "")
+		assert.EqualError(t, err, "the keystore passphrase can't be empty")
+	})
+}
+
+func Test_Keychain_Key(t *testing.T) {
+	keyring.MockInit()
+
+	pkey, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+	require.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		require.NoError(t, StoreKeychainKey("alice", pkey))
+
+		key := NewKeychainKey("alice", 1, crypto.ECDSA_P256, crypto.SHA3_256)
+		privateKey, err := key.PrivateKey()
+		require.NoError(t, err)
+		assert.Equal(t, pkey.String(), (*privateKey).String())
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, pkey.PublicKey().String(), signer.PublicKey().String())
+
+		fromConfig, err := keyFromConfig(key.ToConfig())
+		require.NoError(t, err)
+		assert.Equal(t, config.AccountKey{
+			Type:     config.KeyTypeKeychain,
+			Index:    1,
+			SigAlgo:  crypto.ECDSA_P256,
+			HashAlgo: crypto.SHA3_256,
+			Keychain: "alice",
+		}, fromConfig.ToConfig())
+	})
+
+	t.Run("Success exists", func(t *testing.T) {
+		exists, err := KeychainKeyExists("carol")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		require.NoError(t, StoreKeychainKey("carol", pkey))
+		exists, err = KeychainKeyExists("carol")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Fail missing key", func(t *testing.T) {
+		_, err := NewKeychainKey("bob", 0, crypto.ECDSA_P256, crypto.SHA3_256).PrivateKey()
+		assert.EqualError(t, err, "the key bob is not stored in the keychain")
+	})
+}
+
 func Test_BIP44(t *testing.T) {
 	confKey := config.AccountKey{
 		Type:           config.KeyTypeBip44,
@@ -86,3 +500,52 @@ func Test_BIP44(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, pubKey, sig.PublicKey().String())
 }
+
+// Test_ExecSignerHelper is run as the external signer command by Test_Exec_Key.
+func Test_ExecSignerHelper(t *testing.T) {
+	if os.Getenv("FLOW_EXEC_SIGNER_HELPER") != "1" {
+		t.Skip("only run as the external signer")
+	}
+
+	var request ExecSignerRequest
+	_ = json.NewDecoder(os.Stdin).Decode(&request)
+
+	response := ExecSignerResponse{Error: "unsupported request"}
+	if request.Type == "sign" {
+		response = ExecSignerResponse{Signature: fmt.Sprintf("%s%s%02x", request.Message, request.Address[2:], request.KeyIndex)}
+	}
+	_ = json.NewEncoder(os.Stdout).Encode(response)
+	os.Exit(0)
+}
+
+func Test_Exec_Key(t *testing.T) {
+	t.Setenv("FLOW_EXEC_SIGNER_HELPER", "1")
+	command := []string{os.Args[0], "-test.run=^Test_ExecSignerHelper$"}
+
+	confKey := config.AccountKey{
+		Type:     config.KeyTypeExec,
+		Index:    1,
+		SigAlgo:  config.DefaultSigAlgo,
+		HashAlgo: config.DefaultHashAlgo,
+		Exec:     command,
+	}
+
+	account, err := fromConfig(config.Account{Name: "custody", Address: flow.HexToAddress("0x01"), Key: confKey})
+	require.NoError(t, err)
+	assert.Equal(t, confKey, account.Key.ToConfig())
+
+	_, err = account.Key.PrivateKey()
+	assert.Error(t, err)
+
+	signer, err := account.Key.Signer(context.Background())
+	require.NoError(t, err)
+
+	signature, err := signer.Sign([]byte{0xab, 0xcd})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xab, 0xcd, 0, 0, 0, 0, 0, 0, 0, 1, 1}, signature)
+
+	assert.Nil(t, signer.PublicKey()) // helper returns an error for public key requests
+
+	missing := NewExecKey([]string{"flow-missing-signer"}, flow.HexToAddress("0x01"), 0, config.DefaultSigAlgo, config.DefaultHashAlgo)
+	assert.ErrorContains(t, missing.Validate(), "external signer command flow-missing-signer not found")
+}
@@ -0,0 +1,318 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Key = &KeystoreKey{}
+
+// KeystorePasswordEnv is the environment variable with the passphrase of keystore keys without a password file.
+const KeystorePasswordEnv = "FLOW_KEYSTORE_PASSWORD"
+
+const (
+	keystoreVersion = 1
+	keystoreCipher  = "aes-256-gcm"
+	keystoreKDF     = "scrypt"
+	keystoreKeyLen  = 32
+	keystoreScryptR = 8
+	keystoreScryptP = 1
+)
+
+// keystoreScryptN is the scrypt cost of new keystores, decryption uses the cost stored in the keystore.
+var keystoreScryptN = 1 << 17
+
+// keystore is the JSON format of a private key encrypted with a passphrase.
+type keystore struct {
+	Version   int            `json:"version"`
+	SigAlgo   string         `json:"signatureAlgorithm"`
+	PublicKey string         `json:"publicKey"`
+	Crypto    keystoreCrypto `json:"crypto"`
+}
+
+type keystoreCrypto struct {
+	Cipher     string            `json:"cipher"`
+	CipherText string            `json:"ciphertext"`
+	Nonce      string            `json:"nonce"`
+	KDF        string            `json:"kdf"`
+	KDFParams  keystoreKDFParams `json:"kdfparams"`
+}
+
+type keystoreKDFParams struct {
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	KeyLen int    `json:"dklen"`
+	Salt   string `json:"salt"`
+}
+
+// EncryptKeystore encrypts the private key with the passphrase to the keystore JSON format.
+//
+// The encryption key is derived from the passphrase with scrypt and the private key is encrypted with AES-256-GCM.
+func EncryptKeystore(privateKey crypto.PrivateKey, password string) ([]byte, error) {
+	if password == "" {
+		return nil, fmt.Errorf("the keystore passphrase can't be empty")
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	params := keystoreKDFParams{
+		N:      keystoreScryptN,
+		R:      keystoreScryptR,
+		P:      keystoreScryptP,
+		KeyLen: keystoreKeyLen,
+		Salt:   hex.EncodeToString(salt),
+	}
+	gcm, err := keystoreAEAD(password, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(keystore{
+		Version:   keystoreVersion,
+		SigAlgo:   privateKey.Algorithm().String(),
+		PublicKey: hex.EncodeToString(privateKey.PublicKey().Encode()),
+		Crypto: keystoreCrypto{
+			Cipher:     keystoreCipher,
+			CipherText: hex.EncodeToString(gcm.Seal(nil, nonce, privateKey.Encode(), nil)),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        keystoreKDF,
+			KDFParams:  params,
+		},
+	}, "", "\t")
+}
+
+// DecryptKeystore decrypts the private key of the keystore JSON with the passphrase.
+func DecryptKeystore(data []byte, password string) (crypto.PrivateKey, error) {
+	var ks keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("invalid keystore: %w", err)
+	}
+	if ks.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	if ks.Crypto.Cipher != keystoreCipher || ks.Crypto.KDF != keystoreKDF {
+		return nil, fmt.Errorf("unsupported keystore encryption %s with %s", ks.Crypto.Cipher, ks.Crypto.KDF)
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(ks.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid keystore signature algorithm %s", ks.SigAlgo)
+	}
+
+	nonce, err := hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore nonce: %w", err)
+	}
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore ciphertext: %w", err)
+	}
+
+	gcm, err := keystoreAEAD(password, ks.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid keystore nonce length %d", len(nonce))
+	}
+
+	encoded, err := gcm.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt the keystore, the passphrase is wrong or the keystore is corrupted")
+	}
+
+	privateKey, err := crypto.DecodePrivateKey(sigAlgo, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the keystore private key: %w", err)
+	}
+	if !strings.EqualFold(hex.EncodeToString(privateKey.PublicKey().Encode()), ks.PublicKey) {
+		return nil, fmt.Errorf("the keystore private key doesn't match its public key")
+	}
+
+	return privateKey, nil
+}
+
+func keystoreAEAD(password string, params keystoreKDFParams) (cipher.AEAD, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore salt: %w", err)
+	}
+	if params.KeyLen != keystoreKeyLen {
+		return nil, fmt.Errorf("invalid keystore key length %d", params.KeyLen)
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, params.KeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore scrypt parameters: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// KeystoreKey is a private key stored encrypted in a keystore file and lazy-loaded when used.
+//
+// The passphrase is read from the password file if configured, or from the KeystorePasswordEnv environment variable.
+type KeystoreKey struct {
+	*baseKey
+	privateKey   crypto.PrivateKey
+	location     string
+	passwordFile string
+	password     string
+}
+
+// NewKeystoreKey creates a new account key stored in the keystore file at the location.
+//
+// The password file is optional, the passphrase is read from the environment if not provided.
+func NewKeystoreKey(
+	location string,
+	passwordFile string,
+	index int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) *KeystoreKey {
+	return &KeystoreKey{
+		baseKey: &baseKey{
+			keyType:  config.KeyTypeKeystore,
+			index:    index,
+			sigAlgo:  sigAlgo,
+			hashAlgo: hashAlgo,
+		},
+		location:     location,
+		passwordFile: passwordFile,
+	}
+}
+
+func keystoreKeyFromConfig(key config.AccountKey) (*KeystoreKey, error) {
+	return &KeystoreKey{
+		baseKey:      baseKeyFromConfig(key),
+		location:     key.Location,
+		passwordFile: key.PasswordFile,
+	}, nil
+}
+
+// Location returns the path of the keystore file.
+func (k *KeystoreKey) Location() string {
+	return k.location
+}
+
+// PasswordFile returns the path of the file with the passphrase, empty if not configured.
+func (k *KeystoreKey) PasswordFile() string {
+	return k.passwordFile
+}
+
+// SetPassword sets the passphrase used instead of the environment if the key has no password file.
+//
+// The passphrase isn't saved to the configuration.
+func (k *KeystoreKey) SetPassword(password string) {
+	k.password = password
+}
+
+func (k *KeystoreKey) Signer(_ context.Context) (crypto.Signer, error) {
+	key, err := k.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewInMemorySigner(*key, k.HashAlgo())
+}
+
+func (k *KeystoreKey) PrivateKey() (*crypto.PrivateKey, error) {
+	if k.privateKey == nil { // lazy load the key
+		data, err := os.ReadFile(k.location)
+		if err != nil {
+			return nil, fmt.Errorf("could not load the keystore from provided location %s: %w", k.location, err)
+		}
+
+		password, err := k.readPassword()
+		if err != nil {
+			return nil, err
+		}
+
+		pkey, err := DecryptKeystore(data, password)
+		if err != nil {
+			return nil, fmt.Errorf("could not decrypt the keystore %s: %w", k.location, err)
+		}
+		if pkey.Algorithm() != k.sigAlgo {
+			return nil, fmt.Errorf("the keystore %s has a %s key, the account key uses %s", k.location, pkey.Algorithm(), k.sigAlgo)
+		}
+		k.privateKey = pkey
+	}
+	return &k.privateKey, nil
+}
+
+func (k *KeystoreKey) readPassword() (string, error) {
+	if k.passwordFile != "" {
+		password, err := os.ReadFile(k.passwordFile)
+		if err != nil {
+			return "", fmt.Errorf("could not read the keystore password file %s: %w", k.passwordFile, err)
+		}
+		return strings.TrimRight(string(password), "\r\n"), nil
+	}
+	if k.password != "" {
+		return k.password, nil
+	}
+	if password, ok := os.LookupEnv(KeystorePasswordEnv); ok {
+		return password, nil
+	}
+
+	return "", fmt.Errorf(
+		"missing the passphrase of the keystore %s, configure a password file or set %s",
+		k.location,
+		KeystorePasswordEnv,
+	)
+}
+
+func (k *KeystoreKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:         config.KeyTypeKeystore,
+		Index:        k.index,
+		SigAlgo:      k.sigAlgo,
+		HashAlgo:     k.hashAlgo,
+		Location:     k.location,
+		PasswordFile: k.passwordFile,
+	}
+}
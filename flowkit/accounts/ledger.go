@@ -0,0 +1,273 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	goeth "github.com/ethereum/go-ethereum/accounts"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	ledger "github.com/zondax/ledger-go"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Key = &LedgerKey{}
+
+// Flow Ledger app APDU protocol.
+const (
+	ledgerCLA          = 0x33
+	ledgerInsPublicKey = 0x01
+	ledgerInsSign      = 0x02
+
+	ledgerSignInit = 0x00 // first sign chunk containing the path and crypto options
+	ledgerSignAdd  = 0x01
+	ledgerSignLast = 0x02
+
+	ledgerChunkSize = 250
+)
+
+// ledgerDevice exchanges APDU commands with a connected Ledger device.
+type ledgerDevice interface {
+	Exchange(command []byte) ([]byte, error)
+	Close() error
+}
+
+// openLedger connects to the first Ledger device.
+var openLedger = func() (ledgerDevice, error) {
+	admin := ledger.NewLedgerAdmin()
+	if admin.CountDevices() == 0 {
+		return nil, fmt.Errorf("no Ledger device connected")
+	}
+
+	return admin.Connect(0)
+}
+
+// LedgerKey implements signing with the key at the derivation path of a Ledger device running the Flow app.
+//
+// The device is connected for each operation and the transaction is reviewed and approved on the device.
+type LedgerKey struct {
+	*baseKey
+	derivationPath string
+	path           goeth.DerivationPath
+}
+
+// NewLedgerKey creates a new account key that signs with the Ledger key at the derivation path.
+func NewLedgerKey(
+	derivationPath string,
+	index int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*LedgerKey, error) {
+	return ledgerKeyFromConfig(config.AccountKey{
+		Type:           config.KeyTypeDevice,
+		Index:          index,
+		SigAlgo:        sigAlgo,
+		HashAlgo:       hashAlgo,
+		Device:         config.DeviceLedger,
+		DerivationPath: derivationPath,
+	})
+}
+
+func ledgerKeyFromConfig(key config.AccountKey) (*LedgerKey, error) {
+	if key.Device != config.DeviceLedger {
+		return nil, fmt.Errorf("unsupported device: %s", key.Device)
+	}
+	if key.DerivationPath == "" {
+		key.DerivationPath = config.DefaultDerivationPath
+	}
+
+	path, err := goeth.ParseDerivationPath(key.DerivationPath)
+	if err != nil || len(path) != 5 {
+		return nil, fmt.Errorf("invalid derivation path %s, expected a path like %s", key.DerivationPath, config.DefaultDerivationPath)
+	}
+
+	return &LedgerKey{
+		baseKey:        baseKeyFromConfig(key),
+		derivationPath: key.DerivationPath,
+		path:           path,
+	}, nil
+}
+
+// ToConfig convert account key to configuration.
+func (l *LedgerKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:           l.keyType,
+		Index:          l.index,
+		SigAlgo:        l.sigAlgo,
+		HashAlgo:       l.hashAlgo,
+		Device:         config.DeviceLedger,
+		DerivationPath: l.derivationPath,
+	}
+}
+
+// DerivationPath returns the derivation path of the key on the device.
+func (l *LedgerKey) DerivationPath() string {
+	return l.derivationPath
+}
+
+func (l *LedgerKey) Validate() error {
+	if _, err := l.cryptoOptions(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PublicKey reads the public key at the derivation path from the device.
+func (l *LedgerKey) PublicKey() (crypto.PublicKey, error) {
+	options, err := l.cryptoOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := openLedger()
+	if err != nil {
+		return nil, err
+	}
+	defer device.Close()
+
+	response, err := device.Exchange(ledgerCommand(ledgerInsPublicKey, 0x00, append(l.serializedPath(), options...)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the public key from the Ledger, make sure the Flow app is open: %w", err)
+	}
+	if len(response) < 65 {
+		return nil, fmt.Errorf("invalid public key response from the Ledger")
+	}
+
+	// the response starts with the uncompressed public key
+	return crypto.DecodePublicKey(l.SigAlgo(), response[1:65])
+}
+
+func (l *LedgerKey) Signer(_ context.Context) (crypto.Signer, error) {
+	publicKey, err := l.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ledgerSigner{key: l, publicKey: publicKey}, nil
+}
+
+func (l *LedgerKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible")
+}
+
+// serializedPath returns the derivation path as little endian integers.
+func (l *LedgerKey) serializedPath() []byte {
+	serialized := make([]byte, 4*len(l.path))
+	for i, component := range l.path {
+		binary.LittleEndian.PutUint32(serialized[4*i:], component)
+	}
+
+	return serialized
+}
+
+// cryptoOptions returns the curve and hash algorithm of the key in the format of the Flow app.
+func (l *LedgerKey) cryptoOptions() ([]byte, error) {
+	curves := map[crypto.SignatureAlgorithm]byte{
+		crypto.ECDSA_P256:      0x02,
+		crypto.ECDSA_secp256k1: 0x03,
+	}
+	hashes := map[crypto.HashAlgorithm]byte{
+		crypto.SHA2_256: 0x01,
+		crypto.SHA3_256: 0x03,
+	}
+
+	curve, ok := curves[l.SigAlgo()]
+	if !ok {
+		return nil, fmt.Errorf("the Ledger doesn't support the %s signature algorithm", l.SigAlgo())
+	}
+	hash, ok := hashes[l.HashAlgo()]
+	if !ok {
+		return nil, fmt.Errorf("the Ledger doesn't support the %s hash algorithm", l.HashAlgo())
+	}
+
+	return []byte{hash, curve}, nil
+}
+
+// ledgerCommand returns the APDU command of the Flow app.
+func ledgerCommand(ins byte, p1 byte, data []byte) []byte {
+	return append([]byte{ledgerCLA, ins, p1, 0x00, byte(len(data))}, data...)
+}
+
+var _ crypto.Signer = &ledgerSigner{}
+
+type ledgerSigner struct {
+	key       *LedgerKey
+	publicKey crypto.PublicKey
+}
+
+// Sign sends the transaction to the device to be reviewed and signed.
+//
+// The device shows the transaction and adds the domain tag itself, so only transaction messages can be signed.
+func (s *ledgerSigner) Sign(message []byte) ([]byte, error) {
+	if !bytes.HasPrefix(message, flowsdk.TransactionDomainTag[:]) {
+		return nil, fmt.Errorf("the Ledger can only sign transactions")
+	}
+	payload := message[len(flowsdk.TransactionDomainTag):]
+
+	options, err := s.key.cryptoOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := openLedger()
+	if err != nil {
+		return nil, err
+	}
+	defer device.Close()
+
+	_, err = device.Exchange(ledgerCommand(ledgerInsSign, ledgerSignInit, append(s.key.serializedPath(), options...)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start signing on the Ledger, make sure the Flow app is open: %w", err)
+	}
+
+	var response []byte
+	for start := 0; start < len(payload) || start == 0; start += ledgerChunkSize {
+		end := start + ledgerChunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+
+		chunkType := byte(ledgerSignAdd)
+		if end == len(payload) {
+			chunkType = ledgerSignLast
+		}
+
+		response, err = device.Exchange(ledgerCommand(ledgerInsSign, chunkType, payload[start:end]))
+		if err != nil {
+			return nil, fmt.Errorf("the Ledger didn't sign the transaction: %w", err)
+		}
+	}
+
+	// the response starts with the r || s || v signature
+	if len(response) < 64 {
+		return nil, fmt.Errorf("invalid signature response from the Ledger")
+	}
+
+	return response[:64], nil
+}
+
+func (s *ledgerSigner) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
@@ -0,0 +1,277 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Key = &VaultKey{}
+
+// vaultClient sends the requests to Vault servers.
+var vaultClient = &http.Client{Timeout: 30 * time.Second}
+
+// VaultKey implements signing with a key of the HashiCorp Vault transit secrets engine.
+//
+// Only ECDSA P-256 transit keys are supported, the message is hashed with the key hash algorithm before it
+// is sent to Vault, so the private key never leaves the server.
+type VaultKey struct {
+	*baseKey
+	vault config.VaultKey
+}
+
+// NewVaultKey creates a new account key that signs with the Vault transit key.
+func NewVaultKey(
+	vault config.VaultKey,
+	index int,
+	hashAlgo crypto.HashAlgorithm,
+) (*VaultKey, error) {
+	err := vault.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &VaultKey{
+		baseKey: &baseKey{
+			keyType:  config.KeyTypeVault,
+			index:    index,
+			sigAlgo:  crypto.ECDSA_P256,
+			hashAlgo: hashAlgo,
+		},
+		vault: vault,
+	}, nil
+}
+
+func vaultKeyFromConfig(key config.AccountKey) (Key, error) {
+	err := key.Vault.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &VaultKey{
+		baseKey: baseKeyFromConfig(key),
+		vault:   key.Vault,
+	}, nil
+}
+
+// ToConfig convert account key to configuration.
+func (v *VaultKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:     v.keyType,
+		Index:    v.index,
+		SigAlgo:  v.sigAlgo,
+		HashAlgo: v.hashAlgo,
+		Vault:    v.vault,
+	}
+}
+
+func (v *VaultKey) Validate() error {
+	if v.SigAlgo() != crypto.ECDSA_P256 {
+		return fmt.Errorf("vault transit keys only support the ECDSA_P256 signature algorithm")
+	}
+
+	return nil
+}
+
+// Signer authenticates with Vault and fetches the public key of the transit key.
+func (v *VaultKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	vault, err := v.vault.Expanded()
+	if err != nil {
+		return nil, err
+	}
+	if vault.Address == "" {
+		return nil, fmt.Errorf("missing vault address, configure the address or set VAULT_ADDR")
+	}
+
+	signer := &vaultSigner{
+		ctx:      ctx,
+		vault:    vault,
+		hashAlgo: v.HashAlgo(),
+		token:    vault.Token,
+	}
+
+	if vault.RoleID != "" {
+		var login struct {
+			Auth struct {
+				ClientToken string `json:"client_token"`
+			} `json:"auth"`
+		}
+		err = signer.request(http.MethodPost, fmt.Sprintf("auth/%s/login", vault.AuthMount), map[string]string{
+			"role_id":   vault.RoleID,
+			"secret_id": vault.SecretID,
+		}, &login)
+		if err != nil {
+			return nil, fmt.Errorf("failed to log in with AppRole: %w", err)
+		}
+		signer.token = login.Auth.ClientToken
+	}
+
+	var key struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	err = signer.request(http.MethodGet, fmt.Sprintf("%s/keys/%s", vault.Mount, url.PathEscape(vault.Key)), nil, &key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transit key %s: %w", vault.Key, err)
+	}
+	if key.Data.Type != "ecdsa-p256" {
+		return nil, fmt.Errorf("transit key %s has the unsupported type %s, only ecdsa-p256 keys are supported", vault.Key, key.Data.Type)
+	}
+
+	latest, ok := key.Data.Keys[fmt.Sprint(key.Data.LatestVersion)]
+	if !ok {
+		return nil, fmt.Errorf("transit key %s is missing the public key of version %d", vault.Key, key.Data.LatestVersion)
+	}
+	signer.publicKey, err = crypto.DecodePublicKeyPEM(crypto.ECDSA_P256, latest.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key of transit key %s: %w", vault.Key, err)
+	}
+
+	return signer, nil
+}
+
+func (v *VaultKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible")
+}
+
+var _ crypto.Signer = &vaultSigner{}
+
+type vaultSigner struct {
+	ctx       context.Context
+	vault     config.VaultKey
+	hashAlgo  crypto.HashAlgorithm
+	token     string
+	publicKey crypto.PublicKey
+}
+
+// Sign hashes the message and signs the digest with the transit key, returning the signature in the
+// raw r || s format used by Flow.
+func (s *vaultSigner) Sign(message []byte) ([]byte, error) {
+	hasher, err := crypto.NewHasher(s.hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	err = s.request(http.MethodPost, fmt.Sprintf("%s/sign/%s", s.vault.Mount, url.PathEscape(s.vault.Key)), map[string]any{
+		"input":                base64.StdEncoding.EncodeToString(hasher.ComputeHash(message)),
+		"prehashed":            true,
+		"hash_algorithm":       "sha2-256", // only sets the expected digest size as the input is prehashed
+		"marshaling_algorithm": "asn1",
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with transit key %s: %w", s.vault.Key, err)
+	}
+
+	// signatures have the format vault:v<version>:<base64 signature>
+	parts := strings.Split(result.Data.Signature, ":")
+	der, err := base64.StdEncoding.DecodeString(parts[len(parts)-1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	var signature struct {
+		R, S *big.Int
+	}
+	_, err = asn1.Unmarshal(der, &signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	raw := make([]byte, 64)
+	signature.R.FillBytes(raw[:32])
+	signature.S.FillBytes(raw[32:])
+
+	return raw, nil
+}
+
+func (s *vaultSigner) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+// request sends the request to the Vault HTTP API and decodes the JSON response into the result.
+func (s *vaultSigner) request(method string, path string, body any, result any) error {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(
+		s.ctx,
+		method,
+		fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(s.vault.Address, "/"), path),
+		payload,
+	)
+	if err != nil {
+		return err
+	}
+	if s.token != "" {
+		req.Header.Set("X-Vault-Token", s.token)
+	}
+	if s.vault.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.vault.Namespace)
+	}
+
+	res, err := vaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		var failed struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(res.Body).Decode(&failed)
+		if len(failed.Errors) > 0 {
+			return fmt.Errorf("vault responded with status %d: %s", res.StatusCode, strings.Join(failed.Errors, ", "))
+		}
+		return fmt.Errorf("vault responded with status %d", res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
@@ -21,6 +21,8 @@ package arguments
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/onflow/cadence"
@@ -31,6 +33,7 @@ import (
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
+	"golang.org/x/exp/maps"
 )
 
 type cadenceArgument struct {
@@ -73,9 +76,159 @@ func ParseJSON(args string) ([]cadence.Value, error) {
 // Using the Cadence code required arguments are computed and then extracted from passed slice of arguments.
 // The fileName argument is optional and can be empty if not present.
 func ParseWithoutType(args []string, code []byte, fileName string) (scriptArgs []cadence.Value, err error) {
+	parameters, err := Parameters(code, fileName)
+	if err != nil {
+		return nil, err
+	}
 
 	resultArgs := make([]cadence.Value, 0, len(args))
+	if parameters == nil {
+		return resultArgs, nil
+	}
+
+	if len(parameters) != len(args) {
+		return nil, fmt.Errorf("argument count is %d, expected %d", len(args), len(parameters))
+	}
+
+	for index, argumentString := range args {
+		value, err := parameters[index].Parse(argumentString)
+		if err != nil {
+			return nil, err
+		}
 
+		resultArgs = append(resultArgs, value)
+	}
+	return resultArgs, nil
+}
+
+// namedArgument matches arguments in the "name=value" or "name:Type:value" format.
+var namedArgument = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)([=:])(.*)$`)
+
+// AreNamed returns true if all the arguments are provided in the "name=value" or "name:Type:value" format
+// and each name is one of the declared parameters, so positional values such as "Hello:World" are not
+// mistaken for named arguments.
+func AreNamed(args []string, parameters []Parameter) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	declared := make(map[string]bool, len(parameters))
+	for _, parameter := range parameters {
+		declared[parameter.Name] = true
+	}
+
+	for _, arg := range args {
+		match := namedArgument.FindStringSubmatch(arg)
+		if match == nil || !declared[match[1]] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseNamed parses arguments keyed by the parameter name instead of the position.
+//
+// Arguments are provided in the "name=value" format or in the "name:Type:value" format, in which case
+// the provided type must match the declared parameter type. All declared parameters must be provided.
+func ParseNamed(args []string, code []byte, fileName string) ([]cadence.Value, error) {
+	parameters, err := Parameters(code, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(args))
+	types := make(map[string]string, len(args))
+	for _, arg := range args {
+		match := namedArgument.FindStringSubmatch(arg)
+		if match == nil {
+			return nil, fmt.Errorf("argument `%s` is not in the name=value or name:Type:value format", arg)
+		}
+
+		name, value := match[1], match[3]
+		if _, exists := values[name]; exists {
+			return nil, fmt.Errorf("argument `%s` is provided more than once", name)
+		}
+
+		if match[2] == ":" {
+			argType, typedValue, found := strings.Cut(value, ":")
+			if !found {
+				return nil, fmt.Errorf("argument `%s` is not in the name:Type:value format", name)
+			}
+			types[name] = argType
+			value = typedValue
+		}
+		values[name] = value
+	}
+
+	resultArgs := make([]cadence.Value, 0, len(parameters))
+	for _, parameter := range parameters {
+		value, exists := values[parameter.Name]
+		if !exists {
+			return nil, fmt.Errorf("argument `%s` of type `%s` is missing", parameter.Name, parameter.Type)
+		}
+		delete(values, parameter.Name)
+
+		if argType, typed := types[parameter.Name]; typed && argType != parameter.Type {
+			return nil, fmt.Errorf(
+				"argument `%s` is declared as type `%s` but provided as `%s`",
+				parameter.Name,
+				parameter.Type,
+				argType,
+			)
+		}
+
+		cadenceValue, err := parameter.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		resultArgs = append(resultArgs, cadenceValue)
+	}
+
+	if len(values) > 0 {
+		unknown := maps.Keys(values)
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("argument `%s` is not a declared parameter", strings.Join(unknown, "`, `"))
+	}
+
+	return resultArgs, nil
+}
+
+// Parameter is a parameter declared by a transaction, a script or a contract initializer.
+type Parameter struct {
+	Name     string
+	Type     string
+	semaType sema.Type
+	inter    *interpreter.Interpreter
+}
+
+// Parse parses the argument string as the parameter type.
+//
+// String arguments don't need to be quoted and address arguments don't need the 0x prefix.
+func (p Parameter) Parse(argument string) (cadence.Value, error) {
+	if p.semaType == sema.StringType {
+		if !strings.HasPrefix(argument, "\"") {
+			argument = ast.QuoteString(argument)
+		}
+	} else if _, ok := p.semaType.(*sema.AddressType); ok {
+		if !strings.HasPrefix(argument, "0x") {
+			argument = fmt.Sprintf("0x%s", argument)
+		}
+	}
+
+	value, err := runtime.ParseLiteral(argument, p.semaType, p.inter)
+	if err != nil {
+		return nil, fmt.Errorf("argument `%s` is not expected type `%s`", p.Name, p.Type)
+	}
+
+	return value, nil
+}
+
+// Parameters returns the parameters declared in the Cadence code by the transaction, the script main function
+// or the contract initializer. The returned list is nil if the code doesn't declare a parameter list.
+//
+// The fileName argument is optional and can be empty if not present.
+func Parameters(code []byte, fileName string) ([]Parameter, error) {
 	codes := map[common.Location][]byte{}
 	location := common.StringLocation(fileName)
 	program, must := cmd.PrepareProgram(code, location, codes)
@@ -108,11 +261,7 @@ func ParseWithoutType(args []string, code []byte, fileName string) (scriptArgs [
 	}
 
 	if parameterList == nil {
-		return resultArgs, nil
-	}
-
-	if len(parameterList) != len(args) {
-		return nil, fmt.Errorf("argument count is %d, expected %d", len(args), len(parameterList))
+		return nil, nil
 	}
 
 	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{})
@@ -120,30 +269,16 @@ func ParseWithoutType(args []string, code []byte, fileName string) (scriptArgs [
 		return nil, err
 	}
 
-	for index, argumentString := range args {
-		astType := parameterList[index].TypeAnnotation.Type
-		semaType := checker.ConvertType(astType)
-
-		if semaType == sema.StringType {
-			if !strings.HasPrefix(argumentString, "\"") {
-				argumentString = ast.QuoteString(argumentString)
-			}
-		} else if _, ok := semaType.(*sema.AddressType); ok {
-			if !strings.HasPrefix(argumentString, "0x") {
-				argumentString = fmt.Sprintf("0x%s", argumentString)
-			}
-		}
-
-		value, err := runtime.ParseLiteral(argumentString, semaType, inter)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"argument `%s` is not expected type `%s`",
-				parameterList[index].Identifier,
-				semaType.QualifiedString(),
-			)
-		}
-
-		resultArgs = append(resultArgs, value)
+	parameters := make([]Parameter, 0, len(parameterList))
+	for _, parameter := range parameterList {
+		semaType := checker.ConvertType(parameter.TypeAnnotation.Type)
+		parameters = append(parameters, Parameter{
+			Name:     parameter.Identifier.Identifier,
+			Type:     semaType.QualifiedString(),
+			semaType: semaType,
+			inter:    inter,
+		})
 	}
-	return resultArgs, nil
+
+	return parameters, nil
 }
@@ -252,3 +252,84 @@ func Test_ParseJSON(t *testing.T) {
 	assert.Equal(t, `"Hello World"`, values[0].String())
 	assert.Equal(t, "String", values[0].Type().ID())
 }
+
+func Test_Parameters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transaction", func(t *testing.T) {
+		t.Parallel()
+
+		code := []byte(`transaction(amount: UFix64, to: Address, note: String?) { prepare(signer: AuthAccount) {} }`)
+		parameters, err := Parameters(code, "")
+		require.NoError(t, err)
+		require.Len(t, parameters, 3)
+
+		assert.Equal(t, "amount", parameters[0].Name)
+		assert.Equal(t, "UFix64", parameters[0].Type)
+		assert.Equal(t, "to", parameters[1].Name)
+		assert.Equal(t, "Address", parameters[1].Type)
+		assert.Equal(t, "String?", parameters[2].Type)
+
+		value, err := parameters[1].Parse("01")
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1}), value)
+
+		_, err = parameters[0].Parse("ten")
+		assert.EqualError(t, err, "argument `amount` is not expected type `UFix64`")
+	})
+
+	t.Run("no parameters", func(t *testing.T) {
+		t.Parallel()
+
+		parameters, err := Parameters([]byte(`transaction { execute {} }`), "")
+		require.NoError(t, err)
+		assert.Nil(t, parameters)
+	})
+}
+
+func Test_ParseNamed(t *testing.T) {
+	t.Parallel()
+
+	code := []byte(`transaction(amount: UFix64, to: Address) { prepare(signer: AuthAccount) {} }`)
+	amount, _ := cadence.NewUFix64("10.0")
+	to := cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		values, err := ParseNamed([]string{"to:Address:0x01", "amount=10.0"}, code, "")
+		require.NoError(t, err)
+		assert.Equal(t, []cadence.Value{amount, to}, values)
+	})
+
+	t.Run("are named", func(t *testing.T) {
+		t.Parallel()
+
+		parameters, err := Parameters(code, "")
+		require.NoError(t, err)
+
+		assert.True(t, AreNamed([]string{"amount=10.0", "to:Address:0x01"}, parameters))
+		assert.False(t, AreNamed([]string{"amount=10.0", "0x01"}, parameters))
+		assert.False(t, AreNamed([]string{"Hello:World"}, parameters))
+		assert.False(t, AreNamed([]string{"a=b"}, parameters))
+		assert.False(t, AreNamed(nil, parameters))
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := map[string][]string{
+			"argument `to` of type `Address` is missing":                           {"amount=10.0"},
+			"argument `amount` is declared as type `UFix64` but provided as `Int`": {"amount:Int:10", "to=0x01"},
+			"argument `amount` is not expected type `UFix64`":                      {"amount=ten", "to=0x01"},
+			"argument `from` is not a declared parameter":                          {"amount=10.0", "to=0x01", "from=0x02"},
+			"argument `amount` is provided more than once":                         {"amount=10.0", "amount=1.0"},
+			"argument `amount` is not in the name:Type:value format":               {"amount:10.0", "to=0x01"},
+		}
+
+		for expected, args := range testCases {
+			_, err := ParseNamed(args, code, "")
+			assert.EqualError(t, err, expected)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/project"
+)
+
+// DefaultBuildCacheDir is the project relative directory where resolved contract payloads are cached.
+const DefaultBuildCacheDir = ".flow/build"
+
+// BuildCache stores contract code with resolved imports on disk.
+//
+// The cache is keyed by the contract source, the network and the addresses imports resolve to,
+// so repeated deployments of unchanged contracts don't need to parse and resolve them again.
+type BuildCache struct {
+	dir          string
+	readerWriter ReaderWriter
+}
+
+// NewBuildCache creates a build cache storing entries in the provided directory.
+func NewBuildCache(dir string, readerWriter ReaderWriter) *BuildCache {
+	return &BuildCache{
+		dir:          dir,
+		readerWriter: readerWriter,
+	}
+}
+
+// Dir returns the directory used by the cache.
+func (c *BuildCache) Dir() string {
+	return c.dir
+}
+
+type buildCacheEntry struct {
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+// key creates a cache key from the contract source and location, relative imports are resolved
+// from the location, all the addresses the imports can be resolved to and the import options.
+func (c *BuildCache) key(
+	contract Script,
+	network config.Network,
+	aliases project.LocationAliases,
+	contracts []*project.Contract,
+	imports project.ImportOptions,
+) string {
+	resolved := make(map[string]string, len(aliases)+2*len(contracts))
+	for location, address := range aliases {
+		resolved[location] = address
+	}
+	for _, contract := range contracts {
+		resolved[contract.Location()] = contract.AccountAddress.String()
+		resolved[contract.Name] = contract.AccountAddress.String()
+	}
+	// imports resolved or ignored by the options are keyed separately so they can't collide with locations
+	for name, address := range imports.Resolve {
+		resolved["resolve:"+name] = address
+	}
+	for _, name := range imports.Ignore {
+		resolved["ignore:"+name] = ""
+	}
+
+	locations := make([]string, 0, len(resolved))
+	for location := range resolved {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+
+	hash := sha256.New()
+	hash.Write(contract.Code)
+	hash.Write([]byte{0})
+	hash.Write([]byte(filepath.Clean(contract.Location)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(network.Name))
+	for _, location := range locations {
+		hash.Write([]byte{0})
+		hash.Write([]byte(location + "=" + resolved[location]))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *BuildCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// get returns the cached entry, any failure to read the entry is treated as a cache miss.
+func (c *BuildCache) get(key string) (*buildCacheEntry, bool) {
+	data, err := c.readerWriter.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry buildCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Name == "" {
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+func (c *BuildCache) put(key string, entry buildCacheEntry) error {
+	if err := MkdirAll(c.readerWriter, c.dir); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return c.readerWriter.WriteFile(c.path(key), data, 0644)
+}
@@ -51,7 +51,33 @@ type AccountKey struct {
 	DerivationPath string
 	PrivateKey     crypto.PrivateKey
 	Location       string
+	PasswordFile   string
 	Env            string
+	Exec           []string
+	Keychain       string
+	KMS            KMSKey
+	Vault          VaultKey
+	Device         Device
+}
+
+// Device is the hardware wallet signing with the key at the derivation path, used by the KeyTypeDevice key type.
+type Device string
+
+const DeviceLedger Device = "ledger"
+
+// DefaultDerivationPath is the derivation path of the first Flow key of a mnemonic or a hardware wallet.
+const DefaultDerivationPath = "m/44'/539'/0'/0/0"
+
+// KMSProvider is the cloud provider managing a KMS key.
+type KMSProvider string
+
+const KMSProviderAWS KMSProvider = "aws"
+
+// KMSKey references a signing key managed by a cloud KMS provider, used by the KeyTypeKMS key type.
+type KMSKey struct {
+	Provider KMSProvider
+	// ARN of the AWS KMS key, e.g. "arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	ARN string
 }
 
 func NewDefaultAccountKey(pkey crypto.PrivateKey) AccountKey {
@@ -70,6 +96,76 @@ func (a *AccountKey) IsDefault() bool {
 		a.HashAlgo == DefaultHashAlgo
 }
 
+// DefaultVaultMount is the mount of the transit secrets engine if the Vault key doesn't configure one.
+const DefaultVaultMount = "transit"
+
+// DefaultVaultAuthMount is the mount of the AppRole auth method if the Vault key doesn't configure one.
+const DefaultVaultAuthMount = "approle"
+
+// VaultKey references a key of the HashiCorp Vault transit secrets engine, used by the KeyTypeVault key type.
+//
+// The key authenticates with either the token or the AppRole role ID and secret ID, if none is set the token is read
+// from the VAULT_TOKEN environment variable. Values can reference environment variables as $NAME or ${NAME}, so
+// secrets don't have to be stored in the configuration, the references are only expanded by Expanded.
+type VaultKey struct {
+	Address   string // address of the Vault server, the VAULT_ADDR environment variable if empty
+	Namespace string // enterprise namespace of the key
+	Mount     string // mount of the transit secrets engine, DefaultVaultMount if empty
+	Key       string
+	Token     string
+	RoleID    string
+	SecretID  string
+	AuthMount string // mount of the AppRole auth method, DefaultVaultAuthMount if empty
+}
+
+// Validate checks the key name is set and at most one authentication method is configured.
+func (v VaultKey) Validate() error {
+	if v.Key == "" {
+		return fmt.Errorf("missing transit key name")
+	}
+	if (v.RoleID == "") != (v.SecretID == "") {
+		return fmt.Errorf("AppRole authentication requires both the role ID and the secret ID")
+	}
+	if v.Token != "" && v.RoleID != "" {
+		return fmt.Errorf("configure either the token or the AppRole role ID and secret ID")
+	}
+	if v.AuthMount != "" && v.RoleID == "" {
+		return fmt.Errorf("auth mount can only be configured with AppRole authentication")
+	}
+
+	return nil
+}
+
+// Expanded returns the key with the environment variables referenced by the values expanded and the defaults set.
+func (v VaultKey) Expanded() (VaultKey, error) {
+	err := v.Validate()
+	if err != nil {
+		return VaultKey{}, err
+	}
+
+	if v.Address == "" {
+		v.Address = "$VAULT_ADDR"
+	}
+	if v.Mount == "" {
+		v.Mount = DefaultVaultMount
+	}
+	if v.Token == "" && v.RoleID == "" {
+		v.Token = "$VAULT_TOKEN"
+	}
+	if v.RoleID != "" && v.AuthMount == "" {
+		v.AuthMount = DefaultVaultAuthMount
+	}
+
+	for _, value := range []*string{&v.Address, &v.Namespace, &v.Token, &v.RoleID, &v.SecretID} {
+		*value, err = expandEnv(*value)
+		if err != nil {
+			return VaultKey{}, err
+		}
+	}
+
+	return v, nil
+}
+
 // ByName get account by name or error if not found.
 func (a *Accounts) ByName(name string) (*Account, error) {
 	for _, account := range *a {
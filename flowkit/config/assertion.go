@@ -0,0 +1,87 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+)
+
+// Assertion defines a script executed after deployment and the result it is expected to return on each network.
+type Assertion struct {
+	Name     string
+	Script   string
+	Args     []cadence.Value
+	Expected []AssertionResult
+}
+
+// AssertionResult defines the expected script result on a specific network.
+type AssertionResult struct {
+	Network string
+	Value   cadence.Value
+}
+
+// ExpectedOn returns the expected result on the network or nil if the assertion doesn't apply to the network.
+func (a *Assertion) ExpectedOn(network string) cadence.Value {
+	for _, expected := range a.Expected {
+		if expected.Network == network {
+			return expected.Value
+		}
+	}
+
+	return nil
+}
+
+type Assertions []Assertion
+
+// ByName get assertion by name or return an error if it doesn't exist.
+func (a *Assertions) ByName(name string) (*Assertion, error) {
+	for i, assertion := range *a {
+		if assertion.Name == name {
+			return &(*a)[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("assertion %s does not exist", name)
+}
+
+// ByNetwork get all assertions with an expected result on the network.
+func (a *Assertions) ByNetwork(network string) Assertions {
+	var assertions Assertions
+	for _, assertion := range *a {
+		if assertion.ExpectedOn(network) != nil {
+			assertions = append(assertions, assertion)
+		}
+	}
+
+	return assertions
+}
+
+// AddOrUpdate add new or update if already present.
+func (a *Assertions) AddOrUpdate(assertion Assertion) {
+	for i, existing := range *a {
+		if existing.Name == assertion.Name {
+			(*a)[i] = assertion
+			return
+		}
+	}
+
+	*a = append(*a, assertion)
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
 // Config contains all the configuration for CLI and implements getters and setters for properties.
@@ -32,12 +33,20 @@ import (
 // Networks defines all the Flow networks addresses
 // Accounts defines Flow accounts and their addresses, private key and more properties
 // Deployments describes which contracts should be deployed to which accounts
+// Assertions defines scripts verifying the deployment and their expected results
+// Scripts defines named scripts and the files containing their code
+// Payers defines the pools of accounts paying for transactions in rotation on each network
+// Defaults defines values used by commands when the corresponding flags are not provided
 type Config struct {
 	Emulators   Emulators
 	Contracts   Contracts
 	Networks    Networks
 	Accounts    Accounts
 	Deployments Deployments
+	Assertions  Assertions
+	Scripts     Scripts
+	Payers      PayerPools
+	Defaults    Defaults
 }
 
 type KeyType string
@@ -47,6 +56,12 @@ const (
 	KeyTypeGoogleKMS KeyType = "google-kms"
 	KeyTypeBip44     KeyType = "bip44"
 	KeyTypeFile      KeyType = "file"
+	KeyTypeExec      KeyType = "exec"
+	KeyTypeKMS       KeyType = "kms"
+	KeyTypeVault     KeyType = "vault"
+	KeyTypeDevice    KeyType = "device"
+	KeyTypeKeystore  KeyType = "keystore"
+	KeyTypeKeychain  KeyType = "keychain"
 )
 
 // Validate the configuration values.
@@ -82,6 +97,31 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for _, a := range c.Assertions {
+		for _, expected := range a.Expected {
+			if _, err := c.Networks.ByName(expected.Network); err != nil {
+				return fmt.Errorf("assertion %s contains nonexisting network %s", a.Name, expected.Network)
+			}
+		}
+	}
+
+	for _, pool := range c.Payers {
+		if _, err := c.Networks.ByName(pool.Network); err != nil {
+			return fmt.Errorf("payer pool contains nonexisting network %s", pool.Network)
+		}
+		for _, account := range pool.Accounts {
+			if _, err := c.Accounts.ByName(account); err != nil {
+				return fmt.Errorf("payer pool for network %s contains nonexisting account %s", pool.Network, account)
+			}
+		}
+	}
+
+	if c.Defaults.Signer != "" {
+		if _, err := c.Accounts.ByName(c.Defaults.Signer); err != nil {
+			return fmt.Errorf("default signer %s is not an account in the configuration", c.Defaults.Signer)
+		}
+	}
+
 	return nil
 }
 
@@ -111,6 +151,24 @@ func GlobalPath() string {
 	return fmt.Sprintf("%s/%s", dirname, DefaultPath)
 }
 
+// UserPath gets the path of the user configuration based on home dir.
+//
+// The user configuration uses the project configuration format and contains personal settings shared by all
+// projects, such as accounts, defaults and network explorers, see Loader.Load for how it's merged.
+func UserPath() string {
+	dirname, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dirname, userDir, userFile)
+}
+
+const (
+	userDir  = ".flow"
+	userFile = "config.json"
+)
+
 // DefaultPaths determines default paths for configuration.
 func DefaultPaths() []string {
 	return []string{
@@ -25,10 +25,25 @@ import (
 	"golang.org/x/exp/slices"
 )
 
-// ContractDeployment defines the deployment of the contract with possible args.
+// TestOnlyTag marks contract deployments that are never deployed to production networks.
+const TestOnlyTag = "test-only"
+
+// ContractDeployment defines the deployment of the contract with possible args and tags.
 type ContractDeployment struct {
 	Name string
 	Args []cadence.Value
+	Tags []string
+}
+
+// HasAnyTag checks if the contract deployment is tagged with any of the provided tags.
+func (c *ContractDeployment) HasAnyTag(tags []string) bool {
+	for _, tag := range tags {
+		if slices.Contains(c.Tags, tag) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Deployment defines the configuration for a contract deployment.
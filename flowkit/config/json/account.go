@@ -25,6 +25,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/anmitsu/go-shlex"
 	"github.com/invopop/jsonschema"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -143,6 +144,9 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 	}
 
 	hashAlgo := config.DefaultHashAlgo // default to sha3 as default
+	if a.Key.Type == config.KeyTypeKMS {
+		hashAlgo = crypto.SHA2_256 // KMS keys only sign with sha2
+	}
 	if a.Key.HashAlgo != "" {
 		hashAlgo = crypto.StringToHashAlgorithm(a.Key.HashAlgo)
 	}
@@ -151,19 +155,31 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 		return nil, fmt.Errorf("invalid hash algorithm for account %s", accountName)
 	}
 
-	validTypes := []config.KeyType{config.KeyTypeHex, config.KeyTypeFile, config.KeyTypeBip44, config.KeyTypeGoogleKMS}
+	if a.Signer != nil {
+		return transformSignerToConfig(accountName, a, sigAlgo, hashAlgo)
+	}
+
+	validTypes := []config.KeyType{config.KeyTypeHex, config.KeyTypeFile, config.KeyTypeBip44, config.KeyTypeGoogleKMS, config.KeyTypeKMS, config.KeyTypeVault, config.KeyTypeDevice, config.KeyTypeKeystore, config.KeyTypeKeychain}
 	if !slices.Contains(validTypes, a.Key.Type) {
 		return nil, fmt.Errorf("invalid key type for account %s", accountName)
 	}
 
 	// check that only one is provided because the values are mutually exclusive
 	set := false
-	for _, v := range []string{a.Key.ResourceID, a.Key.PrivateKey, a.Key.Location} {
-		if v == "" {
+	for _, v := range []bool{
+		a.Key.ResourceID != "",
+		a.Key.PrivateKey != "",
+		a.Key.Location != "",
+		a.Key.KMS != nil,
+		a.Key.Vault != nil,
+		a.Key.Device != "",
+		a.Key.Keychain != "",
+	} {
+		if !v {
 			continue
 		}
 		if set {
-			return nil, fmt.Errorf("can only provide one property (resource ID, private key, location) on account %s", accountName)
+			return nil, fmt.Errorf("can only provide one property (resource ID, private key, location, kms, vault, device, keychain) on account %s", accountName)
 		}
 		set = true
 	}
@@ -225,6 +241,65 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 			return nil, fmt.Errorf("missing location to a file containing the private key value for the account %s", accountName)
 		}
 		key.Location = a.Key.Location
+
+	case config.KeyTypeKeystore:
+		if a.Key.Location == "" {
+			return nil, fmt.Errorf("missing location of the keystore file for the account %s", accountName)
+		}
+		key.Location = a.Key.Location
+		key.PasswordFile = a.Key.PasswordFile
+
+	case config.KeyTypeKeychain:
+		if a.Key.Keychain == "" {
+			return nil, fmt.Errorf("missing keychain item name of the private key for the account %s", accountName)
+		}
+		key.Keychain = a.Key.Keychain
+
+	case config.KeyTypeKMS:
+		if a.Key.KMS == nil || a.Key.KMS.ARN == "" {
+			return nil, fmt.Errorf("missing kms key ARN for the account %s", accountName)
+		}
+		if config.KMSProvider(a.Key.KMS.Provider) != config.KMSProviderAWS {
+			return nil, fmt.Errorf("unsupported kms provider %s for the account %s, supported providers are: aws", a.Key.KMS.Provider, accountName)
+		}
+		if hashAlgo != crypto.SHA2_256 {
+			return nil, fmt.Errorf("kms key for the account %s only supports the SHA2_256 hash algorithm", accountName)
+		}
+		key.KMS = config.KMSKey{
+			Provider: config.KMSProviderAWS,
+			ARN:      a.Key.KMS.ARN,
+		}
+
+	case config.KeyTypeVault:
+		if a.Key.Vault == nil {
+			return nil, fmt.Errorf("missing vault transit key for the account %s", accountName)
+		}
+		if sigAlgo != crypto.ECDSA_P256 {
+			return nil, fmt.Errorf("vault key for the account %s only supports the ECDSA_P256 signature algorithm", accountName)
+		}
+		key.Vault = config.VaultKey{
+			Address:   a.Key.Vault.Address,
+			Namespace: a.Key.Vault.Namespace,
+			Mount:     a.Key.Vault.Mount,
+			Key:       a.Key.Vault.Key,
+			Token:     a.Key.Vault.Token,
+			RoleID:    a.Key.Vault.RoleID,
+			SecretID:  a.Key.Vault.SecretID,
+			AuthMount: a.Key.Vault.AuthMount,
+		}
+		if err := key.Vault.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid vault key for the account %s: %w", accountName, err)
+		}
+
+	case config.KeyTypeDevice:
+		if config.Device(a.Key.Device) != config.DeviceLedger {
+			return nil, fmt.Errorf("unsupported device %s for the account %s, supported devices are: ledger", a.Key.Device, accountName)
+		}
+		key.Device = config.DeviceLedger
+		key.DerivationPath = a.Key.DerivationPath
+		if key.DerivationPath == "" {
+			key.DerivationPath = config.DefaultDerivationPath
+		}
 	}
 
 	return &config.Account{
@@ -234,6 +309,39 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 	}, nil
 }
 
+// transformSignerToConfig transforms an account signing with an external signer to config account,
+// its key only sets the index and the algorithms.
+func transformSignerToConfig(
+	accountName string,
+	a advancedAccount,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*config.Account, error) {
+	if a.Key.Type != "" {
+		return nil, fmt.Errorf("can not provide both a key type and a signer on account %s", accountName)
+	}
+	if len(a.Signer.Exec) == 0 {
+		return nil, fmt.Errorf("missing exec command of the external signer for the account %s", accountName)
+	}
+
+	address, err := transformAddress(a.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.Account{
+		Name:    accountName,
+		Address: address,
+		Key: config.AccountKey{
+			Type:     config.KeyTypeExec,
+			Index:    a.Key.Index,
+			SigAlgo:  sigAlgo,
+			HashAlgo: hashAlgo,
+			Exec:     a.Signer.Exec,
+		},
+	}, nil
+}
+
 // transformToConfig transforms json structures to config structure.
 func (j jsonAccounts) transformToConfig() (config.Accounts, error) {
 	accounts := make(config.Accounts, 0)
@@ -289,6 +397,18 @@ func transformSimpleAccountToJSON(a config.Account) account {
 }
 
 func transformAdvancedAccountToJSON(a config.Account) account {
+	if a.Key.Type == config.KeyTypeExec {
+		key := transformAdvancedKeyToJSON(a.Key)
+		key.Type = ""
+		return account{
+			Advanced: advancedAccount{
+				Address: a.Address.String(),
+				Key:     key,
+				Signer:  &accountSigner{Exec: a.Key.Exec},
+			},
+		}
+	}
+
 	return account{
 		Advanced: advancedAccount{
 			Address: a.Address.String(),
@@ -327,6 +447,32 @@ func transformAdvancedKeyToJSON(key config.AccountKey) advanceKey {
 		advancedKey.ResourceID = key.ResourceID
 	case config.KeyTypeFile:
 		advancedKey.Location = key.Location
+	case config.KeyTypeKeystore:
+		advancedKey.Location = key.Location
+		advancedKey.PasswordFile = key.PasswordFile
+	case config.KeyTypeKeychain:
+		advancedKey.Keychain = key.Keychain
+	case config.KeyTypeKMS:
+		advancedKey.KMS = &kmsKey{
+			Provider: string(key.KMS.Provider),
+			ARN:      key.KMS.ARN,
+		}
+	case config.KeyTypeDevice:
+		advancedKey.Device = string(key.Device)
+		if key.DerivationPath != config.DefaultDerivationPath {
+			advancedKey.DerivationPath = key.DerivationPath
+		}
+	case config.KeyTypeVault:
+		advancedKey.Vault = &vaultKey{
+			Address:   key.Vault.Address,
+			Namespace: key.Vault.Namespace,
+			Mount:     key.Vault.Mount,
+			Key:       key.Vault.Key,
+			Token:     key.Vault.Token,
+			RoleID:    key.Vault.RoleID,
+			SecretID:  key.Vault.SecretID,
+			AuthMount: key.Vault.AuthMount,
+		}
 	}
 
 	return advancedKey
@@ -343,8 +489,54 @@ type simpleAccount struct {
 }
 
 type advancedAccount struct {
-	Address string     `json:"address"`
-	Key     advanceKey `json:"key"`
+	Address string         `json:"address"`
+	Key     advanceKey     `json:"key,omitempty"`
+	Signer  *accountSigner `json:"signer,omitempty"`
+}
+
+// accountSigner signs the transactions of the account with an external signer instead of a key.
+type accountSigner struct {
+	Exec execCommand `json:"exec"`
+}
+
+// execCommand is the external signer program and its arguments, configured as a list or as a string
+// split into words like a shell command.
+type execCommand []string
+
+func (c *execCommand) UnmarshalJSON(b []byte) error {
+	var command string
+	if err := json.Unmarshal(b, &command); err != nil {
+		var args []string
+		if err := json.Unmarshal(b, &args); err != nil {
+			return fmt.Errorf("exec must be a command string or a list of the program and its arguments")
+		}
+		*c = args
+		return nil
+	}
+
+	args, err := shlex.Split(command, true)
+	if err != nil {
+		return fmt.Errorf("invalid exec command %s: %w", command, err)
+	}
+	*c = args
+	return nil
+}
+
+// MarshalJSON writes a command without arguments as a string, and the list otherwise.
+func (c execCommand) MarshalJSON() ([]byte, error) {
+	if len(c) == 1 {
+		return json.Marshal(c[0])
+	}
+	return json.Marshal([]string(c))
+}
+
+func (execCommand) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+		},
+	}
 }
 
 type advanceKey struct {
@@ -361,10 +553,37 @@ type advanceKey struct {
 	ResourceID string `json:"resourceID,omitempty"`
 	// key location
 	Location string `json:"location,omitempty"`
+	// keystore passphrase location
+	PasswordFile string `json:"passwordFile,omitempty"`
+	// cloud kms key
+	KMS *kmsKey `json:"kms,omitempty"`
+	// vault transit key
+	Vault *vaultKey `json:"vault,omitempty"`
+	// hardware wallet, the derivation path is shared with the bip44 key type
+	Device string `json:"device,omitempty"`
+	// operating system keychain item
+	Keychain string `json:"keychain,omitempty"`
 	// old key format
 	Context map[string]string `json:"context,omitempty"`
 }
 
+type kmsKey struct {
+	Provider string `json:"provider"`
+	ARN      string `json:"arn"`
+}
+
+// vaultKey values can reference environment variables as $NAME or ${NAME}.
+type vaultKey struct {
+	Address   string `json:"address,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Mount     string `json:"mount,omitempty"`
+	Key       string `json:"key"`
+	Token     string `json:"token,omitempty"`
+	RoleID    string `json:"roleId,omitempty"`
+	SecretID  string `json:"secretId,omitempty"`
+	AuthMount string `json:"authMount,omitempty"`
+}
+
 // support for pre v0.22 formats
 type simpleAccountPre022 struct {
 	Address string `json:"address"`
@@ -454,10 +673,37 @@ func (j account) MarshalJSON() ([]byte, error) {
 	if j.Simple != (simpleAccount{}) {
 		return json.Marshal(j.Simple)
 	}
+	if j.Advanced.Signer != nil {
+		return json.Marshal(signerAccountJSON(j.Advanced))
+	}
 
 	return json.Marshal(j.Advanced)
 }
 
+// signerAccount is the saved format of accounts signing with an external signer,
+// the key is only saved for a non-default index or algorithms.
+type signerAccount struct {
+	Address string         `json:"address"`
+	Signer  *accountSigner `json:"signer"`
+	Key     *signerKey     `json:"key,omitempty"`
+}
+
+type signerKey struct {
+	Index    int    `json:"index,omitempty"`
+	SigAlgo  string `json:"signatureAlgorithm,omitempty"`
+	HashAlgo string `json:"hashAlgorithm,omitempty"`
+}
+
+func signerAccountJSON(a advancedAccount) signerAccount {
+	account := signerAccount{Address: a.Address, Signer: a.Signer}
+	key := signerKey{Index: a.Key.Index, SigAlgo: a.Key.SigAlgo, HashAlgo: a.Key.HashAlgo}
+	if key != (signerKey{}) {
+		account.Key = &key
+	}
+
+	return account
+}
+
 func (a account) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
 		OneOf: []*jsonschema.Schema{
@@ -564,3 +564,245 @@ func Test_ReplaceENV(t *testing.T) {
 		}
 	})
 }
+
+func Test_ConfigExecKey(t *testing.T) {
+	b := []byte(`{"custody":{"address":"f8d6e0586b0a20c7","signer":{"exec":["my-signer","--profile","prod account"]}}}`)
+
+	var signerAccounts jsonAccounts
+	err := json.Unmarshal(b, &signerAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := signerAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	custody, err := accounts.ByName("custody")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeExec, custody.Key.Type)
+	assert.Equal(t, []string{"my-signer", "--profile", "prod account"}, custody.Key.Exec)
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(x))
+
+	t.Run("Command string", func(t *testing.T) {
+		b := []byte(`{"custody":{"address":"f8d6e0586b0a20c7","signer":{"exec":"my-signer --profile 'prod account'"}}}`)
+		var execAccounts jsonAccounts
+		assert.NoError(t, json.Unmarshal(b, &execAccounts))
+
+		accounts, err := execAccounts.transformToConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"my-signer", "--profile", "prod account"}, accounts[0].Key.Exec)
+	})
+
+	t.Run("Key index", func(t *testing.T) {
+		b := []byte(`{"custody":{"address":"f8d6e0586b0a20c7","signer":{"exec":"my-signer"},"key":{"index":2,"hashAlgorithm":"SHA2_256"}}}`)
+		var execAccounts jsonAccounts
+		assert.NoError(t, json.Unmarshal(b, &execAccounts))
+
+		accounts, err := execAccounts.transformToConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"my-signer"}, accounts[0].Key.Exec)
+		assert.Equal(t, 2, accounts[0].Key.Index)
+		assert.Equal(t, "SHA2_256", accounts[0].Key.HashAlgo.String())
+
+		x, err := json.Marshal(transformAccountsToJSON(accounts))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"custody":{"address":"f8d6e0586b0a20c7","signer":{"exec":"my-signer"},"key":{"index":2,"hashAlgorithm":"SHA2_256"}}}`, string(x))
+	})
+
+	for _, test := range []struct {
+		account string
+		err     string
+	}{
+		{
+			account: `{"address":"f8d6e0586b0a20c7","signer":{"exec":[]}}`,
+			err:     "missing exec command of the external signer for the account custody",
+		},
+		{
+			account: `{"address":"f8d6e0586b0a20c7","signer":{"exec":"my-signer"},"key":{"type":"hex","privateKey":"1111111111111111111111111111111111111111111111111111111111111111"}}`,
+			err:     "can not provide both a key type and a signer on account custody",
+		},
+	} {
+		var execAccounts jsonAccounts
+		assert.NoError(t, json.Unmarshal([]byte(`{"custody":`+test.account+`}`), &execAccounts))
+		_, err = execAccounts.transformToConfig()
+		assert.EqualError(t, err, test.err)
+	}
+}
+
+func Test_ConfigAWSKMSKey(t *testing.T) {
+	arn := "arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	b := []byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":{"type":"kms","index":1,"hashAlgorithm":"SHA2_256","kms":{"provider":"aws","arn":"` + arn + `"}}}}`)
+
+	var kmsAccounts jsonAccounts
+	err := json.Unmarshal(b, &kmsAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := kmsAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	admin, err := accounts.ByName("admin")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeKMS, admin.Key.Type)
+	assert.Equal(t, config.KMSKey{Provider: config.KMSProviderAWS, ARN: arn}, admin.Key.KMS)
+	assert.Equal(t, "SHA2_256", admin.Key.HashAlgo.String())
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(x))
+
+	t.Run("Default hash algorithm", func(t *testing.T) {
+		b := []byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":{"type":"kms","kms":{"provider":"aws","arn":"` + arn + `"}}}}`)
+		var defaultAccounts jsonAccounts
+		assert.NoError(t, json.Unmarshal(b, &defaultAccounts))
+
+		accounts, err := defaultAccounts.transformToConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, "SHA2_256", accounts[0].Key.HashAlgo.String())
+	})
+
+	for _, test := range []struct {
+		key string
+		err string
+	}{
+		{`{"type":"kms"}`, "missing kms key ARN for the account admin"},
+		{`{"type":"kms","kms":{"provider":"azure","arn":"` + arn + `"}}`, "unsupported kms provider azure for the account admin, supported providers are: aws"},
+		{`{"type":"kms","hashAlgorithm":"SHA3_256","kms":{"provider":"aws","arn":"` + arn + `"}}`, "kms key for the account admin only supports the SHA2_256 hash algorithm"},
+		{`{"type":"kms","location":"admin.pkey","kms":{"provider":"aws","arn":"` + arn + `"}}`, "can only provide one property (resource ID, private key, location, kms, vault, device, keychain) on account admin"},
+	} {
+		var invalid jsonAccounts
+		assert.NoError(t, json.Unmarshal([]byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":`+test.key+`}}`), &invalid))
+
+		_, err := invalid.transformToConfig()
+		assert.EqualError(t, err, test.err)
+	}
+}
+
+func Test_ConfigVaultKey(t *testing.T) {
+	b := []byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":{"type":"vault","vault":{"address":"https://vault.example.com:8200","key":"flow-admin","roleId":"flow-cli","secretId":"$VAULT_SECRET_ID"}}}}`)
+
+	var vaultAccounts jsonAccounts
+	err := json.Unmarshal(b, &vaultAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := vaultAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	admin, err := accounts.ByName("admin")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeVault, admin.Key.Type)
+	assert.Equal(t, config.VaultKey{
+		Address:  "https://vault.example.com:8200",
+		Key:      "flow-admin",
+		RoleID:   "flow-cli",
+		SecretID: "$VAULT_SECRET_ID",
+	}, admin.Key.Vault)
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(x))
+
+	for _, test := range []struct {
+		key string
+		err string
+	}{
+		{`{"type":"vault"}`, "missing vault transit key for the account admin"},
+		{`{"type":"vault","vault":{"key":"flow-admin","roleId":"flow-cli"}}`, "invalid vault key for the account admin: AppRole authentication requires both the role ID and the secret ID"},
+		{`{"type":"vault","vault":{"key":"flow-admin","token":"$VAULT_TOKEN","roleId":"flow-cli","secretId":"secret"}}`, "invalid vault key for the account admin: configure either the token or the AppRole role ID and secret ID"},
+		{`{"type":"vault","signatureAlgorithm":"ECDSA_secp256k1","vault":{"key":"flow-admin"}}`, "vault key for the account admin only supports the ECDSA_P256 signature algorithm"},
+	} {
+		var invalid jsonAccounts
+		assert.NoError(t, json.Unmarshal([]byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":`+test.key+`}}`), &invalid))
+
+		_, err := invalid.transformToConfig()
+		assert.EqualError(t, err, test.err)
+	}
+}
+
+func Test_ConfigDeviceKey(t *testing.T) {
+	b := []byte(`{"ledger":{"address":"f8d6e0586b0a20c7","key":{"type":"device","device":"ledger","derivationPath":"m/44'/539'/0'/0/2"}}}`)
+
+	var deviceAccounts jsonAccounts
+	err := json.Unmarshal(b, &deviceAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := deviceAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	ledger, err := accounts.ByName("ledger")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeDevice, ledger.Key.Type)
+	assert.Equal(t, config.DeviceLedger, ledger.Key.Device)
+	assert.Equal(t, "m/44'/539'/0'/0/2", ledger.Key.DerivationPath)
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(x))
+
+	b = []byte(`{"ledger":{"address":"f8d6e0586b0a20c7","key":{"type":"device","device":"ledger"}}}`)
+	assert.NoError(t, json.Unmarshal(b, &deviceAccounts))
+	accounts, err = deviceAccounts.transformToConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, config.DefaultDerivationPath, accounts[0].Key.DerivationPath)
+
+	x, err = json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(x))
+
+	b = []byte(`{"ledger":{"address":"f8d6e0586b0a20c7","key":{"type":"device","device":"trezor"}}}`)
+	assert.NoError(t, json.Unmarshal(b, &deviceAccounts))
+	_, err = deviceAccounts.transformToConfig()
+	assert.EqualError(t, err, "unsupported device trezor for the account ledger, supported devices are: ledger")
+}
+
+func Test_ConfigKeystoreKey(t *testing.T) {
+	b := []byte(`{"alice":{"address":"f8d6e0586b0a20c7","key":{"type":"keystore","index":1,"location":"alice.keystore.json","passwordFile":"alice.password"}}}`)
+
+	var keystoreAccounts jsonAccounts
+	err := json.Unmarshal(b, &keystoreAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := keystoreAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	alice, err := accounts.ByName("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeKeystore, alice.Key.Type)
+	assert.Equal(t, 1, alice.Key.Index)
+	assert.Equal(t, "alice.keystore.json", alice.Key.Location)
+	assert.Equal(t, "alice.password", alice.Key.PasswordFile)
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(x))
+
+	b = []byte(`{"alice":{"address":"f8d6e0586b0a20c7","key":{"type":"keystore","passwordFile":"alice.password"}}}`)
+	assert.NoError(t, json.Unmarshal(b, &keystoreAccounts))
+	_, err = keystoreAccounts.transformToConfig()
+	assert.EqualError(t, err, "missing location of the keystore file for the account alice")
+}
+
+func Test_ConfigKeychainKey(t *testing.T) {
+	b := []byte(`{"alice":{"address":"f8d6e0586b0a20c7","key":{"type":"keychain","keychain":"my-account"}}}`)
+
+	var keychainAccounts jsonAccounts
+	err := json.Unmarshal(b, &keychainAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := keychainAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	alice, err := accounts.ByName("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeKeychain, alice.Key.Type)
+	assert.Equal(t, "my-account", alice.Key.Keychain)
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(x))
+
+	b = []byte(`{"alice":{"address":"f8d6e0586b0a20c7","key":{"type":"keychain"}}}`)
+	assert.NoError(t, json.Unmarshal(b, &keychainAccounts))
+	_, err = keychainAccounts.transformToConfig()
+	assert.EqualError(t, err, "missing keychain item name of the private key for the account alice")
+}
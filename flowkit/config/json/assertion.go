@@ -0,0 +1,107 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// jsonAssertions maps assertion names to the script and the expected JSON-Cadence results by network.
+type jsonAssertions map[string]jsonAssertion
+
+type jsonAssertion struct {
+	Script   string                     `json:"script"`
+	Args     []json.RawMessage          `json:"args,omitempty"`
+	Expected map[string]json.RawMessage `json:"expected"`
+}
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonAssertions) transformToConfig() (config.Assertions, error) {
+	assertions := make(config.Assertions, 0)
+
+	for name, a := range j {
+		if a.Script == "" {
+			return nil, fmt.Errorf("assertion %s is missing the script path", name)
+		}
+
+		assertion := config.Assertion{
+			Name:   name,
+			Script: a.Script,
+		}
+
+		for _, arg := range a.Args {
+			value, err := jsoncdc.Decode(nil, arg)
+			if err != nil {
+				return nil, fmt.Errorf("assertion %s contains an invalid argument: %w", name, err)
+			}
+			assertion.Args = append(assertion.Args, value)
+		}
+
+		for network, expected := range a.Expected {
+			value, err := jsoncdc.Decode(nil, expected)
+			if err != nil {
+				return nil, fmt.Errorf("assertion %s contains an invalid expected result for network %s: %w", name, network, err)
+			}
+			assertion.Expected = append(assertion.Expected, config.AssertionResult{
+				Network: network,
+				Value:   value,
+			})
+		}
+
+		assertions = append(assertions, assertion)
+	}
+
+	return assertions, nil
+}
+
+// transformAssertionsToJSON transforms config structure to json structures for saving.
+func transformAssertionsToJSON(assertions config.Assertions) jsonAssertions {
+	jsonAssertions := jsonAssertions{}
+
+	for _, a := range assertions {
+		assertion := jsonAssertion{
+			Script:   a.Script,
+			Expected: make(map[string]json.RawMessage),
+		}
+
+		for _, arg := range a.Args {
+			assertion.Args = append(assertion.Args, encodeValue(arg))
+		}
+
+		for _, expected := range a.Expected {
+			assertion.Expected[expected.Network] = encodeValue(expected.Value)
+		}
+
+		jsonAssertions[a.Name] = assertion
+	}
+
+	return jsonAssertions
+}
+
+func encodeValue(value cadence.Value) json.RawMessage {
+	// values were decoded from JSON-Cadence so encoding them back can't fail
+	encoded, _ := jsoncdc.Encode(value)
+	return encoded
+}
@@ -0,0 +1,82 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigAssertions(t *testing.T) {
+	b := []byte(`{
+		"total-supply": {
+			"script": "./scripts/total_supply.cdc",
+			"args": [{ "type": "String", "value": "Kitty" }],
+			"expected": {
+				"emulator": { "type": "UInt64", "value": "10" }
+			}
+		}
+	}`)
+
+	var jsonAssertions jsonAssertions
+	err := json.Unmarshal(b, &jsonAssertions)
+	require.NoError(t, err)
+
+	assertions, err := jsonAssertions.transformToConfig()
+	require.NoError(t, err)
+
+	assertion, err := assertions.ByName("total-supply")
+	require.NoError(t, err)
+	assert.Equal(t, "./scripts/total_supply.cdc", assertion.Script)
+	assert.Equal(t, []cadence.Value{cadence.String("Kitty")}, assertion.Args)
+	assert.Equal(t, cadence.UInt64(10), assertion.ExpectedOn("emulator"))
+	assert.Nil(t, assertion.ExpectedOn("testnet"))
+	assert.Len(t, assertions.ByNetwork("testnet"), 0)
+}
+
+func Test_TransformAssertionsToJSON(t *testing.T) {
+	b := []byte(`{"total-supply":{"script":"./scripts/total_supply.cdc","expected":{"emulator":{"value":"10","type":"UInt64"}}}}`)
+
+	var jsonAssertions jsonAssertions
+	err := json.Unmarshal(b, &jsonAssertions)
+	require.NoError(t, err)
+
+	assertions, err := jsonAssertions.transformToConfig()
+	require.NoError(t, err)
+
+	j := transformAssertionsToJSON(assertions)
+	x, _ := json.Marshal(j)
+
+	assert.JSONEq(t, string(b), string(x))
+}
+
+func Test_ConfigAssertionsInvalidExpected(t *testing.T) {
+	b := []byte(`{"total-supply":{"script":"./scripts/total_supply.cdc","expected":{"emulator":{"value":"x","type":"UInt64"}}}}`)
+
+	var jsonAssertions jsonAssertions
+	err := json.Unmarshal(b, &jsonAssertions)
+	require.NoError(t, err)
+
+	_, err = jsonAssertions.transformToConfig()
+	assert.ErrorContains(t, err, "assertion total-supply contains an invalid expected result for network emulator")
+}
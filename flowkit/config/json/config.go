@@ -32,6 +32,10 @@ type jsonConfig struct {
 	Networks    jsonNetworks    `json:"networks,omitempty"`
 	Accounts    jsonAccounts    `json:"accounts,omitempty"`
 	Deployments jsonDeployments `json:"deployments,omitempty"`
+	Assertions  jsonAssertions  `json:"assertions,omitempty"`
+	Scripts     jsonScripts     `json:"scripts,omitempty"`
+	Payers      jsonPayers      `json:"payers,omitempty"`
+	Defaults    *jsonDefaults   `json:"defaults,omitempty"`
 }
 
 func (j *jsonConfig) transformToConfig() (*config.Config, error) {
@@ -60,12 +64,31 @@ func (j *jsonConfig) transformToConfig() (*config.Config, error) {
 		return nil, err
 	}
 
+	assertions, err := j.Assertions.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scripts, err := j.Scripts.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	payers, err := j.Payers.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	conf := &config.Config{
 		Emulators:   emulators,
 		Contracts:   contracts,
 		Networks:    networks,
 		Accounts:    accounts,
 		Deployments: deployments,
+		Assertions:  assertions,
+		Scripts:     scripts,
+		Payers:      payers,
+		Defaults:    j.Defaults.transformToConfig(),
 	}
 
 	return conf, nil
@@ -78,6 +101,10 @@ func transformConfigToJSON(config *config.Config) jsonConfig {
 		Networks:    transformNetworksToJSON(config.Networks),
 		Accounts:    transformAccountsToJSON(config.Accounts),
 		Deployments: transformDeploymentsToJSON(config.Deployments),
+		Assertions:  transformAssertionsToJSON(config.Assertions),
+		Scripts:     transformScriptsToJSON(config.Scripts),
+		Payers:      transformPayersToJSON(config.Payers),
+		Defaults:    transformDefaultsToJSON(config.Defaults),
 	}
 }
 
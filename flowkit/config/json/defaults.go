@@ -0,0 +1,53 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// jsonDefaults are the values used by commands when the corresponding flags are not provided.
+type jsonDefaults struct {
+	Signer string `json:"signer,omitempty"`
+	Output string `json:"output,omitempty"`
+}
+
+// transformToConfig transforms json structures to config structure.
+func (j *jsonDefaults) transformToConfig() config.Defaults {
+	if j == nil {
+		return config.Defaults{}
+	}
+
+	return config.Defaults{
+		Signer: j.Signer,
+		Output: j.Output,
+	}
+}
+
+// transformDefaultsToJSON transforms config structure to json structures for saving.
+func transformDefaultsToJSON(defaults config.Defaults) *jsonDefaults {
+	if defaults == (config.Defaults{}) {
+		return nil
+	}
+
+	return &jsonDefaults{
+		Signer: defaults.Signer,
+		Output: defaults.Output,
+	}
+}
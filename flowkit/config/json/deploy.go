@@ -75,6 +75,7 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 						config.ContractDeployment{
 							Name: contract.advanced.Name,
 							Args: args,
+							Tags: contract.advanced.Tags,
 						},
 					)
 				}
@@ -96,7 +97,7 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 
 		deployments := make([]deployment, 0)
 		for _, c := range d.Contracts {
-			if len(c.Args) == 0 {
+			if len(c.Args) == 0 && len(c.Tags) == 0 {
 				deployments = append(deployments, deployment{
 					simple: c.Name,
 				})
@@ -121,6 +122,7 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 					advanced: contractDeployment{
 						Name: c.Name,
 						Args: args,
+						Tags: c.Tags,
 					},
 				})
 			}
@@ -142,6 +144,7 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 type contractDeployment struct {
 	Name string           `json:"name"`
 	Args []map[string]any `json:"args"`
+	Tags []string         `json:"tags,omitempty"`
 }
 
 type deployment struct {
@@ -151,3 +151,24 @@ func Test_DeploymentAdvanced(t *testing.T) {
 	assert.Equal(t, "KittyItemsMarket", alice.Contracts[1].Name)
 	assert.Len(t, alice.Contracts[1].Args, 0)
 }
+
+func Test_DeploymentTags(t *testing.T) {
+	b := []byte(`{"emulator":{"alice":[{"name":"Kibble","args":[],"tags":["core","test-only"]},"KittyItemsMarket"]}}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	require.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	require.NoError(t, err)
+
+	alice := deployments.ByAccountAndNetwork("alice", "emulator")
+	require.NotNil(t, alice)
+	assert.Equal(t, []string{"core", "test-only"}, alice.Contracts[0].Tags)
+	assert.True(t, alice.Contracts[0].HasAnyTag([]string{"core"}))
+	assert.False(t, alice.Contracts[1].HasAnyTag([]string{"core"}))
+
+	x, err := json.Marshal(transformDeploymentsToJSON(deployments))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+}
@@ -37,16 +37,43 @@ func (j jsonNetworks) transformToConfig() (config.Networks, error) {
 	networks := make(config.Networks, 0)
 
 	for networkName, n := range j {
-		if n.Advanced.Key != "" && n.Advanced.Host != "" {
-			err := validateECDSAP256Pub(n.Advanced.Key)
-			if err != nil {
-				return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
+		if n.Advanced.Host != "" && (n.Advanced.Key != "" || n.Advanced.Production || n.Advanced.Auth != nil || n.Advanced.Explorer != "") {
+			if n.Advanced.Key != "" {
+				err := validateECDSAP256Pub(n.Advanced.Key)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
+				}
+			}
+
+			var auth *config.NetworkAuth
+			if n.Advanced.Auth != nil {
+				auth = &config.NetworkAuth{
+					Header:   n.Advanced.Auth.Header,
+					APIKey:   n.Advanced.Auth.APIKey,
+					Token:    n.Advanced.Auth.Token,
+					Username: n.Advanced.Auth.Username,
+					Password: n.Advanced.Auth.Password,
+				}
+				err := auth.Validate()
+				if err != nil {
+					return nil, fmt.Errorf("invalid auth for network with name %s: %w", networkName, err)
+				}
+			}
+
+			if n.Advanced.Explorer != "" {
+				err := config.ValidateExplorer(n.Advanced.Explorer)
+				if err != nil {
+					return nil, fmt.Errorf("invalid explorer for network with name %s: %w", networkName, err)
+				}
 			}
 
 			networks = append(networks, config.Network{
-				Name: networkName,
-				Host: n.Advanced.Host,
-				Key:  n.Advanced.Key,
+				Name:       networkName,
+				Host:       n.Advanced.Host,
+				Key:        n.Advanced.Key,
+				Production: n.Advanced.Production,
+				Auth:       auth,
+				Explorer:   n.Advanced.Explorer,
 			})
 		} else if n.Simple.Host != "" {
 			networks = append(networks, config.Network{
@@ -66,7 +93,7 @@ func transformNetworksToJSON(networks config.Networks) jsonNetworks {
 	jsonNetworks := jsonNetworks{}
 
 	for _, n := range networks {
-		if n.Key != "" {
+		if n.Key != "" || n.Production || n.Auth != nil || n.Explorer != "" {
 			jsonNetworks[n.Name] = transformAdvancedNetworkToJSON(n)
 		} else {
 			jsonNetworks[n.Name] = transformSimpleNetworkToJSON(n)
@@ -85,10 +112,24 @@ func transformSimpleNetworkToJSON(n config.Network) jsonNetwork {
 }
 
 func transformAdvancedNetworkToJSON(n config.Network) jsonNetwork {
+	var auth *networkAuth
+	if n.Auth != nil {
+		auth = &networkAuth{
+			Header:   n.Auth.Header,
+			APIKey:   n.Auth.APIKey,
+			Token:    n.Auth.Token,
+			Username: n.Auth.Username,
+			Password: n.Auth.Password,
+		}
+	}
+
 	return jsonNetwork{
 		Advanced: advancedNetwork{
-			Host: n.Host,
-			Key:  n.Key,
+			Host:       n.Host,
+			Key:        n.Key,
+			Production: n.Production,
+			Auth:       auth,
+			Explorer:   n.Explorer,
 		},
 	}
 }
@@ -103,8 +144,20 @@ type simpleNetwork struct {
 }
 
 type advancedNetwork struct {
-	Host string `json:"host"`
-	Key  string `json:"key"`
+	Host       string       `json:"host"`
+	Key        string       `json:"key,omitempty"`
+	Production bool         `json:"production,omitempty"`
+	Auth       *networkAuth `json:"auth,omitempty"`
+	Explorer   string       `json:"explorer,omitempty"`
+}
+
+// networkAuth values can reference environment variables as $NAME or ${NAME}.
+type networkAuth struct {
+	Header   string `json:"header,omitempty"`
+	APIKey   string `json:"apiKey,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
 }
 
 func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
@@ -121,6 +174,9 @@ func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
 	if err == nil {
 		j.Advanced.Host = advanced.Host
 		j.Advanced.Key = advanced.Key
+		j.Advanced.Production = advanced.Production
+		j.Advanced.Auth = advanced.Auth
+		j.Advanced.Explorer = advanced.Explorer
 	}
 
 	return err
@@ -22,6 +22,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
 )
 
 func Test_ConfigNetworkSimple(t *testing.T) {
@@ -153,3 +156,81 @@ func Test_TransformConfigAdvanced(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func Test_ConfigNetworkProduction(t *testing.T) {
+	b := []byte(`{"production":{"host":"access.mainnet.nodes.onflow.org:9000","production":true}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	require.NoError(t, err)
+
+	network, err := networks.ByName("production")
+	require.NoError(t, err)
+	assert.True(t, network.IsProduction())
+
+	x, err := json.Marshal(transformNetworksToJSON(networks))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigNetworkAuth(t *testing.T) {
+	b := []byte(`{"provider":{"host":"flow.provider.example:443","auth":{"header":"x-token","apiKey":"$PROVIDER_KEY"}}}`)
+
+	var parsed jsonNetworks
+	err := json.Unmarshal(b, &parsed)
+	require.NoError(t, err)
+
+	networks, err := parsed.transformToConfig()
+	require.NoError(t, err)
+
+	network, err := networks.ByName("provider")
+	require.NoError(t, err)
+	assert.Equal(t, &config.NetworkAuth{Header: "x-token", APIKey: "$PROVIDER_KEY"}, network.Auth)
+
+	// environment variable references are saved as configured
+	x, err := json.Marshal(transformNetworksToJSON(networks))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+
+	t.Run("Fail invalid auth", func(t *testing.T) {
+		b := []byte(`{"provider":{"host":"flow.provider.example:443","auth":{"apiKey":"key","token":"token"}}}`)
+		var invalid jsonNetworks
+		err := json.Unmarshal(b, &invalid)
+		require.NoError(t, err)
+
+		_, err = invalid.transformToConfig()
+		assert.EqualError(t, err, "invalid auth for network with name provider: configure exactly one of the API key, the token or the username and password")
+	})
+}
+
+func Test_ConfigNetworkExplorer(t *testing.T) {
+	b := []byte(`{"previewnet":{"host":"access.previewnet.nodes.onflow.org:9000","explorer":"https://previewnet.flowscan.io/{type}/{id}"}}`)
+
+	var parsed jsonNetworks
+	err := json.Unmarshal(b, &parsed)
+	require.NoError(t, err)
+
+	networks, err := parsed.transformToConfig()
+	require.NoError(t, err)
+
+	network, err := networks.ByName("previewnet")
+	require.NoError(t, err)
+	assert.Equal(t, "https://previewnet.flowscan.io/{type}/{id}", network.Explorer)
+
+	x, err := json.Marshal(transformNetworksToJSON(networks))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+
+	t.Run("Fail missing id", func(t *testing.T) {
+		b := []byte(`{"previewnet":{"host":"access.previewnet.nodes.onflow.org:9000","explorer":"https://previewnet.flowscan.io"}}`)
+		var invalid jsonNetworks
+		err := json.Unmarshal(b, &invalid)
+		require.NoError(t, err)
+
+		_, err = invalid.transformToConfig()
+		assert.EqualError(t, err, "invalid explorer for network with name previewnet: explorer URL template https://previewnet.flowscan.io must contain the {id} placeholder")
+	})
+}
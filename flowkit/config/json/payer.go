@@ -0,0 +1,56 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// jsonPayers maps network names to the names of the accounts in their payer pool.
+type jsonPayers map[string][]string
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonPayers) transformToConfig() (config.PayerPools, error) {
+	pools := make(config.PayerPools, 0)
+
+	for network, accounts := range j {
+		pool := config.PayerPool{
+			Network:  network,
+			Accounts: accounts,
+		}
+		if err := pool.Validate(); err != nil {
+			return nil, err
+		}
+
+		pools = append(pools, pool)
+	}
+
+	return pools, nil
+}
+
+// transformPayersToJSON transforms config structure to json structures for saving.
+func transformPayersToJSON(pools config.PayerPools) jsonPayers {
+	jsonPayers := jsonPayers{}
+
+	for _, p := range pools {
+		jsonPayers[p.Network] = p.Accounts
+	}
+
+	return jsonPayers
+}
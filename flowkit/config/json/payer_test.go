@@ -0,0 +1,57 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigPayers(t *testing.T) {
+	b := []byte(`{"testnet":["relayer-1","relayer-2"]}`)
+
+	var jsonPayers jsonPayers
+	err := json.Unmarshal(b, &jsonPayers)
+	require.NoError(t, err)
+
+	pools, err := jsonPayers.transformToConfig()
+	require.NoError(t, err)
+
+	pool := pools.ByNetwork("testnet")
+	require.NotNil(t, pool)
+	assert.Equal(t, []string{"relayer-1", "relayer-2"}, pool.Accounts)
+	assert.Nil(t, pools.ByNetwork("mainnet"))
+
+	x, _ := json.Marshal(transformPayersToJSON(pools))
+	assert.JSONEq(t, string(b), string(x))
+}
+
+func Test_ConfigPayersDuplicateAccount(t *testing.T) {
+	b := []byte(`{"testnet":["relayer-1","relayer-1"]}`)
+
+	var jsonPayers jsonPayers
+	err := json.Unmarshal(b, &jsonPayers)
+	require.NoError(t, err)
+
+	_, err = jsonPayers.transformToConfig()
+	assert.EqualError(t, err, "payer pool for network testnet contains account relayer-1 more than once")
+}
@@ -0,0 +1,57 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// jsonScripts maps script names to the files containing their code.
+type jsonScripts map[string]string
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonScripts) transformToConfig() (config.Scripts, error) {
+	scripts := make(config.Scripts, 0)
+
+	for name, filename := range j {
+		if filename == "" {
+			return nil, fmt.Errorf("script %s is missing the file path", name)
+		}
+
+		scripts = append(scripts, config.Script{
+			Name:     name,
+			Filename: filename,
+		})
+	}
+
+	return scripts, nil
+}
+
+// transformScriptsToJSON transforms config structure to json structures for saving.
+func transformScriptsToJSON(scripts config.Scripts) jsonScripts {
+	jsonScripts := jsonScripts{}
+
+	for _, s := range scripts {
+		jsonScripts[s.Name] = s.Filename
+	}
+
+	return jsonScripts
+}
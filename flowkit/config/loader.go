@@ -19,10 +19,13 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"golang.org/x/exp/slices"
 )
 
 // ErrDoesNotExist is error to be returned when config file does not exists.
@@ -68,6 +71,18 @@ type Loader struct {
 	readerWriter    ReaderWriter
 	configParsers   Parsers
 	LoadedLocations []string
+	user            userEntries
+}
+
+// userEntries are the entries merged from the user configuration as they were merged, they are saved
+// back to the user configuration instead of the project files.
+type userEntries struct {
+	path      string
+	accounts  map[string]Account
+	networks  map[string]Network
+	explorers map[string]string
+	signer    string
+	output    string
 }
 
 // NewLoader returns a new loader.
@@ -92,7 +107,12 @@ func (l *Loader) Save(conf *Config, path string) error {
 		return fmt.Errorf("parser not found for format")
 	}
 
-	data, err := configFormat.Serialize(conf)
+	err := l.saveUserEntries(conf)
+	if err != nil {
+		return err
+	}
+
+	data, err := configFormat.Serialize(l.withoutUserEntries(conf))
 	if err != nil {
 		return err
 	}
@@ -107,6 +127,10 @@ func (l *Loader) Save(conf *Config, path string) error {
 
 func (l *Loader) loadConfig(confPath string) (*Config, error) {
 	l.LoadedLocations = append(l.LoadedLocations, confPath)
+	return l.parseConfig(confPath)
+}
+
+func (l *Loader) parseConfig(confPath string) (*Config, error) {
 	raw, err := l.loadFile(confPath)
 
 	if err != nil {
@@ -126,13 +150,34 @@ func (l *Loader) loadConfig(confPath string) (*Config, error) {
 //
 // If more than one path is specified, their contents are merged
 // together into on configuration object.
+//
+// The user configuration found at UserPath is merged beneath the loaded configuration, which gives
+// the precedence: command flags, the configuration files from the last to the first, the user configuration
+// and at last the built-in defaults. Accounts and networks of the user configuration are only added if the
+// configuration doesn't define them, an explorer is only added to networks without one and defaults only
+// set the values the configuration doesn't set. Merged accounts and networks are saved back to the user
+// configuration, merged explorers and defaults are only saved to the configuration files if they are changed.
 func (l *Loader) Load(paths []string) (*Config, error) {
+	conf, err := l.load(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.mergeUserConfig(conf, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.postprocess(conf)
+}
+
+func (l *Loader) load(paths []string) (*Config, error) {
 	// special case for default configs
 	// try to load local config and only if not found try to load global config
 	if IsDefaultPath(paths) {
 		conf, err := l.loadConfig(DefaultPath)
 		if err == nil { // if we could load it then process it
-			return l.postprocess(conf)
+			return conf, nil
 		}
 		if !errors.Is(err, ErrDoesNotExist) {
 			return nil, err
@@ -142,7 +187,7 @@ func (l *Loader) Load(paths []string) (*Config, error) {
 		if err != nil {
 			return nil, ErrDoesNotExist
 		} else {
-			return l.postprocess(conf)
+			return conf, nil
 		}
 	}
 
@@ -166,7 +211,7 @@ func (l *Loader) Load(paths []string) (*Config, error) {
 		return nil, ErrDoesNotExist
 	}
 
-	return l.postprocess(baseConf)
+	return baseConf, nil
 }
 
 // preprocess does all manipulations to the raw configuration format happens here.
@@ -200,6 +245,199 @@ func (l *Loader) composeConfig(baseConf *Config, conf *Config) {
 	for _, deployment := range conf.Deployments {
 		baseConf.Deployments.AddOrUpdate(deployment)
 	}
+	for _, assertion := range conf.Assertions {
+		baseConf.Assertions.AddOrUpdate(assertion)
+	}
+	for _, script := range conf.Scripts {
+		baseConf.Scripts.AddOrUpdate(script)
+	}
+	for _, pool := range conf.Payers {
+		baseConf.Payers.AddOrUpdate(pool)
+	}
+	if conf.Defaults.Signer != "" {
+		baseConf.Defaults.Signer = conf.Defaults.Signer
+	}
+	if conf.Defaults.Output != "" {
+		baseConf.Defaults.Output = conf.Defaults.Output
+	}
+}
+
+// mergeUserConfig merges the user configuration beneath the configuration, unless it's one of the loaded paths.
+//
+// Relative key file paths of the user accounts are resolved relative to the user configuration directory.
+func (l *Loader) mergeUserConfig(conf *Config, paths []string) error {
+	l.user = userEntries{
+		accounts:  make(map[string]Account),
+		networks:  make(map[string]Network),
+		explorers: make(map[string]string),
+	}
+
+	userPath := UserPath()
+	if userPath == "" || slices.Contains(paths, userPath) {
+		return nil
+	}
+
+	userConf, err := l.parseConfig(userPath)
+	if errors.Is(err, ErrDoesNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load user configuration %s: %w", userPath, err)
+	}
+	l.user.path = userPath
+
+	for _, account := range userConf.Accounts {
+		if _, err := conf.Accounts.ByName(account.Name); err == nil {
+			continue
+		}
+		account.Key = resolveKeyPaths(account.Key, filepath.Dir(userPath))
+		conf.Accounts.AddOrUpdate(account.Name, account)
+		l.user.accounts[account.Name] = account
+	}
+
+	for _, network := range userConf.Networks {
+		existing, err := conf.Networks.ByName(network.Name)
+		if err != nil {
+			conf.Networks.AddOrUpdate(network)
+			l.user.networks[network.Name] = network
+			continue
+		}
+		if existing.Explorer == "" && network.Explorer != "" {
+			existing.Explorer = network.Explorer
+			conf.Networks.AddOrUpdate(*existing)
+			l.user.explorers[network.Name] = network.Explorer
+		}
+	}
+
+	if conf.Defaults.Signer == "" && userConf.Defaults.Signer != "" {
+		conf.Defaults.Signer = userConf.Defaults.Signer
+		l.user.signer = userConf.Defaults.Signer
+	}
+	if conf.Defaults.Output == "" && userConf.Defaults.Output != "" {
+		conf.Defaults.Output = userConf.Defaults.Output
+		l.user.output = userConf.Defaults.Output
+	}
+
+	return nil
+}
+
+// resolveKeyPaths resolves the relative key file paths to the directory of the configuration declaring the key.
+func resolveKeyPaths(key AccountKey, dir string) AccountKey {
+	if key.Location != "" && !filepath.IsAbs(key.Location) {
+		key.Location = filepath.Join(dir, key.Location)
+	}
+	if key.PasswordFile != "" && !filepath.IsAbs(key.PasswordFile) {
+		key.PasswordFile = filepath.Join(dir, key.PasswordFile)
+	}
+
+	return key
+}
+
+// saveUserEntries saves the changes to the accounts and networks merged from the user configuration back to
+// the user configuration, the file is only written if any of them changed or was removed.
+func (l *Loader) saveUserEntries(conf *Config) error {
+	if len(l.user.accounts) == 0 && len(l.user.networks) == 0 {
+		return nil
+	}
+
+	configFormat := l.configParsers.FindForFormat(filepath.Ext(l.user.path))
+	if configFormat == nil {
+		return fmt.Errorf("parser not found for config: %s", l.user.path)
+	}
+
+	userConf, err := l.parseConfig(l.user.path)
+	if err != nil {
+		return fmt.Errorf("failed to load user configuration %s: %w", l.user.path, err)
+	}
+
+	current, err := configFormat.Serialize(userConf)
+	if err != nil {
+		return err
+	}
+
+	for name := range l.user.accounts {
+		account, err := conf.Accounts.ByName(name)
+		if err != nil {
+			userConf.Accounts.Remove(name)
+			continue
+		}
+
+		updated := *account
+		if original, err := userConf.Accounts.ByName(name); err == nil {
+			updated.Key = unresolveKeyPaths(updated.Key, original.Key, filepath.Dir(l.user.path))
+		}
+		userConf.Accounts.AddOrUpdate(name, updated)
+	}
+
+	for name := range l.user.networks {
+		network, err := conf.Networks.ByName(name)
+		if err != nil {
+			_ = userConf.Networks.Remove(name)
+			continue
+		}
+		userConf.Networks.AddOrUpdate(*network)
+	}
+
+	data, err := configFormat.Serialize(userConf)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(current, data) {
+		return nil
+	}
+
+	err = l.readerWriter.WriteFile(l.user.path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to save user configuration %s: %w", l.user.path, err)
+	}
+
+	return nil
+}
+
+// unresolveKeyPaths restores the key file paths as written in the user configuration if they are unchanged.
+func unresolveKeyPaths(key AccountKey, original AccountKey, dir string) AccountKey {
+	resolved := resolveKeyPaths(original, dir)
+	if key.Location == resolved.Location {
+		key.Location = original.Location
+	}
+	if key.PasswordFile == resolved.PasswordFile {
+		key.PasswordFile = original.PasswordFile
+	}
+
+	return key
+}
+
+// withoutUserEntries returns a copy of the configuration without the entries merged from the user configuration,
+// a merged explorer or default is kept if it was changed.
+func (l *Loader) withoutUserEntries(conf *Config) *Config {
+	stripped := *conf
+
+	stripped.Accounts = make(Accounts, 0, len(conf.Accounts))
+	for _, account := range conf.Accounts {
+		if _, ok := l.user.accounts[account.Name]; !ok {
+			stripped.Accounts = append(stripped.Accounts, account)
+		}
+	}
+
+	stripped.Networks = make(Networks, 0, len(conf.Networks))
+	for _, network := range conf.Networks {
+		if _, ok := l.user.networks[network.Name]; ok {
+			continue
+		}
+		if explorer, ok := l.user.explorers[network.Name]; ok && network.Explorer == explorer {
+			network.Explorer = ""
+		}
+		stripped.Networks = append(stripped.Networks, network)
+	}
+
+	if l.user.signer != "" && stripped.Defaults.Signer == l.user.signer {
+		stripped.Defaults.Signer = ""
+	}
+	if l.user.output != "" && stripped.Defaults.Output == l.user.output {
+		stripped.Defaults.Output = ""
+	}
+
+	return &stripped
 }
 
 // loadFile simple file loader.
@@ -20,8 +20,11 @@ package config_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/onflow/flow-go-sdk"
+
 	"github.com/onflow/flow-cli/flowkit/config"
 
 	"github.com/spf13/afero"
@@ -317,6 +320,163 @@ func Test_ComposeJSONOverwrite(t *testing.T) {
 	assert.Equal(t, "0x3335dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7", account.Key.PrivateKey.String())
 }
 
+func Test_MergeUserConfig(t *testing.T) {
+	b := []byte(`{
+		"networks": {
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"defaults": {
+			"output": "json"
+		}
+	}`)
+
+	user := []byte(`{
+		"networks": {
+			"testnet": {
+				"host": "access.testnet.nodes.onflow.org:9000",
+				"explorer": "https://testnet.flowdiver.io/{type}/{id}"
+			},
+			"private": "127.0.0.1:4569"
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f1d6e0586b0a20c7",
+				"key": "3335dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			},
+			"alice": {
+				"address": "01cf0e2f2f715450",
+				"key": "3335dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"defaults": {
+			"signer": "alice",
+			"output": "inline"
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "flow.json", b, 0644))
+	require.NoError(t, afero.WriteFile(mockFS, config.UserPath(), user, 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+
+	conf, err := composer.Load(config.DefaultPaths())
+	require.NoError(t, err)
+
+	assert.Len(t, conf.Accounts, 2)
+	account, err := conf.Accounts.ByName("emulator-account")
+	require.NoError(t, err)
+	assert.Equal(t, "f8d6e0586b0a20c7", account.Address.String())
+	_, err = conf.Accounts.ByName("alice")
+	assert.NoError(t, err)
+
+	testnet, err := conf.Networks.ByName("testnet")
+	require.NoError(t, err)
+	assert.Equal(t, "access.devnet.nodes.onflow.org:9000", testnet.Host)
+	assert.Equal(t, "https://testnet.flowdiver.io/{type}/{id}", testnet.Explorer)
+	_, err = conf.Networks.ByName("private")
+	assert.NoError(t, err)
+
+	assert.Equal(t, config.Defaults{Signer: "alice", Output: "json"}, conf.Defaults)
+
+	require.NoError(t, composer.Save(conf, "flow.json"))
+	saved, err := afero.ReadFile(mockFS, "flow.json")
+	require.NoError(t, err)
+	assert.NotContains(t, string(saved), "alice")
+	assert.NotContains(t, string(saved), "private")
+	assert.NotContains(t, string(saved), "flowdiver")
+	assert.Contains(t, string(saved), `"output": "json"`)
+	savedUser, err := afero.ReadFile(mockFS, config.UserPath())
+	require.NoError(t, err)
+	assert.Equal(t, user, savedUser)
+
+	alice, err := conf.Accounts.ByName("alice")
+	require.NoError(t, err)
+	alice.Address = flow.HexToAddress("179b6b1cb6755e31")
+	conf.Accounts.AddOrUpdate("alice", *alice)
+	require.NoError(t, conf.Networks.Remove("private"))
+	conf.Defaults.Signer = "emulator-account"
+
+	require.NoError(t, composer.Save(conf, "flow.json"))
+	saved, err = afero.ReadFile(mockFS, "flow.json")
+	require.NoError(t, err)
+	assert.NotContains(t, string(saved), "179b6b1cb6755e31")
+	assert.Contains(t, string(saved), `"signer": "emulator-account"`)
+
+	userConf, err := composer.Load([]string{config.UserPath()})
+	require.NoError(t, err)
+	alice, err = userConf.Accounts.ByName("alice")
+	require.NoError(t, err)
+	assert.Equal(t, "179b6b1cb6755e31", alice.Address.String())
+	_, err = userConf.Networks.ByName("private")
+	assert.Error(t, err)
+	assert.Equal(t, "alice", userConf.Defaults.Signer)
+}
+
+func Test_UserConfigKeyFiles(t *testing.T) {
+	user := []byte(`{
+		"accounts": {
+			"alice": {
+				"address": "01cf0e2f2f715450",
+				"key": {
+					"type": "file",
+					"location": "alice.pkey"
+				}
+			}
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "flow.json", []byte(`{}`), 0644))
+	require.NoError(t, afero.WriteFile(mockFS, config.UserPath(), user, 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+
+	conf, err := composer.Load([]string{"flow.json"})
+	require.NoError(t, err)
+
+	alice, err := conf.Accounts.ByName("alice")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(filepath.Dir(config.UserPath()), "alice.pkey"), alice.Key.Location)
+
+	alice.Key.Index = 1
+	conf.Accounts.AddOrUpdate("alice", *alice)
+	require.NoError(t, composer.Save(conf, "flow.json"))
+
+	userConf, err := composer.Load([]string{config.UserPath()})
+	require.NoError(t, err)
+	alice, err = userConf.Accounts.ByName("alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice.pkey", alice.Key.Location)
+	assert.Equal(t, 1, alice.Key.Index)
+}
+
+func Test_UserConfigInvalidDefaultSigner(t *testing.T) {
+	user := []byte(`{
+		"defaults": {
+			"signer": "bob"
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "flow.json", []byte(`{}`), 0644))
+	require.NoError(t, afero.WriteFile(mockFS, config.UserPath(), user, 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+
+	_, err := composer.Load([]string{"flow.json"})
+	assert.EqualError(t, err, "default signer bob is not an account in the configuration")
+}
+
 func Test_JSONEnv(t *testing.T) {
 	b := []byte(`{
 		"accounts": {
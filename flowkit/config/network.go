@@ -19,7 +19,11 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
 )
 
 var (
@@ -52,9 +56,154 @@ type Networks []Network
 
 // Network defines the configuration for a Flow network.
 type Network struct {
-	Name string
-	Host string
-	Key  string
+	Name       string
+	Host       string
+	Key        string
+	Production bool         // production networks never get test-only deployments
+	Auth       *NetworkAuth // credentials sent to access nodes of providers requiring authenticated requests
+	Explorer   string       // block explorer URL template with {type} and {id} placeholders, see ExplorerURL
+}
+
+// ExplorerLinkType is the type of the entity linked on a block explorer, replacing the {type} placeholder.
+type ExplorerLinkType string
+
+const (
+	ExplorerTransaction ExplorerLinkType = "tx"
+	ExplorerAccount     ExplorerLinkType = "account"
+	ExplorerBlock       ExplorerLinkType = "block"
+)
+
+// DefaultExplorers are the block explorer URL templates of networks not configuring an explorer.
+var DefaultExplorers = map[string]string{
+	MainnetNetwork.Name: "https://flowscan.io/{type}/{id}",
+	TestnetNetwork.Name: "https://testnet.flowscan.io/{type}/{id}",
+}
+
+// ValidateExplorer checks the block explorer URL template contains the {id} placeholder.
+func ValidateExplorer(explorer string) error {
+	if !strings.Contains(explorer, "{id}") {
+		return fmt.Errorf("explorer URL template %s must contain the {id} placeholder", explorer)
+	}
+	return nil
+}
+
+// ExplorerURL returns the link to the entity on the block explorer of the network, or an empty
+// string if the network has no explorer, which is the case for the emulator.
+func (n Network) ExplorerURL(linkType ExplorerLinkType, id string) string {
+	explorer := n.Explorer
+	if explorer == "" {
+		explorer = DefaultExplorers[n.Name]
+	}
+	if explorer == "" {
+		return ""
+	}
+
+	return strings.NewReplacer("{type}", string(linkType), "{id}", id).Replace(explorer)
+}
+
+// DefaultAPIKeyHeader is the header carrying the API key if the network doesn't configure one.
+const DefaultAPIKeyHeader = "x-api-key"
+
+// NetworkAuth defines the credentials sent with every request to the access node of a network.
+//
+// Exactly one of the API key, the bearer token or the basic authentication username and password is set.
+// Values can reference environment variables as $NAME or ${NAME}, so secrets don't have to be stored in the
+// configuration, the references are kept in the configuration and only expanded by Headers.
+type NetworkAuth struct {
+	Header   string // header carrying the API key, DefaultAPIKeyHeader if empty
+	APIKey   string
+	Token    string
+	Username string
+	Password string
+}
+
+// Validate checks exactly one authentication method is configured.
+func (a NetworkAuth) Validate() error {
+	methods := 0
+	if a.APIKey != "" {
+		methods++
+	}
+	if a.Token != "" {
+		methods++
+	}
+	if a.Username != "" || a.Password != "" {
+		if a.Username == "" || a.Password == "" {
+			return fmt.Errorf("basic authentication requires both the username and the password")
+		}
+		methods++
+	}
+
+	if methods != 1 {
+		return fmt.Errorf("configure exactly one of the API key, the token or the username and password")
+	}
+	if a.Header != "" && a.APIKey == "" {
+		return fmt.Errorf("header can only be configured with an API key")
+	}
+
+	return nil
+}
+
+var envReference = regexp.MustCompile(`^\$\{(\w+)\}$|^\$(\w+)$`)
+
+// expandEnv returns the value of the environment variable referenced by the value, or the value itself.
+func expandEnv(value string) (string, error) {
+	found := envReference.FindStringSubmatch(value)
+	if found == nil {
+		return value, nil
+	}
+
+	name := found[1] + found[2]
+	expanded := os.Getenv(name)
+	if expanded == "" {
+		return "", fmt.Errorf("required environment variable %s not set", name)
+	}
+
+	return expanded, nil
+}
+
+// Headers returns the headers authenticating requests, with the environment variables referenced by the values expanded.
+//
+// Header names are lowercase, as required for gRPC metadata and accepted by HTTP.
+func (a NetworkAuth) Headers() (map[string]string, error) {
+	err := a.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case a.APIKey != "":
+		key, err := expandEnv(a.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		header := a.Header
+		if header == "" {
+			header = DefaultAPIKeyHeader
+		}
+		return map[string]string{strings.ToLower(header): key}, nil
+	case a.Token != "":
+		token, err := expandEnv(a.Token)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"authorization": "Bearer " + token}, nil
+	default:
+		username, err := expandEnv(a.Username)
+		if err != nil {
+			return nil, err
+		}
+		password, err := expandEnv(a.Password)
+		if err != nil {
+			return nil, err
+		}
+		credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		return map[string]string{"authorization": "Basic " + credentials}, nil
+	}
+}
+
+// IsProduction checks if the network is marked as production, mainnet always is.
+func (n Network) IsProduction() bool {
+	return n.Production || n.Name == MainnetNetwork.Name
 }
 
 // ByName get network by name or return an error if not found.
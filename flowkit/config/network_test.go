@@ -77,3 +77,57 @@ func TestNetworks_Remove(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, "network named flow-mainnet does not exist in configuration")
 }
+
+func TestNetworkAuth_Headers(t *testing.T) {
+	t.Run("API key", func(t *testing.T) {
+		headers, err := NetworkAuth{APIKey: "secret"}.Headers()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"x-api-key": "secret"}, headers)
+
+		headers, err = NetworkAuth{Header: "X-Token", APIKey: "secret"}.Headers()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"x-token": "secret"}, headers)
+	})
+
+	t.Run("Bearer token from environment", func(t *testing.T) {
+		t.Setenv("ACCESS_TOKEN", "secret")
+
+		headers, err := NetworkAuth{Token: "${ACCESS_TOKEN}"}.Headers()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"authorization": "Bearer secret"}, headers)
+	})
+
+	t.Run("Basic", func(t *testing.T) {
+		headers, err := NetworkAuth{Username: "alice", Password: "secret"}.Headers()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"authorization": "Basic YWxpY2U6c2VjcmV0"}, headers)
+	})
+
+	t.Run("Fail environment variable not set", func(t *testing.T) {
+		_, err := NetworkAuth{Token: "$MISSING_ACCESS_TOKEN"}.Headers()
+		assert.EqualError(t, err, "required environment variable MISSING_ACCESS_TOKEN not set")
+	})
+
+	t.Run("Fail invalid", func(t *testing.T) {
+		err := NetworkAuth{APIKey: "secret", Token: "secret"}.Validate()
+		assert.EqualError(t, err, "configure exactly one of the API key, the token or the username and password")
+
+		err = NetworkAuth{Username: "alice"}.Validate()
+		assert.EqualError(t, err, "basic authentication requires both the username and the password")
+
+		err = NetworkAuth{Header: "x-token", Token: "secret"}.Validate()
+		assert.EqualError(t, err, "header can only be configured with an API key")
+	})
+}
+
+func TestNetwork_ExplorerURL(t *testing.T) {
+	assert.Equal(t, "https://testnet.flowscan.io/tx/abc", TestnetNetwork.ExplorerURL(ExplorerTransaction, "abc"))
+	assert.Equal(t, "https://flowscan.io/account/0x01", MainnetNetwork.ExplorerURL(ExplorerAccount, "0x01"))
+	assert.Equal(t, "", EmulatorNetwork.ExplorerURL(ExplorerBlock, "abc"))
+
+	network := Network{Name: "previewnet", Explorer: "https://explorer.example/{type}s/{id}?network=previewnet"}
+	assert.Equal(t, "https://explorer.example/blocks/abc?network=previewnet", network.ExplorerURL(ExplorerBlock, "abc"))
+
+	assert.NoError(t, ValidateExplorer(network.Explorer))
+	assert.EqualError(t, ValidateExplorer("https://explorer.example"), "explorer URL template https://explorer.example must contain the {id} placeholder")
+}
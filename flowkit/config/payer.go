@@ -0,0 +1,71 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+)
+
+// PayerPool defines the accounts paying for the transactions sent to a network in rotation.
+type PayerPool struct {
+	Network  string
+	Accounts []string
+}
+
+type PayerPools []PayerPool
+
+// ByNetwork get the payer pool of the network or nil if the network has no pool.
+func (p *PayerPools) ByNetwork(network string) *PayerPool {
+	for i, pool := range *p {
+		if pool.Network == network {
+			return &(*p)[i]
+		}
+	}
+
+	return nil
+}
+
+// AddOrUpdate add new or update if already present.
+func (p *PayerPools) AddOrUpdate(pool PayerPool) {
+	for i, existing := range *p {
+		if existing.Network == pool.Network {
+			(*p)[i] = pool
+			return
+		}
+	}
+
+	*p = append(*p, pool)
+}
+
+// Validate checks the pool contains at least one account and no account more than once.
+func (p PayerPool) Validate() error {
+	if len(p.Accounts) == 0 {
+		return fmt.Errorf("payer pool for network %s contains no accounts", p.Network)
+	}
+
+	seen := make(map[string]bool, len(p.Accounts))
+	for _, account := range p.Accounts {
+		if seen[account] {
+			return fmt.Errorf("payer pool for network %s contains account %s more than once", p.Network, account)
+		}
+		seen[account] = true
+	}
+
+	return nil
+}
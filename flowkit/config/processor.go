@@ -30,6 +30,10 @@ func processorRun(raw []byte) []byte {
 		Networks    any                       `json:"networks,omitempty"`
 		Deployments any                       `json:"deployments,omitempty"`
 		Emulators   any                       `json:"emulators,omitempty"`
+		Assertions  any                       `json:"assertions,omitempty"`
+		Scripts     any                       `json:"scripts,omitempty"`
+		Payers      any                       `json:"payers,omitempty"`
+		Defaults    any                       `json:"defaults,omitempty"`
 	}
 
 	var conf config
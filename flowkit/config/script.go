@@ -0,0 +1,54 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+)
+
+// Script defines a named script and the file containing its code.
+type Script struct {
+	Name     string
+	Filename string
+}
+
+type Scripts []Script
+
+// ByName get script by name or return an error if it doesn't exist.
+func (s *Scripts) ByName(name string) (*Script, error) {
+	for i, script := range *s {
+		if script.Name == name {
+			return &(*s)[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("script %s does not exist", name)
+}
+
+// AddOrUpdate add new or update if already present.
+func (s *Scripts) AddOrUpdate(script Script) {
+	for i, existing := range *s {
+		if existing.Name == script.Name {
+			(*s)[i] = script
+			return
+		}
+	}
+
+	*s = append(*s, script)
+}
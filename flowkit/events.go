@@ -19,6 +19,8 @@
 package flowkit
 
 import (
+	"fmt"
+
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 )
@@ -65,13 +67,69 @@ func NewEvent(event flow.Event) Event {
 	}
 }
 
+// GetCreatedAddresses returns addresses of the account created events, events without an address are skipped.
 func (e *Events) GetCreatedAddresses() []*flow.Address {
 	addresses := make([]*flow.Address, 0)
 	for _, event := range *e {
-		if event.Type == flow.EventAccountCreated {
-			addresses = append(addresses, event.GetAddress())
+		if event.Type != flow.EventAccountCreated {
+			continue
+		}
+		if address := event.GetAddress(); address != nil {
+			addresses = append(addresses, address)
 		}
 	}
 
 	return addresses
 }
+
+// CreatedAccount is an account created by a transaction.
+type CreatedAccount struct {
+	Address       flow.Address
+	TransactionID flow.Identifier
+}
+
+// CreatedAccounts parses the flow.AccountCreated events of the transaction result and returns the
+// created accounts in the order they were emitted, so all accounts created by a transaction are found.
+//
+// An error is returned if the transaction failed or an account created event has no address.
+func CreatedAccounts(result *flow.TransactionResult) ([]CreatedAccount, error) {
+	if result.Error != nil {
+		return nil, fmt.Errorf("transaction failed: %w", result.Error)
+	}
+
+	created := make([]CreatedAccount, 0)
+	for _, event := range result.Events {
+		if event.Type != flow.EventAccountCreated {
+			continue
+		}
+
+		address, err := accountCreatedAddress(event)
+		if err != nil {
+			return nil, err
+		}
+
+		id := event.TransactionID
+		if id == flow.EmptyID {
+			id = result.TransactionID
+		}
+		created = append(created, CreatedAccount{Address: address, TransactionID: id})
+	}
+
+	return created, nil
+}
+
+// accountCreatedAddress reads the address field of the account created event by its name.
+func accountCreatedAddress(event flow.Event) (flow.Address, error) {
+	if event.Value.EventType != nil {
+		for i, field := range event.Value.EventType.Fields {
+			if field.Identifier != "address" || i >= len(event.Value.Fields) {
+				continue
+			}
+			if address, ok := event.Value.Fields[i].(cadence.Address); ok {
+				return flow.Address(address), nil
+			}
+		}
+	}
+
+	return flow.EmptyAddress, fmt.Errorf("%s event at index %d has no address field", event.Type, event.EventIndex)
+}
@@ -50,6 +50,39 @@ func Test_AccountCreatedEvent(t *testing.T) {
 	assert.Equal(t, `flow.AccountCreated(address: 0x00c4fef62310c807)`, flowEvent.Value.String())
 }
 
+func Test_CreatedAccounts(t *testing.T) {
+	first := flow.HexToAddress("01")
+	second := flow.HexToAddress("02")
+	fields := []cadence.Field{{Identifier: "address", Type: cadence.AddressType{}}}
+
+	t.Run("Success multiple", func(t *testing.T) {
+		tx := tests.NewTransactionResult([]flow.Event{
+			*tests.NewEvent(0, flow.EventAccountCreated, fields, []cadence.Value{cadence.NewAddress(first)}),
+			*tests.NewEvent(1, "A.01.Foo.Bar", fields, []cadence.Value{cadence.NewAddress(first)}),
+			*tests.NewEvent(2, flow.EventAccountCreated, fields, []cadence.Value{cadence.NewAddress(second)}),
+		})
+		tx.TransactionID = flow.HexToID("0a")
+
+		created, err := flowkit.CreatedAccounts(tx)
+		assert.NoError(t, err)
+		assert.Equal(t, []flowkit.CreatedAccount{
+			{Address: first, TransactionID: flow.HexToID("0a")},
+			{Address: second, TransactionID: flow.HexToID("0a")},
+		}, created)
+	})
+
+	t.Run("Fail missing address", func(t *testing.T) {
+		tx := tests.NewTransactionResult([]flow.Event{
+			*tests.NewEvent(0, flow.EventAccountCreated, []cadence.Field{{Identifier: "account", Type: cadence.AddressType{}}}, []cadence.Value{cadence.NewAddress(first)}),
+		})
+
+		_, err := flowkit.CreatedAccounts(tx)
+		assert.EqualError(t, err, "flow.AccountCreated event at index 0 has no address field")
+		events := flowkit.EventsFromTransaction(tx)
+		assert.Len(t, events.GetCreatedAddresses(), 0)
+	})
+}
+
 func TestAddress(t *testing.T) {
 	address := flow.HexToAddress("cdfef0f4f0786e9")
 	assert.Equal(t, "0cdfef0f4f0786e9", address.String())
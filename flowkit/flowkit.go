@@ -23,6 +23,7 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,6 +34,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/templates"
 	"github.com/pkg/errors"
 	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/exp/maps"
@@ -102,14 +104,48 @@ func NewFlowkit(
 	gateway gateway.Gateway,
 	logger output.Logger,
 ) *Flowkit {
-	return &Flowkit{state, network, gateway, logger}
+	return &Flowkit{
+		state:   state,
+		network: network,
+		gateway: gateway,
+		logger:  logger,
+	}
+}
+
+// LoadProject loads the project configuration from the paths and returns services connected to the
+// network with the name, which lets Go tools embed the project workflows of the CLI. The gateway is secured
+// when the network is configured with a key.
+func LoadProject(
+	configPaths []string,
+	networkName string,
+	readerWriter ReaderWriter,
+	logger output.Logger,
+) (*Flowkit, error) {
+	state, err := Load(configPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := state.Networks().ByName(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	gw, err := gateway.NewNetworkGateway(*network)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFlowkit(state, *network, gw, logger), nil
 }
 
 type Flowkit struct {
-	state   *State
-	network config.Network
-	gateway gateway.Gateway
-	logger  output.Logger
+	state      *State
+	network    config.Network
+	gateway    gateway.Gateway
+	logger     output.Logger
+	buildCache *BuildCache
+	imports    project.ImportOptions
 }
 
 func (f *Flowkit) Network() config.Network {
@@ -124,6 +160,16 @@ func (f *Flowkit) SetLogger(logger output.Logger) {
 	f.logger = logger
 }
 
+// SetBuildCache sets the cache used for contracts with resolved imports, a nil cache disables caching.
+func (f *Flowkit) SetBuildCache(cache *BuildCache) {
+	f.buildCache = cache
+}
+
+// SetImportOptions overrides how imports are resolved, imports can be resolved to a fixed address or ignored.
+func (f *Flowkit) SetImportOptions(options project.ImportOptions) {
+	f.imports = options
+}
+
 func (f *Flowkit) State() (*State, error) {
 	if f.state == nil {
 		return nil, config.ErrDoesNotExist
@@ -140,14 +186,40 @@ func (f *Flowkit) GetAccount(_ context.Context, address flow.Address) (*flow.Acc
 	return f.gateway.GetAccount(address)
 }
 
+// GetAccountAtBlockHeight fetches account on the Flow network as it was at the block height.
+func (f *Flowkit) GetAccountAtBlockHeight(_ context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return f.gateway.GetAccountAtBlockHeight(address, height)
+}
+
+// BeforeSendHook is called with the signed transaction before it's sent, an error aborts sending it.
+type BeforeSendHook func(tx *transactions.Transaction) error
+
+type beforeSendHookKey struct{}
+
+// WithBeforeSendHook returns a context calling the hook with the signed account creation transaction
+// before CreateAccount sends it, e.g. to record the transaction ID.
+func WithBeforeSendHook(ctx context.Context, hook BeforeSendHook) context.Context {
+	return context.WithValue(ctx, beforeSendHookKey{}, hook)
+}
+
+// BeforeSend calls the hook of the context with the signed transaction, implementations of Services call it
+// before sending the transaction.
+func BeforeSend(ctx context.Context, tx *transactions.Transaction) error {
+	if hook, ok := ctx.Value(beforeSendHookKey{}).(BeforeSendHook); ok {
+		return hook(tx)
+	}
+	return nil
+}
+
 // CreateAccount on the Flow network with the provided keys and using the signer for creation transaction.
 // Returns the newly created account as well as the ID of the transaction that created the account.
 //
 // Keys is a slice but only one can be passed as well. If the transaction fails or there are other issues an error is returned.
 func (f *Flowkit) CreateAccount(
-	_ context.Context,
+	ctx context.Context,
 	signer *accounts.Account,
 	keys []accounts.PublicKey,
+	contracts ...Script,
 ) (*flow.Account, flow.Identifier, error) {
 	var accKeys []*flow.AccountKey
 	for _, k := range keys {
@@ -170,7 +242,23 @@ func (f *Flowkit) CreateAccount(
 		accKeys = append(accKeys, accKey)
 	}
 
-	tx, err := transactions.NewCreateAccount(signer, accKeys, nil)
+	var deployContracts []templates.Contract
+	if len(contracts) > 0 {
+		state, err := f.State()
+		if err != nil {
+			return nil, flow.EmptyID, err
+		}
+
+		for _, contract := range contracts {
+			name, code, err := f.resolveContract(state, contract)
+			if err != nil {
+				return nil, flow.EmptyID, err
+			}
+			deployContracts = append(deployContracts, templates.Contract{Name: name, Source: string(code)})
+		}
+	}
+
+	tx, err := transactions.NewCreateAccount(signer, accKeys, deployContracts)
 	if err != nil {
 		return nil, flow.EmptyID, err
 	}
@@ -180,6 +268,10 @@ func (f *Flowkit) CreateAccount(
 		return nil, flow.EmptyID, err
 	}
 
+	if err := BeforeSend(ctx, tx); err != nil {
+		return nil, flow.EmptyID, err
+	}
+
 	f.logger.Info(fmt.Sprintf("Transaction ID: %s", tx.FlowTransaction().ID()))
 	f.logger.StartProgress("Creating account...")
 	defer f.logger.StopProgress()
@@ -201,13 +293,15 @@ func (f *Flowkit) CreateAccount(
 		return nil, flow.EmptyID, result.Error
 	}
 
-	events := EventsFromTransaction(result)
-	newAccountAddress := events.GetCreatedAddresses()
-	if len(newAccountAddress) == 0 {
+	created, err := CreatedAccounts(result)
+	if err != nil {
+		return nil, flow.EmptyID, err
+	}
+	if len(created) == 0 {
 		return nil, flow.EmptyID, fmt.Errorf("new account address couldn't be fetched")
 	}
 
-	account, err := f.gateway.GetAccount(*newAccountAddress[0]) // we know it's the only and first event
+	account, err := f.gateway.GetAccount(created[0].Address) // the transaction creates a single account
 	if err != nil {
 		return nil, flow.EmptyID, err
 	}
@@ -244,7 +338,8 @@ func (f *Flowkit) prepareTransaction(
 	return tx, nil
 }
 
-var errUpdateNoDiff = errors.New("contract already exists and is the same as the contract provided for update")
+// ErrUpdateNoDiff is returned when adding a contract that already exists on the account with the same code.
+var ErrUpdateNoDiff = errors.New("contract already exists and is the same as the contract provided for update")
 
 type UpdateContract func(existing []byte, new []byte) bool
 
@@ -270,29 +365,7 @@ func (f *Flowkit) AddContract(
 		return flow.EmptyID, false, err
 	}
 
-	program, err := project.NewProgram(contract.Code, contract.Args, contract.Location)
-	if err != nil {
-		return flow.EmptyID, false, err
-	}
-
-	if program.HasImports() {
-		contracts, err := state.DeploymentContractsByNetwork(f.network)
-		if err != nil {
-			return flow.EmptyID, false, err
-		}
-
-		importReplacer := project.NewImportReplacer(
-			contracts,
-			state.AliasesForNetwork(f.network),
-		)
-
-		program, err = importReplacer.Replace(program)
-		if err != nil {
-			return flow.EmptyID, false, err
-		}
-	}
-
-	name, err := program.Name()
+	name, code, err := f.resolveContract(state, contract)
 	if err != nil {
 		return flow.EmptyID, false, err
 	}
@@ -300,7 +373,7 @@ func (f *Flowkit) AddContract(
 	tx, err := transactions.NewAddAccountContract(
 		account,
 		name,
-		program.Code(),
+		code,
 		contract.Args,
 	)
 	if err != nil {
@@ -315,19 +388,19 @@ func (f *Flowkit) AddContract(
 		return flow.EmptyID, false, err
 	}
 	existingContract, exists := flowAccount.Contracts[name]
-	noDiffInContract := bytes.Equal(program.Code(), existingContract)
+	noDiffInContract := bytes.Equal(code, existingContract)
 
 	if exists && noDiffInContract {
-		return flow.EmptyID, false, errUpdateNoDiff
+		return flow.EmptyID, false, ErrUpdateNoDiff
 	}
 
-	updateExisting := update(existingContract, program.Code())
+	updateExisting := update(existingContract, code)
 	if exists && !updateExisting {
 		return flow.EmptyID, false, fmt.Errorf(fmt.Sprintf("contract %s exists in account %s", name, account.Name))
 	}
 
 	if exists && updateExisting {
-		tx, err = transactions.NewUpdateAccountContract(account, name, program.Code())
+		tx, err = transactions.NewUpdateAccountContract(account, name, code)
 		if err != nil {
 			return flow.EmptyID, false, err
 		}
@@ -377,6 +450,91 @@ func (f *Flowkit) AddContract(
 	return sentTx.ID(), updateExisting, err
 }
 
+// resolveContract returns the contract name and the contract code with imports replaced by addresses.
+//
+// If the build cache is set, the result is loaded from the cache when the contract and the addresses
+// the imports resolve to didn't change, otherwise it is stored in the cache after resolving.
+func (f *Flowkit) resolveContract(state *State, contract Script) (string, []byte, error) {
+	var cacheKey string
+	if f.buildCache != nil {
+		contracts, err := state.DeploymentContractsByNetwork(f.network)
+		if err != nil {
+			return "", nil, err
+		}
+
+		cacheKey = f.buildCache.key(contract, f.network, state.AliasesForNetwork(f.network), contracts, f.imports)
+		if entry, ok := f.buildCache.get(cacheKey); ok {
+			return entry.Name, []byte(entry.Code), nil
+		}
+	}
+
+	program, err := project.NewProgram(contract.Code, contract.Args, contract.Location)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if program.HasImports() {
+		program, err = f.replaceImports(state, program)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	name, err := program.Name()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if f.buildCache != nil {
+		err = f.buildCache.put(cacheKey, buildCacheEntry{Name: name, Code: string(program.Code())})
+		if err != nil {
+			f.logger.Debug(fmt.Sprintf("failed to store contract %s in build cache: %s", name, err))
+		}
+	}
+
+	return name, program.Code(), nil
+}
+
+// replaceImports replaces imports in the program with the addresses the imported contracts are deployed to
+// or aliased on the selected network.
+//
+// Imports of contracts present in the configuration but neither deployed nor aliased on the network
+// result in an error naming the contract and the network. Without a configuration only the imports
+// resolved by the import options are replaced.
+func (f *Flowkit) replaceImports(state *State, program *project.Program) (*project.Program, error) {
+	var contracts []*project.Contract
+	var aliases project.LocationAliases
+	if state != nil {
+		var err error
+		contracts, err = state.DeploymentContractsByNetwork(f.network)
+		if err != nil {
+			return nil, err
+		}
+		aliases = state.AliasesForNetwork(f.network)
+	}
+
+	importReplacer := project.NewImportReplacer(contracts, aliases).WithOptions(f.imports)
+
+	replaced, err := importReplacer.Replace(program)
+	var unresolved *project.UnresolvedImportError
+	if errors.As(err, &unresolved) {
+		if state == nil {
+			return nil, config.ErrDoesNotExist
+		}
+		for _, contract := range *state.Contracts() {
+			if contract.Name == unresolved.Import || path.Clean(contract.Location) == unresolved.Location {
+				return nil, fmt.Errorf(
+					"contract %s is not deployed or aliased on the %s network, add a deployment or an alias for it to the configuration",
+					contract.Name,
+					f.network.Name,
+				)
+			}
+		}
+	}
+
+	return replaced, err
+}
+
 // RemoveContract from the provided account by its name.
 //
 // If removal is successful transaction ID is returned.
@@ -446,7 +604,7 @@ func (f *Flowkit) GetBlock(_ context.Context, query BlockQuery) (*flow.Block, er
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("error fetching block: %s", err.Error())
+		return nil, fmt.Errorf("error fetching block: %w", err)
 	}
 
 	if block == nil {
@@ -728,7 +886,7 @@ func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*
 			Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()},
 			update,
 		)
-		if err != nil && errors.Is(err, errUpdateNoDiff) {
+		if err != nil && errors.Is(err, ErrUpdateNoDiff) {
 			f.logger.Info(fmt.Sprintf(
 				"%s -> 0x%s [skipping, no changes found]",
 				output.Italic(contract.Name),
@@ -747,6 +905,7 @@ func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*
 			txID.String(),
 			map[bool]string{true: "[updated]", false: ""}[updated],
 		))
+		contract.TransactionID = txID
 	}
 
 	if len(deployErr.contracts) > 0 {
@@ -803,17 +962,7 @@ func (f *Flowkit) ExecuteScript(_ context.Context, script Script, query ScriptQu
 	}
 
 	if program.HasImports() {
-		contracts, err := state.DeploymentContractsByNetwork(f.network)
-		if err != nil {
-			return nil, err
-		}
-
-		importReplacer := project.NewImportReplacer(
-			contracts,
-			state.AliasesForNetwork(f.network),
-		)
-
-		if state == nil {
+		if state == nil && f.imports.IsEmpty() {
 			return nil, config.ErrDoesNotExist
 		}
 		if f.network == config.EmptyNetwork {
@@ -823,7 +972,7 @@ func (f *Flowkit) ExecuteScript(_ context.Context, script Script, query ScriptQu
 			return nil, fmt.Errorf("resolving imports in scripts not supported")
 		}
 
-		program, err = importReplacer.Replace(program)
+		program, err = f.replaceImports(state, program)
 		if err != nil {
 			return nil, err
 		}
@@ -919,17 +1068,7 @@ func (f *Flowkit) BuildTransaction(
 			return nil, fmt.Errorf("resolving imports in transactions not supported")
 		}
 
-		contracts, err := state.DeploymentContractsByNetwork(f.network)
-		if err != nil {
-			return nil, err
-		}
-
-		importReplacer := project.NewImportReplacer(
-			contracts,
-			state.AliasesForNetwork(f.network),
-		)
-
-		program, err = importReplacer.Replace(program)
+		program, err = f.replaceImports(state, program)
 		if err != nil {
 			return nil, fmt.Errorf("error resolving imports: %w", err)
 		}
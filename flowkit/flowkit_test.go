@@ -23,6 +23,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -35,6 +36,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -115,6 +117,18 @@ func TestAccounts(t *testing.T) {
 		assert.Equal(t, serviceAddress, account.Address)
 	})
 
+	t.Run("Get an Account at block height", func(t *testing.T) {
+		_, flowkit, gw := setup()
+		gw.Mock.On("GetAccountAtBlockHeight", serviceAddress, uint64(42)).
+			Return(tests.NewAccountWithAddress(serviceAddress.String()), nil)
+
+		account, err := flowkit.GetAccountAtBlockHeight(ctx, serviceAddress, 42)
+
+		gw.Mock.AssertCalled(t, "GetAccountAtBlockHeight", serviceAddress, uint64(42))
+		assert.NoError(t, err)
+		assert.Equal(t, serviceAddress, account.Address)
+	})
+
 	t.Run("Create an Account", func(t *testing.T) {
 		_, flowkit, gw := setup()
 		newAddress := flow.HexToAddress("192440c99cb17282")
@@ -163,6 +177,32 @@ func TestAccounts(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Create an Account aborted before send", func(t *testing.T) {
+		_, flowkit, gw := setup()
+
+		var hooked *transactions.Transaction
+		hookCtx := WithBeforeSendHook(ctx, func(tx *transactions.Transaction) error {
+			hooked = tx
+			return fmt.Errorf("record failed")
+		})
+
+		_, _, err := flowkit.CreateAccount(
+			hookCtx,
+			serviceAcc,
+			[]accounts.PublicKey{{
+				pubKey,
+				flow.AccountKeyWeightThreshold,
+				crypto.ECDSA_P256,
+				crypto.SHA3_256,
+			}},
+		)
+
+		assert.EqualError(t, err, "record failed")
+		require.NotNil(t, hooked)
+		assert.Equal(t, serviceAddress, hooked.FlowTransaction().Payer)
+		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 0)
+	})
+
 	t.Run("Contract Add for Account", func(t *testing.T) {
 		_, flowkit, gw := setup()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
@@ -188,6 +228,44 @@ func TestAccounts(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Contract Resolve with build cache", func(t *testing.T) {
+		state, flowkit, _ := setup()
+		cache := NewBuildCache(DefaultBuildCacheDir, state.ReaderWriter())
+		flowkit.SetBuildCache(cache)
+		contract := resourceToContract(tests.ContractHelloString)
+
+		name, code, err := flowkit.resolveContract(state, contract)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", name)
+
+		key := cache.key(contract, config.EmulatorNetwork, state.AliasesForNetwork(config.EmulatorNetwork), nil, project.ImportOptions{})
+		entry, ok := cache.get(key)
+		require.True(t, ok)
+		assert.Equal(t, buildCacheEntry{Name: name, Code: string(code)}, *entry)
+
+		// a cached entry is used instead of resolving the contract again
+		require.NoError(t, cache.put(key, buildCacheEntry{Name: "Cached", Code: entry.Code}))
+		name, _, err = flowkit.resolveContract(state, contract)
+		require.NoError(t, err)
+		assert.Equal(t, "Cached", name)
+
+		// a different network uses a different cache entry
+		otherKey := cache.key(contract, config.TestnetNetwork, state.AliasesForNetwork(config.TestnetNetwork), nil, project.ImportOptions{})
+		assert.NotEqual(t, key, otherKey)
+
+		// the same source in a different directory resolves relative imports differently
+		moved := contract
+		moved.Location = filepath.Join("other", contract.Location)
+		movedKey := cache.key(moved, config.EmulatorNetwork, state.AliasesForNetwork(config.EmulatorNetwork), nil, project.ImportOptions{})
+		assert.NotEqual(t, key, movedKey)
+
+		// imports resolved by the import options use a different cache entry
+		resolvedKey := cache.key(contract, config.EmulatorNetwork, state.AliasesForNetwork(config.EmulatorNetwork), nil, project.ImportOptions{
+			Resolve: map[string]string{"Foo": "0000000000000001"},
+		})
+		assert.NotEqual(t, key, resolvedKey)
+	})
+
 	t.Run("Contract Remove for Account", func(t *testing.T) {
 		_, flowkit, gw := setup()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
@@ -1351,6 +1429,55 @@ func TestScripts(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Execute Script Import Aliased", func(t *testing.T) {
+		state, flowkit, gw := setup()
+		state.Contracts().AddOrUpdate(config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Aliases: config.Aliases{{
+				Network: config.EmulatorNetwork.Name,
+				Address: flow.HexToAddress("0x01"),
+			}},
+		})
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			assert.Contains(t, string(args.Get(0).([]byte)), "import Hello from 0x0000000000000001")
+			gw.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
+		})
+
+		_, err := flowkit.ExecuteScript(ctx, resourceToContract(tests.ScriptImport), LatestScriptQuery)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Execute Script Import Not On Network", func(t *testing.T) {
+		state, flowkit, _ := setup()
+		state.Contracts().AddOrUpdate(config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Aliases: config.Aliases{{
+				Network: config.TestnetNetwork.Name,
+				Address: flow.HexToAddress("0x01"),
+			}},
+		})
+
+		_, err := flowkit.ExecuteScript(ctx, resourceToContract(tests.ScriptImport), LatestScriptQuery)
+		assert.EqualError(t, err, "contract Hello is not deployed or aliased on the emulator network, add a deployment or an alias for it to the configuration")
+	})
+
+	t.Run("Execute Script Import Resolved By Options", func(t *testing.T) {
+		_, flowkit, gw := setup()
+		flowkit.SetImportOptions(project.ImportOptions{
+			Resolve: map[string]string{"Hello": flow.HexToAddress("0x02").String()},
+		})
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			assert.Contains(t, string(args.Get(0).([]byte)), "import Hello from 0x0000000000000002")
+			gw.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
+		})
+
+		_, err := flowkit.ExecuteScript(ctx, resourceToContract(tests.ScriptImport), LatestScriptQuery)
+		assert.NoError(t, err)
+	})
 }
 
 func TestScripts_Integration(t *testing.T) {
@@ -1593,6 +1720,15 @@ func Test_TransactionRoles(t *testing.T) {
 			signerAddresses: []flow.Address{
 				a.Address, b.Address,
 			},
+		}, {
+			AccountRoles: &transactions.AccountRoles{
+				Proposer:    *a,
+				Payer:       *a,
+				Authorizers: []accounts.Account{*a, *b},
+			},
+			signerAddresses: []flow.Address{
+				b.Address, a.Address,
+			},
 		}, {
 			AccountRoles: &transactions.AccountRoles{
 				Proposer: *a,
@@ -2092,3 +2228,31 @@ func Test_BlockQuery(t *testing.T) {
 	assert.EqualError(t, err, "invalid query: invalid, valid are: \"latest\", block height or block ID")
 
 }
+
+func Test_LoadProject(t *testing.T) {
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := af.WriteFile("flow.json", []byte(`{
+		"networks": {
+			"emulator": "127.0.0.1:3569",
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		}
+	}`), 0644)
+	require.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		flow, err := LoadProject([]string{"flow.json"}, "testnet", af, output.NewStdoutLogger(output.NoneLog))
+		require.NoError(t, err)
+		assert.Equal(t, "access.devnet.nodes.onflow.org:9000", flow.Network().Host)
+		assert.NotNil(t, flow.Gateway())
+	})
+
+	t.Run("Fail missing network", func(t *testing.T) {
+		_, err := LoadProject([]string{"flow.json"}, "mainnet", af, output.NewStdoutLogger(output.NoneLog))
+		assert.EqualError(t, err, "network named mainnet does not exist in configuration")
+	})
+
+	t.Run("Fail missing configuration", func(t *testing.T) {
+		_, err := LoadProject([]string{"missing.json"}, "testnet", af, output.NewStdoutLogger(output.NoneLog))
+		assert.ErrorIs(t, err, config.ErrDoesNotExist)
+	})
+}
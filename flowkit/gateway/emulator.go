@@ -119,6 +119,14 @@ func (g *EmulatorGateway) GetAccount(address flow.Address) (*flow.Account, error
 	return account, nil
 }
 
+func (g *EmulatorGateway) GetAccountAtBlockHeight(address flow.Address, height uint64) (*flow.Account, error) {
+	account, err := g.adapter.GetAccountAtBlockHeight(g.ctx, address, height)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+	return account, nil
+}
+
 func (g *EmulatorGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
 	err := g.adapter.SendTransaction(context.Background(), *tx)
 	if err != nil {
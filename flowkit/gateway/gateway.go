@@ -28,6 +28,7 @@ import (
 // Gateway describes blockchain access interface
 type Gateway interface {
 	GetAccount(flow.Address) (*flow.Account, error)
+	GetAccountAtBlockHeight(flow.Address, uint64) (*flow.Account, error)
 	SendSignedTransaction(*flow.Transaction) (*flow.Transaction, error)
 	GetTransaction(flow.Identifier) (*flow.Transaction, error)
 	GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error)
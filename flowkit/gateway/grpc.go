@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/onflow/cadence"
@@ -39,20 +40,56 @@ import (
 const maxGRPCMessageSize = 1024 * 1024 * 20
 
 // GrpcGateway is a gateway implementation that uses the Flow Access gRPC API.
+//
+// Methods the access node doesn't implement fail with an UnsupportedError, or use an alternative if one
+// exists, in which case the method is remembered as unsupported and the node isn't called for it again.
 type GrpcGateway struct {
 	client       *grpcAccess.Client
 	ctx          context.Context
 	secureClient bool
+	host         string
+	unsupported  sync.Map
+}
+
+// headerCredentials sends the headers as metadata with every request.
+type headerCredentials map[string]string
+
+func (h headerCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return h, nil
+}
+
+// RequireTransportSecurity is false since access nodes are also served over insecure connections.
+func (h headerCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// dialOptions returns the options of the connection to the network, sending the network credentials
+// with every request if the network configures authentication.
+func dialOptions(network config.Network, transport grpc.DialOption) ([]grpc.DialOption, error) {
+	options := []grpc.DialOption{
+		transport,
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
+	}
+
+	if network.Auth != nil {
+		headers, err := network.Auth.Headers()
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth for network %s: %w", network.Name, err)
+		}
+		options = append(options, grpc.WithPerRPCCredentials(headerCredentials(headers)))
+	}
+
+	return options, nil
 }
 
 // NewGrpcGateway returns a new gRPC gateway.
 func NewGrpcGateway(network config.Network) (*GrpcGateway, error) {
+	options, err := dialOptions(network, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
 
-	gClient, err := grpcAccess.NewClient(
-		network.Host,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
-	)
+	gClient, err := grpcAccess.NewClient(network.Host, options...)
 	ctx := context.Background()
 
 	if err != nil || gClient == nil {
@@ -63,6 +100,7 @@ func NewGrpcGateway(network config.Network) (*GrpcGateway, error) {
 		client:       gClient,
 		ctx:          ctx,
 		secureClient: false,
+		host:         network.Host,
 	}, nil
 }
 
@@ -73,11 +111,12 @@ func NewSecureGrpcGateway(network config.Network) (*GrpcGateway, error) {
 		return nil, fmt.Errorf("failed to create secure GRPC dial options with network key \"%s\": %w", network.Key, err)
 	}
 
-	gClient, err := grpcAccess.NewClient(
-		network.Host,
-		secureDialOpts,
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
-	)
+	options, err := dialOptions(network, secureDialOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	gClient, err := grpcAccess.NewClient(network.Host, options...)
 	ctx := context.Background()
 
 	if err != nil || gClient == nil {
@@ -88,9 +127,20 @@ func NewSecureGrpcGateway(network config.Network) (*GrpcGateway, error) {
 		client:       gClient,
 		ctx:          ctx,
 		secureClient: true,
+		host:         network.Host,
 	}, nil
 }
 
+// NewNetworkGateway returns a new gRPC gateway for the network, the client connection is secure
+// if the network is configured with a key.
+func NewNetworkGateway(network config.Network) (*GrpcGateway, error) {
+	if network.Key != "" {
+		return NewSecureGrpcGateway(network)
+	}
+
+	return NewGrpcGateway(network)
+}
+
 // GetAccount gets an account by address from the Flow Access API.
 func (g *GrpcGateway) GetAccount(address flow.Address) (*flow.Account, error) {
 	account, err := g.client.GetAccountAtLatestBlock(g.ctx, address)
@@ -101,6 +151,16 @@ func (g *GrpcGateway) GetAccount(address flow.Address) (*flow.Account, error) {
 	return account, nil
 }
 
+// GetAccountAtBlockHeight gets an account by address at the block height from the Flow Access API.
+func (g *GrpcGateway) GetAccountAtBlockHeight(address flow.Address, height uint64) (*flow.Account, error) {
+	account, err := g.client.GetAccountAtBlockHeight(g.ctx, address, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account with address %s at block height %d: %w", address, height, err)
+	}
+
+	return account, nil
+}
+
 // SendSignedTransaction sends a transaction to flow that is already prepared and signed.
 func (g *GrpcGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
 	err := g.client.SendTransaction(g.ctx, *tx)
@@ -116,12 +176,51 @@ func (g *GrpcGateway) GetTransaction(ID flow.Identifier) (*flow.Transaction, err
 	return g.client.GetTransaction(g.ctx, ID)
 }
 
+// isSupported checks if the method wasn't reported as unimplemented by the access node.
+func (g *GrpcGateway) isSupported(method string) bool {
+	_, unsupported := g.unsupported.Load(method)
+	return !unsupported
+}
+
+// checkSupported remembers the method as unsupported if the access node doesn't implement it
+// and returns an UnsupportedError in that case, otherwise it returns the error unchanged.
+func (g *GrpcGateway) checkSupported(method string, err error) error {
+	if !isUnimplemented(err) {
+		return err
+	}
+
+	g.unsupported.Store(method, true)
+	return &UnsupportedError{Host: g.host, Method: method, Err: err}
+}
+
+// GetTransactionResultsByBlockID gets all transaction results of the block, from its collections if
+// the access node doesn't support getting them by the block ID.
 func (g *GrpcGateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
-	return g.client.GetTransactionResultsByBlockID(g.ctx, blockID)
+	const method = "GetTransactionResultsByBlockID"
+	if g.isSupported(method) {
+		results, err := g.client.GetTransactionResultsByBlockID(g.ctx, blockID)
+		if !isUnimplemented(err) {
+			return results, err
+		}
+		g.unsupported.Store(method, true)
+	}
+
+	return transactionResultsFromCollections(g, blockID)
 }
 
+// GetTransactionsByBlockID gets all transactions of the block, from its collections if the access
+// node doesn't support getting them by the block ID.
 func (g *GrpcGateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
-	return g.client.GetTransactionsByBlockID(g.ctx, blockID)
+	const method = "GetTransactionsByBlockID"
+	if g.isSupported(method) {
+		txs, err := g.client.GetTransactionsByBlockID(g.ctx, blockID)
+		if !isUnimplemented(err) {
+			return txs, err
+		}
+		g.unsupported.Store(method, true)
+	}
+
+	return transactionsFromCollections(g, blockID)
 }
 
 // GetTransactionResult gets a transaction result by ID from the Flow Access API.
@@ -146,12 +245,14 @@ func (g *GrpcGateway) ExecuteScript(script []byte, arguments []cadence.Value) (c
 
 // ExecuteScriptAtHeight executes a script at block height.
 func (g *GrpcGateway) ExecuteScriptAtHeight(script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
-	return g.client.ExecuteScriptAtBlockHeight(g.ctx, height, script, arguments)
+	value, err := g.client.ExecuteScriptAtBlockHeight(g.ctx, height, script, arguments)
+	return value, g.checkSupported("ExecuteScriptAtBlockHeight", err)
 }
 
 // ExecuteScriptAtID executes a script at block ID.
 func (g *GrpcGateway) ExecuteScriptAtID(script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
-	return g.client.ExecuteScriptAtBlockID(g.ctx, ID, script, arguments)
+	value, err := g.client.ExecuteScriptAtBlockID(g.ctx, ID, script, arguments)
+	return value, g.checkSupported("ExecuteScriptAtBlockID", err)
 }
 
 // GetLatestBlock gets the latest block on Flow through the Access API.
@@ -188,12 +289,14 @@ func (g *GrpcGateway) GetEvents(
 
 // GetCollection gets a collection by ID from the Flow Access API.
 func (g *GrpcGateway) GetCollection(id flow.Identifier) (*flow.Collection, error) {
-	return g.client.GetCollection(g.ctx, id)
+	collection, err := g.client.GetCollection(g.ctx, id)
+	return collection, g.checkSupported("GetCollection", err)
 }
 
 // GetLatestProtocolStateSnapshot gets the latest finalized protocol state snapshot
 func (g *GrpcGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
-	return g.client.GetLatestProtocolStateSnapshot(g.ctx)
+	snapshot, err := g.client.GetLatestProtocolStateSnapshot(g.ctx)
+	return snapshot, g.checkSupported("GetLatestProtocolStateSnapshot", err)
 }
 
 // Ping is used to check if the access node is alive and healthy.
@@ -0,0 +1,73 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_DialOptionsAuth(t *testing.T) {
+	transport := grpc.WithTransportCredentials(insecure.NewCredentials())
+
+	t.Run("Without auth", func(t *testing.T) {
+		options, err := dialOptions(config.TestnetNetwork, transport)
+		require.NoError(t, err)
+		assert.Len(t, options, 2)
+	})
+
+	t.Run("With auth", func(t *testing.T) {
+		network := config.Network{Name: "provider", Host: "flow.provider.example:443", Auth: &config.NetworkAuth{Token: "secret"}}
+		options, err := dialOptions(network, transport)
+		require.NoError(t, err)
+		assert.Len(t, options, 3)
+
+		metadata, err := headerCredentials{"authorization": "Bearer secret"}.GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"authorization": "Bearer secret"}, metadata)
+	})
+
+	t.Run("Fail environment variable not set", func(t *testing.T) {
+		network := config.Network{Name: "provider", Host: "flow.provider.example:443", Auth: &config.NetworkAuth{APIKey: "$MISSING_PROVIDER_KEY"}}
+		_, err := dialOptions(network, transport)
+		assert.EqualError(t, err, "invalid auth for network provider: required environment variable MISSING_PROVIDER_KEY not set")
+	})
+}
+
+func Test_NewNetworkGateway(t *testing.T) {
+	gw, err := NewNetworkGateway(config.EmulatorNetwork)
+	require.NoError(t, err)
+	assert.False(t, gw.SecureConnection())
+
+	secure := config.Network{
+		Name: "secure",
+		Host: "access.mainnet.nodes.onflow.org:9001",
+		Key:  "ba69f7d2e82b9edf25b103c195cd371cf0cc047ef8884a9bbe331e62982d46daeebf836f7445a2ac16741013b192959d8ad26998aff12f2adc67a99e1eb2988d",
+	}
+	gw, err = NewNetworkGateway(secure)
+	require.NoError(t, err)
+	assert.True(t, gw.SecureConnection())
+}
@@ -118,6 +118,32 @@ func (_m *Gateway) GetAccount(_a0 flow.Address) (*flow.Account, error) {
 	return r0, r1
 }
 
+// GetAccountAtBlockHeight provides a mock function with given fields: _a0, _a1
+func (_m *Gateway) GetAccountAtBlockHeight(_a0 flow.Address, _a1 uint64) (*flow.Account, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *flow.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(flow.Address, uint64) (*flow.Account, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(flow.Address, uint64) *flow.Account); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(flow.Address, uint64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockByHeight provides a mock function with given fields: _a0
 func (_m *Gateway) GetBlockByHeight(_a0 uint64) (*flow.Block, error) {
 	ret := _m.Called(_a0)
@@ -0,0 +1,116 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnsupportedError is returned when the access node doesn't implement an Access API method,
+// usually because it runs an older version of the node or the emulator.
+type UnsupportedError struct {
+	Host   string
+	Method string
+	Err    error
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf(
+		"the access node %s doesn't support %s, it may run an older version of the Access API, upgrade the node or the emulator to use this feature",
+		e.Host,
+		e.Method,
+	)
+}
+
+func (e *UnsupportedError) Unwrap() error {
+	return e.Err
+}
+
+// isUnimplemented checks if the error is the gRPC unimplemented status returned for unknown methods.
+func isUnimplemented(err error) bool {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return false
+	}
+
+	return grpcErr.GRPCStatus().Code() == codes.Unimplemented
+}
+
+// blockTransactionIDs returns the IDs of the transactions in the block collections, used as an alternative
+// to the methods fetching transactions by the block ID. The system transaction is not part of any collection.
+func blockTransactionIDs(g Gateway, blockID flow.Identifier) ([]flow.Identifier, error) {
+	block, err := g.GetBlockByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []flow.Identifier
+	for _, guarantee := range block.CollectionGuarantees {
+		collection, err := g.GetCollection(guarantee.CollectionID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, collection.TransactionIDs...)
+	}
+
+	return ids, nil
+}
+
+// transactionsFromCollections gets the transactions of the block from its collections.
+func transactionsFromCollections(g Gateway, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	ids, err := blockTransactionIDs(g, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*flow.Transaction, 0, len(ids))
+	for _, id := range ids {
+		tx, err := g.GetTransaction(id)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+// transactionResultsFromCollections gets the transaction results of the block from its collections.
+func transactionResultsFromCollections(g Gateway, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	ids, err := blockTransactionIDs(g, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*flow.TransactionResult, 0, len(ids))
+	for _, id := range ids {
+		result, err := g.GetTransactionResult(id, false)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,72 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	grpcAccess "github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+func Test_IsUnimplemented(t *testing.T) {
+	unimplemented := grpcAccess.RPCError{GRPCErr: status.Error(codes.Unimplemented, "unknown method")}
+
+	assert.True(t, isUnimplemented(unimplemented))
+	assert.True(t, isUnimplemented(fmt.Errorf("failed: %w", unimplemented)))
+	assert.False(t, isUnimplemented(status.Error(codes.NotFound, "not found")))
+	assert.False(t, isUnimplemented(nil))
+
+	err := &UnsupportedError{Host: "127.0.0.1:3569", Method: "GetTransactionsByBlockID", Err: unimplemented}
+	assert.EqualError(t, err, "the access node 127.0.0.1:3569 doesn't support GetTransactionsByBlockID, it may run an older version of the Access API, upgrade the node or the emulator to use this feature")
+}
+
+func Test_TransactionsFromCollections(t *testing.T) {
+	g := mocks.NewGateway(t)
+	blockID := flow.HexToID("01")
+	collectionID := flow.HexToID("02")
+	txIDs := []flow.Identifier{flow.HexToID("03"), flow.HexToID("04")}
+
+	g.On("GetBlockByID", blockID).Return(&flow.Block{
+		BlockPayload: flow.BlockPayload{
+			CollectionGuarantees: []*flow.CollectionGuarantee{{CollectionID: collectionID}},
+		},
+	}, nil)
+	g.On("GetCollection", collectionID).Return(&flow.Collection{TransactionIDs: txIDs}, nil)
+	for _, id := range txIDs {
+		g.On("GetTransaction", id).Return(flow.NewTransaction().SetGasLimit(10), nil)
+		g.On("GetTransactionResult", id, false).Return(&flow.TransactionResult{TransactionID: id}, nil)
+	}
+
+	txs, err := transactionsFromCollections(g, blockID)
+	require.NoError(t, err)
+	assert.Len(t, txs, 2)
+
+	results, err := transactionResultsFromCollections(g, blockID)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, txIDs[1], results[1].TransactionID)
+}
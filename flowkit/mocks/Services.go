@@ -92,34 +92,41 @@ func (_m *Services) BuildTransaction(_a0 context.Context, _a1 transactions.Addre
 	return r0, r1
 }
 
-// CreateAccount provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Services) CreateAccount(_a0 context.Context, _a1 *accounts.Account, _a2 []accounts.PublicKey) (*flow.Account, flow.Identifier, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// CreateAccount provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Services) CreateAccount(_a0 context.Context, _a1 *accounts.Account, _a2 []accounts.PublicKey, _a3 ...flowkit.Script) (*flow.Account, flow.Identifier, error) {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 *flow.Account
 	var r1 flow.Identifier
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account, []accounts.PublicKey) (*flow.Account, flow.Identifier, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account, []accounts.PublicKey, ...flowkit.Script) (*flow.Account, flow.Identifier, error)); ok {
+		return rf(_a0, _a1, _a2, _a3...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account, []accounts.PublicKey) *flow.Account); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account, []accounts.PublicKey, ...flowkit.Script) *flow.Account); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*flow.Account)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *accounts.Account, []accounts.PublicKey) flow.Identifier); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, *accounts.Account, []accounts.PublicKey, ...flowkit.Script) flow.Identifier); ok {
+		r1 = rf(_a0, _a1, _a2, _a3...)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(flow.Identifier)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, *accounts.Account, []accounts.PublicKey) error); ok {
-		r2 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(2).(func(context.Context, *accounts.Account, []accounts.PublicKey, ...flowkit.Script) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3...)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -306,6 +313,32 @@ func (_m *Services) GetAccount(_a0 context.Context, _a1 flow.Address) (*flow.Acc
 	return r0, r1
 }
 
+// GetAccountAtBlockHeight provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Services) GetAccountAtBlockHeight(_a0 context.Context, _a1 flow.Address, _a2 uint64) (*flow.Account, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *flow.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) (*flow.Account, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) *flow.Account); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlock provides a mock function with given fields: _a0, _a1
 func (_m *Services) GetBlock(_a0 context.Context, _a1 flowkit.BlockQuery) (*flow.Block, error) {
 	ret := _m.Called(_a0, _a1)
@@ -37,6 +37,7 @@ const (
 	gatewayFunc                      = "Gateway"
 	generateKeyFunc                  = "GenerateKey"
 	generateMnemonicKeyFunc          = "GenerateMnemonicKey"
+	getAccountAtBlockHeightFunc      = "GetAccountAtBlockHeight"
 	getBlockFunc                     = "GetBlock"
 	getTransactionByIDFunc           = "GetTransactionByID"
 	getTransactionsByBlockIDFunc     = "GetTransactionsByBlockID"
@@ -59,6 +60,7 @@ type MockServices struct {
 	Gateway                      *mock.Call
 	GenerateKey                  *mock.Call
 	GenerateMnemonicKey          *mock.Call
+	GetAccountAtBlockHeight      *mock.Call
 	GetBlock                     *mock.Call
 	GetTransactionByID           *mock.Call
 	GetTransactionsByBlockID     *mock.Call
@@ -155,6 +157,12 @@ func DefaultMockServices() *MockServices {
 			mock.AnythingOfType("crypto.SignatureAlgorithm"),
 			mock.AnythingOfType("string"),
 		),
+		GetAccountAtBlockHeight: m.On(
+			getAccountAtBlockHeightFunc,
+			mock.Anything,
+			mock.AnythingOfType("flow.Address"),
+			mock.AnythingOfType("uint64"),
+		),
 		GetBlock: m.On(
 			getBlockFunc,
 			mock.Anything,
@@ -206,6 +214,11 @@ func DefaultMockServices() *MockServices {
 		t.GetAccount.Return(tests.NewAccountWithAddress(addr.String()), nil)
 	})
 
+	t.GetAccountAtBlockHeight.Run(func(args mock.Arguments) {
+		addr := args.Get(1).(flow.Address)
+		t.GetAccountAtBlockHeight.Return(tests.NewAccountWithAddress(addr.String()), nil)
+	})
+
 	t.ExecuteScript.Run(func(args mock.Arguments) {
 		t.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
 	})
@@ -31,6 +31,9 @@ type Contract struct {
 	AccountAddress flow.Address
 	AccountName    string
 	Args           []cadence.Value
+	// TransactionID is the ID of the transaction that deployed the contract,
+	// it is empty if the contract wasn't deployed because it didn't change.
+	TransactionID flow.Identifier
 }
 
 func NewContract(
@@ -21,6 +21,7 @@ package project
 import (
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/onflow/flow-go-sdk"
 )
@@ -34,6 +35,19 @@ type Account interface {
 type ImportReplacer struct {
 	contracts []*Contract
 	aliases   LocationAliases
+	options   ImportOptions
+}
+
+// UnresolvedImportError is returned when an import doesn't match any of the provided contracts or aliases.
+type UnresolvedImportError struct {
+	// Import as written in the program.
+	Import string
+	// Location of the imported file resolved relative to the program.
+	Location string
+}
+
+func (e *UnresolvedImportError) Error() string {
+	return fmt.Sprintf("import %s could not be resolved from provided contracts", e.Import)
 }
 
 func NewImportReplacer(contracts []*Contract, aliases LocationAliases) *ImportReplacer {
@@ -43,13 +57,32 @@ func NewImportReplacer(contracts []*Contract, aliases LocationAliases) *ImportRe
 	}
 }
 
+// WithOptions overrides the resolution of imports matching the options.
+func (i *ImportReplacer) WithOptions(options ImportOptions) *ImportReplacer {
+	i.options = options
+	return i
+}
+
 func (i *ImportReplacer) Replace(program *Program) (*Program, error) {
-	imports := program.imports()
+	imports := program.Imports()
 	contractsLocations := i.getContractsLocations()
 
 	for _, imp := range imports {
-		// check if import by path exists (e.g. import X from ["./X.cdc"])
 		importLocation := path.Clean(absolutePath(program.Location(), imp))
+		// resolutions provided in the options take precedence over the project configuration
+		if !i.options.IsEmpty() {
+			candidates := append([]string{imp, importLocation}, program.importIdentifiers(imp)...)
+			address, resolved, ignored := i.options.resolve(candidates)
+			if ignored {
+				continue
+			}
+			if resolved {
+				program.replaceImport(imp, address)
+				continue
+			}
+		}
+
+		// check if import by path exists (e.g. import X from ["./X.cdc"])
 		address, isPath := contractsLocations[importLocation]
 		if isPath {
 			program.replaceImport(imp, address)
@@ -62,7 +95,7 @@ func (i *ImportReplacer) Replace(program *Program) (*Program, error) {
 			continue
 		}
 
-		return nil, fmt.Errorf("import %s could not be resolved from provided contracts", imp)
+		return nil, &UnresolvedImportError{Import: imp, Location: importLocation}
 	}
 
 	return program, nil
@@ -84,6 +117,73 @@ func (i *ImportReplacer) getContractsLocations() map[string]string {
 	return locationAddress
 }
 
+// ImportOptions override the resolution of imports without changing the project configuration.
+//
+// Imports are matched by the contract name, the import as written in the program, or the location of the
+// imported file resolved relative to the program.
+type ImportOptions struct {
+	// Resolve imports to the provided address instead of the deployed or aliased address.
+	Resolve map[string]string
+	// Ignore imports and leave them untouched in the program.
+	Ignore []string
+}
+
+// IsEmpty returns true if no import resolution is overridden.
+func (o ImportOptions) IsEmpty() bool {
+	return len(o.Resolve) == 0 && len(o.Ignore) == 0
+}
+
+// ParseImportOptions parses the imports resolved to an address in the "name=address" format and the ignored imports.
+func ParseImportOptions(resolve []string, ignore []string) (ImportOptions, error) {
+	options := ImportOptions{Ignore: ignore}
+	if len(resolve) > 0 {
+		options.Resolve = make(map[string]string, len(resolve))
+	}
+
+	for _, value := range resolve {
+		name, address, found := strings.Cut(value, "=")
+		if !found || name == "" || address == "" {
+			return ImportOptions{}, fmt.Errorf("import resolution %s is not in the name=address format", value)
+		}
+
+		parsed := flow.HexToAddress(address)
+		if parsed == flow.EmptyAddress {
+			return ImportOptions{}, fmt.Errorf("invalid address %s for import %s", address, name)
+		}
+		options.Resolve[name] = parsed.String()
+	}
+
+	return options, nil
+}
+
+// match returns the value of the first key matching the import, by the import as written, the location
+// of the imported file or the contract names the import declares, and whether a key matched.
+func match(candidates []string, keys map[string]string) (string, bool) {
+	for _, candidate := range candidates {
+		if value, ok := keys[candidate]; ok {
+			return value, true
+		}
+		if value, ok := keys[path.Clean(candidate)]; ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// resolve returns the address the import is resolved to by the options and whether the import is ignored.
+func (o ImportOptions) resolve(candidates []string) (address string, resolved bool, ignored bool) {
+	keys := make(map[string]string, len(o.Ignore))
+	for _, name := range o.Ignore {
+		keys[name] = ""
+	}
+	if _, ok := match(candidates, keys); ok {
+		return "", false, true
+	}
+
+	address, resolved = match(candidates, o.Resolve)
+	return address, resolved, false
+}
+
 func absolutePath(basePath, relativePath string) string {
 	return path.Join(path.Dir(basePath), relativePath)
 }
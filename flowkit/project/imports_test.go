@@ -135,4 +135,41 @@ func TestResolver(t *testing.T) {
 		assert.Equal(t, cleanCode(expected), cleanCode(replaced.Code()))
 	})
 
+	t.Run("Resolve with options", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Foo", "./Foo.cdc", nil, flow.HexToAddress("0x1"), "", nil),
+		}
+
+		options, err := ParseImportOptions([]string{"Foo=0xabc", "Bar=0x2"}, []string{"./Zoo.cdc"})
+		require.NoError(t, err)
+		replacer := NewImportReplacer(contracts, nil).WithOptions(options)
+
+		code := []byte(`
+			import Foo from "./Foo.cdc"
+			import "Bar"
+			import Zoo from "./Zoo.cdc"
+			pub fun main() {}
+		`)
+		program, err := NewProgram(code, nil, "./script.cdc")
+		require.NoError(t, err)
+
+		replaced, err := replacer.Replace(program)
+		require.NoError(t, err)
+
+		expected := []byte(`
+			import Foo from 0x0000000000000abc
+			import Bar from 0x0000000000000002
+			import Zoo from "./Zoo.cdc"
+			pub fun main() {}
+		`)
+		assert.Equal(t, cleanCode(expected), cleanCode(replaced.Code()))
+	})
+
+	t.Run("Fail parse options", func(t *testing.T) {
+		_, err := ParseImportOptions([]string{"Foo"}, nil)
+		assert.EqualError(t, err, "import resolution Foo is not in the name=address format")
+
+		_, err = ParseImportOptions([]string{"Foo=0x0"}, nil)
+		assert.EqualError(t, err, "invalid address 0x0 for import Foo")
+	})
 }
@@ -25,7 +25,6 @@ import (
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
-	"github.com/onflow/cadence/runtime/parser"
 )
 
 type Program struct {
@@ -36,7 +35,7 @@ type Program struct {
 }
 
 func NewProgram(code []byte, args []cadence.Value, location string) (*Program, error) {
-	astProgram, err := parser.ParseProgram(nil, code, parser.Config{})
+	astProgram, err := ParseProgram(code, location)
 	if err != nil {
 		return nil, err
 	}
@@ -49,11 +48,11 @@ func NewProgram(code []byte, args []cadence.Value, location string) (*Program, e
 	}, nil
 }
 
-// imports builds an array of all the import locations
+// Imports builds an array of all the import locations
 //
 // It currently supports getting import locations as identifiers or as strings. Strings locations
 // can represent a file or an account name, whereas identifiers represent contract names.
-func (p *Program) imports() []string {
+func (p *Program) Imports() []string {
 	imports := make([]string, 0)
 
 	for _, importDeclaration := range p.astProgram.ImportDeclarations() {
@@ -67,8 +66,23 @@ func (p *Program) imports() []string {
 	return imports
 }
 
+// importIdentifiers returns the contract names declared by the string import, e.g. X for import X from "./X.cdc".
+func (p *Program) importIdentifiers(imp string) []string {
+	identifiers := make([]string, 0)
+	for _, importDeclaration := range p.astProgram.ImportDeclarations() {
+		location, isStringImport := importDeclaration.Location.(common.StringLocation)
+		if !isStringImport || location.String() != imp {
+			continue
+		}
+		for _, identifier := range importDeclaration.Identifiers {
+			identifiers = append(identifiers, identifier.Identifier)
+		}
+	}
+	return identifiers
+}
+
 func (p *Program) HasImports() bool {
-	return len(p.imports()) > 0
+	return len(p.Imports()) > 0
 }
 
 func (p *Program) replaceImport(from string, to string) *Program {
@@ -116,7 +130,7 @@ func (p *Program) Name() (string, error) {
 }
 
 func (p *Program) reload() {
-	astProgram, err := parser.ParseProgram(nil, p.code, parser.Config{})
+	astProgram, err := ParseProgram(p.code, p.location)
 	if err != nil {
 		return
 	}
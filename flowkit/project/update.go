@@ -0,0 +1,110 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	cadenceErrors "github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// UpdateIssue is an incompatible change preventing a deployed contract from being updated.
+type UpdateIssue struct {
+	Message string
+	// Details explains the issue further, empty if there are no details.
+	Details string
+	// Line and Column of the issue in the updated code, starting at 1, zero if the position is unknown.
+	Line   int
+	Column int
+}
+
+func (i UpdateIssue) String() string {
+	message := i.Message
+	if i.Details != "" {
+		message = fmt.Sprintf("%s, %s", message, i.Details)
+	}
+	if i.Line == 0 {
+		return message
+	}
+	return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, message)
+}
+
+// CheckContractUpdate validates the deployed contract code can be updated to the updated code, using the same
+// validation the network runs when a contract is updated, and returns the incompatible changes found such as
+// removed fields or changed field types. No issues are returned for a valid update.
+func CheckContractUpdate(address flow.Address, deployed []byte, updated []byte, location string) ([]UpdateIssue, error) {
+	oldProgram, err := ParseProgram(deployed, "deployed contract")
+	if err != nil {
+		return nil, err
+	}
+
+	newProgram, err := ParseProgram(updated, location)
+	if err != nil {
+		return nil, err
+	}
+
+	program := &Program{code: updated, location: location, astProgram: newProgram}
+	name, err := program.Name()
+	if err != nil {
+		return nil, err
+	}
+
+	validator := stdlib.NewContractUpdateValidator(
+		common.AddressLocation{Address: common.Address(address), Name: name},
+		name,
+		oldProgram,
+		newProgram,
+	)
+
+	err = validator.Validate()
+	if err == nil {
+		return nil, nil
+	}
+
+	var updateErr *stdlib.ContractUpdateError
+	if !errors.As(err, &updateErr) {
+		return nil, err
+	}
+
+	issues := make([]UpdateIssue, 0, len(updateErr.Errors))
+	for _, childErr := range updateErr.Errors {
+		issue := UpdateIssue{Message: childErr.Error()}
+
+		var secondary cadenceErrors.SecondaryError
+		if errors.As(childErr, &secondary) {
+			issue.Details = secondary.SecondaryError()
+		}
+
+		var positioned ast.HasPosition
+		if errors.As(childErr, &positioned) {
+			position := positioned.StartPosition()
+			issue.Line = position.Line
+			issue.Column = position.Column + 1
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckContractUpdate(t *testing.T) {
+	address := flow.HexToAddress("0x01")
+	deployed := []byte(`
+pub contract Foo {
+    pub let id: UInt64
+    pub var name: String
+
+    init() {
+        self.id = 1
+        self.name = "foo"
+    }
+}`)
+
+	t.Run("Compatible", func(t *testing.T) {
+		updated := []byte(`
+pub contract Foo {
+    pub let id: UInt64
+    pub var name: String
+
+    pub fun hello(): String {
+        return "hello"
+    }
+
+    init() {
+        self.id = 1
+        self.name = "foo"
+    }
+}`)
+
+		issues, err := CheckContractUpdate(address, deployed, updated, "Foo.cdc")
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("Incompatible", func(t *testing.T) {
+		updated := []byte(`
+pub contract Foo {
+    pub let id: String
+    pub var name: String
+    pub var extra: Int
+
+    init() {
+        self.id = "1"
+        self.name = "foo"
+        self.extra = 0
+    }
+}`)
+
+		issues, err := CheckContractUpdate(address, deployed, updated, "Foo.cdc")
+		require.NoError(t, err)
+		require.Len(t, issues, 2)
+		assert.Equal(t, "3:17: mismatching field `id` in `Foo`, incompatible type annotations. expected `UInt64`, found `String`", issues[0].String())
+		assert.Equal(t, "5:13: found new field `extra` in `Foo`", issues[1].String())
+	})
+
+	t.Run("Fail parse", func(t *testing.T) {
+		_, err := CheckContractUpdate(address, deployed, []byte("pub contract Foo {"), "Foo.cdc")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,114 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+)
+
+// CadenceVersion is the Cadence language version a program targets.
+type CadenceVersion string
+
+const (
+	CadenceV0 CadenceVersion = "0.x"
+	CadenceV1 CadenceVersion = "1.0"
+)
+
+// BundledCadenceVersion is the Cadence version of the embedded parser.
+const BundledCadenceVersion = CadenceV0
+
+// parsers are the embedded parsers by the Cadence version they parse.
+var parsers = map[CadenceVersion]func(code []byte) (*ast.Program, error){
+	CadenceV0: func(code []byte) (*ast.Program, error) {
+		return parser.ParseProgram(nil, code, parser.Config{})
+	},
+}
+
+// UnsupportedCadenceVersionError is returned when parsing a program targeting a Cadence version
+// that no embedded parser supports.
+type UnsupportedCadenceVersionError struct {
+	Location string
+	Version  CadenceVersion
+}
+
+func (e *UnsupportedCadenceVersionError) Error() string {
+	location := e.Location
+	if location == "" {
+		location = "the program"
+	}
+	return fmt.Sprintf(
+		"%s targets Cadence %s which is not supported, only Cadence %s can be parsed by this version of flow-cli",
+		location,
+		e.Version,
+		BundledCadenceVersion,
+	)
+}
+
+var (
+	// versionPragma is the pragma declaring the Cadence version, e.g. #cadenceVersion("1.0")
+	versionPragma = regexp.MustCompile(`(?m)^\s*#cadenceVersion\(\s*"([^"]+)"\s*\)`)
+	comments      = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	literals      = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+	// v0Syntax matches declarations removed in Cadence 1.0.
+	v0Syntax = regexp.MustCompile(`\bpub\b|\bpriv\b|\bAuthAccount\b|\bPublicAccount\b`)
+	// v1Syntax matches declarations introduced in Cadence 1.0.
+	v1Syntax = regexp.MustCompile(`\bentitlement\b|\bauth\s*\(|&Account\b|\bview\s+fun\b|\baccess\s*\(\s*mapping\b`)
+)
+
+// DetectCadenceVersion returns the Cadence version the code targets.
+//
+// The version is read from a #cadenceVersion("1.0") pragma if the code declares one, otherwise
+// it's Cadence 1.0 if the code only uses syntax introduced in Cadence 1.0 and the bundled version if not.
+func DetectCadenceVersion(code []byte) CadenceVersion {
+	if match := versionPragma.FindSubmatch(code); match != nil {
+		switch version := string(match[1]); {
+		case version == "0" || strings.HasPrefix(version, "0."):
+			return CadenceV0
+		case version == "1" || strings.HasPrefix(version, "1."):
+			return CadenceV1
+		default:
+			return CadenceVersion(version)
+		}
+	}
+
+	stripped := literals.ReplaceAll(comments.ReplaceAll(code, nil), []byte(`""`))
+	if v1Syntax.Match(stripped) && !v0Syntax.Match(stripped) {
+		return CadenceV1
+	}
+
+	return BundledCadenceVersion
+}
+
+// ParseProgram parses the code with the embedded parser of the Cadence version it targets.
+//
+// The location is only used in the error returned if the version is not supported.
+func ParseProgram(code []byte, location string) (*ast.Program, error) {
+	version := DetectCadenceVersion(code)
+	parse, ok := parsers[version]
+	if !ok {
+		return nil, &UnsupportedCadenceVersionError{Location: location, Version: version}
+	}
+
+	return parse(code)
+}
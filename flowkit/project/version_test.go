@@ -0,0 +1,90 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectCadenceVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		version CadenceVersion
+	}{{
+		name:    "pre 1.0 access modifiers",
+		code:    `pub contract Foo { pub fun bar(acct: AuthAccount) {} }`,
+		version: CadenceV0,
+	}, {
+		name:    "1.0 entitlements",
+		code:    `access(all) contract Foo { access(all) entitlement Withdraw }`,
+		version: CadenceV1,
+	}, {
+		name:    "1.0 account references",
+		code:    "transaction { prepare(signer: auth(Storage) &Account) {} }",
+		version: CadenceV1,
+	}, {
+		name:    "mixed syntax is parsed by the bundled parser",
+		code:    `pub contract Foo { access(all) view fun bar() {} }`,
+		version: CadenceV0,
+	}, {
+		name:    "syntax in comments and strings is ignored",
+		code:    "// auth(Storage) &Account\naccess(all) fun main(): String { return \"view fun\" }",
+		version: BundledCadenceVersion,
+	}, {
+		name:    "pragma",
+		code:    "#cadenceVersion(\"1.0\")\npub fun main() {}",
+		version: CadenceV1,
+	}, {
+		name:    "pragma pre 1.0",
+		code:    "#cadenceVersion(\"0.42\")\naccess(all) view fun main() {}",
+		version: CadenceV0,
+	}, {
+		name:    "pragma unknown version",
+		code:    "#cadenceVersion(\"2.0\")\naccess(all) fun main() {}",
+		version: CadenceVersion("2.0"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.version, DetectCadenceVersion([]byte(test.code)))
+		})
+	}
+}
+
+func TestParseProgram(t *testing.T) {
+	t.Run("Success bundled version", func(t *testing.T) {
+		program, err := ParseProgram([]byte(`pub contract Foo {}`), "Foo.cdc")
+		require.NoError(t, err)
+		assert.Len(t, program.CompositeDeclarations(), 1)
+	})
+
+	t.Run("Fail unsupported version", func(t *testing.T) {
+		_, err := ParseProgram([]byte(`access(all) contract Foo { access(all) entitlement E }`), "Foo.cdc")
+		require.Error(t, err)
+
+		var versionErr *UnsupportedCadenceVersionError
+		require.ErrorAs(t, err, &versionErr)
+		assert.Equal(t, CadenceV1, versionErr.Version)
+		assert.EqualError(t, err, "Foo.cdc targets Cadence 1.0 which is not supported, only Cadence 0.x can be parsed by this version of flow-cli")
+	})
+}
@@ -44,11 +44,16 @@ type Services interface {
 	// GetAccount fetches account on the Flow network.
 	GetAccount(context.Context, flow.Address) (*flow.Account, error)
 
+	// GetAccountAtBlockHeight fetches account on the Flow network as it was at the block height.
+	GetAccountAtBlockHeight(context.Context, flow.Address, uint64) (*flow.Account, error)
+
 	// CreateAccount on the Flow network with the provided keys and using the signer for creation transaction.
 	// Returns the newly created account as well as the ID of the transaction that created the account.
 	//
 	// Keys is a slice but only one can be passed as well. If the transaction fails or there are other issues an error is returned.
-	CreateAccount(context.Context, *accounts.Account, []accounts.PublicKey) (*flow.Account, flow.Identifier, error)
+	//
+	// Contracts are deployed to the new account in the same transaction, with imports resolved for the selected network.
+	CreateAccount(context.Context, *accounts.Account, []accounts.PublicKey, ...Script) (*flow.Account, flow.Identifier, error)
 
 	// AddContract to the Flow account provided and return the transaction ID.
 	//
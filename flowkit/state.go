@@ -40,6 +40,21 @@ type ReaderWriter interface {
 	WriteFile(filename string, data []byte, perm os.FileMode) error
 }
 
+// MkdirAll creates the directory and its parents with the reader writer.
+//
+// The reader writer interface doesn't manage directories, but most implementations (e.g. afero) do,
+// the directory is only created if the reader writer implements MkdirAll.
+func MkdirAll(readerWriter ReaderWriter, dir string) error {
+	dirMaker, ok := readerWriter.(interface {
+		MkdirAll(path string, perm os.FileMode) error
+	})
+	if !ok {
+		return nil
+	}
+
+	return dirMaker.MkdirAll(dir, 0755)
+}
+
 // State manages the state for a Flow project.
 type State struct {
 	conf         *config.Config
@@ -104,6 +119,21 @@ func (p *State) Deployments() *config.Deployments {
 	return &p.conf.Deployments
 }
 
+// Assertions get deployment assertions configuration.
+func (p *State) Assertions() *config.Assertions {
+	return &p.conf.Assertions
+}
+
+// Scripts get named scripts configuration.
+func (p *State) Scripts() *config.Scripts {
+	return &p.conf.Scripts
+}
+
+// Payers get the payer pools configuration.
+func (p *State) Payers() *config.PayerPools {
+	return &p.conf.Payers
+}
+
 // Contracts get contracts configuration.
 func (p *State) Contracts() *config.Contracts {
 	return &p.conf.Contracts
@@ -697,6 +697,9 @@ func Test_DefaultEmulatorNotPresentInConfig(t *testing.T) {
 		}},
 		Contracts:   config.Contracts{},
 		Deployments: config.Deployments{},
+		Assertions:  config.Assertions{},
+		Scripts:     config.Scripts{},
+		Payers:      config.PayerPools{},
 		Accounts: config.Accounts{{
 			Name:    "emulator-account",
 			Address: flow.ServiceAddress(flow.Emulator),
@@ -805,6 +808,9 @@ func Test_DefaultEmulatorPresentInConfig(t *testing.T) {
 		}},
 		Contracts:   config.Contracts{},
 		Deployments: config.Deployments{},
+		Assertions:  config.Assertions{},
+		Scripts:     config.Scripts{},
+		Payers:      config.PayerPools{},
 		Accounts: config.Accounts{{
 			Name:    "emulator-account",
 			Address: flow.ServiceAddress(flow.Emulator),
@@ -861,6 +867,9 @@ func Test_CustomEmulatorValuesInConfig(t *testing.T) {
 		}},
 		Contracts:   config.Contracts{},
 		Deployments: config.Deployments{},
+		Assertions:  config.Assertions{},
+		Scripts:     config.Scripts{},
+		Payers:      config.PayerPools{},
 		Accounts: config.Accounts{{
 			Name:    "emulator-account",
 			Address: flow.ServiceAddress(flow.Emulator),
@@ -887,3 +896,28 @@ func Test_CustomEmulatorValuesInConfig(t *testing.T) {
 	assert.Equal(t, state.conf, &cfg)
 	assert.NoError(t, err)
 }
+
+// fileWriter is a reader writer that doesn't manage directories.
+type fileWriter struct {
+	files map[string][]byte
+}
+
+func (w fileWriter) ReadFile(source string) ([]byte, error) {
+	return w.files[source], nil
+}
+
+func (w fileWriter) WriteFile(filename string, data []byte, _ os.FileMode) error {
+	w.files[filename] = data
+	return nil
+}
+
+func Test_MkdirAll(t *testing.T) {
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	require.NoError(t, MkdirAll(af, ".flow/cache"))
+
+	exists, err := af.DirExists(".flow/cache")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.NoError(t, MkdirAll(fileWriter{files: map[string][]byte{}}, ".flow/cache"))
+}
@@ -395,10 +395,16 @@ func (t AccountRoles) AddressRoles() AddressesRoles {
 }
 
 // Signers for signing the transaction, detect if all accounts are same so only return the one account.
+//
+// The payer is always the last signer, because it signs the envelope which includes the payload
+// signatures of the proposer and all authorizers that are not the payer.
 func (t AccountRoles) Signers() []*accounts.Account {
 	// build only unique accounts to sign, it's important payer account is last
 	sigs := make([]*accounts.Account, 0)
 	addLastIfUnique := func(signer accounts.Account) {
+		if signer.Address == t.Payer.Address {
+			return
+		}
 		for _, sig := range sigs {
 			if sig.Address == signer.Address {
 				return
@@ -411,9 +417,8 @@ func (t AccountRoles) Signers() []*accounts.Account {
 	for _, auth := range t.Authorizers {
 		addLastIfUnique(auth)
 	}
-	addLastIfUnique(t.Payer)
-
-	return sigs
+	payer := t.Payer
+	return append(sigs, &payer)
 }
 
 // AddressesRoles defines transaction roles by account addresses.
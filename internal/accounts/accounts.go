@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -42,14 +43,33 @@ func init() {
 	removeCommand.AddToParent(Cmd)
 	updateCommand.AddToParent(Cmd)
 	createCommand.AddToParent(Cmd)
+	rotateKeyCommand.AddToParent(Cmd)
 	stakingCommand.AddToParent(Cmd)
 	getCommand.AddToParent(Cmd)
+	diffCommand.AddToParent(Cmd)
+	fundCommand.AddToParent(Cmd)
+	setWeightsCommand.AddToParent(Cmd)
+	listCommand.AddToParent(Cmd)
+	contractsCommand.AddToParent(Cmd)
+	getContractCommand.AddToParent(Cmd)
+	exportContractsCommand.AddToParent(Cmd)
+	watchCommand.AddToParent(Cmd)
+	findCommand.AddToParent(Cmd)
+	balancesCommand.AddToParent(Cmd)
+	nftsCommand.AddToParent(Cmd)
+	proveCommand.AddToParent(Cmd)
+	verifyProofCommand.AddToParent(Cmd)
+	setupCommand.AddToParent(Cmd)
+	ledgerCommand.AddToParent(Cmd)
+	Cmd.AddCommand(keysCmd)
 }
 
 // accountResult represent result from all account commands.
 type accountResult struct {
 	*flow.Account
-	include []string
+	include       []string
+	transactionID flow.Identifier // set if the account was created by the command
+	link          command.ExplorerLink
 }
 
 func (r *accountResult) JSON() any {
@@ -64,6 +84,10 @@ func (r *accountResult) JSON() any {
 
 	result["keys"] = keys
 
+	if r.transactionID != flow.EmptyID {
+		result["transactionId"] = r.transactionID.String()
+	}
+
 	contracts := make([]string, 0, len(r.Contracts))
 	for name := range r.Contracts {
 		contracts = append(contracts, name)
@@ -71,6 +95,10 @@ func (r *accountResult) JSON() any {
 
 	result["contracts"] = contracts
 
+	if link := r.link.Included(config.ExplorerAccount, "0x"+r.Address.Hex()); link != "" {
+		result["link"] = link
+	}
+
 	if command.ContainsFlag(r.include, "contracts") {
 		c := make(map[string]string)
 		for name, code := range r.Contracts {
@@ -87,7 +115,13 @@ func (r *accountResult) String() string {
 	writer := util.CreateTabWriter(&b)
 
 	_, _ = fmt.Fprintf(writer, "Address\t 0x%s\n", r.Address)
+	if r.transactionID != flow.EmptyID {
+		_, _ = fmt.Fprintf(writer, "Transaction ID\t %s\n", r.transactionID)
+	}
 	_, _ = fmt.Fprintf(writer, "Balance\t %s\n", cadence.UFix64(r.Balance))
+	if link := r.link.URL(config.ExplorerAccount, "0x"+r.Address.Hex()); link != "" {
+		_, _ = fmt.Fprintf(writer, "Explorer\t %s\n", link)
+	}
 
 	_, _ = fmt.Fprintf(writer, "Keys\t %d\n", len(r.Keys))
 
@@ -133,5 +167,10 @@ func (r *accountResult) Oneliner() string {
 		keys = append(keys, key.PublicKey.String())
 	}
 
-	return fmt.Sprintf("Address: 0x%s, Balance: %s, Public Keys: %s", r.Address, cadence.UFix64(r.Balance), keys)
+	result := fmt.Sprintf("Address: 0x%s, Balance: %s, Public Keys: %s", r.Address, cadence.UFix64(r.Balance), keys)
+	if link := r.link.Included(config.ExplorerAccount, "0x"+r.Address.Hex()); link != "" {
+		result += fmt.Sprintf(", Explorer: %s", link)
+	}
+
+	return result
 }
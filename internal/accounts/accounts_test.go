@@ -19,20 +19,36 @@
 package accounts
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/mocks"
 
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -84,6 +100,89 @@ func Test_DeployContract(t *testing.T) {
 		assert.NotNil(t, result)
 	})
 
+	t.Run("Success arg flag", func(t *testing.T) {
+		srv.AddContract.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Len(t, script.Args, 1)
+			assert.Equal(t, "2", script.Args[0].String())
+		})
+
+		addContractFlags.ArgsJSON = ""
+		addContractFlags.Arg = []string{"2"}
+		defer func() { addContractFlags.Arg = nil }()
+
+		result, err := deployContract(false, &addContractFlags)(
+			[]string{tests.ContractSimpleWithArgs.Filename},
+			command.GlobalFlags{},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success named arg flag", func(t *testing.T) {
+		srv.AddContract.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Len(t, script.Args, 1)
+			assert.Equal(t, "3", script.Args[0].String())
+		})
+
+		addContractFlags.ArgsJSON = ""
+		addContractFlags.Arg = []string{"initId:UInt64:3"}
+		defer func() { addContractFlags.Arg = nil }()
+
+		result, err := deployContract(false, &addContractFlags)(
+			[]string{tests.ContractSimpleWithArgs.Filename},
+			command.GlobalFlags{},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail named arg flag with positional arguments", func(t *testing.T) {
+		addContractFlags.ArgsJSON = ""
+		addContractFlags.Arg = []string{"initId=3"}
+		defer func() { addContractFlags.Arg = nil }()
+
+		result, err := deployContract(false, &addContractFlags)(
+			[]string{tests.ContractSimpleWithArgs.Filename, "1"},
+			command.GlobalFlags{},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "error parsing transaction arguments: arguments keyed by parameter name can not be combined with positional arguments")
+	})
+
+	t.Run("Fail JSON arguments with arg flag", func(t *testing.T) {
+		addContractFlags.ArgsJSON = `[{"type": "UInt64", "value": "1"}]`
+		addContractFlags.Arg = []string{"1"}
+		defer func() {
+			addContractFlags.ArgsJSON = ""
+			addContractFlags.Arg = nil
+		}()
+
+		result, err := deployContract(false, &addContractFlags)(
+			[]string{tests.ContractSimpleWithArgs.Filename},
+			command.GlobalFlags{},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "error parsing transaction arguments: arguments in JSON-Cadence format can not be combined with other arguments")
+	})
+
 	t.Run("Fail non-existing file", func(t *testing.T) {
 		args := []string{"non-existing"}
 		result, err := deployContract(false, &addContractFlags)(
@@ -130,6 +229,69 @@ func Test_DeployContract(t *testing.T) {
 
 }
 
+func Test_UpdateContract(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(args.Get(1).(flow.Address).String())
+		account.Contracts = map[string][]byte{"Simple": tests.ContractSimpleWithArgs.Source}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	incompatible := []byte(`
+pub contract Simple {
+    pub let id: String
+    init(initId: String) {
+        self.id = initId
+    }
+}`)
+	require.NoError(t, rw.WriteFile("incompatible.cdc", incompatible, 0644))
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := deployContract(true, &updateContractFlags)(
+			[]string{tests.ContractSimpleWithArgs.Filename, "1"},
+			command.GlobalFlags{},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success skip update check", func(t *testing.T) {
+		updateContractFlags.SkipUpdateCheck = true
+		defer func() { updateContractFlags.SkipUpdateCheck = false }()
+
+		result, err := deployContract(true, &updateContractFlags)(
+			[]string{"incompatible.cdc", "1"},
+			command.GlobalFlags{},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail incompatible update", func(t *testing.T) {
+		result, err := deployContract(true, &updateContractFlags)(
+			[]string{"incompatible.cdc", "1"},
+			command.GlobalFlags{},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "contract Simple can not be updated, the changes are incompatible with the deployed contract:\n"+
+			"  incompatible.cdc:3:17: mismatching field `id` in `Simple`, incompatible type annotations. expected `UInt64`, found `String`\n"+
+			"use --skip-update-check to send the update anyway")
+	})
+}
+
 func Test_RemoveContract(t *testing.T) {
 	srv, state, _ := util.TestMocks(t)
 
@@ -207,6 +369,93 @@ func Test_Create(t *testing.T) {
 		require.NotNil(t, result)
 	})
 
+	t.Run("Success keys with settings", func(t *testing.T) {
+		pkey1 := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
+		pkey2 := "c4bcde70e3c29cdc472ce7be46e219ca42f0ed2174369b3ba693c5655ed03f7027c571ba3881ed4b480fba41760572bcc167a8dbcf4e6ed952dcce831f82fc92"
+		createFlags.Keys = []string{
+			pkey1 + ":weight=500",
+			pkey2 + ":weight=500:sig-algo=ECDSA_secp256k1:hash-algo=SHA2_256",
+			pkey1,
+		}
+		createFlags.SigAlgo = []string{"ECDSA_P256"}
+		createFlags.HashAlgo = []string{"SHA3_256"}
+		createFlags.Weights = []int{500}
+
+		srv.CreateAccount.Run(func(args mock.Arguments) {
+			keys := args.Get(2).([]accounts.PublicKey)
+			require.Len(t, keys, 3)
+
+			assert.Equal(t, fmt.Sprintf("0x%s", pkey1), keys[0].Public.String())
+			assert.Equal(t, crypto.ECDSA_P256, keys[0].SigAlgo)
+			assert.Equal(t, crypto.SHA3_256, keys[0].HashAlgo)
+			assert.Equal(t, 500, keys[0].Weight)
+
+			assert.Equal(t, fmt.Sprintf("0x%s", pkey2), keys[1].Public.String())
+			assert.Equal(t, crypto.ECDSA_secp256k1, keys[1].SigAlgo)
+			assert.Equal(t, crypto.SHA2_256, keys[1].HashAlgo)
+			assert.Equal(t, 500, keys[1].Weight)
+
+			// settings not following the key are taken from the list flags
+			assert.Equal(t, crypto.ECDSA_P256, keys[2].SigAlgo)
+			assert.Equal(t, 500, keys[2].Weight)
+		})
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Fail keys with invalid settings", func(t *testing.T) {
+		pkey := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
+
+		_, err := parseKeys([]string{pkey + ":weight=1001"}, []string{"ECDSA_P256"}, []string{"SHA3_256"}, []int{1000})
+		assert.EqualError(t, err, "invalid key weight 1001, must be between 0 and 1000")
+
+		_, err = parseKeys([]string{pkey + ":index=1"}, []string{"ECDSA_P256"}, []string{"SHA3_256"}, []int{1000})
+		assert.EqualError(t, err, "invalid key setting index=1, expected weight, sig-algo or hash-algo")
+
+		_, err = parseKeys([]string{pkey + ":weight=500", pkey}, []string{"ECDSA_P256"}, []string{"SHA3_256"}, []int{500, 500})
+		assert.ErrorContains(t, err, "settings following a key can not be combined with multiple")
+	})
+
+	t.Run("Success public keys", func(t *testing.T) {
+		pkey := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
+		pem := "-----BEGIN PUBLIC KEY-----\n" +
+			"MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEAU2R62i1/d7KEYgh509wtI2VgshU\n" +
+			"bYoq6daDXNt9HQCGJJRfVcS0CcYotjqJpUVw7QKOjmih/gyY7wjX9IgDew==\n" +
+			"-----END PUBLIC KEY-----\n"
+		require.NoError(t, state.ReaderWriter().WriteFile("kms.pem", []byte(pem), 0644))
+
+		keys := createFlags.Keys
+		createFlags.Keys = nil
+		createFlags.PublicKeys = []string{"kms.pem:hash-algo=SHA2_256", "0x04" + pkey}
+		createFlags.SigAlgo = []string{"ECDSA_P256"}
+		createFlags.HashAlgo = []string{"SHA3_256"}
+		createFlags.Weights = []int{500}
+		defer func() {
+			createFlags.Keys = keys
+			createFlags.PublicKeys = nil
+		}()
+
+		srv.CreateAccount.Run(func(args mock.Arguments) {
+			keys := args.Get(2).([]accounts.PublicKey)
+			require.Len(t, keys, 2)
+
+			assert.Equal(t, fmt.Sprintf("0x%s", pkey), keys[0].Public.String())
+			assert.Equal(t, crypto.SHA2_256, keys[0].HashAlgo)
+			assert.Equal(t, fmt.Sprintf("0x%s", pkey), keys[1].Public.String())
+			assert.Equal(t, crypto.SHA3_256, keys[1].HashAlgo)
+		})
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		createFlags.PublicKeys = []string{"missing.pem"}
+		_, err = create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "public key missing.pem is neither hex encoded nor a readable PEM file")
+	})
+
 	t.Run("Fail not enough weights", func(t *testing.T) {
 		pkey1 := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
 		pkey2 := "c4bcde70e3c29cdc472ce7be46e219ca42f0ed2174369b3ba693c5655ed03f7027c571ba3881ed4b480fba41760572bcc167a8dbcf4e6ed952dcce831f82fc92"
@@ -232,6 +481,306 @@ func Test_Create(t *testing.T) {
 		require.Nil(t, result)
 	})
 
+	t.Run("Success idempotency key", func(t *testing.T) {
+		srv, state, rw := util.TestMocks(t)
+		pkey := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
+		createFlags.Keys = []string{pkey}
+		createFlags.SigAlgo = []string{"ECDSA_P256"}
+		createFlags.HashAlgo = []string{"SHA3_256"}
+		createFlags.Weights = []int{1000}
+		createFlags.IdempotencyKey = "ci-1"
+		defer func() { createFlags.IdempotencyKey = "" }()
+		globalFlags := command.GlobalFlags{ConfigPaths: []string{"project/flow.json"}}
+
+		tx := transactions.New()
+		srv.CreateAccount.Run(func(args mock.Arguments) {
+			require.NoError(t, flowkit.BeforeSend(args.Get(0).(context.Context), tx))
+
+			// the transaction is recorded before it's sent
+			data, err := rw.ReadFile("project/.flow/idempotency.json")
+			require.NoError(t, err)
+			assert.Contains(t, string(data), tx.FlowTransaction().ID().String())
+		}).Return(tests.NewAccountWithAddress("01"), tx.FlowTransaction().ID(), nil)
+
+		result, err := create([]string{}, globalFlags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000001", result.(*accountResult).Address.Hex())
+
+		// retry returns the already created account
+		result, err = create([]string{}, globalFlags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000001", result.(*accountResult).Address.Hex())
+		srv.Mock.AssertNumberOfCalls(t, "CreateAccount", 1)
+
+		// the same key can't be used for a different request
+		createFlags.Weights = []int{500}
+		_, err = create([]string{}, globalFlags, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, fmt.Sprintf(
+			"idempotency key ci-1 was already used to create an account in transaction %s with different parameters",
+			tx.FlowTransaction().ID(),
+		))
+	})
+
+	// pendingIdempotencyRecord saves the record of a job interrupted after the transaction was signed
+	pendingIdempotencyRecord := func(t *testing.T, state *flowkit.State, rw flowkit.ReaderWriter, key string, tx *transactions.Transaction) {
+		signer, err := state.Accounts().ByName(createFlags.Signer)
+		require.NoError(t, err)
+		public, err := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, createFlags.Keys[0])
+		require.NoError(t, err)
+		keys := []accounts.PublicKey{{
+			Public:   public,
+			Weight:   1000,
+			SigAlgo:  crypto.ECDSA_P256,
+			HashAlgo: crypto.SHA3_256,
+		}}
+
+		records, err := loadIdempotencyRecords(rw, ".")
+		require.NoError(t, err)
+		require.NoError(t, records.save(rw, "emulator", key, idempotencyRecord{
+			TransactionID: tx.FlowTransaction().ID().String(),
+			Transaction:   hex.EncodeToString(tx.FlowTransaction().Encode()),
+			Request:       createRequestHash(signer, keys),
+		}))
+	}
+
+	t.Run("Success idempotency key resolves pending transaction", func(t *testing.T) {
+		srv, state, rw := util.TestMocks(t)
+		createFlags.Keys = []string{"014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"}
+		createFlags.Weights = []int{1000}
+		createFlags.IdempotencyKey = "ci-2"
+		defer func() { createFlags.IdempotencyKey = "" }()
+
+		tx := transactions.New()
+		pendingIdempotencyRecord(t, state, rw, "ci-2", tx)
+
+		srv.GetTransactionByID.Run(func(args mock.Arguments) {
+			assert.Equal(t, tx.FlowTransaction().ID(), args.Get(1).(flow.Identifier))
+		}).Return(tests.NewTransaction(), tests.NewAccountCreateResult(flow.HexToAddress("02")), nil)
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000002", result.(*accountResult).Address.Hex())
+		srv.Mock.AssertNotCalled(t, "SendSignedTransaction", mock.Anything, mock.Anything)
+		srv.Mock.AssertNotCalled(t, "CreateAccount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success idempotency key sends transaction not found again", func(t *testing.T) {
+		srv, state, rw := util.TestMocks(t)
+		createFlags.Keys = []string{"014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"}
+		createFlags.Weights = []int{1000}
+		createFlags.IdempotencyKey = "ci-3"
+		defer func() { createFlags.IdempotencyKey = "" }()
+
+		tx := transactions.New()
+		pendingIdempotencyRecord(t, state, rw, "ci-3", tx)
+
+		srv.GetTransactionByID.Return(nil, nil, status.Error(codes.NotFound, "transaction not found"))
+		srv.GetBlock.Return(&flow.Block{BlockHeader: flow.BlockHeader{Height: 100}}, nil)
+		srv.SendSignedTransaction.Run(func(args mock.Arguments) {
+			assert.Equal(t, tx.FlowTransaction().ID(), args.Get(1).(*transactions.Transaction).FlowTransaction().ID())
+		}).Return(tests.NewTransaction(), tests.NewAccountCreateResult(flow.HexToAddress("03")), nil)
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000003", result.(*accountResult).Address.Hex())
+		srv.Mock.AssertNumberOfCalls(t, "SendSignedTransaction", 1)
+		srv.Mock.AssertNotCalled(t, "CreateAccount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success idempotency key expired transaction", func(t *testing.T) {
+		srv, state, rw := util.TestMocks(t)
+		createFlags.Keys = []string{"014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"}
+		createFlags.Weights = []int{1000}
+		createFlags.IdempotencyKey = "ci-4"
+		defer func() { createFlags.IdempotencyKey = "" }()
+
+		tx := transactions.New()
+		pendingIdempotencyRecord(t, state, rw, "ci-4", tx)
+
+		srv.GetTransactionByID.Return(nil, nil, status.Error(codes.NotFound, "transaction not found"))
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			height := uint64(1000)
+			if args.Get(1).(flowkit.BlockQuery).ID != nil {
+				height = 100
+			}
+			srv.GetBlock.Return(&flow.Block{BlockHeader: flow.BlockHeader{Height: height}}, nil)
+		})
+		srv.CreateAccount.Return(tests.NewAccountWithAddress("04"), util.TestID, nil)
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000004", result.(*accountResult).Address.Hex())
+		srv.Mock.AssertNotCalled(t, "SendSignedTransaction", mock.Anything, mock.Anything)
+		srv.Mock.AssertNumberOfCalls(t, "CreateAccount", 1)
+	})
+
+	t.Run("Fail idempotency key with bulk flags", func(t *testing.T) {
+		createFlags.IdempotencyKey = "ci-5"
+		createFlags.Count = 2
+		defer func() {
+			createFlags.IdempotencyKey = ""
+			createFlags.Count = 0
+		}()
+
+		_, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "the idempotency key can't be used with the --deploy, --count, --keys-file or --use-faucet flags")
+	})
+
+	t.Run("Success deploy contracts", func(t *testing.T) {
+		srv, state, rw := util.TestMocks(t)
+		keys := createFlags.Keys
+		createFlags.Keys = nil
+		createFlags.Name = "alice"
+		createFlags.Deploy = []string{fmt.Sprintf("Hello:%s", tests.ContractHelloString.Filename)}
+		defer func() {
+			createFlags.Keys = keys
+			createFlags.Name = ""
+			createFlags.Deploy = nil
+		}()
+
+		srv.Mock.On("GenerateKey", mock.Anything, mock.Anything, mock.Anything).Return(tests.PrivKeys()[0], nil)
+		srv.Mock.
+			On("CreateAccount", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				assert.Equal(t, tests.PrivKeys()[0].PublicKey(), args.Get(2).([]accounts.PublicKey)[0].Public)
+				contract := args.Get(3).(flowkit.Script)
+				assert.Equal(t, tests.ContractHelloString.Filename, contract.Location)
+				assert.Equal(t, tests.ContractHelloString.Source, contract.Code)
+			}).
+			Return(tests.NewAccountWithAddress("0x03"), flow.EmptyID, nil)
+
+		globalFlags := command.GlobalFlags{ConfigPaths: []string{"project/flow.json"}}
+		result, err := create([]string{}, globalFlags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000003", result.(*accountResult).Address.Hex())
+
+		account, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("0x03"), account.Address)
+
+		contract, err := state.Contracts().ByName("Hello")
+		require.NoError(t, err)
+		assert.Equal(t, tests.ContractHelloString.Filename, contract.Location)
+
+		deployment := state.Deployments().ByAccountAndNetwork("alice", "emulator")
+		require.NotNil(t, deployment)
+		assert.Equal(t, "Hello", deployment.Contracts[0].Name)
+
+		key, err := rw.ReadFile("alice.pkey")
+		require.NoError(t, err)
+		assert.Equal(t, tests.PrivKeys()[0].String(), string(key))
+
+		config, err := rw.ReadFile("project/flow.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(config), `"alice"`)
+	})
+
+	t.Run("Fail deploy contract name mismatch", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		keys := createFlags.Keys
+		createFlags.Keys = nil
+		createFlags.Name = "alice"
+		createFlags.Deploy = []string{fmt.Sprintf("Foo:%s", tests.ContractHelloString.Filename)}
+		defer func() {
+			createFlags.Keys = keys
+			createFlags.Name = ""
+			createFlags.Deploy = nil
+		}()
+
+		_, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "contract Hello declared in contractHello.cdc doesn't match the name Foo")
+
+		createFlags.Name = ""
+		_, err = create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "account name is required when deploying contracts, provide it using the --name flag")
+	})
+
+	t.Run("Success bulk", func(t *testing.T) {
+		srv, state, rw := util.TestMocks(t)
+		keys := createFlags.Keys
+		createFlags.Keys = nil
+		createFlags.Count = 4
+		createFlags.BatchSize = 2
+		createFlags.Name = "load"
+		createFlags.AccountsFile = "accounts.csv"
+		defer func() {
+			createFlags.Keys = keys
+			createFlags.Count = 0
+			createFlags.BatchSize = 10
+			createFlags.Name = ""
+			createFlags.AccountsFile = ""
+		}()
+
+		created := tests.NewAccountCreateResult(flow.HexToAddress("0x03"))
+		created.Events = append(created.Events, tests.NewAccountCreateResult(flow.HexToAddress("0x04")).Events...)
+
+		srv.Mock.On("GenerateKey", mock.Anything, mock.Anything, mock.Anything).Return(tests.PrivKeys()[0], nil)
+		srv.BuildTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(3).(flowkit.Script)
+			assert.Contains(t, string(script.Code), "AuthAccount(payer: signer)")
+			require.Len(t, script.Args, 1)
+			assert.Len(t, script.Args[0].(cadence.Array).Values, 2)
+		}).Return(transactions.New(), nil)
+		srv.SignTransactionPayload.Return(transactions.New(), nil)
+		srv.SendSignedTransaction.Return(tests.NewTransaction(), created, nil)
+
+		globalFlags := command.GlobalFlags{ConfigPaths: []string{"project/flow.json"}}
+		result, err := create([]string{}, globalFlags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "0x0000000000000003, 0x0000000000000004, 0x0000000000000003, 0x0000000000000004", result.Oneliner())
+		srv.Mock.AssertNumberOfCalls(t, "SendSignedTransaction", 2)
+
+		account, err := state.Accounts().ByName("load-4")
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("0x04"), account.Address)
+
+		file, err := rw.ReadFile("accounts.csv")
+		require.NoError(t, err)
+		assert.Contains(t, string(file), "index,address,public_key,private_key,sig_algo,hash_algo,name")
+		assert.Contains(t, string(file), hex.EncodeToString(tests.PrivKeys()[0].Encode()))
+	})
+
+	t.Run("Success bulk keys file", func(t *testing.T) {
+		srv, state, rw := util.TestMocks(t)
+		pkey := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
+		require.NoError(t, rw.WriteFile("keys.csv", []byte("public_key,hash_algo\n"+pkey+",SHA2_256\n"), 0644))
+
+		keys := createFlags.Keys
+		createFlags.Keys = nil
+		createFlags.KeysFile = "keys.csv"
+		defer func() {
+			createFlags.Keys = keys
+			createFlags.KeysFile = ""
+		}()
+
+		srv.BuildTransaction.Return(transactions.New(), nil)
+		srv.SignTransactionPayload.Return(transactions.New(), nil)
+		srv.SendSignedTransaction.Return(tests.NewTransaction(), tests.NewAccountCreateResult(flow.HexToAddress("0x03")), nil)
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "0x0000000000000003", result.Oneliner())
+		assert.Equal(t, "SHA2_256", result.(*bulkCreateResult).accounts[0].HashAlgo)
+
+		createFlags.Name = "load"
+		defer func() { createFlags.Name = "" }()
+		_, err = create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "accounts can only be added to the configuration with their private keys, the key in row 1 has none")
+	})
+
+	t.Run("Fail bulk generated keys not saved", func(t *testing.T) {
+		keys := createFlags.Keys
+		createFlags.Keys = nil
+		createFlags.Count = 2
+		defer func() {
+			createFlags.Keys = keys
+			createFlags.Count = 0
+		}()
+
+		_, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "generated keys must be saved, provide a name for the accounts added to the configuration or an accounts file")
+	})
+
 	t.Run("Fail parse keys", func(t *testing.T) {
 		_, err := parsePublicKeys([]string{"invalid"}, []crypto.SignatureAlgorithm{crypto.ECDSA_P256})
 		assert.EqualError(t, err, "failed decoding public key: invalid with error: encoding/hex: invalid byte: U+0069 'i'")
@@ -264,19 +813,153 @@ func Test_Get(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 	})
+
+	t.Run("Success at block height", func(t *testing.T) {
+		getFlags.BlockHeight = 42
+		defer func() { getFlags.BlockHeight = 0 }()
+
+		srv.GetAccountAtBlockHeight.Run(func(args mock.Arguments) {
+			assert.Equal(t, uint64(42), args.Get(2).(uint64))
+			srv.GetAccountAtBlockHeight.Return(tests.NewAccountWithAddress("0x01"), nil)
+		})
+
+		result, err := get([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, nil, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000001", result.(*accountResult).Address.Hex())
+		srv.Mock.AssertNumberOfCalls(t, "GetAccountAtBlockHeight", 1)
+	})
+
+	t.Run("Fail invalid configuration", func(t *testing.T) {
+		_, _, rw := util.TestMocks(t)
+		require.NoError(t, rw.WriteFile("invalid.json", []byte(`{"networks": {"emulator": 5}}`), 0644))
+
+		_, err := get([]string{"0x01"}, command.GlobalFlags{ConfigPaths: []string{"invalid.json"}}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "configuration syntax error")
+	})
+
+	t.Run("Resolve account name by network chain", func(t *testing.T) {
+		srv, state, rw := util.TestMocks(t)
+		require.NoError(t, state.Save("flow.json"))
+		globalFlags := command.GlobalFlags{ConfigPaths: []string{"flow.json"}}
+		name := config.DefaultEmulator.ServiceAccount
+
+		srv.Network.Return(config.Network{Name: "mainnet-fork", Host: "127.0.0.1:3569"})
+		result, err := get([]string{name}, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+
+		srv.Network.Return(config.Network{Name: "custom-mainnet", Host: config.MainnetNetwork.Host})
+		_, err = get([]string{name}, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "which can't be used on the custom-mainnet network")
+	})
+
+	t.Run("Success without configuration", func(t *testing.T) {
+		_, _, rw := util.TestMocks(t)
+
+		result, err := get([]string{"0x01"}, command.GlobalFlags{ConfigPaths: []string{"missing.json"}}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
 }
 
-func Test_Result(t *testing.T) {
+func Test_Diff(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
 	pkey, _ := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, "a60b9c10a39070806d37d8f0e6be081e7af2d18cd92ee1bd850d10c994d61d538d2693eebe8faa94fea59ee579ea65a70ed897b05126e508e74f55b8669eec6b")
-	account := &flow.Account{
+	before := &flow.Account{
 		Address: flow.HexToAddress("0x01"),
-		Balance: uint64(1),
-		Keys: []*flow.AccountKey{{
-			Index:          0,
-			PublicKey:      pkey,
-			SigAlgo:        crypto.ECDSA_P256,
-			HashAlgo:       crypto.SHA3_256,
-			Weight:         1000,
+		Balance: 150000000,
+		Keys: []*flow.AccountKey{
+			{Index: 0, PublicKey: pkey, Weight: 1000},
+			{Index: 1, PublicKey: pkey, Weight: 500},
+		},
+		Contracts: map[string][]byte{
+			"Kept":    []byte("pub contract Kept {}"),
+			"Updated": []byte("pub contract Updated {}"),
+			"Removed": []byte("pub contract Removed {}"),
+		},
+	}
+	after := &flow.Account{
+		Address: flow.HexToAddress("0x01"),
+		Balance: 100000000,
+		Keys: []*flow.AccountKey{
+			{Index: 0, PublicKey: pkey, Weight: 1000},
+			{Index: 1, PublicKey: pkey, Weight: 500, Revoked: true},
+			{Index: 2, PublicKey: pkey, Weight: 1000},
+		},
+		Contracts: map[string][]byte{
+			"Added":   []byte("pub contract Added {}"),
+			"Kept":    []byte("pub contract Kept {}"),
+			"Updated": []byte("pub contract Updated { pub let a: Int; init() { self.a = 1 } }"),
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		diffFlags.From = 10
+		defer func() { diffFlags.From = 0 }()
+
+		latest := tests.NewBlock()
+		latest.Height = 20
+		srv.GetBlock.Return(latest, nil)
+		srv.GetAccountAtBlockHeight.Run(func(args mock.Arguments) {
+			if args.Get(2).(uint64) == 10 {
+				srv.GetAccountAtBlockHeight.Return(before, nil)
+			} else {
+				srv.GetAccountAtBlockHeight.Return(after, nil)
+			}
+		})
+
+		result, err := diff([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, diffOutput{
+			Address:    "0x0000000000000001",
+			FromHeight: 10,
+			ToHeight:   20,
+			Balance:    balanceChange{From: "1.50000000", To: "1.00000000", Change: "-0.50000000"},
+			Keys: []keyChange{
+				{Index: 1, Change: "revoked", Weight: 500},
+				{Index: 2, Change: "added", Weight: 1000},
+			},
+			Contracts: []contractChange{
+				{Name: "Added", Change: "added"},
+				{Name: "Removed", Change: "removed"},
+				{Name: "Updated", Change: "updated"},
+			},
+		}, result.JSON())
+	})
+
+	t.Run("Success unchanged", func(t *testing.T) {
+		result := newAccountDiff(before, before, 10, 20)
+		assert.Equal(t, "+0.00000000", result.Balance.Change)
+		assert.Contains(t, result.String(), "No changes")
+	})
+
+	t.Run("Fail heights", func(t *testing.T) {
+		_, err := diff([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "provide the block height to compare the account from using the --from flag")
+
+		diffFlags.From = 30
+		diffFlags.To = 20
+		defer func() {
+			diffFlags.From = 0
+			diffFlags.To = 0
+		}()
+		_, err = diff([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "the block height to compare from must be lower than 20")
+	})
+}
+
+func Test_Result(t *testing.T) {
+	pkey, _ := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, "a60b9c10a39070806d37d8f0e6be081e7af2d18cd92ee1bd850d10c994d61d538d2693eebe8faa94fea59ee579ea65a70ed897b05126e508e74f55b8669eec6b")
+	account := &flow.Account{
+		Address: flow.HexToAddress("0x01"),
+		Balance: uint64(1),
+		Keys: []*flow.AccountKey{{
+			Index:          0,
+			PublicKey:      pkey,
+			SigAlgo:        crypto.ECDSA_P256,
+			HashAlgo:       crypto.SHA3_256,
+			Weight:         1000,
 			SequenceNumber: 0,
 			Revoked:        false,
 		}},
@@ -312,3 +995,1251 @@ Contracts (hidden, use --include contracts)`, "\n"), result.String())
 	}, result.JSON())
 
 }
+
+func Test_RotateKey(t *testing.T) {
+	newKey := tests.PrivKeys()[0]
+
+	// account returns the emulator account with the key of the configuration as key 0
+	account := func(t *testing.T, state *flowkit.State) *flow.Account {
+		signer, err := state.EmulatorServiceAccount()
+		require.NoError(t, err)
+		oldKey, err := signer.Key.PrivateKey()
+		require.NoError(t, err)
+
+		return &flow.Account{
+			Address: signer.Address,
+			Keys: []*flow.AccountKey{
+				{Index: 0, PublicKey: (*oldKey).PublicKey(), SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000},
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Mock.On("GenerateKey", mock.Anything, mock.Anything, mock.Anything).Return(newKey, nil)
+		account := account(t, state)
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(account, nil)
+		globalFlags := command.GlobalFlags{Yes: true, ConfigPaths: []string{"flow.json"}}
+
+		var scripts []string
+		var indexes []int
+		tx := transactions.New()
+		srv.BuildTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(3).(flowkit.Script)
+			scripts = append(scripts, string(script.Code))
+			indexes = append(indexes, args.Get(2).(int))
+			if string(script.Code) == addKeyTransaction {
+				// the key is added once the transaction is sent
+				account.Keys = append(account.Keys, &flow.AccountKey{
+					Index: 1, PublicKey: newKey.PublicKey(), SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000,
+				})
+			} else {
+				assert.Equal(t, "0", script.Args[0].String())
+			}
+		}).Return(tx, nil)
+		srv.SignTransactionPayload.Return(tx, nil)
+		srv.SendSignedTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		result, err := rotateKey([]string{"emulator-account"}, globalFlags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, []string{addKeyTransaction, revokeKeyTransaction}, scripts)
+		assert.Equal(t, []int{0, 1}, indexes)
+		assert.Equal(t, 1, result.JSON().(map[string]any)["index"])
+		assert.Equal(t, true, result.JSON().(map[string]any)["revoked"])
+
+		signer, err := state.Accounts().ByName("emulator-account")
+		require.NoError(t, err)
+		assert.Equal(t, 1, signer.Key.Index())
+		saved, err := signer.Key.PrivateKey()
+		require.NoError(t, err)
+		assert.Equal(t, newKey.String(), (*saved).String())
+	})
+
+	t.Run("Fail new key not active", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Mock.On("GenerateKey", mock.Anything, mock.Anything, mock.Anything).Return(newKey, nil)
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(account(t, state), nil)
+
+		tx := transactions.New()
+		srv.BuildTransaction.Return(tx, nil)
+		srv.SignTransactionPayload.Return(tx, nil)
+		srv.SendSignedTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		_, err := rotateKey([]string{"emulator-account"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "the new key was not found on account 0xf8d6e0586b0a20c7")
+
+		signer, err := state.Accounts().ByName("emulator-account")
+		require.NoError(t, err)
+		assert.Equal(t, 0, signer.Key.Index())
+	})
+
+	t.Run("Fail low weight", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		account := account(t, state)
+		account.Keys[0].Weight = 500
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(account, nil)
+
+		_, err := rotateKey([]string{"emulator-account"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "key 0 of account 0xf8d6e0586b0a20c7 has a weight of 500, below the 1000 required to add and revoke keys alone")
+	})
+}
+
+func Test_Faucet(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	srv.Network.Return(config.TestnetNetwork)
+
+	created := flow.HexToAddress("0x179b6b1cb6755e31")
+	txID := flow.HexToID("a8c7")
+
+	var requests []string
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.URL.Path+" "+string(body))
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(faucetResponse{TransactionID: txID.String()})
+	}))
+	defer server.Close()
+
+	defaultURL := faucetURL
+	faucetURL = server.URL
+	faucetPollInterval = time.Millisecond
+	defer func() {
+		faucetURL = defaultURL
+		faucetPollInterval = time.Second
+	}()
+
+	t.Run("Success create", func(t *testing.T) {
+		requests = nil
+		pkey := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
+		createFlags.Keys = []string{pkey}
+		createFlags.SigAlgo = []string{"ECDSA_P256"}
+		createFlags.HashAlgo = []string{"SHA3_256"}
+		createFlags.Weights = []int{1000}
+		createFlags.UseFaucet = true
+		createFlags.FaucetToken = "secret"
+		defer func() {
+			createFlags.UseFaucet = false
+			createFlags.FaucetToken = ""
+		}()
+
+		srv.GetTransactionByID.Run(func(args mock.Arguments) {
+			assert.Equal(t, txID, args.Get(1).(flow.Identifier))
+		}).Return(tests.NewTransaction(), tests.NewAccountCreateResult(created), nil)
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			srv.GetAccount.Return(tests.NewAccountWithAddress(args.Get(1).(flow.Address).String()), nil)
+		})
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, created, result.(*accountResult).Address)
+		assert.Equal(t, txID, result.(*accountResult).transactionID)
+		assert.Equal(t, []string{
+			`/api/v1/accounts {"publicKey":"` + pkey + `","signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256"}`,
+		}, requests)
+		assert.Equal(t, "Bearer secret", authorization)
+		srv.Mock.AssertNotCalled(t, "CreateAccount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success fund", func(t *testing.T) {
+		requests = nil
+		t.Setenv(faucetTokenEnv, "from-env")
+
+		srv.GetTransactionByID.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		result, err := fund([]string{"0x179b6b1cb6755e31"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, created, result.(*accountResult).Address)
+		assert.Equal(t, []string{`/api/v1/fund {"address":"0x179b6b1cb6755e31"}`}, requests)
+		assert.Equal(t, "Bearer from-env", authorization)
+	})
+
+	t.Run("Fail not testnet", func(t *testing.T) {
+		srv.Network.Return(config.EmulatorNetwork)
+		defer srv.Network.Return(config.TestnetNetwork)
+
+		_, err := fund([]string{"0x179b6b1cb6755e31"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "the faucet is only available on testnet, use the --network testnet flag")
+	})
+
+	t.Run("Fail rate limited", func(t *testing.T) {
+		limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer limited.Close()
+		faucetURL = limited.URL
+		defer func() { faucetURL = server.URL }()
+
+		_, err := fund([]string{"0x179b6b1cb6755e31"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "could not fund the account using the faucet: rate limited, provide an API token using the --faucet-token flag or the FLOW_FAUCET_TOKEN environment variable")
+	})
+
+	t.Run("Fail unavailable faucet not retried", func(t *testing.T) {
+		// the faucet might have handled the request, so it isn't sent again
+		attempts := 0
+		unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer unavailable.Close()
+		faucetURL = unavailable.URL
+		util.DefaultHTTPOptions.Backoff = time.Millisecond
+		defer func() {
+			faucetURL = server.URL
+			util.DefaultHTTPOptions.Backoff = 500 * time.Millisecond
+		}()
+
+		_, err := fund([]string{"0x179b6b1cb6755e31"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("Success retry unreachable faucet", func(t *testing.T) {
+		// the request is sent again if no connection to the faucet was made
+		closed, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := closed.Addr().String()
+		require.NoError(t, closed.Close())
+
+		faucetURL = "http://" + address
+		util.DefaultHTTPOptions.Backoff = 100 * time.Millisecond
+		defer func() {
+			faucetURL = server.URL
+			util.DefaultHTTPOptions.Backoff = 500 * time.Millisecond
+		}()
+
+		started := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(faucetResponse{TransactionID: txID.String()})
+		})}
+		defer started.Close()
+		time.AfterFunc(20*time.Millisecond, func() {
+			listener, err := net.Listen("tcp", address)
+			if err == nil {
+				_ = started.Serve(listener)
+			}
+		})
+
+		result, err := fund([]string{"0x179b6b1cb6755e31"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, txID, result.(*accountResult).transactionID)
+	})
+
+	t.Run("Success resume", func(t *testing.T) {
+		requests = nil
+		fundFlags.TransactionID = txID.String()
+		defer func() { fundFlags.TransactionID = "" }()
+
+		result, err := fund([]string{"0x179b6b1cb6755e31"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, txID, result.(*accountResult).transactionID)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("Fail transaction", func(t *testing.T) {
+		failed := tests.NewTransactionResult(nil)
+		failed.Error = fmt.Errorf("insufficient faucet balance")
+		srv.GetTransactionByID.Return(tests.NewTransaction(), failed, nil)
+
+		_, err := fund([]string{"0x179b6b1cb6755e31"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, fmt.Sprintf("faucet transaction %s failed: insufficient faucet balance", txID))
+	})
+}
+
+func Test_SetWeights(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	pkey, _ := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, "a60b9c10a39070806d37d8f0e6be081e7af2d18cd92ee1bd850d10c994d61d538d2693eebe8faa94fea59ee579ea65a70ed897b05126e508e74f55b8669eec6b")
+	account := &flow.Account{
+		Address: flow.HexToAddress("0xf8d6e0586b0a20c7"),
+		Keys: []*flow.AccountKey{
+			{Index: 0, PublicKey: pkey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000},
+			{Index: 1, PublicKey: pkey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000},
+			{Index: 2, PublicKey: pkey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000, Revoked: true},
+		},
+	}
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(account, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		setWeightsFlags.Keys = []string{"0=500", "1=500"}
+
+		tx := transactions.New()
+		srv.BuildTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(3).(flowkit.Script)
+			assert.Equal(t, setWeightsTransaction, string(script.Code))
+			require.Len(t, script.Args, 2)
+			assert.Len(t, script.Args[0].(cadence.Array).Values, 2)
+			assert.Equal(t, "[0, 1]", script.Args[1].String())
+		}).Return(tx, nil)
+		srv.SignTransactionPayload.Return(tx, nil)
+		srv.SendSignedTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		result, err := setWeights([]string{"emulator-account"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, []map[string]any{
+			{"revokedIndex": 0, "index": 3, "weight": 500},
+			{"revokedIndex": 1, "index": 4, "weight": 500},
+		}, result.JSON().(map[string]any)["keys"])
+	})
+
+	t.Run("Fail below threshold", func(t *testing.T) {
+		_, err := planKeyWeights(account, map[int]int{0: 500, 1: 400})
+		assert.EqualError(t, err, "the keys would have a total weight of 900, below the 1000 required to sign transactions for the account")
+	})
+
+	t.Run("Fail revoked key", func(t *testing.T) {
+		_, err := planKeyWeights(account, map[int]int{2: 500})
+		assert.EqualError(t, err, "key 2 of account 0xf8d6e0586b0a20c7 is revoked")
+	})
+
+	t.Run("Fail missing key", func(t *testing.T) {
+		_, err := planKeyWeights(account, map[int]int{5: 500})
+		assert.EqualError(t, err, "key 5 doesn't exist on account 0xf8d6e0586b0a20c7")
+	})
+
+	t.Run("Fail unchanged", func(t *testing.T) {
+		_, err := planKeyWeights(account, map[int]int{0: 1000})
+		assert.EqualError(t, err, "the keys already have the weights")
+	})
+
+	t.Run("Fail parse", func(t *testing.T) {
+		_, err := parseKeyWeights([]string{"0:500"})
+		assert.EqualError(t, err, "invalid key 0:500, expected the format index=weight")
+
+		_, err = parseKeyWeights([]string{"0=1500"})
+		assert.EqualError(t, err, "invalid key weight 1500, must be between 0 and 1000")
+
+		_, err = parseKeyWeights([]string{"0=500", "0=400"})
+		assert.EqualError(t, err, "key 0 provided multiple times")
+	})
+}
+
+func Test_Keys(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	pkey, _ := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, "a60b9c10a39070806d37d8f0e6be081e7af2d18cd92ee1bd850d10c994d61d538d2693eebe8faa94fea59ee579ea65a70ed897b05126e508e74f55b8669eec6b")
+	account := &flow.Account{
+		Address: flow.HexToAddress("0xf8d6e0586b0a20c7"),
+		Keys: []*flow.AccountKey{
+			{Index: 0, PublicKey: pkey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000, SequenceNumber: 4},
+			{Index: 1, PublicKey: pkey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 500},
+			{Index: 2, PublicKey: pkey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000, Revoked: true},
+		},
+	}
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(account, nil)
+
+	t.Run("Success list", func(t *testing.T) {
+		result, err := keysList([]string{"0xf8d6e0586b0a20c7"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		keys := result.JSON().([]accountKeyOutput)
+		require.Len(t, keys, 3)
+		assert.Equal(t, accountKeyOutput{
+			Index:          0,
+			PublicKey:      "a60b9c10a39070806d37d8f0e6be081e7af2d18cd92ee1bd850d10c994d61d538d2693eebe8faa94fea59ee579ea65a70ed897b05126e508e74f55b8669eec6b",
+			Weight:         1000,
+			SequenceNumber: 4,
+			SigAlgo:        "ECDSA_P256",
+			HashAlgo:       "SHA3_256",
+		}, keys[0])
+		assert.True(t, keys[2].Revoked)
+		assert.Equal(t, "Keys: 3, Revoked: 1", result.Oneliner())
+	})
+
+	t.Run("Success revoke", func(t *testing.T) {
+		keysRevokeFlags.Index = 1
+		defer func() { keysRevokeFlags.Index = -1 }()
+
+		tx := transactions.New()
+		srv.BuildTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(3).(flowkit.Script)
+			assert.Equal(t, revokeKeyTransaction, string(script.Code))
+			assert.Equal(t, []cadence.Value{cadence.NewInt(1)}, script.Args)
+		}).Return(tx, nil)
+		srv.SignTransactionPayload.Return(tx, nil)
+		srv.SendSignedTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		result, err := keysRevoke([]string{"emulator-account"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.JSON().(map[string]any)["index"])
+	})
+
+	t.Run("Fail missing index", func(t *testing.T) {
+		keysRevokeFlags.Index = -1
+
+		_, err := keysRevoke([]string{"emulator-account"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "provide the index of the key to revoke using the --index flag")
+	})
+
+	t.Run("Fail revoke", func(t *testing.T) {
+		assert.EqualError(t, checkRevokeKey(account, 0), "the keys would have a total weight of 500, below the 1000 required to sign transactions for the account")
+		assert.EqualError(t, checkRevokeKey(account, 2), "key 2 of account 0xf8d6e0586b0a20c7 is already revoked")
+		assert.EqualError(t, checkRevokeKey(account, 3), "key 3 doesn't exist on account 0xf8d6e0586b0a20c7")
+	})
+}
+
+func Test_List(t *testing.T) {
+	_, state, _ := util.TestMocks(t)
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: "bob", Address: flow.HexToAddress("0x01cf0e2f2f715450")})
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: "alice", Address: flow.HexToAddress("0x9a0766d93b6608b7")})
+
+	// accounts are fetched in parallel so the results are set by address instead of overriding the default
+	srv := &mocks.Services{}
+	emulatorAccount := tests.NewAccountWithAddress("0xf8d6e0586b0a20c7")
+	emulatorAccount.Contracts = map[string][]byte{"Foo": nil, "Bar": nil}
+	srv.On("GetAccount", mock.Anything, flow.HexToAddress("0xf8d6e0586b0a20c7")).Return(emulatorAccount, nil)
+	srv.On("GetAccount", mock.Anything, flow.HexToAddress("0x01cf0e2f2f715450")).Return(nil, fmt.Errorf("account not found"))
+	srv.On("GetAccount", mock.Anything, flow.HexToAddress("0x9a0766d93b6608b7")).Return(tests.NewAccountWithAddress("0x9a0766d93b6608b7"), nil)
+	network := srv.On("Network").Return(config.EmulatorNetwork)
+
+	result, err := list(nil, command.GlobalFlags{}, util.NoLogger, srv, state)
+	require.NoError(t, err)
+
+	listed := result.JSON().([]listedAccountOutput)
+	require.Len(t, listed, 2)
+	assert.Equal(t, "emulator-account", listed[0].Name)
+	assert.Equal(t, "0xf8d6e0586b0a20c7", listed[0].Address)
+	assert.Equal(t, []string{"Bar", "Foo"}, listed[0].Contracts)
+	assert.Empty(t, listed[0].Error)
+	assert.Equal(t, listedAccountOutput{
+		Name:      "bob",
+		Address:   "0x01cf0e2f2f715450",
+		Contracts: []string{},
+		Error:     "account not found",
+	}, listed[1])
+
+	network.Return(config.TestnetNetwork)
+
+	result, err = list(nil, command.GlobalFlags{}, util.NoLogger, srv, state)
+	require.NoError(t, err)
+	assert.Equal(t, "Accounts: alice", result.Oneliner())
+}
+
+func Test_Contracts(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	bar := []byte("import Foo from 0xf8d6e0586b0a20c7\n\npub contract Bar {}\n")
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(&flow.Account{
+		Address: flow.HexToAddress("0xf8d6e0586b0a20c7"),
+		Contracts: map[string][]byte{
+			"Foo": []byte("pub contract Foo {}"),
+			"Bar": bar,
+		},
+	}, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := listContracts([]string{"0xf8d6e0586b0a20c7"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, []deployedContractOutput{{
+			Name:   "Bar",
+			Size:   len(bar),
+			SHA256: hashCode(bar),
+		}, {
+			Name:   "Foo",
+			Size:   19,
+			SHA256: hashCode([]byte("pub contract Foo {}")),
+		}}, result.JSON())
+	})
+
+	t.Run("Fail compare local without configuration", func(t *testing.T) {
+		contractsFlags.CompareLocal = true
+		defer func() { contractsFlags.CompareLocal = false }()
+
+		_, err := listContracts([]string{"0xf8d6e0586b0a20c7"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "comparing with the local contracts requires a project configuration")
+	})
+
+	t.Run("Compare local", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("contracts/Foo.cdc", []byte("pub contract Foo { pub let x: Int\n init() { self.x = 1 } }"), 0644))
+		require.NoError(t, rw.WriteFile("contracts/Bar.cdc", []byte("import Foo from \"./Foo.cdc\"\n\npub contract Bar {}\n"), 0644))
+		state.Contracts().AddOrUpdate(config.Contract{Name: "Foo", Location: "contracts/Foo.cdc"})
+		state.Contracts().AddOrUpdate(config.Contract{Name: "Bar", Location: "contracts/Bar.cdc"})
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "emulator",
+			Account: "emulator-account",
+			Contracts: []config.ContractDeployment{
+				{Name: "Foo"},
+				{Name: "Bar"},
+			},
+		})
+
+		local, err := compareLocal("Bar", bar, state, config.EmulatorNetwork)
+		require.NoError(t, err)
+		assert.Equal(t, localMatch, local)
+
+		local, err = compareLocal("Foo", []byte("pub contract Foo {}"), state, config.EmulatorNetwork)
+		require.NoError(t, err)
+		assert.Equal(t, localDiffers, local)
+
+		local, err = compareLocal("Zoo", []byte("pub contract Zoo {}"), state, config.EmulatorNetwork)
+		require.NoError(t, err)
+		assert.Equal(t, localMissing, local)
+	})
+}
+
+func Test_GetContract(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	code := []byte("pub contract Foo {}")
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(&flow.Account{
+		Address:   flow.HexToAddress("0xf8d6e0586b0a20c7"),
+		Contracts: map[string][]byte{"Foo": code},
+	}, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := getContract([]string{"0xf8d6e0586b0a20c7", "Foo"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, string(code), result.String())
+		assert.Equal(t, contractCodeOutput{
+			Address: "0xf8d6e0586b0a20c7",
+			Name:    "Foo",
+			Size:    len(code),
+			SHA256:  hashCode(code),
+			Code:    string(code),
+		}, result.JSON())
+	})
+
+	t.Run("Fail not deployed", func(t *testing.T) {
+		_, err := getContract([]string{"0xf8d6e0586b0a20c7", "Bar"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "contract Bar is not deployed to account 0xf8d6e0586b0a20c7")
+	})
+}
+
+func Test_ExportContracts(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	foo := []byte("pub contract Foo {}")
+	bar := []byte("import Foo from 0xf8d6e0586b0a20c7\n\npub contract Bar {}\n")
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(&flow.Account{
+		Address:   flow.HexToAddress("0xf8d6e0586b0a20c7"),
+		Contracts: map[string][]byte{"Foo": foo, "Bar": bar},
+	}, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := exportContracts([]string{"0xf8d6e0586b0a20c7", "out"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, []exportedContractOutput{{
+			Name:   "Bar",
+			File:   filepath.Join("out", "Bar.cdc"),
+			Size:   len(bar),
+			SHA256: hashCode(bar),
+		}, {
+			Name:   "Foo",
+			File:   filepath.Join("out", "Foo.cdc"),
+			Size:   len(foo),
+			SHA256: hashCode(foo),
+		}}, result.JSON())
+
+		exported, err := rw.ReadFile(filepath.Join("out", "Bar.cdc"))
+		require.NoError(t, err)
+		assert.Equal(t, bar, exported)
+	})
+
+	t.Run("Success selected contract", func(t *testing.T) {
+		exportContractsFlags.Contract = []string{"Foo"}
+		defer func() { exportContractsFlags.Contract = nil }()
+
+		result, err := exportContracts([]string{"0xf8d6e0586b0a20c7", "selected"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "Exported contracts: 1", result.Oneliner())
+	})
+
+	t.Run("Fail not deployed", func(t *testing.T) {
+		exportContractsFlags.Contract = []string{"Baz"}
+		defer func() { exportContractsFlags.Contract = nil }()
+
+		_, err := exportContracts([]string{"0xf8d6e0586b0a20c7", "out"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "contract Baz is not deployed to account 0xf8d6e0586b0a20c7")
+	})
+}
+
+func Test_Watch(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+	address := flow.HexToAddress("0x01")
+
+	height := uint64(10)
+	balance := uint64(150000000)
+	storageUsed := uint64(1000)
+	srv.GetBlock.Run(func(mock.Arguments) {
+		block := tests.NewBlock()
+		block.Height = height
+		srv.GetBlock.Return(block, nil)
+	})
+	srv.GetAccountAtBlockHeight.Run(func(args mock.Arguments) {
+		assert.Equal(t, height, args.Get(2).(uint64))
+		srv.GetAccountAtBlockHeight.Return(&flow.Account{Address: address, Balance: balance}, nil)
+	})
+	srv.ExecuteScript.Run(func(args mock.Arguments) {
+		assert.Equal(t, storageScript, string(args.Get(1).(flowkit.Script).Code))
+		assert.Equal(t, height, args.Get(2).(flowkit.ScriptQuery).Height)
+		srv.ExecuteScript.Return(cadence.NewArray([]cadence.Value{
+			cadence.NewUInt64(storageUsed),
+			cadence.NewUInt64(100000),
+		}), nil)
+	})
+
+	watcher := &accountWatcher{flow: srv.Mock, address: address}
+
+	change, err := watcher.poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, watchAccountOutput{
+		BlockHeight:     10,
+		Address:         "0x0000000000000001",
+		Balance:         "1.50000000",
+		StorageUsed:     1000,
+		StorageCapacity: 100000,
+	}, change.JSON())
+
+	height = 11
+	change, err = watcher.poll(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, change)
+
+	height = 12
+	balance = 100000000
+	storageUsed = 1200
+	change, err = watcher.poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, watchAccountOutput{
+		BlockHeight:       12,
+		Address:           "0x0000000000000001",
+		Balance:           "1.00000000",
+		BalanceChange:     "-0.50000000",
+		StorageUsed:       1200,
+		StorageUsedChange: 200,
+		StorageCapacity:   100000,
+	}, change.JSON())
+	assert.Equal(t, "Block 12\tBalance: 1.00000000 (-0.50000000)\tStorage: 1200 / 100000 bytes (+200)\n", change.String())
+}
+
+func Test_Find(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	require.NoError(t, state.Save("flow.json"))
+	globalFlags := command.GlobalFlags{ConfigPaths: []string{"flow.json"}}
+
+	publicKey := "a60b9c10a39070806d37d8f0e6be081e7af2d18cd92ee1bd850d10c994d61d538d2693eebe8faa94fea59ee579ea65a70ed897b05126e508e74f55b8669eec6b"
+	pkey, err := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, publicKey)
+	require.NoError(t, err)
+
+	t.Run("Success key indexer", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/key/"+publicKey, r.URL.Path)
+			_, _ = w.Write([]byte(`{"publicKey":"` + publicKey + `","accounts":[{"address":"0x179b6b1cb6755e31","keyId":2,"weight":1000,"isRevoked":false}]}`))
+		}))
+		defer server.Close()
+
+		defaultURL := util.KeyIndexerURLs[config.TestnetNetwork.Name]
+		util.KeyIndexerURLs[config.TestnetNetwork.Name] = server.URL
+		defer func() { util.KeyIndexerURLs[config.TestnetNetwork.Name] = defaultURL }()
+		srv.Network.Return(config.TestnetNetwork)
+		defer srv.Network.Return(config.EmulatorNetwork)
+
+		findFlags.PublicKey = "0x" + strings.ToUpper(publicKey)
+		defer func() { findFlags.PublicKey = "" }()
+
+		result, err := find(nil, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, []foundKeyOutput{{
+			Address:  "0x179b6b1cb6755e31",
+			KeyIndex: 2,
+			Weight:   1000,
+		}}, result.JSON())
+	})
+
+	t.Run("Success scan configured accounts", func(t *testing.T) {
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(&flow.Account{
+			Address: flow.HexToAddress("0xf8d6e0586b0a20c7"),
+			Keys: []*flow.AccountKey{
+				{Index: 0, PublicKey: pkey, Weight: 1000, Revoked: true},
+				{Index: 1, PublicKey: pkey, Weight: 500},
+			},
+		}, nil)
+
+		findFlags.PublicKey = publicKey
+		defer func() { findFlags.PublicKey = "" }()
+
+		result, err := find(nil, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, []foundKeyOutput{
+			{Address: "0xf8d6e0586b0a20c7", Account: "emulator-account", KeyIndex: 0, Weight: 1000, Revoked: true},
+			{Address: "0xf8d6e0586b0a20c7", Account: "emulator-account", KeyIndex: 1, Weight: 500},
+		}, result.JSON())
+	})
+
+	t.Run("Fail invalid public key", func(t *testing.T) {
+		findFlags.PublicKey = "xyz"
+		defer func() { findFlags.PublicKey = "" }()
+
+		_, err := find(nil, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid public key xyz, expected a hex encoded key")
+	})
+}
+
+func Test_Balances(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	vaultType := &cadence.StructType{
+		QualifiedIdentifier: "VaultBalance",
+		Fields: []cadence.Field{
+			{Identifier: "type", Type: cadence.StringType{}},
+			{Identifier: "path", Type: cadence.StoragePathType{}},
+			{Identifier: "balance", Type: cadence.UFix64Type{}},
+		},
+	}
+	vault := func(typ string, path string, balance string) cadence.Value {
+		amount, _ := cadence.NewUFix64(balance)
+		return cadence.NewStruct([]cadence.Value{
+			cadence.String(typ),
+			cadence.Path{Domain: common.PathDomainStorage, Identifier: path},
+			amount,
+		}).WithType(vaultType)
+	}
+
+	srv.ExecuteScript.Run(func(args mock.Arguments) {
+		script := args.Get(1).(flowkit.Script)
+		assert.Contains(t, string(script.Code), "import FungibleToken from 0xee82856bf20e2aa6")
+		assert.Equal(t, "0xf8d6e0586b0a20c7", script.Args[0].String())
+	}).Return(cadence.NewArray([]cadence.Value{
+		vault("A.01cf0e2f2f715450.ExampleToken.Vault", "exampleTokenVault", "5.0"),
+		vault("A.0ae53cb6e3f42a79.FlowToken.Vault", "flowTokenVault", "10.5"),
+	}), nil)
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := balances([]string{"0xf8d6e0586b0a20c7"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, []vaultBalance{{
+			Token:   "FLOW",
+			Type:    "A.0ae53cb6e3f42a79.FlowToken.Vault",
+			Path:    "/storage/flowTokenVault",
+			Balance: "10.50000000",
+		}, {
+			Token:   "ExampleToken",
+			Type:    "A.01cf0e2f2f715450.ExampleToken.Vault",
+			Path:    "/storage/exampleTokenVault",
+			Balance: "5.00000000",
+		}}, result.JSON())
+		assert.Equal(t, "Address: 0xf8d6e0586b0a20c7, Balances: FLOW: 10.50000000, ExampleToken: 5.00000000", result.Oneliner())
+	})
+
+	t.Run("Success token", func(t *testing.T) {
+		for _, token := range []string{"exampletoken", "A.01cf0e2f2f715450.ExampleToken", "A.01cf0e2f2f715450.ExampleToken.Vault"} {
+			balancesFlags.Token = token
+			result, err := balances([]string{"0xf8d6e0586b0a20c7"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+			require.NoError(t, err, token)
+			assert.Equal(t, "Address: 0xf8d6e0586b0a20c7, Balances: ExampleToken: 5.00000000", result.Oneliner())
+		}
+		balancesFlags.Token = ""
+	})
+
+	t.Run("Fail token not found", func(t *testing.T) {
+		balancesFlags.Token = "FUSD"
+		defer func() { balancesFlags.Token = "" }()
+
+		_, err := balances([]string{"0xf8d6e0586b0a20c7"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "no vault of token FUSD found in account 0xf8d6e0586b0a20c7")
+	})
+}
+
+func Test_NFTs(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	nftType := &cadence.StructType{
+		QualifiedIdentifier: "NFTMetadata",
+		Fields: []cadence.Field{
+			{Identifier: "id", Type: cadence.UInt64Type{}},
+			{Identifier: "name", Type: cadence.NewOptionalType(cadence.StringType{})},
+			{Identifier: "description", Type: cadence.NewOptionalType(cadence.StringType{})},
+			{Identifier: "thumbnail", Type: cadence.NewOptionalType(cadence.StringType{})},
+			{Identifier: "views", Type: cadence.NewVariableSizedArrayType(cadence.StringType{})},
+		},
+	}
+	collectionType := &cadence.StructType{
+		QualifiedIdentifier: "NFTCollection",
+		Fields: []cadence.Field{
+			{Identifier: "type", Type: cadence.StringType{}},
+			{Identifier: "path", Type: cadence.StoragePathType{}},
+			{Identifier: "nfts", Type: cadence.NewVariableSizedArrayType(nftType)},
+		},
+	}
+
+	srv.ExecuteScript.Run(func(args mock.Arguments) {
+		script := args.Get(1).(flowkit.Script)
+		assert.Contains(t, string(script.Code), "import NonFungibleToken from 0xf8d6e0586b0a20c7")
+		assert.Contains(t, string(script.Code), "import MetadataViews from 0xf8d6e0586b0a20c7")
+	}).Return(cadence.NewArray([]cadence.Value{
+		cadence.NewStruct([]cadence.Value{
+			cadence.String("A.01cf0e2f2f715450.ExampleNFT.Collection"),
+			cadence.Path{Domain: common.PathDomainStorage, Identifier: "exampleNFTCollection"},
+			cadence.NewArray([]cadence.Value{
+				cadence.NewStruct([]cadence.Value{
+					cadence.UInt64(7),
+					cadence.NewOptional(nil),
+					cadence.NewOptional(nil),
+					cadence.NewOptional(nil),
+					cadence.NewArray([]cadence.Value{}),
+				}).WithType(nftType),
+				cadence.NewStruct([]cadence.Value{
+					cadence.UInt64(2),
+					cadence.NewOptional(cadence.String("Cat")),
+					cadence.NewOptional(cadence.String("A cat")),
+					cadence.NewOptional(cadence.String("https://example.com/cat.png")),
+					cadence.NewArray([]cadence.Value{cadence.String("A.f8d6e0586b0a20c7.MetadataViews.Display")}),
+				}).WithType(nftType),
+			}),
+		}).WithType(collectionType),
+	}), nil)
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := nfts([]string{"0xf8d6e0586b0a20c7"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, []nftCollection{{
+			Name: "ExampleNFT",
+			Type: "A.01cf0e2f2f715450.ExampleNFT.Collection",
+			Path: "/storage/exampleNFTCollection",
+			NFTs: []nftMetadata{{
+				ID:          2,
+				Name:        "Cat",
+				Description: "A cat",
+				Thumbnail:   "https://example.com/cat.png",
+				Views:       []string{"A.f8d6e0586b0a20c7.MetadataViews.Display"},
+			}, {
+				ID:    7,
+				Views: []string{},
+			}},
+		}}, result.JSON())
+		assert.Equal(t, "Address: 0xf8d6e0586b0a20c7, NFTs: ExampleNFT: 2", result.Oneliner())
+	})
+
+	t.Run("Fail collection not found", func(t *testing.T) {
+		nftsFlags.Collection = "TopShot"
+		defer func() { nftsFlags.Collection = "" }()
+
+		_, err := nfts([]string{"0xf8d6e0586b0a20c7"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "no NFT collection TopShot found in account 0xf8d6e0586b0a20c7")
+	})
+}
+
+func Test_ExplorerLink(t *testing.T) {
+	result := &accountResult{
+		Account: &flow.Account{Address: flow.HexToAddress("0x01cf0e2f2f715450")},
+		link:    command.ExplorerLink{Network: config.TestnetNetwork},
+	}
+
+	assert.Contains(t, result.String(), "Explorer\t https://testnet.flowscan.io/account/0x01cf0e2f2f715450\n")
+	assert.NotContains(t, result.JSON(), "link")
+	assert.NotContains(t, result.Oneliner(), "Explorer")
+
+	result.link.Include = true
+	assert.Equal(t, "https://testnet.flowscan.io/account/0x01cf0e2f2f715450", result.JSON().(map[string]any)["link"])
+	assert.Contains(t, result.Oneliner(), ", Explorer: https://testnet.flowscan.io/account/0x01cf0e2f2f715450")
+
+	// the emulator has no explorer
+	result.link.Network = config.EmulatorNetwork
+	assert.NotContains(t, result.String(), "Explorer")
+	assert.NotContains(t, result.JSON(), "link")
+}
+
+func Test_AccountProof(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	const (
+		nonce     = "75f8587e5bd5f9dcc9909d0dae1f0ac5814458b2ae129620502cb936fde7120a"
+		signature = "fd57d2dd8ed7d7c74a207e22e285f5bb0480d1d27c45797fb0fa9d3fd2808b01d9199e26caa73a3dc1508375a2212f56e1e2413f2a0f30f4638e2503d8b0acd2"
+	)
+	proof := fmt.Sprintf(
+		`{"f_type":"account-proof","f_vsn":"2.0.0","address":"0x0000000000000001","nonce":"%s","signatures":[{"f_type":"CompositeSignature","f_vsn":"1.0.0","addr":"0x0000000000000001","keyId":0,"signature":"%s"}]}`,
+		nonce,
+		signature,
+	)
+
+	accountWithKey := func(revoked bool) *flow.Account {
+		return &flow.Account{
+			Address: flow.HexToAddress("0x01"),
+			Keys: []*flow.AccountKey{{
+				Index:     0,
+				PublicKey: tests.PubKeys()[0],
+				SigAlgo:   crypto.ECDSA_P256,
+				HashAlgo:  crypto.SHA3_256,
+				Weight:    flow.AccountKeyWeightThreshold,
+				Revoked:   revoked,
+			}},
+		}
+	}
+
+	t.Run("Fail prove without app identifier", func(t *testing.T) {
+		_, err := prove(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "app identifier is required, provide it using the --app-identifier flag")
+	})
+
+	t.Run("Fail prove invalid nonce", func(t *testing.T) {
+		proveFlags.AppIdentifier = "Test App"
+		proveFlags.Nonce = "0x1234"
+		defer func() {
+			proveFlags.AppIdentifier = ""
+			proveFlags.Nonce = ""
+		}()
+
+		_, err := prove(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.ErrorIs(t, err, flow.ErrInvalidNonce)
+	})
+
+	t.Run("Success verify proof", func(t *testing.T) {
+		verifyProofFlags.AppIdentifier = "Test App"
+		verifyProofFlags.Nonce = "0x" + nonce
+		defer func() {
+			verifyProofFlags.AppIdentifier = ""
+			verifyProofFlags.Nonce = ""
+		}()
+		srv.GetAccount.Run(func(mock.Arguments) { srv.GetAccount.Return(accountWithKey(false), nil) })
+
+		result, err := verifyProof([]string{proof}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "valid: true, address: 0x0000000000000001, weight: 1000", result.Oneliner())
+	})
+
+	t.Run("Success verify proof service file", func(t *testing.T) {
+		verifyProofFlags.AppIdentifier = "Test App"
+		verifyProofFlags.Nonce = nonce
+		defer func() {
+			verifyProofFlags.AppIdentifier = ""
+			verifyProofFlags.Nonce = ""
+		}()
+		srv.GetAccount.Run(func(mock.Arguments) { srv.GetAccount.Return(accountWithKey(false), nil) })
+
+		service := fmt.Sprintf(`{"f_type":"Service","type":"account-proof","data":%s}`, proof)
+		require.NoError(t, rw.WriteFile("proof.json", []byte(service), 0644))
+
+		result, err := verifyProof([]string{"proof.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.True(t, result.(*verifyProofResult).valid())
+	})
+
+	t.Run("Fail verify invalid proof", func(t *testing.T) {
+		verifyProofFlags.AppIdentifier = "Other App"
+		verifyProofFlags.Nonce = nonce
+		defer func() {
+			verifyProofFlags.AppIdentifier = ""
+			verifyProofFlags.Nonce = ""
+		}()
+		srv.GetAccount.Run(func(mock.Arguments) { srv.GetAccount.Return(accountWithKey(false), nil) })
+
+		_, err := verifyProof([]string{proof}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "account proof is not valid, the valid signatures have a weight of 0 of the required 1000 (key 0: invalid signature)")
+
+		verifyProofFlags.AppIdentifier = "Test App"
+		srv.GetAccount.Run(func(mock.Arguments) { srv.GetAccount.Return(accountWithKey(true), nil) })
+
+		_, err = verifyProof([]string{proof}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "account proof is not valid, the valid signatures have a weight of 0 of the required 1000 (key 0: key is revoked)")
+	})
+
+	t.Run("Fail verify proof without nonce", func(t *testing.T) {
+		verifyProofFlags.AppIdentifier = "Test App"
+		defer func() { verifyProofFlags.AppIdentifier = "" }()
+
+		_, err := verifyProof([]string{proof}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "nonce is required, provide the nonce the proof was requested with using the --nonce flag")
+	})
+
+	t.Run("Fail verify proof nonce mismatch", func(t *testing.T) {
+		verifyProofFlags.AppIdentifier = "Test App"
+		verifyProofFlags.Nonce = "00"
+		defer func() {
+			verifyProofFlags.AppIdentifier = ""
+			verifyProofFlags.Nonce = ""
+		}()
+
+		_, err := verifyProof([]string{proof}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, fmt.Sprintf("proof nonce %s doesn't match the expected nonce 00", nonce))
+	})
+}
+
+func Test_StakingInfo(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	srv.Network.Return(config.TestnetNetwork)
+
+	ufix := func(value string) cadence.UFix64 {
+		amount, _ := cadence.NewUFix64(value)
+		return amount
+	}
+	tokenFields := []cadence.Field{
+		{Identifier: "tokensCommitted", Type: cadence.UFix64Type{}},
+		{Identifier: "tokensStaked", Type: cadence.UFix64Type{}},
+		{Identifier: "tokensUnstaking", Type: cadence.UFix64Type{}},
+		{Identifier: "tokensRewarded", Type: cadence.UFix64Type{}},
+		{Identifier: "tokensUnstaked", Type: cadence.UFix64Type{}},
+		{Identifier: "tokensRequestedToUnstake", Type: cadence.UFix64Type{}},
+	}
+	nodeType := &cadence.StructType{
+		QualifiedIdentifier: "FlowIDTableStaking.NodeInfo",
+		Fields: append([]cadence.Field{
+			{Identifier: "id", Type: cadence.StringType{}},
+			{Identifier: "role", Type: cadence.UInt8Type{}},
+		}, tokenFields...),
+	}
+	delegatorType := &cadence.StructType{
+		QualifiedIdentifier: "FlowIDTableStaking.DelegatorInfo",
+		Fields: append([]cadence.Field{
+			{Identifier: "id", Type: cadence.UInt32Type{}},
+			{Identifier: "nodeID", Type: cadence.StringType{}},
+		}, tokenFields...),
+	}
+
+	srv.ExecuteScript.Run(func(args mock.Arguments) {
+		script := args.Get(1).(flowkit.Script)
+		switch {
+		case strings.Contains(string(script.Code), "getAllNodeInfo"):
+			assert.Equal(t, "0x9eca2b38b18b5dfe", script.Args[0].String())
+			srv.ExecuteScript.Return(cadence.NewArray([]cadence.Value{
+				cadence.NewStruct([]cadence.Value{
+					cadence.String("6a1f"), cadence.UInt8(4),
+					ufix("10.0"), ufix("135000.0"), ufix("0.0"), ufix("12.5"), ufix("0.0"), ufix("0.0"),
+				}).WithType(nodeType),
+			}), nil)
+		case strings.Contains(string(script.Code), "getAllDelegatorInfo"):
+			srv.ExecuteScript.Return(cadence.NewArray([]cadence.Value{
+				cadence.NewStruct([]cadence.Value{
+					cadence.UInt32(1), cadence.String("6a1f"),
+					ufix("0.0"), ufix("100.0"), ufix("50.0"), ufix("1.5"), ufix("0.0"), ufix("0.0"),
+				}).WithType(delegatorType),
+				cadence.NewStruct([]cadence.Value{
+					cadence.UInt32(2), cadence.String("7b2e"),
+					ufix("0.0"), ufix("200.0"), ufix("0.0"), ufix("3.0"), ufix("25.0"), ufix("0.0"),
+				}).WithType(delegatorType),
+			}), nil)
+		default:
+			assert.Equal(t, `"6a1f"`, script.Args[0].String())
+			srv.ExecuteScript.Return(ufix("135300.0"), nil)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := stakingInfo([]string{"0x9eca2b38b18b5dfe"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		json := result.JSON().(map[string]any)
+		assert.Equal(t, []map[string]any{{
+			"id":                       "6a1f",
+			"role":                     uint8(4),
+			"tokensCommitted":          "10.00000000",
+			"tokensStaked":             "135000.00000000",
+			"tokensUnstaking":          "0.00000000",
+			"tokensRewarded":           "12.50000000",
+			"tokensUnstaked":           "0.00000000",
+			"tokensRequestedToUnstake": "0.00000000",
+			"nodeTotalStake":           "135300.00000000",
+		}}, json["staking"])
+
+		totals := json["totals"].(map[string]any)
+		assert.Equal(t, map[string]any{
+			"count":                    2,
+			"tokensCommitted":          "0.00000000",
+			"tokensStaked":             "300.00000000",
+			"tokensRequestedToUnstake": "0.00000000",
+			"tokensUnstaking":          "50.00000000",
+			"tokensUnstaked":           "25.00000000",
+			"tokensRewarded":           "4.50000000",
+		}, totals["delegations"])
+		assert.Contains(t, result.String(), "Delegations\t")
+	})
+
+	t.Run("Fail emulator address", func(t *testing.T) {
+		_, err := stakingInfo([]string{"0xf8d6e0586b0a20c7"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "emulator chain not supported")
+	})
+}
+
+func Test_Setup(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	tokens := tests.NewAccountWithAddress("0ae53cb6e3f42a79")
+	tokens.Contracts = map[string][]byte{
+		"FlowToken":  []byte("import FungibleToken from 0xee82856bf20e2aa6\n\npub contract FlowToken: FungibleToken {}"),
+		"ExampleNFT": []byte("pub contract ExampleNFT: NonFungibleToken, ViewResolver {}"),
+		"Registry":   []byte("pub contract Registry {}"),
+	}
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(tokens, nil)
+
+	var paths []string
+	tx := transactions.New()
+	srv.BuildTransaction.Run(func(args mock.Arguments) {
+		script := args.Get(3).(flowkit.Script)
+		paths = nil
+		for _, arg := range script.Args[2:] {
+			paths = append(paths, arg.String())
+		}
+	}).Return(tx, nil)
+	srv.SignTransactionPayload.Return(tx, nil)
+	srv.SendSignedTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+	t.Run("Success standard fungible token", func(t *testing.T) {
+		setupFlags = flagsSetup{Contract: "FlowToken", Signer: "emulator-account", GasLimit: 1000}
+
+		result, err := setup(nil, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/storage/flowTokenVault", "/public/flowTokenReceiver", "/public/flowTokenBalance"}, paths)
+		assert.Equal(t, "ft", result.JSON().(map[string]any)["type"])
+		assert.Equal(t, "A.0ae53cb6e3f42a79.FlowToken", result.JSON().(map[string]any)["contract"])
+	})
+
+	t.Run("Success non-fungible token with address", func(t *testing.T) {
+		setupFlags = flagsSetup{Contract: "ExampleNFT", Address: "0x0ae53cb6e3f42a79", Signer: "emulator-account", GasLimit: 1000}
+
+		result, err := setup(nil, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/storage/exampleNFTCollection", "/public/exampleNFTCollection"}, paths)
+		assert.Equal(t, "nft", result.JSON().(map[string]any)["type"])
+	})
+
+	t.Run("Success custom paths", func(t *testing.T) {
+		setupFlags = flagsSetup{
+			Contract:    "Registry",
+			Address:     "0x0ae53cb6e3f42a79",
+			Type:        "ft",
+			Signer:      "emulator-account",
+			StoragePath: "/storage/registryTokens",
+			PublicPath:  "registryReceiver",
+			GasLimit:    1000,
+		}
+
+		_, err := setup(nil, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/storage/registryTokens", "/public/registryReceiver", "/public/registryBalance"}, paths)
+	})
+
+	t.Run("Fail unknown contract", func(t *testing.T) {
+		setupFlags = flagsSetup{Contract: "MyToken", Signer: "emulator-account"}
+
+		_, err := setup(nil, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "contract MyToken is not aliased or deployed on network emulator in the configuration, provide its address with the --address flag")
+	})
+
+	t.Run("Fail not a token", func(t *testing.T) {
+		setupFlags = flagsSetup{Contract: "Registry", Address: "0x0ae53cb6e3f42a79", Signer: "emulator-account"}
+
+		_, err := setup(nil, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "contract Registry doesn't conform to FungibleToken or NonFungibleToken, provide the type with the --type flag")
+	})
+
+	t.Run("Fail invalid type", func(t *testing.T) {
+		setupFlags = flagsSetup{Contract: "FlowToken", Type: "token", Signer: "emulator-account"}
+
+		_, err := setup(nil, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid type token, valid types are: ft, nft")
+	})
+
+	setupFlags = flagsSetup{}
+}
+
+func Test_Ledger(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	require.NoError(t, state.Save("flow.json"))
+	globalFlags := command.GlobalFlags{ConfigPaths: []string{"flow.json"}}
+
+	publicKey := "a60b9c10a39070806d37d8f0e6be081e7af2d18cd92ee1bd850d10c994d61d538d2693eebe8faa94fea59ee579ea65a70ed897b05126e508e74f55b8669eec6b"
+	pkey, err := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, publicKey)
+	require.NoError(t, err)
+
+	readKey := util.LedgerPublicKey
+	defer func() { util.LedgerPublicKey = readKey }()
+	var paths []string
+	util.LedgerPublicKey = func(key *accounts.LedgerKey) (crypto.PublicKey, error) {
+		paths = append(paths, key.DerivationPath())
+		return pkey, nil
+	}
+
+	defaults := ledgerFlags
+	reset := func() {
+		ledgerFlags = defaults
+		paths = nil
+	}
+
+	t.Run("Success key indexer", func(t *testing.T) {
+		defer reset()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/key/"+publicKey, r.URL.Path)
+			_, _ = w.Write([]byte(`{"accounts":[{"address":"0x179b6b1cb6755e31","keyId":2,"weight":1000,"isRevoked":false}]}`))
+		}))
+		defer server.Close()
+
+		defaultURL := util.KeyIndexerURLs[config.TestnetNetwork.Name]
+		util.KeyIndexerURLs[config.TestnetNetwork.Name] = server.URL
+		defer func() { util.KeyIndexerURLs[config.TestnetNetwork.Name] = defaultURL }()
+		srv.Network.Return(config.TestnetNetwork)
+		defer srv.Network.Return(config.EmulatorNetwork)
+
+		ledgerFlags.Index = 3
+		ledgerFlags.Count = 2
+
+		result, err := ledger(nil, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"m/44'/539'/0'/0/3", "m/44'/539'/0'/0/4"}, paths)
+
+		found := []foundKeyOutput{{Address: "0x179b6b1cb6755e31", KeyIndex: 2, Weight: 1000}}
+		assert.Equal(t, []ledgerKeyOutput{
+			{DerivationPath: "m/44'/539'/0'/0/3", PublicKey: publicKey, Accounts: found},
+			{DerivationPath: "m/44'/539'/0'/0/4", PublicKey: publicKey, Accounts: found},
+		}, result.JSON())
+	})
+
+	t.Run("Success add account with address", func(t *testing.T) {
+		defer reset()
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(&flow.Account{
+			Address: flow.HexToAddress("0x01cf0e2f2f715450"),
+			Keys: []*flow.AccountKey{
+				{Index: 0, PublicKey: pkey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000, Revoked: true},
+				{Index: 1, PublicKey: pkey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA2_256, Weight: 1000},
+			},
+		}, nil)
+
+		ledgerFlags.DerivationPath = "m/44'/539'/0'/0/2"
+		ledgerFlags.Add = "my-ledger"
+		ledgerFlags.Address = "0x01cf0e2f2f715450"
+
+		_, err := ledger(nil, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		saved, err := flowkit.Load(globalFlags.ConfigPaths, rw)
+		require.NoError(t, err)
+		account, err := saved.Accounts().ByName("my-ledger")
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("0x01cf0e2f2f715450"), account.Address)
+		assert.Equal(t, 1, account.Key.Index())
+		assert.Equal(t, "m/44'/539'/0'/0/2", account.Key.ToConfig().DerivationPath)
+		assert.Equal(t, config.DeviceLedger, account.Key.ToConfig().Device)
+		assert.Equal(t, crypto.SHA2_256, account.Key.HashAlgo())
+	})
+
+	t.Run("Fail add account with different hash algorithm", func(t *testing.T) {
+		defer reset()
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(&flow.Account{
+			Address: flow.HexToAddress("0x01cf0e2f2f715450"),
+			Keys: []*flow.AccountKey{
+				{Index: 0, PublicKey: pkey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA2_256, Weight: 1000},
+			},
+		}, nil)
+
+		ledgerFlags.Add = "my-ledger"
+		ledgerFlags.Address = "0x01cf0e2f2f715450"
+		ledgerFlags.HashAlgo = "SHA3_256"
+
+		_, err := ledger(nil, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "key 0 of account 0x01cf0e2f2f715450 uses the SHA2_256 hash algorithm, not SHA3_256")
+	})
+
+	t.Run("Fail add account without discovered key", func(t *testing.T) {
+		defer reset()
+		ledgerFlags.Add = "my-ledger"
+
+		_, err := ledger(nil, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "the Ledger public key at m/44'/539'/0'/0/0 is used by 0 known accounts, provide the account address with the --address flag")
+	})
+
+	t.Run("Fail add account with multiple paths", func(t *testing.T) {
+		defer reset()
+		ledgerFlags.Add = "my-ledger"
+		ledgerFlags.Count = 3
+
+		_, err := ledger(nil, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "select a single derivation path to add the account")
+	})
+}
@@ -0,0 +1,248 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/templates"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsBalances struct {
+	Token string `default:"" flag:"token" info:"Only report the balance of the token, by symbol, contract name or vault type identifier"`
+}
+
+var balancesFlags = flagsBalances{}
+
+var balancesCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "balances <address | account name>",
+		Short: "Get the balances of all fungible tokens held by an account",
+		Long: `Get the balances of all fungible tokens held by an account, the vaults are detected by inspecting
+the account storage for resources conforming to the FungibleToken standard.`,
+		Example: `flow accounts balances 0xf8d6e0586b0a20c7
+flow accounts balances 0x1654653399040a61 --network mainnet --token FUSD`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &balancesFlags,
+	Run:   balances,
+}
+
+// fusdAddresses are the addresses of the FUSD contract, which is not part of the core contracts.
+var fusdAddresses = map[flowsdk.ChainID]string{
+	flowsdk.Mainnet: "3c5959b568896393",
+	flowsdk.Testnet: "e223d8a629e49c68",
+}
+
+func balances(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := templates.ByName("get-vault-balances", templates.KindScript)
+	if err != nil {
+		return nil, err
+	}
+	chain := util.NetworkChain(flow.Network())
+
+	logger.StartProgress(fmt.Sprintf("Loading token balances of account %s...", address))
+	defer logger.StopProgress()
+
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: template.Source(chain),
+			Args: []cadence.Value{cadence.NewAddress(address)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect the vaults of account 0x%s: %w", address, err)
+	}
+
+	vaults, err := decodeVaultBalances(value, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	if balancesFlags.Token != "" {
+		for _, vault := range vaults {
+			if vault.matches(balancesFlags.Token) {
+				return &balancesResult{address: address, vaults: []vaultBalance{vault}}, nil
+			}
+		}
+		return nil, fmt.Errorf("no vault of token %s found in account 0x%s", balancesFlags.Token, address)
+	}
+
+	return &balancesResult{address: address, vaults: vaults}, nil
+}
+
+// vaultBalance is the balance of a fungible token vault stored in the account.
+type vaultBalance struct {
+	Token   string `json:"token"`
+	Type    string `json:"type"`
+	Path    string `json:"path"`
+	Balance string `json:"balance"`
+}
+
+// matches the vault with the token symbol, contract name, contract or vault type identifier.
+func (v vaultBalance) matches(token string) bool {
+	return strings.EqualFold(token, v.Token) || matchesResourceType(token, v.Type)
+}
+
+// matchesResourceType matches the type identifier of a resource nested in a contract, such as
+// A.<address>.<contract>.Vault, with the identifier, the contract identifier or the contract name.
+func matchesResourceType(query string, identifier string) bool {
+	contract := identifier[:strings.LastIndex(identifier, ".")+1]
+	contract = strings.TrimSuffix(contract, ".")
+	return strings.EqualFold(query, identifier) ||
+		strings.EqualFold(query, contract) ||
+		strings.EqualFold(query, contractName(identifier))
+}
+
+// contractName returns the contract name of the A.<address>.<contract>.<resource> type identifier.
+func contractName(identifier string) string {
+	parts := strings.Split(identifier, ".")
+	if len(parts) < 2 {
+		return identifier
+	}
+	return parts[len(parts)-2]
+}
+
+// structFields returns the fields of the struct value by name.
+func structFields(value cadence.Value) (map[string]cadence.Value, error) {
+	s, ok := value.(cadence.Struct)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value %s", value)
+	}
+
+	fields := make(map[string]cadence.Value, len(s.Fields))
+	for i, field := range s.StructType.Fields {
+		fields[field.Identifier] = s.Fields[i]
+	}
+	return fields, nil
+}
+
+// decodeVaultBalances decodes the vault balances returned by the script, the vaults of core
+// tokens are listed first and the rest are sorted by the token name.
+func decodeVaultBalances(value cadence.Value, chain flowsdk.ChainID) ([]vaultBalance, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("unexpected vault balances %s", value)
+	}
+
+	symbols := map[string]string{
+		fmt.Sprintf("A.%s.FlowToken.Vault", util.EnvFromNetwork(chain).FlowTokenAddress): "FLOW",
+	}
+	if address, ok := fusdAddresses[chain]; ok {
+		symbols[fmt.Sprintf("A.%s.FUSD.Vault", address)] = "FUSD"
+	}
+
+	vaults := make([]vaultBalance, 0, len(array.Values))
+	for _, v := range array.Values {
+		fields, err := structFields(v)
+		if err != nil {
+			return nil, err
+		}
+
+		typ, _ := fields["type"].(cadence.String)
+		balance := vaultBalance{
+			Token:   symbols[string(typ)],
+			Type:    string(typ),
+			Path:    fields["path"].String(),
+			Balance: fields["balance"].String(),
+		}
+		if balance.Token == "" {
+			balance.Token = contractName(balance.Type)
+		}
+
+		vaults = append(vaults, balance)
+	}
+
+	sort.SliceStable(vaults, func(i, j int) bool {
+		_, iCore := symbols[vaults[i].Type]
+		_, jCore := symbols[vaults[j].Type]
+		if iCore != jCore {
+			return iCore
+		}
+		return vaults[i].Token < vaults[j].Token
+	})
+
+	return vaults, nil
+}
+
+type balancesResult struct {
+	address flowsdk.Address
+	vaults  []vaultBalance
+}
+
+func (r *balancesResult) JSON() any {
+	return r.vaults
+}
+
+func (r *balancesResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address\t 0x%s\n\n", r.address)
+	if len(r.vaults) == 0 {
+		_, _ = fmt.Fprintf(writer, "No fungible token vaults found\n")
+	} else {
+		_, _ = fmt.Fprintf(writer, "Token\tBalance\tPath\tType\n")
+	}
+	for _, v := range r.vaults {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", v.Token, v.Balance, v.Path, v.Type)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *balancesResult) Oneliner() string {
+	balances := make([]string, 0, len(r.vaults))
+	for _, v := range r.vaults {
+		balances = append(balances, fmt.Sprintf("%s: %s", v.Token, v.Balance))
+	}
+
+	return fmt.Sprintf("Address: 0x%s, Balances: %s", r.address, strings.Join(balances, ", "))
+}
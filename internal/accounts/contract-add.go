@@ -21,6 +21,7 @@ package accounts
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/flow-cli/internal/util"
 
@@ -31,24 +32,29 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/arguments"
 	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
 	"github.com/onflow/flow-cli/internal/command"
 )
 
 type deployContractFlags struct {
-	ArgsJSON string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	Signer   string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
-	Include  []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
-	ShowDiff bool     `default:"false" flag:"show-diff" info:"Shows diff between existing and new contracts on update"`
+	ArgsJSON        string   `default:"" flag:"args-json" info:"initializer arguments in JSON-Cadence format, @file to read them from a file or - from the standard input"`
+	Arg             []string `default:"" flag:"arg" info:"Initializer argument value, or argument keyed by parameter name as name=value or name:Type:value, can be provided multiple times"`
+	Signer          string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
+	Include         []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	ShowDiff        bool     `default:"false" flag:"show-diff" info:"Shows diff between existing and new contracts on update"`
+	SkipUpdateCheck bool     `default:"false" flag:"skip-update-check" info:"Skip checking the update is compatible with the deployed contract"`
 }
 
 var addContractFlags = deployContractFlags{}
 
 var addContractCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "add-contract <filename> <args>",
-		Short:   "Deploy a new contract to an account",
-		Example: `flow accounts add-contract ./FungibleToken.cdc helloArg`,
-		Args:    cobra.MinimumNArgs(1),
+		Use:   "add-contract <filename> <args>",
+		Short: "Deploy a new contract to an account",
+		Example: `flow accounts add-contract ./FungibleToken.cdc helloArg
+flow accounts add-contract ./Token.cdc --arg name=Token --arg supply:UFix64:1000.0
+flow accounts add-contract ./Token.cdc --args-json @args.json`,
+		Args: cobra.MinimumNArgs(1),
 	},
 	Flags: &addContractFlags,
 	RunS:  deployContract(false, &addContractFlags),
@@ -69,22 +75,23 @@ func deployContract(update bool, flags *deployContractFlags) command.RunWithStat
 			return nil, fmt.Errorf("error loading contract file: %w", err)
 		}
 
-		to, err := state.Accounts().ByName(flags.Signer)
+		to, err := util.AccountByName(state, flags.Signer)
 		if err != nil {
 			return nil, err
 		}
 
-		var contractArgs []cadence.Value
-		if flags.ArgsJSON != "" {
-			contractArgs, err = arguments.ParseJSON(flags.ArgsJSON)
-		} else if len(args) > 1 {
-			contractArgs, err = arguments.ParseWithoutType(args[1:], code, filename)
-		}
-
+		contractArgs, err := parseContractArguments(args[1:], flags, code, filename, state)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
 		}
 
+		if update && !flags.SkipUpdateCheck {
+			err = checkContractUpdate(flow, to.Address, code, filename)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		deployFunc := flowkit.UpdateExistingContract(update)
 		if updateContractFlags.ShowDiff {
 			deployFunc = util.ShowContractDiffPrompt(logger)
@@ -135,6 +142,85 @@ func deployContract(update bool, flags *deployContractFlags) command.RunWithStat
 		return &accountResult{
 			Account: account,
 			include: flags.Include,
+			link:    command.NewExplorerLink(flow.Network(), globalFlags),
 		}, nil
 	}
 }
+
+// parseContractArguments parses the arguments passed to the contract initializer, provided either
+// positionally, with the arg flag keyed by position or by parameter name, or in the JSON-Cadence format.
+func parseContractArguments(
+	argValues []string,
+	flags *deployContractFlags,
+	code []byte,
+	filename string,
+	state *flowkit.State,
+) ([]cadence.Value, error) {
+	if flags.ArgsJSON != "" {
+		if len(argValues) > 0 || len(flags.Arg) > 0 {
+			return nil, fmt.Errorf("arguments in JSON-Cadence format can not be combined with other arguments")
+		}
+		return util.ParseArgsJSON(flags.ArgsJSON, state.ReaderWriter())
+	}
+
+	if len(flags.Arg) > 0 {
+		parameters, err := arguments.Parameters(code, filename)
+		if err != nil {
+			return nil, err
+		}
+
+		// arguments keyed by the parameter name are matched to the initializer parameters instead of the position
+		if arguments.AreNamed(flags.Arg, parameters) {
+			if len(argValues) > 0 {
+				return nil, fmt.Errorf("arguments keyed by parameter name can not be combined with positional arguments")
+			}
+			return arguments.ParseNamed(flags.Arg, code, filename)
+		}
+		argValues = append(argValues, flags.Arg...)
+	}
+
+	if len(argValues) == 0 {
+		return nil, nil
+	}
+	return arguments.ParseWithoutType(argValues, code, filename)
+}
+
+// checkContractUpdate validates the contract can be updated on the account with the validation the network runs,
+// so incompatible changes are reported with their position instead of failing the transaction.
+func checkContractUpdate(flow flowkit.Services, address flowsdk.Address, code []byte, filename string) error {
+	program, err := project.NewProgram(code, nil, filename)
+	if err != nil {
+		return err
+	}
+	name, err := program.Name()
+	if err != nil {
+		return err
+	}
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return err
+	}
+	deployed, exists := account.Contracts[name]
+	if !exists {
+		return nil
+	}
+
+	issues, err := project.CheckContractUpdate(address, deployed, code, filename)
+	if err != nil {
+		return fmt.Errorf("failed to check the contract update: %w", err)
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	for _, issue := range issues {
+		_, _ = fmt.Fprintf(&b, "\n  %s:%s", filename, issue)
+	}
+	return fmt.Errorf(
+		"contract %s can not be updated, the changes are incompatible with the deployed contract:%s\nuse --skip-update-check to send the update anyway",
+		name,
+		b.String(),
+	)
+}
@@ -0,0 +1,119 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsGetContract struct{}
+
+var getContractFlags = flagsGetContract{}
+
+var getContractCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "get-contract <address | account name> <contract name>",
+		Short: "Get the code of a contract deployed to an account",
+		Long: `Get the code of a contract deployed to an account, the code is printed as deployed so it can be saved
+to a file with the --save flag and compared with the local source.`,
+		Example: `flow accounts get-contract 0xf8d6e0586b0a20c7 FungibleToken
+flow accounts get-contract 0xf233dcee88fe0abe FungibleToken --network mainnet --save ./FungibleToken.cdc`,
+		Args: cobra.ExactArgs(2),
+	},
+	Flags:  &getContractFlags,
+	Output: contractCodeOutput{},
+	Run:    getContract,
+}
+
+func getContract(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+	name := args[1]
+
+	logger.StartProgress(fmt.Sprintf("Loading contract %s of account %s...", name, address))
+	defer logger.StopProgress()
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	code, exists := account.Contracts[name]
+	if !exists {
+		return nil, fmt.Errorf("contract %s is not deployed to account 0x%s", name, address)
+	}
+
+	return &contractCodeResult{address: address, name: name, code: code}, nil
+}
+
+type contractCodeResult struct {
+	address flowsdk.Address
+	name    string
+	code    []byte
+}
+
+// contractCodeOutput is the JSON output of the get-contract command.
+type contractCodeOutput struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	Size    int    `json:"size"`
+	SHA256  string `json:"sha256"`
+	Code    string `json:"code"`
+}
+
+func (r *contractCodeResult) JSON() any {
+	return contractCodeOutput{
+		Address: fmt.Sprintf("0x%s", r.address),
+		Name:    r.name,
+		Size:    len(r.code),
+		SHA256:  hashCode(r.code),
+		Code:    string(r.code),
+	}
+}
+
+// String returns the code as deployed, so the saved output is the contract source.
+func (r *contractCodeResult) String() string {
+	return string(r.code)
+}
+
+func (r *contractCodeResult) Oneliner() string {
+	return fmt.Sprintf("Contract %s on 0x%s, size %d, SHA256 %s", r.name, r.address, len(r.code), hashCode(r.code))
+}
@@ -52,14 +52,14 @@ var removeCommand = &command.Command{
 
 func removeContract(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
 	flow flowkit.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
 	contractName := args[0]
 
-	from, err := state.Accounts().ByName(flagsRemove.Signer)
+	from, err := util.AccountByName(state, flagsRemove.Signer)
 	if err != nil {
 		return nil, err
 	}
@@ -104,5 +104,6 @@ func removeContract(
 	return &accountResult{
 		Account: account,
 		include: flagsRemove.Include,
+		link:    command.NewExplorerLink(flow.Network(), globalFlags),
 	}, nil
 }
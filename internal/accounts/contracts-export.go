@@ -0,0 +1,174 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsExportContracts struct {
+	Contract []string `default:"" flag:"contract" info:"Name of a contract to export, all contracts are exported if not provided"`
+}
+
+var exportContractsFlags = flagsExportContracts{}
+
+var exportContractsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "export-contracts <address | account name> <directory>",
+		Short: "Export the code of the contracts deployed to an account to files",
+		Long: `Export the code of the contracts deployed to an account to files named after the contracts in the directory,
+existing files are overwritten. The exported code can be diffed with local sources or vendored in a project.`,
+		Example: `flow accounts export-contracts 0xf233dcee88fe0abe ./out --network mainnet
+flow accounts export-contracts alice ./vendor --contract FungibleToken --contract NonFungibleToken`,
+		Args: cobra.ExactArgs(2),
+	},
+	Flags:  &exportContractsFlags,
+	Output: []exportedContractOutput{},
+	Run:    exportContracts,
+}
+
+func exportContracts(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+	dir := args[1]
+
+	logger.StartProgress(fmt.Sprintf("Loading contracts of account %s...", address))
+	account, err := flow.GetAccount(context.Background(), address)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	names := exportContractsFlags.Contract
+	if len(names) == 0 {
+		for name := range account.Contracts {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, exists := account.Contracts[name]; !exists {
+			return nil, fmt.Errorf("contract %s is not deployed to account 0x%s", name, address)
+		}
+	}
+
+	if err := flowkit.MkdirAll(readerWriter, dir); err != nil {
+		return nil, err
+	}
+
+	result := &exportContractsResult{address: address}
+	for _, name := range names {
+		code := account.Contracts[name]
+		file := filepath.Join(dir, fmt.Sprintf("%s.cdc", name))
+		if err := readerWriter.WriteFile(file, code, 0644); err != nil {
+			return nil, fmt.Errorf("failed to export contract %s: %w", name, err)
+		}
+
+		result.contracts = append(result.contracts, exportedContract{
+			name: name,
+			file: file,
+			size: len(code),
+			hash: hashCode(code),
+		})
+	}
+
+	return result, nil
+}
+
+type exportedContract struct {
+	name string
+	file string
+	size int
+	hash string
+}
+
+type exportContractsResult struct {
+	address   flowsdk.Address
+	contracts []exportedContract
+}
+
+// exportedContractOutput is the JSON output of a contract exported by the export-contracts command.
+type exportedContractOutput struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+func (r *exportContractsResult) JSON() any {
+	contracts := make([]exportedContractOutput, 0, len(r.contracts))
+	for _, contract := range r.contracts {
+		contracts = append(contracts, exportedContractOutput{
+			Name:   contract.name,
+			File:   contract.file,
+			Size:   contract.size,
+			SHA256: contract.hash,
+		})
+	}
+	return contracts
+}
+
+func (r *exportContractsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if len(r.contracts) == 0 {
+		_, _ = fmt.Fprintf(writer, "No contracts are deployed to account 0x%s\n", r.address)
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "Name\tFile\tSize\tSHA256\n")
+	for _, contract := range r.contracts {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%d\t%s\n", contract.name, contract.file, contract.size, contract.hash)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *exportContractsResult) Oneliner() string {
+	return fmt.Sprintf("Exported contracts: %d", len(r.contracts))
+}
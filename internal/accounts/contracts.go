@@ -0,0 +1,242 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsContracts struct {
+	CompareLocal bool `default:"false" flag:"compare-local" info:"Compare the deployed contracts with the contract sources of the project"`
+}
+
+var contractsFlags = flagsContracts{}
+
+var contractsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "contracts <address | account name>",
+		Short: "List the contracts deployed to an account",
+		Long: `List the contracts deployed to an account with the size and the SHA-256 hash of their code.
+
+With the --compare-local flag the deployed code is compared with the source of the contract with the same name in
+the project, after replacing the imports of the source with the contract addresses on the selected network.`,
+		Example: `flow accounts contracts 0xf8d6e0586b0a20c7
+flow accounts contracts alice --compare-local --network testnet`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags:  &contractsFlags,
+	Output: []deployedContractOutput{},
+	Run:    listContracts,
+}
+
+// Local statuses of a deployed contract compared with the project source.
+const (
+	localMatch   = "match"
+	localDiffers = "differs"
+	localMissing = "missing"
+	localError   = "error"
+)
+
+func listContracts(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	if contractsFlags.CompareLocal && state == nil {
+		return nil, fmt.Errorf("comparing with the local contracts requires a project configuration")
+	}
+
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading contracts of account %s...", address))
+	defer logger.StopProgress()
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &contractsResult{address: address, compared: contractsFlags.CompareLocal}
+	for name, code := range account.Contracts {
+		contract := deployedContract{
+			name: name,
+			size: len(code),
+			hash: hashCode(code),
+		}
+		if contractsFlags.CompareLocal {
+			contract.local, contract.localErr = compareLocal(name, code, state, flow.Network())
+		}
+		result.contracts = append(result.contracts, contract)
+	}
+	sort.Slice(result.contracts, func(i, j int) bool {
+		return result.contracts[i].name < result.contracts[j].name
+	})
+
+	return result, nil
+}
+
+func hashCode(code []byte) string {
+	hash := sha256.Sum256(code)
+	return hex.EncodeToString(hash[:])
+}
+
+// compareLocal compares the deployed code with the project source of the contract with the imports replaced
+// for the network, returning the local status of the contract.
+func compareLocal(name string, code []byte, state *flowkit.State, network config.Network) (string, error) {
+	contract, err := state.Contracts().ByName(name)
+	if err != nil {
+		return localMissing, nil
+	}
+
+	source, err := state.ReadFile(contract.Location)
+	if err != nil {
+		return localError, fmt.Errorf("error loading contract file %s: %w", contract.Location, err)
+	}
+
+	program, err := project.NewProgram(source, nil, contract.Location)
+	if err != nil {
+		return localError, err
+	}
+
+	if program.HasImports() {
+		contracts, err := state.DeploymentContractsByNetwork(network)
+		if err != nil {
+			return localError, err
+		}
+
+		program, err = project.NewImportReplacer(contracts, state.AliasesForNetwork(network)).Replace(program)
+		if err != nil {
+			return localError, err
+		}
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(program.Code()), bytes.TrimSpace(code)) {
+		return localDiffers, nil
+	}
+
+	return localMatch, nil
+}
+
+// deployedContract is a contract deployed to the account and its status compared with the project source.
+type deployedContract struct {
+	name     string
+	size     int
+	hash     string
+	local    string
+	localErr error
+}
+
+type contractsResult struct {
+	address   flowsdk.Address
+	contracts []deployedContract
+	compared  bool
+}
+
+// deployedContractOutput is the JSON output of a contract listed by the contracts command.
+type deployedContractOutput struct {
+	Name       string `json:"name"`
+	Size       int    `json:"size"`
+	SHA256     string `json:"sha256"`
+	Local      string `json:"local,omitempty"`
+	LocalError string `json:"localError,omitempty"`
+}
+
+func (r *contractsResult) JSON() any {
+	contracts := make([]deployedContractOutput, 0, len(r.contracts))
+	for _, contract := range r.contracts {
+		out := deployedContractOutput{
+			Name:   contract.name,
+			Size:   contract.size,
+			SHA256: contract.hash,
+			Local:  contract.local,
+		}
+		if contract.localErr != nil {
+			out.LocalError = contract.localErr.Error()
+		}
+		contracts = append(contracts, out)
+	}
+
+	return contracts
+}
+
+func (r *contractsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if len(r.contracts) == 0 {
+		_, _ = fmt.Fprintf(writer, "No contracts are deployed to account 0x%s\n", r.address)
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	if r.compared {
+		_, _ = fmt.Fprintf(writer, "Name\tSize\tSHA256\tLocal\n")
+	} else {
+		_, _ = fmt.Fprintf(writer, "Name\tSize\tSHA256\n")
+	}
+	for _, contract := range r.contracts {
+		if !r.compared {
+			_, _ = fmt.Fprintf(writer, "%s\t%d\t%s\n", contract.name, contract.size, contract.hash)
+			continue
+		}
+
+		local := contract.local
+		switch contract.local {
+		case localMatch:
+			local = fmt.Sprintf("%s %s", output.OkEmoji(), local)
+		case localDiffers:
+			local = fmt.Sprintf("%s %s", output.WarningEmoji(), local)
+		case localError:
+			local = fmt.Sprintf("%s %s", output.ErrorEmoji(), contract.localErr)
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%d\t%s\t%s\n", contract.name, contract.size, contract.hash, local)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *contractsResult) Oneliner() string {
+	return fmt.Sprintf("Contracts: %d", len(r.contracts))
+}
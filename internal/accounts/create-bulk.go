@@ -0,0 +1,520 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/templates"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// createAccountsTransaction creates an account for each of the keys, all paid by the signer.
+const createAccountsTransaction = `import Crypto
+
+transaction(publicKeys: [Crypto.KeyListEntry]) {
+	prepare(signer: AuthAccount) {
+		for key in publicKeys {
+			let account = AuthAccount(payer: signer)
+			account.keys.add(publicKey: key.publicKey, hashAlgorithm: key.hashAlgorithm, weight: key.weight)
+		}
+	}
+}
+`
+
+// bulkCreateGasLimit is the gas limit of each transaction creating a batch of accounts.
+const bulkCreateGasLimit = flowsdk.DefaultTransactionGasLimit
+
+// bulkAccount is an account created in bulk, the private key is only known if it was generated or provided.
+type bulkAccount struct {
+	Name       string `json:"name,omitempty"`
+	Address    string `json:"address"`
+	PublicKey  string `json:"publicKey"`
+	PrivateKey string `json:"privateKey,omitempty"`
+	SigAlgo    string `json:"sigAlgo"`
+	HashAlgo   string `json:"hashAlgo"`
+
+	key     accounts.PublicKey
+	private crypto.PrivateKey
+}
+
+// createBulk creates an account for each generated key or each key of the keys file, sending the accounts
+// in batched transactions, and adds them to the configuration or writes them to the accounts file.
+func createBulk(
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if createFlags.Count > 0 && createFlags.KeysFile != "" {
+		return nil, fmt.Errorf("count and keys file flags can not be combined, the keys file determines the number of accounts")
+	}
+	if len(createFlags.Keys) > 0 || len(createFlags.PublicKeys) > 0 {
+		return nil, fmt.Errorf("keys can not be provided when creating accounts in bulk, use the keys file flag instead")
+	}
+	if createFlags.UseFaucet {
+		return nil, fmt.Errorf("the faucet is not supported when creating accounts in bulk")
+	}
+	if createFlags.BatchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", createFlags.BatchSize)
+	}
+
+	format, err := accountsFileFormat(createFlags.AccountsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	sigAlgos, err := parseSignatureAlgorithms(createFlags.SigAlgo[:1])
+	if err != nil {
+		return nil, err
+	}
+	hashAlgos, err := parseHashingAlgorithms(createFlags.HashAlgo[:1])
+	if err != nil {
+		return nil, err
+	}
+
+	var created []bulkAccount
+	if createFlags.KeysFile != "" {
+		created, err = readBulkKeys(state.ReaderWriter(), createFlags.KeysFile, sigAlgos[0], hashAlgos[0])
+	} else {
+		if createFlags.Name == "" && createFlags.AccountsFile == "" {
+			return nil, fmt.Errorf("generated keys must be saved, provide a name for the accounts added to the configuration or an accounts file")
+		}
+		created, err = generateBulkKeys(flow, createFlags.Count, sigAlgos[0], hashAlgos[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(created) == 0 {
+		return nil, fmt.Errorf("no keys found in %s", createFlags.KeysFile)
+	}
+
+	if createFlags.Name != "" {
+		for i := range created {
+			if created[i].private == nil {
+				return nil, fmt.Errorf("accounts can only be added to the configuration with their private keys, the key in row %d has none", i+1)
+			}
+			created[i].Name = fmt.Sprintf("%s-%d", createFlags.Name, i+1)
+			if _, err := state.Accounts().ByName(created[i].Name); err == nil {
+				return nil, fmt.Errorf("account %s already exists in the configuration", created[i].Name)
+			}
+		}
+	}
+
+	signer, err := util.AccountByName(state, createFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	var txIDs []flowsdk.Identifier
+	for start := 0; start < len(created); start += createFlags.BatchSize {
+		end := start + createFlags.BatchSize
+		if end > len(created) {
+			end = len(created)
+		}
+
+		logger.Info(fmt.Sprintf("Creating accounts %d to %d of %d", start+1, end, len(created)))
+		txID, err := createAccountsBatch(flow, signer, created[start:end])
+		if err != nil {
+			// accounts created by previous batches are still saved so their keys are not lost
+			_ = saveBulkAccounts(globalFlags, flow, state, format, created[:start])
+			return nil, fmt.Errorf("failed to create accounts %d to %d: %w", start+1, end, err)
+		}
+		txIDs = append(txIDs, txID)
+	}
+
+	err = saveBulkAccounts(globalFlags, flow, state, format, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bulkCreateResult{
+		accounts:       created,
+		transactionIDs: txIDs,
+		file:           createFlags.AccountsFile,
+		link:           command.NewExplorerLink(flow.Network(), globalFlags),
+	}, nil
+}
+
+// generateBulkKeys generates the number of key pairs using random seeds.
+func generateBulkKeys(
+	flow flowkit.Services,
+	count int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) ([]bulkAccount, error) {
+	created := make([]bulkAccount, 0, count)
+	for i := 0; i < count; i++ {
+		privateKey, err := flow.GenerateKey(context.Background(), sigAlgo, "")
+		if err != nil {
+			return nil, err
+		}
+
+		created = append(created, newBulkAccount(privateKey.PublicKey(), privateKey, hashAlgo))
+	}
+
+	return created, nil
+}
+
+// readBulkKeys reads the keys from a CSV file with a header containing the public_key column, the optional
+// private_key, sig_algo and hash_algo columns are read if present, such as in files of generated key batches.
+func readBulkKeys(
+	readerWriter flowkit.ReaderWriter,
+	file string,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) ([]bulkAccount, error) {
+	data, err := readerWriter.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keys file: %w", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse keys file %s: %w", file, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("keys file %s is empty", file)
+	}
+
+	columns := make(map[string]int)
+	for i, column := range records[0] {
+		columns[strings.TrimSpace(column)] = i
+	}
+	if _, ok := columns["public_key"]; !ok {
+		return nil, fmt.Errorf("keys file %s has no public_key column", file)
+	}
+	value := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	created := make([]bulkAccount, 0, len(records)-1)
+	for i, record := range records[1:] {
+		rowSigAlgo := sigAlgo
+		if algo := value(record, "sig_algo"); algo != "" {
+			rowSigAlgo = crypto.StringToSignatureAlgorithm(algo)
+			if rowSigAlgo == crypto.UnknownSignatureAlgorithm {
+				return nil, fmt.Errorf("invalid signature algorithm %s in row %d", algo, i+1)
+			}
+		}
+		rowHashAlgo := hashAlgo
+		if algo := value(record, "hash_algo"); algo != "" {
+			rowHashAlgo = crypto.StringToHashAlgorithm(algo)
+			if rowHashAlgo == crypto.UnknownHashAlgorithm {
+				return nil, fmt.Errorf("invalid hash algorithm %s in row %d", algo, i+1)
+			}
+		}
+
+		publicKey, err := parsePublicKeys([]string{value(record, "public_key")}, []crypto.SignatureAlgorithm{rowSigAlgo})
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key in row %d: %w", i+1, err)
+		}
+
+		var privateKey crypto.PrivateKey
+		if private := value(record, "private_key"); private != "" {
+			privateKey, err = crypto.DecodePrivateKeyHex(rowSigAlgo, strings.TrimPrefix(private, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid private key in row %d: %w", i+1, err)
+			}
+			if !privateKey.PublicKey().Equals(publicKey[0]) {
+				return nil, fmt.Errorf("private key in row %d doesn't match the public key", i+1)
+			}
+		}
+
+		created = append(created, newBulkAccount(publicKey[0], privateKey, rowHashAlgo))
+	}
+
+	return created, nil
+}
+
+func newBulkAccount(publicKey crypto.PublicKey, privateKey crypto.PrivateKey, hashAlgo crypto.HashAlgorithm) bulkAccount {
+	account := bulkAccount{
+		PublicKey: hex.EncodeToString(publicKey.Encode()),
+		SigAlgo:   publicKey.Algorithm().String(),
+		HashAlgo:  hashAlgo.String(),
+		key: accounts.PublicKey{
+			Public:   publicKey,
+			Weight:   flowsdk.AccountKeyWeightThreshold,
+			SigAlgo:  publicKey.Algorithm(),
+			HashAlgo: hashAlgo,
+		},
+		private: privateKey,
+	}
+	if privateKey != nil {
+		account.PrivateKey = hex.EncodeToString(privateKey.Encode())
+	}
+
+	return account
+}
+
+// createAccountsBatch creates an account for each key in a single transaction signed by the signer
+// and sets the created addresses in the order of the keys.
+func createAccountsBatch(flow flowkit.Services, signer *accounts.Account, batch []bulkAccount) (flowsdk.Identifier, error) {
+	keys := make([]cadence.Value, 0, len(batch))
+	for _, account := range batch {
+		key, err := templates.AccountKeyToCadenceCryptoKey(&flowsdk.AccountKey{
+			PublicKey: account.key.Public,
+			SigAlgo:   account.key.SigAlgo,
+			HashAlgo:  account.key.HashAlgo,
+			Weight:    account.key.Weight,
+		})
+		if err != nil {
+			return flowsdk.EmptyID, err
+		}
+		keys = append(keys, key)
+	}
+
+	tx, err := flow.BuildTransaction(
+		context.Background(),
+		transactions.AddressesRoles{
+			Proposer:    signer.Address,
+			Authorizers: []flowsdk.Address{signer.Address},
+			Payer:       signer.Address,
+		},
+		signer.Key.Index(),
+		flowkit.Script{
+			Code: []byte(createAccountsTransaction),
+			Args: []cadence.Value{cadence.NewArray(keys)},
+		},
+		bulkCreateGasLimit,
+	)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+
+	signed, err := flow.SignTransactionPayload(
+		context.Background(),
+		signer,
+		[]byte(hex.EncodeToString(tx.FlowTransaction().Encode())),
+	)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+
+	sentTx, result, err := flow.SendSignedTransaction(context.Background(), signed)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+
+	addresses, err := flowkit.CreatedAccounts(result)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+	if len(addresses) != len(batch) {
+		return flowsdk.EmptyID, fmt.Errorf("transaction created %d accounts instead of %d", len(addresses), len(batch))
+	}
+
+	for i := range batch {
+		batch[i].Address = fmt.Sprintf("0x%s", addresses[i].Address.Hex())
+	}
+
+	return sentTx.ID(), nil
+}
+
+// saveBulkAccounts adds the named accounts to the configuration and writes all accounts to the accounts file.
+//
+// Private keys of accounts on the emulator are stored in the configuration, while on other networks
+// they are saved to a key file per account which is added to the gitignore.
+func saveBulkAccounts(
+	globalFlags command.GlobalFlags,
+	flow flowkit.Services,
+	state *flowkit.State,
+	format string,
+	created []bulkAccount,
+) error {
+	if len(created) == 0 {
+		return nil
+	}
+
+	err := writeAccountsFile(state.ReaderWriter(), createFlags.AccountsFile, format, created)
+	if err != nil {
+		return err
+	}
+
+	if createFlags.Name == "" {
+		return nil
+	}
+
+	for _, account := range created {
+		var key accounts.Key
+		if flow.Network().Name == config.EmulatorNetwork.Name {
+			key = accounts.NewHexKeyFromPrivateKey(0, account.key.HashAlgo, account.private)
+		} else {
+			privateFile := fmt.Sprintf("%s.pkey", account.Name)
+			err = util.AddToGitIgnore(privateFile, state.ReaderWriter())
+			if err != nil {
+				return err
+			}
+
+			err = state.ReaderWriter().WriteFile(privateFile, []byte(account.private.String()), os.FileMode(0644))
+			if err != nil {
+				return fmt.Errorf("failed saving private key: %w", err)
+			}
+			key = accounts.NewFileKey(privateFile, 0, account.key.SigAlgo, account.key.HashAlgo)
+		}
+
+		state.Accounts().AddOrUpdate(&accounts.Account{
+			Name:    account.Name,
+			Address: flowsdk.HexToAddress(account.Address),
+			Key:     key,
+		})
+	}
+
+	return state.SaveEdited(globalFlags.ConfigPaths)
+}
+
+// accountsFileFormat returns the format of the accounts file from the extension, it's empty if no file is provided.
+func accountsFileFormat(file string) (string, error) {
+	if file == "" {
+		return "", nil
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+	if format != "csv" && format != "json" {
+		return "", fmt.Errorf("unsupported accounts file %s, use a .csv or .json file", file)
+	}
+
+	return format, nil
+}
+
+// writeAccountsFile writes the accounts in the CSV columns used by generated key batches, or as JSON.
+func writeAccountsFile(readerWriter flowkit.ReaderWriter, file string, format string, created []bulkAccount) error {
+	if file == "" {
+		return nil
+	}
+
+	var data []byte
+	var err error
+	if format == "json" {
+		data, err = json.MarshalIndent(created, "", "  ")
+	} else {
+		var b bytes.Buffer
+		records := [][]string{{"index", "address", "public_key", "private_key", "sig_algo", "hash_algo", "name"}}
+		for i, account := range created {
+			records = append(records, []string{
+				strconv.Itoa(i),
+				account.Address,
+				account.PublicKey,
+				account.PrivateKey,
+				account.SigAlgo,
+				account.HashAlgo,
+				account.Name,
+			})
+		}
+		err = csv.NewWriter(&b).WriteAll(records)
+		data = b.Bytes()
+	}
+	if err != nil {
+		return err
+	}
+
+	err = readerWriter.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write accounts to %s: %w", file, err)
+	}
+
+	return nil
+}
+
+type bulkCreateResult struct {
+	accounts       []bulkAccount
+	transactionIDs []flowsdk.Identifier
+	file           string
+	link           command.ExplorerLink
+}
+
+func (r *bulkCreateResult) JSON() any {
+	created := make([]map[string]any, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		result := map[string]any{
+			"address":   account.Address,
+			"publicKey": account.PublicKey,
+		}
+		if account.Name != "" {
+			result["name"] = account.Name
+		}
+		if link := r.link.Included(config.ExplorerAccount, account.Address); link != "" {
+			result["link"] = link
+		}
+		created = append(created, result)
+	}
+
+	transactionIDs := make([]string, 0, len(r.transactionIDs))
+	for _, id := range r.transactionIDs {
+		transactionIDs = append(transactionIDs, id.String())
+	}
+
+	return map[string]any{
+		"accounts":     created,
+		"transactions": transactionIDs,
+		"file":         r.file,
+	}
+}
+
+func (r *bulkCreateResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Created %d accounts in %d transactions\n", output.SuccessEmoji(), len(r.accounts), len(r.transactionIDs))
+	if r.file != "" {
+		_, _ = fmt.Fprintf(writer, "Accounts and their keys written to %s, store the file safely and don't share it with anyone!\n", r.file)
+	}
+	_, _ = fmt.Fprintf(writer, "\nName\tAddress\tPublic Key\n")
+	for _, account := range r.accounts {
+		name := account.Name
+		if name == "" {
+			name = "-"
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", name, account.Address, account.PublicKey)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *bulkCreateResult) Oneliner() string {
+	addresses := make([]string, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		addresses = append(addresses, account.Address)
+	}
+	return strings.Join(addresses, ", ")
+}
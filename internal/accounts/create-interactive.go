@@ -21,7 +21,6 @@ package accounts
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -133,9 +132,11 @@ func createNetworkAccount(
 		return nil, err
 	}
 
-	events := flowkit.EventsFromTransaction(result)
-	address := events.GetCreatedAddresses()
-	if len(address) == 0 {
+	created, err := flowkit.CreatedAccounts(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(created) == 0 {
 		return nil, fmt.Errorf("account creation error")
 	}
 
@@ -151,7 +152,7 @@ func createNetworkAccount(
 
 	return &accounts.Account{
 		Name:    name,
-		Address: *address[0],
+		Address: created[0].Address,
 		Key:     accounts.NewFileKey(privateFile, 0, defaultSignAlgo, defaultHashAlgo),
 	}, nil
 }
@@ -250,11 +251,14 @@ func (l *lilicoAccount) create(network string) (flowsdk.Identifier, error) {
 	request.Header.Add("Content-Type", "application/json; charset=UTF-8")
 	request.Header.Add("Authorization", accountToken)
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // lilico api doesn't yet have a valid cert, todo reevaluate
-		},
+	options := util.DefaultHTTPOptions
+	options.InsecureSkipVerify = true      // lilico api doesn't yet have a valid cert, todo reevaluate
+	options.Timeout = faucetRequestTimeout // the account is created before the response is sent
+	client, err := util.NewHTTPClient(options)
+	if err != nil {
+		return flowsdk.EmptyID, err
 	}
+
 	res, err := client.Do(request)
 	if err != nil {
 		return flowsdk.EmptyID, fmt.Errorf("could not create an account: %w", err)
@@ -20,35 +20,60 @@ package accounts
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/onflow/flow-cli/flowkit/accounts"
 
+	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsCreate struct {
-	Signer   string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
-	Keys     []string `flag:"key" info:"Public keys to attach to account"`
-	Weights  []int    `default:"1000" flag:"key-weight" info:"Weight for the key"`
-	SigAlgo  []string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm used to generate the keys"`
-	HashAlgo []string `default:"SHA3_256" flag:"hash-algo" info:"Hash used for the digest"`
-	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Signer         string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
+	Keys           []string `flag:"key" info:"Public keys to attach to account, each optionally followed by its own settings as key:weight=500:sig-algo=ECDSA_secp256k1:hash-algo=SHA2_256"`
+	PublicKeys     []string `flag:"public-key" info:"Public keys generated outside the project, e.g. by a hardware wallet or a KMS, as hex or the path of a PEM file, each optionally followed by its settings like --key"`
+	Weights        []int    `default:"1000" flag:"key-weight" info:"Weight for the key"`
+	SigAlgo        []string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm used to generate the keys"`
+	HashAlgo       []string `default:"SHA3_256" flag:"hash-algo" info:"Hash used for the digest"`
+	Include        []string `default:"" flag:"include" info:"Fields to include in the output"`
+	IdempotencyKey string   `default:"" flag:"idempotency-key" info:"Key identifying the request, retries with the same key return the already created account"`
+	Deploy         []string `default:"" flag:"deploy" info:"Contracts deployed to the account in the creation transaction, in the format name:path"`
+	Name           string   `default:"" flag:"name" info:"Account name added to the configuration together with the deployed contracts, or the prefix of the names of accounts created in bulk"`
+	UseFaucet      bool     `default:"false" flag:"use-faucet" info:"Create the account using the testnet faucet instead of a signer account"`
+	FaucetToken    string   `default:"" flag:"faucet-token" info:"API token of the testnet faucet, read from the FLOW_FAUCET_TOKEN environment variable if not provided"`
+	Count          int      `default:"0" flag:"count" info:"Number of accounts to create in bulk, each with a generated key, named by suffixing --name with their number"`
+	KeysFile       string   `default:"" flag:"keys-file" info:"CSV file with a public_key column and optional private_key, sig_algo and hash_algo columns, creating an account for each row"`
+	BatchSize      int      `default:"10" flag:"batch-size" info:"Number of accounts created in each transaction when creating accounts in bulk"`
+	AccountsFile   string   `default:"" flag:"accounts-file" info:"CSV or JSON file the accounts created in bulk are written to with their keys, the format is chosen by the extension"`
 }
 
 var createFlags = flagsCreate{}
 
 var createCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "create",
-		Short:   "Create a new account on network",
-		Example: `flow accounts create --key d651f1931a2...8745`,
+		Use:   "create",
+		Short: "Create a new account on network",
+		Example: `flow accounts create --key d651f1931a2...8745
+flow accounts create --key d651f1931a2...8745:weight=500 --key 2e4a8c05b1f...91c3:weight=500 --key 7bd04a3c9e8...04f2:weight=500:sig-algo=ECDSA_secp256k1:hash-algo=SHA2_256
+flow accounts create --key d651f1931a2...8745 --idempotency-key ci-build-1234
+flow accounts create --key d651f1931a2...8745 --network testnet --use-faucet
+flow accounts create --public-key ./kms-key.pem --sig-algo ECDSA_P256 --hash-algo SHA2_256 --network testnet
+flow accounts create --name alice --deploy MyContract:./contracts/MyContract.cdc --signer funder
+flow accounts create --count 50 --name load-test --accounts-file accounts.csv
+flow accounts create --keys-file keys.csv --batch-size 20 --accounts-file accounts.json --network testnet --signer funder`,
 	},
 	Flags: &createFlags,
 	RunS:  create,
@@ -56,29 +81,268 @@ var createCommand = &command.Command{
 
 func create(
 	_ []string,
-	_ command.GlobalFlags,
-	_ output.Logger,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
 	flow flowkit.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
 	sigsFlag := createFlags.SigAlgo
 	hashFlag := createFlags.HashAlgo
-	keysFlag := createFlags.Keys
 	weightFlag := createFlags.Weights
 
+	if createFlags.IdempotencyKey != "" &&
+		(len(createFlags.Deploy) > 0 || createFlags.Count > 0 || createFlags.KeysFile != "" || createFlags.UseFaucet) {
+		return nil, fmt.Errorf("the idempotency key can't be used with the --deploy, --count, --keys-file or --use-faucet flags")
+	}
+
+	if len(createFlags.Deploy) > 0 {
+		return createWithContracts(globalFlags, logger, flow, state)
+	}
+	if createFlags.Count > 0 || createFlags.KeysFile != "" {
+		return createBulk(globalFlags, logger, flow, state)
+	}
+
+	publicKeys, err := readPublicKeyFiles(createFlags.PublicKeys, state.ReaderWriter())
+	if err != nil {
+		return nil, err
+	}
+	keysFlag := append(append([]string{}, createFlags.Keys...), publicKeys...)
+
 	if len(keysFlag) == 0 { // if user doesn't provide any flags go into interactive mode
 		return nil, createInteractive(state)
 	}
 
-	signer, err := state.Accounts().ByName(createFlags.Signer)
+	keys, err := parseKeys(keysFlag, sigsFlag, hashFlag, weightFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	if createFlags.UseFaucet {
+		return createWithFaucet(globalFlags, logger, flow, keys)
+	}
+
+	signer, err := util.AccountByName(state, createFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+	if weight := totalWeight(keys); weight < flowsdk.AccountKeyWeightThreshold {
+		logger.Info(fmt.Sprintf(
+			"%s The keys have a total weight of %d, below the %d required to sign transactions for the account",
+			output.WarningEmoji(),
+			weight,
+			flowsdk.AccountKeyWeightThreshold,
+		))
+	}
+
+	var account *flowsdk.Account
+	var txID flowsdk.Identifier
+	if createFlags.IdempotencyKey != "" {
+		account, txID, err = createAccountIdempotent(
+			flow,
+			state.ReaderWriter(),
+			util.ProjectDir(globalFlags.ConfigPaths),
+			logger,
+			createFlags.IdempotencyKey,
+			signer,
+			keys,
+		)
+	} else {
+		account, txID, err = flow.CreateAccount(context.Background(), signer, keys)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account:       account,
+		include:       createFlags.Include,
+		transactionID: txID,
+		link:          command.NewExplorerLink(flow.Network(), globalFlags),
+	}, nil
+}
+
+// createWithFaucet creates an account with the key using the testnet faucet and waits for the account to be created.
+func createWithFaucet(
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	keys []accounts.PublicKey,
+) (command.Result, error) {
+	if len(keys) != 1 {
+		return nil, fmt.Errorf("the faucet creates accounts with a single key, provide one key using the --key flag")
+	}
+
+	faucet, err := newFaucet(flow.Network(), createFlags.FaucetToken)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress("Creating account using the faucet...")
+	defer logger.StopProgress()
+
+	txID, err := faucet.createAccount(keys[0])
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := waitForFaucetTransaction(flow, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := flowkit.CreatedAccounts(result)
 	if err != nil {
 		return nil, err
 	}
+	if len(created) == 0 {
+		return nil, fmt.Errorf("faucet transaction %s didn't create an account", txID)
+	}
+
+	account, err := flow.GetAccount(context.Background(), created[0].Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account:       account,
+		include:       createFlags.Include,
+		transactionID: txID,
+		link:          command.NewExplorerLink(flow.Network(), globalFlags),
+	}, nil
+}
+
+// createWithContracts creates an account with a generated key and deploys the contracts in the creation transaction,
+// adding the account, the contracts and their deployment on the network to the configuration.
+func createWithContracts(
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if len(createFlags.Keys) > 0 || len(createFlags.PublicKeys) > 0 {
+		return nil, fmt.Errorf("keys can not be provided when deploying contracts, a key is generated for the new account")
+	}
+	if createFlags.Name == "" {
+		return nil, fmt.Errorf("account name is required when deploying contracts, provide it using the --name flag")
+	}
+	if _, err := state.Accounts().ByName(createFlags.Name); err == nil {
+		return nil, fmt.Errorf("account %s already exists in the configuration", createFlags.Name)
+	}
+
+	signer, err := util.AccountByName(state, createFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make([]config.Contract, 0, len(createFlags.Deploy))
+	scripts := make([]flowkit.Script, 0, len(createFlags.Deploy))
+	for _, deploy := range createFlags.Deploy {
+		name, location, ok := strings.Cut(deploy, ":")
+		if !ok || name == "" || location == "" {
+			return nil, fmt.Errorf("invalid contract %s, expected the format name:path", deploy)
+		}
+
+		code, err := state.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("error loading contract file: %w", err)
+		}
+
+		program, err := project.NewProgram(code, nil, location)
+		if err != nil {
+			return nil, err
+		}
+		declared, err := program.Name()
+		if err != nil {
+			return nil, err
+		}
+		if declared != name {
+			return nil, fmt.Errorf("contract %s declared in %s doesn't match the name %s", declared, location, name)
+		}
+
+		contracts = append(contracts, config.Contract{Name: name, Location: location})
+		scripts = append(scripts, flowkit.Script{Code: code, Location: location})
+	}
+
+	key, err := flow.GenerateKey(context.Background(), defaultSignAlgo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	account, txID, err := flow.CreateAccount(
+		context.Background(),
+		signer,
+		[]accounts.PublicKey{{
+			Public:   key.PublicKey(),
+			Weight:   flowsdk.AccountKeyWeightThreshold,
+			SigAlgo:  defaultSignAlgo,
+			HashAlgo: defaultHashAlgo,
+		}},
+		scripts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	privateFile := fmt.Sprintf("%s.pkey", createFlags.Name)
+	err = util.AddToGitIgnore(privateFile, state.ReaderWriter())
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.ReaderWriter().WriteFile(privateFile, []byte(key.String()), os.FileMode(0644))
+	if err != nil {
+		return nil, fmt.Errorf("failed saving private key: %w", err)
+	}
+
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    createFlags.Name,
+		Address: account.Address,
+		Key:     accounts.NewFileKey(privateFile, 0, defaultSignAlgo, defaultHashAlgo),
+	})
+
+	deployment := config.Deployment{
+		Network: flow.Network().Name,
+		Account: createFlags.Name,
+	}
+	for _, contract := range contracts {
+		if _, err := state.Contracts().ByName(contract.Name); err != nil {
+			state.Contracts().AddOrUpdate(contract)
+		}
+		deployment.Contracts = append(deployment.Contracts, config.ContractDeployment{Name: contract.Name})
+	}
+	state.Deployments().AddOrUpdate(deployment)
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf(
+		"Account %s and the deployment of its contracts on %s added to the configuration, the private key is saved to %s",
+		createFlags.Name,
+		flow.Network().Name,
+		privateFile,
+	))
+
+	return &accountResult{
+		Account:       account,
+		include:       createFlags.Include,
+		transactionID: txID,
+		link:          command.NewExplorerLink(flow.Network(), globalFlags),
+	}, nil
+}
+
+// parseKeys parses the public keys of the key flag with the signature algorithms, hash algorithms and weights
+// provided by the list flags, or by the settings following each key if any key has them.
+func parseKeys(keysFlag []string, sigsFlag []string, hashFlag []string, weightFlag []int) ([]accounts.PublicKey, error) {
+	if hasKeySettings(keysFlag) {
+		return parseKeysWithSettings(keysFlag, sigsFlag, hashFlag, weightFlag)
+	}
 
 	if len(sigsFlag) == 1 && len(hashFlag) == 1 {
 		// Fill up depending on size of key input
-		if len(createFlags.Keys) > 1 {
-			for i := 1; i < len(createFlags.Keys); i++ {
+		if len(keysFlag) > 1 {
+			for i := 1; i < len(keysFlag); i++ {
 				sigsFlag = append(sigsFlag, sigsFlag[0])
 				hashFlag = append(hashFlag, hashFlag[0])
 			}
@@ -113,19 +377,90 @@ func create(
 		}
 	}
 
-	account, _, err := flow.CreateAccount(
-		context.Background(),
-		signer,
-		keys,
-	)
-	if err != nil {
-		return nil, err
+	return keys, nil
+}
+
+// keySettingsSeparator separates the public key from its settings in the key flag.
+const keySettingsSeparator = ":"
+
+func hasKeySettings(keys []string) bool {
+	for _, key := range keys {
+		if strings.Contains(key, keySettingsSeparator) {
+			return true
+		}
 	}
+	return false
+}
 
-	return &accountResult{
-		Account: account,
-		include: createFlags.Include,
-	}, nil
+// parseKeysWithSettings parses keys each optionally followed by its settings in the format
+// key:weight=500:sig-algo=ECDSA_secp256k1:hash-algo=SHA2_256, settings not provided for a key
+// are taken from the list flags which must then contain a single value.
+func parseKeysWithSettings(keysFlag []string, sigsFlag []string, hashFlag []string, weightFlag []int) ([]accounts.PublicKey, error) {
+	if len(sigsFlag) > 1 || len(hashFlag) > 1 || len(weightFlag) > 1 {
+		return nil, fmt.Errorf("settings following a key can not be combined with multiple --sig-algo, --hash-algo or --key-weight values, provide the settings of each key after it")
+	}
+
+	keys := make([]accounts.PublicKey, 0, len(keysFlag))
+	for _, keyFlag := range keysFlag {
+		parts := strings.Split(keyFlag, keySettingsSeparator)
+		sigAlgo := defaultSignAlgo.String()
+		if len(sigsFlag) == 1 {
+			sigAlgo = sigsFlag[0]
+		}
+		hashAlgo := defaultHashAlgo.String()
+		if len(hashFlag) == 1 {
+			hashAlgo = hashFlag[0]
+		}
+		weight := flowsdk.AccountKeyWeightThreshold
+		if len(weightFlag) == 1 {
+			weight = weightFlag[0]
+		}
+
+		for _, setting := range parts[1:] {
+			name, value, _ := strings.Cut(setting, "=")
+			switch name {
+			case "sig-algo":
+				sigAlgo = value
+			case "hash-algo":
+				hashAlgo = value
+			case "weight":
+				parsed, err := strconv.Atoi(value)
+				if err != nil || parsed < 0 || parsed > flowsdk.AccountKeyWeightThreshold {
+					return nil, fmt.Errorf("invalid key weight %s, must be between 0 and %d", value, flowsdk.AccountKeyWeightThreshold)
+				}
+				weight = parsed
+			default:
+				return nil, fmt.Errorf("invalid key setting %s, expected weight, sig-algo or hash-algo", setting)
+			}
+		}
+
+		sigAlgos, err := parseSignatureAlgorithms([]string{sigAlgo})
+		if err != nil {
+			return nil, err
+		}
+		hashAlgos, err := parseHashingAlgorithms([]string{hashAlgo})
+		if err != nil {
+			return nil, err
+		}
+		pubKeys, err := parsePublicKeys(parts[:1], sigAlgos)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, accounts.PublicKey{
+			Public: pubKeys[0], Weight: weight, SigAlgo: sigAlgos[0], HashAlgo: hashAlgos[0],
+		})
+	}
+
+	return keys, nil
+}
+
+func totalWeight(keys []accounts.PublicKey) int {
+	total := 0
+	for _, key := range keys {
+		total += key.Weight
+	}
+	return total
 }
 
 func parseHashingAlgorithms(algorithms []string) ([]crypto.HashAlgorithm, error) {
@@ -152,10 +487,58 @@ func parseSignatureAlgorithms(algorithms []string) ([]crypto.SignatureAlgorithm,
 	return sigAlgos, nil
 }
 
+// readPublicKeyFiles replaces the paths of PEM files in the public key flag with the PEM encoded keys,
+// keeping the settings following each key. Hex encoded keys are returned as provided.
+func readPublicKeyFiles(publicKeys []string, readerWriter flowkit.ReaderWriter) ([]string, error) {
+	keys := make([]string, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		key, settings, hasSettings := strings.Cut(publicKey, keySettingsSeparator)
+		if _, err := hex.DecodeString(strings.TrimPrefix(key, "0x")); err == nil {
+			keys = append(keys, publicKey)
+			continue
+		}
+
+		content, err := readerWriter.ReadFile(key)
+		if err != nil {
+			return nil, fmt.Errorf("public key %s is neither hex encoded nor a readable PEM file: %w", key, err)
+		}
+		if block, _ := pem.Decode(content); block == nil {
+			return nil, fmt.Errorf("public key file %s doesn't contain a PEM encoded key", key)
+		}
+
+		key = strings.TrimSpace(string(content))
+		if hasSettings {
+			key = key + keySettingsSeparator + settings
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// uncompressedPointPrefix prefixes the hex of public keys exported as an uncompressed curve point, such as
+// by hardware wallets, which is dropped since Flow encodes ECDSA public keys as the 64 bytes of the coordinates.
+const (
+	uncompressedPointPrefix = "04"
+	uncompressedPointLength = len(uncompressedPointPrefix) + 2*64
+)
+
 func parsePublicKeys(publicKeys []string, sigAlgorithms []crypto.SignatureAlgorithm) ([]crypto.PublicKey, error) {
-	pubKeys := make([]crypto.PublicKey, 0, len(createFlags.Keys))
+	pubKeys := make([]crypto.PublicKey, 0, len(publicKeys))
 	for i, k := range publicKeys {
+		if strings.HasPrefix(k, "-----BEGIN") {
+			key, err := crypto.DecodePublicKeyPEM(sigAlgorithms[i], k)
+			if err != nil {
+				return nil, fmt.Errorf("failed decoding PEM public key with error: %w", err)
+			}
+			pubKeys = append(pubKeys, key)
+			continue
+		}
+
 		k = strings.TrimPrefix(k, "0x") // clear possible prefix
+		if len(k) == uncompressedPointLength && strings.HasPrefix(k, uncompressedPointPrefix) {
+			k = strings.TrimPrefix(k, uncompressedPointPrefix)
+		}
 		key, err := crypto.DecodePublicKeyHex(sigAlgorithms[i], k)
 		if err != nil {
 			return nil, fmt.Errorf("failed decoding public key: %s with error: %w", k, err)
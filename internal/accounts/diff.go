@@ -0,0 +1,242 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDiff struct {
+	From uint64 `default:"" flag:"from" info:"Block height to compare the account from"`
+	To   uint64 `default:"" flag:"to" info:"Block height to compare the account to, the latest sealed block if not provided"`
+}
+
+var diffFlags = flagsDiff{}
+
+var diffCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "diff <address | account name>",
+		Short: "Show the changes of an account between two block heights",
+		Long: `Show the changes of the balance, the keys and the contracts of an account between two block heights.
+
+Access nodes only serve the state of recent blocks, use an archive node to compare older block heights.`,
+		Example: `flow accounts diff f8d6e0586b0a20c7 --from 58423160 --to 58423900 --network mainnet
+flow accounts diff alice --from 120`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags:  &diffFlags,
+	Output: diffOutput{},
+	Run:    diff,
+}
+
+func diff(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if diffFlags.From == 0 {
+		return nil, fmt.Errorf("provide the block height to compare the account from using the --from flag")
+	}
+
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	to := diffFlags.To
+	if to == 0 {
+		latest, err := flow.GetBlock(context.Background(), flowkit.LatestBlockQuery)
+		if err != nil {
+			return nil, err
+		}
+		to = latest.Height
+	}
+	if diffFlags.From >= to {
+		return nil, fmt.Errorf("the block height to compare from must be lower than %d", to)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading account %s at block heights %d and %d...", address, diffFlags.From, to))
+	defer logger.StopProgress()
+
+	before, err := flow.GetAccountAtBlockHeight(context.Background(), address, diffFlags.From)
+	if err != nil {
+		return nil, err
+	}
+	after, err := flow.GetAccountAtBlockHeight(context.Background(), address, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAccountDiff(before, after, diffFlags.From, to), nil
+}
+
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeUpdated = "updated"
+	changeRevoked = "revoked"
+)
+
+// diffOutput is the JSON output of the diff command.
+type diffOutput struct {
+	Address    string           `json:"address"`
+	FromHeight uint64           `json:"fromHeight"`
+	ToHeight   uint64           `json:"toHeight"`
+	Balance    balanceChange    `json:"balance"`
+	Keys       []keyChange      `json:"keys"`
+	Contracts  []contractChange `json:"contracts"`
+}
+
+type balanceChange struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Change string `json:"change"`
+}
+
+type keyChange struct {
+	Index  int    `json:"index"`
+	Change string `json:"change"`
+	Weight int    `json:"weight"`
+}
+
+type contractChange struct {
+	Name   string `json:"name"`
+	Change string `json:"change"`
+}
+
+// newAccountDiff compares the account before and after, keys can only be added or revoked and contracts can be
+// added, removed or updated.
+func newAccountDiff(before *flowsdk.Account, after *flowsdk.Account, from uint64, to uint64) *diffResult {
+	change := "+" + cadence.UFix64(after.Balance-before.Balance).String()
+	if after.Balance < before.Balance {
+		change = "-" + cadence.UFix64(before.Balance-after.Balance).String()
+	}
+
+	keys := make([]keyChange, 0)
+	for _, key := range after.Keys {
+		if key.Index >= len(before.Keys) {
+			keys = append(keys, keyChange{Index: key.Index, Change: changeAdded, Weight: key.Weight})
+		} else if key.Revoked && !before.Keys[key.Index].Revoked {
+			keys = append(keys, keyChange{Index: key.Index, Change: changeRevoked, Weight: key.Weight})
+		}
+	}
+
+	contracts := make([]contractChange, 0)
+	for name, code := range after.Contracts {
+		previous, ok := before.Contracts[name]
+		if !ok {
+			contracts = append(contracts, contractChange{Name: name, Change: changeAdded})
+		} else if !bytes.Equal(previous, code) {
+			contracts = append(contracts, contractChange{Name: name, Change: changeUpdated})
+		}
+	}
+	for name := range before.Contracts {
+		if _, ok := after.Contracts[name]; !ok {
+			contracts = append(contracts, contractChange{Name: name, Change: changeRemoved})
+		}
+	}
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].Name < contracts[j].Name
+	})
+
+	return &diffResult{diffOutput{
+		Address:    fmt.Sprintf("0x%s", after.Address),
+		FromHeight: from,
+		ToHeight:   to,
+		Balance: balanceChange{
+			From:   cadence.UFix64(before.Balance).String(),
+			To:     cadence.UFix64(after.Balance).String(),
+			Change: change,
+		},
+		Keys:      keys,
+		Contracts: contracts,
+	}}
+}
+
+type diffResult struct {
+	diffOutput
+}
+
+func (r *diffResult) unchanged() bool {
+	return r.Balance.From == r.Balance.To && len(r.Keys) == 0 && len(r.Contracts) == 0
+}
+
+func (r *diffResult) JSON() any {
+	return r.diffOutput
+}
+
+func (r *diffResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address\t%s\n", r.Address)
+	_, _ = fmt.Fprintf(writer, "Block Heights\t%d to %d\n", r.FromHeight, r.ToHeight)
+	if r.unchanged() {
+		_, _ = fmt.Fprintf(writer, "\nNo changes\n")
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "Balance\t%s to %s (%s)\n", r.Balance.From, r.Balance.To, r.Balance.Change)
+
+	if len(r.Keys) > 0 {
+		_, _ = fmt.Fprintf(writer, "\nKeys\n")
+		for _, key := range r.Keys {
+			_, _ = fmt.Fprintf(writer, "\t%d\t%s\tweight %d\n", key.Index, key.Change, key.Weight)
+		}
+	}
+
+	if len(r.Contracts) > 0 {
+		_, _ = fmt.Fprintf(writer, "\nContracts\n")
+		for _, contract := range r.Contracts {
+			_, _ = fmt.Fprintf(writer, "\t%s\t%s\n", contract.Name, contract.Change)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *diffResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Balance: %s, Keys: %d changed, Contracts: %d changed",
+		r.Balance.Change,
+		len(r.Keys),
+		len(r.Contracts),
+	)
+}
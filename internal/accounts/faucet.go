@@ -0,0 +1,209 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// faucetURL is the address of the public testnet faucet API.
+var faucetURL = "https://testnet-faucet.onflow.org"
+
+// faucetTokenEnv is the environment variable the faucet API token is read from if not provided by a flag.
+const faucetTokenEnv = "FLOW_FAUCET_TOKEN"
+
+// faucetPollInterval is how often the faucet transaction is checked for being sealed.
+var faucetPollInterval = time.Second
+
+// faucetTimeout is how long the faucet transaction is waited for to be sealed.
+const faucetTimeout = 2 * time.Minute
+
+// faucetRequestTimeout is how long a faucet request is waited for, the faucet responds once it sent the
+// transaction, which takes longer than most requests. Faucet requests are not retried after a timeout.
+const faucetRequestTimeout = 5 * time.Minute
+
+// faucet requests accounts and tokens from the testnet faucet API.
+//
+// The API token identifies the caller instead of solving a captcha, requests without a token are
+// accepted by the faucet but are rate limited.
+type faucet struct {
+	url    string
+	token  string
+	client *util.HTTPClient
+}
+
+// newFaucet returns the faucet for the network, failing if the network has no faucet.
+func newFaucet(network config.Network, token string) (*faucet, error) {
+	if network.Name != config.TestnetNetwork.Name {
+		return nil, fmt.Errorf("the faucet is only available on testnet, use the --network testnet flag")
+	}
+
+	if token == "" {
+		token = os.Getenv(faucetTokenEnv)
+	}
+
+	options := util.DefaultHTTPOptions
+	options.Timeout = faucetRequestTimeout
+	client, err := util.NewHTTPClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &faucet{url: strings.TrimSuffix(faucetURL, "/"), token: token, client: client}, nil
+}
+
+type faucetAccountRequest struct {
+	PublicKey          string `json:"publicKey"`
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+	HashAlgorithm      string `json:"hashAlgorithm"`
+}
+
+type faucetFundRequest struct {
+	Address string `json:"address"`
+}
+
+type faucetResponse struct {
+	TransactionID string `json:"transactionId"`
+	Error         string `json:"error"`
+}
+
+// createAccount requests an account with the key and returns the ID of the account creation transaction.
+func (f *faucet) createAccount(key accounts.PublicKey) (flowsdk.Identifier, error) {
+	if key.Weight != flowsdk.AccountKeyWeightThreshold {
+		return flowsdk.EmptyID, fmt.Errorf(
+			"the faucet creates accounts with a key of weight %d",
+			flowsdk.AccountKeyWeightThreshold,
+		)
+	}
+
+	id, err := f.request("/api/v1/accounts", faucetAccountRequest{
+		PublicKey:          strings.TrimPrefix(key.Public.String(), "0x"),
+		SignatureAlgorithm: key.SigAlgo.String(),
+		HashAlgorithm:      key.HashAlgo.String(),
+	})
+	if err != nil {
+		return flowsdk.EmptyID, fmt.Errorf("could not create an account using the faucet: %w", err)
+	}
+
+	return id, nil
+}
+
+// fund requests tokens for the account and returns the ID of the funding transaction.
+func (f *faucet) fund(address flowsdk.Address) (flowsdk.Identifier, error) {
+	id, err := f.request("/api/v1/fund", faucetFundRequest{Address: fmt.Sprintf("0x%s", address.String())})
+	if err != nil {
+		return flowsdk.EmptyID, fmt.Errorf("could not fund the account using the faucet: %w", err)
+	}
+
+	return id, nil
+}
+
+func (f *faucet) request(path string, body any) (flowsdk.Identifier, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, f.url+path, bytes.NewReader(data))
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+	request.Header.Add("Content-Type", "application/json; charset=UTF-8")
+	if f.token != "" {
+		request.Header.Add("Authorization", "Bearer "+f.token)
+	}
+
+	res, err := f.client.Do(request)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+	defer res.Body.Close()
+
+	resBody, _ := io.ReadAll(res.Body)
+	var faucetRes faucetResponse
+	_ = json.Unmarshal(resBody, &faucetRes)
+
+	if res.StatusCode == http.StatusTooManyRequests && f.token == "" {
+		return flowsdk.EmptyID, fmt.Errorf(
+			"rate limited, provide an API token using the --faucet-token flag or the %s environment variable",
+			faucetTokenEnv,
+		)
+	}
+	if res.StatusCode != http.StatusOK {
+		if faucetRes.Error != "" {
+			return flowsdk.EmptyID, fmt.Errorf("%s", faucetRes.Error)
+		}
+		return flowsdk.EmptyID, fmt.Errorf("faucet responded with status %s", res.Status)
+	}
+
+	id := flowsdk.HexToID(faucetRes.TransactionID)
+	if id == flowsdk.EmptyID {
+		return flowsdk.EmptyID, fmt.Errorf("faucet responded without a transaction ID")
+	}
+
+	return id, nil
+}
+
+// waitForFaucetTransaction polls the transaction sent by the faucet until it's sealed and returns its result.
+//
+// The faucet might return before the transaction propagated to the access node, so a transaction
+// not found yet is polled again until the timeout, as is a temporarily unavailable access node.
+func waitForFaucetTransaction(flow flowkit.Services, id flowsdk.Identifier) (*flowsdk.TransactionResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), faucetTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(faucetPollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, result, err := flow.GetTransactionByID(ctx, id, false)
+		if err != nil && status.Code(err) != codes.NotFound && status.Code(err) != codes.Unavailable {
+			return nil, err
+		}
+		if err == nil && result.Status == flowsdk.TransactionStatusSealed {
+			if result.Error != nil {
+				return nil, fmt.Errorf("faucet transaction %s failed: %w", id, result.Error)
+			}
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("faucet transaction %s was not sealed in %s: %w", id, faucetTimeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
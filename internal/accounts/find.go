@@ -0,0 +1,216 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsFind struct {
+	PublicKey string `default:"" flag:"public-key" info:"Public key in hex to find the accounts of"`
+	Scan      bool   `default:"false" flag:"scan" info:"Scan the accounts in the configuration instead of using the key indexer"`
+}
+
+var findFlags = flagsFind{}
+
+var findCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "find --public-key <hex>",
+		Short: "Find the accounts with a public key",
+		Long: `Find the accounts with a public key using the public key indexer on mainnet and testnet.
+
+On other networks, or with the --scan flag, the accounts in the configuration with an address of the selected network
+are fetched and searched for the key instead.`,
+		Example: `flow accounts find --public-key 0x6e6bf9...d3c1 --network mainnet
+flow accounts find --public-key 6e6bf9...d3c1 --scan`,
+		Args: cobra.NoArgs,
+	},
+	Flags:  &findFlags,
+	Output: []foundKeyOutput{},
+	Run:    find,
+}
+
+func find(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	publicKey, err := parsePublicKeyHex(findFlags.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	indexerURL, hasIndexer := util.KeyIndexerURLs[flow.Network().Name]
+	if hasIndexer && !findFlags.Scan {
+		logger.StartProgress("Looking up the public key...")
+		defer logger.StopProgress()
+
+		indexed, err := util.LookupPublicKey(indexerURL, publicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		found := make([]foundKey, 0, len(indexed))
+		for _, key := range indexed {
+			found = append(found, foundKey{
+				address: key.Address,
+				index:   key.Index,
+				weight:  key.Weight,
+				revoked: key.Revoked,
+			})
+		}
+		return &findResult{publicKey: publicKey, keys: found, scanned: false}, nil
+	}
+
+	state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, fmt.Errorf("scanning the configured accounts requires a project configuration, the key indexer is only available on mainnet and testnet")
+	}
+
+	chain := util.NetworkChain(flow.Network())
+	var configured []accounts.Account
+	for _, account := range *state.Accounts() {
+		if network, _ := util.GetAddressNetwork(account.Address); network == chain {
+			configured = append(configured, account)
+		}
+	}
+
+	logger.StartProgress(fmt.Sprintf("Scanning %d configured accounts...", len(configured)))
+	defer logger.StopProgress()
+
+	found := make([]foundKey, 0)
+	for _, listed := range fetchAccounts(flow, configured) {
+		if listed.err != nil {
+			logger.Error(fmt.Sprintf("failed to get account %s: %s", listed.name, listed.err))
+			continue
+		}
+		for _, key := range listed.account.Keys {
+			if hex.EncodeToString(key.PublicKey.Encode()) == publicKey {
+				found = append(found, foundKey{
+					address: listed.address,
+					account: listed.name,
+					index:   key.Index,
+					weight:  key.Weight,
+					revoked: key.Revoked,
+				})
+			}
+		}
+	}
+
+	return &findResult{publicKey: publicKey, keys: found, scanned: true}, nil
+}
+
+// parsePublicKeyHex returns the public key as lowercase hex without the 0x prefix.
+func parsePublicKeyHex(value string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("provide the public key to find using the --public-key flag")
+	}
+
+	publicKey := strings.ToLower(strings.TrimPrefix(value, "0x"))
+	if _, err := hex.DecodeString(publicKey); err != nil {
+		return "", fmt.Errorf("invalid public key %s, expected a hex encoded key", value)
+	}
+
+	return publicKey, nil
+}
+
+// foundKey is an account key matching the public key, the account name is set for configured accounts.
+type foundKey struct {
+	address flowsdk.Address
+	account string
+	index   int
+	weight  int
+	revoked bool
+}
+
+type findResult struct {
+	publicKey string
+	keys      []foundKey
+	scanned   bool
+}
+
+// foundKeyOutput is the JSON output of an account key found by the find command.
+type foundKeyOutput struct {
+	Address  string `json:"address"`
+	Account  string `json:"account,omitempty"`
+	KeyIndex int    `json:"keyIndex"`
+	Weight   int    `json:"weight"`
+	Revoked  bool   `json:"revoked"`
+}
+
+func (r *findResult) JSON() any {
+	keys := make([]foundKeyOutput, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, foundKeyOutput{
+			Address:  fmt.Sprintf("0x%s", key.address),
+			Account:  key.account,
+			KeyIndex: key.index,
+			Weight:   key.weight,
+			Revoked:  key.revoked,
+		})
+	}
+
+	return keys
+}
+
+func (r *findResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	source := "the key indexer"
+	if r.scanned {
+		source = "the configured accounts"
+	}
+	if len(r.keys) == 0 {
+		_, _ = fmt.Fprintf(writer, "No accounts with the public key were found in %s\n", source)
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "Accounts with the public key found in %s\n\n", source)
+	_, _ = fmt.Fprintf(writer, "Address\tAccount\tKey Index\tWeight\tRevoked\n")
+	for _, key := range r.keys {
+		_, _ = fmt.Fprintf(writer, "0x%s\t%s\t%d\t%d\t%t\n", key.address, key.account, key.index, key.weight, key.revoked)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *findResult) Oneliner() string {
+	addresses := make([]string, 0, len(r.keys))
+	for _, key := range r.keys {
+		addresses = append(addresses, fmt.Sprintf("0x%s", key.address))
+	}
+	return strings.Join(addresses, ", ")
+}
@@ -0,0 +1,112 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsFund struct {
+	FaucetToken   string `default:"" flag:"faucet-token" info:"API token of the testnet faucet, read from the FLOW_FAUCET_TOKEN environment variable if not provided"`
+	TransactionID string `default:"" flag:"transaction-id" info:"resume an interrupted funding by waiting for its faucet transaction instead of requesting tokens again"`
+}
+
+var fundFlags = flagsFund{}
+
+var fundCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "fund <address | account name>",
+		Short: "Fund an account with tokens from the testnet faucet",
+		Example: `flow accounts fund 0x01cf0e2f2f715450 --network testnet
+flow accounts fund alice --network testnet --faucet-token $TOKEN
+flow accounts fund alice --network testnet --transaction-id 0a5b...`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &fundFlags,
+	Run:   fund,
+}
+
+func fund(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	faucet, err := newFaucet(flow.Network(), fundFlags.FaucetToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Funding account %s using the faucet...", address))
+	defer logger.StopProgress()
+
+	var txID flowsdk.Identifier
+	if fundFlags.TransactionID != "" {
+		txID = flowsdk.HexToID(strings.TrimPrefix(fundFlags.TransactionID, "0x"))
+		if txID == flowsdk.EmptyID {
+			return nil, fmt.Errorf("invalid transaction ID: %s", fundFlags.TransactionID)
+		}
+	} else {
+		txID, err = faucet.fund(address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = waitForFaucetTransaction(flow, txID)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w, resume waiting using the --transaction-id %s flag", err, txID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account:       account,
+		transactionID: txID,
+		link:          command.NewExplorerLink(flow.Network(), globalFlags),
+	}, nil
+}
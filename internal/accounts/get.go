@@ -28,20 +28,23 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsGet struct {
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	Include     []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	BlockHeight uint64   `default:"" flag:"block-height" info:"block height to get the account at, for reading historical state"`
 }
 
 var getFlags = flagsGet{}
 
 var getCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <address>",
-		Short:   "Gets an account by address",
-		Example: "flow accounts get f8d6e0586b0a20c7",
-		Args:    cobra.ExactArgs(1),
+		Use:   "get <address | account name>",
+		Short: "Gets an account by address",
+		Example: `flow accounts get f8d6e0586b0a20c7
+flow accounts get f8d6e0586b0a20c7 --block-height 58423160 --network mainnet`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &getFlags,
 	Run:   get,
@@ -49,17 +52,30 @@ var getCommand = &command.Command{
 
 func get(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
-	_ flowkit.ReaderWriter,
+	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
-	address := flowsdk.HexToAddress(args[0])
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
 
 	logger.StartProgress(fmt.Sprintf("Loading account %s...", address))
 	defer logger.StopProgress()
 
-	account, err := flow.GetAccount(context.Background(), address)
+	var account *flowsdk.Account
+	if getFlags.BlockHeight != 0 {
+		account, err = flow.GetAccountAtBlockHeight(context.Background(), address, getFlags.BlockHeight)
+	} else {
+		account, err = flow.GetAccount(context.Background(), address)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -67,5 +83,6 @@ func get(
 	return &accountResult{
 		Account: account,
 		include: getFlags.Include,
+		link:    command.NewExplorerLink(flow.Network(), globalFlags),
 	}, nil
 }
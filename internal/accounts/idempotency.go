@@ -0,0 +1,283 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	flowgo "github.com/onflow/flow-go/model/flow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+)
+
+// idempotencyFile stores created accounts by the idempotency keys next to the project configuration,
+// so retried automation jobs don't create duplicate accounts.
+const idempotencyFile = ".flow/idempotency.json"
+
+// idempotencyRecord is saved with the signed transaction before the account creation transaction is sent,
+// and the address is added once the transaction is sealed.
+type idempotencyRecord struct {
+	Address       string `json:"address,omitempty"`
+	TransactionID string `json:"transactionId"`
+	Transaction   string `json:"transaction,omitempty"`
+	Request       string `json:"request"`
+}
+
+// idempotencyRecords are records by the network name and the idempotency key.
+type idempotencyRecords struct {
+	path    string
+	records map[string]map[string]idempotencyRecord
+}
+
+func loadIdempotencyRecords(readerWriter flowkit.ReaderWriter, projectDir string) (*idempotencyRecords, error) {
+	records := &idempotencyRecords{
+		path:    filepath.Join(projectDir, idempotencyFile),
+		records: make(map[string]map[string]idempotencyRecord),
+	}
+
+	data, err := readerWriter.ReadFile(records.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// the file is created with the first record
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", records.path, err)
+	}
+
+	err = json.Unmarshal(data, &records.records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", records.path, err)
+	}
+
+	return records, nil
+}
+
+// lookup returns the record of the key, it fails if the key was used for a request with different parameters.
+func (r *idempotencyRecords) lookup(network string, key string, request string) (*idempotencyRecord, error) {
+	record, ok := r.records[network][key]
+	if !ok {
+		return nil, nil
+	}
+
+	if record.Request != request {
+		return nil, fmt.Errorf(
+			"idempotency key %s was already used to create an account in transaction %s with different parameters",
+			key,
+			record.TransactionID,
+		)
+	}
+
+	return &record, nil
+}
+
+func (r *idempotencyRecords) save(
+	readerWriter flowkit.ReaderWriter,
+	network string,
+	key string,
+	record idempotencyRecord,
+) error {
+	if r.records[network] == nil {
+		r.records[network] = make(map[string]idempotencyRecord)
+	}
+	r.records[network][key] = record
+
+	if err := flowkit.MkdirAll(readerWriter, filepath.Dir(r.path)); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(r.records, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return readerWriter.WriteFile(r.path, data, 0644)
+}
+
+// createRequestHash fingerprints the account creation parameters.
+func createRequestHash(signer *accounts.Account, keys []accounts.PublicKey) string {
+	hash := sha256.New()
+	hash.Write(signer.Address.Bytes())
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(hash, "|%s,%d,%s,%s", key.Public.String(), key.Weight, key.SigAlgo, key.HashAlgo)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// createAccountIdempotent creates the account recording the signed transaction with the idempotency key before
+// it's sent, so a retry after the job was interrupted resolves the address from the AccountCreated event of
+// that transaction instead of creating another account.
+func createAccountIdempotent(
+	services flowkit.Services,
+	readerWriter flowkit.ReaderWriter,
+	projectDir string,
+	logger output.Logger,
+	key string,
+	signer *accounts.Account,
+	keys []accounts.PublicKey,
+) (*flowsdk.Account, flowsdk.Identifier, error) {
+	records, err := loadIdempotencyRecords(readerWriter, projectDir)
+	if err != nil {
+		return nil, flowsdk.EmptyID, err
+	}
+
+	network := services.Network().Name
+	request := createRequestHash(signer, keys)
+	record, err := records.lookup(network, key, request)
+	if err != nil {
+		return nil, flowsdk.EmptyID, err
+	}
+
+	if record != nil {
+		address, err := createdAddress(services, logger, *record)
+		if err != nil {
+			return nil, flowsdk.EmptyID, fmt.Errorf(
+				"failed to resolve the account created with the idempotency key %s in transaction %s: %w",
+				key,
+				record.TransactionID,
+				err,
+			)
+		}
+
+		// the previous transaction failed or expired, so it's safe to create the account again
+		if address != nil {
+			logger.Info(fmt.Sprintf("Account 0x%s was already created with the idempotency key %s", address.Hex(), key))
+
+			if record.Address == "" {
+				record.Address = address.Hex()
+				if err := records.save(readerWriter, network, key, *record); err != nil {
+					return nil, flowsdk.EmptyID, err
+				}
+			}
+
+			account, err := services.GetAccount(context.Background(), *address)
+			return account, flowsdk.HexToID(record.TransactionID), err
+		}
+	}
+
+	ctx := flowkit.WithBeforeSendHook(context.Background(), func(tx *transactions.Transaction) error {
+		err := records.save(readerWriter, network, key, idempotencyRecord{
+			TransactionID: tx.FlowTransaction().ID().String(),
+			Transaction:   hex.EncodeToString(tx.FlowTransaction().Encode()),
+			Request:       request,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record the idempotency key: %w", err)
+		}
+		return nil
+	})
+
+	account, txID, err := services.CreateAccount(ctx, signer, keys)
+	if err != nil {
+		return nil, flowsdk.EmptyID, err
+	}
+
+	err = records.save(readerWriter, network, key, idempotencyRecord{
+		Address:       account.Address.Hex(),
+		TransactionID: txID.String(),
+		Request:       request,
+	})
+	if err != nil {
+		return nil, flowsdk.EmptyID, fmt.Errorf("account 0x%s created but failed to record the idempotency key: %w", account.Address.Hex(), err)
+	}
+
+	return account, txID, nil
+}
+
+// createdAddress returns the address of the account created by the recorded transaction,
+// or nil if the transaction failed or expired.
+//
+// A transaction the access node doesn't know is sent again, it has the same ID so it's executed
+// at most once. Once its reference block is past the expiry window it can't be executed anymore
+// and the record is expired instead.
+func createdAddress(services flowkit.Services, logger output.Logger, record idempotencyRecord) (*flowsdk.Address, error) {
+	if record.Address != "" {
+		address := flowsdk.HexToAddress(record.Address)
+		return &address, nil
+	}
+
+	_, result, err := services.GetTransactionByID(context.Background(), flowsdk.HexToID(record.TransactionID), true)
+	if status.Code(err) == codes.NotFound {
+		result, err = resendRecorded(services, logger, record)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || result.Error != nil {
+		return nil, nil
+	}
+
+	created, err := flowkit.CreatedAccounts(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(created) == 0 {
+		return nil, fmt.Errorf("transaction has no account created event")
+	}
+
+	return &created[0].Address, nil
+}
+
+// resendRecorded sends the recorded transaction again and returns its result, or nil if it expired.
+func resendRecorded(services flowkit.Services, logger output.Logger, record idempotencyRecord) (*flowsdk.TransactionResult, error) {
+	if record.Transaction == "" {
+		return nil, fmt.Errorf("transaction not found and it wasn't recorded to be sent again")
+	}
+
+	tx, err := transactions.NewFromPayload([]byte(record.Transaction))
+	if err != nil {
+		return nil, err
+	}
+
+	referenceID := tx.FlowTransaction().ReferenceBlockID
+	reference, err := services.GetBlock(context.Background(), flowkit.BlockQuery{ID: &referenceID})
+	if err != nil {
+		return nil, err
+	}
+	latest, err := services.GetBlock(context.Background(), flowkit.LatestBlockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	if latest.Height >= reference.Height+flowgo.DefaultTransactionExpiry {
+		logger.Info(fmt.Sprintf(
+			"%s Transaction %s was never executed and expired, creating the account again",
+			output.WarningEmoji(),
+			record.TransactionID,
+		))
+		return nil, nil
+	}
+
+	logger.Info(fmt.Sprintf("Transaction %s not found, sending it again", record.TransactionID))
+	_, result, err := services.SendSignedTransaction(context.Background(), tx)
+	return result, err
+}
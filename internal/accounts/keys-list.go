@@ -0,0 +1,142 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsKeysList struct{}
+
+var keysListFlags = flagsKeysList{}
+
+var keysListCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "list <address | account name>",
+		Short:   "List the keys of an account",
+		Example: "flow accounts keys list 0xf8d6e0586b0a20c7",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags:  &keysListFlags,
+	Output: []accountKeyOutput{},
+	Run:    keysList,
+}
+
+func keysList(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading account %s...", address))
+	defer logger.StopProgress()
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keysListResult{account: account}, nil
+}
+
+type keysListResult struct {
+	account *flowsdk.Account
+}
+
+// accountKeyOutput is the JSON output of an account key.
+type accountKeyOutput struct {
+	Index          int    `json:"index"`
+	PublicKey      string `json:"publicKey"`
+	Weight         int    `json:"weight"`
+	Revoked        bool   `json:"revoked"`
+	SequenceNumber uint64 `json:"sequenceNumber"`
+	SigAlgo        string `json:"sigAlgo"`
+	HashAlgo       string `json:"hashAlgo"`
+}
+
+func (r *keysListResult) JSON() any {
+	keys := make([]accountKeyOutput, 0, len(r.account.Keys))
+	for _, key := range r.account.Keys {
+		keys = append(keys, accountKeyOutput{
+			Index:          key.Index,
+			PublicKey:      fmt.Sprintf("%x", key.PublicKey.Encode()),
+			Weight:         key.Weight,
+			Revoked:        key.Revoked,
+			SequenceNumber: key.SequenceNumber,
+			SigAlgo:        key.SigAlgo.String(),
+			HashAlgo:       key.HashAlgo.String(),
+		})
+	}
+
+	return keys
+}
+
+func (r *keysListResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Index\tWeight\tRevoked\tSequence Number\tSignature Algorithm\tHash Algorithm\tPublic Key\n")
+	for _, key := range r.account.Keys {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%d\t%d\t%t\t%d\t%s\t%s\t%x\n",
+			key.Index,
+			key.Weight,
+			key.Revoked,
+			key.SequenceNumber,
+			key.SigAlgo,
+			key.HashAlgo,
+			key.PublicKey.Encode(),
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *keysListResult) Oneliner() string {
+	active := 0
+	for _, key := range r.account.Keys {
+		if !key.Revoked {
+			active++
+		}
+	}
+	return fmt.Sprintf("Keys: %d, Revoked: %d", len(r.account.Keys), len(r.account.Keys)-active)
+}
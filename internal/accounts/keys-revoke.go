@@ -0,0 +1,194 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsKeysRevoke struct {
+	Index    int    `default:"-1" flag:"index" info:"Index of the account key to revoke"`
+	GasLimit uint64 `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var keysRevokeFlags = flagsKeysRevoke{}
+
+var keysRevokeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "revoke <account name> --index <key index>",
+		Short: "Revoke an account key",
+		Long: `Revoke an account key with a transaction signed by the account.
+
+The keys not revoked must keep a total weight of at least 1000, otherwise the account couldn't sign transactions anymore.`,
+		Example: "flow accounts keys revoke alice --index 1 --network testnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &keysRevokeFlags,
+	RunS:  keysRevoke,
+}
+
+func keysRevoke(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	index := keysRevokeFlags.Index
+	if index < 0 {
+		return nil, fmt.Errorf("provide the index of the key to revoke using the --index flag")
+	}
+
+	signer, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkRevokeKey(account, index); err != nil {
+		return nil, err
+	}
+
+	if index == signer.Key.Index() {
+		logger.Info(fmt.Sprintf(
+			"%s Account %s signs with key %d which will be revoked, configure another key for the account afterwards",
+			output.WarningEmoji(),
+			args[0],
+			index,
+		))
+	}
+
+	tx, err := flow.BuildTransaction(
+		context.Background(),
+		transactions.AddressesRoles{
+			Proposer:    signer.Address,
+			Authorizers: []flowsdk.Address{signer.Address},
+			Payer:       signer.Address,
+		},
+		signer.Key.Index(),
+		flowkit.Script{
+			Code: []byte(revokeKeyTransaction),
+			Args: []cadence.Value{cadence.NewInt(index)},
+		},
+		keysRevokeFlags.GasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction()) {
+		return nil, fmt.Errorf("transaction was not approved")
+	}
+
+	signed, err := flow.SignTransactionPayload(
+		context.Background(),
+		signer,
+		[]byte(hex.EncodeToString(tx.FlowTransaction().Encode())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, txResult, err := flow.SendSignedTransaction(context.Background(), signed)
+	if err != nil {
+		return nil, err
+	}
+	if txResult.Error != nil {
+		return nil, fmt.Errorf("transaction %s failed: %w", sentTx.ID(), txResult.Error)
+	}
+
+	return &keysRevokeResult{
+		account:       args[0],
+		index:         index,
+		transactionID: sentTx.ID(),
+	}, nil
+}
+
+// checkRevokeKey returns an error if the key doesn't exist or is revoked, or if the keys not revoked
+// would have a total weight below the threshold after revoking the key.
+func checkRevokeKey(account *flowsdk.Account, index int) error {
+	if index >= len(account.Keys) {
+		return fmt.Errorf("key %d doesn't exist on account 0x%s", index, account.Address)
+	}
+	if account.Keys[index].Revoked {
+		return fmt.Errorf("key %d of account 0x%s is already revoked", index, account.Address)
+	}
+
+	total := 0
+	for _, key := range account.Keys {
+		if !key.Revoked && key.Index != index {
+			total += key.Weight
+		}
+	}
+	if total < flowsdk.AccountKeyWeightThreshold {
+		return fmt.Errorf(
+			"the keys would have a total weight of %d, below the %d required to sign transactions for the account",
+			total,
+			flowsdk.AccountKeyWeightThreshold,
+		)
+	}
+
+	return nil
+}
+
+type keysRevokeResult struct {
+	account       string
+	index         int
+	transactionID flowsdk.Identifier
+}
+
+func (r *keysRevokeResult) JSON() any {
+	return map[string]any{
+		"account":       r.account,
+		"index":         r.index,
+		"transactionId": r.transactionID.String(),
+	}
+}
+
+func (r *keysRevokeResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Key %d of account %s revoked\n\n", output.SuccessEmoji(), r.index, r.account)
+	_, _ = fmt.Fprintf(writer, "Transaction ID\t%s\n", r.transactionID)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *keysRevokeResult) Oneliner() string {
+	return r.transactionID.String()
+}
@@ -0,0 +1,307 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// ledgerPathFormat is the Flow derivation path of the Ledger keys by address index.
+const ledgerPathFormat = "m/44'/539'/0'/0/%d"
+
+type flagsLedger struct {
+	Index          uint32 `default:"0" flag:"index" info:"Address index of the first derivation path m/44'/539'/0'/0/<index> to read"`
+	Count          int    `default:"1" flag:"count" info:"Number of consecutive derivation paths to read"`
+	DerivationPath string `default:"" flag:"derivation-path" info:"Derivation path to read instead of the indexed paths"`
+	SigAlgo        string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the Ledger key"`
+	HashAlgo       string `default:"" flag:"hash-algo" info:"Hash algorithm of the added account key, the one of the on-chain key by default"`
+	Add            string `default:"" flag:"add" info:"Name of the account to add to the configuration with the Ledger key"`
+	Address        string `default:"" flag:"address" info:"Address of the account to add, required if the key isn't found on exactly one account"`
+}
+
+var ledgerFlags = flagsLedger{}
+
+var ledgerCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "ledger",
+		Short: "Discover the accounts of the keys on a Ledger device",
+		Long: `Read the public keys of a Ledger device running the Flow app and find the accounts using them
+with the public key indexer on mainnet and testnet.
+
+Use the --add flag to add the account of a key to the configuration, the transactions of the account
+are then signed on the device. Without a configured account, '--signer ledger' selects the account
+of the key at the default derivation path.`,
+		Example: `flow accounts ledger --network mainnet
+flow accounts ledger --count 5 --network testnet
+flow accounts ledger --add my-ledger --network mainnet
+flow accounts ledger --derivation-path "m/44'/539'/0'/0/2" --add my-ledger --address 0xf8d6e0586b0a20c7`,
+		Args: cobra.NoArgs,
+	},
+	Flags:  &ledgerFlags,
+	Output: []ledgerKeyOutput{},
+	Run:    ledger,
+}
+
+func ledger(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	sigAlgo := crypto.StringToSignatureAlgorithm(ledgerFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", ledgerFlags.SigAlgo)
+	}
+	hashAlgo := crypto.UnknownHashAlgorithm
+	if ledgerFlags.HashAlgo != "" {
+		hashAlgo = crypto.StringToHashAlgorithm(ledgerFlags.HashAlgo)
+		if hashAlgo == crypto.UnknownHashAlgorithm {
+			return nil, fmt.Errorf("invalid hash algorithm: %s", ledgerFlags.HashAlgo)
+		}
+	}
+
+	paths, err := ledgerPaths(ledgerFlags.DerivationPath, ledgerFlags.Index, ledgerFlags.Count)
+	if err != nil {
+		return nil, err
+	}
+	if ledgerFlags.Add != "" && len(paths) != 1 {
+		return nil, fmt.Errorf("select a single derivation path to add the account")
+	}
+	if ledgerFlags.Address != "" && ledgerFlags.Add == "" {
+		return nil, fmt.Errorf("the address is only used to add the account, provide the account name with the --add flag")
+	}
+
+	indexerURL, hasIndexer := util.KeyIndexerURLs[flow.Network().Name]
+
+	logger.StartProgress("Reading the keys from the Ledger, approve on the device if asked...")
+	result := &ledgerResult{network: flow.Network().Name, indexed: hasIndexer}
+	for _, path := range paths {
+		// the hash algorithm isn't used to read the public key
+		key, err := accounts.NewLedgerKey(path, 0, sigAlgo, crypto.SHA3_256)
+		if err != nil {
+			logger.StopProgress()
+			return nil, err
+		}
+
+		publicKey, err := util.LedgerPublicKey(key)
+		if err != nil {
+			logger.StopProgress()
+			return nil, err
+		}
+
+		found := []util.IndexedKey{}
+		if hasIndexer {
+			found, err = util.LookupPublicKey(indexerURL, fmt.Sprintf("%x", publicKey.Encode()))
+			if err != nil {
+				logger.StopProgress()
+				return nil, err
+			}
+		}
+
+		result.keys = append(result.keys, ledgerKey{path: path, publicKey: publicKey, accounts: found})
+	}
+	logger.StopProgress()
+
+	if ledgerFlags.Add == "" {
+		return result, nil
+	}
+
+	state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, fmt.Errorf("adding the account requires a project configuration: %w", err)
+	}
+
+	account, err := ledgerAccount(flow, result.keys[0], hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	state.Accounts().AddOrUpdate(account)
+	if err := state.SaveEdited(globalFlags.ConfigPaths); err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf(
+		"Account %s with address 0x%s added to the configuration, its transactions are signed with the Ledger key at %s",
+		account.Name,
+		account.Address,
+		result.keys[0].path,
+	))
+	return result, nil
+}
+
+// ledgerPaths returns the derivation path if provided, or the paths of count address indexes starting at index.
+func ledgerPaths(derivationPath string, index uint32, count int) ([]string, error) {
+	if derivationPath != "" {
+		return []string{derivationPath}, nil
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("the number of derivation paths must be positive")
+	}
+
+	paths := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		paths = append(paths, fmt.Sprintf(ledgerPathFormat, index+uint32(i)))
+	}
+
+	return paths, nil
+}
+
+// ledgerAccount returns the account using the Ledger key, at the address flag or found by the key indexer.
+// The algorithms of the key are the ones of the on-chain key, a different hash algorithm flag is refused.
+func ledgerAccount(
+	flow flowkit.Services,
+	key ledgerKey,
+	hashAlgo crypto.HashAlgorithm,
+) (*accounts.Account, error) {
+	address := flowsdk.HexToAddress(ledgerFlags.Address)
+	keyIndex := -1
+
+	if ledgerFlags.Address == "" {
+		active := make([]util.IndexedKey, 0, len(key.accounts))
+		for _, indexed := range key.accounts {
+			if !indexed.Revoked {
+				active = append(active, indexed)
+			}
+		}
+		if len(active) != 1 {
+			return nil, fmt.Errorf(
+				"the Ledger public key at %s is used by %d known accounts, provide the account address with the --address flag",
+				key.path,
+				len(active),
+			)
+		}
+		address = active[0].Address
+		keyIndex = active[0].Index
+	}
+
+	accountKey, err := util.LedgerAccountKey(flow, address, keyIndex, key.publicKey, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey, err := accounts.NewLedgerKey(key.path, accountKey.Index, accountKey.SigAlgo, accountKey.HashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accounts.Account{
+		Name:    ledgerFlags.Add,
+		Address: address,
+		Key:     signingKey,
+	}, nil
+}
+
+// ledgerKey is a public key of the Ledger and the account keys using it.
+type ledgerKey struct {
+	path      string
+	publicKey crypto.PublicKey
+	accounts  []util.IndexedKey
+}
+
+type ledgerResult struct {
+	network string
+	indexed bool
+	keys    []ledgerKey
+}
+
+// ledgerKeyOutput is the JSON output of a Ledger key read by the ledger command.
+type ledgerKeyOutput struct {
+	DerivationPath string           `json:"derivationPath"`
+	PublicKey      string           `json:"publicKey"`
+	Accounts       []foundKeyOutput `json:"accounts"`
+}
+
+func (r *ledgerResult) JSON() any {
+	keys := make([]ledgerKeyOutput, 0, len(r.keys))
+	for _, key := range r.keys {
+		found := make([]foundKeyOutput, 0, len(key.accounts))
+		for _, indexed := range key.accounts {
+			found = append(found, foundKeyOutput{
+				Address:  fmt.Sprintf("0x%s", indexed.Address),
+				KeyIndex: indexed.Index,
+				Weight:   indexed.Weight,
+				Revoked:  indexed.Revoked,
+			})
+		}
+
+		keys = append(keys, ledgerKeyOutput{
+			DerivationPath: key.path,
+			PublicKey:      fmt.Sprintf("%x", key.publicKey.Encode()),
+			Accounts:       found,
+		})
+	}
+
+	return keys
+}
+
+func (r *ledgerResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for i, key := range r.keys {
+		if i > 0 {
+			_, _ = fmt.Fprintf(writer, "\n")
+		}
+		_, _ = fmt.Fprintf(writer, "Derivation Path\t %s\n", key.path)
+		_, _ = fmt.Fprintf(writer, "Public Key\t %x\n", key.publicKey.Encode())
+
+		if !r.indexed {
+			_, _ = fmt.Fprintf(writer, "Accounts\t not discovered on %s\n", r.network)
+			continue
+		}
+		if len(key.accounts) == 0 {
+			_, _ = fmt.Fprintf(writer, "Accounts\t none on %s\n", r.network)
+			continue
+		}
+		for _, indexed := range key.accounts {
+			revoked := ""
+			if indexed.Revoked {
+				revoked = " (revoked)"
+			}
+			_, _ = fmt.Fprintf(writer, "Account\t 0x%s key %d weight %d%s\n", indexed.Address, indexed.Index, indexed.Weight, revoked)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *ledgerResult) Oneliner() string {
+	addresses := make([]string, 0)
+	for _, key := range r.keys {
+		for _, indexed := range key.accounts {
+			addresses = append(addresses, fmt.Sprintf("0x%s", indexed.Address))
+		}
+	}
+	return strings.Join(addresses, ", ")
+}
@@ -0,0 +1,191 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsList struct{}
+
+var listFlags = flagsList{}
+
+var listCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "list",
+		Short: "List the configured accounts with their balances",
+		Long: `List the accounts in the configuration with an address of the selected network,
+the accounts are fetched in parallel to show their balance, number of keys and deployed contracts.`,
+		Example: `flow accounts list
+flow accounts list --network testnet`,
+		Args: cobra.NoArgs,
+	},
+	Flags:  &listFlags,
+	Output: []listedAccountOutput{},
+	RunS:   list,
+}
+
+func list(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	chain := util.NetworkChain(flow.Network())
+
+	var configured []accounts.Account
+	for _, account := range *state.Accounts() {
+		if network, _ := util.GetAddressNetwork(account.Address); network == chain {
+			configured = append(configured, account)
+		}
+	}
+	if len(configured) == 0 {
+		return nil, fmt.Errorf("no accounts are configured for the %s network", flow.Network().Name)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading %d accounts...", len(configured)))
+	defer logger.StopProgress()
+
+	return &listResult{accounts: fetchAccounts(flow, configured)}, nil
+}
+
+// listedAccount is a configured account with the account fetched from the network or the error fetching it.
+type listedAccount struct {
+	name    string
+	address flowsdk.Address
+	account *flowsdk.Account
+	err     error
+}
+
+// fetchAccounts fetches the accounts in parallel, the listed accounts are in the order of the configured accounts.
+func fetchAccounts(flow flowkit.Services, configured []accounts.Account) []listedAccount {
+	listed := make([]listedAccount, len(configured))
+
+	var wg sync.WaitGroup
+	for i, account := range configured {
+		wg.Add(1)
+		go func(i int, account accounts.Account) {
+			defer wg.Done()
+			fetched, err := flow.GetAccount(context.Background(), account.Address)
+			listed[i] = listedAccount{
+				name:    account.Name,
+				address: account.Address,
+				account: fetched,
+				err:     err,
+			}
+		}(i, account)
+	}
+	wg.Wait()
+
+	return listed
+}
+
+type listResult struct {
+	accounts []listedAccount
+}
+
+// listedAccountOutput is the JSON output of an account listed by the list command.
+type listedAccountOutput struct {
+	Name      string   `json:"name"`
+	Address   string   `json:"address"`
+	Balance   string   `json:"balance,omitempty"`
+	Keys      int      `json:"keys"`
+	Contracts []string `json:"contracts"`
+	Error     string   `json:"error,omitempty"`
+}
+
+func (a listedAccount) contracts() []string {
+	contracts := make([]string, 0)
+	if a.account != nil {
+		for name := range a.account.Contracts {
+			contracts = append(contracts, name)
+		}
+	}
+	sort.Strings(contracts)
+	return contracts
+}
+
+func (r *listResult) JSON() any {
+	listed := make([]listedAccountOutput, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		out := listedAccountOutput{
+			Name:      account.name,
+			Address:   fmt.Sprintf("0x%s", account.address),
+			Contracts: account.contracts(),
+		}
+		if account.err != nil {
+			out.Error = account.err.Error()
+		} else {
+			out.Balance = cadence.UFix64(account.account.Balance).String()
+			out.Keys = len(account.account.Keys)
+		}
+		listed = append(listed, out)
+	}
+
+	return listed
+}
+
+func (r *listResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Name\tAddress\tBalance\tKeys\tContracts\n")
+	for _, account := range r.accounts {
+		if account.err != nil {
+			_, _ = fmt.Fprintf(writer, "%s\t0x%s\t%s %s\n", account.name, account.address, output.ErrorEmoji(), account.err)
+			continue
+		}
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t0x%s\t%s\t%d\t%s\n",
+			account.name,
+			account.address,
+			cadence.UFix64(account.account.Balance),
+			len(account.account.Keys),
+			strings.Join(account.contracts(), ", "),
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *listResult) Oneliner() string {
+	names := make([]string, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		names = append(names, account.name)
+	}
+	return fmt.Sprintf("Accounts: %s", strings.Join(names, ", "))
+}
@@ -0,0 +1,256 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/templates"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsNFTs struct {
+	Collection string `default:"" flag:"collection" info:"Only list the NFTs of the collection, by contract name or collection type identifier"`
+}
+
+var nftsFlags = flagsNFTs{}
+
+var nftsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "nfts <address | account name>",
+		Short: "List the NFTs held by an account",
+		Long: `List the NFTs held by an account, the collections are detected by inspecting the account storage
+for resources conforming to the NonFungibleToken standard and the display metadata of each NFT is
+resolved with MetadataViews if the collection supports it.`,
+		Example: `flow accounts nfts 0xf8d6e0586b0a20c7
+flow accounts nfts 0x1d7e57aa55817448 --network mainnet --collection ExampleNFT --output json`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &nftsFlags,
+	Run:   nfts,
+}
+
+func nfts(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := templates.ByName("get-nft-collections", templates.KindScript)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading NFT collections of account %s...", address))
+	defer logger.StopProgress()
+
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: template.Source(util.NetworkChain(flow.Network())),
+			Args: []cadence.Value{cadence.NewAddress(address)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect the NFT collections of account 0x%s: %w", address, err)
+	}
+
+	collections, err := decodeNFTCollections(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if nftsFlags.Collection != "" {
+		for _, collection := range collections {
+			if matchesResourceType(nftsFlags.Collection, collection.Type) {
+				return &nftsResult{address: address, collections: []nftCollection{collection}}, nil
+			}
+		}
+		return nil, fmt.Errorf("no NFT collection %s found in account 0x%s", nftsFlags.Collection, address)
+	}
+
+	return &nftsResult{address: address, collections: collections}, nil
+}
+
+// nftCollection is a NonFungibleToken collection stored in the account.
+type nftCollection struct {
+	Name string        `json:"name"`
+	Type string        `json:"type"`
+	Path string        `json:"path"`
+	NFTs []nftMetadata `json:"nfts"`
+}
+
+// nftMetadata is the NFT ID with the display metadata and the views the NFT resolves.
+type nftMetadata struct {
+	ID          uint64   `json:"id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Thumbnail   string   `json:"thumbnail,omitempty"`
+	Views       []string `json:"views"`
+}
+
+// decodeNFTCollections decodes the collections returned by the script sorted by the contract name,
+// with the NFTs of each collection sorted by ID.
+func decodeNFTCollections(value cadence.Value) ([]nftCollection, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("unexpected NFT collections %s", value)
+	}
+
+	collections := make([]nftCollection, 0, len(array.Values))
+	for _, v := range array.Values {
+		fields, err := structFields(v)
+		if err != nil {
+			return nil, err
+		}
+
+		typ, _ := fields["type"].(cadence.String)
+		collection := nftCollection{
+			Name: contractName(string(typ)),
+			Type: string(typ),
+			Path: fields["path"].String(),
+			NFTs: make([]nftMetadata, 0),
+		}
+
+		ids, _ := fields["nfts"].(cadence.Array)
+		for _, id := range ids.Values {
+			nft, err := decodeNFTMetadata(id)
+			if err != nil {
+				return nil, err
+			}
+			collection.NFTs = append(collection.NFTs, nft)
+		}
+		sort.Slice(collection.NFTs, func(i, j int) bool {
+			return collection.NFTs[i].ID < collection.NFTs[j].ID
+		})
+
+		collections = append(collections, collection)
+	}
+
+	sort.SliceStable(collections, func(i, j int) bool {
+		return collections[i].Name < collections[j].Name
+	})
+
+	return collections, nil
+}
+
+func decodeNFTMetadata(value cadence.Value) (nftMetadata, error) {
+	fields, err := structFields(value)
+	if err != nil {
+		return nftMetadata{}, err
+	}
+
+	id, _ := fields["id"].(cadence.UInt64)
+	nft := nftMetadata{
+		ID:          uint64(id),
+		Name:        optionalString(fields["name"]),
+		Description: optionalString(fields["description"]),
+		Thumbnail:   optionalString(fields["thumbnail"]),
+		Views:       make([]string, 0),
+	}
+
+	views, _ := fields["views"].(cadence.Array)
+	for _, view := range views.Values {
+		if s, ok := view.(cadence.String); ok {
+			nft.Views = append(nft.Views, string(s))
+		}
+	}
+
+	return nft, nil
+}
+
+// optionalString returns the string of the optional value, or an empty string if it's nil.
+func optionalString(value cadence.Value) string {
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+	if s, ok := value.(cadence.String); ok {
+		return string(s)
+	}
+	return ""
+}
+
+type nftsResult struct {
+	address     flowsdk.Address
+	collections []nftCollection
+}
+
+func (r *nftsResult) JSON() any {
+	return r.collections
+}
+
+func (r *nftsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address\t 0x%s\n", r.address)
+	if len(r.collections) == 0 {
+		_, _ = fmt.Fprintf(writer, "\nNo NFT collections found\n")
+	}
+
+	for _, c := range r.collections {
+		_, _ = fmt.Fprintf(writer, "\nCollection\t %s\n", c.Name)
+		_, _ = fmt.Fprintf(writer, "Type\t %s\n", c.Type)
+		_, _ = fmt.Fprintf(writer, "Path\t %s\n", c.Path)
+		_, _ = fmt.Fprintf(writer, "NFTs\t %d\n", len(c.NFTs))
+		if len(c.NFTs) == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(writer, "\nID\tName\tThumbnail\n")
+		for _, nft := range c.NFTs {
+			_, _ = fmt.Fprintf(writer, "%d\t%s\t%s\n", nft.ID, nft.Name, nft.Thumbnail)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *nftsResult) Oneliner() string {
+	collections := make([]string, 0, len(r.collections))
+	for _, c := range r.collections {
+		collections = append(collections, fmt.Sprintf("%s: %d", c.Name, len(c.NFTs)))
+	}
+
+	return fmt.Sprintf("Address: 0x%s, NFTs: %s", r.address, strings.Join(collections, ", "))
+}
@@ -0,0 +1,195 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsProve struct {
+	Signer        string `default:"emulator-account" flag:"signer" info:"Account name from configuration whose key signs the proof"`
+	Address       string `default:"" flag:"address" info:"Address or account name of the proven account, the signer address if not provided"`
+	Nonce         string `default:"" flag:"nonce" info:"Hex encoded nonce of at least 32 bytes provided by the app backend, a random nonce is generated if not provided"`
+	AppIdentifier string `default:"" flag:"app-identifier" info:"Identifier of the app requesting the proof, as configured in FCL with app.detail.title or fcl.accountProof.resolver"`
+}
+
+var proveFlags = flagsProve{}
+
+var proveCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "prove",
+		Short: "Generate an FCL account proof signed by a configured key",
+		Example: `flow accounts prove --signer alice --app-identifier "My App" --nonce 75f8587e5bd5f9dcc9909d0dae1f0ac5814458b2ae129620502cb936fde7120a
+flow accounts prove --signer alice --app-identifier "My App" --output json > proof.json`,
+		Args: cobra.NoArgs,
+	},
+	Flags:  &proveFlags,
+	Output: accountProof{},
+	RunS:   prove,
+}
+
+// accountProofDomainTag is the domain tag FCL account proofs are signed with, right padded to 32 bytes.
+var accountProofDomainTag = func() []byte {
+	tag := make([]byte, 32)
+	copy(tag, "FCL-ACCOUNT-PROOF-V0.0")
+	return tag
+}()
+
+// accountProof is the account proof data of the FCL account-proof service.
+type accountProof struct {
+	Type       string               `json:"f_type"`
+	Version    string               `json:"f_vsn"`
+	Address    string               `json:"address"`
+	Nonce      string               `json:"nonce"`
+	Signatures []compositeSignature `json:"signatures"`
+}
+
+// compositeSignature is a signature of an account key in the format used by FCL.
+type compositeSignature struct {
+	Type      string `json:"f_type"`
+	Version   string `json:"f_vsn"`
+	Address   string `json:"addr"`
+	KeyID     int    `json:"keyId"`
+	Signature string `json:"signature"`
+}
+
+func prove(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if proveFlags.AppIdentifier == "" {
+		return nil, fmt.Errorf("app identifier is required, provide it using the --app-identifier flag")
+	}
+
+	signer, err := util.AccountByName(state, proveFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	address := signer.Address
+	if proveFlags.Address != "" {
+		address, err = util.ResolveAddress(proveFlags.Address, state, flow.Network())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nonce := proveFlags.Nonce
+	if nonce == "" {
+		nonce, err = randomNonce()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	message, err := accountProofMessage(address, proveFlags.AppIdentifier, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := signer.Key.Signer(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proveResult{
+		appIdentifier: proveFlags.AppIdentifier,
+		proof: accountProof{
+			Type:    "account-proof",
+			Version: "2.0.0",
+			Address: fmt.Sprintf("0x%s", address.Hex()),
+			Nonce:   nonce,
+			Signatures: []compositeSignature{{
+				Type:      "CompositeSignature",
+				Version:   "1.0.0",
+				Address:   fmt.Sprintf("0x%s", address.Hex()),
+				KeyID:     signer.Key.Index(),
+				Signature: hex.EncodeToString(signature),
+			}},
+		},
+	}, nil
+}
+
+// accountProofMessage returns the message signed for the account proof, prefixed by the account proof domain tag.
+func accountProofMessage(address flowsdk.Address, appIdentifier string, nonce string) ([]byte, error) {
+	message, err := flowsdk.EncodeAccountProofMessage(address, appIdentifier, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, accountProofDomainTag...), message...), nil
+}
+
+func randomNonce() (string, error) {
+	nonce := make([]byte, flowsdk.AccountProofNonceMinLenBytes)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return hex.EncodeToString(nonce), nil
+}
+
+type proveResult struct {
+	proof         accountProof
+	appIdentifier string
+}
+
+func (r *proveResult) JSON() any {
+	return r.proof
+}
+
+func (r *proveResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address\t %s\n", r.proof.Address)
+	_, _ = fmt.Fprintf(writer, "App Identifier\t %s\n", r.appIdentifier)
+	_, _ = fmt.Fprintf(writer, "Nonce\t %s\n", r.proof.Nonce)
+	for _, signature := range r.proof.Signatures {
+		_, _ = fmt.Fprintf(writer, "Signature\t %s (key %d)\n", signature.Signature, signature.KeyID)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *proveResult) Oneliner() string {
+	return fmt.Sprintf("address: %s, nonce: %s, signature: %s", r.proof.Address, r.proof.Nonce, r.proof.Signatures[0].Signature)
+}
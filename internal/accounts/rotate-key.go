@@ -0,0 +1,327 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRotateKey struct {
+	SigAlgo  string `default:"" flag:"sig-algo" info:"Signature algorithm of the new key, defaults to the algorithm of the rotated key"`
+	HashAlgo string `default:"" flag:"hash-algo" info:"Hash algorithm of the new key, defaults to the algorithm of the rotated key"`
+	GasLimit uint64 `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var rotateKeyFlags = flagsRotateKey{}
+
+var rotateKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "rotate-key <account name>",
+		Short: "Replace the key of an account with a newly generated key",
+		Long: `Replace the key an account signs with by a newly generated key with the same weight.
+
+The new key is added by a transaction signed by the old key, and once the new key is active on the account
+the configuration is updated with the new key. The old key is then revoked by a transaction signed by the new key.
+Keys stored in a file are replaced in the same file, other keys are saved in the configuration as hex keys.`,
+		Example: `flow accounts rotate-key alice --network testnet`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &rotateKeyFlags,
+	RunS:  rotateKey,
+}
+
+// addKeyTransaction adds a key to the signer account.
+const addKeyTransaction = `import Crypto
+
+transaction(key: Crypto.KeyListEntry) {
+	prepare(signer: AuthAccount) {
+		signer.keys.add(publicKey: key.publicKey, hashAlgorithm: key.hashAlgorithm, weight: key.weight)
+	}
+}`
+
+// revokeKeyTransaction revokes a key of the signer account by index.
+const revokeKeyTransaction = `transaction(keyIndex: Int) {
+	prepare(signer: AuthAccount) {
+		signer.keys.revoke(keyIndex: keyIndex) ?? panic("key does not exist")
+	}
+}`
+
+func rotateKey(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	signer, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	oldIndex := signer.Key.Index()
+	if oldIndex >= len(account.Keys) {
+		return nil, fmt.Errorf("key %d doesn't exist on account 0x%s", oldIndex, account.Address)
+	}
+	oldKey := account.Keys[oldIndex]
+	if oldKey.Revoked {
+		return nil, fmt.Errorf("key %d of account 0x%s is already revoked", oldIndex, account.Address)
+	}
+	if oldKey.Weight < flowsdk.AccountKeyWeightThreshold {
+		return nil, fmt.Errorf(
+			"key %d of account 0x%s has a weight of %d, below the %d required to add and revoke keys alone",
+			oldIndex,
+			account.Address,
+			oldKey.Weight,
+			flowsdk.AccountKeyWeightThreshold,
+		)
+	}
+
+	sigAlgo := oldKey.SigAlgo
+	if rotateKeyFlags.SigAlgo != "" {
+		sigAlgo = crypto.StringToSignatureAlgorithm(rotateKeyFlags.SigAlgo)
+		if sigAlgo == crypto.UnknownSignatureAlgorithm {
+			return nil, fmt.Errorf("invalid signature algorithm: %s", rotateKeyFlags.SigAlgo)
+		}
+	}
+	hashAlgo := oldKey.HashAlgo
+	if rotateKeyFlags.HashAlgo != "" {
+		hashAlgo = crypto.StringToHashAlgorithm(rotateKeyFlags.HashAlgo)
+		if hashAlgo == crypto.UnknownHashAlgorithm {
+			return nil, fmt.Errorf("invalid hash algorithm: %s", rotateKeyFlags.HashAlgo)
+		}
+	}
+
+	privateKey, err := flow.GenerateKey(context.Background(), sigAlgo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := templates.AccountKeyToCadenceCryptoKey(&flowsdk.AccountKey{
+		PublicKey: privateKey.PublicKey(),
+		SigAlgo:   sigAlgo,
+		HashAlgo:  hashAlgo,
+		Weight:    oldKey.Weight,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := flow.BuildTransaction(
+		context.Background(),
+		transactions.AddressesRoles{
+			Proposer:    signer.Address,
+			Authorizers: []flowsdk.Address{signer.Address},
+			Payer:       signer.Address,
+		},
+		oldIndex,
+		flowkit.Script{
+			Code: []byte(addKeyTransaction),
+			Args: []cadence.Value{key},
+		},
+		rotateKeyFlags.GasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf(
+		"Key %d of account %s will be replaced by a new %s key, the key is revoked once the new key is active",
+		oldIndex,
+		args[0],
+		sigAlgo,
+	))
+
+	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction()) {
+		return nil, fmt.Errorf("transaction was not approved")
+	}
+
+	addID, err := sendRotateKeyTransaction(flow, signer, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err = flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+	newIndex := -1
+	for _, accountKey := range account.Keys {
+		if !accountKey.Revoked && accountKey.PublicKey.Equals(privateKey.PublicKey()) {
+			newIndex = accountKey.Index
+		}
+	}
+	if newIndex == -1 {
+		return nil, fmt.Errorf("the new key was not found on account 0x%s after transaction %s, key %d was not revoked", account.Address, addID, oldIndex)
+	}
+
+	// the new key is saved before revoking the old key, so the account can't be left without a key in the configuration
+	newKey := accounts.NewHexKeyFromPrivateKey(newIndex, hashAlgo, privateKey)
+	if fileKey, ok := signer.Key.(*accounts.FileKey); ok {
+		location := fileKey.ToConfig().Location
+		err = state.ReaderWriter().WriteFile(location, []byte(privateKey.String()), 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed writing the new key to %s: %w", location, err)
+		}
+		signer.Key = accounts.NewFileKey(location, newIndex, sigAlgo, hashAlgo)
+	} else {
+		signer.Key = newKey
+	}
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &rotateKeyResult{
+		account:    args[0],
+		oldIndex:   oldIndex,
+		newIndex:   newIndex,
+		publicKey:  privateKey.PublicKey(),
+		addKeyTxID: addID,
+	}
+
+	tx, err = flow.BuildTransaction(
+		context.Background(),
+		transactions.AddressesRoles{
+			Proposer:    signer.Address,
+			Authorizers: []flowsdk.Address{signer.Address},
+			Payer:       signer.Address,
+		},
+		newIndex,
+		flowkit.Script{
+			Code: []byte(revokeKeyTransaction),
+			Args: []cadence.Value{cadence.NewInt(oldIndex)},
+		},
+		rotateKeyFlags.GasLimit,
+	)
+	if err == nil {
+		result.revokeKeyTxID, err = sendRotateKeyTransaction(
+			flow,
+			&accounts.Account{Name: signer.Name, Address: signer.Address, Key: newKey},
+			tx,
+		)
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf(
+			"The new key %d was saved but revoking key %d failed, revoke it with a transaction signed by the new key: %s",
+			newIndex,
+			oldIndex,
+			err,
+		))
+	}
+
+	return result, nil
+}
+
+// sendRotateKeyTransaction signs the transaction with the signer and sends it, returning an error if the transaction failed.
+func sendRotateKeyTransaction(
+	flow flowkit.Services,
+	signer *accounts.Account,
+	tx *transactions.Transaction,
+) (flowsdk.Identifier, error) {
+	signed, err := flow.SignTransactionPayload(
+		context.Background(),
+		signer,
+		[]byte(hex.EncodeToString(tx.FlowTransaction().Encode())),
+	)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+
+	sentTx, txResult, err := flow.SendSignedTransaction(context.Background(), signed)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+	if txResult.Error != nil {
+		return flowsdk.EmptyID, fmt.Errorf("transaction %s failed: %w", sentTx.ID(), txResult.Error)
+	}
+
+	return sentTx.ID(), nil
+}
+
+type rotateKeyResult struct {
+	account       string
+	oldIndex      int
+	newIndex      int
+	publicKey     crypto.PublicKey
+	addKeyTxID    flowsdk.Identifier
+	revokeKeyTxID flowsdk.Identifier
+}
+
+func (r *rotateKeyResult) JSON() any {
+	result := map[string]any{
+		"account":          r.account,
+		"revokedIndex":     r.oldIndex,
+		"index":            r.newIndex,
+		"publicKey":        hex.EncodeToString(r.publicKey.Encode()),
+		"addTransactionId": r.addKeyTxID.String(),
+		"revoked":          r.revokeKeyTxID != flowsdk.EmptyID,
+	}
+	if r.revokeKeyTxID != flowsdk.EmptyID {
+		result["revokeTransactionId"] = r.revokeKeyTxID.String()
+	}
+
+	return result
+}
+
+func (r *rotateKeyResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if r.revokeKeyTxID != flowsdk.EmptyID {
+		_, _ = fmt.Fprintf(writer, "%s Key of account %s rotated\n\n", output.SuccessEmoji(), r.account)
+	} else {
+		_, _ = fmt.Fprintf(writer, "%s New key of account %s added but the old key was not revoked\n\n", output.WarningEmoji(), r.account)
+	}
+	_, _ = fmt.Fprintf(writer, "New Key\t%d\n", r.newIndex)
+	_, _ = fmt.Fprintf(writer, "Public Key\t%x\n", r.publicKey.Encode())
+	_, _ = fmt.Fprintf(writer, "Add Transaction ID\t%s\n", r.addKeyTxID)
+	if r.revokeKeyTxID != flowsdk.EmptyID {
+		_, _ = fmt.Fprintf(writer, "Revoked Key\t%d\n", r.oldIndex)
+		_, _ = fmt.Fprintf(writer, "Revoke Transaction ID\t%s\n", r.revokeKeyTxID)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *rotateKeyResult) Oneliner() string {
+	return fmt.Sprintf("%d", r.newIndex)
+}
@@ -0,0 +1,325 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSetWeights struct {
+	Keys     []string `default:"" flag:"key" info:"New weight of a key as index=weight, can be provided multiple times"`
+	GasLimit uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var setWeightsFlags = flagsSetWeights{}
+
+var setWeightsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "set-weights <account name>",
+		Short: "Change the weights of account keys",
+		Long: `Change the weights of account keys by adding each key again with the new weight and revoking the key,
+since the weight of an account key can't be changed. Keys are added and revoked by a single transaction signed by
+the account, so the account is never left with only some of the keys changed.
+
+The keys not revoked must keep a total weight of at least 1000, otherwise the account couldn't sign transactions anymore.`,
+		Example: `flow accounts set-weights alice --key 0=500 --key 1=500 --network testnet`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &setWeightsFlags,
+	RunS:  setWeights,
+}
+
+// setWeightsTransaction adds the keys and revokes the keys by index.
+const setWeightsTransaction = `import Crypto
+
+transaction(keys: [Crypto.KeyListEntry], revokedKeys: [Int]) {
+	prepare(signer: AuthAccount) {
+		for key in keys {
+			signer.keys.add(publicKey: key.publicKey, hashAlgorithm: key.hashAlgorithm, weight: key.weight)
+		}
+		for keyIndex in revokedKeys {
+			signer.keys.revoke(keyIndex: keyIndex)
+		}
+	}
+}`
+
+func setWeights(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	weights, err := parseKeyWeights(setWeightsFlags.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := planKeyWeights(account, weights)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		if change.revokedIndex == signer.Key.Index() {
+			logger.Info(fmt.Sprintf(
+				"%s Account %s signs with key %d which will be revoked, set the key index of the account to %d in the configuration afterwards",
+				output.WarningEmoji(),
+				args[0],
+				change.revokedIndex,
+				change.index,
+			))
+		}
+	}
+
+	keys := make([]cadence.Value, 0, len(changes))
+	revoked := make([]cadence.Value, 0, len(changes))
+	for _, change := range changes {
+		accountKey := account.Keys[change.revokedIndex]
+		key, err := templates.AccountKeyToCadenceCryptoKey(&flowsdk.AccountKey{
+			PublicKey: accountKey.PublicKey,
+			SigAlgo:   accountKey.SigAlgo,
+			HashAlgo:  accountKey.HashAlgo,
+			Weight:    change.weight,
+		})
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		revoked = append(revoked, cadence.NewInt(change.revokedIndex))
+	}
+
+	tx, err := flow.BuildTransaction(
+		context.Background(),
+		transactions.AddressesRoles{
+			Proposer:    signer.Address,
+			Authorizers: []flowsdk.Address{signer.Address},
+			Payer:       signer.Address,
+		},
+		signer.Key.Index(),
+		flowkit.Script{
+			Code: []byte(setWeightsTransaction),
+			Args: []cadence.Value{cadence.NewArray(keys), cadence.NewArray(revoked)},
+		},
+		setWeightsFlags.GasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		logger.Info(fmt.Sprintf(
+			"Key %d will be revoked and added again as key %d with weight %d",
+			change.revokedIndex,
+			change.index,
+			change.weight,
+		))
+	}
+
+	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction()) {
+		return nil, fmt.Errorf("transaction was not approved")
+	}
+
+	signed, err := flow.SignTransactionPayload(
+		context.Background(),
+		signer,
+		[]byte(hex.EncodeToString(tx.FlowTransaction().Encode())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, txResult, err := flow.SendSignedTransaction(context.Background(), signed)
+	if err != nil {
+		return nil, err
+	}
+	if txResult.Error != nil {
+		return nil, fmt.Errorf("transaction %s failed: %w", sentTx.ID(), txResult.Error)
+	}
+
+	return &setWeightsResult{
+		account:       args[0],
+		changes:       changes,
+		transactionID: sentTx.ID(),
+	}, nil
+}
+
+// parseKeyWeights parses the key flags in the format index=weight and returns the weights by key index.
+func parseKeyWeights(keysFlag []string) (map[int]int, error) {
+	if len(keysFlag) == 0 {
+		return nil, fmt.Errorf("provide the new weight of at least one key using the --key flag as index=weight")
+	}
+
+	weights := make(map[int]int, len(keysFlag))
+	for _, flag := range keysFlag {
+		index, weight, ok := strings.Cut(flag, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid key %s, expected the format index=weight", flag)
+		}
+
+		parsedIndex, err := strconv.Atoi(index)
+		if err != nil || parsedIndex < 0 {
+			return nil, fmt.Errorf("invalid key index %s", index)
+		}
+		parsedWeight, err := strconv.Atoi(weight)
+		if err != nil || parsedWeight < 0 || parsedWeight > flowsdk.AccountKeyWeightThreshold {
+			return nil, fmt.Errorf("invalid key weight %s, must be between 0 and %d", weight, flowsdk.AccountKeyWeightThreshold)
+		}
+
+		if _, exists := weights[parsedIndex]; exists {
+			return nil, fmt.Errorf("key %d provided multiple times", parsedIndex)
+		}
+		weights[parsedIndex] = parsedWeight
+	}
+
+	return weights, nil
+}
+
+// keyWeightChange is a key revoked and added again with a new weight at the new index.
+type keyWeightChange struct {
+	revokedIndex int
+	index        int
+	weight       int
+}
+
+// planKeyWeights returns the changes setting the weights of the account keys, sorted by the revoked key index.
+//
+// Keys that already have the weight aren't changed. An error is returned if a key doesn't exist or is revoked,
+// or if the keys not revoked would have a total weight below the threshold.
+func planKeyWeights(account *flowsdk.Account, weights map[int]int) ([]keyWeightChange, error) {
+	indexes := make([]int, 0, len(weights))
+	for index := range weights {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	changes := make([]keyWeightChange, 0, len(indexes))
+	for _, index := range indexes {
+		if index >= len(account.Keys) {
+			return nil, fmt.Errorf("key %d doesn't exist on account 0x%s", index, account.Address)
+		}
+		key := account.Keys[index]
+		if key.Revoked {
+			return nil, fmt.Errorf("key %d of account 0x%s is revoked", index, account.Address)
+		}
+		if key.Weight == weights[index] {
+			continue
+		}
+
+		changes = append(changes, keyWeightChange{
+			revokedIndex: index,
+			index:        len(account.Keys) + len(changes),
+			weight:       weights[index],
+		})
+	}
+
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("the keys already have the weights")
+	}
+
+	total := 0
+	for _, key := range account.Keys {
+		if key.Revoked {
+			continue
+		}
+		if weight, ok := weights[key.Index]; ok {
+			total += weight
+		} else {
+			total += key.Weight
+		}
+	}
+	if total < flowsdk.AccountKeyWeightThreshold {
+		return nil, fmt.Errorf(
+			"the keys would have a total weight of %d, below the %d required to sign transactions for the account",
+			total,
+			flowsdk.AccountKeyWeightThreshold,
+		)
+	}
+
+	return changes, nil
+}
+
+type setWeightsResult struct {
+	account       string
+	changes       []keyWeightChange
+	transactionID flowsdk.Identifier
+}
+
+func (r *setWeightsResult) JSON() any {
+	keys := make([]map[string]any, 0, len(r.changes))
+	for _, change := range r.changes {
+		keys = append(keys, map[string]any{
+			"revokedIndex": change.revokedIndex,
+			"index":        change.index,
+			"weight":       change.weight,
+		})
+	}
+
+	return map[string]any{
+		"account":       r.account,
+		"keys":          keys,
+		"transactionId": r.transactionID.String(),
+	}
+}
+
+func (r *setWeightsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Key weights of account %s changed\n\n", output.SuccessEmoji(), r.account)
+	_, _ = fmt.Fprintf(writer, "Revoked Key\tNew Key\tWeight\n")
+	for _, change := range r.changes {
+		_, _ = fmt.Fprintf(writer, "%d\t%d\t%d\n", change.revokedIndex, change.index, change.weight)
+	}
+	_, _ = fmt.Fprintf(writer, "\nTransaction ID\t%s\n", r.transactionID)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *setWeightsResult) Oneliner() string {
+	return r.transactionID.String()
+}
@@ -0,0 +1,342 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/templates"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSetup struct {
+	Contract    string `default:"" flag:"contract" info:"Name of the fungible or non-fungible token contract to set up the account for"`
+	Address     string `default:"" flag:"address" info:"Address of the contract, resolved from the configuration or the standard contracts if not provided"`
+	Type        string `default:"" flag:"type" info:"Type of the contract, ft or nft, detected from the contract if not provided"`
+	Signer      string `default:"emulator-account" flag:"signer" info:"Account name from configuration to set up"`
+	StoragePath string `default:"" flag:"storage-path" info:"Storage path of the vault or collection, resolved from the contract name if not provided"`
+	PublicPath  string `default:"" flag:"public-path" info:"Public path of the receiver or collection capability, resolved from the contract name if not provided"`
+	GasLimit    uint64 `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var setupFlags = flagsSetup{}
+
+var setupCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "setup",
+		Short: "Set up an account to hold a fungible or non-fungible token",
+		Long: `Set up an account to hold a fungible or non-fungible token by saving an empty vault or collection
+and linking its public capabilities. Accounts already set up are left unchanged.`,
+		Example: `flow accounts setup --contract FUSD --signer alice --network testnet
+flow accounts setup --contract ExampleNFT --signer alice
+flow accounts setup --contract MyToken --address 0x01cf0e2f2f715450 --type ft --signer alice`,
+		Args: cobra.NoArgs,
+	},
+	Flags: &setupFlags,
+	RunS:  setup,
+}
+
+const (
+	setupTypeFungible    = "ft"
+	setupTypeNonFungible = "nft"
+)
+
+// standardTokenContracts are the addresses of the token contracts set up without configuring them in the project.
+func standardTokenContracts(chain flowsdk.ChainID) map[string]string {
+	contracts := map[string]string{
+		"FlowToken": util.EnvFromNetwork(chain).FlowTokenAddress,
+	}
+	if address, ok := fusdAddresses[chain]; ok {
+		contracts["FUSD"] = address
+	}
+
+	return contracts
+}
+
+// standardVaultPaths are the paths of fungible tokens not following the vault naming convention.
+var standardVaultPaths = map[string][3]string{
+	"FlowToken": {"flowTokenVault", "flowTokenReceiver", "flowTokenBalance"},
+	"FUSD":      {"fusdVault", "fusdReceiver", "fusdBalance"},
+}
+
+func setup(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if setupFlags.Contract == "" {
+		return nil, fmt.Errorf("missing required flag --contract")
+	}
+	name := setupFlags.Contract
+
+	signer, err := util.AccountByName(state, setupFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := resolveTokenContract(name, setupFlags.Address, flow, state)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenType := strings.ToLower(setupFlags.Type)
+	if tokenType == "" {
+		tokenType, err = detectTokenType(name, address, flow)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if tokenType != setupTypeFungible && tokenType != setupTypeNonFungible {
+		return nil, fmt.Errorf("invalid type %s, valid types are: ft, nft", setupFlags.Type)
+	}
+
+	paths, err := setupPaths(name, tokenType, setupFlags.StoragePath, setupFlags.PublicPath)
+	if err != nil {
+		return nil, err
+	}
+
+	templateName := "setup-vault"
+	if tokenType == setupTypeNonFungible {
+		templateName = "setup-collection"
+	}
+	template, err := templates.ByName(templateName, templates.KindTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []cadence.Value{cadence.NewAddress(address), cadence.String(name)}
+	for _, path := range paths {
+		args = append(args, path)
+	}
+
+	tx, err := flow.BuildTransaction(
+		context.Background(),
+		transactions.AddressesRoles{
+			Proposer:    signer.Address,
+			Authorizers: []flowsdk.Address{signer.Address},
+			Payer:       signer.Address,
+		},
+		signer.Key.Index(),
+		flowkit.Script{
+			Code: template.Source(util.NetworkChain(flow.Network())),
+			Args: args,
+		},
+		setupFlags.GasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction()) {
+		return nil, fmt.Errorf("transaction was not approved")
+	}
+
+	signed, err := flow.SignTransactionPayload(
+		context.Background(),
+		signer,
+		[]byte(hex.EncodeToString(tx.FlowTransaction().Encode())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Setting up account %s for %s...", signer.Name, name))
+	defer logger.StopProgress()
+
+	sentTx, txResult, err := flow.SendSignedTransaction(context.Background(), signed)
+	if err != nil {
+		return nil, err
+	}
+	if txResult.Error != nil {
+		return nil, fmt.Errorf("transaction %s failed: %w", sentTx.ID(), txResult.Error)
+	}
+
+	return &setupResult{
+		account:       signer.Name,
+		address:       signer.Address,
+		contract:      fmt.Sprintf("A.%s.%s", address.Hex(), name),
+		tokenType:     tokenType,
+		paths:         paths,
+		transactionID: sentTx.ID(),
+	}, nil
+}
+
+// resolveTokenContract returns the address of the contract from the flag, the project configuration or the standard contracts.
+func resolveTokenContract(name string, address string, flow flowkit.Services, state *flowkit.State) (flowsdk.Address, error) {
+	if address != "" {
+		return util.ResolveAddress(address, state, flow.Network())
+	}
+
+	if contract, err := state.Contracts().ByName(name); err == nil {
+		if alias := contract.Aliases.ByNetwork(flow.Network().Name); alias != nil {
+			return alias.Address, nil
+		}
+	}
+	for _, deployment := range state.Deployments().ByNetwork(flow.Network().Name) {
+		for _, contract := range deployment.Contracts {
+			if contract.Name != name {
+				continue
+			}
+			account, err := state.Accounts().ByName(deployment.Account)
+			if err != nil {
+				return flowsdk.EmptyAddress, err
+			}
+			return account.Address, nil
+		}
+	}
+
+	if standard, ok := standardTokenContracts(util.NetworkChain(flow.Network()))[name]; ok {
+		return flowsdk.HexToAddress(standard), nil
+	}
+
+	return flowsdk.EmptyAddress, fmt.Errorf(
+		"contract %s is not aliased or deployed on network %s in the configuration, provide its address with the --address flag",
+		name,
+		flow.Network().Name,
+	)
+}
+
+// detectTokenType returns whether the deployed contract is a fungible or non-fungible token from the interfaces it conforms to.
+func detectTokenType(name string, address flowsdk.Address, flow flowkit.Services) (string, error) {
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return "", err
+	}
+
+	code, ok := account.Contracts[name]
+	if !ok {
+		return "", fmt.Errorf("contract %s is not deployed to account 0x%s", name, address)
+	}
+
+	declaration := regexp.MustCompile(fmt.Sprintf(`contract\s+%s\s*:([^{]+)\{`, regexp.QuoteMeta(name)))
+	found := declaration.FindSubmatch(code)
+	if found != nil {
+		for _, conformance := range strings.Split(string(found[1]), ",") {
+			switch strings.TrimSpace(conformance) {
+			case "NonFungibleToken":
+				return setupTypeNonFungible, nil
+			case "FungibleToken":
+				return setupTypeFungible, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("contract %s doesn't conform to FungibleToken or NonFungibleToken, provide the type with the --type flag", name)
+}
+
+// setupPaths returns the storage path and the public paths of the vault or collection, following the convention of
+// naming them after the contract, e.g. /storage/exampleTokenVault for ExampleToken, unless provided.
+func setupPaths(name string, tokenType string, storage string, public string) ([]cadence.Path, error) {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	prefix := string(runes)
+
+	identifiers := []string{prefix + "Collection", prefix + "Collection"}
+	if tokenType == setupTypeFungible {
+		standard, ok := standardVaultPaths[name]
+		if !ok {
+			standard = [3]string{prefix + "Vault", prefix + "Receiver", prefix + "Balance"}
+		}
+		identifiers = standard[:]
+	}
+
+	if storage != "" {
+		identifiers[0] = storage
+	}
+	if public != "" {
+		identifiers[1] = public
+	}
+
+	paths := make([]cadence.Path, 0, len(identifiers))
+	for i, identifier := range identifiers {
+		domain := common.PathDomainPublic
+		if i == 0 {
+			domain = common.PathDomainStorage
+		}
+
+		identifier = strings.TrimPrefix(identifier, fmt.Sprintf("/%s/", domain.Identifier()))
+		if identifier == "" || strings.Contains(identifier, "/") {
+			return nil, fmt.Errorf("invalid %s path %s", domain.Identifier(), identifiers[i])
+		}
+		paths = append(paths, cadence.Path{Domain: domain, Identifier: identifier})
+	}
+
+	return paths, nil
+}
+
+type setupResult struct {
+	account       string
+	address       flowsdk.Address
+	contract      string
+	tokenType     string
+	paths         []cadence.Path
+	transactionID flowsdk.Identifier
+}
+
+func (r *setupResult) JSON() any {
+	paths := make([]string, 0, len(r.paths))
+	for _, path := range r.paths {
+		paths = append(paths, path.String())
+	}
+
+	return map[string]any{
+		"account":       r.account,
+		"address":       fmt.Sprintf("0x%s", r.address),
+		"contract":      r.contract,
+		"type":          r.tokenType,
+		"paths":         paths,
+		"transactionId": r.transactionID.String(),
+	}
+}
+
+func (r *setupResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Account\t%s (0x%s)\n", r.account, r.address)
+	_, _ = fmt.Fprintf(writer, "Contract\t%s\n", r.contract)
+	for _, path := range r.paths {
+		_, _ = fmt.Fprintf(writer, "Path\t%s\n", path)
+	}
+	_, _ = fmt.Fprintf(writer, "Transaction ID\t%s\n", r.transactionID)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *setupResult) Oneliner() string {
+	return fmt.Sprintf("account %s set up for %s", r.account, r.contract)
+}
@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence"
 	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
@@ -40,10 +41,11 @@ var stakingFlags = flagsStakingInfo{}
 
 var stakingCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "staking-info <address>",
-		Short:   "Get account staking info",
-		Example: "flow accounts staking-info f8d6e0586b0a20c7",
-		Args:    cobra.ExactArgs(1),
+		Use:   "staking-info <address | account name>",
+		Short: "Get account staking and delegation info",
+		Example: `flow accounts staking-info 0x8624b52f9ddcd04a --network mainnet
+flow accounts staking-info operator --network testnet --output json`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &stakingFlags,
 	Run:   stakingInfo,
@@ -51,12 +53,20 @@ var stakingCommand = &command.Command{
 
 func stakingInfo(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
-	_ flowkit.ReaderWriter,
+	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
-	address := flowsdk.HexToAddress(args[0])
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
 
 	logger.StartProgress(fmt.Sprintf("Fetching info for %s...", address.String()))
 	defer logger.StopProgress()
@@ -72,7 +82,7 @@ func stakingInfo(
 		return nil, fmt.Errorf("emulator chain not supported")
 	}
 
-	env := envFromNetwork(chain)
+	env := util.EnvFromNetwork(chain)
 
 	stakingInfoScript := tmpl.GenerateCollectionGetAllNodeInfoScript(env)
 	delegationInfoScript := tmpl.GenerateCollectionGetAllDelegatorInfoScript(env)
@@ -142,31 +152,14 @@ func stakingInfo(
 
 	logger.StopProgress()
 
-	return &stakingResult{staking, delegation}, nil
-}
-
-func envFromNetwork(network flowsdk.ChainID) tmpl.Environment {
-	if network == flowsdk.Mainnet {
-		return tmpl.Environment{
-			IDTableAddress:       "8624b52f9ddcd04a",
-			FungibleTokenAddress: "f233dcee88fe0abe",
-			FlowTokenAddress:     "1654653399040a61",
-			LockedTokensAddress:  "8d0e87b65159ae63",
-			StakingProxyAddress:  "62430cf28c26d095",
-		}
-	}
-
-	if network == flowsdk.Testnet {
-		return tmpl.Environment{
-			IDTableAddress:       "9eca2b38b18b5dfe",
-			FungibleTokenAddress: "9a0766d93b6608b7",
-			FlowTokenAddress:     "7e60df042a9c0868",
-			LockedTokensAddress:  "95e019a17d0e23d7",
-			StakingProxyAddress:  "7aad92e5a0715d21",
-		}
-	}
-
-	return tmpl.Environment{}
+	return &stakingResult{
+		staking:    staking,
+		delegation: delegation,
+		totals: []stakingTotals{
+			newStakingTotals("Nodes", staking),
+			newStakingTotals("Delegations", delegation),
+		},
+	}, nil
 }
 
 func nodeIDToString(value any) string {
@@ -204,15 +197,103 @@ func newStakingInfoFromValue(value cadence.Value) ([]map[string]any, error) {
 	return stakingInfo, nil
 }
 
+// stakingTotals sums the token amounts of all the nodes or delegations of the account.
+type stakingTotals struct {
+	name               string
+	count              int
+	committed          cadence.UFix64
+	staked             cadence.UFix64
+	requestedToUnstake cadence.UFix64
+	unstaking          cadence.UFix64
+	unstaked           cadence.UFix64
+	rewarded           cadence.UFix64
+}
+
+func newStakingTotals(name string, infos []map[string]any) stakingTotals {
+	totals := stakingTotals{name: name, count: len(infos)}
+
+	amount := func(info map[string]any, field string) cadence.UFix64 {
+		value, _ := info[field].(cadence.UFix64)
+		return value
+	}
+	for _, info := range infos {
+		totals.committed += amount(info, "tokensCommitted")
+		totals.staked += amount(info, "tokensStaked")
+		totals.requestedToUnstake += amount(info, "tokensRequestedToUnstake")
+		totals.unstaking += amount(info, "tokensUnstaking")
+		totals.unstaked += amount(info, "tokensUnstaked")
+		totals.rewarded += amount(info, "tokensRewarded")
+	}
+
+	return totals
+}
+
+func (t stakingTotals) JSON() map[string]any {
+	return map[string]any{
+		"count":                    t.count,
+		"tokensCommitted":          t.committed.String(),
+		"tokensStaked":             t.staked.String(),
+		"tokensRequestedToUnstake": t.requestedToUnstake.String(),
+		"tokensUnstaking":          t.unstaking.String(),
+		"tokensUnstaked":           t.unstaked.String(),
+		"tokensRewarded":           t.rewarded.String(),
+	}
+}
+
+// stakingInfoJSON converts the Cadence values of the staking infos to plain JSON values.
+func stakingInfoJSON(infos []map[string]any) []map[string]any {
+	result := make([]map[string]any, 0, len(infos))
+	for _, info := range infos {
+		values := make(map[string]any, len(info))
+		for field, value := range info {
+			values[field] = stakingValueJSON(value)
+		}
+		result = append(result, values)
+	}
+
+	return result
+}
+
+func stakingValueJSON(value any) any {
+	switch v := value.(type) {
+	case cadence.Optional:
+		if v.Value == nil {
+			return nil
+		}
+		return stakingValueJSON(v.Value)
+	case cadence.String:
+		return string(v)
+	case cadence.UFix64:
+		return v.String()
+	case cadence.Array:
+		values := make([]any, 0, len(v.Values))
+		for _, element := range v.Values {
+			values = append(values, stakingValueJSON(element))
+		}
+		return values
+	case cadence.Value:
+		return v.ToGoValue()
+	}
+
+	return value
+}
+
 type stakingResult struct {
 	staking    []map[string]any // stake as FlowIDTableStaking.NodeInfo
 	delegation []map[string]any // delegation as FlowIDTableStaking.DelegatorInfo
+	totals     []stakingTotals
 }
 
 func (r *stakingResult) JSON() any {
 	result := make(map[string]any)
-	result["staking"] = r.staking
-	result["delegation"] = r.delegation
+	result["staking"] = stakingInfoJSON(r.staking)
+	result["delegation"] = stakingInfoJSON(r.delegation)
+
+	totals := make(map[string]any)
+	for _, t := range r.totals {
+		totals[strings.ToLower(t.name)] = t.JSON()
+	}
+	result["totals"] = totals
 
 	return result
 }
@@ -262,6 +343,22 @@ func (r *stakingResult) String() string {
 		_, _ = fmt.Fprintf(writer, "Account has no delegations.\n")
 	}
 
+	_, _ = fmt.Fprintf(writer, "\nTotals\tCount\tCommitted\tStaked\tTo Unstake\tUnstaking\tUnstaked\tRewarded\n")
+	for _, t := range r.totals {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			t.name,
+			t.count,
+			t.committed,
+			t.staked,
+			t.requestedToUnstake,
+			t.unstaking,
+			t.unstaked,
+			t.rewarded,
+		)
+	}
+
 	writer.Flush()
 	return b.String()
 }
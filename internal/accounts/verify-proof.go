@@ -0,0 +1,272 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsVerifyProof struct {
+	AppIdentifier string `default:"" flag:"app-identifier" info:"Identifier of the app the proof was requested for"`
+	Nonce         string `default:"" flag:"nonce" info:"Hex encoded nonce the proof was requested with"`
+}
+
+var verifyProofFlags = flagsVerifyProof{}
+
+var verifyProofCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "verify-proof <proof file | proof JSON>",
+		Short: "Verify an FCL account proof against the account keys",
+		Example: `flow accounts verify-proof proof.json --app-identifier "My App" --nonce 75f8...120a
+flow accounts verify-proof '{"address":"0xf8d6e0586b0a20c7","nonce":"75f8...120a","signatures":[...]}' --app-identifier "My App" --nonce 75f8...120a`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags:  &verifyProofFlags,
+	Output: verifyProofOutput{},
+	Run:    verifyProof,
+}
+
+// verifyProof verifies the account proof like fcl.AppUtils.verifyAccountProof, the proof is valid if the signatures
+// of distinct, not revoked account keys are valid and their weights add up to the full weight. An error is returned
+// if the proof is not valid.
+func verifyProof(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if verifyProofFlags.AppIdentifier == "" {
+		return nil, fmt.Errorf("app identifier is required, provide it using the --app-identifier flag")
+	}
+	if verifyProofFlags.Nonce == "" {
+		return nil, fmt.Errorf("nonce is required, provide the nonce the proof was requested with using the --nonce flag")
+	}
+
+	proof, err := parseAccountProof(args[0], readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(trimHex(verifyProofFlags.Nonce), trimHex(proof.Nonce)) {
+		return nil, fmt.Errorf("proof nonce %s doesn't match the expected nonce %s", proof.Nonce, verifyProofFlags.Nonce)
+	}
+
+	address := flowsdk.HexToAddress(proof.Address)
+	message, err := accountProofMessage(address, verifyProofFlags.AppIdentifier, proof.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading account %s...", address))
+	defer logger.StopProgress()
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &verifyProofResult{address: address}
+	counted := make(map[int]bool)
+	for _, signature := range proof.Signatures {
+		key := verifiedProofKey{Index: signature.KeyID}
+		if signature.KeyID < 0 || signature.KeyID >= len(account.Keys) {
+			key.Error = fmt.Sprintf("account has no key with index %d", signature.KeyID)
+			result.keys = append(result.keys, key)
+			continue
+		}
+
+		accountKey := account.Keys[signature.KeyID]
+		key.Weight = accountKey.Weight
+		key.Revoked = accountKey.Revoked
+
+		valid, err := verifyProofSignature(accountKey, signature.Signature, message)
+		if err != nil {
+			key.Error = err.Error()
+		}
+		key.Valid = valid && !accountKey.Revoked
+
+		if key.Valid && !counted[signature.KeyID] {
+			counted[signature.KeyID] = true
+			result.weight += accountKey.Weight
+		}
+		result.keys = append(result.keys, key)
+	}
+
+	if !result.valid() {
+		return nil, result.invalidError()
+	}
+
+	return result, nil
+}
+
+// parseAccountProof reads the account proof from a JSON file or the provided JSON, which is either the
+// account proof data or the FCL account-proof service containing it.
+func parseAccountProof(proof string, readerWriter flowkit.ReaderWriter) (*accountProof, error) {
+	data := []byte(proof)
+	if !strings.HasPrefix(strings.TrimSpace(proof), "{") {
+		var err error
+		data, err = readerWriter.ReadFile(proof)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the account proof: %w", err)
+		}
+	}
+
+	var parsed struct {
+		accountProof
+		Data *accountProof `json:"data"`
+	}
+	err := json.Unmarshal(data, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account proof: %w", err)
+	}
+
+	result := &parsed.accountProof
+	if parsed.Data != nil {
+		result = parsed.Data
+	}
+	if result.Address == "" || len(result.Signatures) == 0 {
+		return nil, fmt.Errorf("invalid account proof: the address and signatures are required")
+	}
+
+	return result, nil
+}
+
+func verifyProofSignature(key *flowsdk.AccountKey, signature string, message []byte) (bool, error) {
+	sig, err := hex.DecodeString(trimHex(signature))
+	if err != nil {
+		return false, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	hasher, err := crypto.NewHasher(key.HashAlgo)
+	if err != nil {
+		return false, err
+	}
+
+	return key.PublicKey.Verify(sig, message, hasher)
+}
+
+func trimHex(value string) string {
+	return strings.TrimPrefix(value, "0x")
+}
+
+// verifiedProofKey is the verification of the signature of an account key.
+type verifiedProofKey struct {
+	Index   int    `json:"index"`
+	Weight  int    `json:"weight"`
+	Revoked bool   `json:"revoked"`
+	Valid   bool   `json:"valid"`
+	Error   string `json:"error,omitempty"`
+}
+
+// verifyProofOutput is the JSON output of the account proof verification.
+type verifyProofOutput struct {
+	Valid   bool               `json:"valid"`
+	Address string             `json:"address"`
+	Weight  int                `json:"weight"`
+	Keys    []verifiedProofKey `json:"keys"`
+}
+
+type verifyProofResult struct {
+	address flowsdk.Address
+	weight  int
+	keys    []verifiedProofKey
+}
+
+func (r *verifyProofResult) valid() bool {
+	return r.weight >= flowsdk.AccountKeyWeightThreshold
+}
+
+// invalidError describes why the proof is not valid with the weight of the valid signatures and the invalid keys.
+func (r *verifyProofResult) invalidError() error {
+	var invalid []string
+	for _, key := range r.keys {
+		if key.Valid {
+			continue
+		}
+
+		reason := "invalid signature"
+		if key.Error != "" {
+			reason = key.Error
+		} else if key.Revoked {
+			reason = "key is revoked"
+		}
+		invalid = append(invalid, fmt.Sprintf("key %d: %s", key.Index, reason))
+	}
+
+	var details string
+	if len(invalid) > 0 {
+		details = fmt.Sprintf(" (%s)", strings.Join(invalid, ", "))
+	}
+
+	return fmt.Errorf(
+		"account proof is not valid, the valid signatures have a weight of %d of the required %d%s",
+		r.weight,
+		flowsdk.AccountKeyWeightThreshold,
+		details,
+	)
+}
+
+func (r *verifyProofResult) JSON() any {
+	return verifyProofOutput{
+		Valid:   r.valid(),
+		Address: fmt.Sprintf("0x%s", r.address.Hex()),
+		Weight:  r.weight,
+		Keys:    r.keys,
+	}
+}
+
+func (r *verifyProofResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Valid\t %v\n", r.valid())
+	_, _ = fmt.Fprintf(writer, "Address\t 0x%s\n", r.address.Hex())
+	_, _ = fmt.Fprintf(writer, "Weight\t %d of %d\n", r.weight, flowsdk.AccountKeyWeightThreshold)
+
+	_, _ = fmt.Fprintf(writer, "\nKey Index\tWeight\tRevoked\tValid\n")
+	for _, key := range r.keys {
+		valid := fmt.Sprintf("%v", key.Valid)
+		if key.Error != "" {
+			valid = fmt.Sprintf("%s (%s)", valid, key.Error)
+		}
+		_, _ = fmt.Fprintf(writer, "%d\t%d\t%v\t%s\n", key.Index, key.Weight, key.Revoked, valid)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *verifyProofResult) Oneliner() string {
+	return fmt.Sprintf("valid: %v, address: 0x%s, weight: %d", r.valid(), r.address.Hex(), r.weight)
+}
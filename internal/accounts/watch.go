@@ -0,0 +1,253 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsWatch struct {
+	Interval string `default:"10s" flag:"interval" info:"How often the account is checked for changes (e.g. 10s, 1m)"`
+}
+
+var watchFlags = flagsWatch{}
+
+var watchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "watch <address | account name>",
+		Short: "Print the balance and storage of an account when they change",
+		Long: `Check the balance and storage of an account on the interval and print them when they change, until it's interrupted.
+
+With the JSON output format each change is printed on a line, to be piped into monitoring systems.`,
+		Example: `flow accounts watch 0xf8d6e0586b0a20c7
+flow accounts watch alice --interval 1m --network testnet -o json`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags:  &watchFlags,
+	Output: watchAccountOutput{},
+	Run:    watch,
+}
+
+// storageScript returns the storage used and the storage capacity of the account.
+const storageScript = `pub fun main(address: Address): [UInt64] {
+	let account = getAccount(address)
+	return [account.storageUsed, account.storageCapacity]
+}`
+
+func watch(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	interval, err := time.ParseDuration(watchFlags.Interval)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid interval %s, provide a positive duration such as 10s or 1m", watchFlags.Interval)
+	}
+
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(args[0], state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	logger.Info(fmt.Sprintf("%s Watching account 0x%s every %s, stop with Ctrl+C", output.TryEmoji(), address, interval))
+
+	watcher := &accountWatcher{flow: flow, address: address}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		change, err := watcher.poll(ctx)
+		if err != nil {
+			// the access node might be temporarily unavailable so the next poll is tried anyway
+			logger.Error(fmt.Sprintf("failed to get account 0x%s: %s", address, err))
+		} else if change != nil {
+			err = command.PrintResult(change, globalFlags)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// accountSnapshot is the balance and storage of an account at a block height.
+type accountSnapshot struct {
+	height          uint64
+	balance         uint64
+	storageUsed     uint64
+	storageCapacity uint64
+}
+
+// accountWatcher fetches the account at the latest block and reports the changes since the last poll.
+type accountWatcher struct {
+	flow    flowkit.Services
+	address flowsdk.Address
+	last    *accountSnapshot
+}
+
+// poll returns the change of the account since the last poll, or nil if the account didn't change.
+// The first poll returns the account without the previous snapshot.
+func (w *accountWatcher) poll(ctx context.Context) (*watchAccountResult, error) {
+	latest, err := w.flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	// the account and the storage are read at the same height so they are consistent
+	account, err := w.flow.GetAccountAtBlockHeight(ctx, w.address, latest.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := w.flow.ExecuteScript(
+		ctx,
+		flowkit.Script{
+			Code: []byte(storageScript),
+			Args: []cadence.Value{cadence.NewAddress(w.address)},
+		},
+		flowkit.ScriptQuery{Height: latest.Height},
+	)
+	if err != nil {
+		return nil, err
+	}
+	storage, ok := value.(cadence.Array)
+	if !ok || len(storage.Values) != 2 {
+		return nil, fmt.Errorf("unexpected storage result %s", value)
+	}
+	used, usedOk := storage.Values[0].(cadence.UInt64)
+	capacity, capacityOk := storage.Values[1].(cadence.UInt64)
+	if !usedOk || !capacityOk {
+		return nil, fmt.Errorf("unexpected storage result %s", value)
+	}
+
+	snapshot := &accountSnapshot{
+		height:          latest.Height,
+		balance:         account.Balance,
+		storageUsed:     uint64(used),
+		storageCapacity: uint64(capacity),
+	}
+
+	previous := w.last
+	if previous != nil && previous.balance == snapshot.balance && previous.storageUsed == snapshot.storageUsed &&
+		previous.storageCapacity == snapshot.storageCapacity {
+		return nil, nil
+	}
+	w.last = snapshot
+
+	return &watchAccountResult{address: w.address, current: snapshot, previous: previous}, nil
+}
+
+type watchAccountResult struct {
+	address  flowsdk.Address
+	current  *accountSnapshot
+	previous *accountSnapshot
+}
+
+// watchAccountOutput is the JSON output of an account change of the watch command.
+type watchAccountOutput struct {
+	BlockHeight       uint64 `json:"blockHeight"`
+	Address           string `json:"address"`
+	Balance           string `json:"balance"`
+	BalanceChange     string `json:"balanceChange,omitempty"`
+	StorageUsed       uint64 `json:"storageUsed"`
+	StorageUsedChange int64  `json:"storageUsedChange,omitempty"`
+	StorageCapacity   uint64 `json:"storageCapacity"`
+}
+
+// balanceChange returns the signed change of the balance since the previous snapshot.
+func (r *watchAccountResult) balanceChange() string {
+	if r.previous == nil || r.previous.balance == r.current.balance {
+		return ""
+	}
+	if r.current.balance > r.previous.balance {
+		return "+" + cadence.UFix64(r.current.balance-r.previous.balance).String()
+	}
+	return "-" + cadence.UFix64(r.previous.balance-r.current.balance).String()
+}
+
+func (r *watchAccountResult) storageUsedChange() int64 {
+	if r.previous == nil {
+		return 0
+	}
+	return int64(r.current.storageUsed) - int64(r.previous.storageUsed)
+}
+
+func (r *watchAccountResult) JSON() any {
+	return watchAccountOutput{
+		BlockHeight:       r.current.height,
+		Address:           fmt.Sprintf("0x%s", r.address),
+		Balance:           cadence.UFix64(r.current.balance).String(),
+		BalanceChange:     r.balanceChange(),
+		StorageUsed:       r.current.storageUsed,
+		StorageUsedChange: r.storageUsedChange(),
+		StorageCapacity:   r.current.storageCapacity,
+	}
+}
+
+func (r *watchAccountResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	balance := cadence.UFix64(r.current.balance).String()
+	if change := r.balanceChange(); change != "" {
+		balance = fmt.Sprintf("%s (%s)", balance, change)
+	}
+	storage := fmt.Sprintf("%d / %d bytes", r.current.storageUsed, r.current.storageCapacity)
+	if change := r.storageUsedChange(); change != 0 {
+		storage = fmt.Sprintf("%s (%+d)", storage, change)
+	}
+
+	_, _ = fmt.Fprintf(writer, "Block %d\tBalance: %s\tStorage: %s\n", r.current.height, balance, storage)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *watchAccountResult) Oneliner() string {
+	return fmt.Sprintf("%d %s %d", r.current.height, cadence.UFix64(r.current.balance), r.current.storageUsed)
+}
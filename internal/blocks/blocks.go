@@ -25,6 +25,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/events"
 	"github.com/onflow/flow-cli/internal/util"
@@ -46,6 +47,7 @@ type blockResult struct {
 	events      []flow.BlockEvents
 	collections []*flow.Collection
 	included    []string
+	link        command.ExplorerLink
 }
 
 func (r *blockResult) JSON() any {
@@ -73,6 +75,10 @@ func (r *blockResult) JSON() any {
 	}
 
 	result["collection"] = collections
+
+	if link := r.link.Included(config.ExplorerBlock, r.block.ID.String()); link != "" {
+		result["link"] = link
+	}
 	return result
 }
 
@@ -97,6 +103,9 @@ func (r *blockResult) String() string {
 	_, _ = fmt.Fprintf(writer, "Proposal Timestamp Unix\t%d\n", r.block.Timestamp.Unix())
 	_, _ = fmt.Fprintf(writer, "Height\t%v\n", r.block.Height)
 	_, _ = fmt.Fprintf(writer, "Status\t%s\n", blockStatusToString(r.block.Status))
+	if link := r.link.URL(config.ExplorerBlock, r.block.ID.String()); link != "" {
+		_, _ = fmt.Fprintf(writer, "Explorer\t%s\n", link)
+	}
 
 	_, _ = fmt.Fprintf(writer, "Total Seals\t%v\n", len(r.block.Seals))
 
@@ -124,5 +133,8 @@ func (r *blockResult) String() string {
 }
 
 func (r *blockResult) Oneliner() string {
+	if link := r.link.Included(config.ExplorerBlock, r.block.ID.String()); link != "" {
+		return fmt.Sprintf("%s, Explorer: %s", r.block.ID, link)
+	}
 	return r.block.ID.String()
 }
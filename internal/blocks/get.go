@@ -49,7 +49,7 @@ var getCommand = &command.Command{
 
 func get(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
 	_ flowkit.ReaderWriter,
 	flow flowkit.Services,
@@ -97,5 +97,6 @@ func get(
 		events:      events,
 		collections: collections,
 		included:    blockFlags.Include,
+		link:        command.NewExplorerLink(flow.Network(), globalFlags),
 	}, nil
 }
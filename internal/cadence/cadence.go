@@ -44,4 +44,6 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(languageserver.Cmd)
+	CheckCommand.AddToParent(Cmd)
+	migrateCommand.AddToParent(Cmd)
 }
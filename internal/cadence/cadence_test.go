@@ -0,0 +1,272 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cadence
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Check(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	require.NoError(t, rw.WriteFile("contracts/Counter.cdc", []byte(`
+		import Token from 0x0000000000000001
+
+		pub contract Counter {
+			pub var count: Int
+			pub let token: Type
+
+			init() {
+				self.count = 0
+				self.token = Type<@Token.Vault>()
+			}
+		}
+	`), 0644))
+	require.NoError(t, rw.WriteFile("scripts/count.cdc", []byte(`
+		import Counter from "../contracts/Counter.cdc"
+
+		pub fun main(): Int {
+			return Counter.count
+		}
+	`), 0644))
+	require.NoError(t, rw.WriteFile("scripts/invalid.cdc", []byte(`
+		import "Counter"
+
+		pub fun main(): String {
+			return Counter.count
+		}
+	`), 0644))
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Counter", Location: "contracts/Counter.cdc"})
+
+	token := []byte(`
+		pub contract Token {
+			pub resource Vault {}
+		}
+	`)
+	defer func() { checkStatus = 0 }()
+
+	t.Run("Fail contract not pinned", func(t *testing.T) {
+		result, err := check(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		files := result.JSON().(checkOutput).Files
+		require.Len(t, files, 1)
+		assert.Equal(t, "contracts/Counter.cdc", files[0].File)
+		assert.Equal(t,
+			"contracts/Counter.cdc:2:21: contract Token in account 0000000000000001 is not pinned in imports/0x0000000000000001/Token.cdc, run the check with the --pull flag once to fetch it",
+			files[0].Errors[0],
+		)
+		assert.Equal(t, 1, checkStatus)
+		srv.Mock.AssertNotCalled(t, "GetAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success pull", func(t *testing.T) {
+		checkFlags.Pull = true
+		defer func() { checkFlags.Pull = false }()
+
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			assert.Equal(t, flow.HexToAddress("0x01"), args.Get(1).(flow.Address))
+			srv.GetAccount.Return(&flow.Account{Contracts: map[string][]byte{"Token": token}}, nil)
+		})
+
+		result, err := check(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, checkOutput{
+			Files:  []checkedFileOutput{{File: "contracts/Counter.cdc"}},
+			Pulled: []string{"imports/0x0000000000000001/Token.cdc"},
+		}, result.JSON())
+
+		pinned, err := rw.ReadFile("imports/0x0000000000000001/Token.cdc")
+		require.NoError(t, err)
+		assert.Equal(t, token, pinned)
+	})
+
+	t.Run("Success offline with pinned contracts", func(t *testing.T) {
+		srv.GetAccount.Run(func(mock.Arguments) {
+			t.Fatal("contracts are fetched while checking offline")
+		})
+
+		result, err := check(
+			[]string{"scripts/count.cdc", "scripts/invalid.cdc"},
+			command.GlobalFlags{},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, checkOutput{
+			Files: []checkedFileOutput{
+				{File: "scripts/count.cdc"},
+				{
+					File:   "scripts/invalid.cdc",
+					Errors: []string{"scripts/invalid.cdc:5:11: mismatched types, expected `String`, got `Int`"},
+				},
+			},
+		}, result.JSON())
+	})
+
+	t.Run("Fail syntax error", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("scripts/syntax.cdc", []byte("pub fun main( {}"), 0644))
+
+		result, err := check([]string{"scripts/syntax.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		errs := result.JSON().(checkOutput).Files[0].Errors
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0], "scripts/syntax.cdc:1:")
+	})
+
+	t.Run("Fail unsupported Cadence version", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("scripts/v1.cdc", []byte(`
+			access(all) fun main(): Int {
+				let account: &Account = getAccount(0x01)
+				return 1
+			}
+		`), 0644))
+
+		result, err := check([]string{"scripts/v1.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"scripts/v1.cdc: the program targets Cadence 1.0 which is not supported, only Cadence 0.x can be parsed by this version of flow-cli",
+		}, result.JSON().(checkOutput).Files[0].Errors)
+	})
+}
+
+func Test_Migrate(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	code := []byte(`// pub contract in a comment
+pub contract Counter {
+	pub(set) var count: Int
+	priv let label: String
+
+	pub fun setup(acct: AuthAccount, public: &PublicAccount) {
+		acct.link<&Counter>(/public/counter, target: /storage/counter)
+	}
+
+	init() {
+		self.count = 0
+		self.label = "pub counter"
+	}
+}
+`)
+	require.NoError(t, rw.WriteFile("contracts/Counter.cdc", code, 0644))
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Counter", Location: "contracts/Counter.cdc"})
+
+	migrated := []byte(`// pub contract in a comment
+access(all) contract Counter {
+	access(all) var count: Int
+	access(self) let label: String
+
+	access(all) fun setup(acct: auth(Storage, Contracts, Keys, Inbox, Capabilities) &Account, public: &Account) {
+		acct.link<&Counter>(/public/counter, target: /storage/counter)
+	}
+
+	init() {
+		self.count = 0
+		self.label = "pub counter"
+	}
+}
+`)
+	expected := migrateOutput{
+		Files: []migratedFileOutput{{
+			File:    "contracts/Counter.cdc",
+			Changes: 6,
+			Manual: []string{
+				"contracts/Counter.cdc:3:2: fields can only be set in the declaring type, add a setter function for pub(set) fields",
+				"contracts/Counter.cdc:7:7: linking capabilities was replaced by capabilities.storage.issue and capabilities.publish",
+			},
+		}},
+	}
+
+	t.Run("Success dry run", func(t *testing.T) {
+		result, err := migrate(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, expected, result.JSON())
+
+		written, err := rw.ReadFile("contracts/Counter.cdc")
+		require.NoError(t, err)
+		assert.Equal(t, string(code), string(written))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		migrateFlags.Write = true
+		defer func() { migrateFlags.Write = false }()
+
+		result, err := migrate([]string{"contracts/Counter.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		expected.Written = true
+		assert.Equal(t, expected, result.JSON())
+
+		written, err := rw.ReadFile("contracts/Counter.cdc")
+		require.NoError(t, err)
+		assert.Equal(t, string(migrated), string(written))
+	})
+
+	t.Run("Success already migrated", func(t *testing.T) {
+		result, err := migrate([]string{"contracts/Counter.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.JSON().(migrateOutput).Files[0].Changes)
+	})
+
+	t.Run("Migrated code parses", func(t *testing.T) {
+		code := []byte(`pub contract Keys {
+	pub fun keys(acct: AuthAccount): &AuthAccount.Keys {
+		return &acct.keys as &AuthAccount.Keys
+	}
+
+	pub fun contracts(acct: PublicAccount): PublicAccount.Contracts {
+		return acct.contracts
+	}
+
+	priv fun borrow(acct: &AuthAccount): &AuthAccount {
+		return acct
+	}
+}
+`)
+		_, err := parser.ParseProgram(nil, code, parser.Config{})
+		require.NoError(t, err)
+
+		migration := migrateCode("Keys.cdc", code)
+		assert.Contains(t, string(migration.code), "acct: auth(Storage, Contracts, Keys, Inbox, Capabilities) &Account): &Account.Keys")
+		assert.Contains(t, string(migration.code), "acct: &Account): Account.Contracts")
+		assert.NoError(t, checkMigration(code, migration.code))
+
+		_, err = parser.ParseProgram(nil, entitledReference.ReplaceAll(migration.code, []byte("auth &")), parser.Config{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail migrated code not parsing", func(t *testing.T) {
+		code := []byte(`pub contract Broken {}`)
+		assert.Error(t, checkMigration(code, []byte(`access(all) contract Broken { access(all) fun }`)))
+		require.NoError(t, rw.WriteFile("contracts/Broken.cdc", []byte(`pub contract Broken {`), 0644))
+
+		// code that can't be parsed before the migration isn't checked
+		_, err := migrate([]string{"contracts/Broken.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+	})
+}
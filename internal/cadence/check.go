@@ -0,0 +1,432 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cadence
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	cadenceErrors "github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsCheck struct {
+	Pull bool `default:"false" flag:"pull" info:"Fetch imported contracts missing from the pinned copies from the network"`
+}
+
+var checkFlags = flagsCheck{}
+
+var checkStatus = 0
+
+var CheckCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "check [<files>]",
+		Short: "Type-check Cadence code offline",
+		Long: `Type-check Cadence files without network access, checking the contracts configured in the project if no files are provided.
+
+Contracts imported by address are checked against pinned copies in the imports directory of the project,
+run the command once with the --pull flag to fetch missing copies from the selected network and commit them.`,
+		Example: `flow cadence check
+flow cadence check ./scripts/get_balance.cdc ./transactions/transfer.cdc
+flow cadence check --pull --network mainnet`,
+	},
+	Flags:    &checkFlags,
+	Status:   &checkStatus,
+	NoDaemon: true,
+	Output:   checkOutput{},
+	RunS:     check,
+}
+
+func check(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	files := args
+	if len(files) == 0 {
+		files = contractFiles(state)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files to check, provide the files or add contracts to the configuration")
+	}
+
+	c := newChecker(state, util.ProjectDir(globalFlags.ConfigPaths))
+	if checkFlags.Pull {
+		c.pull = func(address flowsdk.Address) (*flowsdk.Account, error) {
+			return flow.GetAccount(context.Background(), address)
+		}
+	}
+
+	logger.StartProgress(fmt.Sprintf("Checking %d files...", len(files)))
+	defer logger.StopProgress()
+
+	result := &checkResult{}
+	for _, file := range files {
+		checked := checkedFile{file: file}
+		if err := c.check(common.StringLocation(path.Clean(file))); err != nil {
+			checked.errors = c.formatErrors(common.StringLocation(path.Clean(file)), err)
+			checkStatus = 1
+		}
+		result.files = append(result.files, checked)
+	}
+	result.pulled = c.pulled
+
+	return result, nil
+}
+
+// contractFiles returns the sorted locations of the contracts in the configuration.
+func contractFiles(state *flowkit.State) []string {
+	locations := make(map[string]bool)
+	for _, contract := range *state.Contracts() {
+		if contract.Location != "" {
+			locations[path.Clean(contract.Location)] = true
+		}
+	}
+
+	files := make([]string, 0, len(locations))
+	for location := range locations {
+		files = append(files, location)
+	}
+	sort.Strings(files)
+
+	return files
+}
+
+// pinnedDir is the project directory holding the pinned copies of the contracts imported by address.
+const pinnedDir = "imports"
+
+// checker type-checks programs resolving the imports from the project files and the pinned contracts.
+type checker struct {
+	state      *flowkit.State
+	projectDir string
+	// pull fetches the account of the contracts missing from the pinned copies, nil if checking offline.
+	pull     func(address flowsdk.Address) (*flowsdk.Account, error)
+	pulled   []string
+	checkers map[common.Location]*sema.Checker
+	checking map[common.Location]bool
+}
+
+func newChecker(state *flowkit.State, projectDir string) *checker {
+	return &checker{
+		state:      state,
+		projectDir: projectDir,
+		checkers:   make(map[common.Location]*sema.Checker),
+		checking:   make(map[common.Location]bool),
+	}
+}
+
+// pinnedFile returns the file of the pinned contract copy.
+func (c *checker) pinnedFile(location common.AddressLocation) string {
+	return filepath.Join(c.projectDir, pinnedDir, location.Address.HexWithPrefix(), fmt.Sprintf("%s.cdc", location.Name))
+}
+
+// check parses and type-checks the program at the location, returning the parsing or checking error.
+func (c *checker) check(location common.Location) error {
+	_, err := c.checker(location)
+	return err
+}
+
+func (c *checker) checker(location common.Location) (*sema.Checker, error) {
+	if checker, ok := c.checkers[location]; ok {
+		return checker, nil
+	}
+
+	code, err := c.code(location)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := project.ParseProgram(code, "")
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err := sema.NewChecker(program, location, nil, c.config(program, location))
+	if err != nil {
+		return nil, err
+	}
+
+	c.checking[location] = true
+	defer delete(c.checking, location)
+
+	if err := checker.Check(); err != nil {
+		return nil, err
+	}
+
+	c.checkers[location] = checker
+	return checker, nil
+}
+
+// code returns the code of a project file or of a pinned contract, pulling the contract if allowed.
+func (c *checker) code(location common.Location) ([]byte, error) {
+	switch location := location.(type) {
+	case common.StringLocation:
+		code, err := c.state.ReadFile(string(location))
+		if err != nil {
+			return nil, fmt.Errorf("error loading file %s: %w", location, err)
+		}
+		return code, nil
+
+	case common.AddressLocation:
+		file := c.pinnedFile(location)
+		code, err := c.state.ReadFile(file)
+		if err == nil {
+			return code, nil
+		}
+		if c.pull == nil {
+			return nil, fmt.Errorf(
+				"%s is not pinned in %s, run the check with the --pull flag once to fetch it",
+				location.Description(),
+				file,
+			)
+		}
+		return c.pullContract(location, file)
+
+	default:
+		return nil, fmt.Errorf("importing %s is not supported", location)
+	}
+}
+
+// pullContract fetches the contract code from the network and pins it to the file.
+func (c *checker) pullContract(location common.AddressLocation, file string) ([]byte, error) {
+	account, err := c.pull(flowsdk.Address(location.Address))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", location.Description(), err)
+	}
+
+	code, ok := account.Contracts[location.Name]
+	if !ok {
+		return nil, fmt.Errorf("%s is not deployed", location.Description())
+	}
+
+	if err := flowkit.MkdirAll(c.state.ReaderWriter(), filepath.Dir(file)); err != nil {
+		return nil, err
+	}
+	if err := c.state.ReaderWriter().WriteFile(file, code, 0644); err != nil {
+		return nil, fmt.Errorf("failed to pin %s: %w", location.Description(), err)
+	}
+
+	c.pulled = append(c.pulled, file)
+	return code, nil
+}
+
+// config returns the checker configuration of the program, scripts can also access the authorized accounts.
+func (c *checker) config(program *ast.Program, location common.Location) *sema.Config {
+	values := stdlib.DefaultStandardLibraryValues(nil)
+	if len(program.TransactionDeclarations()) == 0 && len(program.CompositeDeclarations()) == 0 &&
+		len(program.InterfaceDeclarations()) == 0 {
+		values = stdlib.DefaultScriptStandardLibraryValues(nil)
+	}
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	for _, value := range values {
+		baseValueActivation.DeclareValue(value)
+	}
+
+	return &sema.Config{
+		BaseValueActivation: baseValueActivation,
+		AccessCheckMode:     sema.AccessCheckModeStrict,
+		LocationHandler: func(identifiers []ast.Identifier, imported common.Location) ([]sema.ResolvedLocation, error) {
+			return c.resolveLocation(location, identifiers, imported)
+		},
+		ImportHandler: c.importProgram,
+	}
+}
+
+// resolveLocation resolves file imports relative to the importing file, imports by name
+// from the contracts in the configuration and address imports to a location for each contract.
+func (c *checker) resolveLocation(
+	importing common.Location,
+	identifiers []ast.Identifier,
+	imported common.Location,
+) ([]sema.ResolvedLocation, error) {
+	switch imported := imported.(type) {
+	case common.StringLocation:
+		if !strings.HasSuffix(string(imported), ".cdc") { // import by contract name (e.g. import "X")
+			contract, err := c.state.Contracts().ByName(string(imported))
+			if err != nil {
+				return nil, fmt.Errorf("contract %s is not configured in the project", imported)
+			}
+			imported = common.StringLocation(path.Clean(contract.Location))
+		} else if dir, ok := importing.(common.StringLocation); ok {
+			imported = common.StringLocation(path.Join(path.Dir(string(dir)), string(imported)))
+		}
+		return []sema.ResolvedLocation{{Location: imported, Identifiers: identifiers}}, nil
+
+	case common.AddressLocation:
+		if len(identifiers) == 0 {
+			return nil, fmt.Errorf("importing all contracts of %s is not supported, import the contracts by name", imported.Address.HexWithPrefix())
+		}
+		resolved := make([]sema.ResolvedLocation, len(identifiers))
+		for i, identifier := range identifiers {
+			resolved[i] = sema.ResolvedLocation{
+				Location: common.AddressLocation{
+					Address: imported.Address,
+					Name:    identifier.Identifier,
+				},
+				Identifiers: []ast.Identifier{identifier},
+			}
+		}
+		return resolved, nil
+
+	default:
+		return []sema.ResolvedLocation{{Location: imported, Identifiers: identifiers}}, nil
+	}
+}
+
+func (c *checker) importProgram(_ *sema.Checker, location common.Location, importRange ast.Range) (sema.Import, error) {
+	if location == stdlib.CryptoCheckerLocation {
+		return sema.ElaborationImport{Elaboration: stdlib.CryptoChecker().Elaboration}, nil
+	}
+	if c.checking[location] {
+		return nil, &sema.CyclicImportsError{Location: location, Range: importRange}
+	}
+
+	checker, err := c.checker(location)
+	if err != nil {
+		return nil, err
+	}
+
+	return sema.ElaborationImport{Elaboration: checker.Elaboration}, nil
+}
+
+// formatErrors returns the errors of the program at the location prefixed with the file and the position.
+func (c *checker) formatErrors(location common.Location, err error) []string {
+	file := location.String()
+	if address, ok := location.(common.AddressLocation); ok {
+		file = c.pinnedFile(address)
+	}
+
+	if imported, ok := err.(*sema.ImportedProgramError); ok {
+		if _, ok := imported.Err.(cadenceErrors.ParentError); ok {
+			return c.formatErrors(imported.Location, imported.Err)
+		}
+		return []string{formatError(file, imported.StartPos, imported.Err)}
+	}
+
+	if parent, ok := err.(cadenceErrors.ParentError); ok {
+		var errs []string
+		for _, child := range parent.ChildErrors() {
+			errs = append(errs, c.formatErrors(location, child)...)
+		}
+		return errs
+	}
+
+	if positioned, ok := err.(ast.HasPosition); ok {
+		return []string{formatError(file, positioned.StartPosition(), err)}
+	}
+
+	return []string{fmt.Sprintf("%s: %s", file, err)}
+}
+
+// formatError returns the error with the file and the position, columns are converted to start from one.
+func formatError(file string, position ast.Position, err error) string {
+	message := err.Error()
+	if secondary, ok := err.(cadenceErrors.SecondaryError); ok {
+		message = fmt.Sprintf("%s, %s", message, secondary.SecondaryError())
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s", file, position.Line, position.Column+1, message)
+}
+
+type checkedFile struct {
+	file   string
+	errors []string
+}
+
+// checkOutput is the JSON output of the check command.
+type checkOutput struct {
+	Files  []checkedFileOutput `json:"files"`
+	Pulled []string            `json:"pulled,omitempty"`
+}
+
+type checkedFileOutput struct {
+	File   string   `json:"file"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type checkResult struct {
+	files  []checkedFile
+	pulled []string
+}
+
+func (r *checkResult) failed() int {
+	failed := 0
+	for _, file := range r.files {
+		if len(file.errors) > 0 {
+			failed++
+		}
+	}
+	return failed
+}
+
+func (r *checkResult) JSON() any {
+	files := make([]checkedFileOutput, 0, len(r.files))
+	for _, file := range r.files {
+		files = append(files, checkedFileOutput{File: file.file, Errors: file.errors})
+	}
+
+	return checkOutput{Files: files, Pulled: r.pulled}
+}
+
+func (r *checkResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for _, file := range r.pulled {
+		_, _ = fmt.Fprintf(writer, "%s Pinned %s\n", output.SaveEmoji(), file)
+	}
+	for _, file := range r.files {
+		if len(file.errors) == 0 {
+			_, _ = fmt.Fprintf(writer, "%s %s\n", output.OkEmoji(), file.file)
+			continue
+		}
+		_, _ = fmt.Fprintf(writer, "%s %s\n", output.ErrorEmoji(), file.file)
+		for _, err := range file.errors {
+			_, _ = fmt.Fprintf(writer, "\t%s\n", err)
+		}
+	}
+	_, _ = fmt.Fprintf(writer, "\nChecked\t%d\n", len(r.files))
+	_, _ = fmt.Fprintf(writer, "Failed\t%d\n", r.failed())
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *checkResult) Oneliner() string {
+	return fmt.Sprintf("Checked: %d, Failed: %d", len(r.files), r.failed())
+}
@@ -0,0 +1,269 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cadence
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsMigrate struct {
+	Write bool `default:"false" flag:"write" info:"Write the migrated code to the files instead of only reporting the changes"`
+}
+
+var migrateFlags = flagsMigrate{}
+
+var migrateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "migrate [<files>]",
+		Short: "Rewrite Cadence code to the Cadence 1.0 syntax",
+		Long: `Rewrite the mechanical syntax changes of Cadence 1.0 in the files, migrating the contracts configured in the project if no files are provided.
+
+Access modifiers and account types are rewritten, usages of APIs removed in Cadence 1.0 are reported with their position to be migrated manually.
+Comments and strings are left unchanged. The changes are only reported unless the --write flag is provided, files are not written
+if the migrated code can't be parsed.`,
+		Example: `flow cadence migrate
+flow cadence migrate ./contracts/Counter.cdc --write`,
+	},
+	Flags:    &migrateFlags,
+	NoDaemon: true,
+	Output:   migrateOutput{},
+	RunS:     migrate,
+}
+
+func migrate(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	files := args
+	if len(files) == 0 {
+		files = contractFiles(state)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files to migrate, provide the files or add contracts to the configuration")
+	}
+
+	result := &migrateResult{written: migrateFlags.Write}
+	for _, file := range files {
+		code, err := state.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error loading file %s: %w", file, err)
+		}
+
+		migration := migrateCode(file, code)
+		if err := checkMigration(code, migration.code); err != nil {
+			return nil, fmt.Errorf("migrated code of %s can't be parsed, no files were written: %w", file, err)
+		}
+		result.files = append(result.files, migration)
+	}
+
+	if migrateFlags.Write {
+		for _, migration := range result.files {
+			if migration.changes == 0 {
+				continue
+			}
+			if err := state.ReaderWriter().WriteFile(migration.file, migration.code, 0644); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", migration.file, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// migrationRule rewrites the syntax matching the pattern to the Cadence 1.0 syntax.
+type migrationRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+var migrationRules = []migrationRule{
+	{regexp.MustCompile(`\bpub\s*\(\s*set\s*\)`), "access(all)"},
+	{regexp.MustCompile(`\bpub\b`), "access(all)"},
+	{regexp.MustCompile(`\bpriv\b`), "access(self)"},
+	// nested types like AuthAccount.Keys are declared by the account type
+	{regexp.MustCompile(`\b(?:AuthAccount|PublicAccount)\s*\.\s*`), "Account."},
+	{regexp.MustCompile(`&?\bAuthAccount\b`), "auth(Storage, Contracts, Keys, Inbox, Capabilities) &Account"},
+	{regexp.MustCompile(`&?\bPublicAccount\b`), "&Account"},
+}
+
+// manualMigration matches usages of syntax and APIs that can't be rewritten mechanically.
+type manualMigration struct {
+	pattern *regexp.Regexp
+	note    string
+}
+
+var manualMigrations = []manualMigration{
+	{regexp.MustCompile(`\bpub\s*\(\s*set\s*\)`), "fields can only be set in the declaring type, add a setter function for pub(set) fields"},
+	{regexp.MustCompile(`\.link\s*<`), "linking capabilities was replaced by capabilities.storage.issue and capabilities.publish"},
+	{regexp.MustCompile(`\.unlink\s*\(`), "unlinking capabilities was replaced by capabilities.unpublish"},
+	{regexp.MustCompile(`\.getCapability\s*[<(]`), "getting capabilities was replaced by capabilities.get"},
+	{regexp.MustCompile(`\bdestroy\s*\(\s*\)\s*\{`), "custom destructors were removed, declare a ResourceDestroyed event instead"},
+}
+
+// nonCode matches comments and string literals which are not migrated.
+var nonCode = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/|"(?:[^"\\\n]|\\.)*"`)
+
+// blankNonCode replaces comments and string literals with spaces keeping the offsets and lines of the code.
+func blankNonCode(code []byte) []byte {
+	return nonCode.ReplaceAllFunc(code, func(match []byte) []byte {
+		blank := bytes.Map(func(r rune) rune {
+			if r == '\n' {
+				return r
+			}
+			return ' '
+		}, match)
+		// multibyte characters are replaced by a single space, pad to keep the offsets
+		return append(blank, bytes.Repeat([]byte(" "), len(match)-len(blank))...)
+	})
+}
+
+// entitledReference matches the entitlements of references, which the bundled parser doesn't support.
+var entitledReference = regexp.MustCompile(`\bauth\s*\([^()]*\)\s*&`)
+
+// checkMigration parses the migrated code with the bundled parser, with the entitled references of
+// Cadence 1.0 written as authorized references, to make sure the migration rules produced valid syntax.
+//
+// Code that the bundled parser can't parse before the migration isn't checked.
+func checkMigration(code []byte, migrated []byte) error {
+	if _, err := parser.ParseProgram(nil, code, parser.Config{}); err != nil {
+		return nil
+	}
+
+	_, err := parser.ParseProgram(nil, entitledReference.ReplaceAll(migrated, []byte("auth &")), parser.Config{})
+	return err
+}
+
+// migratedFile is the code of a file rewritten to the Cadence 1.0 syntax.
+type migratedFile struct {
+	file    string
+	code    []byte
+	changes int
+	notes   []string
+}
+
+// migrateCode rewrites the code with the migration rules and reports the manual migrations of the original code.
+func migrateCode(file string, code []byte) migratedFile {
+	migrated := migratedFile{file: file, code: code}
+
+	blanked := blankNonCode(code)
+	for _, manual := range manualMigrations {
+		for _, match := range manual.pattern.FindAllIndex(blanked, -1) {
+			line := bytes.Count(blanked[:match[0]], []byte("\n")) + 1
+			column := match[0] - bytes.LastIndexByte(blanked[:match[0]], '\n')
+			migrated.notes = append(migrated.notes, fmt.Sprintf("%s:%d:%d: %s", file, line, column, manual.note))
+		}
+	}
+
+	for _, rule := range migrationRules {
+		matches := rule.pattern.FindAllIndex(blankNonCode(migrated.code), -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		var b bytes.Buffer
+		last := 0
+		for _, match := range matches {
+			b.Write(migrated.code[last:match[0]])
+			b.WriteString(rule.replacement)
+			last = match[1]
+		}
+		b.Write(migrated.code[last:])
+
+		migrated.code = b.Bytes()
+		migrated.changes += len(matches)
+	}
+
+	return migrated
+}
+
+// migrateOutput is the JSON output of the migrate command.
+type migrateOutput struct {
+	Files   []migratedFileOutput `json:"files"`
+	Written bool                 `json:"written"`
+}
+
+type migratedFileOutput struct {
+	File    string   `json:"file"`
+	Changes int      `json:"changes"`
+	Manual  []string `json:"manual,omitempty"`
+}
+
+type migrateResult struct {
+	files   []migratedFile
+	written bool
+}
+
+func (r *migrateResult) changed() int {
+	changed := 0
+	for _, file := range r.files {
+		if file.changes > 0 {
+			changed++
+		}
+	}
+	return changed
+}
+
+func (r *migrateResult) JSON() any {
+	files := make([]migratedFileOutput, 0, len(r.files))
+	for _, file := range r.files {
+		files = append(files, migratedFileOutput{File: file.file, Changes: file.changes, Manual: file.notes})
+	}
+
+	return migrateOutput{Files: files, Written: r.written}
+}
+
+func (r *migrateResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for _, file := range r.files {
+		emoji := output.OkEmoji()
+		if len(file.notes) > 0 {
+			emoji = output.WarningEmoji()
+		}
+		_, _ = fmt.Fprintf(writer, "%s %s\t%d changes\n", emoji, file.file, file.changes)
+		for _, note := range file.notes {
+			_, _ = fmt.Fprintf(writer, "\t%s\n", note)
+		}
+	}
+	_, _ = fmt.Fprintf(writer, "\nMigrated\t%d\n", r.changed())
+	if !r.written {
+		_, _ = fmt.Fprintf(writer, "\nDry run, no files were written, use the --write flag to write the migrated code\n")
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *migrateResult) Oneliner() string {
+	return fmt.Sprintf("Migrated: %d, Files: %d", r.changed(), len(r.files))
+}
@@ -24,9 +24,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"net"
 	"os"
 	"os/user"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"strings"
@@ -43,6 +44,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
 	"github.com/onflow/flow-cli/internal/settings"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -71,12 +73,22 @@ type Command struct {
 	Run    run
 	RunS   RunWithState
 	Status *int
+	// NoDaemon runs the command in the invoking process even if a daemon serves the project,
+	// used for long-running and interactive commands or commands reporting their own status.
+	NoDaemon bool
+	// NoRecord excludes the command from recorded runbooks, used by commands managing recordings and replays.
+	NoRecord bool
+	// Output is a value of the type the command JSON output is encoded from,
+	// used to describe the output with a JSON schema.
+	Output any
 }
 
 const (
 	formatText   = "text"
 	formatInline = "inline"
 	formatJSON   = "json"
+	formatCBOR   = "cbor"
+	formatProto  = "proto"
 )
 
 const (
@@ -95,7 +107,30 @@ func (c Command) AddToParent(parent *cobra.Command) {
 	initCrashReporting()
 
 	c.Cmd.Run = func(cmd *cobra.Command, args []string) {
-		if !isDevelopment() { // only report crashes in production
+		if Flags.Schema {
+			handleError("Schema Error", printSchema(c))
+			return
+		}
+
+		if !c.NoDaemon && delegateToDaemon() {
+			return
+		}
+
+		if Flags.OutputFile != "" {
+			restore, err := redirectOutput(Flags)
+			handleError("Output File Error", err)
+			defer restore()
+
+			// the output is flushed to the file before the command exits on an error
+			commandExit := exit
+			exit = func(code int) {
+				restore()
+				commandExit(code)
+			}
+			defer func() { exit = commandExit }()
+		}
+
+		if !isDevelopment() && !serving { // only report crashes in production
 			defer sentry.Flush(2 * time.Second)
 			defer sentry.Recover()
 		}
@@ -104,24 +139,47 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		loader := &afero.Afero{Fs: afero.NewOsFs()}
 
 		// if we receive a config error that isn't missing config we should handle it
-		state, confErr := flowkit.Load(Flags.ConfigPaths, loader)
+		state, confErr := resources.load(Flags.ConfigPaths, loader)
 		if !errors.Is(confErr, config.ErrDoesNotExist) {
 			handleError("Config Error", confErr)
 		}
+		if state != nil {
+			handleError("Config Error", applyConfigDefaults(cmd.Flags(), state.Config().Defaults))
+		}
+
+		logger := createLogger(Flags.Log, Flags.Format)
 
-		network, err := resolveHost(state, Flags.Host, Flags.HostNetworkKey, Flags.Network)
+		selected := cmd.Flags().Changed("host") || cmd.Flags().Changed("network")
+		network, err := resolveNetwork(state, Flags, selected, config.EmulatorNetwork.Host, logger)
 		handleError("Host Error", err)
 
-		clientGateway, err := createGateway(*network)
-		handleError("Gateway Error", err)
+		if state != nil {
+			handleError("Keystore Error", applyKeystorePassword(state, Flags.PasswordFile))
+		}
 
-		logger := createLogger(Flags.Log, Flags.Format)
+		logResolvedConfig(state, confErr, network, Flags.Host)
+
+		clientGateway, err := resources.gateway(*network)
+		handleError("Gateway Error", err)
+		if Flags.Verbose >= verbosityRequests {
+			clientGateway = &verboseGateway{Gateway: clientGateway}
+		}
 
 		// initialize services
 		flow := flowkit.NewFlowkit(state, *network, clientGateway, logger)
+		if state != nil {
+			handleError("Ledger Error", resolveLedgerSigner(cmd.Flags(), state, flow))
+		}
+		if !Flags.NoBuildCache {
+			flow.SetBuildCache(resources.buildCache(BuildCacheDir(Flags.ConfigPaths), loader))
+		}
+
+		importOptions, err := project.ParseImportOptions(Flags.Resolve, Flags.IgnoreImport)
+		handleError("Import Resolution Error", err)
+		flow.SetImportOptions(importOptions)
 
-		// skip version check if flag is set
-		if !Flags.SkipVersionCheck {
+		// skip version check if flag is set, the daemon checked it when it started
+		if !Flags.SkipVersionCheck && !serving {
 			checkVersion(logger)
 		}
 
@@ -145,6 +203,13 @@ func (c Command) AddToParent(parent *cobra.Command) {
 
 		handleError("Command Error", err)
 
+		if !c.NoRecord {
+			err = recordCommand(cmd, invocationArgs(), network.Name, loader)
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to record the command: %s", err))
+			}
+		}
+
 		// Do not print a result if none is provided.
 		//
 		// This is useful for interactive commands that do not
@@ -168,14 +233,19 @@ func (c Command) AddToParent(parent *cobra.Command) {
 	parent.AddCommand(c.Cmd)
 }
 
+// BuildCacheDir returns the build cache directory of the project, next to the project configuration.
+func BuildCacheDir(configPaths []string) string {
+	return filepath.Join(util.ProjectDir(configPaths), flowkit.DefaultBuildCacheDir)
+}
+
 // createGateway creates a gateway to be used, defaults to grpc but can support others.
 func createGateway(network config.Network) (gateway.Gateway, error) {
-	// create secure grpc client if hostNetworkKey provided
-	if network.Key != "" {
-		return gateway.NewSecureGrpcGateway(network)
+	gw, err := gateway.NewNetworkGateway(network)
+	if err != nil {
+		return nil, err
 	}
 
-	return gateway.NewGrpcGateway(network)
+	return gw, nil
 }
 
 // resolveHost from the flags provided.
@@ -223,6 +293,82 @@ func resolveHost(state *flowkit.State, hostFlag, networkKeyFlag, networkFlag str
 	return network, nil
 }
 
+// emulatorProbeTimeout is the time we wait for a host to accept a connection when autodetecting the emulator.
+const emulatorProbeTimeout = 300 * time.Millisecond
+
+// resolveNetwork resolves the network from the flags and uses a locally running emulator at the emulator host
+// if the user didn't select a host or network, even if the project configuration defines the network.
+func resolveNetwork(
+	state *flowkit.State,
+	flags GlobalFlags,
+	selected bool,
+	emulatorHost string,
+	logger output.Logger,
+) (*config.Network, error) {
+	network, err := resolveHost(state, flags.Host, flags.HostNetworkKey, flags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	selected = selected || flags.Host != "" || flags.Network != config.EmulatorNetwork.Name
+	if autodetectEnabled(flags.NoAutodetect, selected) {
+		network = autodetectEmulator(network, emulatorHost, logger)
+	}
+
+	return network, nil
+}
+
+// autodetectEnabled returns true if the emulator should be autodetected, which is only when the user
+// didn't select a host or network and didn't disable it.
+func autodetectEnabled(noAutodetect bool, selected bool) bool {
+	return !noAutodetect && !selected
+}
+
+// autodetectEmulator returns the network with the emulator host if the resolved network host is
+// unreachable and an emulator is accepting connections on the emulator host instead.
+func autodetectEmulator(network *config.Network, emulatorHost string, logger output.Logger) *config.Network {
+	if reachable(network.Host) || sameAddress(network.Host, emulatorHost) || !reachable(emulatorHost) {
+		return network
+	}
+
+	logger.Info(fmt.Sprintf(
+		"%s Using emulator detected at %s since %s is unreachable, use --no-autodetect to disable\n",
+		output.TryEmoji(),
+		emulatorHost,
+		network.Host,
+	))
+
+	detected := *network
+	detected.Host = emulatorHost
+	return &detected
+}
+
+func reachable(host string) bool {
+	conn, err := net.DialTimeout("tcp", host, emulatorProbeTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+
+	return true
+}
+
+// sameAddress returns true if both hosts resolve to the same address, loopback addresses
+// like localhost and 127.0.0.1 are considered the same.
+func sameAddress(a string, b string) bool {
+	addrA, errA := net.ResolveTCPAddr("tcp", a)
+	addrB, errB := net.ResolveTCPAddr("tcp", b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	if addrA.Port != addrB.Port {
+		return false
+	}
+
+	return addrA.IP.Equal(addrB.IP) || (addrA.IP.IsLoopback() && addrB.IP.IsLoopback())
+}
+
 // create logger utility.
 func createLogger(logFlag string, formatFlag string) output.Logger {
 	// disable logging if we user want a specific format like JSON
@@ -249,7 +395,13 @@ func createLogger(logFlag string, formatFlag string) output.Logger {
 
 // checkVersion fetches latest version and compares it to local.
 func checkVersion(logger output.Logger) {
-	resp, err := http.Get("https://raw.githubusercontent.com/onflow/flow-cli/master/version.txt")
+	// the check runs before every command, so it's not retried to not delay the command
+	client, err := util.NewHTTPClient(util.HTTPOptions{Timeout: 3 * time.Second})
+	if err != nil {
+		return
+	}
+
+	resp, err := client.Get("https://raw.githubusercontent.com/onflow/flow-cli/master/version.txt")
 	if err != nil || resp.StatusCode >= 400 {
 		return
 	}
@@ -356,4 +508,20 @@ type GlobalFlags struct {
 	Yes              bool
 	ConfigPaths      []string
 	SkipVersionCheck bool
+	NoAutodetect     bool
+	NoBuildCache     bool
+	NoDaemon         bool
+	Schema           bool
+	Verbose          int
+	IncludeLinks     bool
+	// Resolve and IgnoreImport override the resolution of imports without changing the configuration.
+	Resolve      []string
+	IgnoreImport []string
+	// PasswordFile has the passphrase of keystore keys without a configured password file.
+	PasswordFile string
+	// OutputFile receives the standard output of the command, rotated by size or age.
+	OutputFile           string
+	OutputFileMaxSize    string
+	OutputFileMaxAge     string
+	OutputFileMaxBackups int
 }
@@ -0,0 +1,668 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	configJson "github.com/onflow/flow-cli/flowkit/config/json"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func listen(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	return listener
+}
+
+// closedHost returns a host nothing is listening on.
+func closedHost(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	return host
+}
+
+func Test_AutodetectEmulator(t *testing.T) {
+	logger := output.NewStdoutLogger(output.NoneLog)
+
+	t.Run("Override unreachable host", func(t *testing.T) {
+		emulator := listen(t).Addr().String()
+		network := &config.Network{Name: "emulator", Host: closedHost(t), Key: "0xabc", Explorer: "https://explorer/{id}"}
+
+		detected := autodetectEmulator(network, emulator, logger)
+		assert.Equal(t, &config.Network{Name: "emulator", Host: emulator, Key: "0xabc", Explorer: "https://explorer/{id}"}, detected)
+		assert.NotEqual(t, emulator, network.Host)
+	})
+
+	t.Run("No override reachable host", func(t *testing.T) {
+		emulator := listen(t).Addr().String()
+		network := &config.Network{Name: "emulator", Host: listen(t).Addr().String()}
+
+		assert.Equal(t, network, autodetectEmulator(network, emulator, logger))
+	})
+
+	t.Run("No override emulator not running", func(t *testing.T) {
+		network := &config.Network{Name: "emulator", Host: closedHost(t)}
+
+		assert.Equal(t, network, autodetectEmulator(network, closedHost(t), logger))
+	})
+
+	t.Run("Resolve configured network to the running emulator", func(t *testing.T) {
+		// an access API without services, any request reaching it fails as unimplemented
+		emulator := listen(t)
+		server := grpc.NewServer()
+		go func() { _ = server.Serve(emulator) }()
+		t.Cleanup(server.Stop)
+
+		_, state, _ := util.TestMocks(t)
+		state.Networks().AddOrUpdate(config.Network{Name: config.EmulatorNetwork.Name, Host: closedHost(t)})
+		flags := GlobalFlags{Network: config.EmulatorNetwork.Name}
+
+		network, err := resolveNetwork(state, flags, false, emulator.Addr().String(), logger)
+		require.NoError(t, err)
+		assert.Equal(t, emulator.Addr().String(), network.Host)
+
+		gw, err := createGateway(*network)
+		require.NoError(t, err)
+		assert.Equal(t, codes.Unimplemented, status.Code(gw.Ping()))
+
+		// the configured network is kept if selected or autodetect is disabled
+		network, err = resolveNetwork(state, flags, true, emulator.Addr().String(), logger)
+		require.NoError(t, err)
+		assert.NotEqual(t, emulator.Addr().String(), network.Host)
+
+		flags.NoAutodetect = true
+		network, err = resolveNetwork(state, flags, false, emulator.Addr().String(), logger)
+		require.NoError(t, err)
+		assert.NotEqual(t, emulator.Addr().String(), network.Host)
+	})
+
+	t.Run("Same address", func(t *testing.T) {
+		assert.True(t, sameAddress("localhost:3569", "127.0.0.1:3569"))
+		assert.False(t, sameAddress("localhost:3570", "127.0.0.1:3569"))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.True(t, autodetectEnabled(false, false))
+		assert.False(t, autodetectEnabled(true, false))
+		assert.False(t, autodetectEnabled(false, true))
+	})
+}
+
+type echoResult struct {
+	message string
+}
+
+func (r *echoResult) JSON() any        { return r.message }
+func (r *echoResult) String() string   { return r.message }
+func (r *echoResult) Oneliner() string { return r.message }
+
+func Test_Daemon(t *testing.T) {
+	echoFlags := struct {
+		Name     []string `default:"" flag:"name" info:"names to echo"`
+		Greeting string   `default:"hello" flag:"greeting" info:"greeting of the names"`
+	}{}
+	t.Setenv("FLOW_GREETING", "daemon")
+
+	root := &cobra.Command{Use: "flow", TraverseChildren: true}
+	InitFlags(root)
+	Command{
+		Cmd:   &cobra.Command{Use: "echo"},
+		Flags: &echoFlags,
+		Run: func(_ []string, _ GlobalFlags, _ output.Logger, _ flowkit.ReaderWriter, _ flowkit.Services) (Result, error) {
+			if len(echoFlags.Name) == 0 {
+				return nil, fmt.Errorf("no names")
+			}
+			return &echoResult{message: fmt.Sprintf("%s %v %s", echoFlags.Greeting, echoFlags.Name, os.Getenv("ECHO_SUFFIX"))}, nil
+		},
+	}.AddToParent(root)
+
+	socket := DaemonSocket([]string{t.TempDir() + "/flow.json"})
+	daemon, err := NewDaemon(root, socket)
+	require.NoError(t, err)
+	go func() { _ = daemon.Serve() }()
+	t.Cleanup(func() { _ = daemon.Close() })
+
+	delegateEnv := func(env []string, args ...string) (int, string, string) {
+		conn, err := net.Dial("unix", socket)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var stdout, stderr bytes.Buffer
+		args = append(args, "--no-autodetect", "--skip-version-check")
+		code, err := runDelegated(conn, daemonRequest{Args: args, Dir: t.TempDir(), Env: env}, &stdout, &stderr)
+		require.NoError(t, err)
+
+		return code, stdout.String(), stderr.String()
+	}
+	delegate := func(args ...string) (int, string, string) {
+		return delegateEnv(nil, args...)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		code, stdout, _ := delegate("echo", "--name", "alice", "--name", "bob")
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "hello [alice bob]")
+	})
+
+	t.Run("Flags reset between commands", func(t *testing.T) {
+		code, stdout, _ := delegate("echo", "--name", "carol")
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "hello [carol]")
+
+		code, _, stderr := delegate("echo")
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "no names")
+	})
+
+	t.Run("Client environment", func(t *testing.T) {
+		code, stdout, _ := delegateEnv([]string{"FLOW_GREETING=hi", "ECHO_SUFFIX=!"}, "echo", "--name", "alice")
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "hi [alice] !")
+
+		// the environment of the daemon and the previous command isn't used
+		code, stdout, _ = delegate("echo", "--name", "alice")
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "hello [alice] \n")
+		assert.Equal(t, "daemon", os.Getenv("FLOW_GREETING"))
+	})
+
+	t.Run("State loaded for every command", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "flow.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"networks": {"emulator": "127.0.0.1:3569"}}`), 0644))
+		loader := &afero.Afero{Fs: afero.NewOsFs()}
+
+		first, err := daemon.load([]string{path}, loader)
+		require.NoError(t, err)
+		require.NoError(t, first.Networks().Remove("emulator"))
+
+		second, err := daemon.load([]string{path}, loader)
+		require.NoError(t, err)
+		assert.NotSame(t, first, second)
+		_, err = second.Networks().ByName("emulator")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Gateway reused until the credentials change", func(t *testing.T) {
+		t.Setenv("DAEMON_TOKEN", "first")
+		network := config.Network{
+			Name: "authenticated",
+			Host: "127.0.0.1:3569",
+			Auth: &config.NetworkAuth{Token: "${DAEMON_TOKEN}"},
+		}
+
+		first, err := daemon.gateway(network)
+		require.NoError(t, err)
+		reused, err := daemon.gateway(network)
+		require.NoError(t, err)
+		assert.Same(t, first, reused)
+		assert.NotContains(t, gatewayKey(network), "first")
+
+		t.Setenv("DAEMON_TOKEN", "rotated")
+		rotated, err := daemon.gateway(network)
+		require.NoError(t, err)
+		assert.NotSame(t, first, rotated)
+
+		network.Auth = &config.NetworkAuth{APIKey: "${DAEMON_TOKEN}"}
+		changed, err := daemon.gateway(network)
+		require.NoError(t, err)
+		assert.NotSame(t, rotated, changed)
+	})
+
+	t.Run("Fail already running", func(t *testing.T) {
+		_, err := NewDaemon(root, socket)
+		assert.EqualError(t, err, fmt.Sprintf("daemon is already running on %s", socket))
+	})
+}
+
+func Test_Recording(t *testing.T) {
+	rw := &afero.Afero{Fs: afero.NewMemMapFs()}
+	configPaths := []string{"flow.json"}
+
+	t.Run("Fail stop without recording", func(t *testing.T) {
+		_, _, err := StopRecording(configPaths, rw)
+		assert.EqualError(t, err, "commands are not being recorded, start a recording with 'flow record start'")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		require.NoError(t, StartRecording(configPaths, "runbook.json", rw))
+
+		err := StartRecording(configPaths, "other.json", rw)
+		assert.ErrorContains(t, err, "commands are already recorded to")
+
+		cmd := &cobra.Command{Use: "get"}
+		require.NoError(t, recordCommand(cmd, []string{"accounts", "get", "0x01"}, "testnet", rw))
+
+		cmd.Flags().String("network", "", "")
+		require.NoError(t, cmd.Flags().Set("network", "mainnet"))
+		require.NoError(t, recordCommand(cmd, []string{"blocks", "get", "latest", "-n", "mainnet"}, "mainnet", rw))
+
+		runbook, file, err := StopRecording(configPaths, rw)
+		require.NoError(t, err)
+		assert.True(t, filepath.IsAbs(file))
+		assert.Equal(t, []RunbookStep{
+			{Args: []string{"accounts", "get", "0x01", "--network", "testnet"}},
+			{Args: []string{"blocks", "get", "latest", "-n", "mainnet"}},
+		}, runbook.Steps)
+
+		// commands after the recording stopped aren't recorded
+		require.NoError(t, recordCommand(cmd, []string{"status"}, "emulator", rw))
+		saved, err := LoadRunbook(file, rw)
+		require.NoError(t, err)
+		assert.Len(t, saved.Steps, 2)
+	})
+}
+
+func Test_CadenceJSON(t *testing.T) {
+	pointType := &cadence.StructType{
+		QualifiedIdentifier: "Point",
+		Fields: []cadence.Field{
+			{Identifier: "x", Type: cadence.IntType{}},
+			{Identifier: "label", Type: &cadence.OptionalType{Type: cadence.StringType{}}},
+		},
+	}
+	point := cadence.NewStruct([]cadence.Value{
+		cadence.NewInt(1),
+		cadence.NewOptional(nil),
+	}).WithType(pointType)
+
+	balance, _ := cadence.NewUFix64("10.5")
+	value := cadence.NewDictionary([]cadence.KeyValuePair{{
+		Key: cadence.String("alice"),
+		Value: cadence.NewArray([]cadence.Value{
+			point,
+			cadence.NewOptional(balance),
+			cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1}),
+			cadence.Path{Domain: common.PathDomainStorage, Identifier: "vault"},
+		}),
+	}})
+
+	encoded, err := json.Marshal(CadenceJSON(value))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"alice": [{"x": 1, "label": null}, 10.50000000, "0x0000000000000001", "/storage/vault"]}`, string(encoded))
+	assert.Nil(t, CadenceJSON(cadence.NewOptional(nil)))
+}
+
+type testOutput struct {
+	Address string   `json:"address"`
+	Keys    []string `json:"keys,omitempty"`
+}
+
+type testOutputResult struct{}
+
+func (testOutputResult) String() string   { return "" }
+func (testOutputResult) Oneliner() string { return "" }
+func (testOutputResult) JSON() any {
+	return testOutput{Address: "0x01", Keys: []string{"a"}}
+}
+
+func Test_OutputSchema(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		parent := &cobra.Command{Use: "flow"}
+		cmd := Command{Cmd: &cobra.Command{Use: "get"}, Output: testOutput{}}
+		parent.AddCommand(cmd.Cmd)
+
+		schema, err := OutputSchema(cmd)
+		require.NoError(t, err)
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(schema, &decoded))
+		assert.Equal(t, "flow get", decoded["title"])
+		assert.Equal(t, "object", decoded["type"])
+		assert.Equal(t, []any{"address"}, decoded["required"])
+		assert.Equal(t, map[string]any{
+			"address": map[string]any{"type": "string"},
+			"keys":    map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		}, decoded["properties"])
+	})
+
+	t.Run("Fail undescribed output", func(t *testing.T) {
+		_, err := OutputSchema(Command{Cmd: &cobra.Command{Use: "get"}})
+		assert.EqualError(t, err, "the get command doesn't describe its JSON output")
+	})
+
+	t.Run("Filter typed output", func(t *testing.T) {
+		value, err := filterResultValue(testOutputResult{}, "address")
+		require.NoError(t, err)
+		assert.Equal(t, "0x01", value)
+	})
+
+	t.Run("Fail binary format not supported", func(t *testing.T) {
+		_, err := formatResult(testOutputResult{}, "", "cbor")
+		assert.EqualError(t, err, "the cbor output format is not supported by the command, use the json output format")
+	})
+}
+
+// captureStderr returns what the function writes to the standard error.
+func captureStderr(t *testing.T, f func()) string {
+	file, err := os.CreateTemp(t.TempDir(), "stderr")
+	require.NoError(t, err)
+	defer func(original *os.File) { os.Stderr = original }(os.Stderr)
+	os.Stderr = file
+
+	f()
+
+	data, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	return string(data)
+}
+
+func Test_Verbose(t *testing.T) {
+	defer func() { Flags.Verbose = 0 }()
+
+	t.Run("Redact key material", func(t *testing.T) {
+		payload := []byte(`{"accounts":{"alice":{"address":"01","key":"abcd"},"bob":{"address":"02","key":{"type":"hex","index":0,"privateKey":"ef01"}}}}`)
+		assert.JSONEq(
+			t,
+			`{"accounts":{"alice":{"address":"01","key":"<redacted>"},"bob":{"address":"02","key":{"type":"hex","index":0,"privateKey":"<redacted>"}}}}`,
+			redactPayload(payload),
+		)
+	})
+
+	t.Run("Redact credentials", func(t *testing.T) {
+		secrets := []string{"api-secret", "token-secret", "basic-secret", "vault-token", "role-secret", "secret-id"}
+		conf, err := configJson.NewParser().Deserialize([]byte(`{
+			"networks": {
+				"keyed": {"host": "127.0.0.1:3570", "auth": {"header": "x-api-key", "apiKey": "api-secret"}},
+				"bearer": {"host": "127.0.0.1:3571", "auth": {"token": "token-secret"}},
+				"basic": {"host": "127.0.0.1:3572", "auth": {"username": "flow", "password": "basic-secret"}}
+			},
+			"accounts": {
+				"token": {"address": "01", "key": {"type": "vault", "vault": {"address": "https://vault", "key": "flow", "token": "vault-token"}}},
+				"approle": {"address": "02", "key": {"type": "vault", "vault": {"address": "https://vault", "key": "flow", "roleId": "role-secret", "secretId": "secret-id"}}}
+			}
+		}`))
+		require.NoError(t, err)
+		payload, err := configJson.NewParser().Serialize(conf)
+		require.NoError(t, err)
+		for _, secret := range secrets {
+			require.Contains(t, string(payload), secret)
+		}
+
+		redacted := redactPayload(payload)
+		for _, secret := range secrets {
+			assert.NotContains(t, redacted, secret)
+		}
+		assert.Contains(t, redacted, `"username":"flow"`)
+	})
+
+	t.Run("Levels", func(t *testing.T) {
+		gw := mocks.NewGateway(t)
+		gw.On("ExecuteScript", mock.Anything, mock.Anything).Return(cadence.NewInt(1), nil)
+		verbose := &verboseGateway{Gateway: gw}
+		code := []byte("pub fun main(a: Int): Int { return a }")
+		args := []cadence.Value{cadence.NewInt(1)}
+
+		Flags.Verbose = verbosityConfig
+		out := captureStderr(t, func() {
+			_, err := verbose.ExecuteScript(code, args)
+			require.NoError(t, err)
+		})
+		assert.Empty(t, out)
+
+		Flags.Verbose = verbosityRequests
+		out = captureStderr(t, func() {
+			_, err := verbose.ExecuteScript(code, args)
+			require.NoError(t, err)
+		})
+		assert.Contains(t, out, "[request] ExecuteScript script of 38 bytes with 1 arguments succeeded in")
+		assert.NotContains(t, out, "[payload]")
+
+		Flags.Verbose = verbosityPayloads
+		out = captureStderr(t, func() {
+			_, err := verbose.ExecuteScript(code, args)
+			require.NoError(t, err)
+		})
+		assert.Contains(t, out, `[payload] ExecuteScript request {"arguments":[{"type":"Int","value":"1"}],"code":"pub fun main(a: Int): Int { return a }"}`)
+		assert.Contains(t, out, `[payload] ExecuteScript response {"type":"Int","value":"1"}`)
+	})
+
+	t.Run("Resolved configuration", func(t *testing.T) {
+		Flags.Verbose = verbosityConfig
+		out := captureStderr(t, func() {
+			logResolvedConfig(nil, config.ErrDoesNotExist, &config.TestnetNetwork, "")
+		})
+		assert.Contains(t, out, "[config] using network testnet at access.devnet.nodes.onflow.org:9000, resolved from the default networks")
+	})
+}
+
+func Test_OutputFile(t *testing.T) {
+	t.Run("Rotate by size", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "flow.log")
+		file, err := openRotatingFile(path, 10, 0, 2)
+		require.NoError(t, err)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		file.now = func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		}
+
+		for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+			_, err := file.Write([]byte(line))
+			require.NoError(t, err)
+		}
+		require.NoError(t, file.Close())
+
+		current, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "fourth\n", string(current))
+
+		// the oldest rotated file is removed keeping two backups
+		backups, err := filepath.Glob(path + ".*")
+		require.NoError(t, err)
+		require.Len(t, backups, 2)
+		second, err := os.ReadFile(backups[0])
+		require.NoError(t, err)
+		assert.Equal(t, "second\n", string(second))
+		third, err := os.ReadFile(backups[1])
+		require.NoError(t, err)
+		assert.Equal(t, "third\n", string(third))
+	})
+
+	t.Run("Rotate by age", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "flow.log")
+		file, err := openRotatingFile(path, 0, time.Hour, 5)
+		require.NoError(t, err)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		file.now = func() time.Time { return now }
+		file.opened = now
+
+		_, err = file.Write([]byte("first\n"))
+		require.NoError(t, err)
+		_, err = file.Write([]byte("second\n"))
+		require.NoError(t, err)
+
+		now = now.Add(time.Hour)
+		_, err = file.Write([]byte("third\n"))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		current, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "third\n", string(current))
+		rotated, err := os.ReadFile(path + ".20240101-010000.000")
+		require.NoError(t, err)
+		assert.Equal(t, "first\nsecond\n", string(rotated))
+	})
+
+	t.Run("Redirect output", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "flow.log")
+		restore, err := redirectOutput(GlobalFlags{OutputFile: path, OutputFileMaxSize: "1MB", OutputFileMaxBackups: 1})
+		require.NoError(t, err)
+
+		fmt.Println("watching")
+		restore()
+		restore()
+
+		written, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "watching\n", string(written))
+	})
+
+	t.Run("Fail invalid size", func(t *testing.T) {
+		_, err := redirectOutput(GlobalFlags{OutputFile: "flow.log", OutputFileMaxSize: "big"})
+		assert.EqualError(t, err, "invalid output file max size big, provide a size such as 100MB")
+	})
+}
+
+func Test_ConfigDefaults(t *testing.T) {
+	t.Run("Success unset flags", func(t *testing.T) {
+		var signer, output string
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.StringVar(&signer, "signer", "emulator-account", "")
+		flags.StringVar(&output, "output", "text", "")
+		require.NoError(t, flags.Parse([]string{"--output", "inline"}))
+
+		err := applyConfigDefaults(flags, config.Defaults{Signer: "alice", Output: "json"})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", signer)
+		assert.Equal(t, "inline", output)
+	})
+
+	t.Run("Success missing flags", func(t *testing.T) {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		err := applyConfigDefaults(flags, config.Defaults{Signer: "alice"})
+		assert.NoError(t, err)
+	})
+}
+
+func Test_LedgerSigner(t *testing.T) {
+	publicKey, err := crypto.DecodePublicKeyHex(
+		crypto.ECDSA_P256,
+		"c8a2a318b9099cc6c872a0ec3dcd9f59d17837e4ffd6cd8a1f913ddfa769559605e1ad6ad603ebb511f5a6c8125f863abc2e9c600216edaa07104a0fe320dba7",
+	)
+	require.NoError(t, err)
+
+	ledgerFlags := func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.String("signer", "emulator-account", "")
+		flags.StringSlice("authorizer", nil, "")
+		require.NoError(t, flags.Parse(args))
+		return flags
+	}
+
+	indexer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("/key/%x", publicKey.Encode()), r.URL.Path)
+		_, _ = w.Write([]byte(`{"accounts":[
+			{"address":"0x01cf0e2f2f715450","keyId":0,"weight":1000,"isRevoked":true},
+			{"address":"0x01cf0e2f2f715450","keyId":2,"weight":1000,"isRevoked":false}
+		]}`))
+	}))
+	defer indexer.Close()
+
+	urls, readKey := util.KeyIndexerURLs, util.LedgerPublicKey
+	defer func() { util.KeyIndexerURLs, util.LedgerPublicKey = urls, readKey }()
+	util.KeyIndexerURLs = map[string]string{config.TestnetNetwork.Name: indexer.URL}
+	util.LedgerPublicKey = func(_ *accounts.LedgerKey) (crypto.PublicKey, error) {
+		return publicKey, nil
+	}
+	defer util.SetLedgerAccount(nil)
+
+	onChain := func(hashAlgo crypto.HashAlgorithm) *flowsdk.Account {
+		return &flowsdk.Account{
+			Address: flowsdk.HexToAddress("0x01cf0e2f2f715450"),
+			Keys: []*flowsdk.AccountKey{
+				{Index: 0, PublicKey: publicKey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000, Revoked: true},
+				{Index: 2, PublicKey: publicKey, SigAlgo: crypto.ECDSA_P256, HashAlgo: hashAlgo, Weight: 1000},
+			},
+		}
+	}
+
+	t.Run("Success not selected", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		util.SetLedgerAccount(&accounts.Account{Name: util.LedgerSigner})
+
+		err := resolveLedgerSigner(ledgerFlags(), state, srv.Mock)
+		require.NoError(t, err)
+
+		_, err = util.AccountByName(state, util.LedgerSigner)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success discover account", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Network.Return(config.TestnetNetwork)
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(onChain(crypto.SHA2_256), nil)
+
+		err := resolveLedgerSigner(ledgerFlags("--authorizer", "alice,ledger"), state, srv.Mock)
+		require.NoError(t, err)
+
+		account, err := util.AccountByName(state, util.LedgerSigner)
+		require.NoError(t, err)
+		assert.Equal(t, "01cf0e2f2f715450", account.Address.String())
+		assert.Equal(t, 2, account.Key.Index())
+		assert.Equal(t, config.KeyTypeDevice, account.Key.Type())
+		assert.Equal(t, crypto.SHA2_256, account.Key.HashAlgo())
+
+		// the discovered account isn't added to the configuration
+		_, err = state.Accounts().ByName(util.LedgerSigner)
+		assert.Error(t, err)
+	})
+
+	t.Run("Fail on-chain signature algorithm mismatch", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Network.Return(config.TestnetNetwork)
+		account := onChain(crypto.SHA3_256)
+		account.Keys[1].SigAlgo = crypto.ECDSA_secp256k1
+		srv.GetAccount.Run(func(mock.Arguments) {}).Return(account, nil)
+
+		err := resolveLedgerSigner(ledgerFlags("--signer", "ledger"), state, srv.Mock)
+		assert.EqualError(t, err, "key 2 of account 0x01cf0e2f2f715450 uses the ECDSA_secp256k1 signature algorithm, the Ledger key uses ECDSA_P256")
+	})
+
+	t.Run("Fail network without indexer", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		err := resolveLedgerSigner(ledgerFlags("--signer", "ledger"), state, srv.Mock)
+		assert.ErrorContains(t, err, "accounts can't be discovered on the emulator network")
+	})
+}
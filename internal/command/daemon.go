@@ -0,0 +1,633 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// DaemonSocket returns the path of the socket a daemon serving the project listens on.
+func DaemonSocket(configPaths []string) string {
+	return filepath.Join(util.ProjectDir(configPaths), ".flow", "daemon.sock")
+}
+
+// projectResources provides the project state, gateways and build caches commands run with.
+type projectResources interface {
+	load(configPaths []string, loader flowkit.ReaderWriter) (*flowkit.State, error)
+	gateway(network config.Network) (gateway.Gateway, error)
+	buildCache(dir string, loader flowkit.ReaderWriter) *flowkit.BuildCache
+}
+
+// localResources creates new resources for every command, used when commands run in their own process.
+type localResources struct{}
+
+func (localResources) load(configPaths []string, loader flowkit.ReaderWriter) (*flowkit.State, error) {
+	return flowkit.Load(configPaths, loader)
+}
+
+func (localResources) gateway(network config.Network) (gateway.Gateway, error) {
+	return createGateway(network)
+}
+
+func (localResources) buildCache(dir string, loader flowkit.ReaderWriter) *flowkit.BuildCache {
+	return flowkit.NewBuildCache(dir, loader)
+}
+
+var resources projectResources = localResources{}
+
+// serving is set in the daemon process, commands run by the daemon are never delegated again.
+var serving = false
+
+// delegatedArgs are the arguments of the command the daemon runs.
+var delegatedArgs []string
+
+// invocationArgs returns the arguments the running command was invoked with.
+func invocationArgs() []string {
+	if delegatedArgs != nil {
+		return delegatedArgs
+	}
+	return os.Args[1:]
+}
+
+// exit terminates the command, the daemon replaces it to stop only the delegated command.
+var exit = os.Exit
+
+// daemonExit is the panic value used to stop a command running in the daemon with the exit code.
+type daemonExit int
+
+// daemonRequest is a command delegated to the daemon, run in the directory and environment of the client.
+type daemonRequest struct {
+	Args []string `json:"args"`
+	Dir  string   `json:"dir"`
+	Env  []string `json:"env"`
+}
+
+// daemonFrame is a message the daemon streams back, either output of the command or its exit code.
+type daemonFrame struct {
+	Stream string `json:"stream,omitempty"`
+	Data   string `json:"data,omitempty"`
+	Exit   *int   `json:"exit,omitempty"`
+}
+
+const (
+	streamStdout = "stdout"
+	streamStderr = "stderr"
+)
+
+// delegateToDaemon runs the command in the daemon serving the project if one is running
+// and returns true after the delegated command finished successfully.
+func delegateToDaemon() bool {
+	// the output file is managed by the process running the command
+	if serving || Flags.NoDaemon || Flags.OutputFile != "" {
+		return false
+	}
+
+	conn, err := net.DialTimeout("unix", DaemonSocket(Flags.ConfigPaths), time.Second)
+	if err != nil {
+		return false // no daemon is running, run the command in this process
+	}
+	defer conn.Close()
+
+	dir, err := os.Getwd()
+	handleError("Daemon Error", err)
+
+	code, err := runDelegated(conn, daemonRequest{Args: os.Args[1:], Dir: dir, Env: os.Environ()}, os.Stdout, os.Stderr)
+	handleError("Daemon Error", err)
+	if code != 0 {
+		exit(code)
+	}
+
+	return true
+}
+
+// runDelegated sends the request to the daemon and copies the command output until it exits.
+func runDelegated(conn net.Conn, req daemonRequest, stdout io.Writer, stderr io.Writer) (int, error) {
+	err := json.NewEncoder(conn).Encode(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send the command to the daemon: %w", err)
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(conn))
+	for {
+		var frame daemonFrame
+		err := decoder.Decode(&frame)
+		if err != nil {
+			return 0, fmt.Errorf("daemon closed the connection before the command finished: %w", err)
+		}
+
+		if frame.Exit != nil {
+			return *frame.Exit, nil
+		}
+
+		switch frame.Stream {
+		case streamStdout:
+			_, _ = io.WriteString(stdout, frame.Data)
+		case streamStderr:
+			_, _ = io.WriteString(stderr, frame.Data)
+		}
+	}
+}
+
+// Daemon serves commands delegated by other invocations of the CLI in the same project.
+//
+// The daemon keeps the configuration files, the gateways with their connections and the
+// build caches in memory, so delegated commands skip reading the configuration and dialing
+// the access nodes. Connections are accepted concurrently but commands run one at a time,
+// since they share the flags, working directory, environment and standard streams of the process.
+// Every command gets its own state parsed from the cached files, which are read again when they change.
+type Daemon struct {
+	root     *cobra.Command
+	listener net.Listener
+	socket   string
+
+	mu       sync.Mutex
+	closed   bool
+	files    map[string]cachedFile
+	gateways map[string]gateway.Gateway
+	caches   map[string]*flowkit.BuildCache
+	defaults map[*pflag.Flag]string
+	environ  []string
+}
+
+type cachedFile struct {
+	data    []byte
+	version string
+}
+
+// NewDaemon creates a daemon running commands of the root command, listening on the socket.
+func NewDaemon(root *cobra.Command, socket string) (*Daemon, error) {
+	conn, err := net.DialTimeout("unix", socket, time.Second)
+	if err == nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("daemon is already running on %s", socket)
+	}
+	_ = os.Remove(socket) // left behind by a daemon that didn't shut down
+
+	err = os.MkdirAll(filepath.Dir(socket), 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socket, err)
+	}
+
+	d := &Daemon{
+		root:     root,
+		listener: listener,
+		socket:   socket,
+		files:    make(map[string]cachedFile),
+		gateways: make(map[string]gateway.Gateway),
+		caches:   make(map[string]*flowkit.BuildCache),
+		defaults: make(map[*pflag.Flag]string),
+		environ:  os.Environ(),
+	}
+	d.resetFlags()
+
+	serving = true
+	resources = d
+	exit = func(code int) {
+		panic(daemonExit(code))
+	}
+
+	return d, nil
+}
+
+// Serve accepts delegated commands until the daemon is closed.
+func (d *Daemon) Serve() error {
+	for {
+		conn, err := d.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		go d.handle(conn)
+	}
+}
+
+// Close stops accepting commands, waits for the running command and removes the socket.
+func (d *Daemon) Close() error {
+	err := d.listener.Close()
+	_ = os.Remove(d.socket)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.closed = true
+	serving = false
+	resources = localResources{}
+	exit = os.Exit
+
+	return err
+}
+
+func (d *Daemon) handle(conn net.Conn) {
+	defer conn.Close()
+
+	var req daemonRequest
+	err := json.NewDecoder(conn).Decode(&req)
+	if err != nil {
+		return
+	}
+
+	frames := &frameWriter{encoder: json.NewEncoder(conn)}
+	code := d.execute(req, frames)
+	_ = frames.write(daemonFrame{Exit: &code})
+}
+
+// execute runs the requested command with the output streamed to the client and returns the exit code.
+func (d *Daemon) execute(req daemonRequest, frames *frameWriter) (code int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return d.fail(frames, fmt.Errorf("daemon stopped"))
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return d.fail(frames, err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	err = os.Chdir(req.Dir)
+	if err != nil {
+		return d.fail(frames, err)
+	}
+
+	restoreEnv := setEnvironment(req.Env)
+	defer restoreEnv()
+
+	restore, err := redirectStreams(frames)
+	if err != nil {
+		return d.fail(frames, err)
+	}
+	defer restore()
+
+	defer func() {
+		if r := recover(); r != nil {
+			if c, ok := r.(daemonExit); ok {
+				code = int(c)
+				return
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "command panicked: %v\n", r)
+			code = 1
+		}
+	}()
+
+	d.resetFlags()
+	d.applyFlagEnvironment()
+	delegatedArgs = req.Args
+	defer func() { delegatedArgs = nil }()
+	d.root.SetArgs(req.Args)
+	err = d.root.Execute()
+	if err != nil {
+		return 1 // cobra already printed the error
+	}
+
+	return 0
+}
+
+func (d *Daemon) fail(frames *frameWriter, err error) int {
+	_ = frames.write(daemonFrame{Stream: streamStderr, Data: err.Error() + "\n"})
+	return 1
+}
+
+// resetFlags sets flags of all commands back to their values before any command was delegated,
+// so values provided to a previous command don't leak to the next one.
+func (d *Daemon) resetFlags() {
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		reset := func(f *pflag.Flag) {
+			def, ok := d.defaults[f]
+			if !ok {
+				def = f.Value.String()
+				if f.Changed {
+					def = f.DefValue // set when starting the daemon, not a default
+				}
+				d.defaults[f] = def
+
+				if slice, ok := f.Value.(pflag.SliceValue); ok {
+					f.Value = &resettableSlice{Value: f.Value, slice: slice}
+				}
+			}
+
+			setFlagDefault(f, def)
+		}
+
+		cmd.PersistentFlags().VisitAll(reset)
+		cmd.Flags().VisitAll(reset)
+		for _, sub := range cmd.Commands() {
+			visit(sub)
+		}
+	}
+
+	visit(d.root)
+}
+
+// applyFlagEnvironment sets the defaults of flags bound to environment variables from the environment
+// of the delegated command, the defaults of flags the daemon environment set are reverted when the
+// client didn't set the variable.
+func (d *Daemon) applyFlagEnvironment() {
+	daemonEnv := make(map[string]bool, len(d.environ))
+	for _, variable := range d.environ {
+		name, _, _ := strings.Cut(variable, "=")
+		daemonEnv[name] = true
+	}
+
+	visitEnvironmentFlags(func(flag *pflag.Flag, variable string, def string) {
+		if value, ok := os.LookupEnv(variable); ok {
+			setFlagDefault(flag, value)
+		} else if daemonEnv[variable] {
+			setFlagDefault(flag, def)
+		}
+	})
+}
+
+// setFlagDefault sets the value of the flag as if it wasn't provided.
+func setFlagDefault(f *pflag.Flag, def string) {
+	if slice, ok := f.Value.(*resettableSlice); ok {
+		slice.reset(def)
+	} else {
+		_ = f.Value.Set(def)
+	}
+	f.Changed = false
+}
+
+// setEnvironment replaces the environment of the process with the environment of a delegated command
+// and returns a function restoring the previous environment.
+func setEnvironment(env []string) func() {
+	previous := os.Environ()
+	replace := func(env []string) {
+		os.Clearenv()
+		for _, variable := range env {
+			if name, value, ok := strings.Cut(variable, "="); ok && name != "" {
+				_ = os.Setenv(name, value)
+			}
+		}
+	}
+
+	replace(env)
+	return func() { replace(previous) }
+}
+
+// resettableSlice wraps a slice flag value so the first value provided after a reset
+// replaces the default instead of being appended to the values of the previous command.
+type resettableSlice struct {
+	pflag.Value
+	slice pflag.SliceValue
+	set   bool
+}
+
+func (s *resettableSlice) Set(value string) error {
+	if !s.set {
+		s.set = true
+		err := s.slice.Replace(nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.Value.Set(value)
+}
+
+func (s *resettableSlice) reset(def string) {
+	s.set = false
+
+	var values []string
+	if trimmed := strings.Trim(def, "[]"); trimmed != "" {
+		values = strings.Split(trimmed, ",")
+	}
+	_ = s.slice.Replace(values)
+}
+
+// load parses a new state for every command, since commands change the state in memory,
+// only the contents of the configuration files are reused.
+func (d *Daemon) load(configPaths []string, loader flowkit.ReaderWriter) (*flowkit.State, error) {
+	if fs, ok := loader.(*afero.Afero); ok {
+		loader = &cachedReader{Afero: fs, daemon: d}
+	}
+	return flowkit.Load(configPaths, loader)
+}
+
+// cachedReader reads files through the file cache of the daemon, other file operations use the file system.
+type cachedReader struct {
+	*afero.Afero
+	daemon *Daemon
+}
+
+func (r *cachedReader) ReadFile(source string) ([]byte, error) {
+	path, err := filepath.Abs(source)
+	if err != nil {
+		return r.Afero.ReadFile(source)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return r.Afero.ReadFile(source)
+	}
+	version := fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size())
+
+	if cached, ok := r.daemon.files[path]; ok && cached.version == version {
+		return cached.data, nil
+	}
+
+	data, err := r.Afero.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+
+	r.daemon.files[path] = cachedFile{data: data, version: version}
+	return data, nil
+}
+
+// proxyEnvironment are the variables used when dialing the network, gateways are only reused by commands
+// with the same values.
+var proxyEnvironment = []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy"}
+
+// gatewayKey returns the key of the gateways dialing the network with the same host, credentials and proxy.
+//
+// The authentication headers are expanded from the environment of the command, so gateways aren't reused
+// once the credentials change, and only their hash is kept in memory.
+func gatewayKey(network config.Network) string {
+	key := fmt.Sprintf("%s|%s|%s", network.Name, network.Host, network.Key)
+	for _, variable := range proxyEnvironment {
+		key += "|" + os.Getenv(variable)
+	}
+
+	if network.Auth != nil {
+		headers, err := network.Auth.Headers()
+		if err != nil {
+			return key // invalid auth fails creating the gateway
+		}
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		hash := sha256.New()
+		for _, name := range names {
+			_, _ = fmt.Fprintf(hash, "%s:%s\n", name, headers[name])
+		}
+		key += "|" + hex.EncodeToString(hash.Sum(nil))
+	}
+
+	return key
+}
+
+func (d *Daemon) gateway(network config.Network) (gateway.Gateway, error) {
+	key := gatewayKey(network)
+	if gw, ok := d.gateways[key]; ok {
+		return gw, nil
+	}
+
+	gw, err := createGateway(network)
+	if err != nil {
+		return nil, err
+	}
+
+	d.gateways[key] = gw
+	return gw, nil
+}
+
+func (d *Daemon) buildCache(dir string, loader flowkit.ReaderWriter) *flowkit.BuildCache {
+	dir, _ = filepath.Abs(dir)
+	if cache, ok := d.caches[dir]; ok {
+		return cache
+	}
+
+	cache := flowkit.NewBuildCache(dir, loader)
+	d.caches[dir] = cache
+	return cache
+}
+
+// frameWriter sends frames to the client, output of both streams is written concurrently.
+type frameWriter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func (w *frameWriter) write(frame daemonFrame) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.encoder.Encode(frame)
+}
+
+// redirectStreams replaces the standard streams of the process while a delegated command runs,
+// the output is streamed to the client and the command can't read from the daemon terminal.
+func redirectStreams(frames *frameWriter) (func(), error) {
+	stdin, stdout, stderr := os.Stdin, os.Stdout, os.Stderr
+
+	inReader, inWriter, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	_ = inWriter.Close() // commands read an empty input
+
+	var wg sync.WaitGroup
+	pipe := func(stream string) (*os.File, error) {
+		reader, writer, err := os.Pipe()
+		if err != nil {
+			return nil, err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer reader.Close()
+
+			buf := make([]byte, 4096)
+			for {
+				n, err := reader.Read(buf)
+				if n > 0 {
+					_ = frames.write(daemonFrame{Stream: stream, Data: string(buf[:n])})
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+
+		return writer, nil
+	}
+
+	outWriter, err := pipe(streamStdout)
+	if err != nil {
+		return nil, err
+	}
+	errWriter, err := pipe(streamStderr)
+	if err != nil {
+		_ = outWriter.Close()
+		return nil, err
+	}
+
+	os.Stdin, os.Stdout, os.Stderr = inReader, outWriter, errWriter
+
+	return func() {
+		os.Stdin, os.Stdout, os.Stderr = stdin, stdout, stderr
+		_ = inReader.Close()
+		_ = outWriter.Close()
+		_ = errWriter.Close()
+		wg.Wait()
+	}, nil
+}
+
+// Warm loads the project state and connects to the network before the first command is delegated.
+func (d *Daemon) Warm(configPaths []string, loader flowkit.ReaderWriter, network config.Network) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, err := d.load(configPaths, loader)
+	if err != nil && !errors.Is(err, config.ErrDoesNotExist) {
+		return err
+	}
+
+	gw, err := d.gateway(network)
+	if err != nil {
+		return err
+	}
+
+	return gw.Ping()
+}
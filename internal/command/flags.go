@@ -21,9 +21,12 @@ package command
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
 
 	"github.com/psiemens/sconfig"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/internal/util"
@@ -31,16 +34,29 @@ import (
 
 // Flags initialized to default values.
 var Flags = GlobalFlags{
-	Filter:           "",
-	Format:           formatText,
-	Save:             "",
-	Host:             "",
-	HostNetworkKey:   "",
-	Network:          config.EmulatorNetwork.Name,
-	Log:              logLevelInfo,
-	Yes:              false,
-	ConfigPaths:      config.DefaultPaths(),
-	SkipVersionCheck: false,
+	Filter:               "",
+	Format:               formatText,
+	Save:                 "",
+	Host:                 "",
+	HostNetworkKey:       "",
+	Network:              config.EmulatorNetwork.Name,
+	Log:                  logLevelInfo,
+	Yes:                  false,
+	ConfigPaths:          config.DefaultPaths(),
+	SkipVersionCheck:     false,
+	NoAutodetect:         false,
+	NoBuildCache:         false,
+	Resolve:              []string{},
+	IgnoreImport:         []string{},
+	PasswordFile:         "",
+	NoDaemon:             false,
+	Schema:               false,
+	Verbose:              0,
+	IncludeLinks:         false,
+	OutputFile:           "",
+	OutputFileMaxSize:    "",
+	OutputFileMaxAge:     "",
+	OutputFileMaxBackups: 5,
 }
 
 // InitFlags init all the global persistent flags.
@@ -74,7 +90,7 @@ func InitFlags(cmd *cobra.Command) {
 		"output",
 		"o",
 		Flags.Format,
-		"Output format, options: \"text\", \"json\", \"inline\"",
+		"Output format, options: \"text\", \"json\", \"inline\", and \"cbor\" or \"proto\" for commands exporting events or listing transactions",
 	)
 
 	cmd.PersistentFlags().StringVarP(
@@ -85,6 +101,38 @@ func InitFlags(cmd *cobra.Command) {
 		"Save result to a filename",
 	)
 
+	cmd.PersistentFlags().StringVarP(
+		&Flags.OutputFile,
+		"output-file",
+		"",
+		Flags.OutputFile,
+		"Write the output of the command to a file, for long running commands like dev and watch",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.OutputFileMaxSize,
+		"output-file-max-size",
+		"",
+		Flags.OutputFileMaxSize,
+		"Rotate the output file when it reaches the size (e.g. 100MB)",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.OutputFileMaxAge,
+		"output-file-max-age",
+		"",
+		Flags.OutputFileMaxAge,
+		"Rotate the output file when it's older than the duration (e.g. 24h)",
+	)
+
+	cmd.PersistentFlags().IntVarP(
+		&Flags.OutputFileMaxBackups,
+		"output-file-max-backups",
+		"",
+		Flags.OutputFileMaxBackups,
+		"Number of rotated output files kept, older files are removed",
+	)
+
 	cmd.PersistentFlags().StringVarP(
 		&Flags.Log,
 		"log",
@@ -93,6 +141,13 @@ func InitFlags(cmd *cobra.Command) {
 		"Log level, options: \"debug\", \"info\", \"error\", \"none\"",
 	)
 
+	cmd.PersistentFlags().CountVarP(
+		&Flags.Verbose,
+		"verbose",
+		"v",
+		"Verbose output, -v shows the resolved configuration and network endpoints, -vv adds access node request summaries and -vvv full payloads with key material redacted",
+	)
+
 	cmd.PersistentFlags().StringSliceVarP(
 		&Flags.ConfigPaths,
 		"config-path",
@@ -124,6 +179,70 @@ func InitFlags(cmd *cobra.Command) {
 		Flags.SkipVersionCheck,
 		"Skip version check during start up",
 	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.NoAutodetect,
+		"no-autodetect",
+		"",
+		Flags.NoAutodetect,
+		"Disable using a locally running emulator when no host or network is provided",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.NoBuildCache,
+		"no-build-cache",
+		"",
+		Flags.NoBuildCache,
+		"Disable caching contracts with resolved imports in the project build directory",
+	)
+
+	cmd.PersistentFlags().StringSliceVarP(
+		&Flags.Resolve,
+		"resolve",
+		"",
+		Flags.Resolve,
+		"Resolve an import to a fixed address instead of the configured one, as name=address, e.g. --resolve Foo=0xabc",
+	)
+
+	cmd.PersistentFlags().StringSliceVarP(
+		&Flags.IgnoreImport,
+		"ignore-import",
+		"",
+		Flags.IgnoreImport,
+		"Leave an import untouched instead of resolving it, by the contract name or the imported file path",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.PasswordFile,
+		"password-file",
+		"",
+		Flags.PasswordFile,
+		"File with the passphrase of keystore keys, the FLOW_KEYSTORE_PASSWORD environment variable is used if not provided",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.NoDaemon,
+		"no-daemon",
+		"",
+		Flags.NoDaemon,
+		"Run the command in this process even if a daemon serves the project",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.IncludeLinks,
+		"include-links",
+		"",
+		Flags.IncludeLinks,
+		"Include block explorer links of transactions, accounts and blocks in JSON and inline output",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.Schema,
+		"schema",
+		"",
+		Flags.Schema,
+		"Print the JSON schema of the command output instead of running it",
+	)
 }
 
 // bindFlags bind all the flags needed.
@@ -135,4 +254,66 @@ func bindFlags(command Command) {
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 	}
+
+	environmentFlags = append(environmentFlags, boundFlags{spec: command.Flags, flags: command.Cmd.PersistentFlags()})
+}
+
+// boundFlags are the flags of a command bound to the fields of its flags struct.
+type boundFlags struct {
+	spec  any
+	flags *pflag.FlagSet
+}
+
+// environmentFlags are the flags of all commands, which take their defaults from environment variables.
+var environmentFlags []boundFlags
+
+// flagEnvironmentVariable returns the environment variable setting the default of the flag bound to the field,
+// it matches the variable the flags are bound to by bindFlags.
+func flagEnvironmentVariable(field reflect.StructField) string {
+	return strings.ToUpper(util.EnvPrefix + "_" + field.Name)
+}
+
+// visitEnvironmentFlags calls visit for every flag bound to an environment variable with the variable
+// and the default of the flag when the variable isn't set.
+func visitEnvironmentFlags(visit func(flag *pflag.Flag, variable string, def string)) {
+	for _, bound := range environmentFlags {
+		spec := reflect.TypeOf(bound.spec)
+		if spec == nil || spec.Kind() != reflect.Ptr || spec.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		for i := 0; i < spec.Elem().NumField(); i++ {
+			field := spec.Elem().Field(i)
+			name, ok := field.Tag.Lookup("flag")
+			if !ok {
+				continue
+			}
+			if flag := bound.flags.Lookup(name); flag != nil {
+				visit(flag, flagEnvironmentVariable(field), field.Tag.Get("default"))
+			}
+		}
+	}
+}
+
+// configDefaultFlags maps the names of flags to the configuration defaults used when they are not provided.
+var configDefaultFlags = map[string]func(config.Defaults) string{
+	"signer": func(d config.Defaults) string { return d.Signer },
+	"output": func(d config.Defaults) string { return d.Output },
+}
+
+// applyConfigDefaults sets the flags the user didn't provide to the defaults of the configuration.
+func applyConfigDefaults(flags *pflag.FlagSet, defaults config.Defaults) error {
+	for name, value := range configDefaultFlags {
+		flag := flags.Lookup(name)
+		if flag == nil || flag.Changed || value(defaults) == "" {
+			continue
+		}
+
+		err := flags.Set(name, value(defaults))
+		if err != nil {
+			return fmt.Errorf("invalid default %s %s in the configuration: %w", name, value(defaults), err)
+		}
+	}
+
+	return nil
 }
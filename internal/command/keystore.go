@@ -0,0 +1,45 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// applyKeystorePassword sets the passphrase in the password file on the keystore keys without a configured password file.
+func applyKeystorePassword(state *flowkit.State, passwordFile string) error {
+	if passwordFile == "" {
+		return nil
+	}
+
+	password, err := util.ReadPasswordFile(state.ReaderWriter(), passwordFile)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range *state.Accounts() {
+		if key, ok := account.Key.(*accounts.KeystoreKey); ok && key.PasswordFile() == "" {
+			key.SetPassword(password)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// signerFlags are the flags naming accounts that sign transactions.
+var signerFlags = []string{"signer", "proposer", "payer", "authorizer"}
+
+// resolveLedgerSigner resolves the account of the connected Ledger device for the command
+// when a signer flag names the ledger signer and no configured account has that name.
+// The account isn't added to the state so commands saving the configuration don't persist it.
+func resolveLedgerSigner(flags *pflag.FlagSet, state *flowkit.State, flow flowkit.Services) error {
+	util.SetLedgerAccount(nil)
+	if !ledgerSignerSelected(flags) {
+		return nil
+	}
+	if _, err := state.Accounts().ByName(util.LedgerSigner); err == nil {
+		return nil
+	}
+
+	account, err := util.DiscoverLedgerAccount(flow)
+	if err != nil {
+		return err
+	}
+
+	util.SetLedgerAccount(account)
+	return nil
+}
+
+func ledgerSignerSelected(flags *pflag.FlagSet) bool {
+	for _, name := range signerFlags {
+		flag := flags.Lookup(name)
+		if flag == nil {
+			continue
+		}
+
+		values := []string{flag.Value.String()}
+		if flag.Value.Type() == "stringSlice" {
+			values, _ = flags.GetStringSlice(name)
+		}
+
+		for _, value := range values {
+			if value == util.LedgerSigner {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,198 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// rotatedTimeFormat is the suffix of rotated output files, it sorts in the order the files were rotated.
+const rotatedTimeFormat = "20060102-150405.000"
+
+// rotatingFile is an output file rotated when it reaches the maximum size or age,
+// keeping the most recent rotated files up to the maximum number of backups.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	file       *os.File
+	size       int64
+	opened     time.Time
+	now        func() time.Time
+}
+
+// openRotatingFile opens the output file appending to it, a zero maximum size or age disables the rotation by it.
+func openRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+		now:        time.Now,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.opened = f.now()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sizeExceeded := f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize
+	ageExceeded := f.maxAge > 0 && f.now().Sub(f.opened) >= f.maxAge
+	if sizeExceeded || ageExceeded {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate renames the output file with the rotation time, opens a new output file and removes
+// the rotated files above the maximum number of backups.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	rotated := fmt.Sprintf("%s.%s", f.path, f.now().Format(rotatedTimeFormat))
+	if err := os.Rename(f.path, rotated); err != nil {
+		return fmt.Errorf("failed to rotate output file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	return f.removeBackups()
+}
+
+func (f *rotatingFile) removeBackups() error {
+	backups, err := filepath.Glob(fmt.Sprintf("%s.*", f.path))
+	if err != nil {
+		return err
+	}
+	if len(backups) <= f.maxBackups {
+		return nil
+	}
+
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-f.maxBackups] {
+		if err := os.Remove(backup); err != nil {
+			return fmt.Errorf("failed to remove rotated output file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// redirectOutput writes everything printed to the standard output to the rotating output file
+// configured by the flags, the returned function restores the standard output.
+func redirectOutput(flags GlobalFlags) (func(), error) {
+	var maxSize uint64
+	if flags.OutputFileMaxSize != "" {
+		var err error
+		maxSize, err = humanize.ParseBytes(flags.OutputFileMaxSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output file max size %s, provide a size such as 100MB", flags.OutputFileMaxSize)
+		}
+	}
+
+	var maxAge time.Duration
+	if flags.OutputFileMaxAge != "" {
+		var err error
+		maxAge, err = time.ParseDuration(flags.OutputFileMaxAge)
+		if err != nil || maxAge < 0 {
+			return nil, fmt.Errorf("invalid output file max age %s, provide a duration such as 24h", flags.OutputFileMaxAge)
+		}
+	}
+
+	if flags.OutputFileMaxBackups < 0 {
+		return nil, fmt.Errorf("output file max backups can't be negative")
+	}
+
+	file, err := openRotatingFile(flags.OutputFile, int64(maxSize), maxAge, flags.OutputFileMaxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	stdout := os.Stdout
+	os.Stdout = writer
+
+	copied := make(chan struct{})
+	go func() {
+		defer close(copied)
+		_, _ = io.Copy(file, reader)
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			os.Stdout = stdout
+			_ = writer.Close()
+			<-copied
+			_ = reader.Close()
+			_ = file.Close()
+		})
+	}, nil
+}
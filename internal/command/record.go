@@ -0,0 +1,151 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// Runbook is a sequence of recorded commands that can be replayed.
+type Runbook struct {
+	Steps []RunbookStep `json:"steps"`
+}
+
+// RunbookStep is a recorded command with the arguments it was invoked with.
+type RunbookStep struct {
+	Args []string `json:"args"`
+}
+
+// LoadRunbook reads the runbook from the file.
+func LoadRunbook(file string, readerWriter flowkit.ReaderWriter) (*Runbook, error) {
+	data, err := readerWriter.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read runbook: %w", err)
+	}
+
+	var runbook Runbook
+	err = json.Unmarshal(data, &runbook)
+	if err != nil {
+		return nil, fmt.Errorf("invalid runbook %s: %w", file, err)
+	}
+
+	return &runbook, nil
+}
+
+// Save writes the runbook to the file.
+func (r *Runbook) Save(file string, readerWriter flowkit.ReaderWriter) error {
+	data, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return readerWriter.WriteFile(file, data, 0644)
+}
+
+// recordingFile returns the file storing the runbook path while commands of the project are recorded.
+func recordingFile(configPaths []string) string {
+	return filepath.Join(util.ProjectDir(configPaths), ".flow", "recording")
+}
+
+// Recording returns the runbook commands of the project are recorded to, or an empty string if none are.
+func Recording(configPaths []string, readerWriter flowkit.ReaderWriter) string {
+	data, err := readerWriter.ReadFile(recordingFile(configPaths))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// StartRecording records all following commands of the project to a new runbook in the file.
+func StartRecording(configPaths []string, file string, readerWriter flowkit.ReaderWriter) error {
+	if current := Recording(configPaths, readerWriter); current != "" {
+		return fmt.Errorf("commands are already recorded to %s, stop the recording first", current)
+	}
+
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+
+	err = (&Runbook{Steps: []RunbookStep{}}).Save(file, readerWriter)
+	if err != nil {
+		return err
+	}
+
+	recording := recordingFile(configPaths)
+	if err := flowkit.MkdirAll(readerWriter, filepath.Dir(recording)); err != nil {
+		return err
+	}
+
+	return readerWriter.WriteFile(recording, []byte(file), 0644)
+}
+
+// StopRecording stops recording commands of the project and returns the recorded runbook and its file.
+func StopRecording(configPaths []string, readerWriter flowkit.ReaderWriter) (*Runbook, string, error) {
+	file := Recording(configPaths, readerWriter)
+	if file == "" {
+		return nil, "", fmt.Errorf("commands are not being recorded, start a recording with 'flow record start'")
+	}
+
+	// an empty recording file means no commands are recorded
+	err := readerWriter.WriteFile(recordingFile(configPaths), nil, 0644)
+	if err != nil {
+		return nil, "", err
+	}
+
+	runbook, err := LoadRunbook(file, readerWriter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return runbook, file, nil
+}
+
+// recordCommand appends the successfully executed command to the runbook if a recording is active.
+//
+// The network the command used is resolved when it's recorded, so the runbook replays
+// on the same network even if the default network changes.
+func recordCommand(cmd *cobra.Command, args []string, network string, readerWriter flowkit.ReaderWriter) error {
+	file := Recording(Flags.ConfigPaths, readerWriter)
+	if file == "" {
+		return nil
+	}
+
+	runbook, err := LoadRunbook(file, readerWriter)
+	if err != nil {
+		return err
+	}
+
+	step := append([]string{}, args...)
+	if !cmd.Flags().Changed("network") && !cmd.Flags().Changed("host") {
+		step = append(step, "--network", network)
+	}
+	runbook.Steps = append(runbook.Steps, RunbookStep{Args: step})
+
+	return runbook.Save(file, readerWriter)
+}
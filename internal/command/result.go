@@ -43,6 +43,46 @@ type Result interface {
 	JSON() any
 }
 
+// BinaryResult is implemented by results of commands exporting many values, which can be encoded
+// in the compact binary output formats.
+type BinaryResult interface {
+	// Binary encodes the result in the cbor or proto output format.
+	Binary(format string) ([]byte, error)
+}
+
+// ExplorerLink links the entities of a result, such as a transaction, to the block explorer of the network.
+//
+// Results print the links in text output, JSON and inline output only include them if Include is set
+// by the include-links flag, so the output parsed by scripts doesn't change.
+type ExplorerLink struct {
+	Network config.Network
+	Include bool
+}
+
+// NewExplorerLink returns the explorer link of results from the network.
+func NewExplorerLink(network config.Network, globalFlags GlobalFlags) ExplorerLink {
+	return ExplorerLink{Network: network, Include: globalFlags.IncludeLinks}
+}
+
+// URL returns the link to the entity on the explorer, or an empty string if the network has no explorer.
+func (l ExplorerLink) URL(linkType config.ExplorerLinkType, id string) string {
+	return l.Network.ExplorerURL(linkType, id)
+}
+
+// Included returns the link to the entity if it's included in JSON and inline output, or an empty string.
+func (l ExplorerLink) Included(linkType config.ExplorerLinkType, id string) string {
+	if !l.Include {
+		return ""
+	}
+	return l.URL(linkType, id)
+}
+
+// isBinaryFormat returns whether the output format is a binary format.
+func isBinaryFormat(formatFlag string) bool {
+	format := strings.ToLower(formatFlag)
+	return format == formatCBOR || format == formatProto
+}
+
 // ContainsFlag checks if output flag is present for the provided field.
 func ContainsFlag(flags []string, field string) bool {
 	for _, n := range flags {
@@ -70,6 +110,16 @@ func formatResult(result Result, filterFlag string, formatFlag string) (string,
 	}
 
 	switch strings.ToLower(formatFlag) {
+	case formatCBOR, formatProto:
+		binary, ok := result.(BinaryResult)
+		if !ok {
+			return "", fmt.Errorf("the %s output format is not supported by the command, use the json output format", formatFlag)
+		}
+		encoded, err := binary.Binary(strings.ToLower(formatFlag))
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
 	case formatJSON:
 		jsonRes, _ := json.Marshal(result.JSON())
 		return string(jsonRes), nil
@@ -80,6 +130,23 @@ func formatResult(result Result, filterFlag string, formatFlag string) (string,
 	}
 }
 
+// PrintResult formats and outputs the result like it's done when a command finishes, for commands
+// printing multiple results while they run. JSON results are printed one per line so the output
+// can be piped as JSON lines.
+func PrintResult(result Result, globalFlags GlobalFlags) error {
+	formattedResult, err := formatResult(result, globalFlags.Filter, globalFlags.Format)
+	if err != nil {
+		return err
+	}
+
+	if strings.ToLower(globalFlags.Format) == formatJSON && globalFlags.Save == "" && globalFlags.Filter == "" {
+		_, _ = fmt.Fprintln(os.Stdout, formattedResult)
+		return nil
+	}
+
+	return outputResult(formattedResult, globalFlags.Save, globalFlags.Format, globalFlags.Filter)
+}
+
 // outputResult to selected media.
 func outputResult(result string, saveFlag string, formatFlag string, filterFlag string) error {
 	if saveFlag != "" {
@@ -91,7 +158,7 @@ func outputResult(result string, saveFlag string, formatFlag string, filterFlag
 		return af.WriteFile(saveFlag, []byte(result), 0644)
 	}
 
-	if formatFlag == formatInline || filterFlag != "" {
+	if formatFlag == formatInline || isBinaryFormat(formatFlag) || filterFlag != "" {
 		_, _ = fmt.Fprintf(os.Stdout, "%s", result)
 	} else { // default normal output
 		_, _ = fmt.Fprintf(os.Stdout, "\n%s\n\n", result)
@@ -103,7 +170,11 @@ func outputResult(result string, saveFlag string, formatFlag string, filterFlag
 func filterResultValue(result Result, filter string) (any, error) {
 	res, ok := result.JSON().(map[string]any)
 	if !ok {
-		return "", fmt.Errorf("not possible to filter by the value")
+		// typed outputs are filtered by their encoded JSON property names
+		encoded, err := json.Marshal(result.JSON())
+		if err != nil || json.Unmarshal(encoded, &res) != nil {
+			return "", fmt.Errorf("not possible to filter by the value")
+		}
 	}
 
 	value := res[filter]
@@ -160,5 +231,5 @@ func handleError(description string, err error) {
 	}
 
 	fmt.Println()
-	os.Exit(1)
+	exit(1)
 }
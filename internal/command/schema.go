@@ -0,0 +1,52 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/invopop/jsonschema"
+)
+
+// OutputSchema returns the JSON schema of the command JSON output generated from the command output type.
+func OutputSchema(c Command) ([]byte, error) {
+	if c.Output == nil {
+		return nil, fmt.Errorf("the %s command doesn't describe its JSON output", c.Cmd.Name())
+	}
+
+	reflector := jsonschema.Reflector{
+		DoNotReference: true,
+	}
+	schema := reflector.Reflect(c.Output)
+	schema.Title = c.Cmd.CommandPath()
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func printSchema(c Command) error {
+	schema, err := OutputSchema(c)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "%s\n", schema)
+	return nil
+}
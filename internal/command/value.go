@@ -0,0 +1,108 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"encoding/json"
+
+	"github.com/onflow/cadence"
+)
+
+// CadenceJSON converts the Cadence value to a plain JSON value, composites are converted to objects
+// keyed by field names, optionals to their value or null and numbers keep their full precision.
+func CadenceJSON(value cadence.Value) any {
+	switch v := value.(type) {
+	case nil, cadence.Void:
+		return nil
+	case cadence.Optional:
+		return CadenceJSON(v.Value)
+	case cadence.Bool:
+		return bool(v)
+	case cadence.String:
+		return string(v)
+	case cadence.Character:
+		return string(v)
+	case cadence.Address:
+		return v.String()
+	case cadence.Int, cadence.Int8, cadence.Int16, cadence.Int32, cadence.Int64, cadence.Int128, cadence.Int256,
+		cadence.UInt, cadence.UInt8, cadence.UInt16, cadence.UInt32, cadence.UInt64, cadence.UInt128, cadence.UInt256,
+		cadence.Word8, cadence.Word16, cadence.Word32, cadence.Word64, cadence.Fix64, cadence.UFix64:
+		return json.Number(v.String())
+	case cadence.Array:
+		values := make([]any, len(v.Values))
+		for i, element := range v.Values {
+			values[i] = CadenceJSON(element)
+		}
+		return values
+	case cadence.Dictionary:
+		pairs := make(map[string]any, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			pairs[dictionaryKey(pair.Key)] = CadenceJSON(pair.Value)
+		}
+		return pairs
+	case cadence.HasFields:
+		fields := make(map[string]any)
+		for name, field := range cadence.GetFieldsMappedByName(v) {
+			fields[name] = CadenceJSON(field)
+		}
+		return fields
+	case cadence.Path:
+		return v.String()
+	case cadence.TypeValue:
+		if v.StaticType == nil {
+			return nil
+		}
+		return v.StaticType.ID()
+	case cadence.PathCapability:
+		return map[string]any{
+			"address":    v.Address.String(),
+			"path":       v.Path.String(),
+			"borrowType": typeID(v.BorrowType),
+		}
+	case cadence.IDCapability:
+		return map[string]any{
+			"address":    v.Address.String(),
+			"id":         json.Number(v.ID.String()),
+			"borrowType": typeID(v.BorrowType),
+		}
+	default:
+		return v.String()
+	}
+}
+
+// dictionaryKey returns the object key of the dictionary key, strings are used without quotes.
+func dictionaryKey(key cadence.Value) string {
+	switch k := key.(type) {
+	case cadence.String:
+		return string(k)
+	case cadence.Character:
+		return string(k)
+	case cadence.Address:
+		return k.String()
+	default:
+		return k.String()
+	}
+}
+
+func typeID(t cadence.Type) string {
+	if t == nil {
+		return ""
+	}
+	return t.ID()
+}
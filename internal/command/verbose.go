@@ -0,0 +1,348 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	configJson "github.com/onflow/flow-cli/flowkit/config/json"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+// Verbosity levels selected by repeating the verbose flag.
+const (
+	// verbosityConfig shows the resolved configuration decisions and network endpoints.
+	verbosityConfig = 1
+	// verbosityRequests adds summaries of the requests to the access node and their responses.
+	verbosityRequests = 2
+	// verbosityPayloads adds the full configuration and payloads, with key material redacted.
+	verbosityPayloads = 3
+)
+
+var verbosityPrefixes = map[int]string{
+	verbosityConfig:   "config",
+	verbosityRequests: "request",
+	verbosityPayloads: "payload",
+}
+
+// verbosef prints the message to the standard error, so it doesn't mix with the result,
+// if the verbosity selected by the verbose flag is at least the level.
+func verbosef(level int, format string, args ...any) {
+	if Flags.Verbose < level {
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "[%s] %s\n", verbosityPrefixes[level], fmt.Sprintf(format, args...))
+}
+
+// logResolvedConfig prints the configuration the command runs with and the network it connects to.
+func logResolvedConfig(state *flowkit.State, confErr error, network *config.Network, hostFlag string) {
+	if Flags.Verbose < verbosityConfig {
+		return
+	}
+
+	switch {
+	case state != nil:
+		verbosef(verbosityConfig, "loaded configuration from %s", strings.Join(Flags.ConfigPaths, ", "))
+	case confErr != nil:
+		verbosef(verbosityConfig, "no configuration loaded from %s: %s", strings.Join(Flags.ConfigPaths, ", "), confErr)
+	}
+
+	source := "the default networks"
+	switch {
+	case hostFlag != "":
+		source = "the host flag"
+	case state != nil:
+		source = "the configuration"
+	}
+	verbosef(verbosityConfig, "using network %s at %s, resolved from %s", network.Name, network.Host, source)
+	if network.Key != "" {
+		verbosef(verbosityConfig, "using a secure connection with network key %s", network.Key)
+	}
+
+	if Flags.NoBuildCache {
+		verbosef(verbosityConfig, "build cache disabled")
+	} else {
+		verbosef(verbosityConfig, "build cache in %s", BuildCacheDir(Flags.ConfigPaths))
+	}
+
+	if state != nil && Flags.Verbose >= verbosityPayloads {
+		conf, err := configJson.NewParser().Serialize(state.Config())
+		if err == nil {
+			verbosef(verbosityPayloads, "configuration %s", redactPayload(conf))
+		}
+	}
+}
+
+// redactedKeys are the names of properties containing key material or credentials,
+// like the network authentication and the vault key credentials.
+var redactedKeys = map[string]bool{
+	"key":        true,
+	"privateKey": true,
+	"mnemonic":   true,
+	"apiKey":     true,
+	"token":      true,
+	"password":   true,
+	"roleId":     true,
+	"secretId":   true,
+}
+
+// redactPayload returns the JSON payload with the values of properties containing key material or credentials replaced.
+func redactPayload(payload []byte) string {
+	var value any
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return string(payload)
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(payload)
+	}
+	return string(redacted)
+}
+
+func redactValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for name, property := range value {
+			if _, isString := property.(string); isString && redactedKeys[name] {
+				value[name] = "<redacted>"
+			} else {
+				value[name] = redactValue(property)
+			}
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+// verboseGateway prints the requests sent by the wrapped gateway and their responses.
+type verboseGateway struct {
+	gateway.Gateway
+}
+
+var _ gateway.Gateway = &verboseGateway{}
+
+// log prints the summary of the request and the response, and the payloads at the payloads verbosity.
+func (g *verboseGateway) log(method string, summary string, request any, response any, err error, start time.Time) {
+	duration := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		verbosef(verbosityRequests, "%s %s failed in %s: %s", method, summary, duration, err)
+	} else {
+		verbosef(verbosityRequests, "%s %s succeeded in %s", method, summary, duration)
+	}
+
+	if Flags.Verbose >= verbosityPayloads {
+		verbosef(verbosityPayloads, "%s request %s", method, encodePayload(request))
+		if err == nil {
+			verbosef(verbosityPayloads, "%s response %s", method, encodePayload(response))
+		}
+	}
+}
+
+// encodePayload encodes the payload as redacted JSON, Cadence values are encoded as JSON-Cadence.
+func encodePayload(payload any) string {
+	var data []byte
+	var err error
+	switch payload := payload.(type) {
+	case cadence.Value:
+		data, err = jsoncdc.Encode(payload)
+	case []cadence.Value:
+		values := make([]json.RawMessage, 0, len(payload))
+		for _, value := range payload {
+			encoded, encodeErr := jsoncdc.Encode(value)
+			if encodeErr != nil {
+				err = encodeErr
+				break
+			}
+			values = append(values, encoded)
+		}
+		data, _ = json.Marshal(values)
+	default:
+		data, err = json.Marshal(payload)
+	}
+	if err != nil {
+		return fmt.Sprintf("%v", payload)
+	}
+
+	return redactPayload(data)
+}
+
+// scriptRequest is the payload of a script execution request.
+type scriptRequest struct {
+	Code      string            `json:"code"`
+	Arguments []json.RawMessage `json:"arguments"`
+	Height    uint64            `json:"height,omitempty"`
+	BlockID   string            `json:"blockId,omitempty"`
+}
+
+func newScriptRequest(code []byte, args []cadence.Value) scriptRequest {
+	request := scriptRequest{Code: string(code), Arguments: make([]json.RawMessage, 0, len(args))}
+	for _, arg := range args {
+		encoded, err := jsoncdc.Encode(arg)
+		if err == nil {
+			request.Arguments = append(request.Arguments, encoded)
+		}
+	}
+	return request
+}
+
+func scriptSummary(code []byte, args []cadence.Value) string {
+	return fmt.Sprintf("script of %d bytes with %d arguments", len(code), len(args))
+}
+
+func (g *verboseGateway) GetAccount(address flow.Address) (*flow.Account, error) {
+	start := time.Now()
+	account, err := g.Gateway.GetAccount(address)
+	g.log("GetAccount", "0x"+address.Hex(), address, account, err, start)
+	return account, err
+}
+
+func (g *verboseGateway) GetAccountAtBlockHeight(address flow.Address, height uint64) (*flow.Account, error) {
+	start := time.Now()
+	account, err := g.Gateway.GetAccountAtBlockHeight(address, height)
+	g.log("GetAccountAtBlockHeight", fmt.Sprintf("0x%s at height %d", address.Hex(), height), address, account, err, start)
+	return account, err
+}
+
+func (g *verboseGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
+	start := time.Now()
+	sent, err := g.Gateway.SendSignedTransaction(tx)
+	g.log("SendSignedTransaction", tx.ID().String(), tx, sent, err, start)
+	return sent, err
+}
+
+func (g *verboseGateway) GetTransaction(id flow.Identifier) (*flow.Transaction, error) {
+	start := time.Now()
+	tx, err := g.Gateway.GetTransaction(id)
+	g.log("GetTransaction", id.String(), id, tx, err, start)
+	return tx, err
+}
+
+func (g *verboseGateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	start := time.Now()
+	results, err := g.Gateway.GetTransactionResultsByBlockID(blockID)
+	g.log("GetTransactionResultsByBlockID", blockID.String(), blockID, results, err, start)
+	return results, err
+}
+
+func (g *verboseGateway) GetTransactionResult(id flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	start := time.Now()
+	result, err := g.Gateway.GetTransactionResult(id, waitSeal)
+	g.log("GetTransactionResult", fmt.Sprintf("%s waiting for seal %t", id, waitSeal), id, result, err, start)
+	return result, err
+}
+
+func (g *verboseGateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
+	start := time.Now()
+	txs, err := g.Gateway.GetTransactionsByBlockID(blockID)
+	g.log("GetTransactionsByBlockID", blockID.String(), blockID, txs, err, start)
+	return txs, err
+}
+
+func (g *verboseGateway) ExecuteScript(code []byte, args []cadence.Value) (cadence.Value, error) {
+	start := time.Now()
+	value, err := g.Gateway.ExecuteScript(code, args)
+	g.log("ExecuteScript", scriptSummary(code, args), newScriptRequest(code, args), value, err, start)
+	return value, err
+}
+
+func (g *verboseGateway) ExecuteScriptAtHeight(code []byte, args []cadence.Value, height uint64) (cadence.Value, error) {
+	start := time.Now()
+	value, err := g.Gateway.ExecuteScriptAtHeight(code, args, height)
+	request := newScriptRequest(code, args)
+	request.Height = height
+	summary := fmt.Sprintf("%s at height %d", scriptSummary(code, args), height)
+	g.log("ExecuteScriptAtHeight", summary, request, value, err, start)
+	return value, err
+}
+
+func (g *verboseGateway) ExecuteScriptAtID(code []byte, args []cadence.Value, id flow.Identifier) (cadence.Value, error) {
+	start := time.Now()
+	value, err := g.Gateway.ExecuteScriptAtID(code, args, id)
+	request := newScriptRequest(code, args)
+	request.BlockID = id.String()
+	summary := fmt.Sprintf("%s at block %s", scriptSummary(code, args), id)
+	g.log("ExecuteScriptAtID", summary, request, value, err, start)
+	return value, err
+}
+
+func (g *verboseGateway) GetLatestBlock() (*flow.Block, error) {
+	start := time.Now()
+	block, err := g.Gateway.GetLatestBlock()
+	g.log("GetLatestBlock", "", nil, block, err, start)
+	return block, err
+}
+
+func (g *verboseGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
+	start := time.Now()
+	block, err := g.Gateway.GetBlockByHeight(height)
+	g.log("GetBlockByHeight", fmt.Sprintf("%d", height), height, block, err, start)
+	return block, err
+}
+
+func (g *verboseGateway) GetBlockByID(id flow.Identifier) (*flow.Block, error) {
+	start := time.Now()
+	block, err := g.Gateway.GetBlockByID(id)
+	g.log("GetBlockByID", id.String(), id, block, err, start)
+	return block, err
+}
+
+func (g *verboseGateway) GetEvents(eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	start := time.Now()
+	events, err := g.Gateway.GetEvents(eventType, startHeight, endHeight)
+	summary := fmt.Sprintf("%s in blocks %d to %d", eventType, startHeight, endHeight)
+	request := map[string]any{"type": eventType, "start": startHeight, "end": endHeight}
+	g.log("GetEvents", summary, request, events, err, start)
+	return events, err
+}
+
+func (g *verboseGateway) GetCollection(id flow.Identifier) (*flow.Collection, error) {
+	start := time.Now()
+	collection, err := g.Gateway.GetCollection(id)
+	g.log("GetCollection", id.String(), id, collection, err, start)
+	return collection, err
+}
+
+func (g *verboseGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
+	start := time.Now()
+	snapshot, err := g.Gateway.GetLatestProtocolStateSnapshot()
+	// the snapshot is binary, only its size is useful
+	g.log("GetLatestProtocolStateSnapshot", "", nil, map[string]int{"bytes": len(snapshot)}, err, start)
+	return snapshot, err
+}
+
+func (g *verboseGateway) Ping() error {
+	start := time.Now()
+	err := g.Gateway.Ping()
+	g.log("Ping", "", nil, nil, err, start)
+	return err
+}
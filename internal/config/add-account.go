@@ -29,7 +29,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
-	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
@@ -42,16 +41,25 @@ type flagsAddAccount struct {
 	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of this account key"`
 	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm to pair with this account key"`
 	Key      string `flag:"private-key" info:"Account private key"`
+	KMSKey   string `flag:"kms-resource-id" info:"Google Cloud KMS key resource ID signing for the account instead of a private key, KMS keys usually pair with the SHA2_256 hash algorithm"`
+	AWSKMS   string `flag:"kms-arn" info:"AWS KMS key ARN signing for the account instead of a private key, credentials are resolved with the standard AWS SDK chain"`
+	Keychain string `flag:"keychain" info:"Store the private key in the operating system keychain under this item name instead of the configuration"`
+	Replace  bool   `default:"false" flag:"replace" info:"Replace the key already stored in the keychain item"`
 }
 
 var addAccountFlags = flagsAddAccount{}
 
 var addAccountCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "account",
-		Short:   "Add account to configuration",
-		Example: "flow config add account",
-		Args:    cobra.NoArgs,
+		Use:   "account",
+		Short: "Add account to configuration",
+		Example: `flow config add account
+flow config add account --name mainnet-admin --address 0xf233dcee88fe0abe --hash-algo SHA2_256 \
+	--kms-resource-id projects/my-project/locations/global/keyRings/flow/cryptoKeys/admin/cryptoKeyVersions/1
+flow config add account --name mainnet-admin --address 0xf233dcee88fe0abe \
+	--kms-arn arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab
+flow config add account --name alice --address 0x01cf0e2f2f715450 --private-key 4247b8408...2402038203e8 --keychain alice`,
+		Args: cobra.NoArgs,
 	},
 	Flags: &addAccountFlags,
 	RunS:  addAccount,
@@ -73,35 +81,20 @@ func addAccount(
 		raw = util.NewAccountPrompt()
 	}
 
-	key, err := parseKey(raw.Key, raw.SigAlgo)
+	index, err := parseKeyIndex(raw.KeyIndex)
 	if err != nil {
 		return nil, err
 	}
 
-	index, err := parseKeyIndex(raw.KeyIndex)
+	key, err := accountKeyFromData(raw, index, addAccountFlags.Replace)
 	if err != nil {
 		return nil, err
 	}
 
-	accountKey := config.AccountKey{
-		Type:       config.KeyTypeHex,
-		Index:      index,
-		SigAlgo:    crypto.StringToSignatureAlgorithm(raw.SigAlgo),
-		HashAlgo:   crypto.StringToHashAlgorithm(raw.HashAlgo),
-		PrivateKey: key,
-	}
-
-	account := &config.Account{
+	state.Accounts().AddOrUpdate(&accounts.Account{
 		Name:    raw.Name,
 		Address: flow.HexToAddress(raw.Address),
-		Key:     accountKey,
-	}
-
-	hexKey := accounts.NewHexKeyFromPrivateKey(account.Key.Index, account.Key.HashAlgo, account.Key.PrivateKey)
-	state.Accounts().AddOrUpdate(&accounts.Account{
-		Name:    account.Name,
-		Address: account.Address,
-		Key:     hexKey,
+		Key:     key,
 	})
 
 	err = state.SaveEdited(globalFlags.ConfigPaths)
@@ -115,6 +108,36 @@ func addAccount(
 
 }
 
+// accountKeyFromData returns the KMS key if a resource ID or an ARN is provided, otherwise the private key
+// stored in the keychain if an item name is provided or the hex private key. A key already stored in the
+// keychain item is only replaced if replace is set or the user confirms it.
+func accountKeyFromData(raw *util.AccountData, index int, replace bool) (accounts.Key, error) {
+	sigAlgo := crypto.StringToSignatureAlgorithm(raw.SigAlgo)
+	hashAlgo := crypto.StringToHashAlgorithm(raw.HashAlgo)
+
+	if raw.KMSResourceID != "" {
+		return accounts.NewKMSKey(raw.KMSResourceID, index, sigAlgo, hashAlgo)
+	}
+	if raw.KMSARN != "" {
+		// AWS KMS only signs with sha2
+		return accounts.NewAWSKMSKey(raw.KMSARN, index, sigAlgo, crypto.SHA2_256)
+	}
+
+	key, err := parseKey(raw.Key, raw.SigAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw.Keychain != "" {
+		if err := util.StoreKeychainKey(raw.Keychain, key, replace); err != nil {
+			return nil, err
+		}
+		return accounts.NewKeychainKey(raw.Keychain, index, sigAlgo, hashAlgo), nil
+	}
+
+	return accounts.NewHexKeyFromPrivateKey(index, hashAlgo, key), nil
+}
+
 func parseKey(key string, sigAlgo string) (crypto.PrivateKey, error) {
 	privateKey, err := crypto.DecodePrivateKeyHex(
 		crypto.StringToSignatureAlgorithm(sigAlgo),
@@ -140,7 +163,7 @@ func parseKeyIndex(value string) (int, error) {
 }
 
 func flagsToAccountData(flags flagsAddAccount) (*util.AccountData, bool, error) {
-	if flags.Name == "" && flags.Address == "" && flags.Key == "" {
+	if flags.Name == "" && flags.Address == "" && flags.Key == "" && flags.KMSKey == "" && flags.AWSKMS == "" {
 		return nil, false, nil
 	}
 
@@ -148,8 +171,24 @@ func flagsToAccountData(flags flagsAddAccount) (*util.AccountData, bool, error)
 		return nil, true, fmt.Errorf("name must be provided")
 	} else if flags.Address == "" {
 		return nil, true, fmt.Errorf("address must be provided")
-	} else if flags.Key == "" {
+	}
+
+	provided := 0
+	for _, key := range []string{flags.Key, flags.KMSKey, flags.AWSKMS} {
+		if key != "" {
+			provided++
+		}
+	}
+	if provided == 0 {
 		return nil, true, fmt.Errorf("key must be provided")
+	} else if provided > 1 {
+		return nil, true, fmt.Errorf("can only pass one of the private key, KMS resource ID and KMS ARN flags")
+	}
+	if flags.Keychain != "" && flags.Key == "" {
+		return nil, true, fmt.Errorf("the keychain flag requires the private key flag")
+	}
+	if flags.Replace && flags.Keychain == "" {
+		return nil, true, fmt.Errorf("the replace flag requires the keychain flag")
 	}
 
 	if flow.HexToAddress(flags.Address) == flow.EmptyAddress {
@@ -163,5 +202,9 @@ func flagsToAccountData(flags flagsAddAccount) (*util.AccountData, bool, error)
 		HashAlgo: flags.HashAlgo,
 		Key:      flags.Key,
 		KeyIndex: flags.KeyIndex,
+
+		KMSResourceID: flags.KMSKey,
+		KMSARN:        flags.AWSKMS,
+		Keychain:      flags.Keychain,
 	}, true, nil
 }
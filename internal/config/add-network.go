@@ -32,19 +32,27 @@ import (
 )
 
 type flagsAddNetwork struct {
-	Name string `flag:"name" info:"Network name"`
-	Host string `flag:"host" info:"Flow Access API host address"`
-	Key  string `flag:"network-key" info:"Flow Access API host network key for secure client connections"`
+	Name         string `flag:"name" info:"Network name"`
+	Host         string `flag:"host" info:"Flow Access API host address"`
+	Key          string `flag:"network-key" info:"Flow Access API host network key for secure client connections"`
+	APIKey       string `flag:"api-key" info:"API key sent to the access node, or a reference to an environment variable as $NAME"`
+	APIKeyHeader string `flag:"api-key-header" info:"Header carrying the API key, x-api-key if not provided"`
+	Token        string `flag:"token" info:"Bearer token sent to the access node, or a reference to an environment variable as $NAME"`
+	Username     string `flag:"username" info:"Username of basic authentication with the access node"`
+	Password     string `flag:"password" info:"Password of basic authentication with the access node, or a reference to an environment variable as $NAME"`
+	Explorer     string `flag:"explorer" info:"Block explorer URL template linked in results, with {type} and {id} placeholders"`
 }
 
 var addNetworkFlags = flagsAddNetwork{}
 
 var addNetworkCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "network",
-		Short:   "Add network to configuration",
-		Example: "flow config add network",
-		Args:    cobra.NoArgs,
+		Use:   "network",
+		Short: "Add network to configuration",
+		Example: `flow config add network
+flow config add network --name provider --host flow.provider.example:443 --token '$PROVIDER_TOKEN'
+flow config add network --name previewnet --host access.previewnet.nodes.onflow.org:9000 --explorer 'https://previewnet.flowscan.io/{type}/{id}'`,
+		Args: cobra.NoArgs,
 	},
 	Flags: &addNetworkFlags,
 	RunS:  addNetwork,
@@ -66,10 +74,24 @@ func addNetwork(
 		raw = util.NewNetworkPrompt()
 	}
 
+	auth, err := flagsToNetworkAuth(addNetworkFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	if addNetworkFlags.Explorer != "" {
+		err = config.ValidateExplorer(addNetworkFlags.Explorer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	state.Networks().AddOrUpdate(config.Network{
-		Name: raw["name"],
-		Host: raw["host"],
-		Key:  raw["key"],
+		Name:     raw["name"],
+		Host:     raw["host"],
+		Key:      raw["key"],
+		Auth:     auth,
+		Explorer: addNetworkFlags.Explorer,
 	})
 
 	err = state.SaveEdited(globalFlags.ConfigPaths)
@@ -109,3 +131,24 @@ func flagsToNetworkData(flags flagsAddNetwork) (map[string]string, bool, error)
 		"key":  flags.Key,
 	}, true, nil
 }
+
+// flagsToNetworkAuth returns the authentication with the access node provided by the flags, or nil if none is provided.
+func flagsToNetworkAuth(flags flagsAddNetwork) (*config.NetworkAuth, error) {
+	auth := config.NetworkAuth{
+		Header:   flags.APIKeyHeader,
+		APIKey:   flags.APIKey,
+		Token:    flags.Token,
+		Username: flags.Username,
+		Password: flags.Password,
+	}
+	if auth == (config.NetworkAuth{}) {
+		return nil, nil
+	}
+
+	err := auth.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid authentication flags: %w", err)
+	}
+
+	return &auth, nil
+}
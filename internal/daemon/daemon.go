@@ -0,0 +1,97 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsDaemon struct{}
+
+var daemonFlags = flagsDaemon{}
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Serve commands of other CLI invocations in the project from a long-lived process",
+	Long: `Run a daemon holding the loaded project, built contracts and network connections in memory.
+
+While the daemon is running, other commands in the project are delegated to it over a local socket,
+which avoids loading the configuration and connecting to the network on every invocation. The daemon
+runs delegated commands one at a time and reloads the project when a configuration file changes.
+Delegated commands can't prompt for input, use the --yes flag to approve them and --no-daemon to run
+a command in its own process.`,
+	Example: "flow daemon",
+	Args:    cobra.NoArgs,
+	GroupID: "project",
+}
+
+var Command = &command.Command{
+	Cmd:      daemonCmd,
+	Flags:    &daemonFlags,
+	Run:      run,
+	NoDaemon: true,
+	NoRecord: true,
+}
+
+func run(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	socket := command.DaemonSocket(globalFlags.ConfigPaths)
+
+	d, err := command.NewDaemon(daemonCmd.Root(), socket)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.Warm(globalFlags.ConfigPaths, readerWriter, flow.Network())
+	if err != nil {
+		logger.Info(fmt.Sprintf("%s Failed to connect to %s: %s\n", output.WarningEmoji(), flow.Network().Host, err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		_ = d.Close()
+	}()
+
+	logger.Info(fmt.Sprintf("%s Daemon serving the project on %s, stop it with Ctrl+C\n", output.SuccessEmoji(), socket))
+
+	err = d.Serve()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Daemon stopped")
+	return nil, nil
+}
@@ -0,0 +1,330 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"nhooyr.io/websocket"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+const (
+	activityPollInterval = 500 * time.Millisecond
+	activityWriteTimeout = 5 * time.Second
+	activityBufferSize   = 1024
+)
+
+// activityMessage is pushed as JSON to all websocket subscribers.
+//
+// The message type is one of "transaction", "event" or "log".
+type activityMessage struct {
+	Type          string          `json:"type"`
+	BlockHeight   uint64          `json:"blockHeight,omitempty"`
+	TransactionID string          `json:"transactionId,omitempty"`
+	Status        string          `json:"status,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	Script        string          `json:"script,omitempty"`
+	EventType     string          `json:"eventType,omitempty"`
+	EventIndex    *int            `json:"eventIndex,omitempty"`
+	Values        json.RawMessage `json:"values,omitempty"`
+	Line          string          `json:"line,omitempty"`
+}
+
+// activityHub keeps websocket subscribers and broadcasts emulator activity to them.
+//
+// Messages are queued on a buffered channel and written by a single goroutine, so slow subscribers
+// don't slow down the emulator, messages are dropped if the queue is full.
+type activityHub struct {
+	mu             sync.Mutex
+	clients        map[*websocket.Conn]struct{}
+	messages       chan activityMessage
+	originPatterns []string
+}
+
+func newActivityHub(originPatterns []string) *activityHub {
+	return &activityHub{
+		clients:        make(map[*websocket.Conn]struct{}),
+		messages:       make(chan activityMessage, activityBufferSize),
+		originPatterns: originPatterns,
+	}
+}
+
+func (h *activityHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// browsers can connect to localhost from any page, so only same origin and the allowed origins are accepted
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{OriginPatterns: h.originPatterns})
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	h.clients[conn] = struct{}{}
+	h.mu.Unlock()
+
+	// subscribers only receive messages, wait until the connection is closed
+	ctx := conn.CloseRead(r.Context())
+	<-ctx.Done()
+
+	h.remove(conn)
+}
+
+func (h *activityHub) remove(conn *websocket.Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[conn]; ok {
+		delete(h.clients, conn)
+		_ = conn.Close(websocket.StatusNormalClosure, "")
+	}
+}
+
+// publish queues the message for the subscribers without blocking.
+func (h *activityHub) publish(message activityMessage) {
+	select {
+	case h.messages <- message:
+	default:
+	}
+}
+
+// broadcast writes queued messages to all subscribers until the context is done.
+func (h *activityHub) broadcast(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message := <-h.messages:
+			h.write(message)
+		}
+	}
+}
+
+func (h *activityHub) write(message activityMessage) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	clients := make([]*websocket.Conn, 0, len(h.clients))
+	for conn := range h.clients {
+		clients = append(clients, conn)
+	}
+	h.mu.Unlock()
+
+	for _, conn := range clients {
+		ctx, cancel := context.WithTimeout(context.Background(), activityWriteTimeout)
+		err := conn.Write(ctx, websocket.MessageText, data)
+		cancel()
+		if err != nil {
+			h.remove(conn)
+		}
+	}
+}
+
+// activityFollower follows the emulator blocks and publishes executed transactions and emitted events.
+type activityFollower struct {
+	gateway     gateway.Gateway
+	hub         *activityHub
+	lastHeight  uint64
+	initialized bool
+}
+
+// poll publishes activity of all the blocks created since the last poll, the first poll only
+// records the latest height so activity that happened before subscribing is not replayed.
+func (f *activityFollower) poll() error {
+	latest, err := f.gateway.GetLatestBlock()
+	if err != nil {
+		return err
+	}
+
+	if !f.initialized {
+		f.lastHeight = latest.Height
+		f.initialized = true
+		return nil
+	}
+
+	for height := f.lastHeight + 1; height <= latest.Height; height++ {
+		block, err := f.gateway.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+
+		err = f.publishBlock(block)
+		if err != nil {
+			return err
+		}
+		f.lastHeight = height
+	}
+
+	return nil
+}
+
+func (f *activityFollower) publishBlock(block *flowsdk.Block) error {
+	txs, err := f.gateway.GetTransactionsByBlockID(block.ID)
+	if err != nil {
+		return err
+	}
+
+	results, err := f.gateway.GetTransactionResultsByBlockID(block.ID)
+	if err != nil {
+		return err
+	}
+
+	for i, tx := range txs {
+		message := activityMessage{
+			Type:          "transaction",
+			BlockHeight:   block.Height,
+			TransactionID: tx.ID().String(),
+			Script:        string(tx.Script),
+		}
+
+		var result *flowsdk.TransactionResult
+		if i < len(results) {
+			result = results[i]
+			message.Status = result.Status.String()
+			if result.Error != nil {
+				message.Error = result.Error.Error()
+			}
+		}
+		f.hub.publish(message)
+
+		if result == nil {
+			continue
+		}
+
+		for _, event := range result.Events {
+			index := event.EventIndex
+			f.hub.publish(activityMessage{
+				Type:          "event",
+				BlockHeight:   block.Height,
+				TransactionID: tx.ID().String(),
+				EventType:     event.Type,
+				EventIndex:    &index,
+				Values:        json.RawMessage(event.Payload),
+			})
+		}
+	}
+
+	return nil
+}
+
+// follow polls the emulator until the context is done, the emulator might not be started yet
+// so failures are retried on the next poll.
+func (f *activityFollower) follow(ctx context.Context) {
+	ticker := time.NewTicker(activityPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = f.poll()
+		}
+	}
+}
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// publishLines copies lines from the reader to the writer and publishes each line as a log message.
+//
+// Copying continues until the reader is closed regardless of the line length, otherwise the emulator
+// writing to the pipe would block once the pipe buffer is full.
+func publishLines(reader io.Reader, writer io.Writer, hub *activityHub) {
+	buffered := bufio.NewReader(reader)
+	for {
+		line, err := buffered.ReadString('\n')
+		if line != "" {
+			_, _ = io.WriteString(writer, line)
+			hub.publish(activityMessage{
+				Type: "log",
+				Line: ansiEscape.ReplaceAllString(strings.TrimRight(line, "\r\n"), ""),
+			})
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// startActivityServer starts the websocket server pushing activity of the emulator running on the gRPC port.
+//
+// The emulator logs are written to the standard output, which is replaced by a pipe so each log line
+// can be published to subscribers as well as written to the original output.
+func startActivityServer(ctx context.Context, wsPort int, grpcPort int, originPatterns []string) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", wsPort))
+	if err != nil {
+		return fmt.Errorf("failed to start activity websocket server: %w", err)
+	}
+
+	grpcGateway, err := gateway.NewGrpcGateway(config.Network{
+		Name: config.EmulatorNetwork.Name,
+		Host: fmt.Sprintf("127.0.0.1:%d", grpcPort),
+	})
+	if err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	hub := newActivityHub(originPatterns)
+	go hub.broadcast(ctx)
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	go publishLines(reader, stdout, hub)
+
+	follower := &activityFollower{gateway: grpcGateway, hub: hub}
+	go follower.follow(ctx)
+
+	server := &http.Server{Handler: hub}
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			_, _ = fmt.Fprintf(stdout, "activity websocket server stopped: %s\n", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	_, _ = fmt.Fprintf(stdout, "📡 Emulator activity is pushed on ws://%s\n", listener.Addr())
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func Test_Activity(t *testing.T) {
+	hub := newActivityHub([]string{"editor.local"})
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go hub.broadcast(ctx)
+
+	conn, _, err := websocket.Dial(ctx, strings.Replace(server.URL, "http", "ws", 1), nil)
+	require.NoError(t, err)
+	defer conn.Close(websocket.StatusNormalClosure, "")
+	conn.SetReadLimit(1 << 20)
+
+	// wait for the subscriber to be registered
+	require.Eventually(t, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return len(hub.clients) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	read := func() activityMessage {
+		_, data, err := conn.Read(ctx)
+		require.NoError(t, err)
+
+		var message activityMessage
+		require.NoError(t, json.Unmarshal(data, &message))
+		return message
+	}
+
+	t.Run("Success transaction and events", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+		block := tests.NewBlock()
+		tx := tests.NewTransaction()
+		event := tests.NewEvent(0, "A.foo.Bar", []cadence.Field{}, []cadence.Value{})
+		event.Payload = []byte(`{"type":"Event"}`)
+
+		gw.Mock.On("GetTransactionsByBlockID", block.ID).Return([]*flow.Transaction{tx}, nil)
+		gw.Mock.On("GetTransactionResultsByBlockID", block.ID).
+			Return([]*flow.TransactionResult{tests.NewTransactionResult([]flow.Event{*event})}, nil)
+
+		follower := &activityFollower{gateway: gw.Mock, hub: hub}
+		require.NoError(t, follower.poll())
+		assert.Equal(t, block.Height, follower.lastHeight)
+
+		follower.lastHeight = block.Height - 1
+		gw.GetBlockByHeight.Return(block, nil)
+		require.NoError(t, follower.poll())
+
+		message := read()
+		assert.Equal(t, "transaction", message.Type)
+		assert.Equal(t, tx.ID().String(), message.TransactionID)
+		assert.Equal(t, "SEALED", message.Status)
+
+		message = read()
+		assert.Equal(t, "event", message.Type)
+		assert.Equal(t, "A.foo.Bar", message.EventType)
+		assert.JSONEq(t, `{"type":"Event"}`, string(message.Values))
+	})
+
+	t.Run("Success log lines", func(t *testing.T) {
+		var out strings.Builder
+		publishLines(strings.NewReader("\x1b[32mLOG\x1b[0m hello\n"), &out, hub)
+
+		assert.Equal(t, "\x1b[32mLOG\x1b[0m hello\n", out.String())
+		assert.Equal(t, activityMessage{Type: "log", Line: "LOG hello"}, read())
+	})
+
+	t.Run("Success long log lines", func(t *testing.T) {
+		long := strings.Repeat("a", 100*1024)
+		var out strings.Builder
+		publishLines(strings.NewReader(long+"\nnext\n"), &out, hub)
+
+		assert.Equal(t, long+"\nnext\n", out.String())
+		assert.Equal(t, long, read().Line)
+		assert.Equal(t, "next", read().Line)
+	})
+
+	t.Run("Success publish without blocking", func(t *testing.T) {
+		full := newActivityHub(nil)
+		for i := 0; i < activityBufferSize+1; i++ {
+			full.publish(activityMessage{Type: "log"})
+		}
+		assert.Len(t, full.messages, activityBufferSize)
+	})
+
+	t.Run("Origins", func(t *testing.T) {
+		url := strings.Replace(server.URL, "http", "ws", 1)
+
+		_, _, err := websocket.Dial(ctx, url, &websocket.DialOptions{
+			HTTPHeader: http.Header{"Origin": []string{"https://evil.example"}},
+		})
+		assert.Error(t, err)
+
+		allowed, _, err := websocket.Dial(ctx, url, &websocket.DialOptions{
+			HTTPHeader: http.Header{"Origin": []string{"https://editor.local"}},
+		})
+		require.NoError(t, err)
+		_ = allowed.Close(websocket.StatusNormalClosure, "")
+	})
+}
+
+func Test_StartActivityServerPortTaken(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	err = startActivityServer(context.Background(), port, 3569, nil)
+	assert.ErrorContains(t, err, "failed to start activity websocket server")
+}
@@ -105,12 +105,36 @@ func configuredServiceKey(
 	return *privateKey, serviceAccount.Key.SigAlgo(), serviceAccount.Key.HashAlgo()
 }
 
+var (
+	wsPort           int
+	wsAllowedOrigins []string
+)
+
 func init() {
 	Cmd = start.Cmd(configuredServiceKey)
 	Cmd.Use = "emulator"
 	Cmd.Short = "Run Flow network for development"
 	Cmd.GroupID = "tools"
 	SnapshotCmd.AddToParent(Cmd)
+
+	Cmd.Flags().IntVar(&wsPort, "ws-port", 0, "port to push executed transactions, events and logs as JSON over a websocket, disabled if not set")
+	Cmd.Flags().StringSliceVar(&wsAllowedOrigins, "ws-allowed-origins", nil, "host patterns of cross origin pages allowed to subscribe to the websocket, like editor webviews")
+	run := Cmd.Run
+	Cmd.Run = func(cmd *cobra.Command, args []string) {
+		if wsPort != 0 {
+			grpcPort, err := cmd.Flags().GetInt("port")
+			if err != nil {
+				exitf(1, err.Error())
+			}
+
+			err = startActivityServer(cmd.Context(), wsPort, grpcPort, wsAllowedOrigins)
+			if err != nil {
+				exitf(1, err.Error())
+			}
+		}
+
+		run(cmd, args)
+	}
 }
 
 func exitf(code int, msg string, args ...any) {
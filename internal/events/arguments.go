@@ -0,0 +1,118 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// namedReference matches event references targeting a parameter in the "name=txID:EventType.field" format.
+var namedReference = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=(.+)$`)
+
+// IsNamedReference returns true if the event reference targets a parameter by name.
+func IsNamedReference(reference string) bool {
+	return namedReference.MatchString(reference)
+}
+
+// ArgumentsFromEvents resolves argument values from events of previously sent transactions.
+//
+// Each reference has the format "txID:A.0x1.Contract.Event.field", the event type can also be
+// provided without the address prefix (e.g. "NFT.Minted.id"). The field value of the first
+// matching event is returned in the format accepted by the argument parser. References in the
+// "name=txID:EventType.field" format are returned as "name=value" arguments keyed by the parameter name.
+func ArgumentsFromEvents(services flowkit.Services, references []string) ([]string, error) {
+	values := make([]string, 0, len(references))
+	for _, ref := range references {
+		name, reference := "", ref
+		if match := namedReference.FindStringSubmatch(ref); match != nil {
+			name, reference = match[1], match[2]
+		}
+
+		value, err := argumentFromEvent(services, reference)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve argument from event %s: %w", ref, err)
+		}
+
+		if name != "" {
+			value = fmt.Sprintf("%s=%s", name, value)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func argumentFromEvent(services flowkit.Services, reference string) (string, error) {
+	txID, path, found := strings.Cut(reference, ":")
+	if !found {
+		return "", fmt.Errorf("invalid format, expected txID:EventType.field")
+	}
+
+	fieldIndex := strings.LastIndex(path, ".")
+	if fieldIndex <= 0 || fieldIndex == len(path)-1 {
+		return "", fmt.Errorf("invalid format, expected txID:EventType.field")
+	}
+	eventType, field := path[:fieldIndex], path[fieldIndex+1:]
+
+	id := flowsdk.HexToID(strings.TrimPrefix(txID, "0x"))
+	_, result, err := services.GetTransactionByID(context.Background(), id, true)
+	if err != nil {
+		return "", err
+	}
+
+	for _, event := range result.Events {
+		if event.Type != eventType && !strings.HasSuffix(event.Type, "."+eventType) {
+			continue
+		}
+
+		value, ok := flowkit.NewEvent(event).Values[field]
+		if !ok {
+			return "", fmt.Errorf("event %s has no field %s", event.Type, field)
+		}
+
+		return argumentString(value), nil
+	}
+
+	return "", fmt.Errorf("event %s not found in transaction %s", eventType, id)
+}
+
+// argumentString converts the value to the string representation used for command arguments.
+func argumentString(value cadence.Value) string {
+	switch v := value.(type) {
+	case cadence.Optional:
+		if v.Value == nil {
+			return "nil"
+		}
+		return argumentString(v.Value)
+	case cadence.String:
+		return string(v)
+	case cadence.Character:
+		return string(v)
+	default:
+		return v.String()
+	}
+}
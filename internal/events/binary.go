@@ -0,0 +1,159 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/golang/protobuf/proto"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventRecord is the CBOR encoding of an event, the output is a CBOR array of records.
+//
+// The records are maps keyed by integers: 1 block height, 2 block ID, 3 transaction ID,
+// 4 transaction index, 5 event index, 6 event type and 7 the event payload in JSON-Cadence.
+// Events not fetched from a block have no block height and ID.
+type eventRecord struct {
+	BlockHeight      uint64 `cbor:"1,keyasint,omitempty"`
+	BlockID          []byte `cbor:"2,keyasint,omitempty"`
+	TransactionID    []byte `cbor:"3,keyasint"`
+	TransactionIndex int    `cbor:"4,keyasint"`
+	EventIndex       int    `cbor:"5,keyasint"`
+	Type             string `cbor:"6,keyasint"`
+	Payload          []byte `cbor:"7,keyasint"`
+}
+
+// Binary encodes the events in the cbor or proto format.
+//
+// The proto format is a stream of flow.access.EventsResponse.Result messages of the Access API,
+// one for each block with events, each prefixed with its length as a varint. Event payloads are
+// encoded in JSON-Cadence.
+func (e *EventResult) Binary(format string) ([]byte, error) {
+	switch format {
+	case "cbor":
+		return e.cbor()
+	case "proto":
+		return e.proto()
+	default:
+		return nil, fmt.Errorf("unsupported binary format %s", format)
+	}
+}
+
+func (e *EventResult) cbor() ([]byte, error) {
+	records := make([]eventRecord, 0)
+	for _, blockEvents := range e.BlockEvents {
+		for _, event := range blockEvents.Events {
+			record, err := newEventRecord(event)
+			if err != nil {
+				return nil, err
+			}
+			record.BlockHeight = blockEvents.Height
+			record.BlockID = blockEvents.BlockID.Bytes()
+			records = append(records, record)
+		}
+	}
+	for _, event := range e.Events {
+		record, err := newEventRecord(event)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return cbor.Marshal(records)
+}
+
+func newEventRecord(event flow.Event) (eventRecord, error) {
+	payload, err := jsoncdc.Encode(event.Value)
+	if err != nil {
+		return eventRecord{}, fmt.Errorf("failed to encode event %s: %w", event.Type, err)
+	}
+
+	return eventRecord{
+		TransactionID:    event.TransactionID.Bytes(),
+		TransactionIndex: event.TransactionIndex,
+		EventIndex:       event.EventIndex,
+		Type:             event.Type,
+		Payload:          payload,
+	}, nil
+}
+
+func (e *EventResult) proto() ([]byte, error) {
+	results := make([]*access.EventsResponse_Result, 0, len(e.BlockEvents)+1)
+	for _, blockEvents := range e.BlockEvents {
+		if len(blockEvents.Events) == 0 {
+			continue
+		}
+		events, err := eventMessages(blockEvents.Events)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &access.EventsResponse_Result{
+			BlockId:        blockEvents.BlockID.Bytes(),
+			BlockHeight:    blockEvents.Height,
+			Events:         events,
+			BlockTimestamp: timestamppb.New(blockEvents.BlockTimestamp),
+		})
+	}
+	if len(e.Events) > 0 {
+		events, err := eventMessages(e.Events)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &access.EventsResponse_Result{Events: events})
+	}
+
+	var encoded []byte
+	for _, result := range results {
+		message, err := proto.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		encoded = protowire.AppendVarint(encoded, uint64(len(message)))
+		encoded = append(encoded, message...)
+	}
+
+	return encoded, nil
+}
+
+func eventMessages(events []flow.Event) ([]*entities.Event, error) {
+	messages := make([]*entities.Event, 0, len(events))
+	for _, event := range events {
+		record, err := newEventRecord(event)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, &entities.Event{
+			Type:             record.Type,
+			TransactionId:    record.TransactionID,
+			TransactionIndex: uint32(record.TransactionIndex),
+			EventIndex:       uint32(record.EventIndex),
+			Payload:          record.Payload,
+		})
+	}
+
+	return messages, nil
+}
@@ -22,11 +22,19 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/fxamacker/cbor/v2"
+	"github.com/golang/protobuf/proto"
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/tests"
@@ -62,6 +70,128 @@ func Test_Get(t *testing.T) {
 		assert.NotNil(t, result)
 	})
 
+	t.Run("Success since until", func(t *testing.T) {
+		inArgs := []string{"test.event"}
+		eventsFlags.Start = 0
+		eventsFlags.End = 0
+		eventsFlags.Since = "2024-01-01T10:00:00Z"
+		eventsFlags.Until = "2024-01-01T20:00:00Z"
+		defer func() {
+			eventsFlags.Since = ""
+			eventsFlags.Until = ""
+		}()
+
+		// block at each height is produced an hour after the previous one, latest is 100
+		genesis := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			query := args.Get(1).(flowkit.BlockQuery)
+			block := tests.NewBlock()
+			block.Height = query.Height
+			if query.Latest {
+				block.Height = 100
+			}
+			block.Timestamp = genesis.Add(time.Duration(block.Height) * time.Hour)
+			srv.GetBlock.Return(block, nil)
+		})
+		srv.GetEvents.Run(func(args mock.Arguments) {
+			assert.Equal(t, uint64(10), args.Get(2).(uint64))
+			assert.Equal(t, uint64(19), args.Get(3).(uint64))
+		})
+
+		result, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success since before spork root", func(t *testing.T) {
+		eventsFlags.Start = 0
+		eventsFlags.End = 0
+		eventsFlags.Since = "2024-01-01T00:00:00Z"
+		eventsFlags.Until = "2024-01-01T20:00:00Z"
+		defer func() {
+			eventsFlags.Since = ""
+			eventsFlags.Until = ""
+		}()
+
+		// blocks before the spork root at height 15 are not found
+		genesis := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			query := args.Get(1).(flowkit.BlockQuery)
+			if !query.Latest && query.Height < 15 {
+				srv.GetBlock.Return(nil, status.Error(codes.NotFound, "block not found"))
+				return
+			}
+			block := tests.NewBlock()
+			block.Height = query.Height
+			if query.Latest {
+				block.Height = 100
+			}
+			block.Timestamp = genesis.Add(time.Duration(block.Height) * time.Hour)
+			srv.GetBlock.Return(block, nil)
+		})
+		srv.GetEvents.Run(func(args mock.Arguments) {
+			assert.Equal(t, uint64(15), args.Get(2).(uint64))
+			assert.Equal(t, uint64(19), args.Get(3).(uint64))
+		})
+
+		_, err := get([]string{"test.event"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail since block error", func(t *testing.T) {
+		eventsFlags.Start = 0
+		eventsFlags.End = 0
+		eventsFlags.Since = "2024-01-01T10:00:00Z"
+		defer func() { eventsFlags.Since = "" }()
+
+		latest := tests.NewBlock()
+		latest.Height = 100
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			if args.Get(1).(flowkit.BlockQuery).Latest {
+				srv.GetBlock.Return(latest, nil)
+				return
+			}
+			srv.GetBlock.Return(nil, status.Error(codes.Unavailable, "connection refused"))
+		})
+
+		_, err := get([]string{"test.event"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "rpc error: code = Unavailable desc = connection refused")
+	})
+
+	t.Run("Success confirmations", func(t *testing.T) {
+		eventsFlags.Start = 10
+		eventsFlags.End = 20
+		eventsFlags.Confirmations = 5
+		defer func() { eventsFlags.Confirmations = 0 }()
+
+		latest := tests.NewBlock()
+		latest.Height = 22
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			srv.GetBlock.Return(latest, nil)
+		})
+		srv.GetEvents.Run(func(args mock.Arguments) {
+			assert.Equal(t, uint64(10), args.Get(2).(uint64))
+			assert.Equal(t, uint64(17), args.Get(3).(uint64))
+		})
+
+		_, err := get([]string{"test.event"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+
+		eventsFlags.Confirmations = 15
+		_, err = get([]string{"test.event"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "no blocks from height 10 have 15 confirmations yet, latest sealed height is 22")
+	})
+
+	t.Run("Fail since with start", func(t *testing.T) {
+		eventsFlags.Start = 10
+		eventsFlags.End = 20
+		eventsFlags.Since = "2024-01-01"
+		defer func() { eventsFlags.Since = "" }()
+
+		_, err := get([]string{"test.event"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "since and until flags can not be combined with start and end flags")
+	})
+
 	t.Run("Fail invalid range", func(t *testing.T) {
 		inArgs := []string{"test.event"}
 		eventsFlags.Start = 20
@@ -72,6 +202,109 @@ func Test_Get(t *testing.T) {
 		assert.Nil(t, result)
 	})
 
+	t.Run("Success max memory", func(t *testing.T) {
+		eventsFlags.Start = 10
+		eventsFlags.End = 60
+		eventsFlags.Workers = 2
+		eventsFlags.Batch = 10
+		eventsFlags.MaxMemory = "1KB"
+		defer func() {
+			eventsFlags.Workers = 10
+			eventsFlags.Batch = 25
+			eventsFlags.MaxMemory = ""
+		}()
+
+		var ranges [][2]uint64
+		srv.GetEvents.Run(func(args mock.Arguments) {
+			ranges = append(ranges, [2]uint64{args.Get(2).(uint64), args.Get(3).(uint64)})
+			srv.GetEvents.Return([]flow.BlockEvents{{Height: args.Get(2).(uint64)}}, nil)
+		})
+
+		// each chunk fetched uses 600 bytes
+		usage := uint64(0)
+		defaultMemoryUsage := memoryUsage
+		memoryUsage = func() uint64 {
+			usage += 600
+			return usage
+		}
+		defer func() { memoryUsage = defaultMemoryUsage }()
+
+		result, err := get([]string{"test.event"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, [][2]uint64{{10, 29}, {30, 49}}, ranges)
+		assert.Len(t, result.(*EventResult).BlockEvents, 2)
+
+		events, resume, exceeded, err := getEventsWithinLimits(
+			srv.Mock,
+			[]string{"test.event"},
+			10,
+			60,
+			&flowkit.EventWorker{Count: 2, BlocksPerWorker: 10},
+			eventLimits{memory: 1 << 20},
+		)
+		require.NoError(t, err)
+		assert.Len(t, events, 3)
+		assert.Equal(t, uint64(0), resume)
+		assert.Empty(t, exceeded)
+	})
+
+	t.Run("Success max disk", func(t *testing.T) {
+		eventsFlags.Start = 10
+		eventsFlags.End = 60
+		eventsFlags.Workers = 2
+		eventsFlags.Batch = 10
+		eventsFlags.MaxDisk = "1KB"
+		defer func() {
+			eventsFlags.Workers = 10
+			eventsFlags.Batch = 25
+			eventsFlags.MaxDisk = ""
+		}()
+
+		// each chunk fetched has 400 bytes of payloads
+		var ranges [][2]uint64
+		srv.GetEvents.Run(func(args mock.Arguments) {
+			ranges = append(ranges, [2]uint64{args.Get(2).(uint64), args.Get(3).(uint64)})
+			srv.GetEvents.Return([]flow.BlockEvents{{
+				Height: args.Get(2).(uint64),
+				Events: []flow.Event{{Type: "test.event", Payload: make([]byte, 400)}},
+			}}, nil)
+		})
+
+		result, err := get([]string{"test.event"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, [][2]uint64{{10, 29}, {30, 49}, {50, 60}}, ranges)
+		assert.Len(t, result.(*EventResult).BlockEvents, 2)
+
+		events, resume, exceeded, err := getEventsWithinLimits(
+			srv.Mock,
+			[]string{"test.event"},
+			10,
+			60,
+			&flowkit.EventWorker{Count: 2, BlocksPerWorker: 10},
+			eventLimits{disk: 1000},
+		)
+		require.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, uint64(50), resume)
+		assert.Equal(t, "disk", exceeded)
+	})
+
+	t.Run("Fail invalid max memory", func(t *testing.T) {
+		eventsFlags.MaxMemory = "lots"
+		defer func() { eventsFlags.MaxMemory = "" }()
+
+		_, err := get([]string{"test.event"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid max memory lots, provide a size such as 512MB or 2GB")
+	})
+
+	t.Run("Fail invalid max disk", func(t *testing.T) {
+		eventsFlags.MaxDisk = "0"
+		defer func() { eventsFlags.MaxDisk = "" }()
+
+		_, err := get([]string{"test.event"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid max disk 0, provide a size such as 512MB or 2GB")
+	})
+
 }
 
 func Test_Result(t *testing.T) {
@@ -109,4 +342,137 @@ Events Block #1:
 		"type":          "A.foo",
 		"values":        json.RawMessage{0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x69, 0x64, 0x22, 0x3a, 0x22, 0x41, 0x2e, 0x66, 0x6f, 0x6f, 0x22, 0x2c, 0x22, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x3a, 0x5b, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x22, 0x31, 0x22, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x49, 0x6e, 0x74, 0x22, 0x7d, 0x2c, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x22, 0x62, 0x61, 0x72, 0x22, 0x7d, 0x5d, 0x7d, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x7d, 0xa},
 	}}, event.JSON())
+
+	t.Run("CBOR", func(t *testing.T) {
+		encoded, err := event.Binary("cbor")
+		require.NoError(t, err)
+
+		var records []eventRecord
+		require.NoError(t, cbor.Unmarshal(encoded, &records))
+		require.Len(t, records, 1)
+		assert.Equal(t, block.Height, records[0].BlockHeight)
+		assert.Equal(t, block.ID.Bytes(), records[0].BlockID)
+		assert.Equal(t, "A.foo", records[0].Type)
+		assert.JSONEq(t, `{"value":{"id":"A.foo","fields":[{"value":{"value":"1","type":"Int"},"name":"bar"}]},"type":"Event"}`, string(records[0].Payload))
+	})
+
+	t.Run("Proto", func(t *testing.T) {
+		encoded, err := event.Binary("proto")
+		require.NoError(t, err)
+
+		length, n := protowire.ConsumeVarint(encoded)
+		require.Greater(t, n, 0)
+		require.Len(t, encoded, n+int(length))
+
+		var result access.EventsResponse_Result
+		require.NoError(t, proto.Unmarshal(encoded[n:], &result))
+		assert.Equal(t, block.Height, result.BlockHeight)
+		assert.Equal(t, block.ID.Bytes(), result.BlockId)
+		require.Len(t, result.Events, 1)
+		assert.Equal(t, "A.foo", result.Events[0].Type)
+		assert.JSONEq(t, `{"value":{"id":"A.foo","fields":[{"value":{"value":"1","type":"Int"},"name":"bar"}]},"type":"Event"}`, string(result.Events[0].Payload))
+	})
+}
+
+func Test_ArgumentsFromEvents(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+
+	minted := tests.NewEvent(
+		0,
+		"A.f8d6e0586b0a20c7.NFT.Minted",
+		[]cadence.Field{
+			{Type: cadence.UInt64Type{}, Identifier: "id"},
+			{Type: cadence.StringType{}, Identifier: "name"},
+		},
+		[]cadence.Value{cadence.NewUInt64(42), cadence.String("kitty")},
+	)
+	srv.GetTransactionByID.Return(
+		tests.NewTransaction(),
+		tests.NewTransactionResult([]flow.Event{*minted}),
+		nil,
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		values, err := ArgumentsFromEvents(srv.Mock, []string{
+			"0x01:A.f8d6e0586b0a20c7.NFT.Minted.id",
+			"01:NFT.Minted.name",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"42", "kitty"}, values)
+	})
+
+	t.Run("Success named", func(t *testing.T) {
+		values, err := ArgumentsFromEvents(srv.Mock, []string{"tokenID=01:NFT.Minted.id"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tokenID=42"}, values)
+		assert.True(t, IsNamedReference("tokenID=01:NFT.Minted.id"))
+		assert.False(t, IsNamedReference("01:NFT.Minted.id"))
+	})
+
+	t.Run("Fail invalid format", func(t *testing.T) {
+		_, err := ArgumentsFromEvents(srv.Mock, []string{"NFT.Minted.id"})
+		assert.EqualError(t, err, "failed to resolve argument from event NFT.Minted.id: invalid format, expected txID:EventType.field")
+	})
+
+	t.Run("Fail missing event", func(t *testing.T) {
+		_, err := ArgumentsFromEvents(srv.Mock, []string{"01:NFT.Burned.id"})
+		assert.ErrorContains(t, err, "event NFT.Burned not found in transaction")
+	})
+
+	t.Run("Fail missing field", func(t *testing.T) {
+		_, err := ArgumentsFromEvents(srv.Mock, []string{"01:NFT.Minted.owner"})
+		assert.ErrorContains(t, err, "event A.f8d6e0586b0a20c7.NFT.Minted has no field owner")
+	})
+}
+
+func Test_Where(t *testing.T) {
+	amount := func(value string) cadence.UFix64 {
+		v, _ := cadence.NewUFix64(value)
+		return v
+	}
+	deposit := func(value string, to cadence.Value) flow.Event {
+		return *tests.NewEvent(
+			0,
+			"A.1654653399040a61.FlowToken.TokensDeposited",
+			[]cadence.Field{
+				{Type: cadence.UFix64Type{}, Identifier: "amount"},
+				{Type: &cadence.OptionalType{Type: cadence.AddressType{}}, Identifier: "to"},
+			},
+			[]cadence.Value{amount(value), to},
+		)
+	}
+	alice := cadence.NewOptional(cadence.NewAddress(flow.HexToAddress("0x01cf0e2f2f715450")))
+
+	t.Run("Success", func(t *testing.T) {
+		events := []flow.BlockEvents{{
+			Height: 1,
+			Events: []flow.Event{
+				deposit("50.0", alice),
+				deposit("150.0", alice),
+				deposit("200.0", cadence.NewOptional(nil)),
+			},
+		}}
+
+		predicates, err := parsePredicates([]string{"amount>100.0", "to==01cf0e2f2f715450"})
+		require.NoError(t, err)
+
+		filtered := filterEvents(events, predicates)
+		require.Len(t, filtered[0].Events, 1)
+		assert.Equal(t, events[0].Events[1], filtered[0].Events[0])
+
+		predicates, err = parsePredicates([]string{"amount <= 150", "to != nil"})
+		require.NoError(t, err)
+		assert.Len(t, filterEvents(events, predicates)[0].Events, 2)
+	})
+
+	t.Run("Missing field", func(t *testing.T) {
+		predicates, err := parsePredicates([]string{"from==0x01"})
+		require.NoError(t, err)
+		assert.False(t, predicates[0].matches(deposit("1.0", alice)))
+	})
+
+	t.Run("Fail invalid predicate", func(t *testing.T) {
+		_, err := parsePredicates([]string{"amount"})
+		assert.EqualError(t, err, "invalid where predicate amount, use the field<operator>value format with one of the operators == != >= <= > <")
+	})
 }
@@ -21,20 +21,30 @@ package events
 import (
 	"context"
 	"fmt"
+	"runtime"
 
+	"github.com/dustin/go-humanize"
+	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsEvents struct {
-	Start   uint64 `flag:"start" info:"Start block height"`
-	End     uint64 `flag:"end" info:"End block height"`
-	Last    uint64 `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
-	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
-	Batch   uint64 `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+	Start         uint64   `flag:"start" info:"Start block height"`
+	End           uint64   `flag:"end" info:"End block height"`
+	Last          uint64   `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
+	Workers       int      `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+	Batch         uint64   `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+	Since         string   `default:"" flag:"since" info:"Fetch events from blocks at or after the date, in the YYYY-MM-DD or RFC3339 format"`
+	Until         string   `default:"" flag:"until" info:"Fetch events from blocks before the date, in the YYYY-MM-DD or RFC3339 format"`
+	Confirmations uint64   `default:"0" flag:"confirmations" info:"Only fetch events from blocks at least this number of blocks below the latest sealed block"`
+	Where         []string `default:"" flag:"where" info:"Only return events with a field matching the predicate in the field<operator>value format, operators are == != > >= < <=, can be provided multiple times"`
+	MaxMemory     string   `default:"" flag:"max-memory" info:"Stop fetching events when the memory used exceeds the size (e.g. 512MB), the events fetched until then are returned with the height to resume from"`
+	MaxDisk       string   `default:"" flag:"max-disk" info:"Stop fetching events when the size of the fetched event payloads would exceed the size (e.g. 512MB), limiting the size of the saved output, the events fetched until then are returned with the height to resume from"`
 }
 
 var eventsFlags = flagsEvents{}
@@ -53,6 +63,21 @@ flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11559500 --
 #in order to get and event from the 20 latest blocks on a network run
 flow events get A.1654653399040a61.FlowToken.TokensDeposited --last 20 --network mainnet
 
+#fetch events of a date range, the dates are converted to block heights
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --since 2024-01-01 --until 2024-02-01 --network mainnet
+
+#filter events by the decoded field values, all predicates must match
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --where 'amount>100.0' --where 'to==0x1654653399040a61'
+
+#stop fetching a large range before running out of memory, the height to resume from is printed
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11000000 --end 12000000 --max-memory 1GB
+
+#stop fetching before the saved events exceed a size on disk
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11000000 --end 12000000 --max-disk 2GB --save deposits.json
+
+#export events in a compact binary format, cbor or length-delimited Access API EventsResponse.Result protobuf messages
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11000000 --end 11100000 -o proto --save deposits.pb
+
 #if you want to fetch multiple event types that is done by sending in more events. Even fetching will be done in parallel.
 flow events get A.1654653399040a61.FlowToken.TokensDeposited A.1654653399040a61.FlowToken.TokensWithdrawn
 	`,
@@ -68,13 +93,41 @@ func get(
 	_ flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
-	var err error
+	predicates, err := parsePredicates(eventsFlags.Where)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits eventLimits
+	if eventsFlags.MaxMemory != "" {
+		limits.memory, err = humanize.ParseBytes(eventsFlags.MaxMemory)
+		if err != nil || limits.memory == 0 {
+			return nil, fmt.Errorf("invalid max memory %s, provide a size such as 512MB or 2GB", eventsFlags.MaxMemory)
+		}
+	}
+	if eventsFlags.MaxDisk != "" {
+		limits.disk, err = humanize.ParseBytes(eventsFlags.MaxDisk)
+		if err != nil || limits.disk == 0 {
+			return nil, fmt.Errorf("invalid max disk %s, provide a size such as 512MB or 2GB", eventsFlags.MaxDisk)
+		}
+	}
+
 	start := eventsFlags.Start
 	end := eventsFlags.End
 	last := eventsFlags.Last
 
-	// handle if not passing start and end
-	if start == 0 && end == 0 {
+	if eventsFlags.Since != "" || eventsFlags.Until != "" {
+		if start != 0 || end != 0 {
+			return nil, fmt.Errorf("since and until flags can not be combined with start and end flags")
+		}
+
+		logger.StartProgress("Finding blocks in the date range...")
+		start, end, err = heightRangeFromDates(flow, eventsFlags.Since, eventsFlags.Until, last)
+		logger.StopProgress()
+		if err != nil {
+			return nil, err
+		}
+	} else if start == 0 && end == 0 { // handle if not passing start and end
 		latest, err := flow.GetBlock(
 			context.Background(),
 			flowkit.BlockQuery{Latest: true},
@@ -92,22 +145,117 @@ func get(
 		return nil, fmt.Errorf("please provide either both start and end for range or only last flag")
 	}
 
+	end, err = util.ConfirmedRange(flow, logger, start, end, eventsFlags.Confirmations)
+	if err != nil {
+		return nil, err
+	}
+
 	logger.StartProgress("Fetching events...")
 	defer logger.StopProgress()
 
-	events, err := flow.GetEvents(
-		context.Background(),
-		args,
-		start,
-		end,
-		&flowkit.EventWorker{
-			Count:           eventsFlags.Workers,
-			BlocksPerWorker: eventsFlags.Batch,
-		},
-	)
-	if err != nil {
-		return nil, err
+	worker := &flowkit.EventWorker{
+		Count:           eventsFlags.Workers,
+		BlocksPerWorker: eventsFlags.Batch,
+	}
+
+	var events []flowsdk.BlockEvents
+	if limits == (eventLimits{}) {
+		events, err = flow.GetEvents(context.Background(), args, start, end, worker)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var resume uint64
+		var exceeded string
+		events, resume, exceeded, err = getEventsWithinLimits(flow, args, start, end, worker, limits)
+		if err != nil {
+			return nil, err
+		}
+		if resume != 0 {
+			limit := eventsFlags.MaxMemory
+			if exceeded == "disk" {
+				limit = eventsFlags.MaxDisk
+			}
+			logger.Info(fmt.Sprintf(
+				"%s Stopped fetching events at the %s limit of %s, resume with --start %d --end %d",
+				output.WarningEmoji(),
+				exceeded,
+				limit,
+				resume,
+				end,
+			))
+		}
+	}
+
+	return &EventResult{BlockEvents: filterEvents(events, predicates)}, nil
+}
+
+// memoryUsage returns the bytes allocated by the process.
+var memoryUsage = func() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// eventLimits are the limits of the resources used by the fetched events, zero limits are not enforced.
+//
+// The disk limit applies to the size of the event payloads, which the size of the output grows with.
+type eventLimits struct {
+	memory uint64
+	disk   uint64
+}
+
+// payloadSize returns the bytes of the payloads of the events.
+func payloadSize(blocks []flowsdk.BlockEvents) uint64 {
+	size := uint64(0)
+	for _, block := range blocks {
+		for _, event := range block.Events {
+			size += uint64(len(event.Payload))
+		}
+	}
+	return size
+}
+
+// getEventsWithinLimits fetches the events in chunks of the blocks fetched by all the workers at once
+// and stops when the memory used exceeds the limit, or before the payloads of the events would exceed
+// the disk limit. The height of the first block not fetched and the exceeded limit are returned if the
+// fetching stopped before the end of the range.
+func getEventsWithinLimits(
+	flow flowkit.Services,
+	names []string,
+	start uint64,
+	end uint64,
+	worker *flowkit.EventWorker,
+	limits eventLimits,
+) ([]flowsdk.BlockEvents, uint64, string, error) {
+	chunk := uint64(worker.Count) * worker.BlocksPerWorker
+	if chunk == 0 {
+		chunk = 1
+	}
+
+	var events []flowsdk.BlockEvents
+	size := uint64(0)
+	for height := start; height <= end; height += chunk {
+		chunkEnd := height + chunk - 1
+		if chunkEnd > end {
+			chunkEnd = end
+		}
+
+		chunkEvents, err := flow.GetEvents(context.Background(), names, height, chunkEnd, worker)
+		if err != nil {
+			return nil, 0, "", err
+		}
+
+		size += payloadSize(chunkEvents)
+		if limits.disk != 0 && size > limits.disk {
+			return events, height, "disk", nil
+		}
+		events = append(events, chunkEvents...)
+
+		if chunkEnd < end && limits.memory != 0 && memoryUsage() > limits.memory {
+			return events, chunkEnd + 1, "memory", nil
+		}
 	}
 
-	return &EventResult{BlockEvents: events}, nil
+	return events, 0, "", nil
 }
@@ -0,0 +1,114 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// dateLayouts are the accepted formats of the since and until flags.
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %s, use the YYYY-MM-DD or RFC3339 format", value)
+}
+
+// heightAtTime returns the height of the first block with a timestamp at or after the time,
+// it returns latest+1 if all blocks are older.
+//
+// Block timestamps only increase with the height, so the height is found with a binary search.
+// Blocks not found, like the ones before the spork root, are treated as older.
+func heightAtTime(flow flowkit.Services, at time.Time, latest uint64) (uint64, error) {
+	low, high := uint64(0), latest+1
+	for low < high {
+		mid := low + (high-low)/2
+
+		block, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{Height: mid})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return 0, err
+		}
+		if err != nil || block.Timestamp.Before(at) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	return low, nil
+}
+
+// heightRangeFromDates converts the since and until dates to an inclusive height range,
+// until defaults to the latest block and since to the last number of blocks before until.
+func heightRangeFromDates(flow flowkit.Services, since string, until string, last uint64) (uint64, uint64, error) {
+	latest, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{Latest: true})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end := latest.Height
+	if until != "" {
+		untilTime, err := parseDate(until)
+		if err != nil {
+			return 0, 0, err
+		}
+		untilHeight, err := heightAtTime(flow, untilTime, latest.Height)
+		if err != nil {
+			return 0, 0, err
+		}
+		if untilHeight == 0 {
+			return 0, 0, fmt.Errorf("no blocks found before %s", until)
+		}
+		end = untilHeight - 1
+	}
+
+	start := uint64(0)
+	if end > last {
+		start = end - last
+	}
+	if since != "" {
+		sinceTime, err := parseDate(since)
+		if err != nil {
+			return 0, 0, err
+		}
+		start, err = heightAtTime(flow, sinceTime, latest.Height)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if start > end {
+		return 0, 0, fmt.Errorf("no blocks found between %s and %s", since, until)
+	}
+
+	return start, end, nil
+}
@@ -0,0 +1,179 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// whereOperators are the supported comparison operators, two character operators are matched first.
+var whereOperators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// predicate compares a decoded event field with a value.
+type predicate struct {
+	field    []string
+	operator string
+	value    string
+}
+
+// parsePredicates parses predicates in the field<operator>value format, nested fields are separated by dots.
+func parsePredicates(wheres []string) ([]predicate, error) {
+	predicates := make([]predicate, 0, len(wheres))
+	for _, where := range wheres {
+		p, err := parsePredicate(where)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, p)
+	}
+
+	return predicates, nil
+}
+
+func parsePredicate(where string) (predicate, error) {
+	for _, operator := range whereOperators {
+		field, value, found := strings.Cut(where, operator)
+		if !found {
+			continue
+		}
+
+		field = strings.TrimSpace(field)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		if field == "" || value == "" {
+			break
+		}
+
+		return predicate{field: strings.Split(field, "."), operator: operator, value: value}, nil
+	}
+
+	return predicate{}, fmt.Errorf(
+		"invalid where predicate %s, use the field<operator>value format with one of the operators %s",
+		where,
+		strings.Join(whereOperators, " "),
+	)
+}
+
+// matches returns true if the event has the field and its value satisfies the predicate.
+func (p predicate) matches(event flow.Event) bool {
+	var value cadence.Value = event.Value
+	for _, name := range p.field {
+		composite, ok := unwrapOptional(value).(cadence.HasFields)
+		if !ok {
+			return false
+		}
+		value, ok = cadence.GetFieldsMappedByName(composite)[name]
+		if !ok {
+			return false
+		}
+	}
+	value = unwrapOptional(value)
+
+	if fieldNumber, ok := number(value); ok {
+		expected, ok := new(big.Rat).SetString(p.value)
+		if !ok {
+			return false
+		}
+		return p.compare(fieldNumber.Cmp(expected))
+	}
+
+	if p.operator != "==" && p.operator != "!=" {
+		return false // only numbers are ordered
+	}
+
+	equal := argumentString(value) == p.value
+	if address, ok := value.(cadence.Address); ok {
+		equal = flow.Address(address) == flow.HexToAddress(p.value)
+	}
+
+	return equal == (p.operator == "==")
+}
+
+func (p predicate) compare(cmp int) bool {
+	switch p.operator {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp < 0
+	}
+}
+
+func unwrapOptional(value cadence.Value) cadence.Value {
+	for {
+		optional, ok := value.(cadence.Optional)
+		if !ok || optional.Value == nil {
+			return value
+		}
+		value = optional.Value
+	}
+}
+
+// number returns the value of Cadence integers and fixed point numbers.
+func number(value cadence.Value) (*big.Rat, bool) {
+	switch value.(type) {
+	case cadence.Int, cadence.Int8, cadence.Int16, cadence.Int32, cadence.Int64, cadence.Int128, cadence.Int256,
+		cadence.UInt, cadence.UInt8, cadence.UInt16, cadence.UInt32, cadence.UInt64, cadence.UInt128, cadence.UInt256,
+		cadence.Word8, cadence.Word16, cadence.Word32, cadence.Word64, cadence.Fix64, cadence.UFix64:
+		return new(big.Rat).SetString(value.String())
+	default:
+		return nil, false
+	}
+}
+
+// filterEvents returns the block events with only the events matching all predicates.
+func filterEvents(blockEvents []flow.BlockEvents, predicates []predicate) []flow.BlockEvents {
+	if len(predicates) == 0 {
+		return blockEvents
+	}
+
+	filtered := make([]flow.BlockEvents, 0, len(blockEvents))
+	for _, blockEvent := range blockEvents {
+		events := make([]flow.Event, 0)
+		for _, event := range blockEvent.Events {
+			if matchesAll(event, predicates) {
+				events = append(events, event)
+			}
+		}
+		blockEvent.Events = events
+		filtered = append(filtered, blockEvent)
+	}
+
+	return filtered
+}
+
+func matchesAll(event flow.Event, predicates []predicate) bool {
+	for _, p := range predicates {
+		if !p.matches(event) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,248 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// batchKey is a generated key pair with the optional address of the account created for it.
+type batchKey struct {
+	Address    string `json:"address,omitempty"`
+	PublicKey  string `json:"publicKey"`
+	PrivateKey string `json:"privateKey"`
+	SigAlgo    string `json:"sigAlgo"`
+	HashAlgo   string `json:"hashAlgo"`
+}
+
+// generateBatch generates the number of key pairs using random seeds, optionally creates
+// an account for each key and writes the keys to a CSV or JSON file.
+func generateBatch(
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+	sigAlgo crypto.SignatureAlgorithm,
+) (command.Result, error) {
+	if generateFlags.Mnemonic != "" {
+		return nil, fmt.Errorf("mnemonic flag can not be used with the batch flag, each key is generated from a random seed")
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(generateFlags.KeyHashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", generateFlags.KeyHashAlgo)
+	}
+
+	format, err := batchFormat(generateFlags.BatchFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var signer *accounts.Account
+	if generateFlags.CreateAccounts {
+		state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+		if errors.Is(err, config.ErrDoesNotExist) {
+			return nil, fmt.Errorf("creating accounts requires a project configuration with the signer account")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err = util.AccountByName(state, generateFlags.Signer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]batchKey, 0, generateFlags.Batch)
+	for i := 0; i < generateFlags.Batch; i++ {
+		privateKey, err := flow.GenerateKey(context.Background(), sigAlgo, "")
+		if err != nil {
+			return nil, err
+		}
+
+		key := batchKey{
+			PublicKey:  hex.EncodeToString(privateKey.PublicKey().Encode()),
+			PrivateKey: hex.EncodeToString(privateKey.Encode()),
+			SigAlgo:    sigAlgo.String(),
+			HashAlgo:   hashAlgo.String(),
+		}
+
+		if signer != nil {
+			logger.Info(fmt.Sprintf("Creating account %d of %d", i+1, generateFlags.Batch))
+			account, _, err := flow.CreateAccount(context.Background(), signer, []accounts.PublicKey{{
+				Public:   privateKey.PublicKey(),
+				Weight:   flowsdk.AccountKeyWeightThreshold,
+				SigAlgo:  sigAlgo,
+				HashAlgo: hashAlgo,
+			}})
+			if err != nil {
+				// keys of already created accounts are still written so they are not lost
+				_ = writeBatch(readerWriter, generateFlags.BatchFile, format, keys)
+				return nil, fmt.Errorf("failed to create account for key %d: %w", i+1, err)
+			}
+			key.Address = fmt.Sprintf("0x%s", account.Address.Hex())
+		}
+
+		keys = append(keys, key)
+	}
+
+	err = writeBatch(readerWriter, generateFlags.BatchFile, format, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchResult{keys: keys, file: generateFlags.BatchFile}, nil
+}
+
+// batchFormat returns the export format from the file extension, it's empty if no file is provided.
+func batchFormat(file string) (string, error) {
+	if file == "" {
+		return "", nil
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+	if format != "csv" && format != "json" {
+		return "", fmt.Errorf("unsupported batch file %s, use a .csv or .json file", file)
+	}
+
+	return format, nil
+}
+
+func writeBatch(readerWriter flowkit.ReaderWriter, file string, format string, keys []batchKey) error {
+	if file == "" {
+		return nil
+	}
+
+	var data []byte
+	var err error
+	if format == "json" {
+		data, err = json.MarshalIndent(keys, "", "  ")
+	} else {
+		data, err = batchCSV(keys)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = readerWriter.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write keys to %s: %w", file, err)
+	}
+
+	return nil
+}
+
+func batchCSV(keys []batchKey) ([]byte, error) {
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+
+	records := [][]string{{"index", "address", "public_key", "private_key", "sig_algo", "hash_algo"}}
+	for i, key := range keys {
+		records = append(records, []string{
+			strconv.Itoa(i),
+			key.Address,
+			key.PublicKey,
+			key.PrivateKey,
+			key.SigAlgo,
+			key.HashAlgo,
+		})
+	}
+
+	err := writer.WriteAll(records)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+type batchResult struct {
+	keys []batchKey
+	file string
+}
+
+func (r *batchResult) JSON() any {
+	if r.file != "" {
+		return map[string]any{
+			"count": len(r.keys),
+			"file":  r.file,
+		}
+	}
+
+	return r.keys
+}
+
+func (r *batchResult) String() string {
+	if r.file != "" {
+		return fmt.Sprintf(
+			"%s Generated %d keys written to %s, store the file safely and don't share it with anyone!",
+			output.SuccessEmoji(),
+			len(r.keys),
+			r.file,
+		)
+	}
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Store private keys safely and don't share with anyone! \n", output.StopEmoji())
+	_, _ = fmt.Fprintf(writer, "Address\tPublic Key\tPrivate Key\tSignature Algorithm\tHash Algorithm\n")
+	for _, key := range r.keys {
+		address := key.Address
+		if address == "" {
+			address = "-"
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", address, key.PublicKey, key.PrivateKey, key.SigAlgo, key.HashAlgo)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *batchResult) Oneliner() string {
+	if r.file != "" {
+		return fmt.Sprintf("count: %d, file: %s", len(r.keys), r.file)
+	}
+
+	keys := make([]string, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, key.PublicKey)
+	}
+	return strings.Join(keys, ", ")
+}
@@ -35,6 +35,7 @@ import (
 type flagsDecode struct {
 	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
 	FromFile string `default:"" flag:"from-file" info:"Load key from file"`
+	RLP      string `default:"" flag:"rlp" info:"RLP encoded account key in hex to decode"`
 }
 
 var decodeFlags = flagsDecode{}
@@ -43,9 +44,10 @@ var decodeCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:       "decode <rlp|pem> <encoded public key>",
 		Short:     "Decode an encoded public key",
-		Args:      cobra.RangeArgs(1, 2),
+		Args:      cobra.RangeArgs(0, 2),
 		ValidArgs: []string{"rlp", "pem"},
-		Example:   "flow keys decode rlp f847b8408...2402038203e8",
+		Example: `flow keys decode rlp f847b8408...2402038203e8
+flow keys decode --rlp 0xf847b8408...2402038203e8`,
 	},
 	Flags: &decodeFlags,
 	Run:   decode,
@@ -58,6 +60,16 @@ func decode(
 	reader flowkit.ReaderWriter,
 	_ flowkit.Services,
 ) (command.Result, error) {
+	if decodeFlags.RLP != "" {
+		if len(args) > 0 || decodeFlags.FromFile != "" {
+			return nil, fmt.Errorf("can not pass both rlp flag and command arguments or from file flag")
+		}
+		args = []string{"rlp", decodeFlags.RLP}
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("provide the encoding and the encoded key arguments or use the rlp flag")
+	}
+
 	encoding := args[0]
 	fromFile := decodeFlags.FromFile
 
@@ -104,10 +116,11 @@ func decode(
 	}
 
 	return &keyResult{
-		publicKey: accountKey.PublicKey,
-		sigAlgo:   accountKey.SigAlgo,
-		hashAlgo:  accountKey.HashAlgo,
-		weight:    accountKey.Weight,
+		publicKey:   accountKey.PublicKey,
+		sigAlgo:     accountKey.SigAlgo,
+		hashAlgo:    accountKey.HashAlgo,
+		weight:      accountKey.Weight,
+		fingerprint: keyFingerprint(accountKey.PublicKey),
 	}, err
 }
 
@@ -125,7 +138,7 @@ func decodePEM(pubKey string, sigAlgo crypto.SignatureAlgorithm) (*flow.AccountK
 }
 
 func decodeRLP(pubKey string) (*flow.AccountKey, error) {
-	publicKeyBytes, err := hex.DecodeString(pubKey)
+	publicKeyBytes, err := hex.DecodeString(strings.TrimPrefix(pubKey, "0x"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode public key: %w", err)
 	}
@@ -19,6 +19,7 @@
 package keys
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -30,17 +31,24 @@ import (
 )
 
 type flagsDerive struct {
-	KeySigAlgo string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	KeySigAlgo     string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	Mnemonic       string `default:"" flag:"mnemonic" info:"Mnemonic seed to derive the key from"`
+	Index          uint32 `default:"0" flag:"index" info:"Index of the key derived from the mnemonic on the Flow derivation path"`
+	DerivationPath string `default:"" flag:"derivationPath" info:"Derivation path of the key derived from the mnemonic, overrides the index"`
 }
 
+// flowDerivationPath is the BIP44 path of Flow keys, the last component is the key index.
+const flowDerivationPath = "m/44'/539'/0'/0/%d"
+
 var deriveFlags = flagsDerive{}
 
 var deriveCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "derive <encoded private key>",
-		Short:   "Derive public key from a private key",
-		Args:    cobra.ExactArgs(1),
-		Example: "flow keys derive 4247b8408...2402038203e8",
+		Use:   "derive [<encoded private key>]",
+		Short: "Derive public key from a private key or a key pair from a mnemonic",
+		Args:  cobra.MaximumNArgs(1),
+		Example: `flow keys derive 4247b8408...2402038203e8
+flow keys derive --mnemonic "witch collapse practice ... feed shame open" --index 2`,
 	},
 	Flags: &deriveFlags,
 	Run:   derive,
@@ -51,7 +59,7 @@ func derive(
 	_ command.GlobalFlags,
 	_ output.Logger,
 	_ flowkit.ReaderWriter,
-	_ flowkit.Services,
+	flow flowkit.Services,
 ) (command.Result, error) {
 
 	sigAlgo := crypto.StringToSignatureAlgorithm(deriveFlags.KeySigAlgo)
@@ -59,6 +67,19 @@ func derive(
 		return nil, fmt.Errorf("invalid signature algorithm: %s", deriveFlags.KeySigAlgo)
 	}
 
+	if deriveFlags.Mnemonic != "" {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("can not pass both private key argument and mnemonic flag")
+		}
+		return deriveFromMnemonic(flow, sigAlgo)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("provide argument for encoded private key or use the mnemonic flag")
+	}
+	if deriveFlags.Index != 0 || deriveFlags.DerivationPath != "" {
+		return nil, fmt.Errorf("index and derivation path flags require the mnemonic flag")
+	}
+
 	parsedPrivateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, args[0])
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode private key: %w", err)
@@ -66,3 +87,25 @@ func derive(
 
 	return &keyResult{privateKey: parsedPrivateKey, publicKey: parsedPrivateKey.PublicKey()}, nil
 }
+
+// deriveFromMnemonic derives the key pair at the index or derivation path, recovering the keys generated by wallets from the same mnemonic.
+func deriveFromMnemonic(flow flowkit.Services, sigAlgo crypto.SignatureAlgorithm) (command.Result, error) {
+	path := deriveFlags.DerivationPath
+	if path == "" {
+		path = fmt.Sprintf(flowDerivationPath, deriveFlags.Index)
+	} else if deriveFlags.Index != 0 {
+		return nil, fmt.Errorf("can not pass both index and derivation path flags")
+	}
+
+	privateKey, err := flow.DerivePrivateKeyFromMnemonic(context.Background(), deriveFlags.Mnemonic, sigAlgo, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive private key: %w", err)
+	}
+
+	return &keyResult{
+		privateKey:     privateKey,
+		publicKey:      privateKey.PublicKey(),
+		sigAlgo:        sigAlgo,
+		derivationPath: path,
+	}, nil
+}
@@ -0,0 +1,253 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsExport struct {
+	PrivateKey string `default:"" flag:"private-key" info:"Private key in hex to export instead of the key of a configured account"`
+	KeySigAlgo string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the private key"`
+	Keystore   string `default:"" flag:"keystore" info:"Path of the keystore file to write, defaults to <account>.keystore.json"`
+	Keychain   string `default:"" flag:"keychain" info:"Store the private key in the operating system keychain under this item name instead of a keystore file"`
+	Replace    bool   `default:"false" flag:"replace" info:"Replace the key of the account in the configuration with the exported key, and the key already stored in the keychain item"`
+}
+
+var exportFlags = flagsExport{}
+
+var exportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "export [<account>]",
+		Short: "Export a private key to a passphrase encrypted keystore file or the keychain",
+		Long: `Export the private key of a configured account or the provided private key to a keystore file
+encrypted with a passphrase, or to the operating system keychain with the --keychain flag.
+
+The passphrase is read from the --password-file flag, the FLOW_KEYSTORE_PASSWORD environment variable or
+a prompt. With the --replace flag the account key in the configuration is replaced by the exported key.
+A key already stored in the keychain item is only replaced with the --replace flag or after confirming it.`,
+		Args: cobra.MaximumNArgs(1),
+		Example: `flow keys export alice --replace
+flow keys export --private-key 4247b8408...2402038203e8 --keystore bob.keystore.json --password-file bob.password
+flow keys export alice --keychain alice --replace`,
+	},
+	Flags:  &exportFlags,
+	Output: keystoreOutput{},
+	Run:    export,
+}
+
+func export(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	if len(args) > 0 && exportFlags.PrivateKey != "" {
+		return nil, fmt.Errorf("can not pass both account argument and private key flag")
+	}
+	if len(args) == 0 && exportFlags.PrivateKey == "" {
+		return nil, fmt.Errorf("provide the account name argument or the private key flag")
+	}
+	if exportFlags.Replace && len(args) == 0 {
+		return nil, fmt.Errorf("replacing the key requires the account name argument")
+	}
+	if exportFlags.Keychain != "" && exportFlags.Keystore != "" {
+		return nil, fmt.Errorf("can not pass both keystore and keychain flags")
+	}
+
+	var state *flowkit.State
+	var account *accounts.Account
+	var privateKey crypto.PrivateKey
+	location := exportFlags.Keystore
+
+	if len(args) > 0 {
+		var err error
+		state, err = flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+		if err != nil {
+			return nil, err
+		}
+
+		account, err = state.Accounts().ByName(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := account.Key.PrivateKey()
+		if err != nil {
+			return nil, fmt.Errorf("the key of the account %s can't be exported: %w", account.Name, err)
+		}
+		privateKey = *key
+
+		if location == "" && exportFlags.Keychain == "" {
+			location = fmt.Sprintf("%s.keystore.json", account.Name)
+		}
+	} else {
+		sigAlgo := crypto.StringToSignatureAlgorithm(exportFlags.KeySigAlgo)
+		if sigAlgo == crypto.UnknownSignatureAlgorithm {
+			return nil, fmt.Errorf("invalid signature algorithm: %s", exportFlags.KeySigAlgo)
+		}
+
+		var err error
+		privateKey, err = crypto.DecodePrivateKeyHex(sigAlgo, exportFlags.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode private key: %w", err)
+		}
+
+		if location == "" && exportFlags.Keychain == "" {
+			return nil, fmt.Errorf("provide the path of the keystore file with the keystore flag")
+		}
+	}
+
+	if exportFlags.Keychain != "" {
+		return exportKeychain(globalFlags, logger, state, account, privateKey)
+	}
+
+	password, err := util.KeystorePassword(readerWriter, globalFlags.PasswordFile, true)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := accounts.EncryptKeystore(privateKey, password)
+	if err != nil {
+		return nil, err
+	}
+
+	err = readerWriter.WriteFile(location, data, os.FileMode(0600))
+	if err != nil {
+		return nil, fmt.Errorf("failed saving the keystore: %w", err)
+	}
+
+	result := &keystoreResult{location: location, publicKey: privateKey.PublicKey()}
+	if !exportFlags.Replace {
+		return result, nil
+	}
+
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    account.Name,
+		Address: account.Address,
+		Key: accounts.NewKeystoreKey(
+			location,
+			globalFlags.PasswordFile,
+			account.Key.Index(),
+			account.Key.SigAlgo(),
+			account.Key.HashAlgo(),
+		),
+	})
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("The key of the account %s is replaced by the keystore %s in the configuration", account.Name, location))
+	return result, nil
+}
+
+// exportKeychain stores the private key in the keychain, replacing the account key if the replace flag is set.
+func exportKeychain(
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	state *flowkit.State,
+	account *accounts.Account,
+	privateKey crypto.PrivateKey,
+) (command.Result, error) {
+	err := util.StoreKeychainKey(exportFlags.Keychain, privateKey, exportFlags.Replace)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &keystoreResult{keychain: exportFlags.Keychain, publicKey: privateKey.PublicKey()}
+	if !exportFlags.Replace {
+		return result, nil
+	}
+
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    account.Name,
+		Address: account.Address,
+		Key: accounts.NewKeychainKey(
+			exportFlags.Keychain,
+			account.Key.Index(),
+			account.Key.SigAlgo(),
+			account.Key.HashAlgo(),
+		),
+	})
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("The key of the account %s is replaced by the keychain item %s in the configuration", account.Name, exportFlags.Keychain))
+	return result, nil
+}
+
+type keystoreResult struct {
+	location  string
+	keychain  string
+	publicKey crypto.PublicKey
+}
+
+// keystoreOutput is the JSON output of the export command.
+type keystoreOutput struct {
+	Keystore  string `json:"keystore,omitempty"`
+	Keychain  string `json:"keychain,omitempty"`
+	PublicKey string `json:"public"`
+}
+
+func (k *keystoreResult) JSON() any {
+	return keystoreOutput{
+		Keystore:  k.location,
+		Keychain:  k.keychain,
+		PublicKey: fmt.Sprintf("%x", k.publicKey.Encode()),
+	}
+}
+
+func (k *keystoreResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if k.keychain != "" {
+		_, _ = fmt.Fprintf(writer, "Keychain Item\t %s\n", k.keychain)
+	} else {
+		_, _ = fmt.Fprintf(writer, "Keystore\t %s\n", k.location)
+	}
+	_, _ = fmt.Fprintf(writer, "Public Key\t %x\n", k.publicKey.Encode())
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (k *keystoreResult) Oneliner() string {
+	if k.keychain != "" {
+		return k.keychain
+	}
+	return k.location
+}
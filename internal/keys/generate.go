@@ -24,6 +24,7 @@ import (
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
+	"github.com/tyler-smith/go-bip39"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
@@ -32,17 +33,25 @@ import (
 
 type flagsGenerate struct {
 	Mnemonic       string `flag:"mnemonic" info:"Mnemonic seed to use"`
+	Words          int    `default:"12" flag:"words" info:"Number of words of the generated mnemonic, 12 or 24"`
 	DerivationPath string `default:"m/44'/539'/0'/0/0" flag:"derivationPath" info:"Derivation path"`
 	KeySigAlgo     string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	KeyHashAlgo    string `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm recorded with batch keys and used for created accounts"`
+	Batch          int    `default:"0" flag:"batch" info:"Number of key pairs to generate from random seeds"`
+	BatchFile      string `default:"" flag:"batch-file" info:"CSV or JSON file the batch keys are written to, the format is chosen by the extension"`
+	CreateAccounts bool   `default:"false" flag:"create-accounts" info:"Create an account for each batch key"`
+	Signer         string `default:"emulator-account" flag:"signer" info:"Account name from configuration used to create the accounts"`
 }
 
 var generateFlags = flagsGenerate{}
 
 var generateCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "generate",
-		Short:   "Generate a new key-pair",
-		Example: "flow keys generate",
+		Use:   "generate",
+		Short: "Generate a new key-pair",
+		Example: `flow keys generate
+flow keys generate --words 24 --derivationPath "m/44'/539'/0'/0/1"
+flow keys generate --batch 100 --batch-file keys.csv --create-accounts --signer emulator-account`,
 	},
 	Flags: &generateFlags,
 	Run:   generate,
@@ -50,9 +59,9 @@ var generateCommand = &command.Command{
 
 func generate(
 	_ []string,
-	_ command.GlobalFlags,
-	_ output.Logger,
-	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
 	sigAlgo := crypto.StringToSignatureAlgorithm(generateFlags.KeySigAlgo)
@@ -60,10 +69,24 @@ func generate(
 		return nil, fmt.Errorf("invalid signature algorithm: %s", generateFlags.KeySigAlgo)
 	}
 
+	if generateFlags.Batch > 0 {
+		return generateBatch(globalFlags, logger, readerWriter, flow, sigAlgo)
+	}
+	if generateFlags.BatchFile != "" || generateFlags.CreateAccounts {
+		return nil, fmt.Errorf("batch file and create accounts flags require the batch flag")
+	}
+
 	var err error
 	mnemonic := generateFlags.Mnemonic
 	if mnemonic == "" {
-		_, mnemonic, err = flow.GenerateMnemonicKey(context.Background(), sigAlgo, generateFlags.DerivationPath)
+		switch generateFlags.Words {
+		case 12:
+			_, mnemonic, err = flow.GenerateMnemonicKey(context.Background(), sigAlgo, generateFlags.DerivationPath)
+		case 24:
+			mnemonic, err = newMnemonic(256)
+		default:
+			return nil, fmt.Errorf("invalid number of mnemonic words %d, valid values are: 12, 24", generateFlags.Words)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -87,3 +110,13 @@ func generate(
 		derivationPath: generateFlags.DerivationPath,
 	}, nil
 }
+
+// newMnemonic generates a BIP39 mnemonic from random entropy of the provided size in bits.
+func newMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
@@ -0,0 +1,130 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsImport struct {
+	Name     string `default:"" flag:"name" info:"Name of the account to add to the configuration with the keystore key"`
+	Address  string `default:"" flag:"address" info:"Address of the account to add"`
+	KeyIndex int    `default:"0" flag:"key-index" info:"Index of the key on the account to add"`
+	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm of the key on the account to add"`
+}
+
+var importFlags = flagsImport{}
+
+var importCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "import <keystore>",
+		Short: "Decrypt a keystore file or add an account signing with it",
+		Long: `Decrypt the private key of a passphrase encrypted keystore file, or add an account to the configuration
+that signs with the keystore key instead of storing the private key.
+
+The passphrase is read from the --password-file flag, the FLOW_KEYSTORE_PASSWORD environment variable or
+a prompt. The password file of an added account is saved to the configuration.`,
+		Args: cobra.ExactArgs(1),
+		Example: `flow keys import alice.keystore.json
+flow keys import alice.keystore.json --name alice --address 0x01cf0e2f2f715450 --password-file alice.password`,
+	},
+	Flags: &importFlags,
+	Run:   importKeystore,
+}
+
+func importKeystore(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	location := args[0]
+	if importFlags.Name != "" && importFlags.Address == "" {
+		return nil, fmt.Errorf("adding the account requires the address flag")
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(importFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", importFlags.HashAlgo)
+	}
+
+	data, err := readerWriter.ReadFile(location)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the keystore %s: %w", location, err)
+	}
+
+	password, err := util.KeystorePassword(readerWriter, globalFlags.PasswordFile, false)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := accounts.DecryptKeystore(data, password)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &keyResult{
+		privateKey: privateKey,
+		publicKey:  privateKey.PublicKey(),
+		sigAlgo:    privateKey.Algorithm(),
+		hashAlgo:   hashAlgo,
+	}
+	if importFlags.Name == "" {
+		return result, nil
+	}
+
+	state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    importFlags.Name,
+		Address: flowsdk.HexToAddress(importFlags.Address),
+		Key: accounts.NewKeystoreKey(
+			location,
+			globalFlags.PasswordFile,
+			importFlags.KeyIndex,
+			privateKey.Algorithm(),
+			hashAlgo,
+		),
+	})
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("Account %s signing with the keystore %s added to the configuration", importFlags.Name, location))
+
+	// the private key stays encrypted when the account is added
+	result.privateKey = nil
+	return result, nil
+}
@@ -0,0 +1,149 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsInspect struct {
+	PublicKey string `default:"" flag:"public-key" info:"Public key in hex, either the raw key or an RLP encoded account key"`
+	SigAlgo   string `default:"" flag:"sig-algo" info:"Signature algorithm of a raw public key, detected from the curve of the key if not provided"`
+}
+
+var inspectFlags = flagsInspect{}
+
+var inspectCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "inspect --public-key <hex>",
+		Short: "Inspect a public key or an encoded account key",
+		Long: `Inspect a raw public key or an RLP encoded account key and report its signature algorithm,
+hash algorithm, weight and fingerprint.
+
+The signature algorithm of a raw public key is detected from the curve the key is on, the hash algorithm
+and weight are only part of account keys. The fingerprint is the SHA-256 digest of the raw public key.`,
+		Args: cobra.NoArgs,
+		Example: `flow keys inspect --public-key 0x84d716c14b0...8c996d342db24
+flow keys inspect --public-key f847b8408...2402038203e8`,
+	},
+	Flags: &inspectFlags,
+	Run:   inspect,
+}
+
+func inspect(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	if inspectFlags.PublicKey == "" {
+		return nil, fmt.Errorf("provide the public key to inspect using the --public-key flag")
+	}
+
+	encoded, err := hex.DecodeString(strings.TrimPrefix(inspectFlags.PublicKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key %s, expected a hex encoded key", inspectFlags.PublicKey)
+	}
+
+	// raw keys are 64 bytes, optionally with the uncompressed point prefix
+	if len(encoded) == 65 && encoded[0] == 0x04 {
+		encoded = encoded[1:]
+	}
+	if len(encoded) == 64 {
+		publicKey, err := decodeRawPublicKey(encoded, inspectFlags.SigAlgo)
+		if err != nil {
+			return nil, err
+		}
+
+		return &keyResult{
+			publicKey:   publicKey,
+			sigAlgo:     publicKey.Algorithm(),
+			fingerprint: keyFingerprint(publicKey),
+		}, nil
+	}
+
+	if inspectFlags.SigAlgo != "" {
+		return nil, fmt.Errorf("the signature algorithm flag is only used with raw public keys")
+	}
+
+	accountKey, err := flow.DecodeAccountKey(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("the key is neither a raw public key nor an RLP encoded account key: %w", err)
+	}
+
+	return &keyResult{
+		publicKey:   accountKey.PublicKey,
+		sigAlgo:     accountKey.SigAlgo,
+		hashAlgo:    accountKey.HashAlgo,
+		weight:      accountKey.Weight,
+		fingerprint: keyFingerprint(accountKey.PublicKey),
+	}, nil
+}
+
+// decodeRawPublicKey decodes the raw public key with the signature algorithm,
+// or with the algorithm of the only curve the key is on if none is provided.
+func decodeRawPublicKey(encoded []byte, sigAlgoFlag string) (crypto.PublicKey, error) {
+	if sigAlgoFlag != "" {
+		sigAlgo := crypto.StringToSignatureAlgorithm(sigAlgoFlag)
+		if sigAlgo == crypto.UnknownSignatureAlgorithm {
+			return nil, fmt.Errorf("invalid signature algorithm: %s", sigAlgoFlag)
+		}
+
+		publicKey, err := crypto.DecodePublicKey(sigAlgo, encoded)
+		if err != nil {
+			return nil, fmt.Errorf("the public key isn't a valid %s key: %w", sigAlgo, err)
+		}
+		return publicKey, nil
+	}
+
+	var found []crypto.PublicKey
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		if publicKey, err := crypto.DecodePublicKey(sigAlgo, encoded); err == nil {
+			found = append(found, publicKey)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("the public key isn't a valid ECDSA_P256 or ECDSA_secp256k1 key")
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("the public key is valid on both curves, provide the signature algorithm using the --sig-algo flag")
+	}
+}
+
+// keyFingerprint returns the SHA-256 digest of the raw public key in the format used by ssh-keygen.
+func keyFingerprint(publicKey crypto.PublicKey) string {
+	digest := sha256.Sum256(publicKey.Encode())
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(digest[:])
+}
@@ -41,6 +41,9 @@ func init() {
 	generateCommand.AddToParent(Cmd)
 	decodeCommand.AddToParent(Cmd)
 	deriveCommand.AddToParent(Cmd)
+	inspectCommand.AddToParent(Cmd)
+	exportCommand.AddToParent(Cmd)
+	importCommand.AddToParent(Cmd)
 }
 
 type keyResult struct {
@@ -51,11 +54,12 @@ type keyResult struct {
 	weight         int
 	mnemonic       string
 	derivationPath string
+	fingerprint    string
 }
 
 func (k *keyResult) JSON() any {
 	result := make(map[string]any)
-	result["public"] = hex.EncodeToString(k.privateKey.PublicKey().Encode())
+	result["public"] = hex.EncodeToString(k.publicKey.Encode())
 
 	if k.privateKey != nil {
 		result["private"] = hex.EncodeToString(k.privateKey.Encode())
@@ -69,6 +73,22 @@ func (k *keyResult) JSON() any {
 		result["derivationPath"] = k.derivationPath
 	}
 
+	if k.sigAlgo != crypto.UnknownSignatureAlgorithm {
+		result["signatureAlgorithm"] = k.sigAlgo.String()
+	}
+
+	if k.hashAlgo != crypto.UnknownHashAlgorithm {
+		result["hashAlgorithm"] = k.hashAlgo.String()
+	}
+
+	if k.weight > 0 {
+		result["weight"] = k.weight
+	}
+
+	if k.fingerprint != "" {
+		result["fingerprint"] = k.fingerprint
+	}
+
 	return result
 }
 
@@ -83,6 +103,10 @@ func (k *keyResult) String() string {
 
 	_, _ = fmt.Fprintf(writer, "Public Key \t %x \n", k.publicKey.Encode())
 
+	if k.fingerprint != "" {
+		_, _ = fmt.Fprintf(writer, "Fingerprint \t %s\n", k.fingerprint)
+	}
+
 	if k.mnemonic != "" {
 		_, _ = fmt.Fprintf(writer, "Mnemonic \t %s \n", k.mnemonic)
 	}
@@ -19,11 +19,20 @@
 package keys
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
 
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -108,6 +117,96 @@ func Test_DecodeKeys(t *testing.T) {
 		result, err := decode(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
 		assert.EqualError(t, err, "can not pass both command argument and from file flag")
 		assert.Nil(t, result)
+		decodeFlags.FromFile = "" // reset to default
+	})
+}
+
+func Test_DecodeRLPFlag(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	encoded := "0xf847b84084d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db2402038203e8"
+
+	t.Run("Success", func(t *testing.T) {
+		decodeFlags.RLP = encoded
+		defer func() { decodeFlags.RLP = "" }()
+
+		result, err := decode(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		decoded := result.JSON().(map[string]any)
+		assert.Equal(t, "ECDSA_P256", decoded["signatureAlgorithm"])
+		assert.Equal(t, "SHA3_256", decoded["hashAlgorithm"])
+		assert.Equal(t, 1000, decoded["weight"])
+		assert.True(t, strings.HasPrefix(decoded["fingerprint"].(string), "SHA256:"))
+	})
+
+	t.Run("Fail flag and arguments", func(t *testing.T) {
+		decodeFlags.RLP = encoded
+		defer func() { decodeFlags.RLP = "" }()
+
+		_, err := decode([]string{"rlp", encoded}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "can not pass both rlp flag and command arguments or from file flag")
+	})
+
+	t.Run("Fail missing key", func(t *testing.T) {
+		_, err := decode(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "provide the encoding and the encoded key arguments or use the rlp flag")
+	})
+}
+
+func Test_InspectKeys(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	rawKey := "84d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db24"
+	rlpKey := "f847b840" + rawKey + "02038203e8"
+
+	inspectKey := func(publicKey string, sigAlgo string) (map[string]any, error) {
+		inspectFlags.PublicKey = publicKey
+		inspectFlags.SigAlgo = sigAlgo
+		defer func() { inspectFlags = flagsInspect{} }()
+
+		result, err := inspect(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		if err != nil {
+			return nil, err
+		}
+		return result.JSON().(map[string]any), nil
+	}
+
+	t.Run("Success raw key", func(t *testing.T) {
+		inspected, err := inspectKey("0x"+rawKey, "")
+		require.NoError(t, err)
+		assert.Equal(t, rawKey, inspected["public"])
+		assert.Equal(t, "ECDSA_P256", inspected["signatureAlgorithm"])
+		assert.NotContains(t, inspected, "hashAlgorithm")
+		assert.NotContains(t, inspected, "weight")
+
+		uncompressed, err := inspectKey("04"+rawKey, "")
+		require.NoError(t, err)
+		assert.Equal(t, inspected, uncompressed)
+	})
+
+	t.Run("Success account key", func(t *testing.T) {
+		inspected, err := inspectKey(rlpKey, "")
+		require.NoError(t, err)
+		assert.Equal(t, rawKey, inspected["public"])
+		assert.Equal(t, "ECDSA_P256", inspected["signatureAlgorithm"])
+		assert.Equal(t, "SHA3_256", inspected["hashAlgorithm"])
+		assert.Equal(t, 1000, inspected["weight"])
+
+		raw, err := inspectKey(rawKey, "")
+		require.NoError(t, err)
+		assert.Equal(t, raw["fingerprint"], inspected["fingerprint"])
+	})
+
+	t.Run("Fail wrong curve", func(t *testing.T) {
+		_, err := inspectKey(rawKey, "ECDSA_secp256k1")
+		assert.ErrorContains(t, err, "the public key isn't a valid ECDSA_secp256k1 key")
+	})
+
+	t.Run("Fail invalid key", func(t *testing.T) {
+		_, err := inspectKey("xyz", "")
+		assert.EqualError(t, err, "invalid public key xyz, expected a hex encoded key")
+
+		_, err = inspectKey("abcd", "")
+		assert.ErrorContains(t, err, "the key is neither a raw public key nor an RLP encoded account key")
 	})
 }
 
@@ -137,6 +236,45 @@ func Test_DeriveKeys(t *testing.T) {
 		assert.EqualError(t, err, "invalid signature algorithm: invalid")
 		assert.Nil(t, result)
 	})
+
+	t.Run("Success mnemonic", func(t *testing.T) {
+		deriveFlags = flagsDerive{KeySigAlgo: "ECDSA_P256", Mnemonic: "test mnemonic", Index: 2}
+		srv.Mock.On("DerivePrivateKeyFromMnemonic", mock.Anything, "test mnemonic", mock.Anything, "m/44'/539'/0'/0/2").
+			Return(tests.PrivKeys()[0], nil).Once()
+
+		result, err := derive(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "m/44'/539'/0'/0/2", result.JSON().(map[string]any)["derivationPath"])
+		assert.NotContains(t, result.JSON(), "mnemonic")
+	})
+
+	t.Run("Fail mnemonic with key", func(t *testing.T) {
+		deriveFlags = flagsDerive{KeySigAlgo: "ECDSA_P256", Mnemonic: "test mnemonic"}
+
+		_, err := derive([]string{"cf3178b20a73846dc8bf6255c79be47178b0744dd8244bcff099e449a9700d7f"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "can not pass both private key argument and mnemonic flag")
+	})
+
+	t.Run("Fail index and derivation path", func(t *testing.T) {
+		deriveFlags = flagsDerive{KeySigAlgo: "ECDSA_P256", Mnemonic: "test mnemonic", Index: 1, DerivationPath: "m/44'/539'/0'/0/1"}
+
+		_, err := derive(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "can not pass both index and derivation path flags")
+	})
+
+	t.Run("Fail index without mnemonic", func(t *testing.T) {
+		deriveFlags = flagsDerive{KeySigAlgo: "ECDSA_P256", Index: 1}
+
+		_, err := derive([]string{"cf3178b20a73846dc8bf6255c79be47178b0744dd8244bcff099e449a9700d7f"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "index and derivation path flags require the mnemonic flag")
+	})
+
+	t.Run("Fail missing key", func(t *testing.T) {
+		deriveFlags = flagsDerive{KeySigAlgo: "ECDSA_P256"}
+
+		_, err := derive(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "provide argument for encoded private key or use the mnemonic flag")
+	})
 }
 
 func Test_Generate(t *testing.T) {
@@ -147,4 +285,255 @@ func Test_Generate(t *testing.T) {
 		_, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
 		assert.EqualError(t, err, "invalid signature algorithm: invalid")
 	})
+
+	t.Run("Success 24 words", func(t *testing.T) {
+		generateFlags = flagsGenerate{KeySigAlgo: "ECDSA_P256", Words: 24}
+		srv.Mock.On("DerivePrivateKeyFromMnemonic", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(tests.PrivKeys()[0], nil).Once()
+
+		result, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Len(t, strings.Fields(result.JSON().(map[string]any)["mnemonic"].(string)), 24)
+		srv.Mock.AssertNotCalled(t, "GenerateMnemonicKey", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail invalid words", func(t *testing.T) {
+		generateFlags = flagsGenerate{KeySigAlgo: "ECDSA_P256", Words: 18}
+
+		_, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid number of mnemonic words 18, valid values are: 12, 24")
+	})
+}
+
+func Test_GenerateBatch(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	srv.Mock.On("GenerateKey", mock.Anything, mock.Anything, mock.Anything).Return(tests.PrivKeys()[0], nil)
+
+	reset := func() {
+		generateFlags = flagsGenerate{KeySigAlgo: "ECDSA_P256", KeyHashAlgo: "SHA3_256", Signer: "emulator-account"}
+	}
+
+	t.Run("Success CSV", func(t *testing.T) {
+		reset()
+		generateFlags.Batch = 3
+		generateFlags.BatchFile = "keys.csv"
+
+		result, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "count: 3, file: keys.csv", result.Oneliner())
+
+		data, err := rw.ReadFile("keys.csv")
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		assert.Len(t, lines, 4)
+		assert.Equal(t, "index,address,public_key,private_key,sig_algo,hash_algo", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "0,,"))
+		assert.True(t, strings.HasSuffix(lines[1], ",ECDSA_P256,SHA3_256"))
+	})
+
+	t.Run("Success JSON", func(t *testing.T) {
+		reset()
+		generateFlags.Batch = 2
+		generateFlags.BatchFile = "keys.json"
+
+		_, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		data, err := rw.ReadFile("keys.json")
+		require.NoError(t, err)
+		var keys []batchKey
+		require.NoError(t, json.Unmarshal(data, &keys))
+		assert.Len(t, keys, 2)
+		assert.Equal(t, "SHA3_256", keys[0].HashAlgo)
+		assert.NotEmpty(t, keys[0].PrivateKey)
+	})
+
+	t.Run("Fail unsupported file", func(t *testing.T) {
+		reset()
+		generateFlags.Batch = 2
+		generateFlags.BatchFile = "keys.txt"
+
+		_, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "unsupported batch file keys.txt, use a .csv or .json file")
+	})
+
+	t.Run("Fail mnemonic", func(t *testing.T) {
+		reset()
+		generateFlags.Batch = 2
+		generateFlags.Mnemonic = "test"
+
+		_, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "mnemonic flag can not be used with the batch flag, each key is generated from a random seed")
+	})
+
+	t.Run("Fail create accounts without batch", func(t *testing.T) {
+		reset()
+		generateFlags.CreateAccounts = true
+
+		_, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "batch file and create accounts flags require the batch flag")
+	})
+}
+
+func Test_Keystore(t *testing.T) {
+	_, state, rw := util.TestMocks(t)
+	require.NoError(t, state.Save("flow.json"))
+	require.NoError(t, rw.WriteFile("password", []byte("secret\n"), 0600))
+	globalFlags := command.GlobalFlags{ConfigPaths: []string{"flow.json"}, PasswordFile: "password"}
+
+	privateKey := "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+	pkey, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, privateKey)
+	require.NoError(t, err)
+
+	t.Run("Success export and import private key", func(t *testing.T) {
+		exportFlags.PrivateKey = privateKey
+		exportFlags.Keystore = "bob.keystore.json"
+		defer func() { exportFlags = flagsExport{KeySigAlgo: "ECDSA_P256"} }()
+
+		result, err := export(nil, globalFlags, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+		assert.Equal(t, keystoreOutput{
+			Keystore:  "bob.keystore.json",
+			PublicKey: strings.TrimPrefix(pkey.PublicKey().String(), "0x"),
+		}, result.JSON())
+
+		data, err := rw.ReadFile("bob.keystore.json")
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), privateKey)
+
+		result, err = importKeystore([]string{"bob.keystore.json"}, globalFlags, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+		assert.Equal(t, privateKey, result.JSON().(map[string]any)["private"])
+	})
+
+	t.Run("Success export account and add imported account", func(t *testing.T) {
+		exportFlags.Replace = true
+		defer func() { exportFlags = flagsExport{KeySigAlgo: "ECDSA_P256"} }()
+		importFlags.Name = "alice"
+		importFlags.Address = "0x01cf0e2f2f715450"
+		importFlags.KeyIndex = 1
+		defer func() { importFlags = flagsImport{HashAlgo: "SHA3_256"} }()
+
+		_, err := export([]string{"emulator-account"}, globalFlags, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+
+		result, err := importKeystore([]string{"emulator-account.keystore.json"}, globalFlags, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+		assert.NotContains(t, result.JSON(), "private")
+
+		saved, err := flowkit.Load(globalFlags.ConfigPaths, rw)
+		require.NoError(t, err)
+		for _, name := range []string{"emulator-account", "alice"} {
+			account, err := saved.Accounts().ByName(name)
+			require.NoError(t, err)
+			assert.Equal(t, config.KeyTypeKeystore, account.Key.Type())
+			assert.Equal(t, "emulator-account.keystore.json", account.Key.ToConfig().Location)
+			assert.Equal(t, "password", account.Key.ToConfig().PasswordFile)
+		}
+
+		alice, _ := saved.Accounts().ByName("alice")
+		assert.Equal(t, 1, alice.Key.Index())
+	})
+
+	t.Run("Fail wrong password", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("wrong", []byte("wrong"), 0600))
+		wrongFlags := globalFlags
+		wrongFlags.PasswordFile = "wrong"
+
+		_, err := importKeystore([]string{"bob.keystore.json"}, wrongFlags, util.NoLogger, rw, nil)
+		assert.EqualError(t, err, "could not decrypt the keystore, the passphrase is wrong or the keystore is corrupted")
+	})
+
+	t.Run("Fail private key without keystore path", func(t *testing.T) {
+		exportFlags.PrivateKey = privateKey
+		defer func() { exportFlags = flagsExport{KeySigAlgo: "ECDSA_P256"} }()
+
+		_, err := export(nil, globalFlags, util.NoLogger, rw, nil)
+		assert.EqualError(t, err, "provide the path of the keystore file with the keystore flag")
+	})
+}
+
+func Test_ExportKeychain(t *testing.T) {
+	keyring.MockInit()
+	_, state, rw := util.TestMocks(t)
+	require.NoError(t, state.Save("flow.json"))
+	globalFlags := command.GlobalFlags{ConfigPaths: []string{"flow.json"}}
+
+	exportFlags.Keychain = "my-account"
+	exportFlags.Replace = true
+	defer func() { exportFlags = flagsExport{KeySigAlgo: "ECDSA_P256"} }()
+
+	emulatorAccount, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	privateKey, err := emulatorAccount.Key.PrivateKey()
+	require.NoError(t, err)
+
+	result, err := export([]string{"emulator-account"}, globalFlags, util.NoLogger, rw, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "my-account", result.Oneliner())
+
+	stored, err := keyring.Get(accounts.KeychainService, "my-account")
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimPrefix((*privateKey).String(), "0x"), stored)
+
+	data, err := rw.ReadFile("flow.json")
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), stored)
+
+	saved, err := flowkit.Load(globalFlags.ConfigPaths, rw)
+	require.NoError(t, err)
+	account, err := saved.Accounts().ByName("emulator-account")
+	require.NoError(t, err)
+	assert.Equal(t, config.KeyTypeKeychain, account.Key.Type())
+	assert.Equal(t, "my-account", account.Key.ToConfig().Keychain)
+}
+
+func Test_ExportKeychainExisting(t *testing.T) {
+	keyring.MockInit()
+	_, state, rw := util.TestMocks(t)
+	require.NoError(t, state.Save("flow.json"))
+	globalFlags := command.GlobalFlags{ConfigPaths: []string{"flow.json"}}
+	require.NoError(t, keyring.Set(accounts.KeychainService, "existing", "stored"))
+
+	exportFlags.Keychain = "existing"
+	defer func() { exportFlags = flagsExport{KeySigAlgo: "ECDSA_P256"} }()
+
+	prompt := util.KeychainReplacePrompt
+	defer func() { util.KeychainReplacePrompt = prompt }()
+
+	t.Run("Fail replace declined", func(t *testing.T) {
+		util.KeychainReplacePrompt = func(name string) bool {
+			assert.Equal(t, "existing", name)
+			return false
+		}
+
+		_, err := export([]string{"emulator-account"}, globalFlags, util.NoLogger, rw, nil)
+		assert.EqualError(t, err, "the keychain item existing already stores a key, use the --replace flag to replace it")
+
+		stored, err := keyring.Get(accounts.KeychainService, "existing")
+		require.NoError(t, err)
+		assert.Equal(t, "stored", stored)
+	})
+
+	t.Run("Success replace confirmed", func(t *testing.T) {
+		util.KeychainReplacePrompt = func(string) bool { return true }
+
+		_, err := export([]string{"emulator-account"}, globalFlags, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+
+		stored, err := keyring.Get(accounts.KeychainService, "existing")
+		require.NoError(t, err)
+		assert.NotEqual(t, "stored", stored)
+	})
+
+	t.Run("Success replace flag", func(t *testing.T) {
+		util.KeychainReplacePrompt = func(string) bool {
+			t.Fatal("the replace flag doesn't prompt")
+			return false
+		}
+		exportFlags.Replace = true
+
+		_, err := export([]string{"emulator-account"}, globalFlags, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+	})
 }
@@ -0,0 +1,134 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var epochCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "epoch",
+		Short:   "Get the current epoch counter, phase and view boundaries",
+		Example: "flow network epoch --network mainnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	Run:   epoch,
+}
+
+func epoch(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	logger.StartProgress("Fetching epoch information...")
+	defer logger.StopProgress()
+
+	snapshot, err := getProtocolSnapshot(flow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &epochResult{snapshot}, nil
+}
+
+type epochResult struct {
+	snapshot *protocolSnapshot
+}
+
+func (r *epochResult) JSON() any {
+	current := r.snapshot.Epochs.Current
+	result := map[string]any{
+		"chain_id":                 r.snapshot.Params.ChainID,
+		"counter":                  current.Counter,
+		"phase":                    r.snapshot.phase(),
+		"first_view":               current.FirstView,
+		"dkg_phase_1_final_view":   current.DKGPhase1FinalView,
+		"dkg_phase_2_final_view":   current.DKGPhase2FinalView,
+		"dkg_phase_3_final_view":   current.DKGPhase3FinalView,
+		"final_view":               current.FinalView,
+		"current_view":             r.snapshot.Head.View,
+		"current_height":           r.snapshot.Head.Height,
+		"current_block_timestamp":  r.snapshot.Head.Timestamp.Format(time.RFC3339),
+		"views_until_epoch_ending": r.viewsRemaining(),
+	}
+
+	if current.FirstHeight != nil {
+		result["first_height"] = *current.FirstHeight
+	}
+	if r.snapshot.Epochs.Next != nil {
+		result["next_counter"] = r.snapshot.Epochs.Next.Counter
+	}
+
+	return result
+}
+
+// viewsRemaining returns the number of views left until the current epoch ends.
+func (r *epochResult) viewsRemaining() uint64 {
+	if r.snapshot.Head.View >= r.snapshot.Epochs.Current.FinalView {
+		return 0
+	}
+	return r.snapshot.Epochs.Current.FinalView - r.snapshot.Head.View
+}
+
+func (r *epochResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	current := r.snapshot.Epochs.Current
+
+	_, _ = fmt.Fprintf(writer, "Chain ID\t%s\n", r.snapshot.Params.ChainID)
+	_, _ = fmt.Fprintf(writer, "Epoch Counter\t%d\n", current.Counter)
+	_, _ = fmt.Fprintf(writer, "Epoch Phase\t%s\n", r.snapshot.phase())
+	if current.FirstHeight != nil {
+		_, _ = fmt.Fprintf(writer, "First Height\t%d\n", *current.FirstHeight)
+	}
+	_, _ = fmt.Fprintf(writer, "First View\t%d\n", current.FirstView)
+	_, _ = fmt.Fprintf(writer, "DKG Phase 1 Final View\t%d\n", current.DKGPhase1FinalView)
+	_, _ = fmt.Fprintf(writer, "DKG Phase 2 Final View\t%d\n", current.DKGPhase2FinalView)
+	_, _ = fmt.Fprintf(writer, "DKG Phase 3 Final View\t%d\n", current.DKGPhase3FinalView)
+	_, _ = fmt.Fprintf(writer, "Final View\t%d\n", current.FinalView)
+	_, _ = fmt.Fprintf(writer, "Current View\t%d\n", r.snapshot.Head.View)
+	_, _ = fmt.Fprintf(writer, "Current Height\t%d\n", r.snapshot.Head.Height)
+	_, _ = fmt.Fprintf(writer, "Views Until Epoch Ending\t%d\n", r.viewsRemaining())
+	if r.snapshot.Epochs.Next != nil {
+		_, _ = fmt.Fprintf(writer, "Next Epoch Counter\t%d\n", r.snapshot.Epochs.Next.Counter)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *epochResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Counter: %d, Phase: %s, Final View: %d, Current View: %d",
+		r.snapshot.Epochs.Current.Counter, r.snapshot.phase(), r.snapshot.Epochs.Current.FinalView, r.snapshot.Head.View,
+	)
+}
@@ -0,0 +1,118 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	flowgo "github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var testHead = &flowgo.Header{
+	ChainID:   flowgo.Testnet,
+	ParentID:  flowgo.Identifier{0x01},
+	Height:    120,
+	View:      350,
+	Timestamp: time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+}
+
+// testSnapshot encodes a protocol state snapshot the way access nodes return it.
+func testSnapshot(t *testing.T) []byte {
+	firstHeight := uint64(50)
+	snapshot, err := json.Marshal(inmem.EncodableSnapshot{
+		Head:  testHead,
+		Phase: flowgo.EpochPhaseSetup,
+		Epochs: inmem.EncodableEpochs{
+			Current: inmem.EncodableEpoch{
+				Counter:            7,
+				FirstView:          100,
+				DKGPhase1FinalView: 200,
+				DKGPhase2FinalView: 300,
+				DKGPhase3FinalView: 400,
+				FinalView:          500,
+				FirstHeight:        &firstHeight,
+			},
+			Next: &inmem.EncodableEpoch{Counter: 8},
+		},
+		Params: inmem.EncodableParams{
+			ChainID:                    flowgo.Testnet,
+			SporkID:                    flowgo.Identifier{0xc3, 0xd4},
+			SporkRootBlockHeight:       10,
+			ProtocolVersion:            3,
+			EpochCommitSafetyThreshold: 1000,
+		},
+	})
+	require.NoError(t, err)
+	return snapshot
+}
+
+func Test_Epoch(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	gw := mocks.DefaultMockGateway()
+	srv.Gateway.Return(gw.Mock)
+
+	t.Run("Success", func(t *testing.T) {
+		gw.GetLatestProtocolStateSnapshot = gw.Mock.On("GetLatestProtocolStateSnapshot").Return(testSnapshot(t), nil)
+
+		result, err := epoch([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		json := result.JSON().(map[string]any)
+		assert.Equal(t, uint64(7), json["counter"])
+		assert.Equal(t, "setup", json["phase"])
+		assert.Equal(t, uint64(150), json["views_until_epoch_ending"])
+		assert.Equal(t, uint64(8), json["next_counter"])
+		assert.Equal(t, uint64(50), json["first_height"])
+	})
+
+	t.Run("Fail gateway", func(t *testing.T) {
+		gw.GetLatestProtocolStateSnapshot.Return(nil, fmt.Errorf("unavailable"))
+
+		_, err := epoch([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "failed to get latest finalized protocol snapshot: unavailable")
+	})
+}
+
+func Test_Parameters(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	gw := mocks.DefaultMockGateway()
+	srv.Gateway.Return(gw.Mock)
+	gw.Mock.On("GetLatestProtocolStateSnapshot").Return(testSnapshot(t), nil)
+
+	result, err := parameters([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+	require.NoError(t, err)
+
+	json := result.JSON().(map[string]any)
+	assert.Equal(t, "flow-testnet", json["chain_id"])
+	assert.Equal(t, flowgo.Identifier{0xc3, 0xd4}.String(), json["spork_id"])
+	assert.Equal(t, uint(3), json["protocol_version"])
+	assert.Equal(t, "emulator", json["network"])
+	assert.Equal(t, testHead.ID().String(), json["latest_finalized_block_id"])
+	assert.NotEqual(t, flowgo.ZeroID.String(), json["latest_finalized_block_id"])
+}
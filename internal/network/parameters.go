@@ -0,0 +1,111 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package network
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var parametersCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "parameters",
+		Short:   "Get the network parameters",
+		Example: "flow network parameters --network mainnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	Run:   parameters,
+}
+
+func parameters(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	logger.StartProgress("Fetching network parameters...")
+	defer logger.StopProgress()
+
+	snapshot, err := getProtocolSnapshot(flow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parametersResult{
+		network:  flow.Network().Name,
+		host:     flow.Network().Host,
+		snapshot: snapshot,
+	}, nil
+}
+
+type parametersResult struct {
+	network  string
+	host     string
+	snapshot *protocolSnapshot
+}
+
+func (r *parametersResult) JSON() any {
+	params := r.snapshot.Params
+	return map[string]any{
+		"network":                       r.network,
+		"host":                          r.host,
+		"chain_id":                      params.ChainID,
+		"spork_id":                      params.SporkID,
+		"spork_root_block_height":       params.SporkRootBlockHeight,
+		"protocol_version":              params.ProtocolVersion,
+		"epoch_commit_safety_threshold": params.EpochCommitSafetyThreshold,
+		"latest_finalized_block_id":     r.snapshot.Head.ID().String(),
+		"latest_finalized_block_height": r.snapshot.Head.Height,
+	}
+}
+
+func (r *parametersResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	params := r.snapshot.Params
+
+	_, _ = fmt.Fprintf(writer, "Network\t%s\n", r.network)
+	_, _ = fmt.Fprintf(writer, "Access Node\t%s\n", r.host)
+	_, _ = fmt.Fprintf(writer, "Chain ID\t%s\n", params.ChainID)
+	_, _ = fmt.Fprintf(writer, "Spork ID\t%s\n", params.SporkID)
+	_, _ = fmt.Fprintf(writer, "Spork Root Block Height\t%d\n", params.SporkRootBlockHeight)
+	_, _ = fmt.Fprintf(writer, "Protocol Version\t%d\n", params.ProtocolVersion)
+	_, _ = fmt.Fprintf(writer, "Epoch Commit Safety Threshold\t%d\n", params.EpochCommitSafetyThreshold)
+	_, _ = fmt.Fprintf(writer, "Latest Finalized Block\t%s\n", r.snapshot.Head.ID())
+	_, _ = fmt.Fprintf(writer, "Latest Finalized Height\t%d\n", r.snapshot.Head.Height)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *parametersResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Chain ID: %s, Spork ID: %s, Protocol Version: %d",
+		r.snapshot.Params.ChainID, r.snapshot.Params.SporkID, r.snapshot.Params.ProtocolVersion,
+	)
+}
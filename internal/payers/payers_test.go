@@ -0,0 +1,174 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payers
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func setupPool(t *testing.T, state *flowkit.State) {
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	for name, address := range map[string]string{"relayer-1": "0x01cf0e2f2f715450", "relayer-2": "0x179b6b1cb6755e31"} {
+		relayer := *service
+		relayer.Name = name
+		relayer.Address = flow.HexToAddress(address)
+		state.Accounts().AddOrUpdate(&relayer)
+	}
+	state.Payers().AddOrUpdate(config.PayerPool{
+		Network:  config.EmulatorNetwork.Name,
+		Accounts: []string{"relayer-1", "relayer-2"},
+	})
+}
+
+func Test_Pool(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	setupPool(t, state)
+
+	sequence := uint64(7)
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(args.Get(1).(flow.Address).String())
+		account.Keys = []*flow.AccountKey{{Index: 0, Weight: flow.AccountKeyWeightThreshold, SequenceNumber: sequence}}
+		account.Balance = 50000000
+		srv.GetAccount.Return(account, nil)
+	})
+
+	t.Run("Success rotation", func(t *testing.T) {
+		pool, err := Load(state, config.EmulatorNetwork, nil)
+		require.NoError(t, err)
+
+		first, seq, err := pool.Reserve(srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "relayer-1", first.Name)
+		assert.Equal(t, uint64(7), seq)
+
+		second, _, err := pool.Reserve(srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "relayer-2", second.Name)
+
+		// a new run continues the rotation and uses the sequence number following the pending transaction
+		pool, err = Load(state, config.EmulatorNetwork, nil)
+		require.NoError(t, err)
+		third, seq, err := pool.Reserve(srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "relayer-1", third.Name)
+		assert.Equal(t, uint64(8), seq)
+
+		// the reserved sequence number is used again if the transaction wasn't sent
+		require.NoError(t, pool.Release(third))
+		pool.state.Next = 0
+		require.NoError(t, pool.save())
+		_, seq, err = pool.Reserve(srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8), seq)
+	})
+
+	t.Run("Success executed and expired transactions", func(t *testing.T) {
+		pool, err := Load(state, config.EmulatorNetwork, nil)
+		require.NoError(t, err)
+		pool.state.Next = 0
+		require.NoError(t, pool.save())
+
+		sequence = 9
+		_, seq, err := pool.Reserve(srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(9), seq)
+
+		now = func() time.Time { return time.Now().Add(sequenceExpiry + time.Minute) }
+		defer func() { now = time.Now }()
+		pool.state.Next = 0
+		require.NoError(t, pool.save())
+		_, seq, err = pool.Reserve(srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(9), seq)
+	})
+
+	t.Run("Success status", func(t *testing.T) {
+		statusFlags.MinBalance = "1.0"
+		result, err := status([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		payers := result.(*statusResult).payers
+		require.Len(t, payers, 2)
+		assert.Equal(t, "relayer-1", payers[0].Name)
+		assert.Equal(t, "0.50000000", payers[0].Balance)
+		assert.Equal(t, statusLowBalance, payers[0].Status)
+		assert.Equal(t, uint64(1), payers[0].Pending)
+		assert.True(t, payers[1].Next)
+		assert.Equal(t, "0 of 2 payers healthy", result.Oneliner())
+	})
+
+	t.Run("Success parallel runs", func(t *testing.T) {
+		first, err := Load(state, config.EmulatorNetwork, nil)
+		require.NoError(t, err)
+		second, err := Load(state, config.EmulatorNetwork, nil)
+		require.NoError(t, err)
+
+		// each run reads the state the other run saved before reserving
+		firstPayer, _, err := first.Reserve(srv.Mock)
+		require.NoError(t, err)
+		secondPayer, _, err := second.Reserve(srv.Mock)
+		require.NoError(t, err)
+		assert.NotEqual(t, firstPayer.Name, secondPayer.Name)
+
+		thirdPayer, seq, err := first.Reserve(srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, firstPayer.Name, thirdPayer.Name)
+		assert.Equal(t, uint64(10), seq)
+		require.NoError(t, first.Release(thirdPayer))
+	})
+
+	t.Run("Success stale lock", func(t *testing.T) {
+		pool, err := Load(state, config.EmulatorNetwork, nil)
+		require.NoError(t, err)
+
+		fs := pool.fileSystem()
+		lockFile := pool.file + ".lock"
+		require.NoError(t, afero.WriteFile(fs, lockFile, nil, 0644))
+		require.NoError(t, fs.Chtimes(lockFile, time.Now().Add(-2*staleLockAge), time.Now().Add(-2*staleLockAge)))
+
+		_, _, err = pool.Reserve(srv.Mock)
+		require.NoError(t, err)
+		_, err = fs.Stat(lockFile)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Fail no pool", func(t *testing.T) {
+		srv.Network.Return(config.TestnetNetwork)
+		defer srv.Network.Return(config.EmulatorNetwork)
+
+		_, err := status([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "no payer pool configured for network testnet, add the payer accounts to the payers section of the configuration")
+	})
+}
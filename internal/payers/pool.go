@@ -0,0 +1,302 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/afero"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// poolDir is the project relative directory where the rotation state of the payer pools is kept.
+const poolDir = ".flow/payers"
+
+// sequenceExpiry is how long a proposal key sequence number used for a transaction is tracked, a
+// transaction not executed by then expired and its sequence number is used again.
+const sequenceExpiry = 10 * time.Minute
+
+var now = time.Now
+
+// lockTimeout is how long a run waits for other runs to release the lock of the payer pool state.
+const lockTimeout = 30 * time.Second
+
+// staleLockAge is the age of a lock left behind by a run that exited without releasing it, it's removed then.
+const staleLockAge = time.Minute
+
+var lockRetry = 50 * time.Millisecond
+
+// Pool rotates the payer of transactions among the accounts of the payer pool configured for a network.
+//
+// The payer also proposes the transactions it pays for, so transactions sent in parallel with different
+// payers don't compete for the same proposal key. The sequence numbers of the proposal keys are tracked
+// between runs, so transactions sent with the same key before the previous one is executed use the
+// following sequence number instead of the one on chain. Runs in parallel take a lock file while they
+// reserve and release payers, so they never reserve the same sequence number.
+type Pool struct {
+	network      string
+	accounts     []*accounts.Account
+	file         string
+	readerWriter flowkit.ReaderWriter
+	state        poolState
+	released     map[string]reservation
+}
+
+// reservation is the sequence number reserved for a key and the tracked key before, restored on release.
+type reservation struct {
+	reserved trackedKey
+	previous *trackedKey
+}
+
+// poolState is the rotation state persisted between runs.
+type poolState struct {
+	Next int                   `json:"next"`
+	Keys map[string]trackedKey `json:"keys"`
+}
+
+// trackedKey is the last sequence number used for a proposal key and when it was used.
+type trackedKey struct {
+	Sequence uint64    `json:"sequence"`
+	Used     time.Time `json:"used"`
+}
+
+// Load returns the payer pool of the network, or nil if the network has no payer pool configured.
+func Load(state *flowkit.State, network config.Network, configPaths []string) (*Pool, error) {
+	conf := state.Payers().ByNetwork(network.Name)
+	if conf == nil {
+		return nil, nil
+	}
+
+	pool := &Pool{
+		network:      network.Name,
+		accounts:     make([]*accounts.Account, 0, len(conf.Accounts)),
+		file:         filepath.Join(util.ProjectDir(configPaths), poolDir, network.Name+".json"),
+		readerWriter: state.ReaderWriter(),
+		state:        poolState{Keys: make(map[string]trackedKey)},
+		released:     make(map[string]reservation),
+	}
+	for _, name := range conf.Accounts {
+		account, err := state.Accounts().ByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("payer pool account %s doesn't exist in configuration", name)
+		}
+		pool.accounts = append(pool.accounts, account)
+	}
+
+	err := pool.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// read loads the persisted rotation state, changed by other runs since the pool was loaded.
+func (p *Pool) read() error {
+	data, err := p.readerWriter.ReadFile(p.file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	state := poolState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid payer pool state %s: %w", p.file, err)
+	}
+	if state.Keys == nil {
+		state.Keys = make(map[string]trackedKey)
+	}
+	p.state = state
+
+	return nil
+}
+
+// Reserve returns the next payer of the rotation and the sequence number its proposal key must use.
+func (p *Pool) Reserve(flow flowkit.Services) (*accounts.Account, uint64, error) {
+	unlock, err := p.lock()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer unlock()
+
+	err = p.read()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	payer := p.accounts[p.state.Next%len(p.accounts)]
+
+	onChain, err := flow.GetAccount(context.Background(), payer.Address)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch payer account %s: %w", payer.Name, err)
+	}
+	key, err := proposalKey(payer, onChain)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sequence := key.SequenceNumber
+	if pending, ok := p.pendingSequence(payer, key.SequenceNumber); ok {
+		sequence = pending + 1
+	}
+
+	id := keyID(payer)
+	reserved := reservation{reserved: trackedKey{Sequence: sequence, Used: now()}}
+	if previous, ok := p.state.Keys[id]; ok {
+		reserved.previous = &previous
+	}
+	p.released[id] = reserved
+
+	p.state.Next = (p.state.Next + 1) % len(p.accounts)
+	p.state.Keys[id] = reserved.reserved
+
+	if err := p.save(); err != nil {
+		return nil, 0, err
+	}
+
+	return payer, sequence, nil
+}
+
+// Release returns the sequence number reserved for the payer if no transaction was sent with it,
+// so the next transaction doesn't skip it. The sequence number is kept if another run reserved
+// the following one in the meantime.
+func (p *Pool) Release(payer *accounts.Account) error {
+	id := keyID(payer)
+	reserved, ok := p.released[id]
+	if !ok {
+		return nil
+	}
+	delete(p.released, id)
+
+	unlock, err := p.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = p.read()
+	if err != nil {
+		return err
+	}
+
+	if tracked, ok := p.state.Keys[id]; !ok || !tracked.Used.Equal(reserved.reserved.Used) || tracked.Sequence != reserved.reserved.Sequence {
+		return nil
+	}
+	if reserved.previous == nil {
+		delete(p.state.Keys, id)
+	} else {
+		p.state.Keys[id] = *reserved.previous
+	}
+
+	return p.save()
+}
+
+// lock takes the lock of the pool state shared by runs in parallel and returns the function releasing it.
+func (p *Pool) lock() (func(), error) {
+	fs := p.fileSystem()
+	err := fs.MkdirAll(filepath.Dir(p.file), 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	path := p.file + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := fs.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_ = file.Close()
+			return func() { _ = fs.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, err := fs.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = fs.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("payer pool of network %s is locked by another run, remove %s if no other run is sending transactions", p.network, path)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// fileSystem returns the file system of the reader writer used to lock the pool state.
+func (p *Pool) fileSystem() afero.Fs {
+	if fs, ok := p.readerWriter.(afero.Fs); ok {
+		return fs
+	}
+	return afero.NewOsFs()
+}
+
+// pendingSequence returns the last sequence number used for the key of the account if the
+// transaction using it is not executed yet.
+func (p *Pool) pendingSequence(account *accounts.Account, onChain uint64) (uint64, bool) {
+	tracked, ok := p.state.Keys[keyID(account)]
+	if !ok || tracked.Sequence < onChain || now().Sub(tracked.Used) > sequenceExpiry {
+		return 0, false
+	}
+
+	return tracked.Sequence, true
+}
+
+func (p *Pool) save() error {
+	data, err := json.MarshalIndent(p.state, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := flowkit.MkdirAll(p.readerWriter, filepath.Dir(p.file)); err != nil {
+		return err
+	}
+
+	return p.readerWriter.WriteFile(p.file, data, 0644)
+}
+
+// proposalKey returns the on chain key the account proposes with, failing if it can't be used.
+func proposalKey(account *accounts.Account, onChain *flowsdk.Account) (*flowsdk.AccountKey, error) {
+	index := account.Key.Index()
+	if index >= len(onChain.Keys) {
+		return nil, fmt.Errorf("payer account %s has no key at index %d", account.Name, index)
+	}
+
+	key := onChain.Keys[index]
+	if key.Revoked {
+		return nil, fmt.Errorf("payer account %s key at index %d is revoked", account.Name, index)
+	}
+
+	return key, nil
+}
+
+func keyID(account *accounts.Account) string {
+	return fmt.Sprintf("%s/%d", account.Address.Hex(), account.Key.Index())
+}
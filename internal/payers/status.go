@@ -0,0 +1,176 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsStatus struct {
+	MinBalance string `default:"1.0" flag:"min-balance" info:"FLOW balance below which a payer is reported as low on funds"`
+}
+
+var statusFlags = flagsStatus{}
+
+var statusCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "status",
+		Short: "Show the health of the payer pool of the network",
+		Example: `flow payers status --network testnet
+flow payers status --network mainnet --min-balance 10.0`,
+		Args: cobra.NoArgs,
+	},
+	Flags: &statusFlags,
+	RunS:  status,
+}
+
+const (
+	statusOK         = "ok"
+	statusLowBalance = "low balance"
+)
+
+type payerStatus struct {
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	KeyIndex int    `json:"keyIndex"`
+	Balance  string `json:"balance"`
+	Sequence uint64 `json:"sequenceNumber"`
+	Pending  uint64 `json:"pending"`
+	Next     bool   `json:"next"`
+	Status   string `json:"status"`
+}
+
+func status(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	minBalance, err := cadence.NewUFix64(statusFlags.MinBalance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minimum balance %s: %w", statusFlags.MinBalance, err)
+	}
+
+	pool, err := Load(state, flow.Network(), globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("no payer pool configured for network %s, add the payer accounts to the payers section of the configuration", flow.Network().Name)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Checking %d payers...", len(pool.accounts)))
+	defer logger.StopProgress()
+
+	payers := make([]payerStatus, 0, len(pool.accounts))
+	for i, account := range pool.accounts {
+		payer := payerStatus{
+			Name:     account.Name,
+			Address:  fmt.Sprintf("0x%s", account.Address.Hex()),
+			KeyIndex: account.Key.Index(),
+			Next:     i == pool.state.Next%len(pool.accounts),
+		}
+
+		onChain, err := flow.GetAccount(context.Background(), account.Address)
+		if err != nil {
+			payer.Status = fmt.Sprintf("unreachable: %s", err)
+			payers = append(payers, payer)
+			continue
+		}
+		payer.Balance = cadence.UFix64(onChain.Balance).String()
+
+		key, err := proposalKey(account, onChain)
+		if err != nil {
+			payer.Status = err.Error()
+			payers = append(payers, payer)
+			continue
+		}
+		payer.Sequence = key.SequenceNumber
+		if pending, ok := pool.pendingSequence(account, key.SequenceNumber); ok {
+			payer.Pending = pending - key.SequenceNumber + 1
+		}
+
+		payer.Status = statusOK
+		if cadence.UFix64(onChain.Balance) < minBalance {
+			payer.Status = statusLowBalance
+		}
+		payers = append(payers, payer)
+	}
+
+	return &statusResult{network: pool.network, payers: payers}, nil
+}
+
+type statusResult struct {
+	network string
+	payers  []payerStatus
+}
+
+func (r *statusResult) JSON() any {
+	return r.payers
+}
+
+func (r *statusResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Payer pool of network %s:\n\n", r.network)
+	_, _ = fmt.Fprintf(writer, "Name\tAddress\tKey\tBalance\tSequence\tPending\tStatus\n")
+	for _, payer := range r.payers {
+		name := payer.Name
+		if payer.Next {
+			name = fmt.Sprintf("%s (next)", name)
+		}
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%d\t%s\t%d\t%d\t%s\n",
+			name,
+			payer.Address,
+			payer.KeyIndex,
+			payer.Balance,
+			payer.Sequence,
+			payer.Pending,
+			payer.Status,
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *statusResult) Oneliner() string {
+	healthy := 0
+	for _, payer := range r.payers {
+		if payer.Status == statusOK {
+			healthy++
+		}
+	}
+
+	return fmt.Sprintf("%d of %d payers healthy", healthy, len(r.payers))
+}
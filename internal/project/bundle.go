@@ -0,0 +1,350 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/build"
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+const (
+	bundleManifestFile = "manifest.json"
+	bundleContractsDir = "contracts"
+	bundleVersion      = 1
+)
+
+type flagsBundle struct {
+	Out string `default:"bundle.tar.gz" flag:"out" info:"Output path of the bundle artifact"`
+}
+
+var bundleFlags = flagsBundle{}
+
+var BundleCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "bundle",
+		Short:   "Bundle resolved contracts and the deployment plan into a deployable artifact",
+		Example: "flow project bundle --network testnet --out release.tar.gz",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &bundleFlags,
+	RunS:  bundle,
+}
+
+// bundleManifest describes the content of the bundle artifact.
+//
+// Contracts are listed in deployment order and each contains the hash of the resolved
+// source which is verified before deploying from the bundle.
+type bundleManifest struct {
+	Version    int              `json:"version"`
+	CLIVersion string           `json:"cliVersion"`
+	Network    string           `json:"network"`
+	Contracts  []bundleContract `json:"contracts"`
+}
+
+type bundleContract struct {
+	Name    string            `json:"name"`
+	Account string            `json:"account"`
+	Address string            `json:"address"`
+	File    string            `json:"file"`
+	SHA256  string            `json:"sha256"`
+	Args    []json.RawMessage `json:"args"`
+}
+
+func bundle(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	network := flow.Network()
+	contracts, err := resolveDeployment(state, network)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := bundleManifest{
+		Version:    bundleVersion,
+		CLIVersion: build.Semver(),
+		Network:    network.Name,
+		Contracts:  make([]bundleContract, 0, len(contracts)),
+	}
+	sources := make(map[string][]byte)
+
+	for _, contract := range contracts {
+		args := make([]json.RawMessage, 0, len(contract.Args))
+		for _, arg := range contract.Args {
+			encoded, err := jsoncdc.Encode(arg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode arguments of contract %s: %w", contract.Name, err)
+			}
+			args = append(args, bytes.TrimSpace(encoded))
+		}
+
+		file := path.Join(bundleContractsDir, fmt.Sprintf("%s.cdc", contract.Name))
+		sources[file] = contract.code
+		manifest.Contracts = append(manifest.Contracts, bundleContract{
+			Name:    contract.Name,
+			Account: contract.AccountName,
+			Address: contract.AccountAddress.String(),
+			File:    file,
+			SHA256:  hashSource(contract.code),
+			Args:    args,
+		})
+	}
+
+	artifact, err := writeBundle(manifest, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.ReaderWriter().WriteFile(bundleFlags.Out, artifact, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write bundle to %s: %w", bundleFlags.Out, err)
+	}
+
+	logger.Info(fmt.Sprintf("%s Bundled %d contracts for network %s", output.SuccessEmoji(), len(manifest.Contracts), network.Name))
+
+	return &bundleResult{path: bundleFlags.Out, manifest: manifest}, nil
+}
+
+// resolvedContract is a contract of the deployment with the imports in its code replaced by addresses.
+type resolvedContract struct {
+	*project.Contract
+	code []byte
+}
+
+// resolveDeployment returns the contracts deployed on the network in deployment order with their imports resolved.
+//
+// Contracts are ordered by account before sorting, so contracts not depending on each
+// other are in the same order on every run regardless of the configuration map order.
+func resolveDeployment(state *flowkit.State, network config.Network) ([]resolvedContract, error) {
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(contracts, func(i, j int) bool {
+		return contracts[i].AccountName < contracts[j].AccountName
+	})
+
+	deployment, err := project.NewDeployment(contracts, state.AliasesForNetwork(network))
+	if err != nil {
+		return nil, err
+	}
+
+	sorted, err := deployment.Sort()
+	if err != nil {
+		return nil, err
+	}
+
+	importReplacer := project.NewImportReplacer(contracts, state.AliasesForNetwork(network))
+
+	resolved := make([]resolvedContract, 0, len(sorted))
+	for _, contract := range sorted {
+		program, err := project.NewProgram(contract.Code(), contract.Args, contract.Location())
+		if err != nil {
+			return nil, err
+		}
+
+		if program.HasImports() {
+			program, err = importReplacer.Replace(program)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve imports of contract %s: %w", contract.Name, err)
+			}
+		}
+
+		resolved = append(resolved, resolvedContract{Contract: contract, code: program.Code()})
+	}
+
+	return resolved, nil
+}
+
+func hashSource(code []byte) string {
+	hash := sha256.Sum256(code)
+	return hex.EncodeToString(hash[:])
+}
+
+// writeBundle writes the manifest and contract sources to a gzipped tar archive.
+//
+// Headers don't include modification times so the same project produces the same artifact.
+func writeBundle(manifest bundleManifest, sources map[string][]byte) ([]byte, error) {
+	manifestBytes, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	tw := tar.NewWriter(gz)
+
+	write := func(name string, content []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: int64(len(content)),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(content)
+		return err
+	}
+
+	if err := write(bundleManifestFile, manifestBytes); err != nil {
+		return nil, fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+
+	for _, contract := range manifest.Contracts {
+		if err := write(contract.File, sources[contract.File]); err != nil {
+			return nil, fmt.Errorf("failed to write contract %s to bundle: %w", contract.Name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// readBundle reads the bundle artifact and verifies each contract source matches the manifest hash.
+func readBundle(artifact []byte) (*bundleManifest, map[string][]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(artifact))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid bundle: %w", err)
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid bundle: %w", err)
+		}
+		files[header.Name] = content
+	}
+
+	manifestBytes, ok := files[bundleManifestFile]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid bundle: missing %s", bundleManifestFile)
+	}
+
+	var manifest bundleManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid bundle manifest: %w", err)
+	}
+
+	if manifest.Version != bundleVersion {
+		return nil, nil, fmt.Errorf("unsupported bundle version %d", manifest.Version)
+	}
+
+	for _, contract := range manifest.Contracts {
+		code, ok := files[contract.File]
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid bundle: missing source for contract %s", contract.Name)
+		}
+		if hashSource(code) != contract.SHA256 {
+			return nil, nil, fmt.Errorf("contract %s source does not match the manifest hash", contract.Name)
+		}
+	}
+
+	return &manifest, files, nil
+}
+
+// contractsFromBundle converts the bundle manifest to the contracts in the deployment order.
+func contractsFromBundle(manifest *bundleManifest, files map[string][]byte) ([]*project.Contract, error) {
+	contracts := make([]*project.Contract, 0, len(manifest.Contracts))
+	for _, c := range manifest.Contracts {
+		args := make([]cadence.Value, 0, len(c.Args))
+		for _, arg := range c.Args {
+			value, err := jsoncdc.Decode(nil, arg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode arguments of contract %s: %w", c.Name, err)
+			}
+			args = append(args, value)
+		}
+
+		contracts = append(contracts, project.NewContract(
+			c.Name,
+			c.File,
+			files[c.File],
+			flowsdk.HexToAddress(c.Address),
+			c.Account,
+			args,
+		))
+	}
+
+	return contracts, nil
+}
+
+type bundleResult struct {
+	path     string
+	manifest bundleManifest
+}
+
+func (r *bundleResult) JSON() any {
+	return map[string]any{
+		"path":     r.path,
+		"manifest": r.manifest,
+	}
+}
+
+func (r *bundleResult) String() string {
+	var b bytes.Buffer
+	_, _ = fmt.Fprintf(&b, "Bundle saved: %s\n\n", r.path)
+	for _, c := range r.manifest.Contracts {
+		_, _ = fmt.Fprintf(&b, "%s -> 0x%s (%s) sha256:%s\n", c.Name, c.Address, c.Account, c.SHA256)
+	}
+	return b.String()
+}
+
+func (r *bundleResult) Oneliner() string {
+	return fmt.Sprintf("bundle saved: %s", r.path)
+}
@@ -0,0 +1,79 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+var CleanCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "clean",
+		Short:   "Remove cached build artifacts of the project",
+		Example: "flow project clean",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	Run:   clean,
+}
+
+func clean(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	remover, ok := readerWriter.(interface {
+		RemoveAll(path string) error
+	})
+	if !ok {
+		return nil, fmt.Errorf("removing files is not supported")
+	}
+
+	dir := command.BuildCacheDir(globalFlags.ConfigPaths)
+	err := remover.RemoveAll(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove build cache: %w", err)
+	}
+
+	return &cleanResult{dir: dir}, nil
+}
+
+type cleanResult struct {
+	dir string
+}
+
+func (r *cleanResult) JSON() any {
+	return map[string]any{"removed": r.dir}
+}
+
+func (r *cleanResult) String() string {
+	return fmt.Sprintf("%s Build cache %s removed", output.SuccessEmoji(), r.dir)
+}
+
+func (r *cleanResult) Oneliner() string {
+	return fmt.Sprintf("removed: %s", r.dir)
+}
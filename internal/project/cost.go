@@ -0,0 +1,179 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/transactions"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// deploymentCost summarises the fees charged for deploying the contracts and
+// the balances of the deployment accounts before and after the deployment.
+type deploymentCost struct {
+	TotalFees string           `json:"totalFees"`
+	Contracts []contractCost   `json:"contracts"`
+	Accounts  []accountBalance `json:"accounts"`
+}
+
+type contractCost struct {
+	Name                 string `json:"name"`
+	TransactionID        string `json:"transactionId"`
+	Fees                 string `json:"fees"`
+	ExecutionEffortUnits string `json:"executionEffortUnits"`
+}
+
+type accountBalance struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Before  string `json:"balanceBefore"`
+	After   string `json:"balanceAfter"`
+	address flowsdk.Address
+}
+
+// costTracker records the balances of the deployment accounts before the deployment,
+// so the cost can be summarised once the contracts are deployed.
+type costTracker struct {
+	flow     flowkit.Services
+	accounts []accountBalance
+}
+
+// trackCost fetches the balances of the accounts with the names before the deployment.
+//
+// It returns nil on the emulator network, where transaction fees are disabled by default.
+func trackCost(flow flowkit.Services, state *flowkit.State, accountNames []string) (*costTracker, error) {
+	if flow.Network().Name == config.EmulatorNetwork.Name {
+		return nil, nil
+	}
+
+	tracker := &costTracker{flow: flow}
+	tracked := make(map[string]bool)
+	for _, name := range accountNames {
+		if tracked[name] {
+			continue
+		}
+		tracked[name] = true
+
+		account, err := state.Accounts().ByName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		balance, err := tracker.balance(account.Address)
+		if err != nil {
+			return nil, err
+		}
+		tracker.accounts = append(tracker.accounts, accountBalance{
+			Name:    account.Name,
+			Address: "0x" + account.Address.String(),
+			Before:  balance,
+			address: account.Address,
+		})
+	}
+
+	return tracker, nil
+}
+
+func (t *costTracker) balance(address flowsdk.Address) (string, error) {
+	account, err := t.flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return "", fmt.Errorf("failed to get balance of account 0x%s: %w", address, err)
+	}
+	return cadence.UFix64(account.Balance).String(), nil
+}
+
+// summary returns the cost of deploying the contracts, contracts that weren't deployed
+// because they didn't change are skipped. The tracker may be nil, in which case the summary is nil.
+func (t *costTracker) summary(contracts []*project.Contract) (*deploymentCost, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	cost := &deploymentCost{Contracts: make([]contractCost, 0)}
+	var total uint64
+	for _, contract := range contracts {
+		if contract.TransactionID == flowsdk.EmptyID {
+			continue
+		}
+
+		_, result, err := t.flow.GetTransactionByID(context.Background(), contract.TransactionID, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment transaction of contract %s: %w", contract.Name, err)
+		}
+
+		contractCost := contractCost{
+			Name:                 contract.Name,
+			TransactionID:        contract.TransactionID.String(),
+			Fees:                 cadence.UFix64(0).String(),
+			ExecutionEffortUnits: "-",
+		}
+		if fees := transactions.FeesFromResult(result); fees != nil {
+			amount, err := cadence.NewUFix64(fees.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fees charged for contract %s: %w", contract.Name, err)
+			}
+			total += uint64(amount)
+			contractCost.Fees = fees.Amount
+			contractCost.ExecutionEffortUnits = fees.ExecutionEffortUnits
+		}
+		cost.Contracts = append(cost.Contracts, contractCost)
+	}
+	cost.TotalFees = cadence.UFix64(total).String()
+
+	for _, account := range t.accounts {
+		balance, err := t.balance(account.address)
+		if err != nil {
+			return nil, err
+		}
+		account.After = balance
+		cost.Accounts = append(cost.Accounts, account)
+	}
+
+	return cost, nil
+}
+
+func (c *deploymentCost) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Total Fees\t%s FLOW\n", c.TotalFees)
+	for _, contract := range c.Contracts {
+		_, _ = fmt.Fprintf(writer, "\nContract\t%s\n", contract.Name)
+		_, _ = fmt.Fprintf(writer, "    Transaction\t%s\n", contract.TransactionID)
+		_, _ = fmt.Fprintf(writer, "    Fees\t%s FLOW\n", contract.Fees)
+		_, _ = fmt.Fprintf(writer, "    Execution Effort\t%s units\n", contract.ExecutionEffortUnits)
+	}
+	for _, account := range c.Accounts {
+		_, _ = fmt.Fprintf(writer, "\nAccount\t%s (%s)\n", account.Name, account.Address)
+		_, _ = fmt.Fprintf(writer, "    Balance Before\t%s FLOW\n", account.Before)
+		_, _ = fmt.Fprintf(writer, "    Balance After\t%s FLOW\n", account.After)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
@@ -22,6 +22,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
+	"strings"
 
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
@@ -36,17 +38,24 @@ import (
 )
 
 type flagsDeploy struct {
-	Update   bool `flag:"update" default:"false" info:"use update flag to update existing contracts"`
-	ShowDiff bool `flag:"show-diff" default:"false" info:"use show-diff flag to show diff between existing and new contracts on update"`
+	Update       bool     `flag:"update" default:"false" info:"use update flag to update existing contracts"`
+	ShowDiff     bool     `flag:"show-diff" default:"false" info:"use show-diff flag to show diff between existing and new contracts on update"`
+	FromBundle   string   `flag:"from-bundle" default:"" info:"deploy contracts from a bundle artifact created with the bundle command"`
+	Tags         []string `flag:"tags" default:"" info:"only deploy contracts tagged with any of the provided tags in the deployments configuration"`
+	Verify       bool     `flag:"verify" default:"false" info:"run the assertions configured for the network after deployment and fail if any of them doesn't hold"`
+	EmitPayloads string   `flag:"emit-payloads" default:"" info:"write the unsigned deployment transaction payloads to the directory instead of deploying"`
 }
 
 var deployFlags = flagsDeploy{}
 
 var DeployCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "deploy",
-		Short:   "Deploy Cadence contracts",
-		Example: "flow project deploy --network testnet",
+		Use:   "deploy",
+		Short: "Deploy Cadence contracts",
+		Example: `flow project deploy --network testnet
+flow project deploy --network testnet --tags core,marketplace
+flow project deploy --network testnet --verify
+flow project deploy --network mainnet --update --emit-payloads payloads/`,
 	},
 	Flags: &deployFlags,
 	RunS:  deploy,
@@ -72,6 +81,41 @@ func deploy(
 		deployFunc = util.ShowContractDiffPrompt(logger)
 	}
 
+	if deployFlags.EmitPayloads != "" {
+		if deployFlags.ShowDiff || deployFlags.FromBundle != "" || deployFlags.Verify {
+			return nil, fmt.Errorf("emit-payloads flag can not be used together with show-diff, from-bundle or verify flags")
+		}
+		if err := filterDeploymentsByTags(state, flow.Network(), deployFlags.Tags, logger); err != nil {
+			return nil, err
+		}
+		return emitPayloads(deployFlags.EmitPayloads, logger, flow, state)
+	}
+
+	if deployFlags.FromBundle != "" {
+		if len(deployFlags.Tags) > 0 {
+			return nil, fmt.Errorf("tags flag can not be used when deploying from a bundle")
+		}
+		result, err := deployBundle(deployFlags.FromBundle, deployFunc, logger, flow, state)
+		if err != nil {
+			return nil, err
+		}
+		return verifiedResult(result, logger, flow, state)
+	}
+
+	err := filterDeploymentsByTags(state, flow.Network(), deployFlags.Tags, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var accountNames []string
+	for _, deployment := range state.Deployments().ByNetwork(flow.Network().Name) {
+		accountNames = append(accountNames, deployment.Account)
+	}
+	tracker, err := trackCost(flow, state, accountNames)
+	if err != nil {
+		return nil, err
+	}
+
 	c, err := flow.DeployProject(context.Background(), deployFunc)
 	if err != nil {
 		var projectErr *flowkit.ProjectDeploymentError
@@ -89,11 +133,230 @@ func deploy(
 		return nil, err
 	}
 
-	return &deployResult{c}, nil
+	cost, err := tracker.summary(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifiedResult(&deployResult{contracts: c, cost: cost}, logger, flow, state)
+}
+
+// verifiedResult returns the deployment result after the deployment is verified if requested by the verify flag.
+func verifiedResult(
+	result command.Result,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if !deployFlags.Verify {
+		return result, nil
+	}
+
+	if err := verifyDeployment(flow, state, logger); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// filterDeploymentsByTags removes contracts from the loaded deployments of the network that are not
+// tagged with any of the tags, or are tagged as test-only on a production network. An error is returned
+// if a remaining contract imports a removed contract, since the import could not be resolved.
+//
+// The state is only changed in memory and must not be saved afterward.
+func filterDeploymentsByTags(
+	state *flowkit.State,
+	network config.Network,
+	tags []string,
+	logger output.Logger,
+) error {
+	production := network.IsProduction()
+	if len(tags) == 0 && !production {
+		return nil
+	}
+
+	removed := make(map[string][]string)
+	removedLocations := make(map[string]string)
+	var kept []string
+	for _, deployment := range state.Deployments().ByNetwork(network.Name) {
+		for _, contract := range deployment.Contracts {
+			if production && contract.HasAnyTag([]string{config.TestOnlyTag}) {
+				logger.Info(fmt.Sprintf(
+					"%s is tagged %s and is not deployed to production network %s",
+					output.Italic(contract.Name),
+					config.TestOnlyTag,
+					network.Name,
+				))
+			} else if len(tags) == 0 || contract.HasAnyTag(tags) {
+				kept = append(kept, contract.Name)
+				continue
+			}
+
+			conf, err := state.Contracts().ByName(contract.Name)
+			if err != nil {
+				return err
+			}
+			removedLocations[path.Clean(conf.Location)] = contract.Name
+			removedLocations[contract.Name] = contract.Name
+			removed[deployment.Account] = append(removed[deployment.Account], contract.Name)
+		}
+	}
+
+	if len(tags) > 0 && len(kept) == 0 {
+		return fmt.Errorf(
+			"no contracts tagged with %s found in deployments for network %s",
+			strings.Join(tags, ", "),
+			network.Name,
+		)
+	}
+
+	for _, name := range kept {
+		conf, err := state.Contracts().ByName(name)
+		if err != nil {
+			return err
+		}
+		code, err := state.ReadFile(conf.Location)
+		if err != nil {
+			return err
+		}
+		program, err := project.NewProgram(code, nil, conf.Location)
+		if err != nil {
+			return err
+		}
+
+		for _, location := range program.Imports() {
+			imported, ok := removedLocations[location]
+			if !ok {
+				imported, ok = removedLocations[path.Join(path.Dir(conf.Location), location)]
+			}
+			if ok {
+				return fmt.Errorf(
+					"contract %s imports %s which is excluded from the deployment to network %s",
+					name,
+					imported,
+					network.Name,
+				)
+			}
+		}
+	}
+
+	for account, contracts := range removed {
+		deployment := state.Deployments().ByAccountAndNetwork(account, network.Name)
+		for _, name := range contracts {
+			deployment.RemoveContract(name)
+		}
+	}
+
+	return nil
+}
+
+// deployBundle deploys exactly the contracts contained in the bundle artifact in the bundled order.
+func deployBundle(
+	bundlePath string,
+	update flowkit.UpdateContract,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	artifact, err := state.ReadFile(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	manifest, files, err := readBundle(artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Network != flow.Network().Name {
+		return nil, fmt.Errorf(
+			"bundle was created for network %s but deploying to network %s",
+			manifest.Network,
+			flow.Network().Name,
+		)
+	}
+
+	contracts, err := contractsFromBundle(manifest, files)
+	if err != nil {
+		return nil, err
+	}
+
+	accountNames := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		accountNames = append(accountNames, contract.AccountName)
+	}
+	tracker, err := trackCost(flow, state, accountNames)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("\nDeploying %d contracts from bundle %s\n", len(contracts), bundlePath))
+
+	deployed := make([]*project.Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		account, err := state.Accounts().ByName(contract.AccountName)
+		if err != nil {
+			return nil, fmt.Errorf("target account %s for deploying contract %s not found in configuration", contract.AccountName, contract.Name)
+		}
+		if account.Address != contract.AccountAddress {
+			return nil, fmt.Errorf(
+				"account %s address 0x%s does not match bundled address 0x%s",
+				account.Name,
+				account.Address,
+				contract.AccountAddress,
+			)
+		}
+
+		txID, updated, err := flow.AddContract(
+			context.Background(),
+			account,
+			flowkit.Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()},
+			update,
+		)
+		if err != nil && errors.Is(err, flowkit.ErrUpdateNoDiff) {
+			logger.Info(fmt.Sprintf(
+				"%s -> 0x%s [skipping, no changes found]",
+				output.Italic(contract.Name),
+				contract.AccountAddress.String(),
+			))
+			deployed = append(deployed, contract)
+			continue
+		}
+		if err != nil {
+			logger.Info(fmt.Sprintf(
+				"%s Failed to deploy contract %s: %s",
+				output.ErrorEmoji(),
+				contract.Name,
+				err.Error(),
+			))
+			return nil, fmt.Errorf("failed deploying bundle, stopped at contract %s", contract.Name)
+		}
+
+		logger.Info(fmt.Sprintf(
+			"%s -> 0x%s (%s) %s",
+			output.Green(contract.Name),
+			contract.AccountAddress,
+			txID.String(),
+			map[bool]string{true: "[updated]", false: ""}[updated],
+		))
+		contract.TransactionID = txID
+		deployed = append(deployed, contract)
+	}
+
+	logger.Info(fmt.Sprintf("\n%s All bundled contracts deployed successfully", output.SuccessEmoji()))
+
+	cost, err := tracker.summary(deployed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployResult{contracts: deployed, cost: cost}, nil
 }
 
 type deployResult struct {
 	contracts []*project.Contract
+	// cost is only summarised on networks charging transaction fees.
+	cost *deploymentCost
 }
 
 func (r *deployResult) JSON() any {
@@ -103,10 +366,18 @@ func (r *deployResult) JSON() any {
 		result[contract.Name] = contract.AccountAddress.String()
 	}
 
+	if r.cost != nil {
+		result["cost"] = r.cost
+	}
+
 	return result
 }
 
 func (r *deployResult) String() string {
+	if r.cost != nil {
+		return r.cost.String()
+	}
+
 	return ""
 }
 
@@ -0,0 +1,193 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const payloadsManifestFile = "manifest.json"
+
+// payloadsManifest describes the deployment payloads emitted in deployment order.
+type payloadsManifest struct {
+	Network  string              `json:"network"`
+	Payloads []deploymentPayload `json:"payloads"`
+}
+
+type deploymentPayload struct {
+	Contract     string `json:"contract"`
+	Account      string `json:"account"`
+	Address      string `json:"address"`
+	Update       bool   `json:"update"`
+	SourceSHA256 string `json:"sourceSha256"`
+	File         string `json:"file"`
+	SHA256       string `json:"sha256"`
+}
+
+// emitPayloads writes the unsigned deployment transactions of the project to the directory
+// instead of sending them, so they can be audited and signed offline.
+//
+// The payloads are built deterministically: contracts are in a fixed order, arguments are
+// encoded canonically and the proposal key sequence number and reference block are pinned
+// to zero values which must be set before signing.
+func emitPayloads(
+	dir string,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	contracts, err := resolveDeployment(state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := payloadsManifest{
+		Network:  flow.Network().Name,
+		Payloads: make([]deploymentPayload, 0, len(contracts)),
+	}
+	payloads := make(map[string][]byte)
+
+	for _, contract := range contracts {
+		account, err := state.Accounts().ByName(contract.AccountName)
+		if err != nil {
+			return nil, fmt.Errorf("target account %s for deploying contract %s not found in configuration", contract.AccountName, contract.Name)
+		}
+
+		onChain, err := flow.GetAccount(context.Background(), account.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch account %s: %w", account.Name, err)
+		}
+
+		existing, update := onChain.Contracts[contract.Name]
+		if update && bytes.Equal(existing, contract.code) {
+			logger.Info(fmt.Sprintf(
+				"%s -> 0x%s [skipping, no changes found]",
+				output.Italic(contract.Name),
+				account.Address,
+			))
+			continue
+		}
+		if update && !deployFlags.Update {
+			return nil, fmt.Errorf(
+				"contract %s is already deployed to account 0x%s, use the update flag to emit an update payload",
+				contract.Name,
+				account.Address,
+			)
+		}
+
+		var tx *transactions.Transaction
+		if update {
+			tx, err = transactions.NewUpdateAccountContract(account, contract.Name, contract.code)
+		} else {
+			tx, err = transactions.NewAddAccountContract(account, contract.Name, contract.code, contract.Args)
+		}
+		if err != nil {
+			return nil, err
+		}
+		tx.FlowTransaction().SetProposalKey(account.Address, account.Key.Index(), 0)
+
+		payload := []byte(hex.EncodeToString(tx.FlowTransaction().Encode()))
+		file := fmt.Sprintf("%02d-%s-%s.rlp", len(manifest.Payloads)+1, account.Name, contract.Name)
+		payloads[file] = payload
+		manifest.Payloads = append(manifest.Payloads, deploymentPayload{
+			Contract:     contract.Name,
+			Account:      account.Name,
+			Address:      account.Address.String(),
+			Update:       update,
+			SourceSHA256: hashSource(contract.code),
+			File:         file,
+			SHA256:       hashSource(payload),
+		})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	payloads[payloadsManifestFile] = data
+
+	if err := flowkit.MkdirAll(state.ReaderWriter(), dir); err != nil {
+		return nil, err
+	}
+
+	for file, data := range payloads {
+		if err := state.ReaderWriter().WriteFile(path.Join(dir, file), data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write payload %s: %w", file, err)
+		}
+	}
+
+	logger.Info(fmt.Sprintf(
+		"%s Emitted %d deployment payloads for network %s to %s",
+		output.SuccessEmoji(),
+		len(manifest.Payloads),
+		manifest.Network,
+		dir,
+	))
+
+	return &payloadsResult{dir: dir, manifest: manifest}, nil
+}
+
+type payloadsResult struct {
+	dir      string
+	manifest payloadsManifest
+}
+
+func (r *payloadsResult) JSON() any {
+	return r.manifest
+}
+
+func (r *payloadsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Network\t%s\n", r.manifest.Network)
+	_, _ = fmt.Fprintf(writer, "Manifest\t%s\n", path.Join(r.dir, payloadsManifestFile))
+	for _, payload := range r.manifest.Payloads {
+		action := "add"
+		if payload.Update {
+			action = "update"
+		}
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s -> 0x%s (%s)\n",
+			path.Join(r.dir, payload.File),
+			payload.Contract,
+			payload.Address,
+			action,
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *payloadsResult) Oneliner() string {
+	return path.Join(r.dir, payloadsManifestFile)
+}
@@ -31,4 +31,6 @@ var Cmd = &cobra.Command{
 
 func init() {
 	DeployCommand.AddToParent(Cmd)
+	BundleCommand.AddToParent(Cmd)
+	CleanCommand.AddToParent(Cmd)
 }
@@ -19,15 +19,21 @@
 package project
 
 import (
+	"path"
+	"strings"
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -75,4 +81,317 @@ func Test_ProjectDeploy(t *testing.T) {
 		assert.Equal(t, "f233dcee88fe0abe", c.Aliases.ByNetwork(config.MainnetNetwork.Name).Address.String())
 	})
 
+	t.Run("Success filter deployments by tags", func(t *testing.T) {
+		const acc = "tagged-account"
+		network := config.Network{Name: "tagged", Host: "127.0.0.1:3569", Production: true}
+		for _, name := range []string{"Core", "Market", "Mock"} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: name, Location: name + ".cdc"})
+			_ = rw.WriteFile(name+".cdc", []byte("pub contract "+name+" {}"), 0677)
+		}
+		state.Accounts().AddOrUpdate(&accounts.Account{Name: acc, Address: flow.HexToAddress("0x02")})
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: network.Name,
+			Account: acc,
+			Contracts: []config.ContractDeployment{
+				{Name: "Core", Tags: []string{"core"}},
+				{Name: "Market", Tags: []string{"marketplace"}},
+				{Name: "Mock", Tags: []string{"core", config.TestOnlyTag}},
+			},
+		})
+
+		err := filterDeploymentsByTags(state, network, []string{"missing"}, util.NoLogger)
+		assert.EqualError(t, err, "no contracts tagged with missing found in deployments for network tagged")
+
+		err = filterDeploymentsByTags(state, network, []string{"core"}, util.NoLogger)
+		require.NoError(t, err)
+
+		deployment := state.Deployments().ByAccountAndNetwork(acc, network.Name)
+		require.Len(t, deployment.Contracts, 1)
+		assert.Equal(t, "Core", deployment.Contracts[0].Name)
+
+		market, err := state.Contracts().ByName("Market")
+		require.NoError(t, err)
+		assert.Nil(t, market.Aliases.ByNetwork(network.Name))
+	})
+
+	t.Run("Fail filter deployments importing an excluded contract", func(t *testing.T) {
+		const acc = "importing-account"
+		network := config.Network{Name: "importing", Host: "127.0.0.1:3569"}
+		state.Contracts().AddOrUpdate(config.Contract{Name: "Token", Location: "contracts/Token.cdc"})
+		_ = rw.WriteFile("contracts/Token.cdc", []byte("pub contract Token {}"), 0677)
+		state.Contracts().AddOrUpdate(config.Contract{Name: "Shop", Location: "contracts/Shop.cdc"})
+		_ = rw.WriteFile("contracts/Shop.cdc", []byte("import Token from \"./Token.cdc\"\npub contract Shop {}"), 0677)
+		state.Contracts().AddOrUpdate(config.Contract{Name: "Store", Location: "Store.cdc"})
+		_ = rw.WriteFile("Store.cdc", []byte("import \"Token\"\npub contract Store {}"), 0677)
+		state.Accounts().AddOrUpdate(&accounts.Account{Name: acc, Address: flow.HexToAddress("0x03")})
+
+		for _, importing := range []string{"Shop", "Store"} {
+			state.Deployments().AddOrUpdate(config.Deployment{
+				Network: network.Name,
+				Account: acc,
+				Contracts: []config.ContractDeployment{
+					{Name: "Token", Tags: []string{"token"}},
+					{Name: importing, Tags: []string{"shop"}},
+				},
+			})
+
+			err := filterDeploymentsByTags(state, network, []string{"shop"}, util.NoLogger)
+			assert.EqualError(t, err, "contract "+importing+" imports Token which is excluded from the deployment to network importing")
+			assert.Len(t, state.Deployments().ByAccountAndNetwork(acc, network.Name).Contracts, 2)
+		}
+	})
+}
+
+func Test_ProjectDeployCost(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	srv.Network.Return(config.TestnetNetwork)
+
+	const acc = "testnet-account"
+	address := flow.HexToAddress("0x02")
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Core", Location: "Core.cdc"})
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: acc, Address: address})
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.TestnetNetwork.Name,
+		Account:   acc,
+		Contracts: []config.ContractDeployment{{Name: "Core"}},
+	})
+
+	balances := []uint64{10_000_000_000, 9_999_900_000}
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		assert.Equal(t, address, args.Get(1).(flow.Address))
+		srv.GetAccount.Return(&flow.Account{Address: address, Balance: balances[0]}, nil)
+		balances = balances[1:]
+	})
+
+	core := project.NewContract("Core", "Core.cdc", nil, address, acc, nil)
+	core.TransactionID = flow.HexToID("0a")
+	skipped := project.NewContract("Skipped", "Skipped.cdc", nil, address, acc, nil)
+	srv.DeployProject.Return([]*project.Contract{core, skipped}, nil)
+
+	amount, _ := cadence.NewUFix64("0.001")
+	execution, _ := cadence.NewUFix64("0.00035")
+	feesEvent := tests.NewEvent(
+		0,
+		"A.912d5440f7e3769e.FlowFees.FeesDeducted",
+		[]cadence.Field{
+			{Type: cadence.UFix64Type{}, Identifier: "amount"},
+			{Type: cadence.UFix64Type{}, Identifier: "inclusionEffort"},
+			{Type: cadence.UFix64Type{}, Identifier: "executionEffort"},
+		},
+		[]cadence.Value{amount, cadence.UFix64(100_000_000), execution},
+	)
+	srv.GetTransactionByID.Return(tests.NewTransaction(), tests.NewTransactionResult([]flow.Event{*feesEvent}), nil)
+
+	result, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+
+	cost := result.(*deployResult).cost
+	require.NotNil(t, cost)
+	assert.Equal(t, "0.00100000", cost.TotalFees)
+	require.Len(t, cost.Contracts, 1)
+	assert.Equal(t, contractCost{
+		Name:                 "Core",
+		TransactionID:        core.TransactionID.String(),
+		Fees:                 "0.00100000",
+		ExecutionEffortUnits: "0.00035000",
+	}, cost.Contracts[0])
+	require.Len(t, cost.Accounts, 1)
+	assert.Equal(t, "100.00000000", cost.Accounts[0].Before)
+	assert.Equal(t, "99.99900000", cost.Accounts[0].After)
+	assert.Contains(t, result.String(), "Total Fees\t0.00100000 FLOW\n")
+	assert.Equal(t, cost, result.JSON().(map[string]any)["cost"])
+}
+
+func Test_ProjectVerify(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	_ = rw.WriteFile("supply.cdc", []byte("pub fun main(): UInt64 { return 10 }"), 0677)
+	state.Assertions().AddOrUpdate(config.Assertion{
+		Name:   "supply",
+		Script: "supply.cdc",
+		Expected: []config.AssertionResult{{
+			Network: config.EmulatorNetwork.Name,
+			Value:   cadence.UInt64(10),
+		}},
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			assert.Equal(t, "supply.cdc", args.Get(1).(flowkit.Script).Location)
+			srv.ExecuteScript.Return(cadence.UInt64(10), nil)
+		})
+
+		err := verifyDeployment(srv.Mock, state, util.NoLogger)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail assertion doesn't hold", func(t *testing.T) {
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			srv.ExecuteScript.Return(cadence.UInt64(9), nil)
+		})
+
+		err := verifyDeployment(srv.Mock, state, util.NoLogger)
+		assert.EqualError(t, err, "deployment verification failed, 1 of 1 assertions don't hold")
+	})
+
+	t.Run("Success unordered dictionary", func(t *testing.T) {
+		dictionary := func(keys ...string) cadence.Dictionary {
+			pairs := make([]cadence.KeyValuePair, 0, len(keys))
+			for i, key := range keys {
+				pairs = append(pairs, cadence.KeyValuePair{Key: cadence.String(key), Value: cadence.UInt64(len(key) + i)})
+			}
+			return cadence.NewDictionary(pairs)
+		}
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			srv.ExecuteScript.Return(dictionary("b", "a"), nil)
+		})
+		assertion, err := state.Assertions().ByName("supply")
+		require.NoError(t, err)
+
+		err = checkAssertion(srv.Mock, state, *assertion, dictionary("b", "a"))
+		assert.NoError(t, err)
+
+		expected := cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.String("a"), Value: cadence.UInt64(2)},
+			{Key: cadence.String("b"), Value: cadence.UInt64(1)},
+		})
+		err = checkAssertion(srv.Mock, state, *assertion, expected)
+		assert.NoError(t, err)
+
+		err = checkAssertion(srv.Mock, state, *assertion, dictionary("a", "b"))
+		assert.Error(t, err)
+	})
+}
+
+func Test_ProjectBundle(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	for _, c := range []tests.Resource{tests.ContractB, tests.ContractA} {
+		state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+	}
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   config.DefaultEmulator.ServiceAccount,
+		Contracts: []config.ContractDeployment{{Name: tests.ContractB.Name}, {Name: tests.ContractA.Name}},
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		bundleFlags.Out = "release.tar.gz"
+		result, err := bundle([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		artifact, err := rw.ReadFile("release.tar.gz")
+		require.NoError(t, err)
+
+		manifest, files, err := readBundle(artifact)
+		require.NoError(t, err)
+		assert.Equal(t, config.EmulatorNetwork.Name, manifest.Network)
+		require.Len(t, manifest.Contracts, 2)
+		assert.Equal(t, tests.ContractA.Name, manifest.Contracts[0].Name)
+		assert.Equal(t, tests.ContractB.Name, manifest.Contracts[1].Name)
+		assert.True(t, strings.Contains(
+			string(files[manifest.Contracts[1].File]),
+			"import ContractA from 0xf8d6e0586b0a20c7",
+		))
+	})
+
+	t.Run("Success deploy from bundle", func(t *testing.T) {
+		deployFlags.FromBundle = "release.tar.gz"
+		result, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		deployFlags.FromBundle = ""
+
+		require.NoError(t, err)
+		assert.Len(t, result.(*deployResult).contracts, 2)
+		srv.Mock.AssertNumberOfCalls(t, "AddContract", 2)
+	})
+
+	t.Run("Fail tampered bundle", func(t *testing.T) {
+		artifact, _ := rw.ReadFile("release.tar.gz")
+		manifest, files, err := readBundle(artifact)
+		require.NoError(t, err)
+
+		files[manifest.Contracts[0].File] = []byte("pub contract ContractA { pub fun x() {} }")
+		tampered, err := writeBundle(*manifest, files)
+		require.NoError(t, err)
+
+		_, _, err = readBundle(tampered)
+		assert.EqualError(t, err, "contract ContractA source does not match the manifest hash")
+	})
+}
+
+func Test_ProjectDeployEmitPayloads(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	for _, c := range []tests.Resource{tests.ContractB, tests.ContractA} {
+		state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+	}
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   config.DefaultEmulator.ServiceAccount,
+		Contracts: []config.ContractDeployment{{Name: tests.ContractB.Name}, {Name: tests.ContractA.Name}},
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		deployFlags.EmitPayloads = "payloads"
+		result, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		deployFlags.EmitPayloads = ""
+		require.NoError(t, err)
+
+		manifest := result.(*payloadsResult).manifest
+		require.Len(t, manifest.Payloads, 2)
+		assert.Equal(t, "01-emulator-account-ContractA.rlp", manifest.Payloads[0].File)
+		assert.Equal(t, "02-emulator-account-ContractB.rlp", manifest.Payloads[1].File)
+		srv.Mock.AssertNotCalled(t, "AddContract", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		first, err := rw.ReadFile("payloads/01-emulator-account-ContractA.rlp")
+		require.NoError(t, err)
+
+		// emitting again must produce exactly the same payloads
+		result, err = emitPayloads("payloads", util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, manifest, result.(*payloadsResult).manifest)
+
+		second, err := rw.ReadFile("payloads/01-emulator-account-ContractA.rlp")
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("Fail existing contract without update", func(t *testing.T) {
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(args.Get(1).(flow.Address).String())
+			account.Contracts = map[string][]byte{tests.ContractA.Name: []byte("pub contract ContractA { pub fun x() {} }")}
+			srv.GetAccount.Return(account, nil)
+		})
+
+		_, err := emitPayloads("payloads", util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "contract ContractA is already deployed to account 0xf8d6e0586b0a20c7, use the update flag to emit an update payload")
+	})
+
+	t.Run("Fail combined flags", func(t *testing.T) {
+		deployFlags.EmitPayloads = "payloads"
+		deployFlags.Verify = true
+		_, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		deployFlags.EmitPayloads = ""
+		deployFlags.Verify = false
+
+		assert.EqualError(t, err, "emit-payloads flag can not be used together with show-diff, from-bundle or verify flags")
+	})
+}
+
+func Test_ProjectClean(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		cachedFile := path.Join("project", flowkit.DefaultBuildCacheDir, "entry.json")
+		require.NoError(t, rw.WriteFile(cachedFile, []byte("{}"), 0644))
+
+		globalFlags := command.GlobalFlags{ConfigPaths: []string{"project/flow.json"}}
+		result, err := clean([]string{}, globalFlags, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "removed: project/.flow/build", result.Oneliner())
+
+		_, err = rw.ReadFile(cachedFile)
+		assert.Error(t, err)
+	})
 }
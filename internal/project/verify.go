@@ -0,0 +1,158 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+// verifyDeployment executes the assertion scripts configured for the network and fails if any of the
+// results doesn't match the expected value.
+func verifyDeployment(flow flowkit.Services, state *flowkit.State, logger output.Logger) error {
+	network := flow.Network().Name
+	assertions := state.Assertions().ByNetwork(network)
+	if len(assertions) == 0 {
+		logger.Info(fmt.Sprintf("No assertions configured for network %s, nothing to verify", network))
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("\nVerifying deployment with %d assertions\n", len(assertions)))
+
+	failed := 0
+	for _, assertion := range assertions {
+		err := checkAssertion(flow, state, assertion, assertion.ExpectedOn(network))
+		if err != nil {
+			failed++
+			logger.Info(fmt.Sprintf("%s %s: %s", output.ErrorEmoji(), assertion.Name, err.Error()))
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("%s %s", output.OkEmoji(), output.Green(assertion.Name)))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("deployment verification failed, %d of %d assertions don't hold", failed, len(assertions))
+	}
+
+	logger.Info(fmt.Sprintf("\n%s All assertions hold", output.SuccessEmoji()))
+	return nil
+}
+
+func checkAssertion(
+	flow flowkit.Services,
+	state *flowkit.State,
+	assertion config.Assertion,
+	expected cadence.Value,
+) error {
+	code, err := state.ReadFile(assertion.Script)
+	if err != nil {
+		return fmt.Errorf("error loading script file: %w", err)
+	}
+
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code:     code,
+			Args:     assertion.Args,
+			Location: assertion.Script,
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return err
+	}
+
+	actual, err := normalizeValue(value)
+	if err != nil {
+		return err
+	}
+	want, err := normalizeValue(expected)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(actual, want) {
+		return fmt.Errorf("expected %s but got %s", expected, value)
+	}
+
+	return nil
+}
+
+// normalizeValue decodes the JSON-Cadence encoding of the value with the dictionary entries ordered by key
+// and the composite fields ordered by name, so values are equal regardless of the order they are listed in.
+func normalizeValue(value cadence.Value) (any, error) {
+	encoded, err := jsoncdc.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	err = json.Unmarshal(encoded, &decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortEntries(decoded), nil
+}
+
+func sortEntries(value any) any {
+	switch value := value.(type) {
+	case []any:
+		for i, element := range value {
+			value[i] = sortEntries(element)
+		}
+	case map[string]any:
+		for key, field := range value {
+			value[key] = sortEntries(field)
+		}
+
+		if entries, ok := value["value"].([]any); ok && value["type"] == "Dictionary" {
+			sortBy(entries, "key")
+		}
+		if fields, ok := value["fields"].([]any); ok {
+			sortBy(fields, "name")
+		}
+	}
+
+	return value
+}
+
+// sortBy orders the JSON objects by the encoding of the property.
+func sortBy(objects []any, property string) {
+	key := func(object any) string {
+		entry, _ := object.(map[string]any)
+		encoded, _ := json.Marshal(entry[property])
+		return string(encoded)
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return key(objects[i]) < key(objects[j])
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/record"
 )
 
 type flagsRun struct {
@@ -33,22 +34,30 @@ type flagsRun struct {
 
 var runFlags = flagsRun{}
 
-// RunCommand This command will act as an alias for running the emulator and deploying the contracts
+// RunCommand This command will act as an alias for running the emulator and deploying the contracts,
+// or replays a runbook recorded with 'flow record' if one is provided.
 var RunCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "run",
-		Short:   "Start emulator and deploy all project contracts",
-		Example: "flow run",
+		Use:   "run [<runbook>]",
+		Short: "Replay a recorded runbook, or start emulator and deploy all project contracts",
+		Example: `flow run
+flow run runbook.json --yes`,
+		Args:    cobra.MaximumNArgs(1),
 		GroupID: "project",
 	},
-	Flags: &runFlags,
+	Flags:    &runFlags,
+	NoRecord: true,
 	Run: func(
-		_ []string,
-		_ command.GlobalFlags,
-		_ output.Logger,
-		_ flowkit.ReaderWriter,
+		args []string,
+		globalFlags command.GlobalFlags,
+		logger output.Logger,
+		readerWriter flowkit.ReaderWriter,
 		_ flowkit.Services,
 	) (command.Result, error) {
+		if len(args) == 1 {
+			return record.Replay(args[0], globalFlags.Yes, logger, readerWriter)
+		}
+
 		fmt.Println("⚠️Deprecation notice: Use 'flow dev' command.")
 		return &runResult{}, nil
 	},
@@ -0,0 +1,146 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package record
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var Cmd = &cobra.Command{
+	Use:              "record",
+	Short:            "Record commands of a session into a runbook replayed by 'flow run'",
+	TraverseChildren: true,
+	GroupID:          "project",
+}
+
+func init() {
+	startCommand.AddToParent(Cmd)
+	stopCommand.AddToParent(Cmd)
+}
+
+// runner runs a single runbook step.
+type runner func(args []string) error
+
+// execRunner runs the step in a new process of the CLI, connected to the standard streams.
+func execRunner(args []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(executable, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// Replay runs the steps of the runbook in the file in order and stops at the first failing step.
+//
+// Steps are approved without prompts if yes is set.
+func Replay(
+	file string,
+	yes bool,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+) (command.Result, error) {
+	runbook, err := command.LoadRunbook(file, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	return replay(runbook, file, yes, logger, execRunner)
+}
+
+func replay(
+	runbook *command.Runbook,
+	file string,
+	yes bool,
+	logger output.Logger,
+	run runner,
+) (command.Result, error) {
+	for i, step := range runbook.Steps {
+		args := append([]string{}, step.Args...)
+		if yes && !slices.Contains(args, "--yes") && !slices.Contains(args, "-y") {
+			args = append(args, "--yes")
+		}
+
+		logger.Info(fmt.Sprintf("Step %d/%d: flow %s", i+1, len(runbook.Steps), strings.Join(args, " ")))
+		err := run(args)
+		if err != nil {
+			return nil, fmt.Errorf("step %d 'flow %s' failed: %w", i+1, strings.Join(args, " "), err)
+		}
+	}
+
+	return &runbookResult{file: file, runbook: runbook, replayed: true}, nil
+}
+
+type runbookResult struct {
+	file     string
+	runbook  *command.Runbook
+	replayed bool
+}
+
+func (r *runbookResult) JSON() any {
+	steps := make([]string, len(r.runbook.Steps))
+	for i, step := range r.runbook.Steps {
+		steps[i] = strings.Join(step.Args, " ")
+	}
+
+	return map[string]any{
+		"runbook": r.file,
+		"steps":   steps,
+	}
+}
+
+func (r *runbookResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Runbook\t%s\n", r.file)
+	for i, step := range r.runbook.Steps {
+		_, _ = fmt.Fprintf(writer, "Step %d\tflow %s\n", i+1, strings.Join(step.Args, " "))
+	}
+
+	if r.replayed {
+		_, _ = fmt.Fprintf(writer, "\n%s Replayed %d steps\n", output.SuccessEmoji(), len(r.runbook.Steps))
+	} else {
+		_, _ = fmt.Fprintf(writer, "\n%s Recorded %d steps, replay them with 'flow run %s'\n", output.SuccessEmoji(), len(r.runbook.Steps), r.file)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *runbookResult) Oneliner() string {
+	return fmt.Sprintf("Runbook: %s, Steps: %d", r.file, len(r.runbook.Steps))
+}
@@ -0,0 +1,62 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package record
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Replay(t *testing.T) {
+	runbook := &command.Runbook{Steps: []command.RunbookStep{
+		{Args: []string{"accounts", "create", "--network", "emulator"}},
+		{Args: []string{"project", "deploy", "--network", "emulator", "-y"}},
+	}}
+
+	t.Run("Success", func(t *testing.T) {
+		var ran []string
+		result, err := replay(runbook, "runbook.json", true, util.NoLogger, func(args []string) error {
+			ran = append(ran, strings.Join(args, " "))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"accounts create --network emulator --yes",
+			"project deploy --network emulator -y",
+		}, ran)
+		assert.Contains(t, result.String(), "Replayed 2 steps")
+	})
+
+	t.Run("Fail stops at failing step", func(t *testing.T) {
+		ran := 0
+		_, err := replay(runbook, "runbook.json", false, util.NoLogger, func(args []string) error {
+			ran++
+			return fmt.Errorf("exit status 1")
+		})
+		assert.EqualError(t, err, "step 1 'flow accounts create --network emulator' failed: exit status 1")
+		assert.Equal(t, 1, ran)
+	})
+}
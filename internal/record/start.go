@@ -0,0 +1,70 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package record
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsStart struct{}
+
+var startFlags = flagsStart{}
+
+var startCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "start [<runbook>]",
+		Short: "Start recording the commands run in the project",
+		Long: `Start recording the commands run in the project to a runbook, which defaults to runbook.json.
+
+Every command that succeeds until the recording is stopped is appended to the runbook with its
+arguments and the network it used, so 'flow run <runbook>' replays the session on the same network.`,
+		Example: "flow record start setup.json",
+		Args:    cobra.MaximumNArgs(1),
+	},
+	Flags:    &startFlags,
+	Run:      start,
+	NoRecord: true,
+}
+
+func start(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	file := "runbook.json"
+	if len(args) == 1 {
+		file = args[0]
+	}
+
+	err := command.StartRecording(globalFlags.ConfigPaths, file, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("%s Recording commands to %s, stop the recording with 'flow record stop'", output.SuccessEmoji(), file))
+	return nil, nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package record
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsStop struct{}
+
+var stopFlags = flagsStop{}
+
+var stopCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "stop",
+		Short:   "Stop recording commands and show the recorded runbook",
+		Example: "flow record stop",
+		Args:    cobra.NoArgs,
+	},
+	Flags:    &stopFlags,
+	Run:      stop,
+	NoRecord: true,
+}
+
+func stop(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	runbook, file, err := command.StopRecording(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runbookResult{file: file, runbook: runbook}, nil
+}
@@ -0,0 +1,318 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsBench struct {
+	Concurrency int    `default:"1" flag:"concurrency" info:"Number of script executions in flight at the same time"`
+	Iterations  int    `default:"100" flag:"iterations" info:"Total number of times the script is executed"`
+	ArgsJSON    string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format, @file to read them from a file"`
+}
+
+var benchFlags = flagsBench{}
+
+var benchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "bench <filename | name> [<argument> <argument> ...]",
+		Short: "Execute a script repeatedly and report latencies and errors",
+		Long: `Execute the script the number of iterations with the number of executions in flight at the same time,
+and report the latency percentiles, the throughput and the error rate of the access node.
+
+Imports of the script are resolved from the project configuration for the selected network.`,
+		Example: `flow scripts bench balance.cdc 0x01cf0e2f2f715450 --concurrency 10 --iterations 1000 --network testnet
+flow scripts bench getBalance 0x01cf0e2f2f715450 --host access.mainnet.nodes.onflow.org:9000 -o json`,
+		Args: cobra.MinimumNArgs(1),
+	},
+	Flags:  &benchFlags,
+	Output: benchOutput{},
+	Run:    bench,
+}
+
+func bench(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if benchFlags.Concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1")
+	}
+	if benchFlags.Iterations < 1 {
+		return nil, fmt.Errorf("iterations must be at least 1")
+	}
+
+	script, err := loadScript(args, benchFlags.ArgsJSON, globalFlags, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf(
+		"Executing script %d times with concurrency %d...",
+		benchFlags.Iterations,
+		benchFlags.Concurrency,
+	))
+	defer logger.StopProgress()
+
+	start := time.Now()
+	runs := benchScript(flow, script, benchFlags.Iterations, benchFlags.Concurrency)
+
+	return newBenchResult(runs, benchFlags.Concurrency, time.Since(start)), nil
+}
+
+// benchRun is the latency and the error of a single script execution.
+type benchRun struct {
+	latency time.Duration
+	err     error
+}
+
+// benchScript executes the script the number of iterations with the number of workers.
+func benchScript(flow flowkit.Services, script flowkit.Script, iterations int, workers int) []benchRun {
+	queue := make(chan struct{}, workers)
+	results := make(chan benchRun)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range queue {
+				start := time.Now()
+				_, err := flow.ExecuteScript(context.Background(), script, flowkit.LatestScriptQuery)
+				results <- benchRun{latency: time.Since(start), err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(results)
+		wg.Wait()
+	}()
+
+	go func() {
+		defer close(queue)
+		for i := 0; i < iterations; i++ {
+			queue <- struct{}{}
+		}
+	}()
+
+	runs := make([]benchRun, 0, iterations)
+	for run := range results {
+		runs = append(runs, run)
+	}
+
+	return runs
+}
+
+type benchResult struct {
+	concurrency int
+	duration    time.Duration
+	// latencies of the successful executions in increasing order
+	latencies []time.Duration
+	failed    int
+	// errors counts the failed executions by the error message
+	errors map[string]int
+}
+
+func newBenchResult(runs []benchRun, concurrency int, duration time.Duration) *benchResult {
+	result := &benchResult{
+		concurrency: concurrency,
+		duration:    duration,
+		latencies:   make([]time.Duration, 0, len(runs)),
+		errors:      make(map[string]int),
+	}
+
+	for _, run := range runs {
+		if run.err != nil {
+			result.failed++
+			result.errors[run.err.Error()]++
+			continue
+		}
+		result.latencies = append(result.latencies, run.latency)
+	}
+	sort.Slice(result.latencies, func(i, j int) bool {
+		return result.latencies[i] < result.latencies[j]
+	})
+
+	return result
+}
+
+func (r *benchResult) iterations() int {
+	return len(r.latencies) + r.failed
+}
+
+// percentile returns the latency of the successful executions at the percentile using the nearest rank.
+func (r *benchResult) percentile(p float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(r.latencies))))
+	if rank < 1 {
+		rank = 1
+	}
+	return r.latencies[rank-1]
+}
+
+func (r *benchResult) mean() time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, latency := range r.latencies {
+		total += latency
+	}
+	return total / time.Duration(len(r.latencies))
+}
+
+func (r *benchResult) errorRate() float64 {
+	return float64(r.failed) / float64(r.iterations()) * 100
+}
+
+func (r *benchResult) throughput() float64 {
+	return float64(r.iterations()) / r.duration.Seconds()
+}
+
+// benchOutput is the JSON output of the bench command, latencies are in milliseconds.
+type benchOutput struct {
+	Iterations  int               `json:"iterations"`
+	Concurrency int               `json:"concurrency"`
+	Succeeded   int               `json:"succeeded"`
+	Failed      int               `json:"failed"`
+	ErrorRate   float64           `json:"errorRate"`
+	DurationMs  float64           `json:"durationMs"`
+	Throughput  float64           `json:"throughput"`
+	Latency     benchLatency      `json:"latency"`
+	Errors      []benchErrorCount `json:"errors"`
+}
+
+type benchLatency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+type benchErrorCount struct {
+	Error string `json:"error"`
+	Count int    `json:"count"`
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// errorCounts returns the distinct errors from the most to the least frequent.
+func (r *benchResult) errorCounts() []benchErrorCount {
+	counts := make([]benchErrorCount, 0, len(r.errors))
+	for err, count := range r.errors {
+		counts = append(counts, benchErrorCount{Error: err, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Error < counts[j].Error
+	})
+	return counts
+}
+
+func (r *benchResult) JSON() any {
+	return benchOutput{
+		Iterations:  r.iterations(),
+		Concurrency: r.concurrency,
+		Succeeded:   len(r.latencies),
+		Failed:      r.failed,
+		ErrorRate:   r.errorRate(),
+		DurationMs:  milliseconds(r.duration),
+		Throughput:  r.throughput(),
+		Latency: benchLatency{
+			Min:  milliseconds(r.percentile(0)),
+			Mean: milliseconds(r.mean()),
+			P50:  milliseconds(r.percentile(50)),
+			P90:  milliseconds(r.percentile(90)),
+			P95:  milliseconds(r.percentile(95)),
+			P99:  milliseconds(r.percentile(99)),
+			Max:  milliseconds(r.percentile(100)),
+		},
+		Errors: r.errorCounts(),
+	}
+}
+
+func (r *benchResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Iterations\t%d\n", r.iterations())
+	_, _ = fmt.Fprintf(writer, "Concurrency\t%d\n", r.concurrency)
+	_, _ = fmt.Fprintf(writer, "Duration\t%s\n", r.duration.Round(time.Millisecond))
+	_, _ = fmt.Fprintf(writer, "Throughput\t%.2f scripts/s\n", r.throughput())
+	_, _ = fmt.Fprintf(writer, "Succeeded\t%d\n", len(r.latencies))
+	_, _ = fmt.Fprintf(writer, "Failed\t%d (%.2f%%)\n", r.failed, r.errorRate())
+
+	if len(r.latencies) > 0 {
+		_, _ = fmt.Fprintf(writer, "\nLatency\n")
+		_, _ = fmt.Fprintf(writer, "    Min\t%s\n", r.percentile(0))
+		_, _ = fmt.Fprintf(writer, "    Mean\t%s\n", r.mean())
+		for _, p := range []float64{50, 90, 95, 99} {
+			_, _ = fmt.Fprintf(writer, "    P%.0f\t%s\n", p, r.percentile(p))
+		}
+		_, _ = fmt.Fprintf(writer, "    Max\t%s\n", r.percentile(100))
+	}
+
+	if r.failed > 0 {
+		_, _ = fmt.Fprintf(writer, "\nErrors\n")
+		for _, count := range r.errorCounts() {
+			_, _ = fmt.Fprintf(writer, "    %d\t%s\n", count.Count, count.Error)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *benchResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Iterations: %d, Failed: %d, P50: %s, P99: %s",
+		r.iterations(),
+		r.failed,
+		r.percentile(50),
+		r.percentile(99),
+	)
+}
@@ -0,0 +1,186 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// scriptCacheDir is the project relative directory where script results are cached.
+const scriptCacheDir = ".flow/scripts"
+
+// scriptCache stores results of read-only script executions on disk.
+//
+// Results are keyed by the script, the arguments, the network and the block the script is executed at.
+// Results at the latest block expire after the TTL, results at a block height or ID never change.
+type scriptCache struct {
+	dir          string
+	ttl          time.Duration
+	network      config.Network
+	readerWriter flowkit.ReaderWriter
+}
+
+// newScriptCache creates a cache in the project directory, the TTL is a duration such as 30s or 5m.
+func newScriptCache(
+	configPaths []string,
+	ttl string,
+	network config.Network,
+	readerWriter flowkit.ReaderWriter,
+) (*scriptCache, error) {
+	duration, err := time.ParseDuration(ttl)
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("invalid cache TTL %s, provide a positive duration such as 30s or 5m", ttl)
+	}
+
+	return &scriptCache{
+		dir:          filepath.Join(util.ProjectDir(configPaths), scriptCacheDir),
+		ttl:          duration,
+		network:      network,
+		readerWriter: readerWriter,
+	}, nil
+}
+
+type scriptCacheEntry struct {
+	Value   json.RawMessage `json:"value"`
+	Expires *time.Time      `json:"expires,omitempty"`
+}
+
+// key creates a cache key from the script source and location, relative imports are resolved
+// from the location, the arguments, the network and the block of the query.
+func (c *scriptCache) key(script flowkit.Script, query flowkit.ScriptQuery) (string, error) {
+	hash := sha256.New()
+	hash.Write(script.Code)
+	hash.Write([]byte{0})
+	hash.Write([]byte(filepath.Clean(script.Location)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.network.Name + "@" + c.network.Host))
+	for _, arg := range script.Args {
+		encoded, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte{0})
+		hash.Write(encoded)
+	}
+	hash.Write([]byte{0})
+	hash.Write([]byte(queryKey(query)))
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// queryKey identifies the block of the query.
+func queryKey(query flowkit.ScriptQuery) string {
+	switch {
+	case query.Height != 0:
+		return fmt.Sprintf("height:%d", query.Height)
+	case query.ID != flowkit.LatestScriptQuery.ID:
+		return fmt.Sprintf("id:%s", query.ID)
+	default:
+		return "latest"
+	}
+}
+
+func (c *scriptCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// get returns the cached result, any failure to read the entry or an expired entry is treated as a cache miss.
+func (c *scriptCache) get(key string) (cadence.Value, bool) {
+	data, err := c.readerWriter.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry scriptCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if entry.Expires != nil && time.Now().After(*entry.Expires) {
+		return nil, false
+	}
+
+	value, err := jsoncdc.Decode(nil, entry.Value)
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (c *scriptCache) put(key string, value cadence.Value, query flowkit.ScriptQuery) error {
+	if err := flowkit.MkdirAll(c.readerWriter, c.dir); err != nil {
+		return err
+	}
+
+	encoded, err := jsoncdc.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	entry := scriptCacheEntry{Value: encoded}
+	if queryKey(query) == "latest" {
+		expires := time.Now().Add(c.ttl)
+		entry.Expires = &expires
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return c.readerWriter.WriteFile(c.path(key), data, 0644)
+}
+
+// executeScript executes the script, or returns the cached result of a previous execution.
+// Failing to write the cache doesn't fail the execution.
+func (c *scriptCache) executeScript(
+	ctx context.Context,
+	flow flowkit.Services,
+	script flowkit.Script,
+	query flowkit.ScriptQuery,
+) (cadence.Value, error) {
+	key, err := c.key(script, query)
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := c.get(key); ok {
+		return value, nil
+	}
+
+	value, err := flow.ExecuteScript(ctx, script, query)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = c.put(key, value, query)
+	return value, nil
+}
@@ -20,7 +20,9 @@ package scripts
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence"
 	flowsdk "github.com/onflow/flow-go-sdk"
@@ -28,24 +30,45 @@ import (
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/templates"
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsScripts struct {
-	ArgsJSON    string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	BlockID     string `default:"" flag:"block-id" info:"block ID to execute the script at"`
-	BlockHeight uint64 `default:"" flag:"block-height" info:"block height to execute the script at"`
+	ArgsJSON     string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format, @file to read them from a file or - from the standard input"`
+	BlockID      string   `default:"" flag:"block-id" info:"block ID to execute the script at, for reading historical state"`
+	BlockHeight  uint64   `default:"" flag:"block-height" info:"block height to execute the script at, for reading historical state"`
+	ArgFromEvent []string `default:"" flag:"arg-from-event" info:"Argument value taken from a previous transaction event in the format txID:EventType.field, appended after other arguments"`
+	Trace        bool     `default:"false" flag:"trace" info:"Print the Cadence functions called by the script, requires the emulator started with --coverage-reporting"`
+	Template     string   `default:"" flag:"template" info:"Name of a built-in script template to execute instead of a code file"`
+	Param        []string `default:"" flag:"param" info:"Template parameter keyed by name as name=value or name:Type:value, can be provided multiple times"`
+	Watch        bool     `default:"false" flag:"watch" info:"Execute the script again whenever the script file or a file it imports changes"`
+	Profile      bool     `default:"false" flag:"profile" info:"Report the computation and memory used, the contract functions called and the loops executed by the script on the emulator, requires the emulator started with --persist"`
+	EmulatorDB   string   `default:"./flowdb" flag:"emulator-db" info:"Database directory the emulator persists its state to, used by the profile flag"`
+	Cache        bool     `default:"false" flag:"cache" info:"Return the cached result of a previous execution of the script with the same arguments at the same block"`
+	CacheTTL     string   `default:"1m" flag:"cache-ttl" info:"Duration results of scripts executed at the latest block are cached for (e.g. 30s, 5m)"`
 }
 
 var scriptFlags = flagsScripts{}
 
 var executeCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "execute <filename> [<argument> <argument> ...]",
-		Short:   "Execute a script",
-		Example: `flow scripts execute script.cdc "Meow" "Woof"`,
-		Args:    cobra.MinimumNArgs(1),
+		Use:   "execute <filename | name | -> [<argument> <argument> ...]",
+		Short: "Execute a script",
+		Example: `flow scripts execute script.cdc "Meow" "Woof"
+flow scripts execute getBalance 0x01cf0e2f2f715450
+flow scripts execute --template get-flow-balance --param address=0x01cf0e2f2f715450
+flow scripts execute report.cdc --args-json @args.json
+generate-script | flow scripts execute - --args-json @args.json
+flow scripts execute balance.cdc 0x01cf0e2f2f715450 --watch
+flow scripts execute balance.cdc 0x01cf0e2f2f715450 --block-height 52000000 --network mainnet
+flow scripts execute balance.cdc 0x01cf0e2f2f715450 --profile
+flow scripts execute balance.cdc 0x01cf0e2f2f715450 --cache --cache-ttl 5m --network mainnet`,
+		Args: cobra.ArbitraryArgs,
 	},
 	Flags: &scriptFlags,
 	Run:   execute,
@@ -53,50 +76,199 @@ var executeCommand = &command.Command{
 
 func execute(
 	args []string,
-	_ command.GlobalFlags,
-	_ output.Logger,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
 	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
-	filename := args[0]
+	if scriptFlags.Template == "" && len(args) > 0 {
+		args = append([]string{scriptFilename(args[0], globalFlags, readerWriter)}, args[1:]...)
+	}
 
-	code, err := readerWriter.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("error loading script file: %w", err)
+	if scriptFlags.Watch {
+		return nil, watch(args, globalFlags, logger, readerWriter, flow)
+	}
+
+	return executeScript(args, globalFlags, readerWriter, flow)
+}
+
+// executeScript executes the script from the file or template once and returns the result.
+func executeScript(
+	args []string,
+	globalFlags command.GlobalFlags,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	var code []byte
+	var filename string
+	var argValues []string
+	var err error
+	if scriptFlags.Template != "" {
+		template, err := templates.ByName(scriptFlags.Template, templates.KindScript)
+		if err != nil {
+			return nil, err
+		}
+		code = template.Source(util.NetworkChain(flow.Network()))
+		argValues = args
+	} else {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("requires a code filename or the template flag")
+		}
+		code, err = util.ReadCode(args[0], scriptFlags.ArgsJSON, readerWriter)
+		if err != nil {
+			return nil, fmt.Errorf("error loading script file: %w", err)
+		}
+		if args[0] != util.StdinFilename {
+			filename = args[0]
+		}
+		argValues = args[1:]
+	}
+
+	if len(scriptFlags.ArgFromEvent) > 0 {
+		eventValues, err := events.ArgumentsFromEvents(flow, scriptFlags.ArgFromEvent)
+		if err != nil {
+			return nil, err
+		}
+		argValues = append(argValues, eventValues...)
 	}
 
 	var scriptArgs []cadence.Value
 	if scriptFlags.ArgsJSON != "" {
-		scriptArgs, err = arguments.ParseJSON(scriptFlags.ArgsJSON)
+		scriptArgs, err = util.ParseArgsJSON(scriptFlags.ArgsJSON, readerWriter)
+	} else if len(scriptFlags.Param) > 0 {
+		if len(argValues) > 0 {
+			return nil, fmt.Errorf("parameters keyed by name can not be combined with positional arguments")
+		}
+		scriptArgs, err = arguments.ParseNamed(scriptFlags.Param, code, filename)
 	} else {
-		scriptArgs, err = arguments.ParseWithoutType(args[1:], code, filename)
+		scriptArgs, err = arguments.ParseWithoutType(argValues, code, filename)
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("error parsing script arguments: %w", err)
 	}
 
-	query := flowkit.ScriptQuery{}
-	if scriptFlags.BlockHeight != 0 {
-		query.Height = scriptFlags.BlockHeight
-	} else if scriptFlags.BlockID != "" {
-		query.ID = flowsdk.HexToID(scriptFlags.BlockID)
-	} else {
-		query.Latest = true
-	}
-
-	value, err := flow.ExecuteScript(
-		context.Background(),
-		flowkit.Script{
-			Code:     code,
-			Args:     scriptArgs,
-			Location: filename,
-		},
-		query,
-	)
+	query, err := scriptQuery(scriptFlags.BlockID, scriptFlags.BlockHeight)
 	if err != nil {
 		return nil, err
 	}
 
+	script := flowkit.Script{
+		Code:     code,
+		Args:     scriptArgs,
+		Location: filename,
+	}
+
+	if scriptFlags.Profile {
+		if flow.Network().Name != config.EmulatorNetwork.Name {
+			return nil, fmt.Errorf("profiling is only supported on the emulator network")
+		}
+		state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+		if err != nil {
+			return nil, err
+		}
+		return profileScript(script, state, scriptFlags.EmulatorDB)
+	}
+
+	var trace *EmulatorTrace
+	if scriptFlags.Trace {
+		trace, err = StartEmulatorTrace(flow.Network())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var value cadence.Value
+	if scriptFlags.Cache && trace == nil {
+		cache, err := newScriptCache(globalFlags.ConfigPaths, scriptFlags.CacheTTL, flow.Network(), readerWriter)
+		if err != nil {
+			return nil, err
+		}
+		value, err = cache.executeScript(context.Background(), flow, script, query)
+	} else {
+		value, err = flow.ExecuteScript(context.Background(), script, query)
+	}
+	if err != nil {
+		// locations of deployed contracts in runtime errors are mapped to the local sources
+		state, loadErr := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return nil, util.NewSourceMap(state, flow.Network()).AnnotateError(err)
+	}
+
+	if trace != nil {
+		return trace.Collect(&scriptResult{value}, flow, script)
+	}
+
 	return &scriptResult{value}, nil
 }
+
+// scriptFilename returns the file of the script configured in the project with the name,
+// or the name itself if no such script is configured so it's read as a file.
+func scriptFilename(name string, globalFlags command.GlobalFlags, readerWriter flowkit.ReaderWriter) string {
+	if name == util.StdinFilename {
+		return name
+	}
+
+	state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return name
+	}
+
+	script, err := state.Scripts().ByName(name)
+	if err != nil {
+		return name
+	}
+
+	return script.Filename
+}
+
+// loadScript reads the script file or the file of the script configured with the name, and parses
+// the arguments from the rest of the command arguments or the JSON-Cadence arguments flag.
+func loadScript(
+	args []string,
+	argsJSON string,
+	globalFlags command.GlobalFlags,
+	readerWriter flowkit.ReaderWriter,
+) (flowkit.Script, error) {
+	filename := scriptFilename(args[0], globalFlags, readerWriter)
+	code, err := readerWriter.ReadFile(filename)
+	if err != nil {
+		return flowkit.Script{}, fmt.Errorf("error loading script file: %w", err)
+	}
+
+	script := flowkit.Script{Code: code, Location: filename}
+	if argsJSON != "" {
+		script.Args, err = util.ParseArgsJSON(argsJSON, readerWriter)
+	} else {
+		script.Args, err = arguments.ParseWithoutType(args[1:], code, filename)
+	}
+	if err != nil {
+		return flowkit.Script{}, fmt.Errorf("error parsing script arguments: %w", err)
+	}
+
+	return script, nil
+}
+
+// scriptQuery returns the query executing the script at the block with the ID or height,
+// or at the latest block if neither is provided.
+func scriptQuery(blockID string, blockHeight uint64) (flowkit.ScriptQuery, error) {
+	if blockID != "" && blockHeight != 0 {
+		return flowkit.ScriptQuery{}, fmt.Errorf("block ID and block height flags can not be combined")
+	}
+
+	if blockHeight != 0 {
+		return flowkit.ScriptQuery{Height: blockHeight}, nil
+	}
+
+	if blockID != "" {
+		id, err := hex.DecodeString(strings.TrimPrefix(blockID, "0x"))
+		if err != nil || len(id) != len(flowsdk.EmptyID) {
+			return flowkit.ScriptQuery{}, fmt.Errorf("invalid block ID %s", blockID)
+		}
+		return flowkit.ScriptQuery{ID: flowsdk.BytesToID(id)}, nil
+	}
+
+	return flowkit.LatestScriptQuery, nil
+}
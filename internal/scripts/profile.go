@@ -0,0 +1,456 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/flow-emulator/emulator"
+	"github.com/onflow/flow-emulator/storage/sqlite"
+	flowgo "github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// emulatorDatabase is the file the emulator persists its state to in the database directory.
+const emulatorDatabase = "emulator.sqlite"
+
+// profileScript executes the script on a copy of the emulator state persisted in the database directory
+// and profiles the execution of the script and the contracts it calls.
+//
+// The emulator must be started with the --persist flag, the copy is used so the running emulator isn't affected.
+func profileScript(script flowkit.Script, state *flowkit.State, dbPath string) (*profiledResult, error) {
+	dir, err := copyEmulatorDatabase(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := sqlite.New(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	report := runtime.NewCoverageReport()
+	blockchain, err := emulator.New(emulator.WithStore(store), emulator.WithCoverageReport(report))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the emulator state: %w", err)
+	}
+
+	program, err := project.NewProgram(script.Code, script.Args, script.Location)
+	if err != nil {
+		return nil, err
+	}
+	if program.HasImports() {
+		if state == nil {
+			return nil, config.ErrDoesNotExist
+		}
+		contracts, err := state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+		if err != nil {
+			return nil, err
+		}
+		program, err = project.NewImportReplacer(contracts, state.AliasesForNetwork(config.EmulatorNetwork)).Replace(program)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	args := make([][]byte, 0, len(script.Args))
+	for _, arg := range script.Args {
+		encoded, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, encoded)
+	}
+
+	result, err := blockchain.ExecuteScript(program.Code(), args)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	profile := &scriptProfile{
+		computation: result.ComputationUsed,
+		memory:      result.MemoryEstimate,
+	}
+	for location, coverage := range report.Coverage {
+		var code []byte
+		name := location.String()
+		switch location := location.(type) {
+		case common.ScriptLocation:
+			code = program.Code()
+			if script.Location != "" {
+				name = script.Location
+			}
+		case common.AddressLocation:
+			account, err := blockchain.GetAccount(flowgo.Address(location.Address))
+			if err != nil {
+				return nil, err
+			}
+			code = account.Contracts[location.Name]
+		}
+		if code == nil {
+			continue
+		}
+
+		err := profile.add(name, code, coverage.LineHits)
+		if err != nil {
+			return nil, err
+		}
+	}
+	profile.estimateComputation()
+
+	return &profiledResult{Result: &scriptResult{result.Value}, profile: profile}, nil
+}
+
+// copyEmulatorDatabase copies the database of the emulator to a temporary directory.
+func copyEmulatorDatabase(dbPath string) (string, error) {
+	source := filepath.Join(dbPath, emulatorDatabase)
+	if _, err := os.Stat(source); err != nil {
+		return "", fmt.Errorf(
+			"profiling executes the script on the emulator state persisted in %s, start the emulator with the --persist flag",
+			dbPath,
+		)
+	}
+
+	dir, err := os.MkdirTemp("", "flow-profile")
+	if err != nil {
+		return "", err
+	}
+
+	// the write-ahead log contains the changes not yet merged into the database
+	for _, file := range []string{emulatorDatabase, emulatorDatabase + "-wal"} {
+		err := copyFile(filepath.Join(dbPath, file), filepath.Join(dir, file))
+		if err != nil && !os.IsNotExist(err) {
+			_ = os.RemoveAll(dir)
+			return "", fmt.Errorf("failed to copy the emulator state: %w", err)
+		}
+	}
+
+	return dir, nil
+}
+
+func copyFile(source string, destination string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// functionProfile is the execution profile of a function.
+type functionProfile struct {
+	location       string
+	name           string
+	line           int
+	endLine        int
+	calls          int
+	statements     int
+	loopIterations int
+	computation    uint64
+}
+
+// operations returns the number of metered operations the function executed.
+func (f *functionProfile) operations() int {
+	return f.calls + f.statements + f.loopIterations
+}
+
+// loopProfile is the number of iterations a loop executed.
+type loopProfile struct {
+	location   string
+	function   string
+	line       int
+	iterations int
+}
+
+// scriptProfile contains the computation and memory used by the script execution,
+// and the executed functions and loops of the script and the contracts it called.
+type scriptProfile struct {
+	computation uint64
+	memory      uint64
+	functions   []*functionProfile
+	loops       []loopProfile
+}
+
+// add profiles the functions and loops of the program from the number of times each line was executed.
+//
+// Executed statements are attributed to the innermost function declaring them, the calls of a function
+// are the executions of its first statement and the iterations of a loop the executions of its first statement.
+func (p *scriptProfile) add(location string, code []byte, lineHits map[int]int) error {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return err
+	}
+
+	var composites []*ast.CompositeDeclaration
+	var functions []*functionProfile
+	var loops []*ast.Block
+	ast.Inspect(program, func(element ast.Element) bool {
+		switch element := element.(type) {
+		case *ast.CompositeDeclaration:
+			composites = append(composites, element)
+		case *ast.FunctionDeclaration:
+			if element.FunctionBlock == nil || element.FunctionBlock.Block == nil {
+				break
+			}
+			block := element.FunctionBlock.Block
+			function := &functionProfile{
+				location: location,
+				name:     element.Identifier.Identifier,
+				line:     block.StartPos.Line,
+				endLine:  block.EndPos.Line,
+			}
+			if len(block.Statements) > 0 {
+				function.calls = lineHits[block.Statements[0].StartPosition().Line]
+			}
+			functions = append(functions, function)
+		case *ast.ForStatement:
+			loops = append(loops, element.Block)
+		case *ast.WhileStatement:
+			loops = append(loops, element.Block)
+		}
+		return true
+	})
+
+	// qualify the function names with the declaring composites, composites are inspected outer first
+	for _, function := range functions {
+		name := function.name
+		for i := len(composites) - 1; i >= 0; i-- {
+			composite := composites[i]
+			if composite.StartPos.Line <= function.line && function.endLine <= composite.EndPos.Line {
+				name = fmt.Sprintf("%s.%s", composite.Identifier.Identifier, name)
+			}
+		}
+		function.name = name
+	}
+
+	for line, hits := range lineHits {
+		if function := innermostFunction(functions, line); function != nil {
+			function.statements += hits
+		}
+	}
+
+	for _, loop := range loops {
+		if loop == nil || len(loop.Statements) == 0 {
+			continue
+		}
+		iterations := lineHits[loop.Statements[0].StartPosition().Line]
+		if iterations == 0 {
+			continue
+		}
+
+		profile := loopProfile{location: location, line: loop.StartPos.Line, iterations: iterations}
+		if function := innermostFunction(functions, loop.StartPos.Line); function != nil {
+			function.loopIterations += iterations
+			profile.function = function.name
+		}
+		p.loops = append(p.loops, profile)
+	}
+
+	for _, function := range functions {
+		if function.calls > 0 || function.statements > 0 {
+			p.functions = append(p.functions, function)
+		}
+	}
+
+	return nil
+}
+
+// innermostFunction returns the function with the smallest body containing the line.
+func innermostFunction(functions []*functionProfile, line int) *functionProfile {
+	var innermost *functionProfile
+	for _, function := range functions {
+		if function.line > line || function.endLine < line {
+			continue
+		}
+		if innermost == nil || function.endLine-function.line < innermost.endLine-innermost.line {
+			innermost = function
+		}
+	}
+	return innermost
+}
+
+// estimateComputation distributes the computation used by the script to the functions
+// by the share of the metered operations they executed, and orders the functions and loops by cost.
+func (p *scriptProfile) estimateComputation() {
+	operations := 0
+	for _, function := range p.functions {
+		operations += function.operations()
+	}
+	for _, function := range p.functions {
+		if operations > 0 {
+			function.computation = p.computation * uint64(function.operations()) / uint64(operations)
+		}
+	}
+
+	sort.SliceStable(p.functions, func(i, j int) bool {
+		a, b := p.functions[i], p.functions[j]
+		if a.operations() != b.operations() {
+			return a.operations() > b.operations()
+		}
+		if a.location != b.location {
+			return a.location < b.location
+		}
+		return a.line < b.line
+	})
+	sort.SliceStable(p.loops, func(i, j int) bool {
+		a, b := p.loops[i], p.loops[j]
+		if a.iterations != b.iterations {
+			return a.iterations > b.iterations
+		}
+		if a.location != b.location {
+			return a.location < b.location
+		}
+		return a.line < b.line
+	})
+}
+
+// profiledResult appends the execution profile to the script result.
+type profiledResult struct {
+	command.Result
+	profile *scriptProfile
+}
+
+// profileOutput is the JSON output of the execution profile.
+type profileOutput struct {
+	Computation uint64                  `json:"computation"`
+	Memory      uint64                  `json:"memory"`
+	Functions   []functionProfileOutput `json:"functions"`
+	Loops       []loopProfileOutput     `json:"loops"`
+}
+
+type functionProfileOutput struct {
+	Location             string `json:"location"`
+	Function             string `json:"function"`
+	Line                 int    `json:"line"`
+	Calls                int    `json:"calls"`
+	Statements           int    `json:"statements"`
+	LoopIterations       int    `json:"loopIterations"`
+	EstimatedComputation uint64 `json:"estimatedComputation"`
+}
+
+type loopProfileOutput struct {
+	Location   string `json:"location"`
+	Function   string `json:"function,omitempty"`
+	Line       int    `json:"line"`
+	Iterations int    `json:"iterations"`
+}
+
+func (r *profiledResult) JSON() any {
+	profile := profileOutput{
+		Computation: r.profile.computation,
+		Memory:      r.profile.memory,
+		Functions:   make([]functionProfileOutput, 0, len(r.profile.functions)),
+		Loops:       make([]loopProfileOutput, 0, len(r.profile.loops)),
+	}
+	for _, function := range r.profile.functions {
+		profile.Functions = append(profile.Functions, functionProfileOutput{
+			Location:             function.location,
+			Function:             function.name,
+			Line:                 function.line,
+			Calls:                function.calls,
+			Statements:           function.statements,
+			LoopIterations:       function.loopIterations,
+			EstimatedComputation: function.computation,
+		})
+	}
+	for _, loop := range r.profile.loops {
+		profile.Loops = append(profile.Loops, loopProfileOutput{
+			Location:   loop.location,
+			Function:   loop.function,
+			Line:       loop.line,
+			Iterations: loop.iterations,
+		})
+	}
+
+	return map[string]any{
+		"result":  r.Result.JSON(),
+		"profile": profile,
+	}
+}
+
+func (r *profiledResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s\n\nProfile:\n", r.Result.String())
+	_, _ = fmt.Fprintf(writer, "    Computation Used\t%d\n", r.profile.computation)
+	_, _ = fmt.Fprintf(writer, "    Memory Estimate\t%d bytes\n", r.profile.memory)
+
+	_, _ = fmt.Fprintf(writer, "\n    Function\tLocation\tCalls\tStatements\tLoop Iterations\tComputation (est.)\n")
+	for _, function := range r.profile.functions {
+		_, _ = fmt.Fprintf(
+			writer,
+			"    %s\t%s:%d\t%d\t%d\t%d\t%d\n",
+			function.name,
+			function.location,
+			function.line,
+			function.calls,
+			function.statements,
+			function.loopIterations,
+			function.computation,
+		)
+	}
+
+	if len(r.profile.loops) > 0 {
+		_, _ = fmt.Fprintf(writer, "\n    Loop\tFunction\tIterations\n")
+		for _, loop := range r.profile.loops {
+			_, _ = fmt.Fprintf(writer, "    %s:%d\t%s\t%d\n", loop.location, loop.line, loop.function, loop.iterations)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *profiledResult) Oneliner() string {
+	return fmt.Sprintf(
+		"%s, Computation: %d, Memory: %d",
+		r.Result.Oneliner(),
+		r.profile.computation,
+		r.profile.memory,
+	)
+}
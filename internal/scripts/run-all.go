@@ -0,0 +1,233 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRunAll struct {
+	Concurrency int    `default:"1" flag:"concurrency" info:"Number of scripts executed in parallel"`
+	Cache       bool   `default:"false" flag:"cache" info:"Return the cached results of previous executions of the scripts"`
+	CacheTTL    string `default:"1m" flag:"cache-ttl" info:"Duration results of the scripts are cached for (e.g. 30s, 5m)"`
+}
+
+var runAllFlags = flagsRunAll{}
+
+var runAllCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "run-all <directory>",
+		Short: "Execute every script in a directory and report the results",
+		Long: `Execute every .cdc script in the directory, without arguments, and report the result or error of each script.
+
+Imports of the scripts are resolved from the project configuration for the selected network.`,
+		Example: `flow scripts run-all ./scripts
+flow scripts run-all ./scripts --concurrency 4 --network testnet -o json
+flow scripts run-all ./scripts --cache --cache-ttl 10m --network mainnet`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags:  &runAllFlags,
+	Output: runAllOutput{},
+	Run:    runAll,
+}
+
+func runAll(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if runAllFlags.Concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1")
+	}
+
+	var cache *scriptCache
+	if runAllFlags.Cache {
+		var err error
+		cache, err = newScriptCache(globalFlags.ConfigPaths, runAllFlags.CacheTTL, flow.Network(), readerWriter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := util.CadenceFiles(args[0], readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no scripts found in %s", args[0])
+	}
+
+	logger.StartProgress(fmt.Sprintf("Executing %d scripts...", len(files)))
+	defer logger.StopProgress()
+
+	return &runAllResult{runs: runScripts(flow, readerWriter, cache, files, runAllFlags.Concurrency)}, nil
+}
+
+// scriptRun is the result or the error of executing a script file.
+type scriptRun struct {
+	file  string
+	value cadence.Value
+	err   error
+}
+
+// runScripts executes the script files with the number of workers, the runs are sorted by the file.
+// The results are cached if the cache isn't nil.
+func runScripts(
+	flow flowkit.Services,
+	readerWriter flowkit.ReaderWriter,
+	cache *scriptCache,
+	files []string,
+	workers int,
+) []scriptRun {
+	queue := make(chan string, workers)
+	results := make(chan scriptRun)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range queue {
+				results <- runScript(flow, readerWriter, cache, file)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(results)
+		wg.Wait()
+	}()
+
+	go func() {
+		defer close(queue)
+		for _, file := range files {
+			queue <- file
+		}
+	}()
+
+	runs := make([]scriptRun, 0, len(files))
+	for run := range results {
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].file < runs[j].file
+	})
+
+	return runs
+}
+
+func runScript(flow flowkit.Services, readerWriter flowkit.ReaderWriter, cache *scriptCache, file string) scriptRun {
+	code, err := readerWriter.ReadFile(file)
+	if err != nil {
+		return scriptRun{file: file, err: fmt.Errorf("error loading script file: %w", err)}
+	}
+
+	script := flowkit.Script{Code: code, Location: file}
+	var value cadence.Value
+	if cache != nil {
+		value, err = cache.executeScript(context.Background(), flow, script, flowkit.LatestScriptQuery)
+	} else {
+		value, err = flow.ExecuteScript(context.Background(), script, flowkit.LatestScriptQuery)
+	}
+
+	return scriptRun{file: file, value: value, err: err}
+}
+
+type runAllResult struct {
+	runs []scriptRun
+}
+
+func (r *runAllResult) failed() int {
+	failed := 0
+	for _, run := range r.runs {
+		if run.err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// runAllOutput is the JSON output of the run-all command.
+type runAllOutput struct {
+	Scripts   []scriptRunOutput `json:"scripts"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+type scriptRunOutput struct {
+	File   string `json:"file"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (r *runAllResult) JSON() any {
+	scripts := make([]scriptRunOutput, 0, len(r.runs))
+	for _, run := range r.runs {
+		script := scriptRunOutput{File: run.file}
+		if run.err != nil {
+			script.Error = run.err.Error()
+		} else {
+			script.Result = command.CadenceJSON(run.value)
+		}
+		scripts = append(scripts, script)
+	}
+
+	return runAllOutput{
+		Scripts:   scripts,
+		Succeeded: len(r.runs) - r.failed(),
+		Failed:    r.failed(),
+	}
+}
+
+func (r *runAllResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for _, run := range r.runs {
+		if run.err != nil {
+			_, _ = fmt.Fprintf(writer, "%s %s\t%s\n", output.ErrorEmoji(), run.file, run.err)
+		} else {
+			_, _ = fmt.Fprintf(writer, "%s %s\t%s\n", output.OkEmoji(), run.file, run.value)
+		}
+	}
+	_, _ = fmt.Fprintf(writer, "\nSucceeded\t%d\n", len(r.runs)-r.failed())
+	_, _ = fmt.Fprintf(writer, "Failed\t%d\n", r.failed())
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *runAllResult) Oneliner() string {
+	return fmt.Sprintf("Succeeded: %d, Failed: %d", len(r.runs)-r.failed(), r.failed())
+}
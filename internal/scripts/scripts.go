@@ -20,13 +20,12 @@ package scripts
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 
 	"github.com/onflow/cadence"
-	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -39,6 +38,9 @@ var Cmd = &cobra.Command{
 
 func init() {
 	executeCommand.AddToParent(Cmd)
+	runAllCommand.AddToParent(Cmd)
+	benchCommand.AddToParent(Cmd)
+	watchBlocksCommand.AddToParent(Cmd)
 }
 
 type scriptResult struct {
@@ -46,9 +48,7 @@ type scriptResult struct {
 }
 
 func (r *scriptResult) JSON() any {
-	return json.RawMessage(
-		jsoncdc.MustEncode(r.Value),
-	)
+	return command.CadenceJSON(r.Value)
 }
 
 func (r *scriptResult) String() string {
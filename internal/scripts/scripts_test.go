@@ -19,14 +19,26 @@
 package scripts
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/flow-emulator/emulator"
+	"github.com/onflow/flow-emulator/storage/sqlite"
+	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
@@ -49,6 +61,25 @@ func Test_Execute(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Success template", func(t *testing.T) {
+		scriptFlags.Template = "get-flow-balance"
+		scriptFlags.Param = []string{"address=0x01cf0e2f2f715450"}
+		defer func() {
+			scriptFlags.Template = ""
+			scriptFlags.Param = nil
+		}()
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Contains(t, string(script.Code), "import FungibleToken from 0xee82856bf20e2aa6")
+			assert.Equal(t, "0x01cf0e2f2f715450", script.Args[0].String())
+		}).Return(cadence.UFix64(1), nil)
+
+		result, err := execute([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+	})
+
 	t.Run("Fail non-existing file", func(t *testing.T) {
 		inArgs := []string{"non-existing"}
 		result, err := execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
@@ -63,6 +94,606 @@ func Test_Execute(t *testing.T) {
 		result, err := execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
 		assert.Nil(t, result)
 		assert.EqualError(t, err, "error parsing script arguments: invalid character 'i' looking for beginning of value")
+		scriptFlags.ArgsJSON = "" // reset
+	})
+
+	t.Run("Success JSON arguments file", func(t *testing.T) {
+		err := rw.WriteFile("args.json", []byte(`[
+			{"type": "Dictionary", "value": [{"key": {"type": "String", "value": "a"}, "value": {"type": "Int", "value": "1"}}]},
+			{"type": "Array", "value": [{"type": "Struct", "value": {"id": "A.0000000000000001.Geometry.Point", "fields": [{"name": "x", "value": {"type": "Int", "value": "2"}}]}}]},
+			{"type": "Path", "value": {"domain": "storage", "identifier": "flowTokenVault"}}
+		]`), 0644)
+		assert.NoError(t, err)
+		scriptFlags.ArgsJSON = "@args.json"
+		defer func() { scriptFlags.ArgsJSON = "" }()
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Len(t, script.Args, 3)
+			assert.Equal(t, `{"a": 1}`, script.Args[0].String())
+			assert.Equal(t, "/storage/flowTokenVault", script.Args[2].String())
+		}).Return(cadence.NewInt(1), nil)
+
+		result, err := execute([]string{tests.TestScriptSimple.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success code from stdin", func(t *testing.T) {
+		stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+		require.NoError(t, err)
+		_, err = stdin.Write(tests.ScriptArgString.Source)
+		require.NoError(t, err)
+		_, err = stdin.Seek(0, 0)
+		require.NoError(t, err)
+		defer func(original *os.File) { os.Stdin = original }(os.Stdin)
+		os.Stdin = stdin
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Equal(t, tests.ScriptArgString.Source, script.Code)
+			assert.Equal(t, `"foo"`, script.Args[0].String())
+			assert.Empty(t, script.Location)
+		}).Return(cadence.NewInt(1), nil)
+
+		result, err := execute([]string{"-", "foo"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail code and JSON arguments from stdin", func(t *testing.T) {
+		scriptFlags.ArgsJSON = "-"
+		defer func() { scriptFlags.ArgsJSON = "" }()
+
+		_, err := execute([]string{"-"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "the code and the JSON arguments can not both be read from the standard input")
+	})
+
+	t.Run("Fail missing JSON arguments file", func(t *testing.T) {
+		scriptFlags.ArgsJSON = "@missing.json"
+		defer func() { scriptFlags.ArgsJSON = "" }()
+
+		_, err := execute([]string{tests.TestScriptSimple.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "error parsing script arguments: failed to read JSON arguments: open missing.json: file does not exist")
+	})
+
+	t.Run("Success at block height", func(t *testing.T) {
+		scriptFlags.BlockHeight = 42
+		defer func() { scriptFlags.BlockHeight = 0 }()
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			assert.Equal(t, flowkit.ScriptQuery{Height: 42}, args.Get(2).(flowkit.ScriptQuery))
+		}).Return(cadence.NewInt(1), nil)
+
+		result, err := execute([]string{tests.TestScriptSimple.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success at block ID", func(t *testing.T) {
+		scriptFlags.BlockID = "0x" + util.TestID.String()
+		defer func() { scriptFlags.BlockID = "" }()
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			assert.Equal(t, flowkit.ScriptQuery{ID: util.TestID}, args.Get(2).(flowkit.ScriptQuery))
+		}).Return(cadence.NewInt(1), nil)
+
+		result, err := execute([]string{tests.TestScriptSimple.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success named script", func(t *testing.T) {
+		_, state, rw := util.TestMocks(t)
+		state.Scripts().AddOrUpdate(config.Script{Name: "greet", Filename: tests.ScriptArgString.Filename})
+		require.NoError(t, state.SaveDefault())
+		globalFlags := command.GlobalFlags{ConfigPaths: []string{config.DefaultPath}}
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Equal(t, tests.ScriptArgString.Source, script.Code)
+			assert.Equal(t, tests.ScriptArgString.Filename, script.Location)
+			assert.Equal(t, `"foo"`, script.Args[0].String())
+		}).Return(cadence.NewInt(1), nil)
+
+		result, err := execute([]string{"greet", "foo"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail block flags", func(t *testing.T) {
+		_, err := scriptQuery(util.TestID.String(), 42)
+		assert.EqualError(t, err, "block ID and block height flags can not be combined")
+
+		_, err = scriptQuery("invalid", 0)
+		assert.EqualError(t, err, "invalid block ID invalid")
+
+		query, err := scriptQuery("", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, flowkit.LatestScriptQuery, query)
+	})
+
+}
+
+func Test_Watch(t *testing.T) {
+	_, state, rw := util.TestMocks(t)
+
+	t.Run("Imported files", func(t *testing.T) {
+		state.Contracts().AddOrUpdate(config.Contract{Name: "Bar", Location: "contracts/Bar.cdc"})
+		files := map[string]string{
+			"scripts/main.cdc":   "import Foo from \"../contracts/Foo.cdc\"\nimport \"Bar\"\npub fun main() {}",
+			"contracts/Foo.cdc":  "import \"Bar\"\npub contract Foo {}",
+			"contracts/Bar.cdc":  "import Baz from \"./Baz.cdc\"\npub contract Bar {}",
+			"contracts/Baz.cdc":  "pub contract Baz {}",
+			"contracts/Skip.cdc": "pub contract Skip {}",
+		}
+		for name, code := range files {
+			require.NoError(t, rw.WriteFile(name, []byte(code), 0644))
+		}
+
+		assert.Equal(t, []string{
+			"scripts/main.cdc",
+			"contracts/Foo.cdc",
+			"contracts/Bar.cdc",
+			"contracts/Baz.cdc",
+		}, importedFiles("scripts/main.cdc", rw, state))
+	})
+
+	t.Run("Wait for change", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "script.cdc")
+		require.NoError(t, os.WriteFile(file, []byte("pub fun main() {}"), 0644))
+
+		go func() {
+			time.Sleep(2 * watchInterval)
+			_ = os.WriteFile(file, []byte("pub fun main(): Int { return 1 }"), 0644)
+		}()
+
+		changed, err := waitForChange(context.Background(), []string{file})
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+
+	t.Run("Stop waiting", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "script.cdc")
+		require.NoError(t, os.WriteFile(file, []byte("pub fun main() {}"), 0644))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		changed, err := waitForChange(ctx, []string{file})
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("Fail template", func(t *testing.T) {
+		scriptFlags.Template = "get-flow-balance"
+		defer func() { scriptFlags.Template = "" }()
+
+		err := watch(nil, command.GlobalFlags{}, util.NoLogger, rw, nil)
+		assert.EqualError(t, err, "watch flag requires a script file")
+	})
+}
+
+func Test_RunAll(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	scripts := map[string]string{
+		"all/a.cdc":      "pub fun main(): Int { return 1 }",
+		"all/b.cdc":      "pub fun main(): Int { panic(\"b\") }",
+		"all/c.cdc":      "pub fun main(): String { return \"c\" }",
+		"all/readme.txt": "not a script",
+	}
+	for name, code := range scripts {
+		require.NoError(t, rw.WriteFile(name, []byte(code), 0644))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		runAllFlags.Concurrency = 1
+		defer func() { runAllFlags.Concurrency = 0 }()
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			switch args.Get(1).(flowkit.Script).Location {
+			case "all/b.cdc":
+				srv.ExecuteScript.Return(nil, fmt.Errorf("panic: b"))
+			case "all/c.cdc":
+				srv.ExecuteScript.Return(cadence.String("c"), nil)
+			default:
+				srv.ExecuteScript.Return(cadence.NewInt(1), nil)
+			}
+		})
+
+		result, err := runAll([]string{"all"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, runAllOutput{
+			Scripts: []scriptRunOutput{
+				{File: "all/a.cdc", Result: json.Number("1")},
+				{File: "all/b.cdc", Error: "panic: b"},
+				{File: "all/c.cdc", Result: "c"},
+			},
+			Succeeded: 2,
+			Failed:    1,
+		}, result.JSON())
+	})
+
+	t.Run("Success concurrent", func(t *testing.T) {
+		srv.ExecuteScript.Run(func(mock.Arguments) {}).Return(cadence.NewInt(1), nil)
+
+		runs := runScripts(srv.Mock, rw, nil, []string{"all/c.cdc", "all/a.cdc", "all/b.cdc"}, 3)
+		require.Len(t, runs, 3)
+		for i, file := range []string{"all/a.cdc", "all/b.cdc", "all/c.cdc"} {
+			assert.Equal(t, file, runs[i].file)
+			assert.NoError(t, runs[i].err)
+		}
 	})
 
+	t.Run("Fail concurrency", func(t *testing.T) {
+		_, err := runAll([]string{"all"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "concurrency must be at least 1")
+	})
+
+	t.Run("Fail no scripts", func(t *testing.T) {
+		runAllFlags.Concurrency = 1
+		defer func() { runAllFlags.Concurrency = 0 }()
+		require.NoError(t, rw.WriteFile("empty/readme.txt", nil, 0644))
+
+		_, err := runAll([]string{"empty"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "no scripts found in empty")
+	})
+}
+
+func Test_Profile(t *testing.T) {
+
+	t.Run("Analyse", func(t *testing.T) {
+		code := []byte(`
+pub contract Counter {
+    pub fun sum(n: Int): Int {
+        var total = 0
+        var i = 0
+        while i < n {
+            total = total + i
+            i = i + 1
+        }
+        return total
+    }
+
+    pub fun unused(): Int {
+        return 0
+    }
+}`)
+		// each line executed once, the loop body three times
+		lineHits := map[int]int{4: 1, 5: 1, 6: 4, 7: 3, 8: 3, 10: 1}
+
+		profile := &scriptProfile{computation: 100}
+		require.NoError(t, profile.add("Counter", code, lineHits))
+		profile.estimateComputation()
+
+		require.Len(t, profile.functions, 1)
+		function := profile.functions[0]
+		assert.Equal(t, "Counter.sum", function.name)
+		assert.Equal(t, 1, function.calls)
+		assert.Equal(t, 13, function.statements)
+		assert.Equal(t, 3, function.loopIterations)
+		assert.Equal(t, uint64(100), function.computation)
+
+		require.Len(t, profile.loops, 1)
+		assert.Equal(t, loopProfile{location: "Counter", function: "Counter.sum", line: 6, iterations: 3}, profile.loops[0])
+	})
+
+	t.Run("Execute on emulator state", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := sqlite.New(dir)
+		require.NoError(t, err)
+		_, err = emulator.New(emulator.WithStore(store))
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		script := flowkit.Script{
+			Code: []byte(`
+pub fun main(n: Int): Int {
+    var total = 0
+    for i in [1, 2, 3] {
+        total = total + i * n
+    }
+    return total
+}`),
+			Args:     []cadence.Value{cadence.NewInt(2)},
+			Location: "sum.cdc",
+		}
+
+		result, err := profileScript(script, nil, dir)
+		require.NoError(t, err)
+		assert.Equal(t, "Result: 12\n", result.Result.String())
+		assert.NotZero(t, result.profile.computation)
+		require.Len(t, result.profile.functions, 1)
+		assert.Equal(t, "main", result.profile.functions[0].name)
+		assert.Equal(t, "sum.cdc", result.profile.functions[0].location)
+		require.Len(t, result.profile.loops, 1)
+		assert.Equal(t, 3, result.profile.loops[0].iterations)
+	})
+
+	t.Run("Fail not persisted", func(t *testing.T) {
+		_, err := profileScript(flowkit.Script{}, nil, t.TempDir())
+		assert.ErrorContains(t, err, "start the emulator with the --persist flag")
+	})
+
+	t.Run("Fail not emulator", func(t *testing.T) {
+		scriptFlags.Profile = true
+		defer func() { scriptFlags.Profile = false }()
+		srv, _, rw := util.TestMocks(t)
+		srv.Network.Return(config.TestnetNetwork)
+
+		_, err := execute([]string{tests.ScriptArgString.Filename, "foo"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "profiling is only supported on the emulator network")
+	})
+}
+
+func Test_Cache(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	scriptFlags.Cache = true
+	scriptFlags.CacheTTL = "1m"
+	defer func() {
+		scriptFlags.Cache = false
+		scriptFlags.CacheTTL = ""
+	}()
+
+	executions := 0
+	srv.ExecuteScript.Run(func(mock.Arguments) {
+		executions++
+	}).Return(cadence.NewInt(1), nil)
+
+	t.Run("Success", func(t *testing.T) {
+		inArgs := []string{tests.ScriptArgString.Filename, "foo"}
+
+		for i := 0; i < 2; i++ {
+			result, err := execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+			require.NoError(t, err)
+			assert.Equal(t, "Result: 1\n", result.String())
+		}
+		assert.Equal(t, 1, executions)
+
+		// other arguments are executed again
+		_, err := execute([]string{tests.ScriptArgString.Filename, "bar"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, 2, executions)
+	})
+
+	t.Run("Expire latest results", func(t *testing.T) {
+		cache, err := newScriptCache(nil, "1ns", config.EmulatorNetwork, rw)
+		require.NoError(t, err)
+		script := flowkit.Script{Code: tests.ScriptArgString.Source, Location: tests.ScriptArgString.Filename}
+
+		latest, err := cache.key(script, flowkit.LatestScriptQuery)
+		require.NoError(t, err)
+		require.NoError(t, cache.put(latest, cadence.NewInt(2), flowkit.LatestScriptQuery))
+		time.Sleep(time.Millisecond)
+		_, ok := cache.get(latest)
+		assert.False(t, ok)
+
+		// results at a block height don't change
+		query := flowkit.ScriptQuery{Height: 42}
+		atHeight, err := cache.key(script, query)
+		require.NoError(t, err)
+		assert.NotEqual(t, latest, atHeight)
+		require.NoError(t, cache.put(atHeight, cadence.NewInt(2), query))
+		time.Sleep(time.Millisecond)
+		value, ok := cache.get(atHeight)
+		assert.True(t, ok)
+		assert.Equal(t, cadence.NewInt(2), value)
+	})
+
+	t.Run("Fail invalid TTL", func(t *testing.T) {
+		scriptFlags.CacheTTL = "soon"
+		defer func() { scriptFlags.CacheTTL = "1m" }()
+
+		_, err := execute([]string{tests.ScriptArgString.Filename, "foo"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid cache TTL soon, provide a positive duration such as 30s or 5m")
+	})
+}
+
+func Test_Bench(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	benchFlags.Concurrency = 4
+	benchFlags.Iterations = 20
+	defer func() {
+		benchFlags.Concurrency = 0
+		benchFlags.Iterations = 0
+	}()
+
+	t.Run("Success", func(t *testing.T) {
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Equal(t, tests.ScriptArgString.Filename, script.Location)
+			assert.Equal(t, `"foo"`, script.Args[0].String())
+		}).Return(cadence.NewInt(1), nil)
+
+		inArgs := []string{tests.ScriptArgString.Filename, "foo"}
+		result, err := bench(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		out := result.JSON().(benchOutput)
+		assert.Equal(t, 20, out.Iterations)
+		assert.Equal(t, 20, out.Succeeded)
+		assert.Equal(t, 0, out.Failed)
+		assert.Empty(t, out.Errors)
+	})
+
+	t.Run("Percentiles and errors", func(t *testing.T) {
+		runs := make([]benchRun, 0, 100)
+		for i := 100; i > 0; i-- {
+			runs = append(runs, benchRun{latency: time.Duration(i) * time.Millisecond})
+		}
+		runs = append(runs, benchRun{err: fmt.Errorf("rate limited")}, benchRun{err: fmt.Errorf("rate limited")})
+
+		result := newBenchResult(runs, 2, time.Second)
+		out := result.JSON().(benchOutput)
+		assert.Equal(t, 102, out.Iterations)
+		assert.Equal(t, 2, out.Failed)
+		assert.Equal(t, benchLatency{Min: 1, Mean: 50.5, P50: 50, P90: 90, P95: 95, P99: 99, Max: 100}, out.Latency)
+		assert.Equal(t, []benchErrorCount{{Error: "rate limited", Count: 2}}, out.Errors)
+		assert.Contains(t, result.String(), "2 (1.96%)\n")
+	})
+
+	t.Run("Fail iterations", func(t *testing.T) {
+		benchFlags.Iterations = 0
+		defer func() { benchFlags.Iterations = 20 }()
+
+		_, err := bench([]string{tests.ScriptArgString.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "iterations must be at least 1")
+	})
+}
+
+func Test_WatchBlocks(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+
+	latest := tests.NewBlock()
+	srv.GetBlock.Return(latest, nil)
+
+	// the result changes at block 12 and the script fails at block 13
+	srv.ExecuteScript.Run(func(args mock.Arguments) {
+		switch args.Get(2).(flowkit.ScriptQuery).Height {
+		case 12:
+			srv.ExecuteScript.Return(cadence.NewInt(2), nil)
+		case 13:
+			srv.ExecuteScript.Return(nil, fmt.Errorf("rate limited"))
+		default:
+			srv.ExecuteScript.Return(cadence.NewInt(1), nil)
+		}
+	})
+
+	heights := func(runs []watchRun) []uint64 {
+		result := make([]uint64, 0, len(runs))
+		for _, run := range runs {
+			result = append(result, run.height)
+		}
+		return result
+	}
+
+	t.Run("Success every block", func(t *testing.T) {
+		watcher := &blockWatcher{flow: srv.Mock, everyBlock: true}
+
+		latest.Height = 10
+		runs, err := watcher.poll(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{10}, heights(runs))
+
+		latest.Height = 13
+		runs, err = watcher.poll(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{11, 12, 13}, heights(runs))
+		assert.EqualError(t, runs[2].err, "rate limited")
+
+		runs, err = watcher.poll(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+
+	t.Run("Success on change only", func(t *testing.T) {
+		watcher := &blockWatcher{flow: srv.Mock, everyBlock: true, onChangeOnly: true}
+
+		latest.Height = 10
+		_, err := watcher.poll(context.Background())
+		require.NoError(t, err)
+
+		latest.Height = 14
+		runs, err := watcher.poll(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{12, 13, 14}, heights(runs))
+
+		result := &watchResult{runs[0]}
+		assert.Equal(t, watchOutput{BlockHeight: 12, Result: json.Number("2")}, result.JSON())
+		assert.Equal(t, "Block 12\tResult: 2\n", result.String())
+	})
+
+	t.Run("Success interval", func(t *testing.T) {
+		watcher := &blockWatcher{flow: srv.Mock}
+
+		latest.Height = 10
+		_, err := watcher.poll(context.Background())
+		require.NoError(t, err)
+
+		latest.Height = 14
+		runs, err := watcher.poll(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{14}, heights(runs))
+
+		runs, err = watcher.poll(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{14}, heights(runs))
+	})
+
+	t.Run("Fail invalid interval", func(t *testing.T) {
+		watchBlocksFlags.Interval = "often"
+		defer func() { watchBlocksFlags.Interval = "" }()
+
+		_, err := watchBlocks([]string{"script.cdc"}, command.GlobalFlags{}, util.NoLogger, nil, srv.Mock)
+		assert.EqualError(t, err, "invalid interval often, provide a positive duration such as 10s or 1m")
+	})
+}
+
+func Test_EmulatorTrace(t *testing.T) {
+	t.Run("Fail not emulator", func(t *testing.T) {
+		_, err := StartEmulatorTrace(config.TestnetNetwork)
+		assert.EqualError(t, err, "tracing is only supported on the emulator network")
+	})
+
+	t.Run("Fail coverage disabled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"coverage":null}`))
+		}))
+		t.Cleanup(server.Close)
+
+		_, err := startTrace(server.URL)
+		assert.ErrorContains(t, err, "--coverage-reporting")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		srv, _, _ := util.TestMocks(t)
+		contract := []byte(`
+pub contract Counter {
+    pub fun add(_ n: Int): Int {
+        return n + 1
+    }
+}`)
+		srv.GetAccount.Run(func(mock.Arguments) {
+			srv.GetAccount.Return(&flow.Account{Contracts: map[string][]byte{"Counter": contract}}, nil)
+		})
+
+		reports := []string{
+			`{"coverage":{"A.01cf0e2f2f715450.Counter":{"line_hits":{"4":1}}}}`,
+			`{"coverage":{"A.01cf0e2f2f715450.Counter":{"line_hits":{"4":3}},"s.0a":{"line_hits":{"3":1,"4":1}}}}`,
+		}
+		var methods []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			_, _ = w.Write([]byte(reports[0]))
+			reports = reports[1:]
+		}))
+		t.Cleanup(server.Close)
+
+		trace, err := startTrace(server.URL)
+		require.NoError(t, err)
+
+		script := flowkit.Script{
+			Code: []byte(`
+pub fun main(): Int {
+    let a = Counter.add(1)
+    return Counter.add(a)
+}`),
+			Location: "count.cdc",
+		}
+		result, err := trace.Collect(&scriptResult{cadence.NewInt(3)}, srv.Mock, script)
+		require.NoError(t, err)
+		assert.Equal(t, []string{http.MethodGet, http.MethodGet}, methods)
+
+		traced := result.(*TracedResult)
+		require.Len(t, traced.profile.functions, 2)
+		add := traced.profile.functions[0]
+		assert.Equal(t, "Counter.add", add.name)
+		assert.Equal(t, "A.01cf0e2f2f715450.Counter", add.location)
+		assert.Equal(t, 2, add.calls)
+		assert.Equal(t, "main", traced.profile.functions[1].name)
+		assert.Equal(t, "count.cdc", traced.profile.functions[1].location)
+		assert.Equal(t, 2, add.statements)
+		assert.InDelta(t, 57.1, traced.share(add), 0.1)
+	})
 }
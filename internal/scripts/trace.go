@@ -0,0 +1,262 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// emulatorAdminPort is the default port of the emulator admin API.
+const emulatorAdminPort = "8080"
+
+// EmulatorTrace traces the Cadence functions called by a transaction or script on the emulator.
+//
+// The emulator records the executed lines when started with the --coverage-reporting flag, the trace reads
+// that record when it starts and after the traced transaction or script ran, and attributes the lines executed
+// in between to the functions declaring them. The record itself is left untouched. Other transactions executed
+// by the emulator in the meantime are part of the trace as well.
+type EmulatorTrace struct {
+	endpoint string
+	start    *coverageReport
+}
+
+// StartEmulatorTrace starts tracing the called functions on the emulator network.
+func StartEmulatorTrace(network config.Network) (*EmulatorTrace, error) {
+	if network.Name != config.EmulatorNetwork.Name {
+		return nil, fmt.Errorf("tracing is only supported on the emulator network")
+	}
+
+	host, _, err := net.SplitHostPort(network.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid emulator host %s: %w", network.Host, err)
+	}
+
+	return startTrace(fmt.Sprintf("http://%s/emulator/codeCoverage", net.JoinHostPort(host, emulatorAdminPort)))
+}
+
+func startTrace(endpoint string) (*EmulatorTrace, error) {
+	trace := &EmulatorTrace{endpoint: endpoint}
+
+	report, err := trace.report()
+	if err != nil {
+		return nil, err
+	}
+	if report.Coverage == nil {
+		return nil, fmt.Errorf("the emulator doesn't record executed lines, start it with the --coverage-reporting flag to trace")
+	}
+	trace.start = report
+
+	return trace, nil
+}
+
+// Collect returns the result with the trace of the functions called since the trace started.
+//
+// The code of the traced script is used for script locations since the emulator doesn't store scripts,
+// the code of transactions and contracts is fetched from the emulator.
+func (t *EmulatorTrace) Collect(result command.Result, flow flowkit.Services, script flowkit.Script) (command.Result, error) {
+	report, err := t.report()
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make(map[flowsdk.Address]map[string][]byte)
+	profile := &scriptProfile{}
+	for location, coverage := range report.Coverage {
+		lineHits := make(map[int]int)
+		for line, hits := range coverage.LineHits {
+			if executed := hits - t.start.Coverage[location].LineHits[line]; executed > 0 {
+				lineHits[line] = executed
+			}
+		}
+		if len(lineHits) == 0 {
+			continue
+		}
+
+		name := location
+		var code []byte
+		switch parts := strings.Split(location, "."); parts[0] {
+		case "s":
+			code = script.Code
+			if script.Location != "" {
+				name = script.Location
+			}
+		case "t":
+			tx, _, err := flow.GetTransactionByID(context.Background(), flowsdk.HexToID(parts[1]), false)
+			if err != nil {
+				return nil, err
+			}
+			code = tx.Script
+		case "A":
+			if len(parts) != 3 {
+				continue
+			}
+			address := flowsdk.HexToAddress(parts[1])
+			if _, ok := contracts[address]; !ok {
+				account, err := flow.GetAccount(context.Background(), address)
+				if err != nil {
+					return nil, err
+				}
+				contracts[address] = account.Contracts
+			}
+			code = contracts[address][parts[2]]
+		}
+		if code == nil {
+			continue
+		}
+
+		err := profile.add(name, code, lineHits)
+		if err != nil {
+			return nil, err
+		}
+	}
+	profile.estimateComputation()
+
+	return &TracedResult{Result: result, profile: profile}, nil
+}
+
+type coverageReport struct {
+	Coverage map[string]struct {
+		LineHits map[int]int `json:"line_hits"`
+	} `json:"coverage"`
+}
+
+func (t *EmulatorTrace) report() (*coverageReport, error) {
+	req, err := http.NewRequest(http.MethodGet, t.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("emulator trace request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("emulator trace request error: status_code=%d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var report coverageReport
+	err = json.Unmarshal(body, &report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// TracedResult appends the called functions to the result of a transaction or script.
+type TracedResult struct {
+	command.Result
+	profile *scriptProfile
+}
+
+// traceOutput is the JSON output of a called function, the share is the percentage of
+// the metered operations executed by the trace the function executed.
+type traceOutput struct {
+	Location       string  `json:"location"`
+	Function       string  `json:"function"`
+	Line           int     `json:"line"`
+	Calls          int     `json:"calls"`
+	Statements     int     `json:"statements"`
+	LoopIterations int     `json:"loopIterations"`
+	Share          float64 `json:"share"`
+}
+
+// share returns the percentage of the metered operations of the trace executed by the function.
+func (r *TracedResult) share(function *functionProfile) float64 {
+	operations := 0
+	for _, f := range r.profile.functions {
+		operations += f.operations()
+	}
+	if operations == 0 {
+		return 0
+	}
+
+	return float64(function.operations()) * 100 / float64(operations)
+}
+
+func (r *TracedResult) JSON() any {
+	functions := make([]traceOutput, 0, len(r.profile.functions))
+	for _, function := range r.profile.functions {
+		functions = append(functions, traceOutput{
+			Location:       function.location,
+			Function:       function.name,
+			Line:           function.line,
+			Calls:          function.calls,
+			Statements:     function.statements,
+			LoopIterations: function.loopIterations,
+			Share:          r.share(function),
+		})
+	}
+
+	return map[string]any{
+		"result": r.Result.JSON(),
+		"trace":  functions,
+	}
+}
+
+func (r *TracedResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s\n\nTrace:\n", r.Result.String())
+	if len(r.profile.functions) == 0 {
+		_, _ = fmt.Fprintf(writer, "    No called functions recorded\n")
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "    Function\tLocation\tCalls\tStatements\tLoop Iterations\tComputation Share (est.)\n")
+	for _, function := range r.profile.functions {
+		_, _ = fmt.Fprintf(
+			writer,
+			"    %s\t%s:%d\t%d\t%d\t%d\t%.1f%%\n",
+			function.name,
+			function.location,
+			function.line,
+			function.calls,
+			function.statements,
+			function.loopIterations,
+			r.share(function),
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
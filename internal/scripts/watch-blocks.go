@@ -0,0 +1,226 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// blockPollInterval is how often the latest sealed block is checked for a new height.
+const blockPollInterval = time.Second
+
+type flagsWatchBlocks struct {
+	OnChangeOnly bool   `default:"false" flag:"on-change-only" info:"Only print the result when it differs from the previous result"`
+	Interval     string `default:"" flag:"interval" info:"Execute the script at the latest block on the interval (e.g. 10s, 1m) instead of at every new sealed block"`
+	ArgsJSON     string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format, @file to read them from a file"`
+}
+
+var watchBlocksFlags = flagsWatchBlocks{}
+
+var watchBlocksCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "watch <filename | name> [<argument> <argument> ...]",
+		Short: "Execute a script at every new sealed block and print the results",
+		Long: `Execute the script at every new sealed block, or at the latest block on the interval, and print the result
+until it's interrupted. Errors are printed and the script keeps being executed, since a later block might fix them.
+
+Imports of the script are resolved from the project configuration for the selected network.`,
+		Example: `flow scripts watch price.cdc --on-change-only --network mainnet
+flow scripts watch getSupply --interval 30s -o json`,
+		Args: cobra.MinimumNArgs(1),
+	},
+	Flags:  &watchBlocksFlags,
+	Output: watchOutput{},
+	Run:    watchBlocks,
+}
+
+func watchBlocks(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	var interval time.Duration
+	if watchBlocksFlags.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(watchBlocksFlags.Interval)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf(
+				"invalid interval %s, provide a positive duration such as 10s or 1m",
+				watchBlocksFlags.Interval,
+			)
+		}
+	}
+
+	script, err := loadScript(args, watchBlocksFlags.ArgsJSON, globalFlags, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	watcher := &blockWatcher{
+		flow:         flow,
+		script:       script,
+		everyBlock:   interval == 0,
+		onChangeOnly: watchBlocksFlags.OnChangeOnly,
+	}
+
+	if interval == 0 {
+		interval = blockPollInterval
+		logger.Info(fmt.Sprintf("%s Executing the script at every new sealed block, stop with Ctrl+C", output.TryEmoji()))
+	} else {
+		logger.Info(fmt.Sprintf("%s Executing the script every %s, stop with Ctrl+C", output.TryEmoji(), interval))
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		runs, err := watcher.poll(ctx)
+		if err != nil {
+			// the access node might be temporarily unavailable so the next poll is tried anyway
+			logger.Error(fmt.Sprintf("failed to get the latest block: %s", err))
+		}
+
+		for _, run := range runs {
+			if run.err != nil {
+				logger.Error(fmt.Sprintf("block %d: %s", run.height, run.err))
+				continue
+			}
+
+			err = command.PrintResult(&watchResult{run}, globalFlags)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchRun is the result or the error of executing the script at a block height.
+type watchRun struct {
+	height uint64
+	value  cadence.Value
+	err    error
+}
+
+// output is the printed form of the run, used to detect the result changed.
+func (r watchRun) output() string {
+	if r.err != nil {
+		return "error: " + r.err.Error()
+	}
+	return r.value.String()
+}
+
+// blockWatcher executes the script at the blocks sealed since the last poll.
+type blockWatcher struct {
+	flow         flowkit.Services
+	script       flowkit.Script
+	everyBlock   bool
+	onChangeOnly bool
+	lastHeight   uint64
+	lastOutput   string
+	initialized  bool
+}
+
+// poll returns the runs of the script that should be printed.
+//
+// The script is executed at every block sealed since the last poll, or only at the latest block if not
+// watching every block, the first poll executes the script at the latest block. If only changes are
+// watched, runs with the same result or error as the previous run are left out.
+func (w *blockWatcher) poll(ctx context.Context) ([]watchRun, error) {
+	latest, err := w.flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	from := latest.Height
+	if w.initialized && w.everyBlock {
+		from = w.lastHeight + 1
+	}
+
+	runs := make([]watchRun, 0)
+	for height := from; height <= latest.Height; height++ {
+		value, err := w.flow.ExecuteScript(ctx, w.script, flowkit.ScriptQuery{Height: height})
+		run := watchRun{height: height, value: value, err: err}
+
+		changed := !w.initialized || run.output() != w.lastOutput
+		w.lastHeight = height
+		w.lastOutput = run.output()
+		w.initialized = true
+
+		if changed || !w.onChangeOnly {
+			runs = append(runs, run)
+		}
+	}
+
+	return runs, nil
+}
+
+type watchResult struct {
+	run watchRun
+}
+
+// watchOutput is the JSON output of a script execution of the watch command.
+type watchOutput struct {
+	BlockHeight uint64 `json:"blockHeight"`
+	Result      any    `json:"result"`
+}
+
+func (r *watchResult) JSON() any {
+	return watchOutput{
+		BlockHeight: r.run.height,
+		Result:      command.CadenceJSON(r.run.value),
+	}
+}
+
+func (r *watchResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Block %d\tResult: %s\n", r.run.height, r.run.value)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *watchResult) Oneliner() string {
+	return fmt.Sprintf("%d %s", r.run.height, r.run.value)
+}
@@ -0,0 +1,163 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/radovskyb/watcher"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// watchInterval is how often watched files are checked for changes.
+const watchInterval = 300 * time.Millisecond
+
+// clearScreen moves the cursor to the top left corner and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// watch executes the script and executes it again whenever the script or a file it imports changes,
+// until it's interrupted. Errors are printed and the script keeps being watched, since a change might fix them.
+func watch(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) error {
+	if scriptFlags.Template != "" || len(args) == 0 || args[0] == util.StdinFilename {
+		return fmt.Errorf("watch flag requires a script file")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// imports of contracts by name can only be watched with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return err
+	}
+	for {
+		if globalFlags.Format == "text" {
+			fmt.Print(clearScreen)
+		}
+
+		result, err := executeScript(args, globalFlags, readerWriter, flow)
+		if err != nil {
+			logger.Error(err.Error())
+		} else {
+			err = command.PrintResult(result, globalFlags)
+			if err != nil {
+				return err
+			}
+		}
+
+		files := importedFiles(args[0], readerWriter, state)
+		logger.Info(fmt.Sprintf("%s Watching %d files for changes, stop with Ctrl+C", output.TryEmoji(), len(files)))
+
+		changed, err := waitForChange(ctx, files)
+		if err != nil || !changed {
+			return err
+		}
+	}
+}
+
+// importedFiles returns the script file and the files of all contracts it imports, including their imports.
+//
+// Imports by file path are resolved relative to the importing file and imports by contract name
+// are resolved to the location of the contract in the project configuration.
+func importedFiles(filename string, readerWriter flowkit.ReaderWriter, state *flowkit.State) []string {
+	files := make([]string, 0)
+	visited := make(map[string]bool)
+
+	var visit func(file string)
+	visit = func(file string) {
+		file = path.Clean(file)
+		if visited[file] {
+			return
+		}
+		visited[file] = true
+		files = append(files, file)
+
+		code, err := readerWriter.ReadFile(file)
+		if err != nil {
+			return
+		}
+		program, err := project.NewProgram(code, nil, file)
+		if err != nil {
+			return
+		}
+
+		for _, location := range program.Imports() {
+			if strings.HasSuffix(location, ".cdc") {
+				visit(path.Join(path.Dir(file), location))
+				continue
+			}
+
+			if state == nil {
+				continue
+			}
+			contract, err := state.Contracts().ByName(location)
+			if err == nil {
+				visit(contract.Location)
+			}
+		}
+	}
+
+	visit(filename)
+	return files
+}
+
+// waitForChange blocks until one of the files changes and returns true, or returns false if the context is done.
+func waitForChange(ctx context.Context, files []string) (bool, error) {
+	w := watcher.New()
+	w.SetMaxEvents(1)
+	defer w.Close()
+
+	for _, file := range files {
+		err := w.Add(file)
+		if err != nil {
+			return false, fmt.Errorf("failed to watch %s: %w", file, err)
+		}
+	}
+
+	go func() {
+		_ = w.Start(watchInterval)
+	}()
+	w.Wait()
+
+	select {
+	case <-w.Event:
+		return true, nil
+	case err := <-w.Error:
+		return false, err
+	case <-ctx.Done():
+		return false, nil
+	}
+}
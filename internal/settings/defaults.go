@@ -27,12 +27,14 @@ import (
 const (
 	metricsEnabled = "MetricsEnabled"
 	flowserPath    = "FlowserPath"
+	tutorialStep   = "TutorialStep"
 )
 
 // defaults holds the default values for global settings
 var defaults = map[string]any{
 	metricsEnabled: true,
 	flowserPath:    getDefaultInstallDir(),
+	tutorialStep:   0,
 }
 
 const (
@@ -123,6 +123,18 @@ func SetFlowserPath(path string) error {
 	return Set(flowserPath, path)
 }
 
+// TutorialStep gets the number of tutorial steps completed, used to resume the tutorial.
+func TutorialStep() int {
+	if err := loadViper(); err != nil {
+		return 0
+	}
+	return viper.GetInt(tutorialStep)
+}
+
+func SetTutorialStep(step int) error {
+	return Set(tutorialStep, step)
+}
+
 // MetricsEnabled checks whether metric tracking is enabled.
 func MetricsEnabled() bool {
 	if err := loadViper(); err != nil {
@@ -0,0 +1,125 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSign struct {
+	Signer string `default:"emulator-account" flag:"signer" info:"name of the account used to sign"`
+}
+
+var signFlags = flagsSign{}
+
+var signCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "sign <message>",
+		Short:   "Sign the message as a user signature compatible with FCL",
+		Example: "flow signatures sign 'The quick brown fox jumps over the lazy dog' --signer alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &signFlags,
+	RunS:  signUser,
+}
+
+// signUser signs the message prefixed with the user domain tag, the same way
+// wallets do for FCL's currentUser.signUserMessage, so the result can be
+// verified with fcl.AppUtils.verifyUserSignatures.
+func signUser(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	message := []byte(args[0])
+	acc, err := util.AccountByName(state, signFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := acc.Key.Signer(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := flow.SignUserMessage(s, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userSignatureResult{
+		address:   acc.Address,
+		keyIndex:  acc.Key.Index(),
+		signature: signed,
+		message:   message,
+	}, nil
+}
+
+type userSignatureResult struct {
+	address   flow.Address
+	keyIndex  int
+	signature []byte
+	message   []byte
+}
+
+// JSON returns the composite signature in the format FCL expects.
+func (s *userSignatureResult) JSON() any {
+	return map[string]any{
+		"f_type":    "CompositeSignature",
+		"f_vsn":     "1.0.0",
+		"addr":      "0x" + s.address.Hex(),
+		"keyId":     s.keyIndex,
+		"signature": hex.EncodeToString(s.signature),
+		"message":   hex.EncodeToString(s.message),
+	}
+}
+
+func (s *userSignatureResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address \t 0x%s\n", s.address.Hex())
+	_, _ = fmt.Fprintf(writer, "Key Index \t %d\n", s.keyIndex)
+	_, _ = fmt.Fprintf(writer, "Signature \t %x\n", s.signature)
+	_, _ = fmt.Fprintf(writer, "Message \t %s\n", s.message)
+	_, _ = fmt.Fprintf(writer, "Message Hex \t %x\n", s.message)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (s *userSignatureResult) Oneliner() string {
+	return fmt.Sprintf(
+		"address: 0x%s, keyId: %d, signature: %x, message: %x",
+		s.address.Hex(), s.keyIndex, s.signature, s.message,
+	)
+}
@@ -32,4 +32,5 @@ var Cmd = &cobra.Command{
 func init() {
 	generateCommand.AddToParent(Cmd)
 	verifyCommand.AddToParent(Cmd)
+	signCommand.AddToParent(Cmd)
 }
@@ -19,10 +19,16 @@
 package signatures
 
 import (
+	"encoding/hex"
 	"testing"
 
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -85,3 +91,120 @@ func Test_Sign(t *testing.T) {
 		assert.Nil(t, result)
 	})
 }
+
+func Test_SignUser(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		signFlags.Signer = "emulator-account"
+		result, err := signUser([]string{"test message"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		res := result.JSON().(map[string]any)
+		assert.Equal(t, "CompositeSignature", res["f_type"])
+		assert.Equal(t, "0xf8d6e0586b0a20c7", res["addr"])
+		assert.Equal(t, 0, res["keyId"])
+		assert.Equal(t, hex.EncodeToString([]byte("test message")), res["message"])
+	})
+
+	t.Run("Fail unknown signer", func(t *testing.T) {
+		signFlags.Signer = "invalid"
+		defer func() { signFlags.Signer = "emulator-account" }()
+
+		result, err := signUser([]string{"test message"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "could not find account with name invalid in the configuration")
+		assert.Nil(t, result)
+	})
+}
+
+func Test_VerifyAccountKey(t *testing.T) {
+	// user signature of "test message"
+	signature := "c49387efa7a856c1b9390e7ac3bc72ceb45dfd9c64d609737d8521ffdf4196e7cd858829ec86dc8d8dcf309b1e0abec95235b3654388359919156a071d63f108"
+	publicKey, err := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, "813f157c18908d450907f9141ff49943ce285a7fee03a22fdcfbbb154cada2ce0e07c650ee9e1188b9bb283cb1f1166a0cd79a4739804e28ce81a775b8e0f19a")
+	require.NoError(t, err)
+
+	accountWithKey := func(weight int) *flow.Account {
+		account := tests.NewAccountWithAddress("0x01")
+		account.Keys = []*flow.AccountKey{{
+			PublicKey: publicKey,
+			SigAlgo:   crypto.ECDSA_P256,
+			HashAlgo:  crypto.SHA3_256,
+			Weight:    weight,
+		}}
+		return account
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.GetAccount.Run(func(mock.Arguments) { srv.GetAccount.Return(accountWithKey(1000), nil) })
+		verifyFlags.Address = "0x01"
+		defer func() { verifyFlags.Address = "" }()
+
+		result, err := verify([]string{"test message", signature}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.True(t, result.(*verificationResult).valid)
+		assert.Equal(t, "0x0000000000000001", result.JSON().(map[string]any)["addr"])
+	})
+
+	t.Run("Fail insufficient weight", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.GetAccount.Run(func(mock.Arguments) { srv.GetAccount.Return(accountWithKey(500), nil) })
+		verifyFlags.Address = "0x01"
+		defer func() { verifyFlags.Address = "" }()
+
+		result, err := verify([]string{"test message", signature}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.False(t, result.(*verificationResult).valid)
+	})
+
+	t.Run("Fail wrong message", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.GetAccount.Run(func(mock.Arguments) { srv.GetAccount.Return(accountWithKey(1000), nil) })
+		verifyFlags.Address = "0x01"
+		defer func() { verifyFlags.Address = "" }()
+
+		result, err := verify([]string{"other message", signature}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.False(t, result.(*verificationResult).valid)
+	})
+
+	t.Run("Fail missing key", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.GetAccount.Run(func(mock.Arguments) { srv.GetAccount.Return(accountWithKey(1000), nil) })
+		verifyFlags.Address = "0x01"
+		verifyFlags.KeyIndex = 1
+		defer func() {
+			verifyFlags.Address = ""
+			verifyFlags.KeyIndex = 0
+		}()
+
+		_, err := verify([]string{"test message", signature}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "account 0x0000000000000001 has no key with index 1")
+	})
+
+	t.Run("Fail no key source", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+
+		_, err := verify([]string{"test message", signature}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "provide the public key argument, or the signer or address flag to verify with an account key")
+	})
+}
+
+func Test_VerifyUser(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	inArgs := []string{
+		"test message",
+		"c49387efa7a856c1b9390e7ac3bc72ceb45dfd9c64d609737d8521ffdf4196e7cd858829ec86dc8d8dcf309b1e0abec95235b3654388359919156a071d63f108",
+		"813f157c18908d450907f9141ff49943ce285a7fee03a22fdcfbbb154cada2ce0e07c650ee9e1188b9bb283cb1f1166a0cd79a4739804e28ce81a775b8e0f19a",
+	}
+
+	verifyFlags.User = true
+	result, err := verify(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+	assert.True(t, result.(*verificationResult).valid)
+
+	verifyFlags.User = false
+	result, err = verify(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+	assert.False(t, result.(*verificationResult).valid)
+}
@@ -20,10 +20,12 @@ package signatures
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"strings"
 
+	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
 
@@ -36,16 +38,21 @@ import (
 type flagsVerify struct {
 	SigAlgo  string `flag:"sig-algo" default:"ECDSA_P256" info:"Signature algorithm used to create the public key"`
 	HashAlgo string `flag:"hash-algo" default:"SHA3_256" info:"Hashing algorithm used to create signature"`
+	User     bool   `flag:"user" default:"false" info:"Verify a user signature created with the user domain tag, as produced by 'flow signatures sign' or FCL"`
+	Signer   string `flag:"signer" default:"" info:"Account name from configuration whose on-chain key is used to verify a user signature"`
+	Address  string `flag:"address" default:"" info:"Address or account name of the account whose on-chain key is used to verify a user signature"`
+	KeyIndex int    `flag:"key-index" default:"0" info:"Index of the account key used with the address flag"`
 }
 
 var verifyFlags = flagsVerify{}
 
 var verifyCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "verify <message> <signature> <public key>",
-		Short:   "Verify the signature",
-		Example: "flow signatures verify 'The quick brown fox jumps over the lazy dog' 99fa...25b af3...52d",
-		Args:    cobra.ExactArgs(3),
+		Use:   "verify <message> <signature> [<public key>]",
+		Short: "Verify the signature",
+		Example: `flow signatures verify 'The quick brown fox jumps over the lazy dog' 99fa...25b af3...52d
+flow signatures verify 'The quick brown fox jumps over the lazy dog' 99fa...25b --signer alice`,
+		Args: cobra.RangeArgs(2, 3),
 	},
 	Flags: &verifyFlags,
 	RunS:  verify,
@@ -55,8 +62,8 @@ func verify(
 	args []string,
 	_ command.GlobalFlags,
 	_ output.Logger,
-	_ flowkit.Services,
-	_ *flowkit.State,
+	flow flowkit.Services,
+	state *flowkit.State,
 ) (command.Result, error) {
 	message := []byte(args[0])
 
@@ -65,6 +72,10 @@ func verify(
 		return nil, fmt.Errorf("invalid message signature: %w", err)
 	}
 
+	if len(args) == 2 {
+		return verifyAccountKey(flow, state, message, sig)
+	}
+
 	key, err := hex.DecodeString(strings.ReplaceAll(args[2], "0x", ""))
 	if err != nil {
 		return nil, fmt.Errorf("invalid public key: %w", err)
@@ -78,12 +89,7 @@ func verify(
 		return nil, fmt.Errorf("invalid public key: %w", err)
 	}
 
-	hasher, err := crypto.NewHasher(hashAlgo)
-	if err != nil {
-		return nil, err
-	}
-
-	valid, err := pkey.Verify(sig, message, hasher)
+	valid, err := verifySignature(pkey, hashAlgo, sig, message, verifyFlags.User)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +104,93 @@ func verify(
 	}, nil
 }
 
+// verifyAccountKey verifies the user signature against the on-chain account key, like
+// fcl.AppUtils.verifyUserSignatures the signature is only valid if the key isn't revoked
+// and has the full weight.
+func verifyAccountKey(
+	flow flowkit.Services,
+	state *flowkit.State,
+	message []byte,
+	sig []byte,
+) (command.Result, error) {
+	var address flowsdk.Address
+	keyIndex := verifyFlags.KeyIndex
+	switch {
+	case verifyFlags.Signer != "":
+		acc, err := util.AccountByName(state, verifyFlags.Signer)
+		if err != nil {
+			return nil, err
+		}
+		address = acc.Address
+		keyIndex = acc.Key.Index()
+	case verifyFlags.Address != "":
+		resolved, err := util.ResolveAddress(verifyFlags.Address, state, flow.Network())
+		if err != nil {
+			return nil, err
+		}
+		address = resolved
+	default:
+		return nil, fmt.Errorf("provide the public key argument, or the signer or address flag to verify with an account key")
+	}
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyIndex < 0 || keyIndex >= len(account.Keys) {
+		return nil, fmt.Errorf("account 0x%s has no key with index %d", address.Hex(), keyIndex)
+	}
+	accountKey := account.Keys[keyIndex]
+
+	valid, err := verifySignature(accountKey.PublicKey, accountKey.HashAlgo, sig, message, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verificationResult{
+		valid:     valid && !accountKey.Revoked && accountKey.Weight >= flowsdk.AccountKeyWeightThreshold,
+		message:   message,
+		signature: sig,
+		hashAlgo:  accountKey.HashAlgo,
+		sigAlgo:   accountKey.SigAlgo,
+		pubKey:    accountKey.PublicKey.Encode(),
+		account: &verifiedAccountKey{
+			address: address,
+			index:   keyIndex,
+			weight:  accountKey.Weight,
+			revoked: accountKey.Revoked,
+		},
+	}, nil
+}
+
+// verifySignature verifies the signature of the message, user signatures are verified with the user domain tag.
+func verifySignature(
+	pkey crypto.PublicKey,
+	hashAlgo crypto.HashAlgorithm,
+	sig []byte,
+	message []byte,
+	user bool,
+) (bool, error) {
+	hasher, err := crypto.NewHasher(hashAlgo)
+	if err != nil {
+		return false, err
+	}
+
+	if user {
+		message = append(flowsdk.UserDomainTag[:], message...)
+	}
+
+	return pkey.Verify(sig, message, hasher)
+}
+
+type verifiedAccountKey struct {
+	address flowsdk.Address
+	index   int
+	weight  int
+	revoked bool
+}
+
 type verificationResult struct {
 	valid     bool
 	message   []byte
@@ -105,10 +198,11 @@ type verificationResult struct {
 	pubKey    []byte
 	sigAlgo   crypto.SignatureAlgorithm
 	hashAlgo  crypto.HashAlgorithm
+	account   *verifiedAccountKey
 }
 
 func (s *verificationResult) JSON() any {
-	return map[string]any{
+	result := map[string]any{
 		"valid":     fmt.Sprintf("%v", s.valid),
 		"message":   string(s.message),
 		"signature": string(s.signature),
@@ -116,6 +210,15 @@ func (s *verificationResult) JSON() any {
 		"sigAlgo":   s.sigAlgo.String(),
 		"pubKey":    fmt.Sprintf("%x", s.pubKey),
 	}
+
+	if s.account != nil {
+		result["addr"] = fmt.Sprintf("0x%s", s.account.address.Hex())
+		result["keyId"] = s.account.index
+		result["weight"] = s.account.weight
+		result["revoked"] = s.account.revoked
+	}
+
+	return result
 }
 
 func (s *verificationResult) String() string {
@@ -128,6 +231,12 @@ func (s *verificationResult) String() string {
 	_, _ = fmt.Fprintf(writer, "Public Key \t %x\n", s.pubKey)
 	_, _ = fmt.Fprintf(writer, "Hash Algorithm \t %s\n", s.hashAlgo)
 	_, _ = fmt.Fprintf(writer, "Signature Algorithm \t %s\n", s.sigAlgo)
+	if s.account != nil {
+		_, _ = fmt.Fprintf(writer, "Address \t 0x%s\n", s.account.address.Hex())
+		_, _ = fmt.Fprintf(writer, "Key Index \t %d\n", s.account.index)
+		_, _ = fmt.Fprintf(writer, "Key Weight \t %d\n", s.account.weight)
+		_, _ = fmt.Fprintf(writer, "Key Revoked \t %v\n", s.account.revoked)
+	}
 
 	_ = writer.Flush()
 	return b.String()
@@ -0,0 +1,89 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package staking
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDelegate struct {
+	Signer   string `default:"" flag:"signer" info:"Account name from configuration owning the staking collection"`
+	GasLimit uint64 `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var delegateFlags = flagsDelegate{}
+
+var delegateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "delegate <node id> <amount>",
+		Short:   "Register a new delegator to a node and stake tokens",
+		Example: "flow staking delegate 6a1f... 50.0 --signer delegator",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &delegateFlags,
+	RunS:  delegate,
+}
+
+func delegate(
+	args []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if delegateFlags.Signer == "" {
+		return nil, fmt.Errorf("missing required flag --signer")
+	}
+
+	signer, err := util.AccountByName(state, delegateFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := stakingEnvironment(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeID, err := parseNodeID(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := parseAmount(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	txArgs := []cadence.Value{
+		nodeID,
+		amount,
+	}
+
+	return sendStakingTransaction(flow, globalFlags, signer, tmpl.GenerateCollectionRegisterDelegator(env), txArgs, delegateFlags.GasLimit)
+}
@@ -0,0 +1,164 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package staking
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRegisterNode struct {
+	Signer            string `default:"" flag:"signer" info:"Account name from configuration owning the staking collection"`
+	ID                string `default:"" flag:"id" info:"Node ID"`
+	Role              string `default:"" flag:"role" info:"Node role: collection, consensus, execution, verification or access (or 1-5)"`
+	NetworkingAddress string `default:"" flag:"networking-address" info:"Node networking address including the port"`
+	NetworkingKey     string `default:"" flag:"networking-key" info:"Node networking public key"`
+	StakingKey        string `default:"" flag:"staking-key" info:"Node staking public key"`
+	Amount            string `default:"" flag:"amount" info:"Amount of FLOW to stake"`
+	GasLimit          uint64 `default:"9999" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var registerNodeFlags = flagsRegisterNode{}
+
+var registerNodeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "register-node",
+		Short: "Register a new node in the staking collection",
+		Example: `flow staking register-node --signer operator --id 6a1f... --role execution \
+	--networking-address node.example.com:3569 --networking-key 9b1a... --staking-key a5c2... --amount 1250000.0`,
+		Args: cobra.NoArgs,
+	},
+	Flags: &registerNodeFlags,
+	RunS:  registerNode,
+}
+
+const (
+	// networkingKeyLength is the length of the hex encoded ECDSA P-256 networking keys.
+	networkingKeyLength = 128
+	// stakingKeyLength is the length of the hex encoded BLS staking keys.
+	stakingKeyLength = 192
+)
+
+// nodeRoles maps the node role names to the role numbers used by the staking contract.
+var nodeRoles = map[string]uint8{
+	"collection":   1,
+	"consensus":    2,
+	"execution":    3,
+	"verification": 4,
+	"access":       5,
+}
+
+func parseNodeRole(role string) (uint8, error) {
+	if r, ok := nodeRoles[strings.ToLower(role)]; ok {
+		return r, nil
+	}
+
+	r, err := strconv.ParseUint(role, 10, 8)
+	if err != nil || r < 1 || r > 5 {
+		return 0, fmt.Errorf("invalid node role %s, valid roles are: collection, consensus, execution, verification, access", role)
+	}
+
+	return uint8(r), nil
+}
+
+func registerNode(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	flags := registerNodeFlags
+	required := map[string]string{
+		"signer":             flags.Signer,
+		"id":                 flags.ID,
+		"role":               flags.Role,
+		"networking-address": flags.NetworkingAddress,
+		"networking-key":     flags.NetworkingKey,
+		"staking-key":        flags.StakingKey,
+		"amount":             flags.Amount,
+	}
+	for _, name := range []string{"signer", "id", "role", "networking-address", "networking-key", "staking-key", "amount"} {
+		if required[name] == "" {
+			return nil, fmt.Errorf("missing required flag --%s", name)
+		}
+	}
+
+	signer, err := util.AccountByName(state, flags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := stakingEnvironment(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeID, err := parseNodeID(flags.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := parseNodeRole(flags.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, port, err := net.SplitHostPort(flags.NetworkingAddress); err != nil || port == "" {
+		return nil, fmt.Errorf("invalid networking address %s: must be a host and port", flags.NetworkingAddress)
+	}
+
+	networkingKey, err := parseHexKey("networking key", flags.NetworkingKey, networkingKeyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	stakingKey, err := parseHexKey("staking key", flags.StakingKey, stakingKeyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := parseAmount(flags.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []cadence.Value{
+		nodeID,
+		cadence.UInt8(role),
+		cadence.String(flags.NetworkingAddress),
+		networkingKey,
+		stakingKey,
+		amount,
+		cadence.NewOptional(nil),
+	}
+
+	return sendStakingTransaction(flow, globalFlags, signer, tmpl.GenerateCollectionRegisterNode(env), args, flags.GasLimit)
+}
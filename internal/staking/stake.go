@@ -0,0 +1,121 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package staking
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsStake struct {
+	Signer      string `default:"" flag:"signer" info:"Account name from configuration owning the staking collection"`
+	DelegatorID int    `default:"-1" flag:"delegator-id" info:"Delegator ID, when operating on a delegation instead of a node"`
+	GasLimit    uint64 `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var stakeFlags = flagsStake{}
+var unstakeFlags = flagsStake{}
+var withdrawRewardsFlags = flagsStake{}
+
+var stakeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "stake <node id> <amount>",
+		Aliases: []string{"stake-tokens"},
+		Short:   "Stake new tokens for a node or delegator",
+		Example: "flow staking stake 6a1f... 100.0 --signer operator",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &stakeFlags,
+	RunS:  stakeOperation(&stakeFlags, tmpl.GenerateCollectionStakeNewTokens),
+}
+
+var unstakeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "unstake <node id> <amount>",
+		Aliases: []string{"request-unstake"},
+		Short:   "Request unstaking of tokens for a node or delegator",
+		Example: "flow staking unstake 6a1f... 100.0 --signer operator",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &unstakeFlags,
+	RunS:  stakeOperation(&unstakeFlags, tmpl.GenerateCollectionRequestUnstaking),
+}
+
+var withdrawRewardsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "withdraw-rewards <node id> <amount>",
+		Short:   "Withdraw rewarded tokens for a node or delegator",
+		Example: "flow staking withdraw-rewards 6a1f... 10.0 --signer operator --delegator-id 1",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &withdrawRewardsFlags,
+	RunS:  stakeOperation(&withdrawRewardsFlags, tmpl.GenerateCollectionWithdrawRewardedTokens),
+}
+
+// stakeOperation creates a run function for staking collection transactions
+// which take the node ID, an optional delegator ID and an amount as arguments.
+func stakeOperation(flags *flagsStake, template func(tmpl.Environment) []byte) command.RunWithState {
+	return func(
+		args []string,
+		globalFlags command.GlobalFlags,
+		_ output.Logger,
+		flow flowkit.Services,
+		state *flowkit.State,
+	) (command.Result, error) {
+		if flags.Signer == "" {
+			return nil, fmt.Errorf("missing required flag --signer")
+		}
+
+		signer, err := util.AccountByName(state, flags.Signer)
+		if err != nil {
+			return nil, err
+		}
+
+		env, err := stakingEnvironment(signer)
+		if err != nil {
+			return nil, err
+		}
+
+		nodeID, err := parseNodeID(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := parseAmount(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		txArgs := []cadence.Value{
+			nodeID,
+			parseOptionalDelegatorID(flags.DelegatorID),
+			amount,
+		}
+
+		return sendStakingTransaction(flow, globalFlags, signer, template(env), txArgs, flags.GasLimit)
+	}
+}
@@ -0,0 +1,232 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package staking
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var Cmd = &cobra.Command{
+	Use:              "staking",
+	Aliases:          []string{"stake"},
+	Short:            "Register nodes, stake, unstake and delegate using the staking collection",
+	TraverseChildren: true,
+	GroupID:          "interactions",
+}
+
+func init() {
+	registerNodeCommand.AddToParent(Cmd)
+	stakeCommand.AddToParent(Cmd)
+	unstakeCommand.AddToParent(Cmd)
+	withdrawRewardsCommand.AddToParent(Cmd)
+	delegateCommand.AddToParent(Cmd)
+}
+
+// stakingEnvironment returns the core contracts environment for the network the signer belongs to.
+func stakingEnvironment(signer *accounts.Account) (tmpl.Environment, error) {
+	chain, err := util.GetAddressNetwork(signer.Address)
+	if err != nil {
+		return tmpl.Environment{}, fmt.Errorf("failed to determine network from signer address, check the address and network")
+	}
+
+	if chain != flowsdk.Mainnet && chain != flowsdk.Testnet {
+		return tmpl.Environment{}, fmt.Errorf("%s chain not supported, staking is only available on mainnet and testnet", chain)
+	}
+
+	return util.EnvFromNetwork(chain), nil
+}
+
+// sendStakingTransaction builds the staking transaction with the signer in all roles,
+// asks for approval unless skipped, then signs and sends it.
+func sendStakingTransaction(
+	flow flowkit.Services,
+	globalFlags command.GlobalFlags,
+	signer *accounts.Account,
+	code []byte,
+	args []cadence.Value,
+	gasLimit uint64,
+) (command.Result, error) {
+	tx, err := flow.BuildTransaction(
+		context.Background(),
+		transactions.AddressesRoles{
+			Proposer:    signer.Address,
+			Authorizers: []flowsdk.Address{signer.Address},
+			Payer:       signer.Address,
+		},
+		signer.Key.Index(),
+		flowkit.Script{Code: code, Args: args},
+		gasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction()) {
+		return nil, fmt.Errorf("transaction was not approved")
+	}
+
+	signed, err := flow.SignTransactionPayload(
+		context.Background(),
+		signer,
+		[]byte(hex.EncodeToString(tx.FlowTransaction().Encode())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, txResult, err := flow.SendSignedTransaction(context.Background(), signed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stakingTxResult{tx: sentTx, result: txResult}, nil
+}
+
+// parseOptionalDelegatorID converts the delegator ID flag to an optional cadence value.
+func parseOptionalDelegatorID(delegatorID int) cadence.Value {
+	if delegatorID < 0 {
+		return cadence.NewOptional(nil)
+	}
+
+	return cadence.NewOptional(cadence.UInt32(delegatorID))
+}
+
+func parseAmount(amount string) (cadence.UFix64, error) {
+	value, err := cadence.NewUFix64(amount)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %s: %w", amount, err)
+	}
+	if value == 0 {
+		return 0, fmt.Errorf("invalid amount %s: must be greater than zero", amount)
+	}
+
+	return value, nil
+}
+
+// nodeIDLength is the length of the hex encoded node IDs.
+const nodeIDLength = 64
+
+// parseNodeID validates the node ID is hex encoded and returns it without the 0x prefix.
+func parseNodeID(id string) (cadence.String, error) {
+	id = strings.ToLower(strings.TrimPrefix(id, "0x"))
+	if _, err := hex.DecodeString(id); err != nil || len(id) != nodeIDLength {
+		return "", fmt.Errorf("invalid node ID %s: must be %d hex characters", id, nodeIDLength)
+	}
+
+	return cadence.String(id), nil
+}
+
+// parseHexKey validates the public key is hex encoded with the length in hex characters and returns it without the 0x prefix.
+func parseHexKey(name string, key string, length int) (cadence.String, error) {
+	key = strings.TrimPrefix(key, "0x")
+	if _, err := hex.DecodeString(key); err != nil || len(key) != length {
+		return "", fmt.Errorf("invalid %s: must be %d hex characters", name, length)
+	}
+
+	return cadence.String(key), nil
+}
+
+type stakingTxResult struct {
+	tx     *flowsdk.Transaction
+	result *flowsdk.TransactionResult
+}
+
+func (r *stakingTxResult) JSON() any {
+	result := make(map[string]any)
+	result["id"] = r.tx.ID().String()
+	result["signer"] = r.tx.Payer.String()
+
+	if r.result != nil {
+		result["status"] = r.result.Status.String()
+		result["block_height"] = r.result.BlockHeight
+
+		if r.result.Error != nil {
+			result["error"] = r.result.Error.Error()
+		}
+
+		txEvents := make([]any, 0, len(r.result.Events))
+		for _, event := range r.result.Events {
+			txEvents = append(txEvents, map[string]any{
+				"index":  event.EventIndex,
+				"type":   event.Type,
+				"values": json.RawMessage(event.Payload),
+			})
+		}
+		result["events"] = txEvents
+	}
+
+	return result
+}
+
+func (r *stakingTxResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.tx.ID())
+	_, _ = fmt.Fprintf(writer, "Signer\t%s\n", r.tx.Payer.Hex())
+
+	if r.result != nil {
+		if r.result.Error != nil {
+			_, _ = fmt.Fprintf(writer, "%s Transaction Error \n%s\n\n", output.ErrorEmoji(), r.result.Error.Error())
+		}
+
+		statusBadge := ""
+		if r.result.Status == flowsdk.TransactionStatusSealed {
+			statusBadge = output.OkEmoji()
+		}
+		_, _ = fmt.Fprintf(writer, "Status\t%s %s\n", statusBadge, r.result.Status)
+
+		e := events.EventResult{Events: r.result.Events}
+		eventsOutput := e.String()
+		if eventsOutput == "" {
+			eventsOutput = "None"
+		}
+		_, _ = fmt.Fprintf(writer, "\nEvents:\t %s\n", eventsOutput)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *stakingTxResult) Oneliner() string {
+	result := fmt.Sprintf("ID: %s", r.tx.ID())
+	if r.result != nil {
+		result += fmt.Sprintf(", Status: %s", r.result.Status)
+	}
+
+	return result
+}
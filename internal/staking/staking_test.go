@@ -0,0 +1,177 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package staking
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func setupTestnetSigner(t *testing.T, state *flowkit.State) {
+	emulatorAccount, err := state.Accounts().ByName("emulator-account")
+	require.NoError(t, err)
+
+	operator := *emulatorAccount
+	operator.Name = "operator"
+	operator.Address = flow.HexToAddress("0x7aad92e5a0715d21")
+	state.Accounts().AddOrUpdate(&operator)
+}
+
+const testNodeID = "6a1f8d2bb2c5a2c8e1a0f3b9d7c4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6"
+
+func Test_Stake(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	setupTestnetSigner(t, state)
+
+	srv.BuildTransaction.Return(transactions.New(), nil)
+	srv.SignTransactionPayload.Return(transactions.New(), nil)
+	srv.SendSignedTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+	t.Run("Success", func(t *testing.T) {
+		srv.BuildTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AddressesRoles)
+			assert.Equal(t, "7aad92e5a0715d21", roles.Payer.String())
+
+			script := args.Get(3).(flowkit.Script)
+			assert.Contains(t, string(script.Code), "FlowStakingCollection")
+			require.Len(t, script.Args, 3)
+			assert.Equal(t, cadence.String(testNodeID), script.Args[0])
+			assert.Equal(t, cadence.NewOptional(nil), script.Args[1])
+			assert.Equal(t, "10.00000000", script.Args[2].String())
+		})
+
+		flags := flagsStake{Signer: "operator", DelegatorID: -1}
+		result, err := stakeOperation(&flags, tmpl.GenerateCollectionStakeNewTokens)(
+			[]string{testNodeID, "10.0"},
+			command.GlobalFlags{Yes: true},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail missing signer", func(t *testing.T) {
+		flags := flagsStake{DelegatorID: -1}
+		_, err := stakeOperation(&flags, tmpl.GenerateCollectionStakeNewTokens)(
+			[]string{testNodeID, "10.0"},
+			command.GlobalFlags{Yes: true},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+		assert.EqualError(t, err, "missing required flag --signer")
+	})
+
+	t.Run("Fail emulator signer", func(t *testing.T) {
+		flags := flagsStake{Signer: "emulator-account", DelegatorID: -1}
+		_, err := stakeOperation(&flags, tmpl.GenerateCollectionStakeNewTokens)(
+			[]string{testNodeID, "10.0"},
+			command.GlobalFlags{Yes: true},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+		assert.EqualError(t, err, "flow-emulator chain not supported, staking is only available on mainnet and testnet")
+	})
+
+	t.Run("Fail invalid amount", func(t *testing.T) {
+		flags := flagsStake{Signer: "operator", DelegatorID: 1}
+		_, err := stakeOperation(&flags, tmpl.GenerateCollectionStakeNewTokens)(
+			[]string{testNodeID, "ten"},
+			command.GlobalFlags{Yes: true},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+		assert.ErrorContains(t, err, "invalid amount ten")
+	})
+}
+
+func Test_StakeValidation(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	setupTestnetSigner(t, state)
+
+	t.Run("Fail invalid node ID", func(t *testing.T) {
+		flags := flagsStake{Signer: "operator", DelegatorID: -1}
+		_, err := stakeOperation(&flags, tmpl.GenerateCollectionRequestUnstaking)(
+			[]string{"6a1f", "10.0"},
+			command.GlobalFlags{Yes: true},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+		assert.EqualError(t, err, "invalid node ID 6a1f: must be 64 hex characters")
+	})
+
+	t.Run("Fail zero amount", func(t *testing.T) {
+		delegateFlags.Signer = "operator"
+		defer func() { delegateFlags.Signer = "" }()
+
+		_, err := delegate([]string{"0x" + testNodeID, "0.0"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid amount 0.0: must be greater than zero")
+	})
+
+	t.Run("Fail invalid staking key", func(t *testing.T) {
+		registerNodeFlags = flagsRegisterNode{
+			Signer:            "operator",
+			ID:                testNodeID,
+			Role:              "execution",
+			NetworkingAddress: "node.example.com:3569",
+			NetworkingKey:     strings.Repeat("ab", networkingKeyLength/2),
+			StakingKey:        "a5c2",
+			Amount:            "1250000.0",
+		}
+		defer func() { registerNodeFlags = flagsRegisterNode{} }()
+
+		_, err := registerNode([]string{}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid staking key: must be 192 hex characters")
+
+		registerNodeFlags.NetworkingAddress = "node.example.com"
+		_, err = registerNode([]string{}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid networking address node.example.com: must be a host and port")
+	})
+}
+
+func Test_ParseNodeRole(t *testing.T) {
+	role, err := parseNodeRole("Execution")
+	require.NoError(t, err)
+	assert.Equal(t, uint8(3), role)
+
+	role, err = parseNodeRole("5")
+	require.NoError(t, err)
+	assert.Equal(t, uint8(5), role)
+
+	_, err = parseNodeRole("6")
+	assert.EqualError(t, err, "invalid node role 6, valid roles are: collection, consensus, execution, verification, access")
+}
@@ -40,8 +40,9 @@ var Command = &command.Command{
 		Use:   "status",
 		Short: "Display the status of the Flow network",
 	},
-	Flags: &statusFlags,
-	RunS:  status,
+	Flags:  &statusFlags,
+	RunS:   status,
+	Output: statusOutput{},
 }
 
 func status(
@@ -60,6 +61,13 @@ func status(
 	}, nil
 }
 
+// statusOutput is the JSON output of the status command.
+type statusOutput struct {
+	Network    string `json:"network"`
+	AccessNode string `json:"accessNode"`
+	Status     string `json:"status"`
+}
+
 type result struct {
 	network    string
 	accessNode string
@@ -108,13 +116,11 @@ func (r *result) String() string {
 
 // JSON converts result to a JSON.
 func (r *result) JSON() any {
-	result := make(map[string]string)
-
-	result["network"] = r.network
-	result["accessNode"] = r.accessNode
-	result["status"] = r.getStatus()
-
-	return result
+	return statusOutput{
+		Network:    r.network,
+		AccessNode: r.accessNode,
+		Status:     r.getStatus(),
+	}
 }
 
 // Oneliner returns result as one liner grep friendly.
@@ -44,8 +44,9 @@ var DevCommand = &command.Command{
 		Example: "flow dev",
 		GroupID: "super",
 	},
-	Flags: &devFlags,
-	RunS:  dev,
+	Flags:    &devFlags,
+	RunS:     dev,
+	NoDaemon: true,
 }
 
 func dev(
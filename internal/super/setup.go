@@ -23,7 +23,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -53,8 +52,9 @@ var SetupCommand = &command.Command{
 		Args:    cobra.ExactArgs(1),
 		GroupID: "super",
 	},
-	Flags: &setupFlags,
-	Run:   create,
+	Flags:    &setupFlags,
+	Run:      create,
+	NoDaemon: true,
 }
 
 const scaffoldListURL = "https://raw.githubusercontent.com/onflow/flow-cli/master/scaffolds.json"
@@ -145,16 +145,14 @@ func getTargetDirectory(directory string) (string, error) {
 }
 
 func getScaffolds() ([]scaffold, error) {
-	httpClient := http.Client{
-		Timeout: time.Second * 5,
-	}
-
-	req, err := http.NewRequest(http.MethodGet, scaffoldListURL, nil)
+	options := util.DefaultHTTPOptions
+	options.Timeout = time.Second * 5
+	httpClient, err := util.NewHTTPClient(options)
 	if err != nil {
-		return nil, fmt.Errorf("failed creating request for scaffold list: %w", err)
+		return nil, err
 	}
 
-	res, err := httpClient.Do(req)
+	res, err := httpClient.Get(scaffoldListURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed requesting scaffold list: %w", err)
 	}
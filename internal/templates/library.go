@@ -0,0 +1,411 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+	"strings"
+
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// Kind of the template, either a transaction or a script.
+type Kind string
+
+const (
+	KindTransaction Kind = "transaction"
+	KindScript      Kind = "script"
+)
+
+// Template is a built-in transaction or script which can be used by name instead of a code file.
+// The code imports core contracts from address placeholders replaced for the network it's used on.
+type Template struct {
+	Name        string
+	Kind        Kind
+	Description string
+	Arguments   string
+	Code        string
+}
+
+// nonFungibleTokenAddresses are the addresses of the NonFungibleToken and MetadataViews standard
+// contracts, which are not part of the core contracts environment.
+var nonFungibleTokenAddresses = map[flowsdk.ChainID]string{
+	flowsdk.Mainnet:  "0x1d7e57aa55817448",
+	flowsdk.Testnet:  "0x631e88ae7f1d7c20",
+	flowsdk.Emulator: "0xf8d6e0586b0a20c7",
+}
+
+// Source returns the template code with core contract addresses of the provided chain.
+func (t Template) Source(chain flowsdk.ChainID) []byte {
+	code := tmpl.ReplaceAddresses(t.Code, util.EnvFromNetwork(chain))
+	code = strings.ReplaceAll(code, "0xNONFUNGIBLETOKENADDRESS", nonFungibleTokenAddresses[chain])
+	code = strings.ReplaceAll(code, "0xMETADATAVIEWSADDRESS", nonFungibleTokenAddresses[chain])
+	return []byte(code)
+}
+
+// ByName returns the built-in template of the kind with the provided name.
+func ByName(name string, kind Kind) (*Template, error) {
+	for _, t := range Library {
+		if t.Name == name && t.Kind == kind {
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s template %s not found, use 'flow templates list' to see available templates", kind, name)
+}
+
+// ByKind returns all built-in templates of the kind.
+func ByKind(kind Kind) []Template {
+	var templates []Template
+	for _, t := range Library {
+		if t.Kind == kind {
+			templates = append(templates, t)
+		}
+	}
+
+	return templates
+}
+
+// Library contains all built-in templates.
+var Library = []Template{
+	{
+		Name:        "transfer-flow",
+		Kind:        KindTransaction,
+		Description: "Transfer FLOW tokens from the signer to the recipient",
+		Arguments:   "amount: UFix64, to: Address",
+		Code: `import FungibleToken from 0xFUNGIBLETOKENADDRESS
+import FlowToken from 0xFLOWTOKENADDRESS
+
+transaction(amount: UFix64, to: Address) {
+	let sentVault: @FungibleToken.Vault
+
+	prepare(signer: AuthAccount) {
+		let vaultRef = signer.borrow<&FlowToken.Vault>(from: /storage/flowTokenVault)
+			?? panic("Could not borrow reference to the owner's vault")
+
+		self.sentVault <- vaultRef.withdraw(amount: amount)
+	}
+
+	execute {
+		let receiverRef = getAccount(to)
+			.getCapability(/public/flowTokenReceiver)
+			.borrow<&{FungibleToken.Receiver}>()
+			?? panic("Could not borrow receiver reference to the recipient's vault")
+
+		receiverRef.deposit(from: <-self.sentVault)
+	}
+}
+`,
+	},
+	{
+		Name:        "transfer-fungible-tokens",
+		Kind:        KindTransaction,
+		Description: "Transfer any FungibleToken from the signer vault at the storage path to the recipient receiver at the public path",
+		Arguments:   "amount: UFix64, to: Address, storagePath: StoragePath, receiverPath: PublicPath",
+		Code: `import FungibleToken from 0xFUNGIBLETOKENADDRESS
+
+transaction(amount: UFix64, to: Address, storagePath: StoragePath, receiverPath: PublicPath) {
+	let sentVault: @FungibleToken.Vault
+
+	prepare(signer: AuthAccount) {
+		let vaultRef = signer.borrow<&{FungibleToken.Provider}>(from: storagePath)
+			?? panic("Could not borrow reference to the owner's vault at ".concat(storagePath.toString()))
+
+		self.sentVault <- vaultRef.withdraw(amount: amount)
+	}
+
+	execute {
+		let receiverRef = getAccount(to)
+			.getCapability(receiverPath)
+			.borrow<&{FungibleToken.Receiver}>()
+			?? panic("Could not borrow receiver reference to the recipient's vault at ".concat(receiverPath.toString()))
+
+		receiverRef.deposit(from: <-self.sentVault)
+	}
+}
+`,
+	},
+	{
+		Name:        "setup-vault",
+		Kind:        KindTransaction,
+		Description: "Set up an empty vault of any FungibleToken contract with receiver and balance capabilities on the signer account",
+		Arguments:   "tokenAddress: Address, tokenName: String, storagePath: StoragePath, receiverPath: PublicPath, balancePath: PublicPath",
+		Code: `import FungibleToken from 0xFUNGIBLETOKENADDRESS
+
+transaction(tokenAddress: Address, tokenName: String, storagePath: StoragePath, receiverPath: PublicPath, balancePath: PublicPath) {
+	prepare(signer: AuthAccount) {
+		if signer.borrow<&FungibleToken.Vault>(from: storagePath) != nil {
+			return
+		}
+
+		let token = getAccount(tokenAddress).contracts.borrow<&FungibleToken>(name: tokenName)
+			?? panic("Could not borrow the FungibleToken contract ".concat(tokenName))
+
+		signer.save(<-token.createEmptyVault(), to: storagePath)
+		signer.link<&{FungibleToken.Receiver}>(receiverPath, target: storagePath)
+		signer.link<&{FungibleToken.Balance}>(balancePath, target: storagePath)
+	}
+}
+`,
+	},
+	{
+		Name:        "setup-collection",
+		Kind:        KindTransaction,
+		Description: "Set up an empty collection of any NonFungibleToken contract with a public capability on the signer account",
+		Arguments:   "contractAddress: Address, contractName: String, storagePath: StoragePath, publicPath: PublicPath",
+		Code: `import NonFungibleToken from 0xNONFUNGIBLETOKENADDRESS
+
+transaction(contractAddress: Address, contractName: String, storagePath: StoragePath, publicPath: PublicPath) {
+	prepare(signer: AuthAccount) {
+		if signer.borrow<&NonFungibleToken.Collection>(from: storagePath) != nil {
+			return
+		}
+
+		let nft = getAccount(contractAddress).contracts.borrow<&NonFungibleToken>(name: contractName)
+			?? panic("Could not borrow the NonFungibleToken contract ".concat(contractName))
+
+		signer.save(<-nft.createEmptyCollection(), to: storagePath)
+		signer.link<&{NonFungibleToken.CollectionPublic}>(publicPath, target: storagePath)
+	}
+}
+`,
+	},
+	{
+		Name:        "add-key",
+		Kind:        KindTransaction,
+		Description: "Add a public key to the signer account (signature algorithm: 1 ECDSA_P256, 2 ECDSA_secp256k1; hash algorithm: 1 SHA2_256, 3 SHA3_256)",
+		Arguments:   "publicKey: String, signatureAlgorithm: UInt8, hashAlgorithm: UInt8, weight: UFix64",
+		Code: `transaction(publicKey: String, signatureAlgorithm: UInt8, hashAlgorithm: UInt8, weight: UFix64) {
+	prepare(signer: AuthAccount) {
+		let key = PublicKey(
+			publicKey: publicKey.decodeHex(),
+			signatureAlgorithm: SignatureAlgorithm(rawValue: signatureAlgorithm)!
+		)
+
+		signer.keys.add(
+			publicKey: key,
+			hashAlgorithm: HashAlgorithm(rawValue: hashAlgorithm)!,
+			weight: weight
+		)
+	}
+}
+`,
+	},
+	{
+		Name:        "create-account",
+		Kind:        KindTransaction,
+		Description: "Create an account with a public key paid by the signer (signature algorithm: 1 ECDSA_P256, 2 ECDSA_secp256k1; hash algorithm: 1 SHA2_256, 3 SHA3_256)",
+		Arguments:   "publicKey: String, signatureAlgorithm: UInt8, hashAlgorithm: UInt8, weight: UFix64",
+		Code: `transaction(publicKey: String, signatureAlgorithm: UInt8, hashAlgorithm: UInt8, weight: UFix64) {
+	prepare(signer: AuthAccount) {
+		let account = AuthAccount(payer: signer)
+
+		let key = PublicKey(
+			publicKey: publicKey.decodeHex(),
+			signatureAlgorithm: SignatureAlgorithm(rawValue: signatureAlgorithm)!
+		)
+
+		account.keys.add(
+			publicKey: key,
+			hashAlgorithm: HashAlgorithm(rawValue: hashAlgorithm)!,
+			weight: weight
+		)
+	}
+}
+`,
+	},
+	{
+		Name:        "deploy-contract",
+		Kind:        KindTransaction,
+		Description: "Deploy a contract with the provided name and source code to the signer account",
+		Arguments:   "name: String, code: String",
+		Code: `transaction(name: String, code: String) {
+	prepare(signer: AuthAccount) {
+		signer.contracts.add(name: name, code: code.utf8)
+	}
+}
+`,
+	},
+	{
+		Name:        "get-flow-balance",
+		Kind:        KindScript,
+		Description: "Get the FLOW balance of the account",
+		Arguments:   "address: Address",
+		Code: `import FungibleToken from 0xFUNGIBLETOKENADDRESS
+
+pub fun main(address: Address): UFix64 {
+	let vaultRef = getAccount(address)
+		.getCapability(/public/flowTokenBalance)
+		.borrow<&{FungibleToken.Balance}>()
+		?? panic("Could not borrow balance reference to the account's vault")
+
+	return vaultRef.balance
+}
+`,
+	},
+	{
+		Name:        "get-fungible-token-balance",
+		Kind:        KindScript,
+		Description: "Get the balance of any FungibleToken vault of the account exposed at the public balance path",
+		Arguments:   "address: Address, balancePath: PublicPath",
+		Code: `import FungibleToken from 0xFUNGIBLETOKENADDRESS
+
+pub fun main(address: Address, balancePath: PublicPath): UFix64 {
+	let vaultRef = getAccount(address)
+		.getCapability(balancePath)
+		.borrow<&{FungibleToken.Balance}>()
+		?? panic("Could not borrow balance reference to the account's vault at ".concat(balancePath.toString()))
+
+	return vaultRef.balance
+}
+`,
+	},
+	{
+		Name:        "get-vault-balances",
+		Kind:        KindScript,
+		Description: "Get the balances of all FungibleToken vaults stored in the account",
+		Arguments:   "address: Address",
+		Code: `import FungibleToken from 0xFUNGIBLETOKENADDRESS
+
+pub struct VaultBalance {
+	pub let type: String
+	pub let path: StoragePath
+	pub let balance: UFix64
+
+	init(type: String, path: StoragePath, balance: UFix64) {
+		self.type = type
+		self.path = path
+		self.balance = balance
+	}
+}
+
+pub fun main(address: Address): [VaultBalance] {
+	let account = getAuthAccount(address)
+	let balanceType = Type<@AnyResource{FungibleToken.Balance}>()
+	let balances: [VaultBalance] = []
+
+	account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+		if type.isSubtype(of: balanceType) {
+			if let vault = account.borrow<&AnyResource{FungibleToken.Balance}>(from: path) {
+				balances.append(VaultBalance(type: type.identifier, path: path, balance: vault.balance))
+			}
+		}
+		return true
+	})
+
+	return balances
+}
+`,
+	},
+	{
+		Name:        "get-nft-collections",
+		Kind:        KindScript,
+		Description: "Get the NFT IDs and MetadataViews display of all NonFungibleToken collections stored in the account",
+		Arguments:   "address: Address",
+		Code: `import NonFungibleToken from 0xNONFUNGIBLETOKENADDRESS
+import MetadataViews from 0xMETADATAVIEWSADDRESS
+
+pub struct NFTMetadata {
+	pub let id: UInt64
+	pub let name: String?
+	pub let description: String?
+	pub let thumbnail: String?
+	pub let views: [String]
+
+	init(id: UInt64, name: String?, description: String?, thumbnail: String?, views: [String]) {
+		self.id = id
+		self.name = name
+		self.description = description
+		self.thumbnail = thumbnail
+		self.views = views
+	}
+}
+
+pub struct NFTCollection {
+	pub let type: String
+	pub let path: StoragePath
+	pub let nfts: [NFTMetadata]
+
+	init(type: String, path: StoragePath, nfts: [NFTMetadata]) {
+		self.type = type
+		self.path = path
+		self.nfts = nfts
+	}
+}
+
+pub fun main(address: Address): [NFTCollection] {
+	let account = getAuthAccount(address)
+	let collectionType = Type<@AnyResource{NonFungibleToken.CollectionPublic}>()
+	let collections: [NFTCollection] = []
+
+	account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+		if !type.isSubtype(of: collectionType) {
+			return true
+		}
+		let collection = account.borrow<&AnyResource{NonFungibleToken.CollectionPublic}>(from: path)
+		if collection == nil {
+			return true
+		}
+		let resolvers = account.borrow<&AnyResource{MetadataViews.ResolverCollection}>(from: path)
+
+		let nfts: [NFTMetadata] = []
+		for id in collection!.getIDs() {
+			var name: String? = nil
+			var description: String? = nil
+			var thumbnail: String? = nil
+			let views: [String] = []
+
+			if let resolver = resolvers?.borrowViewResolver(id: id) {
+				for view in resolver.getViews() {
+					views.append(view.identifier)
+				}
+				if let display = resolver.resolveView(Type<MetadataViews.Display>()) as! MetadataViews.Display? {
+					name = display.name
+					description = display.description
+					thumbnail = display.thumbnail.uri()
+				}
+			}
+
+			nfts.append(NFTMetadata(id: id, name: name, description: description, thumbnail: thumbnail, views: views))
+		}
+
+		collections.append(NFTCollection(type: type.identifier, path: path, nfts: nfts))
+		return true
+	})
+
+	return collections
+}
+`,
+	},
+	{
+		Name:        "get-available-balance",
+		Kind:        KindScript,
+		Description: "Get the FLOW balance of the account available after the storage reservation",
+		Arguments:   "address: Address",
+		Code: `import FlowStorageFees from 0xFLOWSTORAGEFEESADDRESS
+
+pub fun main(address: Address): UFix64 {
+	return FlowStorageFees.defaultTokenAvailableBalance(address)
+}
+`,
+	},
+}
@@ -0,0 +1,189 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var Cmd = &cobra.Command{
+	Use:              "templates",
+	Short:            "Discover built-in transaction and script templates",
+	TraverseChildren: true,
+	GroupID:          "interactions",
+}
+
+func init() {
+	listCommand.AddToParent(Cmd)
+	showCommand.AddToParent(Cmd)
+}
+
+type flagsList struct {
+	Kind string `default:"" flag:"kind" info:"Only list templates of the kind, transaction or script"`
+}
+
+var listFlags = flagsList{}
+
+var listCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "list",
+		Short: "List built-in transaction and script templates",
+		Example: `flow templates list
+flow templates list --kind script`,
+		Args: cobra.NoArgs,
+	},
+	Flags: &listFlags,
+	Run:   list,
+}
+
+func list(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	switch Kind(listFlags.Kind) {
+	case "":
+		return &listResult{Library}, nil
+	case KindTransaction, KindScript:
+		return List(Kind(listFlags.Kind)), nil
+	default:
+		return nil, fmt.Errorf("invalid template kind %s, use transaction or script", listFlags.Kind)
+	}
+}
+
+// List returns the result listing all built-in templates of the kind.
+func List(kind Kind) command.Result {
+	return &listResult{ByKind(kind)}
+}
+
+type listResult struct {
+	templates []Template
+}
+
+func (r *listResult) JSON() any {
+	result := make([]any, 0, len(r.templates))
+	for _, t := range r.templates {
+		result = append(result, map[string]any{
+			"name":        t.Name,
+			"kind":        t.Kind,
+			"description": t.Description,
+			"arguments":   t.Arguments,
+		})
+	}
+
+	return result
+}
+
+func (r *listResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for _, t := range r.templates {
+		_, _ = fmt.Fprintf(writer, "Name\t%s\n", t.Name)
+		_, _ = fmt.Fprintf(writer, "Kind\t%s\n", t.Kind)
+		_, _ = fmt.Fprintf(writer, "Description\t%s\n", t.Description)
+		arguments := t.Arguments
+		if arguments == "" {
+			arguments = "No arguments"
+		}
+		_, _ = fmt.Fprintf(writer, "Arguments\t%s\n\n", arguments)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *listResult) Oneliner() string {
+	names := make([]string, 0, len(r.templates))
+	for _, t := range r.templates {
+		names = append(names, t.Name)
+	}
+
+	return fmt.Sprintf("%v", names)
+}
+
+var showCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show the code of a built-in template for the network",
+		Example: `flow templates show transfer-flow
+flow templates show get-flow-balance --network testnet`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &struct{}{},
+	Run:   show,
+}
+
+func show(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	for _, t := range Library {
+		if t.Name == args[0] {
+			return &showResult{template: t, code: t.Source(util.NetworkChain(flow.Network()))}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("template %s not found, use 'flow templates list' to see available templates", args[0])
+}
+
+type showResult struct {
+	template Template
+	code     []byte
+}
+
+func (r *showResult) JSON() any {
+	return map[string]any{
+		"name":        r.template.Name,
+		"kind":        r.template.Kind,
+		"description": r.template.Description,
+		"arguments":   r.template.Arguments,
+		"code":        string(r.code),
+	}
+}
+
+func (r *showResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Name\t%s\n", r.template.Name)
+	_, _ = fmt.Fprintf(writer, "Kind\t%s\n", r.template.Kind)
+	_, _ = fmt.Fprintf(writer, "Description\t%s\n", r.template.Description)
+	_, _ = fmt.Fprintf(writer, "\nCode\n\n%s", r.code)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *showResult) Oneliner() string {
+	return string(r.code)
+}
@@ -0,0 +1,77 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Templates(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Success list kind", func(t *testing.T) {
+		listFlags.Kind = "script"
+		defer func() { listFlags.Kind = "" }()
+
+		result, err := list([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "[get-flow-balance get-fungible-token-balance get-vault-balances get-nft-collections get-available-balance]", result.Oneliner())
+	})
+
+	t.Run("Fail list kind", func(t *testing.T) {
+		listFlags.Kind = "contract"
+		defer func() { listFlags.Kind = "" }()
+
+		_, err := list([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid template kind contract, use transaction or script")
+	})
+
+	t.Run("Success show", func(t *testing.T) {
+		result, err := show([]string{"transfer-flow"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.True(t, strings.Contains(result.Oneliner(), "import FlowToken from 0x0ae53cb6e3f42a79"))
+	})
+
+	t.Run("Fail show", func(t *testing.T) {
+		_, err := show([]string{"invalid"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "template invalid not found, use 'flow templates list' to see available templates")
+	})
+
+	t.Run("Library parameters", func(t *testing.T) {
+		for _, template := range Library {
+			parameters, err := arguments.Parameters(template.Source(flow.Emulator), "")
+			require.NoError(t, err, template.Name)
+
+			names := make([]string, 0, len(parameters))
+			for _, parameter := range parameters {
+				names = append(names, parameter.Name+": "+parameter.Type)
+			}
+			assert.Equal(t, template.Arguments, strings.Join(names, ", "), template.Name)
+		}
+	})
+}
@@ -0,0 +1,395 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// matrixConfiguration is a configuration of the emulator and Cadence the tests are run with.
+type matrixConfiguration struct {
+	// Name identifies the configuration in the report.
+	Name string `yaml:"name"`
+	// Command is the Flow CLI executable running the tests, a build embedding another Cadence
+	// version or enabling upcoming features, defaults to the running executable.
+	Command string `yaml:"command"`
+	// Args are appended to the test command, e.g. a configuration path with other contracts.
+	Args []string `yaml:"args"`
+	// Env are the environment variables set for the test command.
+	Env map[string]string `yaml:"env"`
+}
+
+// matrix is the file listing the configurations the tests are run with.
+type matrix struct {
+	Configurations []matrixConfiguration `yaml:"configurations"`
+}
+
+// parseMatrix parses and validates the matrix file.
+func parseMatrix(data []byte) ([]matrixConfiguration, error) {
+	var m matrix
+	err := yaml.Unmarshal(data, &m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the matrix: %w", err)
+	}
+	if len(m.Configurations) == 0 {
+		return nil, fmt.Errorf("the matrix doesn't contain any configurations")
+	}
+
+	names := make(map[string]bool, len(m.Configurations))
+	for i, c := range m.Configurations {
+		if c.Name == "" {
+			return nil, fmt.Errorf("configuration %d of the matrix is missing a name", i+1)
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("configuration %s is duplicated in the matrix", c.Name)
+		}
+		names[c.Name] = true
+	}
+
+	return m.Configurations, nil
+}
+
+// matrixConfigurationResult contains the status of each test by file of a configuration,
+// or the error that prevented the configuration from running the tests.
+type matrixConfigurationResult struct {
+	configuration matrixConfiguration
+	tests         map[string]map[string]string
+	err           error
+}
+
+// runMatrix runs the test files with every configuration of the matrix in parallel.
+func runMatrix(
+	ctx context.Context,
+	configurations []matrixConfiguration,
+	files []string,
+	configPaths []string,
+	logger output.Logger,
+) (*matrixResult, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "flow-test-matrix")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	logger.StartProgress(fmt.Sprintf("Running the tests with %d configurations...", len(configurations)))
+	defer logger.StopProgress()
+
+	results := make([]matrixConfigurationResult, len(configurations))
+	var wg sync.WaitGroup
+	for i, configuration := range configurations {
+		if configuration.Command == "" {
+			configuration.Command = executable
+		}
+
+		wg.Add(1)
+		go func(i int, configuration matrixConfiguration) {
+			defer wg.Done()
+			report := filepath.Join(dir, fmt.Sprintf("%d.json", i))
+			tests, err := runConfiguration(ctx, configuration, files, configPaths, report)
+			results[i] = matrixConfigurationResult{
+				configuration: configurations[i],
+				tests:         tests,
+				err:           err,
+			}
+		}(i, configuration)
+	}
+	wg.Wait()
+
+	return &matrixResult{configurations: results}, nil
+}
+
+// runConfiguration runs the tests with the command of the configuration,
+// which saves the JSON result to the report file.
+func runConfiguration(
+	ctx context.Context,
+	configuration matrixConfiguration,
+	files []string,
+	configPaths []string,
+	report string,
+) (map[string]map[string]string, error) {
+	args := append([]string{"test"}, files...)
+	for _, path := range configPaths {
+		args = append(args, "--config-path", path)
+	}
+	args = append(args, "--output", "json", "--save", report, "--skip-version-check")
+	args = append(args, configuration.Args...)
+
+	cmd := exec.CommandContext(ctx, configuration.Command, args...)
+	cmd.Env = os.Environ()
+	for key, value := range configuration.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+	}
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	// failing tests exit with a non-zero status after saving the result
+	runErr := cmd.Run()
+
+	data, err := os.ReadFile(report)
+	if err != nil {
+		if runErr == nil {
+			runErr = fmt.Errorf("no test result was saved")
+		}
+		return nil, fmt.Errorf("%w: %s", runErr, strings.TrimSpace(out.String()))
+	}
+
+	var tests map[string]map[string]string
+	err = json.Unmarshal(data, &tests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the test result: %w", err)
+	}
+	delete(tests, "meta")
+
+	return tests, nil
+}
+
+// matrixResult compares the test results of the configurations.
+type matrixResult struct {
+	configurations []matrixConfigurationResult
+}
+
+// matrixTest identifies a test by its file and name.
+type matrixTest struct {
+	file string
+	name string
+}
+
+// tests returns all the tests run by any configuration, ordered by file and name.
+func (r *matrixResult) tests() []matrixTest {
+	seen := make(map[matrixTest]bool)
+	var tests []matrixTest
+	for _, c := range r.configurations {
+		for file, results := range c.tests {
+			for name := range results {
+				test := matrixTest{file: file, name: name}
+				if !seen[test] {
+					seen[test] = true
+					tests = append(tests, test)
+				}
+			}
+		}
+	}
+
+	sort.Slice(tests, func(i, j int) bool {
+		if tests[i].file != tests[j].file {
+			return tests[i].file < tests[j].file
+		}
+		return tests[i].name < tests[j].name
+	})
+	return tests
+}
+
+// status returns the status of the test with the configuration, or an empty string if it didn't run.
+func (c matrixConfigurationResult) status(test matrixTest) string {
+	return c.tests[test.file][test.name]
+}
+
+// passed returns whether every configuration ran all the tests successfully.
+func (r *matrixResult) passed() bool {
+	tests := r.tests()
+	for _, c := range r.configurations {
+		if c.err != nil {
+			return false
+		}
+		for _, test := range tests {
+			if c.status(test) != passStatus {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// differs returns whether the configurations don't agree on whether the test passed.
+func (r *matrixResult) differs(test matrixTest) bool {
+	var first *bool
+	for _, c := range r.configurations {
+		if c.err != nil {
+			continue
+		}
+		passed := c.status(test) == passStatus
+		if first == nil {
+			first = &passed
+		} else if *first != passed {
+			return true
+		}
+	}
+	return false
+}
+
+type matrixOutput struct {
+	Configurations []matrixConfigurationOutput `json:"configurations"`
+	Tests          []matrixTestOutput          `json:"tests"`
+}
+
+type matrixConfigurationOutput struct {
+	Name   string `json:"name"`
+	Passed int    `json:"passed"`
+	Failed int    `json:"failed"`
+	Error  string `json:"error,omitempty"`
+}
+
+type matrixTestOutput struct {
+	File    string            `json:"file"`
+	Test    string            `json:"test"`
+	Results map[string]string `json:"results"`
+	Differs bool              `json:"differs"`
+}
+
+// counts returns the number of passed and failed tests of the configuration.
+func (c matrixConfigurationResult) counts(tests []matrixTest) (passed int, failed int) {
+	if c.err != nil {
+		return 0, 0
+	}
+	for _, test := range tests {
+		if c.status(test) == passStatus {
+			passed++
+		} else {
+			failed++
+		}
+	}
+	return passed, failed
+}
+
+func (r *matrixResult) JSON() any {
+	tests := r.tests()
+	out := matrixOutput{
+		Configurations: make([]matrixConfigurationOutput, 0, len(r.configurations)),
+		Tests:          make([]matrixTestOutput, 0, len(tests)),
+	}
+
+	for _, c := range r.configurations {
+		passed, failed := c.counts(tests)
+		configuration := matrixConfigurationOutput{
+			Name:   c.configuration.Name,
+			Passed: passed,
+			Failed: failed,
+		}
+		if c.err != nil {
+			configuration.Error = c.err.Error()
+		}
+		out.Configurations = append(out.Configurations, configuration)
+	}
+
+	for _, test := range tests {
+		results := make(map[string]string, len(r.configurations))
+		for _, c := range r.configurations {
+			if c.err == nil {
+				results[c.configuration.Name] = c.status(test)
+			}
+		}
+		out.Tests = append(out.Tests, matrixTestOutput{
+			File:    test.file,
+			Test:    test.name,
+			Results: results,
+			Differs: r.differs(test),
+		})
+	}
+
+	return out
+}
+
+func (r *matrixResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	tests := r.tests()
+
+	_, _ = fmt.Fprint(writer, "Test\t")
+	for _, c := range r.configurations {
+		_, _ = fmt.Fprintf(writer, "%s\t", c.configuration.Name)
+	}
+	_, _ = fmt.Fprintln(writer)
+
+	differences := 0
+	for _, test := range tests {
+		_, _ = fmt.Fprintf(writer, "%s %s\t", test.file, test.name)
+		for _, c := range r.configurations {
+			_, _ = fmt.Fprintf(writer, "%s\t", matrixStatus(c, test))
+		}
+		if r.differs(test) {
+			differences++
+			_, _ = fmt.Fprint(writer, "differs")
+		}
+		_, _ = fmt.Fprintln(writer)
+	}
+
+	_, _ = fmt.Fprintln(writer)
+	for _, c := range r.configurations {
+		if c.err != nil {
+			_, _ = fmt.Fprintf(writer, "%s %s failed to run: %s\n", output.ErrorEmoji(), c.configuration.Name, c.err)
+			continue
+		}
+		passed, failed := c.counts(tests)
+		_, _ = fmt.Fprintf(writer, "%s\t%d passed, %d failed\n", c.configuration.Name, passed, failed)
+	}
+	_, _ = fmt.Fprintf(writer, "\n%d of %d tests differ between the configurations\n", differences, len(tests))
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *matrixResult) Oneliner() string {
+	tests := r.tests()
+	summaries := make([]string, 0, len(r.configurations))
+	for _, c := range r.configurations {
+		if c.err != nil {
+			summaries = append(summaries, fmt.Sprintf("%s: error", c.configuration.Name))
+			continue
+		}
+		passed, failed := c.counts(tests)
+		summaries = append(summaries, fmt.Sprintf("%s: %d passed, %d failed", c.configuration.Name, passed, failed))
+	}
+	return strings.Join(summaries, ", ")
+}
+
+// matrixStatus returns the short status of the test with the configuration for the report table.
+func matrixStatus(c matrixConfigurationResult, test matrixTest) string {
+	if c.err != nil {
+		return "ERROR"
+	}
+	status := c.status(test)
+	switch {
+	case status == "":
+		return "-"
+	case status == passStatus:
+		return passStatus
+	default:
+		return "FAIL"
+	}
+}
@@ -20,6 +20,7 @@ package test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -42,9 +43,13 @@ import (
 // are considered to be helper/utility scripts for test files.
 const helperScriptSubstr = "_helper"
 
+// passStatus is the status of a passed test in the JSON result.
+const passStatus = "PASS"
+
 type flagsTests struct {
 	Cover        bool   `default:"false" flag:"cover" info:"Use the cover flag to calculate coverage report"`
 	CoverProfile string `default:"coverage.json" flag:"coverprofile" info:"Filename to write the calculated coverage report"`
+	Matrix       string `default:"" flag:"matrix" info:"YAML file listing emulator and Cadence configurations to run the tests with in parallel and compare"`
 }
 
 var testFlags = flagsTests{}
@@ -53,20 +58,22 @@ var status = 0
 
 var TestCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "test <filename>",
-		Short:   "Run Cadence tests",
-		Example: `flow test script.cdc`,
+		Use:   "test <filename>",
+		Short: "Run Cadence tests",
+		Example: `flow test script.cdc
+flow test tests/*_test.cdc --matrix cadence-versions.yaml`,
 		Args:    cobra.MinimumNArgs(1),
 		GroupID: "tools",
 	},
-	Flags:  &testFlags,
-	RunS:   run,
-	Status: &status,
+	Flags:    &testFlags,
+	RunS:     run,
+	Status:   &status,
+	NoDaemon: true,
 }
 
 func run(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
 	_ flowkit.Services,
 	state *flowkit.State,
@@ -75,6 +82,29 @@ func run(
 		return nil, fmt.Errorf("the '--coverprofile' flag requires the '--cover' flag")
 	}
 
+	if testFlags.Matrix != "" {
+		if testFlags.Cover {
+			return nil, fmt.Errorf("the '--matrix' flag can not be combined with the '--cover' flag")
+		}
+		data, err := state.ReadFile(testFlags.Matrix)
+		if err != nil {
+			return nil, fmt.Errorf("error loading matrix file: %w", err)
+		}
+		configurations, err := parseMatrix(data)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := runMatrix(context.Background(), configurations, args, globalFlags.ConfigPaths, logger)
+		if err != nil {
+			return nil, err
+		}
+		if !result.passed() {
+			status = 1
+		}
+		return result, nil
+	}
+
 	testFiles := make(map[string][]byte, 0)
 	for _, filename := range args {
 		code, err := state.ReadFile(filename)
@@ -228,7 +258,7 @@ func (r *result) JSON() any {
 		for _, result := range testResult {
 			var status string
 			if result.Error == nil {
-				status = "PASS"
+				status = passStatus
 			} else {
 				status = fmt.Sprintf("FAIL: %s", result.Error.Error())
 			}
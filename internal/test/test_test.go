@@ -19,7 +19,10 @@
 package test
 
 import (
+	"context"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/onflow/cadence/runtime/common"
@@ -28,6 +31,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -246,3 +250,78 @@ func TestExecutingTests(t *testing.T) {
 		)
 	})
 }
+
+func TestMatrix(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		configurations, err := parseMatrix([]byte(`
+configurations:
+  - name: stable
+  - name: upcoming
+    command: flow-preview
+    args: ["--config-path", "flow.preview.json"]
+    env:
+      FEATURE: "enabled"
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []matrixConfiguration{{
+			Name: "stable",
+		}, {
+			Name:    "upcoming",
+			Command: "flow-preview",
+			Args:    []string{"--config-path", "flow.preview.json"},
+			Env:     map[string]string{"FEATURE": "enabled"},
+		}}, configurations)
+	})
+
+	t.Run("Fail parse", func(t *testing.T) {
+		_, err := parseMatrix([]byte(`configurations: []`))
+		assert.EqualError(t, err, "the matrix doesn't contain any configurations")
+
+		_, err = parseMatrix([]byte("configurations:\n  - command: flow\n"))
+		assert.EqualError(t, err, "configuration 1 of the matrix is missing a name")
+
+		_, err = parseMatrix([]byte("configurations:\n  - name: a\n  - name: a\n"))
+		assert.EqualError(t, err, "configuration a is duplicated in the matrix")
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("the test command is a shell script")
+		}
+
+		// the fake test command saves a failing second test when FEATURE is set
+		command := filepath.Join(t.TempDir(), "flow")
+		require.NoError(t, os.WriteFile(command, []byte(`#!/bin/sh
+while [ "$1" != "--save" ]; do shift; done
+second="PASS"
+if [ -n "$FEATURE" ]; then second="FAIL: assertion failed"; fi
+echo "{\"test.cdc\": {\"testFirst\": \"PASS\", \"testSecond\": \"$second\"}}" > "$2"
+`), 0755))
+
+		result, err := runMatrix(context.Background(), []matrixConfiguration{
+			{Name: "stable", Command: command},
+			{Name: "upcoming", Command: command, Env: map[string]string{"FEATURE": "enabled"}},
+			{Name: "missing", Command: filepath.Join(t.TempDir(), "missing")},
+		}, []string{"test.cdc"}, []string{"flow.json"}, output.NewStdoutLogger(output.NoneLog))
+		require.NoError(t, err)
+
+		assert.False(t, result.passed())
+		out := result.JSON().(matrixOutput)
+		require.Len(t, out.Configurations, 3)
+		assert.Equal(t, matrixConfigurationOutput{Name: "stable", Passed: 2}, out.Configurations[0])
+		assert.Equal(t, matrixConfigurationOutput{Name: "upcoming", Passed: 1, Failed: 1}, out.Configurations[1])
+		assert.NotEmpty(t, out.Configurations[2].Error)
+
+		assert.Equal(t, []matrixTestOutput{{
+			File:    "test.cdc",
+			Test:    "testFirst",
+			Results: map[string]string{"stable": "PASS", "upcoming": "PASS"},
+		}, {
+			File:    "test.cdc",
+			Test:    "testSecond",
+			Results: map[string]string{"stable": "PASS", "upcoming": "FAIL: assertion failed"},
+			Differs: true,
+		}}, out.Tests)
+		assert.Contains(t, result.String(), "1 of 2 tests differ between the configurations")
+	})
+}
@@ -47,8 +47,9 @@ var Flowser = &command.Command{
 		Args:    cobra.ExactArgs(0),
 		GroupID: "tools",
 	},
-	Flags: &flowserFlags,
-	Run:   runFlowser,
+	Flags:    &flowserFlags,
+	Run:      runFlowser,
+	NoDaemon: true,
 }
 
 func runFlowser(
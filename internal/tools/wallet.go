@@ -45,8 +45,9 @@ var DevWallet = &command.Command{
 		Args:    cobra.ExactArgs(0),
 		GroupID: "tools",
 	},
-	Flags: &walletFlags,
-	RunS:  wallet,
+	Flags:    &walletFlags,
+	RunS:     wallet,
+	NoDaemon: true,
 }
 
 func wallet(
@@ -0,0 +1,105 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// transactionRecord is the CBOR encoding of a listed transaction, the output is a CBOR array of records.
+//
+// The records are maps keyed by integers: 1 block height, 2 transaction ID, 3 roles of the address,
+// 4 transaction status and 5 the error message of failed transactions.
+// Transactions without a result have the unknown status.
+type transactionRecord struct {
+	BlockHeight   uint64   `cbor:"1,keyasint"`
+	TransactionID []byte   `cbor:"2,keyasint"`
+	Roles         []string `cbor:"3,keyasint"`
+	Status        string   `cbor:"4,keyasint"`
+	Error         string   `cbor:"5,keyasint,omitempty"`
+}
+
+// Binary encodes the listed transactions in the cbor or proto format.
+//
+// The proto format is a stream of flow.access.TransactionResultResponse messages of the Access API,
+// one for each transaction, each prefixed with its length as a varint. The roles of the address are
+// only included in the cbor format, since the Access API messages have no field for them.
+func (r *listResult) Binary(format string) ([]byte, error) {
+	switch format {
+	case "cbor":
+		return r.cbor()
+	case "proto":
+		return r.proto()
+	default:
+		return nil, fmt.Errorf("unsupported binary format %s", format)
+	}
+}
+
+func (r *listResult) cbor() ([]byte, error) {
+	records := make([]transactionRecord, 0, len(r.transactions))
+	for _, tx := range r.transactions {
+		record := transactionRecord{
+			BlockHeight:   tx.height,
+			TransactionID: tx.tx.ID().Bytes(),
+			Roles:         tx.roles,
+			Status:        "UNKNOWN",
+		}
+		if tx.result != nil {
+			record.Status = tx.result.Status.String()
+			if tx.result.Error != nil {
+				record.Error = tx.result.Error.Error()
+			}
+		}
+		records = append(records, record)
+	}
+
+	return cbor.Marshal(records)
+}
+
+func (r *listResult) proto() ([]byte, error) {
+	var encoded []byte
+	for _, tx := range r.transactions {
+		result := &access.TransactionResultResponse{
+			TransactionId: tx.tx.ID().Bytes(),
+			BlockHeight:   tx.height,
+		}
+		if tx.result != nil {
+			result.Status = entities.TransactionStatus(tx.result.Status)
+			if tx.result.Error != nil {
+				result.StatusCode = 1
+				result.ErrorMessage = tx.result.Error.Error()
+			}
+		}
+
+		message, err := proto.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		encoded = protowire.AppendVarint(encoded, uint64(len(message)))
+		encoded = append(encoded, message...)
+	}
+
+	return encoded, nil
+}
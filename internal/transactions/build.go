@@ -35,7 +35,7 @@ import (
 )
 
 type flagsBuild struct {
-	ArgsJSON         string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	ArgsJSON         string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format, @file to read them from a file or - from the standard input"`
 	Proposer         string   `default:"emulator-account" flag:"proposer" info:"transaction proposer"`
 	ProposerKeyIndex int      `default:"0" flag:"proposer-key-index" info:"proposer key index"`
 	Payer            string   `default:"emulator-account" flag:"payer" info:"transaction payer"`
@@ -63,7 +63,7 @@ func build(
 	flow flowkit.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
-	proposer, err := getAddress(buildFlags.Proposer, state)
+	proposer, err := util.ResolveAddress(buildFlags.Proposer, state, flow.Network())
 	if err != nil {
 		return nil, err
 	}
@@ -71,14 +71,14 @@ func build(
 	// get all authorizers
 	var authorizers []flowsdk.Address
 	for _, auth := range buildFlags.Authorizer {
-		addr, err := getAddress(auth, state)
+		addr, err := util.ResolveAddress(auth, state, flow.Network())
 		if err != nil {
 			return nil, err
 		}
 		authorizers = append(authorizers, addr)
 	}
 
-	payer, err := getAddress(buildFlags.Payer, state)
+	payer, err := util.ResolveAddress(buildFlags.Payer, state, flow.Network())
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +91,7 @@ func build(
 
 	var transactionArgs []cadence.Value
 	if buildFlags.ArgsJSON != "" {
-		transactionArgs, err = arguments.ParseJSON(buildFlags.ArgsJSON)
+		transactionArgs, err = util.ParseArgsJSON(buildFlags.ArgsJSON, state.ReaderWriter())
 	} else {
 		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, filename)
 	}
@@ -127,27 +127,3 @@ func build(
 		include: []string{"code", "payload", "signatures"},
 	}, nil
 }
-
-func getAddress(address string, state *flowkit.State) (flowsdk.Address, error) {
-	addr, valid := parseAddress(address)
-	if valid {
-		return addr, nil
-	}
-
-	// if address is not valid then try using the string as an account name.
-	acc, err := state.Accounts().ByName(address)
-	if err != nil {
-		return flowsdk.EmptyAddress, err
-	}
-	return acc.Address, nil
-}
-
-func parseAddress(value string) (flowsdk.Address, bool) {
-	address := flowsdk.HexToAddress(value)
-
-	// valid on any chain
-	return address, address.IsValid(flowsdk.Mainnet) ||
-		address.IsValid(flowsdk.Testnet) ||
-		address.IsValid(flowsdk.Emulator) ||
-		address.IsValid(flowsdk.Sandboxnet)
-}
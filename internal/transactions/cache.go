@@ -0,0 +1,199 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// transactionCacheDir is the project relative directory where transaction results are cached.
+const transactionCacheDir = ".flow/transactions"
+
+// transactionCache stores fetched transactions with sealed results on disk, keyed by the network and the
+// transaction ID. Only sealed results are final, so transactions with any other status are never cached.
+type transactionCache struct {
+	dir          string
+	network      config.Network
+	readerWriter flowkit.ReaderWriter
+}
+
+func newTransactionCache(
+	configPaths []string,
+	network config.Network,
+	readerWriter flowkit.ReaderWriter,
+) *transactionCache {
+	return &transactionCache{
+		dir:          filepath.Join(util.ProjectDir(configPaths), transactionCacheDir, network.Name),
+		network:      network,
+		readerWriter: readerWriter,
+	}
+}
+
+type transactionCacheEntry struct {
+	Host        string        `json:"host"`
+	Transaction []byte        `json:"transaction"`
+	Status      int           `json:"status"`
+	Error       string        `json:"error,omitempty"`
+	BlockID     string        `json:"blockId"`
+	BlockHeight uint64        `json:"blockHeight"`
+	Events      []cachedEvent `json:"events"`
+}
+
+type cachedEvent struct {
+	Type             string          `json:"type"`
+	TransactionIndex int             `json:"transactionIndex"`
+	EventIndex       int             `json:"eventIndex"`
+	Value            json.RawMessage `json:"value"`
+}
+
+func (c *transactionCache) path(id flowsdk.Identifier) string {
+	return filepath.Join(c.dir, id.String()+".json")
+}
+
+// get returns the cached transaction and result, any failure to read the entry is treated as a cache miss.
+func (c *transactionCache) get(id flowsdk.Identifier) (*flowsdk.Transaction, *flowsdk.TransactionResult, bool) {
+	data, err := c.readerWriter.ReadFile(c.path(id))
+	if err != nil {
+		return nil, nil, false
+	}
+
+	var entry transactionCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, nil, false
+	}
+	// a network with the same name can be configured with a different host, e.g. a restarted emulator
+	if entry.Host != c.network.Host || flowsdk.TransactionStatus(entry.Status) != flowsdk.TransactionStatusSealed {
+		return nil, nil, false
+	}
+
+	tx, err := flowsdk.DecodeTransaction(entry.Transaction)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	result := &flowsdk.TransactionResult{
+		Status:        flowsdk.TransactionStatus(entry.Status),
+		BlockID:       flowsdk.HexToID(entry.BlockID),
+		BlockHeight:   entry.BlockHeight,
+		TransactionID: id,
+		Events:        make([]flowsdk.Event, 0, len(entry.Events)),
+	}
+	if entry.Error != "" {
+		result.Error = errors.New(entry.Error)
+	}
+
+	for _, cached := range entry.Events {
+		value, err := jsoncdc.Decode(nil, cached.Value)
+		if err != nil {
+			return nil, nil, false
+		}
+		event, ok := value.(cadence.Event)
+		if !ok {
+			return nil, nil, false
+		}
+		result.Events = append(result.Events, flowsdk.Event{
+			Type:             cached.Type,
+			TransactionID:    id,
+			TransactionIndex: cached.TransactionIndex,
+			EventIndex:       cached.EventIndex,
+			Value:            event,
+			Payload:          cached.Value,
+		})
+	}
+
+	return tx, result, true
+}
+
+// put stores the transaction if its result is sealed.
+func (c *transactionCache) put(id flowsdk.Identifier, tx *flowsdk.Transaction, result *flowsdk.TransactionResult) error {
+	if result == nil || result.Status != flowsdk.TransactionStatusSealed {
+		return nil
+	}
+
+	entry := transactionCacheEntry{
+		Host:        c.network.Host,
+		Transaction: tx.Encode(),
+		Status:      int(result.Status),
+		BlockID:     result.BlockID.String(),
+		BlockHeight: result.BlockHeight,
+		Events:      make([]cachedEvent, 0, len(result.Events)),
+	}
+	if result.Error != nil {
+		entry.Error = result.Error.Error()
+	}
+
+	for _, event := range result.Events {
+		value, err := jsoncdc.Encode(event.Value)
+		if err != nil {
+			return err
+		}
+		entry.Events = append(entry.Events, cachedEvent{
+			Type:             event.Type,
+			TransactionIndex: event.TransactionIndex,
+			EventIndex:       event.EventIndex,
+			Value:            value,
+		})
+	}
+
+	if err := flowkit.MkdirAll(c.readerWriter, c.dir); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return c.readerWriter.WriteFile(c.path(id), data, 0644)
+}
+
+// getTransaction returns the cached transaction, or fetches it and caches it once sealed.
+// The cache is skipped when refreshing, and failing to write the cache doesn't fail the command.
+func (c *transactionCache) getTransaction(
+	ctx context.Context,
+	flow flowkit.Services,
+	id flowsdk.Identifier,
+	waitSeal bool,
+	refresh bool,
+) (*flowsdk.Transaction, *flowsdk.TransactionResult, error) {
+	if !refresh {
+		if tx, result, ok := c.get(id); ok {
+			return tx, result, nil
+		}
+	}
+
+	tx, result, err := flow.GetTransactionByID(ctx, id, waitSeal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_ = c.put(id, tx, result)
+	return tx, result, nil
+}
@@ -0,0 +1,175 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/parser"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// transactionFile is a transaction file with the metadata declared in its leading comment.
+type transactionFile struct {
+	file       string
+	metadata   util.Metadata
+	parameters []arguments.Parameter
+}
+
+// listFiles lists the transaction files in the directory, files declaring something other than a
+// transaction are skipped.
+func listFiles(dir string, readerWriter flowkit.ReaderWriter) (command.Result, error) {
+	files, err := util.CadenceFiles(dir, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &filesResult{dir: dir, files: make([]transactionFile, 0, len(files))}
+	for _, file := range files {
+		code, err := readerWriter.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error loading transaction file: %w", err)
+		}
+
+		if !isTransaction(code) {
+			continue
+		}
+
+		metadata, err := util.ParseMetadata(code)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing metadata of %s: %w", file, err)
+		}
+		if metadata == nil {
+			metadata = &util.Metadata{}
+		}
+
+		parameters, err := arguments.Parameters(code, file)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing parameters of %s: %w", file, err)
+		}
+
+		result.files = append(result.files, transactionFile{
+			file:       file,
+			metadata:   *metadata,
+			parameters: parameters,
+		})
+	}
+
+	return result, nil
+}
+
+// isTransaction reports whether the code declares a transaction.
+func isTransaction(code []byte) bool {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return false
+	}
+	return len(program.TransactionDeclarations()) > 0
+}
+
+type filesResult struct {
+	dir   string
+	files []transactionFile
+}
+
+type transactionFileOutput struct {
+	File        string                   `json:"file"`
+	Name        string                   `json:"name,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Parameters  []transactionParamOutput `json:"parameters"`
+	Signers     []string                 `json:"signers,omitempty"`
+}
+
+type transactionParamOutput struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+func (r *filesResult) JSON() any {
+	result := make([]transactionFileOutput, 0, len(r.files))
+	for _, file := range r.files {
+		parameters := make([]transactionParamOutput, 0, len(file.parameters))
+		for _, parameter := range file.parameters {
+			parameters = append(parameters, transactionParamOutput{
+				Name:        parameter.Name,
+				Type:        parameter.Type,
+				Description: file.metadata.Parameters[parameter.Name],
+			})
+		}
+
+		result = append(result, transactionFileOutput{
+			File:        file.file,
+			Name:        file.metadata.Name,
+			Description: file.metadata.Description,
+			Parameters:  parameters,
+			Signers:     file.metadata.Signers,
+		})
+	}
+
+	return result
+}
+
+func (r *filesResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if len(r.files) == 0 {
+		_, _ = fmt.Fprintf(writer, "No transaction files found in %s\n", r.dir)
+	}
+
+	for _, file := range r.files {
+		_, _ = fmt.Fprintf(writer, "File\t%s\n", file.file)
+		if file.metadata.Name != "" {
+			_, _ = fmt.Fprintf(writer, "Name\t%s\n", file.metadata.Name)
+		}
+		if file.metadata.Description != "" {
+			_, _ = fmt.Fprintf(writer, "Description\t%s\n", file.metadata.Description)
+		}
+		for _, parameter := range file.parameters {
+			_, _ = fmt.Fprintf(writer, "Parameter\t%s: %s", parameter.Name, parameter.Type)
+			if description := file.metadata.Parameters[parameter.Name]; description != "" {
+				_, _ = fmt.Fprintf(writer, "\t%s", description)
+			}
+			_, _ = fmt.Fprintf(writer, "\n")
+		}
+		if len(file.metadata.Signers) > 0 {
+			_, _ = fmt.Fprintf(writer, "Signers\t%s\n", strings.Join(file.metadata.Signers, ", "))
+		}
+		_, _ = fmt.Fprintf(writer, "\n")
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *filesResult) Oneliner() string {
+	files := make([]string, 0, len(r.files))
+	for _, file := range r.files {
+		files = append(files, file.file)
+	}
+
+	return strings.Join(files, ", ")
+}
@@ -19,14 +19,16 @@
 package transactions
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
 
-	"github.com/onflow/flow-cli/flowkit/transactions"
-
+	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
 )
 
@@ -39,8 +41,8 @@ var decodeFlags = flagsDecode{}
 var decodeCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "decode <transaction filename>",
-		Short:   "Decode a transaction",
-		Example: "flow transactions decode ./transaction.rlp",
+		Short:   "Decode a hex or binary RLP-encoded transaction",
+		Example: "flow transactions decode ./transaction.rlp --include code,signatures",
 		Args:    cobra.ExactArgs(1),
 	},
 	Flags: &decodeFlags,
@@ -60,13 +62,33 @@ func decode(
 		return nil, fmt.Errorf("failed to read transaction from %s: %v", filename, err)
 	}
 
-	tx, err := transactions.NewFromPayload(payload)
+	tx, err := decodePayload(payload)
 	if err != nil {
 		return nil, err
 	}
 
 	return &transactionResult{
-		tx:      tx.FlowTransaction(),
+		tx:      tx,
 		include: decodeFlags.Include,
+		decoded: true,
 	}, nil
 }
+
+// decodePayload decodes a transaction payload which is either hex encoded, as produced
+// by the build and sign commands, or raw binary RLP as produced by other tooling.
+func decodePayload(payload []byte) (*flow.Transaction, error) {
+	trimmed := bytes.TrimSpace(payload)
+	if _, hexErr := hex.DecodeString(string(trimmed)); hexErr != nil {
+		tx, err := flow.DecodeTransaction(payload)
+		if err == nil {
+			return tx, nil
+		}
+	}
+
+	tx, err := transactions.NewFromPayload(trimmed)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.FlowTransaction(), nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+)
+
+// transactionExpiry is the number of blocks after the reference block in which a transaction can be sealed.
+const transactionExpiry = 600
+
+// checkExpiry compares the reference block of the transaction to the latest sealed block and returns
+// whether the reference block was refreshed.
+//
+// If the transaction expires within the window of blocks a warning is shown, or an error if it already
+// expired. The reference block of a transaction without signatures is only refreshed to the latest block
+// if refresh is set, signatures would be invalidated by changing a signed transaction. The check is
+// skipped with a warning if the blocks can't be fetched, e.g. while signing offline.
+func checkExpiry(
+	flow flowkit.Services,
+	logger output.Logger,
+	tx *transactions.Transaction,
+	window uint64,
+	refresh bool,
+) (bool, error) {
+	flowTx := tx.FlowTransaction()
+	referenceID := flowTx.ReferenceBlockID
+
+	reference, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{ID: &referenceID})
+	if err != nil {
+		logger.Info(fmt.Sprintf("%s Skipped checking the transaction expiry, failed to get reference block %s: %s", output.WarningEmoji(), referenceID, err))
+		return false, nil
+	}
+
+	latest, err := flow.GetBlock(context.Background(), flowkit.LatestBlockQuery)
+	if err != nil {
+		logger.Info(fmt.Sprintf("%s Skipped checking the transaction expiry, failed to get the latest block: %s", output.WarningEmoji(), err))
+		return false, nil
+	}
+
+	expiresAt := reference.Height + transactionExpiry
+	if latest.Height+window < expiresAt {
+		return false, nil
+	}
+
+	unsigned := len(flowTx.PayloadSignatures) == 0 && len(flowTx.EnvelopeSignatures) == 0
+	if unsigned && refresh {
+		flowTx.SetReferenceBlockID(latest.ID)
+		logger.Info(fmt.Sprintf(
+			"%s Reference block was refreshed to %s at height %d since the transaction would expire at height %d",
+			output.WarningEmoji(),
+			latest.ID,
+			latest.Height,
+			expiresAt,
+		))
+		return true, nil
+	}
+
+	if latest.Height >= expiresAt {
+		if unsigned {
+			return false, fmt.Errorf(
+				"transaction expired at block height %d, latest sealed height is %d, build the transaction again or refresh its reference block using the --refresh-reference-block flag",
+				expiresAt,
+				latest.Height,
+			)
+		}
+		return false, fmt.Errorf(
+			"transaction expired at block height %d, latest sealed height is %d, build and sign the transaction again",
+			expiresAt,
+			latest.Height,
+		)
+	}
+
+	logger.Info(fmt.Sprintf(
+		"%s Transaction expires in %d blocks at height %d, collect the remaining signatures and send it before then",
+		output.WarningEmoji(),
+		expiresAt-latest.Height,
+		expiresAt,
+	))
+	return false, nil
+}
@@ -0,0 +1,568 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsExport struct {
+	Lang     string `default:"go" flag:"lang" info:"Language of the exported code: go, js or swift"`
+	ArgsJSON string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	Signer   string `default:"" flag:"signer" info:"Account name from configuration used as proposer, payer and authorizer"`
+	GasLimit uint64 `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var exportFlags = flagsExport{}
+
+var exportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "export <code filename> [<argument> <argument> ...]",
+		Short:   "Export a transaction as flow-go-sdk, FCL or flow-swift code",
+		Example: `flow transactions export tx.cdc "Hello world" --lang js --save tx.js`,
+		Args:    cobra.MinimumNArgs(1),
+	},
+	Flags: &exportFlags,
+	RunS:  export,
+}
+
+func export(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	signerName := exportFlags.Signer
+	if signerName == "" {
+		signerName = state.Config().Emulators.Default().ServiceAccount
+	}
+
+	signer, err := util.AccountByName(state, signerName)
+	if err != nil {
+		return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
+	}
+
+	codeFilename := args[0]
+	code, err := state.ReadFile(codeFilename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	var transactionArgs []cadence.Value
+	if exportFlags.ArgsJSON != "" {
+		transactionArgs, err = arguments.ParseJSON(exportFlags.ArgsJSON)
+	} else {
+		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, codeFilename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	header, err := metadataHeader(code, codeFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve imports so the exported code doesn't depend on the project configuration
+	code, err = resolveImports(code, codeFilename, flow.Network(), state)
+	if err != nil {
+		return nil, err
+	}
+
+	snippet := txSnippet{
+		code:     string(code),
+		args:     transactionArgs,
+		signer:   signer,
+		network:  flow.Network(),
+		gasLimit: exportFlags.GasLimit,
+	}
+
+	var exported string
+	switch exportFlags.Lang {
+	case "go":
+		exported, err = snippet.golang()
+	case "js":
+		exported, err = snippet.javascript()
+	case "swift":
+		exported, err = snippet.swift()
+	default:
+		return nil, fmt.Errorf("unsupported language %s, supported languages are: go, js, swift", exportFlags.Lang)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &exportResult{lang: exportFlags.Lang, code: header + exported}, nil
+}
+
+// metadataHeader returns the metadata declared by the transaction as a comment documenting the exported code,
+// or an empty string if the transaction doesn't declare metadata.
+func metadataHeader(code []byte, location string) (string, error) {
+	metadata, err := util.ParseMetadata(code)
+	if err != nil {
+		return "", fmt.Errorf("error parsing transaction metadata: %w", err)
+	}
+	if metadata == nil {
+		return "", nil
+	}
+
+	parameters, err := arguments.Parameters(code, location)
+	if err != nil {
+		return "", fmt.Errorf("error parsing transaction parameters: %w", err)
+	}
+	names := make([]string, 0, len(parameters))
+	for _, parameter := range parameters {
+		names = append(names, parameter.Name)
+	}
+
+	// all supported languages use the same line comments
+	return metadata.Comment("//", names) + "\n", nil
+}
+
+// resolveImports replaces the file imports in the code with the addresses of contracts on the network.
+func resolveImports(code []byte, location string, network config.Network, state *flowkit.State) ([]byte, error) {
+	program, err := project.NewProgram(code, nil, location)
+	if err != nil {
+		return nil, err
+	}
+
+	if !program.HasImports() {
+		return code, nil
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err = project.NewImportReplacer(contracts, state.AliasesForNetwork(network)).Replace(program)
+	if err != nil {
+		return nil, err
+	}
+
+	return program.Code(), nil
+}
+
+// txSnippet contains all the data needed to reproduce the transaction in application code.
+type txSnippet struct {
+	code     string
+	args     []cadence.Value
+	signer   *accounts.Account
+	network  config.Network
+	gasLimit uint64
+}
+
+func (s txSnippet) jsonArgs() ([]string, error) {
+	encoded := make([]string, 0, len(s.args))
+	for _, arg := range s.args {
+		b, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode argument %s: %w", arg, err)
+		}
+		encoded = append(encoded, strings.TrimSpace(string(b)))
+	}
+	return encoded, nil
+}
+
+func (s txSnippet) golang() (string, error) {
+	args, err := s.jsonArgs()
+	if err != nil {
+		return "", err
+	}
+
+	code := goStringLiteral(s.code)
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, `package main
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+const transactionCode = %s
+
+func main() {
+	ctx := context.Background()
+
+	flowClient, err := grpc.NewClient(%q)
+	if err != nil {
+		panic(err)
+	}
+
+	signerAddress := flow.HexToAddress(%q)
+	signerKeyIndex := %d
+
+	account, err := flowClient.GetAccount(ctx, signerAddress)
+	if err != nil {
+		panic(err)
+	}
+	key := account.Keys[signerKeyIndex]
+
+	block, err := flowClient.GetLatestBlock(ctx, true)
+	if err != nil {
+		panic(err)
+	}
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(transactionCode)).
+		SetComputeLimit(%d).
+		SetReferenceBlockID(block.ID).
+		SetProposalKey(signerAddress, key.Index, key.SequenceNumber).
+		SetPayer(signerAddress).
+		AddAuthorizer(signerAddress)
+`, code, s.network.Host, s.signer.Address.Hex(), s.signer.Key.Index(), s.gasLimit)
+
+	for _, arg := range args {
+		_, _ = fmt.Fprintf(&b, `
+	if err := tx.AddRawArgument([]byte(%s)); err != nil {
+		panic(err)
+	}
+`, goStringLiteral(arg))
+	}
+
+	_, _ = fmt.Fprintf(&b, `
+	// replace with the signer private key or use another crypto.Signer implementation
+	privateKey, err := crypto.DecodePrivateKeyHex(crypto.%s, "<private key>")
+	if err != nil {
+		panic(err)
+	}
+
+	signer, err := crypto.NewInMemorySigner(privateKey, key.HashAlgo)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := tx.SignEnvelope(signerAddress, key.Index, signer); err != nil {
+		panic(err)
+	}
+
+	if err := flowClient.SendTransaction(ctx, *tx); err != nil {
+		panic(err)
+	}
+}
+`, s.signer.Key.SigAlgo())
+
+	return b.String(), nil
+}
+
+// fclAccessNodes contains REST access node endpoints used by FCL for the default networks.
+var fclAccessNodes = map[string]string{
+	config.EmulatorNetwork.Name: "http://localhost:8888",
+	config.TestnetNetwork.Name:  "https://rest-testnet.onflow.org",
+	config.MainnetNetwork.Name:  "https://rest-mainnet.onflow.org",
+}
+
+func (s txSnippet) javascript() (string, error) {
+	accessNode, ok := fclAccessNodes[s.network.Name]
+	if !ok {
+		accessNode = "<access node REST API>"
+	}
+
+	code := strings.NewReplacer("\\", "\\\\", "`", "\\`", "${", "\\${").Replace(s.code)
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, `import * as fcl from "@onflow/fcl"
+
+fcl.config().put("accessNode.api", %q)
+
+const transactionId = await fcl.mutate({
+  cadence: `+"`%s`"+`,
+  args: (arg, t) => [
+`, accessNode, code)
+
+	types, err := fclParameterTypes(s.code)
+	if err != nil {
+		return "", err
+	}
+	if len(types) != len(s.args) {
+		return "", fmt.Errorf("transaction requires %d arguments but %d were provided", len(types), len(s.args))
+	}
+
+	for i, arg := range s.args {
+		value, err := fclValue(arg)
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(&b, "    arg(%s, %s),\n", value, types[i])
+	}
+
+	_, _ = fmt.Fprintf(&b, `  ],
+  // account %s (0x%s) is used as proposer, payer and authorizer
+  proposer: fcl.authz,
+  payer: fcl.authz,
+  authorizations: [fcl.authz],
+  limit: %d,
+})
+
+const result = await fcl.tx(transactionId).onceSealed()
+console.log(result)
+`, s.signer.Name, s.signer.Address.Hex(), s.gasLimit)
+
+	return b.String(), nil
+}
+
+// fclParameterTypes returns the FCL type expressions for the transaction parameters declared in the code.
+func fclParameterTypes(code string) ([]string, error) {
+	program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	declarations := program.TransactionDeclarations()
+	if len(declarations) != 1 {
+		return nil, fmt.Errorf("can only support one transaction declaration per file, found %d", len(declarations))
+	}
+
+	types := make([]string, 0)
+	if declarations[0].ParameterList == nil {
+		return types, nil
+	}
+
+	for _, param := range declarations[0].ParameterList.Parameters {
+		t, err := fclType(param.TypeAnnotation.Type)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+
+	return types, nil
+}
+
+// fclType converts the Cadence type annotation to the FCL type expression.
+func fclType(t ast.Type) (string, error) {
+	switch typ := t.(type) {
+	case *ast.NominalType:
+		return fmt.Sprintf("t.%s", typ.String()), nil
+	case *ast.OptionalType:
+		inner, err := fclType(typ.Type)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("t.Optional(%s)", inner), nil
+	case *ast.VariableSizedType:
+		inner, err := fclType(typ.Type)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("t.Array(%s)", inner), nil
+	case *ast.ConstantSizedType:
+		inner, err := fclType(typ.Type)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("t.Array(%s)", inner), nil
+	case *ast.DictionaryType:
+		key, err := fclType(typ.KeyType)
+		if err != nil {
+			return "", err
+		}
+		value, err := fclType(typ.ValueType)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("t.Dictionary({ key: %s, value: %s })", key, value), nil
+	default:
+		return "", fmt.Errorf("argument of type %s is not supported for FCL export", t.String())
+	}
+}
+
+// fclValue converts the Cadence value to the JavaScript value expected by FCL.
+func fclValue(value cadence.Value) (string, error) {
+	switch v := value.(type) {
+	case cadence.Optional:
+		if v.Value == nil {
+			return "null", nil
+		}
+		return fclValue(v.Value)
+	case cadence.Bool:
+		return v.String(), nil
+	case cadence.String:
+		return strconv.Quote(string(v)), nil
+	case cadence.Address:
+		return strconv.Quote(v.String()), nil
+	case cadence.Array:
+		values := make([]string, 0, len(v.Values))
+		for _, element := range v.Values {
+			jsValue, err := fclValue(element)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, jsValue)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(values, ", ")), nil
+	case cadence.Dictionary:
+		pairs := make([]string, 0, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			key, err := fclValue(pair.Key)
+			if err != nil {
+				return "", err
+			}
+			element, err := fclValue(pair.Value)
+			if err != nil {
+				return "", err
+			}
+			pairs = append(pairs, fmt.Sprintf("{ key: %s, value: %s }", key, element))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(pairs, ", ")), nil
+	case cadence.Struct, cadence.Resource, cadence.Event, cadence.Contract, cadence.Enum:
+		return "", fmt.Errorf("argument %s is not supported for FCL export", value)
+	default:
+		// numbers and other primitive values are passed as strings
+		return strconv.Quote(value.String()), nil
+	}
+}
+
+// swiftChainIDs maps the default networks to flow-swift chain IDs.
+var swiftChainIDs = map[string]string{
+	config.EmulatorNetwork.Name: ".emulator",
+	config.TestnetNetwork.Name:  ".testnet",
+	config.MainnetNetwork.Name:  ".mainnet",
+}
+
+func (s txSnippet) swift() (string, error) {
+	args, err := s.jsonArgs()
+	if err != nil {
+		return "", err
+	}
+
+	chainID, ok := swiftChainIDs[s.network.Name]
+	if !ok {
+		chainID = fmt.Sprintf(".custom(name: %q, transport: .gRPC(.init(node: %q)))", s.network.Name, s.network.Host)
+	}
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, `import Flow
+
+flow.configure(chainID: %s)
+
+let signerAddress = Flow.Address(hex: %q)
+
+let arguments = try [
+`, chainID, "0x"+s.signer.Address.Hex())
+
+	for _, arg := range args {
+		delimiter := swiftRawDelimiter(arg)
+		_, _ = fmt.Fprintf(&b, "    %s\"%s\"%s,\n", delimiter, arg, delimiter)
+	}
+
+	_, _ = fmt.Fprintf(&b, `].map { try JSONDecoder().decode(Flow.Argument.self, from: Data($0.utf8)) }
+
+// signers must contain a FlowSigner implementation for the signer account key
+let txID = try await flow.sendTransaction(signers: signers) {
+    cadence {
+        %[4]s"""
+%[1]s
+        """%[4]s
+    }
+    arguments { arguments }
+    proposer { Flow.TransactionProposalKey(address: signerAddress, keyIndex: %[2]d) }
+    payer { signerAddress }
+    authorizers { signerAddress }
+    gasLimit { %[3]d }
+}
+
+let result = try await txID.onceSealed()
+print(result)
+`, indent(s.code, "        "), s.signer.Key.Index(), s.gasLimit, swiftRawDelimiter(s.code))
+
+	return b.String(), nil
+}
+
+// swiftRawDelimiter returns the delimiter of a Swift raw string literal containing the value,
+// so quotes, backslashes and interpolations in the value are not processed. The delimiter has one
+// more # than any sequence of # following a quote or a backslash in the value.
+func swiftRawDelimiter(value string) string {
+	longest := 0
+	for i := 0; i < len(value); i++ {
+		if value[i] != '"' && value[i] != '\\' {
+			continue
+		}
+
+		run := 0
+		for i+1+run < len(value) && value[i+1+run] == '#' {
+			run++
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	return strings.Repeat("#", longest+1)
+}
+
+// goStringLiteral returns a raw string literal of the value, or a quoted one if the value contains a backtick.
+func goStringLiteral(value string) string {
+	if strings.Contains(value, "`") {
+		return strconv.Quote(value)
+	}
+	return "`" + value + "`"
+}
+
+func indent(code string, prefix string) string {
+	lines := strings.Split(strings.TrimRight(code, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+type exportResult struct {
+	lang string
+	code string
+}
+
+func (r *exportResult) JSON() any {
+	return map[string]string{
+		"lang": r.lang,
+		"code": r.code,
+	}
+}
+
+func (r *exportResult) String() string {
+	return r.code
+}
+
+func (r *exportResult) Oneliner() string {
+	return r.code
+}
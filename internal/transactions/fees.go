@@ -0,0 +1,72 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// feesDeductedEvent is the suffix of the event emitted by the FlowFees contract once the
+// transaction fees are charged from the payer, the address prefix differs on each network.
+const feesDeductedEvent = ".FlowFees.FeesDeducted"
+
+// FeeSummary is a breakdown of the fees charged for a sealed transaction.
+//
+// The amount is the total fee in FLOW, the inclusion and execution efforts are the effort units
+// the fee was computed from, not FLOW amounts.
+type FeeSummary struct {
+	Amount               string `json:"amount"`
+	InclusionEffortUnits string `json:"inclusion_effort_units"`
+	ExecutionEffortUnits string `json:"execution_effort_units"`
+}
+
+// FeesFromResult extracts the fee summary from the fees deducted event in the transaction result,
+// it returns nil if the event is not present, which is the case if fees are disabled on the network.
+func FeesFromResult(result *flow.TransactionResult) *FeeSummary {
+	if result == nil {
+		return nil
+	}
+
+	for _, event := range result.Events {
+		if !strings.HasSuffix(event.Type, feesDeductedEvent) {
+			continue
+		}
+
+		values := flowkit.NewEvent(event).Values
+		return &FeeSummary{
+			Amount:               feeValue(values["amount"]),
+			InclusionEffortUnits: feeValue(values["inclusionEffort"]),
+			ExecutionEffortUnits: feeValue(values["executionEffort"]),
+		}
+	}
+
+	return nil
+}
+
+func feeValue(value cadence.Value) string {
+	if value == nil {
+		return "-"
+	}
+	return value.String()
+}
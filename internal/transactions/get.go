@@ -28,12 +28,14 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsGet struct {
 	Sealed  bool     `default:"true" flag:"sealed" info:"Wait for a sealed result"`
 	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
 	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output. Valid values: events."`
+	Refresh bool     `default:"false" flag:"refresh" info:"Fetch the transaction from the network even if a sealed result is cached"`
 }
 
 var getFlags = flagsGet{}
@@ -43,8 +45,13 @@ var getCommand = &command.Command{
 		Use:     "get <tx_id>",
 		Aliases: []string{"status"},
 		Short:   "Get the transaction by ID",
-		Example: "flow transactions get 07a8...b433",
-		Args:    cobra.ExactArgs(1),
+		Long: `Get the transaction by ID and its result.
+
+Transactions with a sealed result are cached in the project directory, so getting them again is instant
+and works offline. Use --refresh to fetch the transaction from the network again.`,
+		Example: `flow transactions get 07a8...b433
+flow transactions get 07a8...b433 --refresh`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &getFlags,
 	Run:   get,
@@ -52,14 +59,21 @@ var getCommand = &command.Command{
 
 func get(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	_ output.Logger,
-	_ flowkit.ReaderWriter,
+	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
 	id := flowsdk.HexToID(strings.TrimPrefix(args[0], "0x"))
 
-	tx, result, err := flow.GetTransactionByID(context.Background(), id, getFlags.Sealed)
+	cache := newTransactionCache(globalFlags.ConfigPaths, flow.Network(), readerWriter)
+	tx, result, err := cache.getTransaction(context.Background(), flow, id, getFlags.Sealed, getFlags.Refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	// the project is optional, it's only used to map error locations to the local sources
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
 	if err != nil {
 		return nil, err
 	}
@@ -69,5 +83,7 @@ func get(
 		tx:      tx,
 		include: getFlags.Include,
 		exclude: getFlags.Exclude,
+		sources: util.NewSourceMap(state, flow.Network()),
+		link:    command.NewExplorerLink(flow.Network(), globalFlags),
 	}, nil
 }
@@ -0,0 +1,340 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsList struct {
+	Address       string `default:"" flag:"address" info:"Address or account name of the account to list transactions for"`
+	Start         uint64 `flag:"start" info:"Start block height"`
+	End           uint64 `flag:"end" info:"End block height"`
+	Last          uint64 `default:"100" flag:"last" info:"Scan number of blocks relative to the last block. Ignored if the start flag is set"`
+	Workers       int    `default:"10" flag:"workers" info:"Number of workers to use when fetching blocks in parallel"`
+	Limit         int    `default:"50" flag:"limit" info:"Maximum number of transactions to list, continue from the reported next height to get the next page"`
+	Confirmations uint64 `default:"0" flag:"confirmations" info:"Only list transactions from blocks at least this number of blocks below the latest sealed block"`
+}
+
+var listFlags = flagsList{}
+
+var listCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "list [<directory>]",
+		Short: "List transactions of an account in a block range, or the transaction files in a directory",
+		Long: `List the transactions of the account in the block range, or when a directory is provided
+list the transaction files in the directory with the metadata declared in their leading comment.`,
+		Example: `flow transactions list --address 0x01cf0e2f2f715450 --last 1000
+flow transactions list --address 0x01cf0e2f2f715450 --start 11559500 --end 11559600 --network mainnet
+flow transactions list --address alice --last 100
+flow transactions list ./cadence/transactions`,
+		Args: cobra.MaximumNArgs(1),
+	},
+	Flags: &listFlags,
+	Run:   list,
+}
+
+// accountTransaction is a transaction the listed account took part in with the roles it had.
+type accountTransaction struct {
+	height uint64
+	tx     *flowsdk.Transaction
+	result *flowsdk.TransactionResult
+	roles  []string
+}
+
+type blockTransactions struct {
+	height       uint64
+	transactions []accountTransaction
+	err          error
+}
+
+func list(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if len(args) == 1 {
+		return listFiles(args[0], readerWriter)
+	}
+
+	if listFlags.Address == "" {
+		return nil, fmt.Errorf("address flag is required")
+	}
+	// account names can only be resolved with a project configuration
+	state, err := util.LoadOptional(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+	address, err := util.ResolveAddress(listFlags.Address, state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+	if listFlags.Workers < 1 || listFlags.Limit < 1 {
+		return nil, fmt.Errorf("workers and limit flags must be positive")
+	}
+
+	start := listFlags.Start
+	end := listFlags.End
+	if start == 0 && end == 0 {
+		latest, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{Latest: true})
+		if err != nil {
+			return nil, err
+		}
+		end = latest.Height
+
+		start = end - listFlags.Last
+		if end < listFlags.Last {
+			start = 0
+		}
+	} else if start == 0 || end == 0 {
+		return nil, fmt.Errorf("please provide either both start and end for range or only last flag")
+	}
+	if start > end {
+		return nil, fmt.Errorf("start height %d is after end height %d", start, end)
+	}
+
+	end, err = util.ConfirmedRange(flow, logger, start, end, listFlags.Confirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Scanning blocks %d to %d...", start, end))
+	defer logger.StopProgress()
+
+	result := &listResult{address: address, start: start, end: end}
+
+	// blocks are fetched in pages of worker size, so the scan stops close to where the limit is reached
+	pageSize := uint64(listFlags.Workers)
+	for height := start; height <= end; height += pageSize {
+		pageEnd := height + pageSize - 1
+		if pageEnd > end {
+			pageEnd = end
+		}
+
+		blocks, err := fetchBlockTransactions(flow, address, height, pageEnd, listFlags.Workers)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range blocks {
+			if len(result.transactions) >= listFlags.Limit {
+				result.next = block.height
+				return result, nil
+			}
+
+			result.transactions = append(result.transactions, block.transactions...)
+		}
+	}
+
+	return result, nil
+}
+
+// fetchBlockTransactions fetches blocks in the height range concurrently and returns
+// the transactions involving the address ordered by height.
+func fetchBlockTransactions(
+	flow flowkit.Services,
+	address flowsdk.Address,
+	start uint64,
+	end uint64,
+	workers int,
+) ([]blockTransactions, error) {
+	heights := make(chan uint64, workers)
+	results := make(chan blockTransactions)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range heights {
+				results <- blockTransactionsAt(flow, address, height)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(results)
+		wg.Wait()
+	}()
+
+	go func() {
+		defer close(heights)
+		for height := start; height <= end; height++ {
+			heights <- height
+		}
+	}()
+
+	blocks := make([]blockTransactions, 0, end-start+1)
+	var err error
+	for block := range results {
+		if block.err != nil && err == nil {
+			err = block.err
+		}
+		blocks = append(blocks, block)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].height < blocks[j].height
+	})
+
+	return blocks, nil
+}
+
+func blockTransactionsAt(flow flowkit.Services, address flowsdk.Address, height uint64) blockTransactions {
+	block, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{Height: height})
+	if err != nil {
+		return blockTransactions{height: height, err: fmt.Errorf("failed to get block at height %d: %w", height, err)}
+	}
+
+	txs, txResults, err := flow.GetTransactionsByBlockID(context.Background(), block.ID)
+	if err != nil {
+		return blockTransactions{height: height, err: fmt.Errorf("failed to get transactions of block at height %d: %w", height, err)}
+	}
+
+	found := blockTransactions{height: height}
+	for i, tx := range txs {
+		roles := transactionRoles(tx, address)
+		if len(roles) == 0 {
+			continue
+		}
+
+		var txResult *flowsdk.TransactionResult
+		if i < len(txResults) {
+			txResult = txResults[i]
+		}
+		found.transactions = append(found.transactions, accountTransaction{
+			height: height,
+			tx:     tx,
+			result: txResult,
+			roles:  roles,
+		})
+	}
+
+	return found
+}
+
+// transactionRoles returns the roles the address has in the transaction.
+func transactionRoles(tx *flowsdk.Transaction, address flowsdk.Address) []string {
+	roles := make([]string, 0)
+	if tx.Payer == address {
+		roles = append(roles, "payer")
+	}
+	if tx.ProposalKey.Address == address {
+		roles = append(roles, "proposer")
+	}
+	if slices.Contains(tx.Authorizers, address) {
+		roles = append(roles, "authorizer")
+	}
+
+	return roles
+}
+
+type listResult struct {
+	address      flowsdk.Address
+	start        uint64
+	end          uint64
+	next         uint64 // height to continue listing from, zero if the range was fully scanned
+	transactions []accountTransaction
+}
+
+func (r *listResult) JSON() any {
+	txs := make([]any, 0, len(r.transactions))
+	for _, tx := range r.transactions {
+		item := map[string]any{
+			"id":           tx.tx.ID().String(),
+			"block_height": tx.height,
+			"roles":        tx.roles,
+		}
+		if tx.result != nil {
+			item["status"] = tx.result.Status.String()
+			if tx.result.Error != nil {
+				item["error"] = tx.result.Error.Error()
+			}
+		}
+		txs = append(txs, item)
+	}
+
+	result := map[string]any{
+		"address":      r.address.String(),
+		"start":        r.start,
+		"end":          r.end,
+		"transactions": txs,
+	}
+	if r.next != 0 {
+		result["next"] = r.next
+	}
+
+	return result
+}
+
+func (r *listResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if len(r.transactions) == 0 {
+		_, _ = fmt.Fprintf(writer, "No transactions found for 0x%s in blocks %d to %d\n", r.address.Hex(), r.start, r.end)
+	} else {
+		_, _ = fmt.Fprintf(writer, "Height\tID\tRoles\tStatus\n")
+		for _, tx := range r.transactions {
+			status := "-"
+			if tx.result != nil {
+				status = tx.result.Status.String()
+				if tx.result.Error != nil {
+					status = fmt.Sprintf("%s %s", output.ErrorEmoji(), status)
+				}
+			}
+			_, _ = fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", tx.height, tx.tx.ID(), strings.Join(tx.roles, ", "), status)
+		}
+	}
+
+	if r.next != 0 {
+		_, _ = fmt.Fprintf(writer, "\nMore transactions may be available, continue with --start %d --end %d\n", r.next, r.end)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *listResult) Oneliner() string {
+	ids := make([]string, 0, len(r.transactions))
+	for _, tx := range r.transactions {
+		ids = append(ids, tx.tx.ID().String())
+	}
+
+	return strings.Join(ids, ", ")
+}
@@ -0,0 +1,219 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// maximumFeeScript returns the available balance of the payer and the fee of a transaction using the whole gas limit.
+const maximumFeeScript = `import FlowFees from 0xFLOWFEESADDRESS
+import FlowStorageFees from 0xFLOWSTORAGEFEESADDRESS
+
+pub fun main(payer: Address, executionEffort: UFix64): [UFix64] {
+	return [
+		FlowStorageFees.defaultTokenAvailableBalance(payer),
+		FlowFees.computeFees(inclusionEffort: 1.0, executionEffort: executionEffort)
+	]
+}
+`
+
+// roleSigners returns the keys signing the transaction by account address, when sent with the provided roles.
+func roleSigners(proposer *accounts.Account, payer *accounts.Account, authorizers []accounts.Account) map[flowsdk.Address][]int {
+	signers := make(map[flowsdk.Address][]int)
+	add := func(account *accounts.Account) {
+		for _, index := range signers[account.Address] {
+			if index == account.Key.Index() {
+				return
+			}
+		}
+		signers[account.Address] = append(signers[account.Address], account.Key.Index())
+	}
+
+	add(proposer)
+	add(payer)
+	for i := range authorizers {
+		add(&authorizers[i])
+	}
+
+	return signers
+}
+
+// signatureSigners returns the keys which signed the transaction by account address.
+func signatureSigners(tx *flowsdk.Transaction) map[flowsdk.Address][]int {
+	signers := make(map[flowsdk.Address][]int)
+	for _, sig := range append(tx.PayloadSignatures, tx.EnvelopeSignatures...) {
+		signers[sig.Address] = append(signers[sig.Address], sig.KeyIndex)
+	}
+
+	return signers
+}
+
+// preflight validates the transaction before it's sent so it fails early instead of on the network: the proposal key
+// must exist and not be revoked, the signing keys of the payer and each authorizer must reach the weight threshold,
+// and the payer must be able to pay the fee of a transaction using the whole gas limit.
+func preflight(flow flowkit.Services, tx *flowsdk.Transaction, signers map[flowsdk.Address][]int) error {
+	fetched := make(map[flowsdk.Address]*flowsdk.Account)
+	getAccount := func(address flowsdk.Address) (*flowsdk.Account, error) {
+		if account, ok := fetched[address]; ok {
+			return account, nil
+		}
+
+		account, err := flow.GetAccount(context.Background(), address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account 0x%s: %w", address.Hex(), err)
+		}
+		fetched[address] = account
+		return account, nil
+	}
+
+	proposer, err := getAccount(tx.ProposalKey.Address)
+	if err != nil {
+		return err
+	}
+	key := accountKey(proposer, tx.ProposalKey.KeyIndex)
+	if key == nil {
+		return fmt.Errorf(
+			"proposer account 0x%s has no key with index %d, set an existing key index for the proposer account in the configuration",
+			proposer.Address.Hex(),
+			tx.ProposalKey.KeyIndex,
+		)
+	}
+	if key.Revoked {
+		return fmt.Errorf(
+			"proposer key %d of account 0x%s is revoked, set a key index that isn't revoked for the proposer account in the configuration",
+			key.Index,
+			proposer.Address.Hex(),
+		)
+	}
+
+	roles := map[flowsdk.Address]string{}
+	for _, authorizer := range tx.Authorizers {
+		roles[authorizer] = "authorizer"
+	}
+	roles[tx.Payer] = "payer"
+
+	addresses := make([]flowsdk.Address, 0, len(roles))
+	for address := range roles {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i].Hex() < addresses[j].Hex() })
+
+	for _, address := range addresses {
+		account, err := getAccount(address)
+		if err != nil {
+			return err
+		}
+
+		weight := 0
+		for _, index := range signers[address] {
+			key := accountKey(account, index)
+			if key == nil {
+				return fmt.Errorf("%s account 0x%s has no key with index %d used for signing", roles[address], address.Hex(), index)
+			}
+			if key.Revoked {
+				return fmt.Errorf(
+					"%s account 0x%s signs with key %d which is revoked, sign with a key that isn't revoked",
+					roles[address],
+					address.Hex(),
+					index,
+				)
+			}
+			weight += key.Weight
+		}
+
+		if weight < flowsdk.AccountKeyWeightThreshold {
+			return fmt.Errorf(
+				"%s account 0x%s signs with keys of total weight %d but at least %d is required, sign with additional keys of the account",
+				roles[address],
+				address.Hex(),
+				weight,
+				flowsdk.AccountKeyWeightThreshold,
+			)
+		}
+	}
+
+	return checkPayerBalance(flow, tx.Payer, tx.GasLimit)
+}
+
+// accountKey returns the account key with the index or nil if it doesn't exist.
+func accountKey(account *flowsdk.Account, index int) *flowsdk.AccountKey {
+	for _, key := range account.Keys {
+		if key.Index == index {
+			return key
+		}
+	}
+
+	return nil
+}
+
+// checkPayerBalance fails if the payer can't pay the fee of the transaction using the whole gas limit.
+// The check is skipped on the emulator which doesn't charge fees by default and on networks without known fee contracts.
+func checkPayerBalance(flow flowkit.Services, payer flowsdk.Address, gasLimit uint64) error {
+	chain, err := util.GetAddressNetwork(payer)
+	if err != nil || chain == flowsdk.Emulator {
+		return nil
+	}
+
+	executionEffort, err := cadence.NewUFix64(fmt.Sprintf("%d.0", gasLimit))
+	if err != nil {
+		return err
+	}
+
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: []byte(tmpl.ReplaceAddresses(maximumFeeScript, util.EnvFromNetwork(chain))),
+			Args: []cadence.Value{cadence.NewAddress(payer), executionEffort},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get the payer balance: %w", err)
+	}
+
+	values, ok := value.(cadence.Array)
+	if !ok || len(values.Values) != 2 {
+		return fmt.Errorf("failed to get the payer balance: unexpected result %s", value)
+	}
+	available, _ := values.Values[0].(cadence.UFix64)
+	fee, _ := values.Values[1].(cadence.UFix64)
+
+	if available < fee {
+		return fmt.Errorf(
+			"payer account 0x%s has an available balance of %s FLOW, lower than the maximum fee of %s FLOW for the gas limit %d, fund the payer account or lower the gas limit",
+			payer.Hex(),
+			available,
+			fee,
+			gasLimit,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-emulator/convert"
+	"github.com/onflow/flow-emulator/emulator"
+	"github.com/onflow/flow-emulator/storage/remote"
+	"github.com/onflow/flow-emulator/storage/sqlite"
+	"github.com/onflow/flow-emulator/types"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	flowgo "github.com/onflow/flow-go/model/flow"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsReplay struct {
+	Include []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+}
+
+var replayFlags = flagsReplay{}
+
+var replayCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "replay <tx_id>",
+		Short:   "Replay a sealed network transaction on a local fork of the network state",
+		Example: "flow transactions replay 07a8...b433 --network mainnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &replayFlags,
+	Run:   replay,
+}
+
+// replayExecutor executes the transaction on a fork of the chain state at the provided height.
+var replayExecutor = executeOnFork
+
+func replay(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	id := flowsdk.HexToID(strings.TrimPrefix(args[0], "0x"))
+
+	logger.StartProgress("Fetching transaction...")
+	tx, result, err := flow.GetTransactionByID(context.Background(), id, true)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := util.GetAddressNetwork(tx.Payer)
+	if err != nil {
+		return nil, err
+	}
+	if chain != flowsdk.Mainnet && chain != flowsdk.Testnet {
+		return nil, fmt.Errorf("replaying transactions is only supported for mainnet and testnet, transaction belongs to %s", chain)
+	}
+	if result.BlockHeight == 0 {
+		return nil, fmt.Errorf("transaction %s has no block height", id)
+	}
+
+	// the state is forked at the block before the transaction block, any transactions executed
+	// before this one within the same block are not replayed
+	logger.Info(fmt.Sprintf("Replaying transaction on %s fork at block height %d\n", chain, result.BlockHeight-1))
+	replayed, err := replayExecutor(flowgo.ChainID(chain), result.BlockHeight-1, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay transaction: %w", err)
+	}
+
+	return &replayResult{
+		tx:       tx,
+		original: result,
+		replayed: replayed,
+		include:  replayFlags.Include,
+		exclude:  replayFlags.Exclude,
+	}, nil
+}
+
+// executeOnFork creates an emulator backed by the archive node of the chain and executes the transaction
+// with signature validation disabled, the execution trace is written to stderr.
+func executeOnFork(chainID flowgo.ChainID, height uint64, tx *flowsdk.Transaction) (*types.TransactionResult, error) {
+	store, err := sqlite.New(sqlite.InMemory)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := remote.New(store, remote.WithChainID(chainID))
+	if err != nil {
+		return nil, err
+	}
+
+	err = provider.SetBlockHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	traceLogger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).
+		Level(zerolog.DebugLevel).
+		With().
+		Timestamp().
+		Logger()
+
+	blockchain, err := emulator.New(
+		emulator.WithStore(provider),
+		emulator.WithChainID(chainID),
+		emulator.WithServerLogger(traceLogger),
+		emulator.WithTransactionValidationEnabled(false),
+		emulator.WithTransactionFeesEnabled(true),
+		emulator.WithStorageLimitEnabled(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = blockchain.AddTransaction(*convert.SDKTransactionToFlow(*tx))
+	if err != nil {
+		return nil, err
+	}
+
+	_, results, err := blockchain.ExecuteAndCommitBlock()
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("expected a single transaction result, got %d", len(results))
+	}
+
+	return results[0], nil
+}
+
+type replayResult struct {
+	tx       *flowsdk.Transaction
+	original *flowsdk.TransactionResult
+	replayed *types.TransactionResult
+	include  []string
+	exclude  []string
+}
+
+func (r *replayResult) JSON() any {
+	replayEvents := make([]any, 0, len(r.replayed.Events))
+	for _, event := range r.replayed.Events {
+		replayEvents = append(replayEvents, map[string]any{
+			"index": event.EventIndex,
+			"type":  event.Type,
+			"values": json.RawMessage(
+				event.Payload,
+			),
+		})
+	}
+
+	replayed := map[string]any{
+		"succeeded":        r.replayed.Succeeded(),
+		"computation_used": r.replayed.ComputationUsed,
+		"memory_estimate":  r.replayed.MemoryEstimate,
+		"logs":             r.replayed.Logs,
+		"events":           replayEvents,
+	}
+	if r.replayed.Error != nil {
+		replayed["error"] = r.replayed.Error.Error()
+	}
+
+	result := map[string]any{
+		"id":           r.tx.ID().String(),
+		"block_height": r.original.BlockHeight,
+		"status":       r.original.Status.String(),
+		"replay":       replayed,
+	}
+	if r.original.Error != nil {
+		result["error"] = r.original.Error.Error()
+	}
+
+	return result
+}
+
+func (r *replayResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.tx.ID())
+	_, _ = fmt.Fprintf(writer, "Block Height\t%d\n", r.original.BlockHeight)
+	_, _ = fmt.Fprintf(writer, "Original Status\t%s\n", r.original.Status)
+	if r.original.Error != nil {
+		_, _ = fmt.Fprintf(writer, "Original Error\t%s\n", r.original.Error.Error())
+	}
+
+	statusBadge := output.OkEmoji()
+	status := "SUCCEEDED"
+	if !r.replayed.Succeeded() {
+		statusBadge = output.ErrorEmoji()
+		status = "FAILED"
+	}
+	_, _ = fmt.Fprintf(writer, "\nReplay Status\t%s %s\n", statusBadge, status)
+	_, _ = fmt.Fprintf(writer, "Computation Used\t%d\n", r.replayed.ComputationUsed)
+	_, _ = fmt.Fprintf(writer, "Memory Estimate\t%d\n", r.replayed.MemoryEstimate)
+
+	if r.replayed.Error != nil {
+		_, _ = fmt.Fprintf(writer, "\n%s Replay Error \n%s\n", output.ErrorEmoji(), r.replayed.Error.Error())
+	}
+
+	if len(r.replayed.Logs) > 0 {
+		_, _ = fmt.Fprintf(writer, "\nLogs:\n")
+		for _, log := range r.replayed.Logs {
+			_, _ = fmt.Fprintf(writer, "    %s\n", log)
+		}
+	} else {
+		_, _ = fmt.Fprintf(writer, "\nLogs:\tNone\n")
+	}
+
+	if !command.ContainsFlag(r.exclude, "events") {
+		e := events.EventResult{Events: r.replayed.Events}
+		eventsOutput := e.String()
+		if eventsOutput == "" {
+			eventsOutput = "None"
+		}
+		_, _ = fmt.Fprintf(writer, "\nEvents:\t %s\n", eventsOutput)
+	}
+
+	if command.ContainsFlag(r.include, "code") {
+		_, _ = fmt.Fprintf(writer, "\nCode\n\n%s\n", r.tx.Script)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *replayResult) Oneliner() string {
+	return fmt.Sprintf(
+		"ID: %s, Original Status: %s, Replay Succeeded: %t, Computation Used: %d",
+		r.tx.ID(), r.original.Status, r.replayed.Succeeded(), r.replayed.ComputationUsed,
+	)
+}
@@ -0,0 +1,186 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSchedule struct {
+	At       string   `default:"" flag:"at" info:"Time to send the transaction at, in RFC3339 format (2006-01-02T15:04:05Z07:00) or local time (2006-01-02 15:04:05)"`
+	After    string   `default:"" flag:"after" info:"Duration to wait before sending the transaction (e.g. 10m, 1h30m)"`
+	ArgsJSON string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	Signer   string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and authorizer"`
+	GasLimit uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude  []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+}
+
+var scheduleFlags = flagsSchedule{}
+
+var scheduleCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "schedule <code filename> [<argument> <argument> ...]",
+		Short: "Send a transaction at the specified time",
+		Long: `Send a transaction at the specified time, the command keeps running in the foreground until the transaction is sent.
+The reference block is fetched just before sending, so the transaction doesn't expire while waiting.`,
+		Example: `flow transactions schedule tx.cdc "Hello" --after 30m
+flow transactions schedule tx.cdc --at 2023-07-01T12:00:00Z --signer alice`,
+		Args: cobra.MinimumNArgs(1),
+	},
+	Flags: &scheduleFlags,
+	RunS:  schedule,
+}
+
+// timeNow and waitUntil can be replaced in tests.
+var (
+	timeNow   = time.Now
+	waitUntil = func(ctx context.Context, at time.Time) error {
+		timer := time.NewTimer(time.Until(at))
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+)
+
+func schedule(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	at, err := scheduledTime(scheduleFlags.At, scheduleFlags.After, timeNow())
+	if err != nil {
+		return nil, err
+	}
+
+	signerName := scheduleFlags.Signer
+	if signerName == "" {
+		signerName = state.Config().Emulators.Default().ServiceAccount
+	}
+	signer, err := util.AccountByName(state, signerName)
+	if err != nil {
+		return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
+	}
+
+	codeFilename := args[0]
+	code, err := state.ReadFile(codeFilename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	var transactionArgs []cadence.Value
+	if scheduleFlags.ArgsJSON != "" {
+		transactionArgs, err = arguments.ParseJSON(scheduleFlags.ArgsJSON)
+	} else {
+		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, codeFilename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	script := flowkit.Script{Code: code, Args: transactionArgs, Location: codeFilename}
+	roles := transactions.SingleAccountRole(*signer)
+
+	// build the transaction now to report any errors before waiting
+	_, err = flow.BuildTransaction(
+		context.Background(),
+		roles.AddressRoles(),
+		signer.Key.Index(),
+		script,
+		scheduleFlags.GasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	logger.Info(fmt.Sprintf("Transaction scheduled to be sent at %s, press Ctrl+C to cancel", at.Format(time.RFC3339)))
+	err = waitUntil(ctx, at)
+	if err != nil {
+		return nil, fmt.Errorf("scheduled transaction was not sent: %w", err)
+	}
+
+	// sending builds the transaction again with the latest reference block
+	tx, txResult, err := flow.SendTransaction(context.Background(), roles, script, scheduleFlags.GasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionResult{
+		result:  txResult,
+		tx:      tx,
+		include: scheduleFlags.Include,
+		exclude: scheduleFlags.Exclude,
+		link:    command.NewExplorerLink(flow.Network(), globalFlags),
+	}, nil
+}
+
+// scheduledTime returns the time to send the transaction at from either the at or the after flag value.
+func scheduledTime(at string, after string, now time.Time) (time.Time, error) {
+	if (at == "") == (after == "") {
+		return time.Time{}, fmt.Errorf("provide either the at or the after flag")
+	}
+
+	if after != "" {
+		duration, err := time.ParseDuration(after)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid after duration %s: %w", after, err)
+		}
+		if duration < 0 {
+			return time.Time{}, fmt.Errorf("after duration must not be negative")
+		}
+		return now.Add(duration), nil
+	}
+
+	scheduled, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		scheduled, err = time.ParseInLocation("2006-01-02 15:04:05", at, now.Location())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid at time %s, use RFC3339 or 2006-01-02 15:04:05 format", at)
+		}
+	}
+	if scheduled.Before(now) {
+		return time.Time{}, fmt.Errorf("at time %s is in the past", at)
+	}
+
+	return scheduled, nil
+}
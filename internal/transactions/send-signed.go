@@ -33,8 +33,10 @@ import (
 )
 
 type flagsSendSigned struct {
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
-	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	Include      []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
+	Exclude      []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	ExpiryBlocks uint64   `default:"50" flag:"expiry-blocks" info:"Warn when the transaction expires within this number of blocks, 0 disables the check"`
+	SkipChecks   bool     `default:"false" flag:"skip-checks" info:"Skip validating the proposal key, signature weights and payer balance before sending"`
 }
 
 var sendSignedFlags = flagsSendSigned{}
@@ -69,6 +71,20 @@ func sendSigned(
 		return nil, err
 	}
 
+	if sendSignedFlags.ExpiryBlocks > 0 {
+		_, err = checkExpiry(flow, logger, tx, sendSignedFlags.ExpiryBlocks, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !sendSignedFlags.SkipChecks {
+		err = preflight(flow, tx.FlowTransaction(), signatureSigners(tx.FlowTransaction()))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction()) {
 		return nil, fmt.Errorf("transaction was not approved for sending")
 	}
@@ -86,5 +102,6 @@ func sendSigned(
 		tx:      sentTx,
 		include: sendSignedFlags.Include,
 		exclude: sendSignedFlags.Exclude,
+		link:    command.NewExplorerLink(flow.Network(), globalFlags),
 	}, nil
 }
@@ -21,9 +21,12 @@ package transactions
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/accounts"
@@ -31,27 +34,49 @@ import (
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/payers"
+	"github.com/onflow/flow-cli/internal/scripts"
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsSend struct {
-	ArgsJSON    string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	Signer      string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and suthorizer"`
-	Proposer    string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer"`
-	Payer       string   `default:"" flag:"payer" info:"Account name from configuration used as payer"`
-	Authorizers []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
-	Include     []string `default:"" flag:"include" info:"Fields to include in the output"`
-	Exclude     []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
-	GasLimit    uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	ArgsJSON     string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format, @file to read them from a file or - from the standard input"`
+	Signer       string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and suthorizer"`
+	Proposer     string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer"`
+	Payer        string   `default:"" flag:"payer" info:"Account name from configuration used as payer, the next account of the network payer pool proposes and pays if not provided and a pool is configured"`
+	Authorizers  []string `default:"" flag:"authorizer" info:"Name of an account from configuration used as authorizer, repeat the flag or separate names with commas for multiple authorizers in the order of the prepare parameters"`
+	Include      []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude      []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	GasLimit     uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Template     string   `default:"" flag:"template" info:"Name of a built-in transaction template to send instead of a code file"`
+	Param        []string `default:"" flag:"param" info:"Template parameter keyed by name as name=value or name:Type:value, can be provided multiple times"`
+	Arg          []string `default:"" flag:"arg" info:"Argument value, or argument keyed by parameter name as name=value or name:Type:value, can be provided multiple times"`
+	ArgFromEvent []string `default:"" flag:"arg-from-event" info:"Argument value taken from a previous transaction event in the format txID:EventType.field, appended after other arguments, or name=txID:EventType.field to key it by parameter name"`
+	Trace        bool     `default:"false" flag:"trace" info:"Print the Cadence functions called by the transaction, requires the emulator started with --coverage-reporting"`
+	SkipChecks   bool     `default:"false" flag:"skip-checks" info:"Skip validating the proposal key, signer weights and payer balance before sending"`
 }
 
 var sendFlags = flagsSend{}
 
+var argumentsPrompt = util.ArgumentsPrompt
+
+// stdinIsTerminal reports whether arguments can be prompted for, it's false when running in CI or with piped input.
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
 var sendCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "send <code filename> [<argument> <argument> ...]",
-		Short:   "Send a transaction",
-		Args:    cobra.MinimumNArgs(1),
-		Example: `flow transactions send tx.cdc "Hello world"`,
+		Use:   "send <code filename | -> [<argument> <argument> ...]",
+		Short: "Send a transaction",
+		Args:  cobra.ArbitraryArgs,
+		Example: `flow transactions send tx.cdc "Hello world"
+flow transactions send --template transfer-flow --param amount=10.0 --param to=0x01cf0e2f2f715450
+flow transactions send transfer.cdc --arg amount=10.0 --arg to:Address:0x01cf0e2f2f715450
+flow transactions send swap.cdc --authorizer alice --authorizer bob --payer alice
+generate-transaction | flow transactions send - --args-json @args.json
+flow transactions send transfer.cdc 0x01cf0e2f2f715450 --arg-from-event 'a8c7...f3d1:A.f8d6e0586b0a20c7.NFT.Minted.id'`,
 	},
 	Flags: &sendFlags,
 	RunS:  send,
@@ -59,17 +84,19 @@ var sendCommand = &command.Command{
 
 func send(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	_ output.Logger,
 	flow flowkit.Services,
 	state *flowkit.State,
 ) (result command.Result, err error) {
-	codeFilename := args[0]
+	if sendFlags.Template == "" && len(args) == 0 {
+		return nil, fmt.Errorf("requires a code filename or the template flag")
+	}
 
 	proposerName := sendFlags.Proposer
 	var proposer *accounts.Account
 	if proposerName != "" {
-		proposer, err = state.Accounts().ByName(proposerName)
+		proposer, err = util.AccountByName(state, proposerName)
 		if err != nil {
 			return nil, fmt.Errorf("proposer account: [%s] doesn't exists in configuration", proposerName)
 		}
@@ -78,7 +105,7 @@ func send(
 	payerName := sendFlags.Payer
 	var payer *accounts.Account
 	if payerName != "" {
-		payer, err = state.Accounts().ByName(payerName)
+		payer, err = util.AccountByName(state, payerName)
 		if err != nil {
 			return nil, fmt.Errorf("payer account: [%s] doesn't exists in configuration", payerName)
 		}
@@ -86,7 +113,7 @@ func send(
 
 	var authorizers []accounts.Account
 	for _, authorizerName := range sendFlags.Authorizers {
-		authorizer, err := state.Accounts().ByName(authorizerName)
+		authorizer, err := util.AccountByName(state, authorizerName)
 		if err != nil {
 			return nil, fmt.Errorf("authorizer account: [%s] doesn't exists in configuration", authorizerName)
 		}
@@ -103,7 +130,7 @@ func send(
 		if proposer != nil || payer != nil || len(authorizers) > 0 {
 			return nil, fmt.Errorf("signer flag cannot be combined with payer/proposer/authorizer flags")
 		}
-		signer, err := state.Accounts().ByName(signerName)
+		signer, err := util.AccountByName(state, signerName)
 		if err != nil {
 			return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
 		}
@@ -112,40 +139,232 @@ func send(
 		authorizers = append(authorizers, *signer)
 	}
 
-	code, err := state.ReadFile(codeFilename)
+	// the first authorizer proposes and pays for the transaction if those roles aren't provided
+	if len(authorizers) > 0 {
+		if proposer == nil {
+			proposer = &authorizers[0]
+		}
+		if payer == nil {
+			payer = &authorizers[0]
+		}
+	}
+
+	// a payer from the pool also proposes, unless the proposer is provided, so the sequence number it tracks is used
+	pool, err := payers.Load(state, flow.Network(), globalFlags.ConfigPaths)
 	if err != nil {
-		return nil, fmt.Errorf("error loading transaction file: %w", err)
+		return nil, err
+	}
+	var poolSequence *uint64
+	submitted := false
+	if pool != nil && sendFlags.Payer == "" {
+		pooled, sequence, err := pool.Reserve(flow)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if !submitted {
+				_ = pool.Release(pooled)
+			}
+		}()
+
+		payer = pooled
+		if sendFlags.Proposer == "" {
+			proposer = pooled
+			poolSequence = &sequence
+		}
+	}
+
+	if proposer == nil || payer == nil {
+		return nil, fmt.Errorf("proposer and payer flags are required when no authorizer is provided")
+	}
+
+	var code []byte
+	var codeFilename string
+	var argValues []string
+	if sendFlags.Template != "" {
+		code, err = templateCode(sendFlags.Template, payer)
+		if err != nil {
+			return nil, err
+		}
+		argValues = args
+	} else {
+		code, err = util.ReadCode(args[0], sendFlags.ArgsJSON, state.ReaderWriter())
+		if err != nil {
+			return nil, fmt.Errorf("error loading transaction file: %w", err)
+		}
+		if args[0] != util.StdinFilename {
+			codeFilename = args[0]
+		}
+		argValues = args[1:]
+	}
+
+	var eventValues []string
+	if len(sendFlags.ArgFromEvent) > 0 {
+		eventValues, err = events.ArgumentsFromEvents(flow, sendFlags.ArgFromEvent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var parameters []arguments.Parameter
+	if sendFlags.ArgsJSON == "" {
+		parameters, err = arguments.Parameters(code, codeFilename)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing transaction parameters: %w", err)
+		}
+	}
+
+	// arguments keyed by the parameter name are matched to declared parameters instead of the position
+	namedValues := append(append(append([]string{}, sendFlags.Arg...), sendFlags.Param...), eventValues...)
+	namedArgs := arguments.AreNamed(namedValues, parameters)
+	if !namedArgs && len(sendFlags.Param) > 0 {
+		return nil, fmt.Errorf("parameters must be keyed by a parameter name declared by the transaction as name=value")
+	}
+	if !namedArgs {
+		for _, ref := range sendFlags.ArgFromEvent {
+			if events.IsNamedReference(ref) {
+				return nil, fmt.Errorf("arguments keyed by parameter name can not be combined with positional arguments")
+			}
+		}
+		argValues = append(argValues, sendFlags.Arg...)
+		argValues = append(argValues, eventValues...)
+	}
+
+	// ask for the arguments if the transaction declares parameters but none were provided
+	if sendFlags.Template == "" && sendFlags.ArgsJSON == "" && len(argValues) == 0 && !namedArgs &&
+		!globalFlags.Yes && stdinIsTerminal() {
+		if len(parameters) > 0 {
+			metadata, err := util.ParseMetadata(code)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing transaction metadata: %w", err)
+			}
+			var descriptions map[string]string
+			if metadata != nil {
+				descriptions = metadata.Parameters
+			}
+
+			argValues, err = argumentsPrompt(parameters, descriptions)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	var transactionArgs []cadence.Value
 	if sendFlags.ArgsJSON != "" {
-		transactionArgs, err = arguments.ParseJSON(sendFlags.ArgsJSON)
+		transactionArgs, err = util.ParseArgsJSON(sendFlags.ArgsJSON, state.ReaderWriter())
+	} else if namedArgs {
+		if len(argValues) > 0 {
+			return nil, fmt.Errorf("arguments keyed by parameter name can not be combined with positional arguments")
+		}
+		transactionArgs, err = arguments.ParseNamed(namedValues, code, codeFilename)
 	} else {
-		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, codeFilename)
+		transactionArgs, err = arguments.ParseWithoutType(argValues, code, codeFilename)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
 	}
 
-	tx, txResult, err := flow.SendTransaction(
-		context.Background(),
-		transactions.AccountRoles{
-			Proposer:    *proposer,
-			Authorizers: authorizers,
-			Payer:       *payer,
-		},
-		flowkit.Script{Code: code, Args: transactionArgs, Location: codeFilename},
-		sendFlags.GasLimit,
-	)
+	if !sendFlags.SkipChecks {
+		roles := flowsdk.NewTransaction().
+			SetProposalKey(proposer.Address, proposer.Key.Index(), 0).
+			SetPayer(payer.Address).
+			SetGasLimit(sendFlags.GasLimit)
+		for _, authorizer := range authorizers {
+			roles.AddAuthorizer(authorizer.Address)
+		}
+
+		err = preflight(flow, roles, roleSigners(proposer, payer, authorizers))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var trace *scripts.EmulatorTrace
+	if sendFlags.Trace {
+		trace, err = scripts.StartEmulatorTrace(flow.Network())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roles := transactions.AccountRoles{
+		Proposer:    *proposer,
+		Authorizers: authorizers,
+		Payer:       *payer,
+	}
+	script := flowkit.Script{Code: code, Args: transactionArgs, Location: codeFilename}
 
+	var tx *flowsdk.Transaction
+	var txResult *flowsdk.TransactionResult
+	if poolSequence != nil {
+		signed, err := signWithSequence(flow, roles, script, sendFlags.GasLimit, *poolSequence)
+		if err != nil {
+			return nil, err
+		}
+		tx, txResult, err = flow.SendSignedTransaction(context.Background(), signed)
+		// the sequence number is used once the transaction is submitted, even if waiting for its result failed
+		submitted = tx != nil || transactionSubmitted(flow, signed.FlowTransaction().ID())
+	} else {
+		tx, txResult, err = flow.SendTransaction(context.Background(), roles, script, sendFlags.GasLimit)
+		submitted = tx != nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &transactionResult{
+	sent := &transactionResult{
 		result:  txResult,
 		tx:      tx,
 		include: sendFlags.Include,
 		exclude: sendFlags.Exclude,
-	}, nil
+		sources: util.NewSourceMap(state, flow.Network()),
+		link:    command.NewExplorerLink(flow.Network(), globalFlags),
+	}
+
+	if trace != nil {
+		return trace.Collect(sent, flow, script)
+	}
+
+	return sent, nil
+}
+
+// signWithSequence builds and signs the transaction proposed with the sequence number instead of the
+// sequence number of the proposal key on chain, which is behind while previous transactions are pending.
+func signWithSequence(
+	flow flowkit.Services,
+	roles transactions.AccountRoles,
+	script flowkit.Script,
+	gasLimit uint64,
+	sequence uint64,
+) (*transactions.Transaction, error) {
+	tx, err := flow.BuildTransaction(
+		context.Background(),
+		roles.AddressRoles(),
+		roles.Proposer.Key.Index(),
+		script,
+		gasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	tx.FlowTransaction().SetProposalKey(roles.Proposer.Address, roles.Proposer.Key.Index(), sequence)
+
+	for _, signer := range roles.Signers() {
+		if err := tx.SetSigner(signer); err != nil {
+			return nil, err
+		}
+		tx, err = tx.Sign()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
+// transactionSubmitted returns whether the network received the transaction.
+func transactionSubmitted(flow flowkit.Services, id flowsdk.Identifier) bool {
+	tx, _, err := flow.GetTransactionByID(context.Background(), id, false)
+	return err == nil && tx != nil
 }
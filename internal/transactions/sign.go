@@ -44,6 +44,8 @@ type flagsSign struct {
 	Signer        []string `default:"emulator-account" flag:"signer" info:"name of a single or multiple comma-separated accounts used to sign"`
 	Include       []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
 	FromRemoteUrl string   `default:"" flag:"from-remote-url" info:"server URL where RLP can be fetched, signed RLP will be posted back to remote URL."`
+	ExpiryBlocks  uint64   `default:"0" flag:"expiry-blocks" info:"Warn when the transaction expires within this number of blocks, checked using the network, 0 disables the check"`
+	Refresh       bool     `default:"false" flag:"refresh-reference-block" info:"Refresh the reference block of an unsigned transaction expiring within the --expiry-blocks"`
 }
 
 var signFlags = flagsSign{}
@@ -62,7 +64,7 @@ var signCommand = &command.Command{
 func sign(
 	args []string,
 	globalFlags command.GlobalFlags,
-	_ output.Logger,
+	logger output.Logger,
 	flow flowkit.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
@@ -99,9 +101,23 @@ func sign(
 		return nil, err
 	}
 
+	if signFlags.Refresh && signFlags.ExpiryBlocks == 0 {
+		return nil, fmt.Errorf("provide the number of blocks using the --expiry-blocks flag to refresh the reference block")
+	}
+
+	if signFlags.ExpiryBlocks > 0 {
+		refreshed, err := checkExpiry(flow, logger, tx, signFlags.ExpiryBlocks, signFlags.Refresh)
+		if err != nil {
+			return nil, err
+		}
+		if refreshed {
+			payload = []byte(hex.EncodeToString(tx.FlowTransaction().Encode()))
+		}
+	}
+
 	// validate all signers
 	for _, signerName := range signFlags.Signer {
-		signer, err := state.Accounts().ByName(signerName)
+		signer, err := util.AccountByName(state, signerName)
 		if err != nil {
 			return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
 		}
@@ -144,12 +160,16 @@ func sign(
 
 // getRLPTransaction payload from a remote server.
 func getRLPTransaction(rlpUrl string) ([]byte, error) {
-	client := http.Client{
-		CheckRedirect: func(r *http.Request, via []*http.Request) error {
-			r.URL.Opaque = r.URL.Path
-			return nil
-		},
+	options := util.DefaultHTTPOptions
+	options.CheckRedirect = func(r *http.Request, via []*http.Request) error {
+		r.URL.Opaque = r.URL.Path
+		return nil
 	}
+	client, err := util.NewHTTPClient(options)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := client.Get(rlpUrl)
 	if err != nil {
 		return nil, err
@@ -166,11 +186,16 @@ func getRLPTransaction(rlpUrl string) ([]byte, error) {
 // postRLPTransaction signed payload to a remote server.
 func postRLPTransaction(rlpUrl string, tx *flowsdk.Transaction) error {
 	signedRlp := hex.EncodeToString(tx.Encode())
-	resp, err := http.Post(rlpUrl, "application/text", bytes.NewBufferString(signedRlp))
+	client, err := util.NewHTTPClient(util.DefaultHTTPOptions)
+	if err != nil {
+		return err
+	}
 
+	resp, err := client.Post(rlpUrl, "application/text", bytes.NewBufferString(signedRlp))
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("error posting signed RLP")
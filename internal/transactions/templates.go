@@ -0,0 +1,78 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/templates"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:              "templates",
+	Short:            "Discover built-in transaction templates",
+	TraverseChildren: true,
+}
+
+var templatesListCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:        "list",
+		Short:      "List built-in transaction templates",
+		Example:    "flow transactions templates list",
+		Args:       cobra.NoArgs,
+		Deprecated: "use 'flow templates list --kind transaction' instead",
+	},
+	Flags: &struct{}{},
+	Run:   listTemplates,
+}
+
+func init() {
+	templatesListCommand.AddToParent(templatesCmd)
+}
+
+func listTemplates(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	return templates.List(templates.KindTransaction), nil
+}
+
+// templateCode returns the code of the named built-in transaction template using core contract addresses
+// of the network the payer account belongs to.
+func templateCode(name string, payer *accounts.Account) ([]byte, error) {
+	template, err := templates.ByName(name, templates.KindTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := util.GetAddressNetwork(payer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return template.Source(chain), nil
+}
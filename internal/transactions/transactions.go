@@ -26,6 +26,8 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/events"
@@ -46,6 +48,11 @@ func init() {
 	buildCommand.AddToParent(Cmd)
 	sendSignedCommand.AddToParent(Cmd)
 	decodeCommand.AddToParent(Cmd)
+	Cmd.AddCommand(templatesCmd)
+	exportCommand.AddToParent(Cmd)
+	replayCommand.AddToParent(Cmd)
+	scheduleCommand.AddToParent(Cmd)
+	listCommand.AddToParent(Cmd)
 }
 
 type transactionResult struct {
@@ -53,6 +60,10 @@ type transactionResult struct {
 	tx      *flow.Transaction
 	include []string
 	exclude []string
+	decoded bool
+	// sources maps locations of deployed contracts in the transaction error to the local sources.
+	sources *util.SourceMap
+	link    command.ExplorerLink
 }
 
 func (r *transactionResult) JSON() any {
@@ -79,11 +90,30 @@ func (r *transactionResult) JSON() any {
 		}
 		result["events"] = txEvents
 
+		if fees := FeesFromResult(r.result); fees != nil {
+			result["fees"] = fees
+		}
+
+		if created, err := flowkit.CreatedAccounts(r.result); err == nil && len(created) > 0 {
+			accounts := make([]map[string]string, 0, len(created))
+			for _, account := range created {
+				accounts = append(accounts, map[string]string{
+					"address":       "0x" + account.Address.Hex(),
+					"transactionId": account.TransactionID.String(),
+				})
+			}
+			result["createdAccounts"] = accounts
+		}
+
 		if r.result.Error != nil {
 			result["error"] = r.result.Error.Error()
 		}
 	}
 
+	if link := r.link.Included(config.ExplorerTransaction, r.tx.ID().String()); link != "" {
+		result["link"] = link
+	}
+
 	return result
 }
 
@@ -95,7 +125,7 @@ func (r *transactionResult) String() string {
 		_, _ = fmt.Fprintf(writer, "Block ID\t%s\n", r.result.BlockID)
 		_, _ = fmt.Fprintf(writer, "Block Height\t%d\n", r.result.BlockHeight)
 		if r.result.Error != nil {
-			_, _ = fmt.Fprintf(writer, "%s Transaction Error \n%s\n\n\n", output.ErrorEmoji(), r.result.Error.Error())
+			_, _ = fmt.Fprintf(writer, "%s Transaction Error \n%s\n\n\n", output.ErrorEmoji(), r.sources.Annotate(r.result.Error.Error()))
 		}
 
 		statusBadge := ""
@@ -103,12 +133,32 @@ func (r *transactionResult) String() string {
 			statusBadge = output.OkEmoji()
 		}
 		_, _ = fmt.Fprintf(writer, "Status\t%s %s\n", statusBadge, r.result.Status)
+
+		if fees := FeesFromResult(r.result); fees != nil {
+			_, _ = fmt.Fprintf(writer, "Fees\t%s FLOW\n", fees.Amount)
+			_, _ = fmt.Fprintf(writer, "    Inclusion Effort\t%s units\n", fees.InclusionEffortUnits)
+			_, _ = fmt.Fprintf(writer, "    Execution Effort\t%s units\n", fees.ExecutionEffortUnits)
+		}
+
+		if created, err := flowkit.CreatedAccounts(r.result); err == nil && len(created) > 0 {
+			for _, account := range created {
+				_, _ = fmt.Fprintf(writer, "Created Account\t0x%s\n", account.Address.Hex())
+			}
+		}
 	}
 
 	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.tx.ID())
+	if link := r.link.URL(config.ExplorerTransaction, r.tx.ID().String()); link != "" {
+		_, _ = fmt.Fprintf(writer, "Explorer\t%s\n", link)
+	}
 	_, _ = fmt.Fprintf(writer, "Payer\t%s\n", r.tx.Payer.Hex())
 	_, _ = fmt.Fprintf(writer, "Authorizers\t%s\n", r.tx.Authorizers)
 
+	if r.decoded {
+		_, _ = fmt.Fprintf(writer, "Reference Block\t%s\n", r.tx.ReferenceBlockID)
+		_, _ = fmt.Fprintf(writer, "Compute Limit\t%d\n", r.tx.GasLimit)
+	}
+
 	_, _ = fmt.Fprintf(writer,
 		"\nProposal Key:\t\n    Address\t%s\n    Index\t%v\n    Sequence\t%v\n",
 		r.tx.ProposalKey.Address, r.tx.ProposalKey.KeyIndex, r.tx.ProposalKey.SequenceNumber,
@@ -196,6 +246,9 @@ func (r *transactionResult) Oneliner() string {
 	if r.result != nil {
 		result += fmt.Sprintf(", Status: %s, Events: %s", r.result.Status, r.result.Events)
 	}
+	if link := r.link.Included(config.ExplorerTransaction, r.tx.ID().String()); link != "" {
+		result += fmt.Sprintf(", Explorer: %s", link)
+	}
 
 	return result
 }
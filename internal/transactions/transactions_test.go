@@ -19,18 +19,34 @@
 package transactions
 
 import (
+	"context"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/fxamacker/cbor/v2"
+	"github.com/golang/protobuf/proto"
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-emulator/types"
 	"github.com/onflow/flow-go-sdk"
+	flowgo "github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/arguments"
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/mocks"
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
@@ -101,6 +117,17 @@ func Test_Decode(t *testing.T) {
 		assert.NotNil(t, result)
 	})
 
+	t.Run("Success binary", func(t *testing.T) {
+		inArgs := []string{"test.rlp"}
+		payload, _ := hex.DecodeString("f8aaf8a6b8617472616e73616374696f6e2829207b0a097072657061726528617574686f72697a65723a20417574684163636f756e7429207b7d0a0965786563757465207b0a09096c65742078203d20310a090970616e696328227465737422290a097d0a7d0ac0a003d40910037d575d52831647b39814f445bc8cc7ba8653286c0eb1473778c34f8203e888f8d6e0586b0a20c7808088f8d6e0586b0a20c7c988f8d6e0586b0a20c7c0c0")
+		_ = rw.WriteFile(inArgs[0], payload, 0677)
+
+		result, err := decode(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, uint64(1000), result.(*transactionResult).tx.GasLimit)
+	})
+
 	t.Run("Fail decode", func(t *testing.T) {
 		inArgs := []string{"test"}
 		_ = rw.WriteFile(inArgs[0], []byte("invalid"), 0677)
@@ -135,8 +162,124 @@ func Test_Get(t *testing.T) {
 	})
 }
 
+func Test_GetCache(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	defer func() { getFlags.Refresh = false }()
+
+	tx := tests.NewTransaction()
+	result := tests.NewAccountCreateResult(flow.HexToAddress("0x01"))
+	result.Status = flow.TransactionStatusSealed
+	result.BlockHeight = 10
+
+	fetched := 0
+	srv.GetTransactionByID.Run(func(mock.Arguments) {
+		fetched++
+	}).Return(tx, result, nil)
+
+	inArgs := []string{tx.ID().String()}
+	_, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetched)
+
+	t.Run("Success cached", func(t *testing.T) {
+		cached, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, 1, fetched)
+
+		cachedResult := cached.(*transactionResult)
+		assert.Equal(t, tx.ID(), cachedResult.tx.ID())
+		assert.Equal(t, uint64(10), cachedResult.result.BlockHeight)
+		require.Len(t, cachedResult.result.Events, 1)
+		assert.Equal(t, result.Events[0].Value.String(), cachedResult.result.Events[0].Value.String())
+	})
+
+	t.Run("Success refresh", func(t *testing.T) {
+		getFlags.Refresh = true
+		_, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, 2, fetched)
+		getFlags.Refresh = false
+	})
+
+	t.Run("Success not cached if not sealed", func(t *testing.T) {
+		pending := tests.NewTransaction()
+		pending.GasLimit = 1
+		srv.GetTransactionByID.Return(pending, &flow.TransactionResult{Status: flow.TransactionStatusPending}, nil)
+
+		pendingArgs := []string{pending.ID().String()}
+		_, err := get(pendingArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		_, err = get(pendingArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, 4, fetched)
+	})
+}
+
+func Test_ErrorSources(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	source := "pub contract Foo {\n    pub fun withdraw(amount: UFix64) {\n        pre {\n            amount > 0.0: \"invalid amount\"\n        }\n    }\n}\n"
+	require.NoError(t, rw.WriteFile("contracts/Foo.cdc", []byte(source), 0644))
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Foo", Location: "contracts/Foo.cdc"})
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   "emulator",
+		Account:   "emulator-account",
+		Contracts: []config.ContractDeployment{{Name: "Foo"}},
+	})
+	require.NoError(t, state.Save("flow.json"))
+
+	tx := tests.NewTransaction()
+	result := tests.NewTransactionResult(nil)
+	result.Status = flow.TransactionStatusExecuted // not sealed, so it isn't cached
+	result.Error = fmt.Errorf("[Error Code: 1101] cadence runtime error: Execution failed:\n" +
+		"error: pre-condition failed: invalid amount\n" +
+		" --> f8d6e0586b0a20c7.Foo:4:12\n")
+	srv.GetTransactionByID.Return(tx, result, nil)
+
+	got, err := get(
+		[]string{tx.ID().String()},
+		command.GlobalFlags{ConfigPaths: []string{"flow.json"}},
+		util.NoLogger,
+		rw,
+		srv.Mock,
+	)
+	require.NoError(t, err)
+
+	assert.Contains(t, got.String(), "Local sources of the error locations:\n"+
+		"\n--> contracts/Foo.cdc:4:12 (deployed as f8d6e0586b0a20c7.Foo:4:12)\n"+
+		"  |\n"+
+		"2 |     pub fun withdraw(amount: UFix64) {\n"+
+		"3 |         pre {\n"+
+		"4 |             amount > 0.0: \"invalid amount\"\n"+
+		"  |             ^\n"+
+		"5 |         }\n"+
+		"6 |     }\n")
+
+	// locations of contracts without a local source are left as they are
+	result.Error = fmt.Errorf("error: pre-condition failed\n --> 0ae53cb6e3f42a79.FlowToken:1:0\n")
+	got, err = get(
+		[]string{tx.ID().String()},
+		command.GlobalFlags{ConfigPaths: []string{"flow.json"}},
+		util.NoLogger,
+		rw,
+		srv.Mock,
+	)
+	require.NoError(t, err)
+	assert.NotContains(t, got.String(), "Local sources")
+}
+
+// mockAccountKey makes the mocked accounts have a full weight key with the index 0 used by configured accounts.
+func mockAccountKey(srv *mocks.MockServices) {
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(args.Get(1).(flow.Address).String())
+		account.Keys = []*flow.AccountKey{{Index: 0, Weight: flow.AccountKeyWeightThreshold}}
+		srv.GetAccount.Return(account, nil)
+	})
+}
+
 func Test_Send(t *testing.T) {
 	srv, state, _ := util.TestMocks(t)
+	mockAccountKey(srv)
 
 	t.Run("Success", func(t *testing.T) {
 		const gas = uint64(1000)
@@ -159,6 +302,206 @@ func Test_Send(t *testing.T) {
 		assert.NotNil(t, result)
 	})
 
+	t.Run("Success code from stdin", func(t *testing.T) {
+		stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+		require.NoError(t, err)
+		_, err = stdin.Write(tests.TransactionArgString.Source)
+		require.NoError(t, err)
+		_, err = stdin.Seek(0, 0)
+		require.NoError(t, err)
+		defer func(original *os.File) { os.Stdin = original }(os.Stdin)
+		os.Stdin = stdin
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Equal(t, tests.TransactionArgString.Source, script.Code)
+			assert.Empty(t, script.Location)
+		}).Return(nil, nil, nil)
+
+		result, err := send([]string{"-", "foo"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success multiple authorizers", func(t *testing.T) {
+		service, err := state.EmulatorServiceAccount()
+		require.NoError(t, err)
+		bob := *service
+		bob.Name = "bob"
+		bob.Address = flow.HexToAddress("0x179b6b1cb6755e31")
+		state.Accounts().AddOrUpdate(&bob)
+
+		sendFlags.Authorizers = []string{service.Name, bob.Name}
+		defer func() { sendFlags.Authorizers = nil }()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			assert.Equal(t, service.Name, roles.Proposer.Name)
+			assert.Equal(t, service.Name, roles.Payer.Name)
+			require.Len(t, roles.Authorizers, 2)
+			assert.Equal(t, bob.Name, roles.Authorizers[1].Name)
+		}).Return(nil, nil, nil)
+
+		result, err := send([]string{tests.TransactionArgString.Filename, "foo"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail missing payer", func(t *testing.T) {
+		sendFlags.Proposer = config.DefaultEmulator.ServiceAccount
+		defer func() { sendFlags.Proposer = "" }()
+
+		_, err := send([]string{tests.TransactionArgString.Filename, "foo"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "proposer and payer flags are required when no authorizer is provided")
+	})
+
+	t.Run("Success payer from pool", func(t *testing.T) {
+		state.Payers().AddOrUpdate(config.PayerPool{Network: config.EmulatorNetwork.Name, Accounts: []string{"bob"}})
+		sendFlags.Proposer = config.DefaultEmulator.ServiceAccount
+		defer func() {
+			*state.Payers() = nil
+			sendFlags.Proposer = ""
+		}()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			assert.Equal(t, config.DefaultEmulator.ServiceAccount, roles.Proposer.Name)
+			assert.Equal(t, "bob", roles.Payer.Name)
+		}).Return(tests.NewTransaction(), nil, nil)
+
+		result, err := send([]string{tests.TransactionArgString.Filename, "foo"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success submitted transaction", func(t *testing.T) {
+		// a transaction the network knows was submitted even if waiting for its result failed,
+		// so the sequence number reserved from the payer pool isn't released
+		srv.GetTransactionByID.Return(tests.NewTransaction(), nil, nil)
+		assert.True(t, transactionSubmitted(srv.Mock, tests.NewTransaction().ID()))
+
+		srv.GetTransactionByID.Return(nil, nil, fmt.Errorf("not found"))
+		assert.False(t, transactionSubmitted(srv.Mock, tests.NewTransaction().ID()))
+	})
+
+	t.Run("Success prompt arguments", func(t *testing.T) {
+		inArgs := []string{tests.TransactionArgString.Filename}
+
+		argumentsPrompt = func(parameters []arguments.Parameter, _ map[string]string) ([]string, error) {
+			require.Len(t, parameters, 1)
+			assert.Equal(t, "greeting", parameters[0].Name)
+			assert.Equal(t, "String", parameters[0].Type)
+			return []string{"hello"}, nil
+		}
+		stdinIsTerminal = func() bool { return true }
+		defer func() {
+			argumentsPrompt = util.ArgumentsPrompt
+			stdinIsTerminal = func() bool { return false }
+		}()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			require.Len(t, script.Args, 1)
+			assert.Equal(t, cadence.String("hello"), script.Args[0])
+		}).Return(nil, nil, nil)
+
+		result, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success prompt arguments with metadata", func(t *testing.T) {
+		_ = state.ReaderWriter().WriteFile("transfer.cdc", []byte(transferWithMetadata), 0644)
+
+		argumentsPrompt = func(parameters []arguments.Parameter, descriptions map[string]string) ([]string, error) {
+			require.Len(t, parameters, 2)
+			assert.Equal(t, "Amount of FLOW to transfer", descriptions["amount"])
+			assert.Equal(t, "Address of the recipient", descriptions["to"])
+			return []string{"1.0", "0x01"}, nil
+		}
+		stdinIsTerminal = func() bool { return true }
+		defer func() {
+			argumentsPrompt = util.ArgumentsPrompt
+			stdinIsTerminal = func() bool { return false }
+		}()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			require.Len(t, args.Get(2).(flowkit.Script).Args, 2)
+		}).Return(nil, nil, nil)
+
+		result, err := send([]string{"transfer.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success named arguments", func(t *testing.T) {
+		inArgs := []string{tests.TransactionArgString.Filename}
+		sendFlags.Arg = []string{"greeting:String:hello"}
+		defer func() { sendFlags.Arg = nil }()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			require.Len(t, script.Args, 1)
+			assert.Equal(t, cadence.String("hello"), script.Args[0])
+		}).Return(nil, nil, nil)
+
+		result, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success positional argument looking like a named argument", func(t *testing.T) {
+		inArgs := []string{tests.TransactionArgString.Filename}
+		sendFlags.Arg = []string{"Hello:World"}
+		defer func() { sendFlags.Arg = nil }()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			require.Len(t, script.Args, 1)
+			assert.Equal(t, cadence.String("Hello:World"), script.Args[0])
+		}).Return(nil, nil, nil)
+
+		result, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail named arguments", func(t *testing.T) {
+		sendFlags.Arg = []string{"greeting:Int:1"}
+		defer func() { sendFlags.Arg = nil }()
+
+		_, err := send([]string{tests.TransactionArgString.Filename}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "error parsing transaction arguments: argument `greeting` is declared as type `String` but provided as `Int`")
+
+		_, err = send([]string{tests.TransactionArgString.Filename, "hello"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "arguments keyed by parameter name can not be combined with positional arguments")
+	})
+
+	t.Run("Fail missing arguments without prompt", func(t *testing.T) {
+		inArgs := []string{tests.TransactionArgString.Filename}
+
+		_, err := send(inArgs, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "error parsing transaction arguments: argument count is 0, expected 1")
+
+		// not a terminal, like a CI run with no stdin
+		_, err = send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "error parsing transaction arguments: argument count is 0, expected 1")
+	})
+
+	t.Run("Fail prompt arguments", func(t *testing.T) {
+		argumentsPrompt = func(parameters []arguments.Parameter, _ map[string]string) ([]string, error) {
+			return nil, fmt.Errorf("failed to read argument greeting: EOF")
+		}
+		stdinIsTerminal = func() bool { return true }
+		defer func() {
+			argumentsPrompt = util.ArgumentsPrompt
+			stdinIsTerminal = func() bool { return false }
+		}()
+
+		_, err := send([]string{tests.TransactionArgString.Filename}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "failed to read argument greeting: EOF")
+	})
+
 	t.Run("Fail non-existing account", func(t *testing.T) {
 		sendFlags.Proposer = "invalid"
 		_, err := send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
@@ -186,17 +529,106 @@ func Test_Send(t *testing.T) {
 		sendFlags.Signer = config.DefaultEmulator.ServiceAccount
 		_, err := send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
 		assert.EqualError(t, err, "signer flag cannot be combined with payer/proposer/authorizer flags")
-		sendFlags.Signer = "" // reset
+		sendFlags.Signer = ""   // reset
+		sendFlags.Proposer = "" // reset
 	})
 
 	t.Run("Fail loading transaction file", func(t *testing.T) {
 		_, err := send([]string{"invalid"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
 		assert.EqualError(t, err, "error loading transaction file: open invalid: file does not exist")
 	})
+
+	t.Run("Success template", func(t *testing.T) {
+		sendFlags.Proposer = ""
+		sendFlags.Template = "transfer-flow"
+		sendFlags.Arg = []string{"10.0", "0x01cf0e2f2f715450"}
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.True(t, strings.Contains(string(script.Code), "import FlowToken from 0x0ae53cb6e3f42a79"))
+			assert.Len(t, script.Args, 2)
+			assert.Equal(t, "10.00000000", script.Args[0].String())
+		}).Return(nil, nil, nil)
+
+		result, err := send([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+
+		sendFlags.Template = "" // reset
+		sendFlags.Arg = nil
+	})
+
+	t.Run("Success template params", func(t *testing.T) {
+		sendFlags.Template = "transfer-flow"
+		sendFlags.Param = []string{"to=0x01cf0e2f2f715450", "amount=10.0"}
+		defer func() {
+			sendFlags.Template = ""
+			sendFlags.Param = nil
+		}()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			require.Len(t, script.Args, 2)
+			assert.Equal(t, "10.00000000", script.Args[0].String())
+			assert.Equal(t, "0x01cf0e2f2f715450", script.Args[1].String())
+		}).Return(nil, nil, nil)
+
+		result, err := send([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail template params not named", func(t *testing.T) {
+		sendFlags.Template = "transfer-flow"
+		sendFlags.Param = []string{"10.0"}
+		defer func() {
+			sendFlags.Template = ""
+			sendFlags.Param = nil
+		}()
+
+		_, err := send([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "parameters must be keyed by a parameter name declared by the transaction as name=value")
+	})
+
+	t.Run("Success setup vault template", func(t *testing.T) {
+		sendFlags.Template = "setup-vault"
+		sendFlags.Arg = []string{"0x0ae53cb6e3f42a79", "FlowToken", "/storage/flowTokenVault", "/public/flowTokenReceiver", "/public/flowTokenBalance"}
+		defer func() {
+			sendFlags.Template = ""
+			sendFlags.Arg = nil
+		}()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Contains(t, string(script.Code), "import FungibleToken from 0xee82856bf20e2aa6")
+			require.Len(t, script.Args, 5)
+			assert.Equal(t, `"FlowToken"`, script.Args[1].String())
+			assert.Equal(t, "/storage/flowTokenVault", script.Args[2].String())
+		}).Return(nil, nil, nil)
+
+		result, err := send([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Fail unknown template", func(t *testing.T) {
+		sendFlags.Template = "invalid"
+		_, err := send([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "transaction template invalid not found, use 'flow templates list' to see available templates")
+		sendFlags.Template = "" // reset
+	})
+
+	t.Run("Fail missing code", func(t *testing.T) {
+		_, err := send([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "requires a code filename or the template flag")
+	})
 }
 
 func Test_SendSigned(t *testing.T) {
 	srv, _, rw := util.TestMocks(t)
+	// the payload is not signed, the checks are covered by Test_Preflight
+	sendSignedFlags.SkipChecks = true
+	defer func() { sendSignedFlags.SkipChecks = false }()
 
 	t.Run("Success", func(t *testing.T) {
 		inArgs := []string{"test"}
@@ -391,4 +823,565 @@ Payload (hidden, use --include payload)`, "\n"), result.String())
 			"status":  "SEALED",
 		}, result.JSON())
 	})
+
+	t.Run("Success with fees", func(t *testing.T) {
+		amount, _ := cadence.NewUFix64("0.00001")
+		inclusion, _ := cadence.NewUFix64("1.0")
+		execution, _ := cadence.NewUFix64("0.00002")
+		feesEvent := tests.NewEvent(
+			1,
+			"A.f919ee77447b7497.FlowFees.FeesDeducted",
+			[]cadence.Field{
+				{Type: cadence.UFix64Type{}, Identifier: "amount"},
+				{Type: cadence.UFix64Type{}, Identifier: "inclusionEffort"},
+				{Type: cadence.UFix64Type{}, Identifier: "executionEffort"},
+			},
+			[]cadence.Value{amount, inclusion, execution},
+		)
+		feesResult := *txResult
+		feesResult.Events = []flow.Event{*event, *feesEvent}
+
+		result := transactionResult{tx: tx, result: &feesResult, exclude: []string{"events"}}
+
+		assert.Equal(t, &FeeSummary{
+			Amount:               "0.00001000",
+			InclusionEffortUnits: "1.00000000",
+			ExecutionEffortUnits: "0.00002000",
+		}, result.JSON().(map[string]any)["fees"])
+		assert.Contains(t, result.String(), "\t0.00001000 FLOW\n")
+		assert.Contains(t, result.String(), "    Execution Effort\t0.00002000 units\n")
+		assert.Nil(t, FeesFromResult(txResult))
+	})
+}
+
+func Test_ResultCreatedAccounts(t *testing.T) {
+	result := tests.NewAccountCreateResult(flow.HexToAddress("0x03"))
+	result.TransactionID = flow.HexToID("0a")
+
+	r := transactionResult{tx: tests.NewTransaction(), result: result}
+
+	assert.Equal(t, []map[string]string{{
+		"address":       "0x0000000000000003",
+		"transactionId": flow.HexToID("0a").String(),
+	}}, r.JSON().(map[string]any)["createdAccounts"])
+	assert.Contains(t, r.String(), "Created Account\t0x0000000000000003")
+}
+
+func Test_Export(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	inArgs := []string{tests.TransactionArgString.Filename, "foo"}
+
+	t.Run("Success go", func(t *testing.T) {
+		exportFlags.Lang = "go"
+		result, err := export(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		code := result.String()
+		assert.Contains(t, code, `tx.AddRawArgument([]byte(`+"`"+`{"value":"foo","type":"String"}`+"`"+`))`)
+		assert.Contains(t, code, `signerAddress := flow.HexToAddress("f8d6e0586b0a20c7")`)
+		assert.Contains(t, code, "SetComputeLimit(1000)")
+	})
+
+	t.Run("Success js", func(t *testing.T) {
+		exportFlags.Lang = "js"
+		result, err := export(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Contains(t, result.String(), `arg("foo", t.String),`)
+	})
+
+	t.Run("Success swift", func(t *testing.T) {
+		exportFlags.Lang = "swift"
+		result, err := export(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Contains(t, result.String(), `#"{"value":"foo","type":"String"}"#,`)
+		assert.Contains(t, result.String(), "flow.configure(chainID: .emulator)")
+	})
+
+	t.Run("Success escaped argument", func(t *testing.T) {
+		escapedArgs := []string{tests.TransactionArgString.Filename, "a`b\\c\"#"}
+
+		exportFlags.Lang = "go"
+		result, err := export(escapedArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Contains(t, result.String(), `tx.AddRawArgument([]byte("{\"value\":\"a`+"`"+`b\\\\c\\\"#\",\"type\":\"String\"}"))`)
+
+		exportFlags.Lang = "swift"
+		result, err = export(escapedArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Contains(t, result.String(), `##"{"value":"a`+"`"+`b\\c\"#","type":"String"}"##,`)
+		assert.Contains(t, result.String(), `#"""`)
+	})
+
+	t.Run("Success metadata header", func(t *testing.T) {
+		_ = state.ReaderWriter().WriteFile("transfer.cdc", []byte(transferWithMetadata), 0644)
+
+		exportFlags.Lang = "js"
+		result, err := export([]string{"transfer.cdc", "1.0", "0x01"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(result.String(), `// Transfer FLOW
+// Transfer FLOW tokens to another account
+//
+// Parameters:
+//   amount: Amount of FLOW to transfer
+//   to: Address of the recipient
+//
+// Signers: sender
+
+import * as fcl from "@onflow/fcl"`))
+	})
+
+	t.Run("Fail unsupported language", func(t *testing.T) {
+		exportFlags.Lang = "rust"
+		_, err := export(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "unsupported language rust, supported languages are: go, js, swift")
+	})
+
+	exportFlags.Lang = "go" // reset
+}
+
+func Test_FCLTypes(t *testing.T) {
+	types, err := fclParameterTypes(`transaction(a: UFix64, b: [String], c: {String: UInt64}?, d: [Address; 2]) {}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"t.UFix64",
+		"t.Array(t.String)",
+		"t.Optional(t.Dictionary({ key: t.String, value: t.UInt64 }))",
+		"t.Array(t.Address)",
+	}, types)
+}
+
+func Test_Replay(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	inArgs := []string{"0x01"}
+
+	t.Run("Success", func(t *testing.T) {
+		tx := tests.NewTransaction()
+		tx.Payer = flow.HexToAddress("e467b9dd11fa00df")
+		txResult := tests.NewTransactionResult(nil)
+		txResult.BlockHeight = 100
+		srv.GetTransactionByID.Return(tx, txResult, nil)
+
+		replayExecutor = func(chainID flowgo.ChainID, height uint64, replayTx *flow.Transaction) (*types.TransactionResult, error) {
+			assert.Equal(t, flowgo.Mainnet, chainID)
+			assert.Equal(t, uint64(99), height)
+			assert.Equal(t, tx.ID(), replayTx.ID())
+			return &types.TransactionResult{TransactionID: tx.ID(), ComputationUsed: 12, Logs: []string{"\"hello\""}}, nil
+		}
+		defer func() { replayExecutor = executeOnFork }()
+
+		result, err := replay(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Contains(t, result.String(), "Computation Used\t12\n")
+		assert.Contains(t, result.String(), "    \"hello\"\n")
+		assert.Equal(t, true, result.JSON().(map[string]any)["replay"].(map[string]any)["succeeded"])
+	})
+
+	t.Run("Fail emulator transaction", func(t *testing.T) {
+		srv.GetTransactionByID.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		result, err := replay(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "replaying transactions is only supported for mainnet and testnet, transaction belongs to flow-emulator")
+		assert.Nil(t, result)
+	})
+}
+
+func Test_Schedule(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	now := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	t.Run("Success", func(t *testing.T) {
+		scheduleFlags.After = "30m"
+		defer func() { scheduleFlags.After = "" }()
+
+		origWaitUntil := waitUntil
+		defer func() { waitUntil = origWaitUntil }()
+
+		var waited time.Time
+		waitUntil = func(_ context.Context, at time.Time) error {
+			waited = at
+			srv.Mock.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			return nil
+		}
+
+		srv.BuildTransaction.Return(transactions.New(), nil)
+		srv.SendTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		result, err := schedule([]string{tests.TransactionArgString.Filename, "foo"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, now.Add(30*time.Minute), waited)
+		srv.Mock.AssertCalled(t, "BuildTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail time", func(t *testing.T) {
+		_, err := scheduledTime("", "", now)
+		assert.EqualError(t, err, "provide either the at or the after flag")
+
+		_, err = scheduledTime("2023-07-01T13:00:00Z", "1m", now)
+		assert.EqualError(t, err, "provide either the at or the after flag")
+
+		_, err = scheduledTime("2023-07-01T11:00:00Z", "", now)
+		assert.EqualError(t, err, "at time 2023-07-01T11:00:00Z is in the past")
+
+		at, err := scheduledTime("2023-07-01 13:00:00", "", now)
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(time.Hour), at)
+	})
+}
+
+func Test_Transfer(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	transferFlags.Signer = config.DefaultEmulator.ServiceAccount
+
+	t.Run("Success", func(t *testing.T) {
+		transferFlags.To = "0x01"
+		defer func() { transferFlags.To = "" }()
+
+		srv.BuildTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(3).(flowkit.Script)
+			assert.True(t, strings.Contains(string(script.Code), "import FungibleToken from 0xee82856bf20e2aa6"))
+			assert.Equal(t, []cadence.Value{
+				cadence.UFix64(1000000000),
+				cadence.NewAddress(flow.HexToAddress("0x01")),
+				cadence.Path{Domain: common.PathDomainStorage, Identifier: "flowTokenVault"},
+				cadence.Path{Domain: common.PathDomainPublic, Identifier: "flowTokenReceiver"},
+			}, script.Args)
+		}).Return(transactions.New(), nil)
+		srv.SignTransactionPayload.Return(transactions.New(), nil)
+		srv.SendSignedTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		result, err := transfer([]string{"10.0", "FLOW"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Success recipient name", func(t *testing.T) {
+		transferFlags.To = config.DefaultEmulator.ServiceAccount
+		defer func() { transferFlags.To = "" }()
+		serviceAccount, err := state.EmulatorServiceAccount()
+		require.NoError(t, err)
+
+		srv.BuildTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(3).(flowkit.Script)
+			assert.Equal(t, cadence.NewAddress(serviceAccount.Address), script.Args[1])
+		}).Return(transactions.New(), nil)
+
+		_, err = transfer([]string{"10.0", "FLOW"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+	})
+
+	t.Run("Success recipient contract", func(t *testing.T) {
+		state.Contracts().AddOrUpdate(config.Contract{
+			Name:    "Market",
+			Aliases: config.Aliases{{Network: config.EmulatorNetwork.Name, Address: flow.HexToAddress("0x01cf0e2f2f715450")}},
+		})
+		defer func() { _ = state.Contracts().Remove("Market") }()
+		transferFlags.To = "Market"
+		defer func() { transferFlags.To = "" }()
+
+		srv.BuildTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(3).(flowkit.Script)
+			assert.Equal(t, cadence.NewAddress(flow.HexToAddress("0x01cf0e2f2f715450")), script.Args[1])
+		}).Return(transactions.New(), nil)
+
+		_, err := transfer([]string{"10.0", "FLOW"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+	})
+
+	t.Run("Fail recipient name", func(t *testing.T) {
+		transferFlags.To = "bob"
+		defer func() { transferFlags.To = "" }()
+
+		_, err := transfer([]string{"10.0", "FLOW"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid recipient: bob is not an address, or the name of an account or a contract in the configuration")
+
+		// the account and the contract have different addresses
+		name := config.DefaultEmulator.ServiceAccount
+		state.Contracts().AddOrUpdate(config.Contract{
+			Name:    name,
+			Aliases: config.Aliases{{Network: config.EmulatorNetwork.Name, Address: flow.HexToAddress("0x01cf0e2f2f715450")}},
+		})
+		defer func() { _ = state.Contracts().Remove(name) }()
+		transferFlags.To = name
+
+		_, err = transfer([]string{"10.0", "FLOW"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, fmt.Sprintf("invalid recipient: %s is ambiguous, it's the name of account %s and contract %s on different addresses", name, name, name))
+	})
+
+	t.Run("Fail recipient on another network", func(t *testing.T) {
+		transferFlags.To = config.DefaultEmulator.ServiceAccount
+		defer func() { transferFlags.To = "" }()
+		srv.Network.Return(config.TestnetNetwork)
+		defer srv.Network.Return(config.EmulatorNetwork)
+
+		_, err := transfer([]string{"10.0", "FLOW"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "which can't be used on the testnet network")
+	})
+
+	t.Run("Fail missing recipient", func(t *testing.T) {
+		_, err := transfer([]string{"10.0", "FLOW"}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "missing required flag --to")
+	})
+
+	t.Run("Resolve token paths", func(t *testing.T) {
+		storage, receiver, err := resolveTokenPaths("ExampleToken", "", "/public/exampleReceiver")
+		require.NoError(t, err)
+		assert.Equal(t, "/storage/exampleTokenVault", storage.String())
+		assert.Equal(t, "/public/exampleReceiver", receiver.String())
+
+		_, _, err = resolveTokenPaths("ExampleToken", "/public/vault", "")
+		assert.EqualError(t, err, "invalid storage path /public/vault")
+	})
+}
+
+const transferWithMetadata = `// ---
+// name: Transfer FLOW
+// description: Transfer FLOW tokens to another account
+// parameters:
+//   amount: Amount of FLOW to transfer
+//   to: Address of the recipient
+// signers: [sender]
+// ---
+transaction(amount: UFix64, to: Address) {
+	prepare(sender: AuthAccount) {}
+}`
+
+func Test_List(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	address := flow.HexToAddress("0x01")
+	other := flow.HexToAddress("0x02")
+	paid := flow.NewTransaction().SetPayer(address).SetProposalKey(other, 0, 0)
+	unrelated := flow.NewTransaction().SetPayer(other).SetProposalKey(other, 0, 0)
+	authorized := flow.NewTransaction().SetPayer(other).SetProposalKey(address, 0, 0).AddAuthorizer(address)
+
+	srv.GetBlock.Return(tests.NewBlock(), nil)
+	srv.GetTransactionsByBlockID.Return(
+		[]*flow.Transaction{paid, unrelated, authorized},
+		[]*flow.TransactionResult{
+			{Status: flow.TransactionStatusSealed},
+			{Status: flow.TransactionStatusSealed},
+			{Status: flow.TransactionStatusSealed},
+		},
+		nil,
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		listFlags = flagsList{Address: "0x01", Start: 1, End: 2, Workers: 2, Limit: 10}
+
+		result, err := list([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		txs := result.(*listResult).transactions
+		require.Len(t, txs, 4)
+		assert.Equal(t, []uint64{1, 1, 2, 2}, []uint64{txs[0].height, txs[1].height, txs[2].height, txs[3].height})
+		assert.Equal(t, []string{"payer"}, txs[0].roles)
+		assert.Equal(t, []string{"proposer", "authorizer"}, txs[1].roles)
+		assert.Equal(t, uint64(0), result.(*listResult).next)
+
+		t.Run("CBOR", func(t *testing.T) {
+			encoded, err := result.(command.BinaryResult).Binary("cbor")
+			require.NoError(t, err)
+
+			var records []transactionRecord
+			require.NoError(t, cbor.Unmarshal(encoded, &records))
+			require.Len(t, records, 4)
+			assert.Equal(t, uint64(1), records[0].BlockHeight)
+			assert.Equal(t, paid.ID().Bytes(), records[0].TransactionID)
+			assert.Equal(t, []string{"payer"}, records[0].Roles)
+			assert.Equal(t, "SEALED", records[0].Status)
+			assert.Equal(t, []string{"proposer", "authorizer"}, records[1].Roles)
+		})
+
+		t.Run("Proto", func(t *testing.T) {
+			encoded, err := result.(command.BinaryResult).Binary("proto")
+			require.NoError(t, err)
+
+			var results []*access.TransactionResultResponse
+			for len(encoded) > 0 {
+				length, n := protowire.ConsumeVarint(encoded)
+				require.Greater(t, n, 0)
+				var message access.TransactionResultResponse
+				require.NoError(t, proto.Unmarshal(encoded[n:n+int(length)], &message))
+				results = append(results, &message)
+				encoded = encoded[n+int(length):]
+			}
+			require.Len(t, results, 4)
+			assert.Equal(t, uint64(2), results[2].BlockHeight)
+			assert.Equal(t, authorized.ID().Bytes(), results[1].TransactionId)
+			assert.Equal(t, entities.TransactionStatus_SEALED, results[1].Status)
+		})
+	})
+
+	t.Run("Success paginated", func(t *testing.T) {
+		listFlags = flagsList{Address: "0x01", Start: 1, End: 5, Workers: 2, Limit: 3}
+
+		result, err := list([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		assert.Len(t, result.(*listResult).transactions, 4)
+		assert.Equal(t, uint64(3), result.(*listResult).next)
+		assert.Contains(t, result.String(), "continue with --start 3 --end 5")
+	})
+
+	t.Run("Success transaction files", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("txs/transfer.cdc", []byte(transferWithMetadata), 0644))
+		require.NoError(t, rw.WriteFile("txs/plain.cdc", []byte("transaction(greeting: String) {}"), 0644))
+		require.NoError(t, rw.WriteFile("txs/script.cdc", []byte("pub fun main(): Int { return 1 }"), 0644))
+
+		result, err := list([]string{"txs"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		assert.Equal(t, []transactionFileOutput{{
+			File:       "txs/plain.cdc",
+			Parameters: []transactionParamOutput{{Name: "greeting", Type: "String"}},
+		}, {
+			File:        "txs/transfer.cdc",
+			Name:        "Transfer FLOW",
+			Description: "Transfer FLOW tokens to another account",
+			Parameters: []transactionParamOutput{
+				{Name: "amount", Type: "UFix64", Description: "Amount of FLOW to transfer"},
+				{Name: "to", Type: "Address", Description: "Address of the recipient"},
+			},
+			Signers: []string{"sender"},
+		}}, result.JSON())
+	})
+
+	t.Run("Fail invalid metadata", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("invalid/tx.cdc", []byte("// ---\n// name: [\ntransaction {}"), 0644))
+
+		_, err := list([]string{"invalid"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "error parsing metadata of invalid/tx.cdc: invalid metadata: missing closing --- line")
+	})
+
+	t.Run("Fail missing address", func(t *testing.T) {
+		listFlags = flagsList{Workers: 2, Limit: 3}
+
+		_, err := list([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "address flag is required")
+	})
+}
+
+func Test_CheckExpiry(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+
+	reference := tests.NewBlock()
+	reference.Height = 100
+	latest := tests.NewBlock()
+	latest.Height = 680
+	latest.ID = flow.HexToID("03")
+	srv.GetBlock.Run(func(args mock.Arguments) {
+		if args.Get(1).(flowkit.BlockQuery).Latest {
+			srv.GetBlock.Return(latest, nil)
+		} else {
+			srv.GetBlock.Return(reference, nil)
+		}
+	})
+
+	newTx := func() *transactions.Transaction {
+		tx := transactions.New()
+		tx.FlowTransaction().SetReferenceBlockID(reference.ID)
+		return tx
+	}
+
+	t.Run("Success refresh unsigned", func(t *testing.T) {
+		tx := newTx()
+		refreshed, err := checkExpiry(srv.Mock, util.NoLogger, tx, 50, true)
+		require.NoError(t, err)
+		assert.True(t, refreshed)
+		assert.Equal(t, latest.ID, tx.FlowTransaction().ReferenceBlockID)
+	})
+
+	t.Run("Success unsigned not refreshed without flag", func(t *testing.T) {
+		tx := newTx()
+		refreshed, err := checkExpiry(srv.Mock, util.NoLogger, tx, 50, false)
+		require.NoError(t, err)
+		assert.False(t, refreshed)
+		assert.Equal(t, reference.ID, tx.FlowTransaction().ReferenceBlockID)
+	})
+
+	t.Run("Success not expiring", func(t *testing.T) {
+		tx := newTx()
+		refreshed, err := checkExpiry(srv.Mock, util.NoLogger, tx, 10, true)
+		require.NoError(t, err)
+		assert.False(t, refreshed)
+		assert.Equal(t, reference.ID, tx.FlowTransaction().ReferenceBlockID)
+	})
+
+	t.Run("Fail signed expired", func(t *testing.T) {
+		latest.Height = 700
+		defer func() { latest.Height = 680 }()
+
+		tx := newTx()
+		tx.FlowTransaction().AddPayloadSignature(flow.HexToAddress("0x01"), 0, []byte{1})
+		_, err := checkExpiry(srv.Mock, util.NoLogger, tx, 50, true)
+		assert.EqualError(t, err, "transaction expired at block height 700, latest sealed height is 700, build and sign the transaction again")
+		assert.Equal(t, reference.ID, tx.FlowTransaction().ReferenceBlockID)
+	})
+
+	t.Run("Success offline", func(t *testing.T) {
+		srv, _, _ := util.TestMocks(t)
+		srv.GetBlock.Return(nil, fmt.Errorf("connection refused"))
+
+		tx := newTx()
+		refreshed, err := checkExpiry(srv.Mock, util.NoLogger, tx, 50, true)
+		require.NoError(t, err)
+		assert.False(t, refreshed)
+		assert.Equal(t, reference.ID, tx.FlowTransaction().ReferenceBlockID)
+	})
+}
+
+func Test_Preflight(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+	proposer := flow.HexToAddress("0x01")
+	payer := flow.HexToAddress("0x02")
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(args.Get(1).(flow.Address).String())
+		account.Keys = []*flow.AccountKey{
+			{Index: 0, Weight: flow.AccountKeyWeightThreshold},
+			{Index: 1, Weight: 500},
+			{Index: 2, Weight: 500, Revoked: true},
+		}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	newTx := func(proposalKey int) *flow.Transaction {
+		return flow.NewTransaction().
+			SetProposalKey(proposer, proposalKey, 0).
+			SetPayer(payer).
+			AddAuthorizer(proposer)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		err := preflight(srv.Mock, newTx(0), map[flow.Address][]int{proposer: {0}, payer: {1, 0}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail missing proposal key", func(t *testing.T) {
+		err := preflight(srv.Mock, newTx(3), map[flow.Address][]int{proposer: {0}, payer: {0}})
+		assert.EqualError(t, err, "proposer account 0x0000000000000001 has no key with index 3, set an existing key index for the proposer account in the configuration")
+	})
+
+	t.Run("Fail revoked proposal key", func(t *testing.T) {
+		err := preflight(srv.Mock, newTx(2), map[flow.Address][]int{proposer: {0}, payer: {0}})
+		assert.EqualError(t, err, "proposer key 2 of account 0x0000000000000001 is revoked, set a key index that isn't revoked for the proposer account in the configuration")
+	})
+
+	t.Run("Fail insufficient weight", func(t *testing.T) {
+		err := preflight(srv.Mock, newTx(0), map[flow.Address][]int{proposer: {0}, payer: {1}})
+		assert.EqualError(t, err, "payer account 0x0000000000000002 signs with keys of total weight 500 but at least 1000 is required, sign with additional keys of the account")
+	})
+
+	t.Run("Fail insufficient balance", func(t *testing.T) {
+		testnetPayer := flow.HexToAddress("0x7aad92e5a0715d21")
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			srv.ExecuteScript.Return(cadence.NewArray([]cadence.Value{cadence.UFix64(100), cadence.UFix64(5000)}), nil)
+		})
+
+		err := checkPayerBalance(srv.Mock, testnetPayer, 1000)
+		assert.EqualError(t, err, "payer account 0x7aad92e5a0715d21 has an available balance of 0.00000100 FLOW, lower than the maximum fee of 0.00005000 FLOW for the gas limit 1000, fund the payer account or lower the gas limit")
+	})
 }
@@ -0,0 +1,195 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsTransfer struct {
+	To           string   `default:"" flag:"to" info:"Address, account name or contract name of the recipient"`
+	Signer       string   `default:"emulator-account" flag:"signer" info:"Account name from configuration sending the tokens"`
+	StoragePath  string   `default:"" flag:"storage-path" info:"Storage path of the signer vault, resolved from the token name if not provided"`
+	ReceiverPath string   `default:"" flag:"receiver-path" info:"Public path of the recipient receiver, resolved from the token name if not provided"`
+	GasLimit     uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Include      []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude      []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+}
+
+var transferFlags = flagsTransfer{}
+
+var TransferCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "transfer <amount> <token>",
+		Short: "Transfer fungible tokens to an account",
+		Example: `flow transfer 10.0 FLOW --to 0x01cf0e2f2f715450 --signer alice
+flow transfer 5.0 FUSD --to 0x01cf0e2f2f715450 --signer alice --network testnet`,
+		Args:    cobra.ExactArgs(2),
+		GroupID: "interactions",
+	},
+	Flags: &transferFlags,
+	RunS:  transfer,
+}
+
+// tokenPaths are the vault storage path and receiver public path of a fungible token.
+type tokenPaths struct {
+	storage  string
+	receiver string
+}
+
+// standardTokenPaths are the paths of tokens not following the vault naming convention.
+var standardTokenPaths = map[string]tokenPaths{
+	"flow": {storage: "flowTokenVault", receiver: "flowTokenReceiver"},
+	"fusd": {storage: "fusdVault", receiver: "fusdReceiver"},
+}
+
+// resolveTokenPaths returns the vault paths of the token, falling back to the convention of naming
+// the vault and receiver after the token contract, e.g. /storage/exampleTokenVault for ExampleToken.
+func resolveTokenPaths(token string, storage string, receiver string) (cadence.Path, cadence.Path, error) {
+	paths, ok := standardTokenPaths[strings.ToLower(token)]
+	if !ok {
+		name := []rune(token)
+		name[0] = unicode.ToLower(name[0])
+		paths = tokenPaths{storage: string(name) + "Vault", receiver: string(name) + "Receiver"}
+	}
+
+	if storage != "" {
+		paths.storage = storage
+	}
+	if receiver != "" {
+		paths.receiver = receiver
+	}
+
+	storagePath, err := parsePath(paths.storage, common.PathDomainStorage)
+	if err != nil {
+		return cadence.Path{}, cadence.Path{}, err
+	}
+
+	receiverPath, err := parsePath(paths.receiver, common.PathDomainPublic)
+	if err != nil {
+		return cadence.Path{}, cadence.Path{}, err
+	}
+
+	return storagePath, receiverPath, nil
+}
+
+// parsePath parses a path in the domain with or without the domain prefix.
+func parsePath(path string, domain common.PathDomain) (cadence.Path, error) {
+	identifier := strings.TrimPrefix(path, fmt.Sprintf("/%s/", domain.Identifier()))
+	if identifier == "" || strings.Contains(identifier, "/") {
+		return cadence.Path{}, fmt.Errorf("invalid %s path %s", domain.Identifier(), path)
+	}
+
+	return cadence.Path{Domain: domain, Identifier: identifier}, nil
+}
+
+func transfer(
+	args []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	amount, err := cadence.NewUFix64(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %s: %w", args[0], err)
+	}
+
+	if transferFlags.To == "" {
+		return nil, fmt.Errorf("missing required flag --to")
+	}
+	to, err := util.ResolveAddress(transferFlags.To, state, flow.Network())
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient: %w", err)
+	}
+
+	storagePath, receiverPath, err := resolveTokenPaths(args[1], transferFlags.StoragePath, transferFlags.ReceiverPath)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := util.AccountByName(state, transferFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := templateCode("transfer-fungible-tokens", signer)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := flow.BuildTransaction(
+		context.Background(),
+		transactions.AddressesRoles{
+			Proposer:    signer.Address,
+			Authorizers: []flowsdk.Address{signer.Address},
+			Payer:       signer.Address,
+		},
+		signer.Key.Index(),
+		flowkit.Script{
+			Code: code,
+			Args: []cadence.Value{amount, cadence.NewAddress(to), storagePath, receiverPath},
+		},
+		transferFlags.GasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction()) {
+		return nil, fmt.Errorf("transaction was not approved")
+	}
+
+	signed, err := flow.SignTransactionPayload(
+		context.Background(),
+		signer,
+		[]byte(hex.EncodeToString(tx.FlowTransaction().Encode())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, txResult, err := flow.SendSignedTransaction(context.Background(), signed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionResult{
+		result:  txResult,
+		tx:      sentTx,
+		include: transferFlags.Include,
+		exclude: transferFlags.Exclude,
+		link:    command.NewExplorerLink(flow.Network(), globalFlags),
+	}, nil
+}
@@ -0,0 +1,431 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tutorial
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/settings"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsTutorial struct {
+	Reset bool `default:"false" flag:"reset" info:"Start the tutorial over from the first step"`
+}
+
+var tutorialFlags = flagsTutorial{}
+
+var Command = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "tutorial",
+		Short: "Learn Flow with a guided walkthrough on a local emulator",
+		Long: `Learn Flow with a guided walkthrough that starts an emulator, creates an account, deploys a contract,
+sends a transaction and queries the emitted events. Each step explains the equivalent CLI command and
+verifies the step completed. Progress is saved, so a stopped tutorial continues where it was left.`,
+		Example: `flow tutorial
+flow tutorial --reset`,
+		Args:    cobra.NoArgs,
+		GroupID: "tools",
+	},
+	Flags:    &tutorialFlags,
+	NoDaemon: true,
+	NoRecord: true,
+	Run:      run,
+}
+
+const (
+	accountName  = "tutorial"
+	contractName = "Tutorial"
+	greeting     = "Hello from the tutorial!"
+)
+
+const contractCode = `pub contract Tutorial {
+    pub var greeting: String
+
+    pub event GreetingChanged(greeting: String)
+
+    pub fun setGreeting(_ greeting: String) {
+        self.greeting = greeting
+        emit GreetingChanged(greeting: greeting)
+    }
+
+    init() {
+        self.greeting = "Hello, Flow!"
+    }
+}
+`
+
+const transactionCode = `import Tutorial from 0x%s
+
+transaction(greeting: String) {
+    prepare(signer: AuthAccount) {}
+
+    execute {
+        Tutorial.setGreeting(greeting)
+    }
+}
+`
+
+// tutorial holds the state shared by the steps, each step builds on the results of the previous steps.
+type tutorial struct {
+	flow    flowkit.Services
+	state   *flowkit.State
+	account *accounts.Account
+	result  *flowsdk.TransactionResult
+}
+
+func (t *tutorial) eventType() string {
+	return fmt.Sprintf("A.%s.%s.GreetingChanged", t.account.Address.Hex(), contractName)
+}
+
+// step of the tutorial explains what the step does and the CLI command doing the same, then runs the step
+// using the same services the command uses and returns a description of the verified outcome.
+type step struct {
+	title       string
+	explanation string
+	command     func(t *tutorial) string
+	run         func(t *tutorial) (string, error)
+}
+
+var steps = []step{{
+	title: "Start the emulator",
+	explanation: "The Flow emulator is a local Flow network for development. It runs the same execution environment " +
+		"as mainnet and produces a block for each transaction, so you can try everything without spending FLOW.",
+	command: func(*tutorial) string { return "flow emulator" },
+	run: func(t *tutorial) (string, error) {
+		if err := t.flow.Ping(); err != nil {
+			return "", fmt.Errorf("emulator is not responding: %w", err)
+		}
+		return "emulator is running", nil
+	},
+}, {
+	title: "Create an account",
+	explanation: "Everything on Flow belongs to an account: tokens, contracts and other resources. An account is " +
+		"created by a transaction signed by an existing account, here the emulator service account, and it is " +
+		"controlled by the keys added to it.",
+	command: func(*tutorial) string { return fmt.Sprintf("flow accounts create --name %s", accountName) },
+	run: func(t *tutorial) (string, error) {
+		key, err := t.flow.GenerateKey(context.Background(), crypto.ECDSA_P256, "")
+		if err != nil {
+			return "", err
+		}
+
+		signer, err := t.state.EmulatorServiceAccount()
+		if err != nil {
+			return "", err
+		}
+
+		created, _, err := t.flow.CreateAccount(
+			context.Background(),
+			signer,
+			[]accounts.PublicKey{{
+				Public:   key.PublicKey(),
+				Weight:   flowsdk.AccountKeyWeightThreshold,
+				SigAlgo:  crypto.ECDSA_P256,
+				HashAlgo: crypto.SHA3_256,
+			}},
+		)
+		if err != nil {
+			return "", err
+		}
+
+		if _, err := t.flow.GetAccount(context.Background(), created.Address); err != nil {
+			return "", fmt.Errorf("created account can not be found: %w", err)
+		}
+
+		t.account = &accounts.Account{
+			Name:    accountName,
+			Address: created.Address,
+			Key:     accounts.NewHexKeyFromPrivateKey(0, crypto.SHA3_256, key),
+		}
+		t.state.Accounts().AddOrUpdate(t.account)
+
+		return fmt.Sprintf("created account 0x%s", created.Address), nil
+	},
+}, {
+	title: "Deploy a contract",
+	explanation: "Contracts are Cadence programs stored on an account. The Tutorial contract stores a greeting " +
+		"and emits a GreetingChanged event whenever the greeting changes.\n\n" + contractCode,
+	command: func(*tutorial) string {
+		return fmt.Sprintf("flow accounts add-contract ./%s.cdc --signer %s", contractName, accountName)
+	},
+	run: func(t *tutorial) (string, error) {
+		_, _, err := t.flow.AddContract(
+			context.Background(),
+			t.account,
+			flowkit.Script{
+				Code:     []byte(contractCode),
+				Location: fmt.Sprintf("%s.cdc", contractName),
+			},
+			flowkit.UpdateExistingContract(false),
+		)
+		if err != nil {
+			return "", err
+		}
+
+		account, err := t.flow.GetAccount(context.Background(), t.account.Address)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := account.Contracts[contractName]; !ok {
+			return "", fmt.Errorf("contract %s is not deployed to the account 0x%s", contractName, t.account.Address)
+		}
+
+		return fmt.Sprintf("deployed %s to 0x%s", contractName, t.account.Address), nil
+	},
+}, {
+	title: "Send a transaction",
+	explanation: "Transactions change the state of the network. This transaction imports the deployed contract and " +
+		"sets a new greeting, it is signed by the tutorial account which also pays the fees.\n\n" +
+		fmt.Sprintf(transactionCode, "<tutorial account>"),
+	command: func(*tutorial) string {
+		return fmt.Sprintf("flow transactions send ./SetGreeting.cdc %q --signer %s", greeting, accountName)
+	},
+	run: func(t *tutorial) (string, error) {
+		code := fmt.Sprintf(transactionCode, t.account.Address.Hex())
+		tx, result, err := t.flow.SendTransaction(
+			context.Background(),
+			transactions.SingleAccountRole(*t.account),
+			flowkit.Script{
+				Code:     []byte(code),
+				Args:     []cadence.Value{cadence.String(greeting)},
+				Location: "SetGreeting.cdc",
+			},
+			flowsdk.DefaultTransactionGasLimit,
+		)
+		if err != nil {
+			return "", err
+		}
+		if result.Error != nil {
+			return "", fmt.Errorf("transaction %s failed: %w", tx.ID(), result.Error)
+		}
+
+		t.result = result
+		return fmt.Sprintf("transaction %s sealed in block %d", tx.ID(), result.BlockHeight), nil
+	},
+}, {
+	title: "Query events",
+	explanation: "Events are emitted by transactions and are the way applications learn what happened on the " +
+		"network. Events are queried by their type, made of the contract location and the event name, in a " +
+		"range of blocks.",
+	command: func(t *tutorial) string {
+		if t.result == nil {
+			return "flow events get A.<tutorial account>.Tutorial.GreetingChanged"
+		}
+		return fmt.Sprintf(
+			"flow events get %s --start %d --end %d",
+			t.eventType(), t.result.BlockHeight, t.result.BlockHeight,
+		)
+	},
+	run: func(t *tutorial) (string, error) {
+		blockEvents, err := t.flow.GetEvents(
+			context.Background(),
+			[]string{t.eventType()},
+			t.result.BlockHeight,
+			t.result.BlockHeight,
+			nil,
+		)
+		if err != nil {
+			return "", err
+		}
+
+		found := 0
+		for _, block := range blockEvents {
+			found += len(block.Events)
+		}
+		if found == 0 {
+			return "", fmt.Errorf("no %s events found in block %d", t.eventType(), t.result.BlockHeight)
+		}
+
+		return fmt.Sprintf("found %d %s event(s)", found, t.eventType()), nil
+	},
+}}
+
+func run(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	completed := settings.TutorialStep()
+	if tutorialFlags.Reset || completed >= len(steps) {
+		completed = 0
+	}
+
+	// the tutorial uses its own configuration and emulator so it doesn't change the project
+	state, err := flowkit.Init(readerWriter, crypto.ECDSA_P256, crypto.SHA3_256)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccount, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+	key, err := serviceAccount.Key.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	gw := gateway.NewEmulatorGateway(&gateway.EmulatorKey{
+		PublicKey: (*key).PublicKey(),
+		SigAlgo:   serviceAccount.Key.SigAlgo(),
+		HashAlgo:  serviceAccount.Key.HashAlgo(),
+	})
+	t := &tutorial{
+		flow:  flowkit.NewFlowkit(state, config.EmulatorNetwork, gw, output.NewStdoutLogger(output.NoneLog)),
+		state: state,
+	}
+
+	if completed > 0 {
+		logger.Info(fmt.Sprintf(
+			"Continuing the tutorial at step %d, the completed steps are replayed on a new emulator. Use --reset to start over.\n",
+			completed+1,
+		))
+	}
+
+	proceed := util.TutorialStepPrompt
+	if globalFlags.Yes {
+		proceed = func() bool { return true }
+	}
+
+	return runSteps(t, steps, completed, logger, proceed, settings.SetTutorialStep)
+}
+
+// runSteps runs the tutorial steps in order, the steps already completed are replayed without explanation
+// to recreate the state the following steps depend on. Progress is saved after each completed step.
+func runSteps(
+	t *tutorial,
+	steps []step,
+	completed int,
+	logger output.Logger,
+	proceed func() bool,
+	saveProgress func(int) error,
+) (*tutorialResult, error) {
+	result := &tutorialResult{}
+
+	for i, s := range steps {
+		number := i + 1
+		status := stepCompleted
+
+		if i >= completed {
+			logger.Info(fmt.Sprintf("%s Step %d of %d: %s\n", output.GoEmoji(), number, len(steps), output.Bold(s.title)))
+			logger.Info(s.explanation)
+			logger.Info(fmt.Sprintf("\nCommand: %s\n", output.Italic(s.command(t))))
+
+			if !proceed() {
+				result.steps = append(result.steps, stepResult{number: number, title: s.title, status: stepPending})
+				for j := i + 1; j < len(steps); j++ {
+					result.steps = append(result.steps, stepResult{number: j + 1, title: steps[j].title, status: stepPending})
+				}
+				logger.Info("Tutorial stopped, run 'flow tutorial' to continue.")
+				return result, nil
+			}
+		} else {
+			status = stepReplayed
+		}
+
+		details, err := s.run(t)
+		if err != nil {
+			return nil, fmt.Errorf("tutorial step %d '%s' failed: %w", number, s.title, err)
+		}
+
+		if status == stepCompleted {
+			logger.Info(fmt.Sprintf("%s %s\n", output.SuccessEmoji(), details))
+			if err := saveProgress(number); err != nil {
+				return nil, fmt.Errorf("failed to save the tutorial progress: %w", err)
+			}
+		}
+
+		result.steps = append(result.steps, stepResult{number: number, title: s.title, status: status, details: details})
+	}
+
+	logger.Info(fmt.Sprintf("%s Tutorial completed, start your own project with 'flow setup'.\n", output.SuccessEmoji()))
+	return result, nil
+}
+
+const (
+	stepCompleted = "completed"
+	stepReplayed  = "replayed"
+	stepPending   = "pending"
+)
+
+type stepResult struct {
+	number  int
+	title   string
+	status  string
+	details string
+}
+
+type tutorialResult struct {
+	steps []stepResult
+}
+
+type stepOutput struct {
+	Step    int    `json:"step"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	Details string `json:"details,omitempty"`
+}
+
+func (r *tutorialResult) JSON() any {
+	steps := make([]stepOutput, 0, len(r.steps))
+	for _, s := range r.steps {
+		steps = append(steps, stepOutput{Step: s.number, Title: s.title, Status: s.status, Details: s.details})
+	}
+	return steps
+}
+
+func (r *tutorialResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Step\tTitle\tStatus\tDetails\n")
+	for _, s := range r.steps {
+		_, _ = fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", s.number, s.title, s.status, s.details)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *tutorialResult) Oneliner() string {
+	done := 0
+	for _, s := range r.steps {
+		if s.status != stepPending {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d of %d tutorial steps completed", done, len(r.steps))
+}
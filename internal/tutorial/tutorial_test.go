@@ -0,0 +1,164 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tutorial
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Tutorial(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+
+		key, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte(strings.Repeat("seed", 8)))
+		require.NoError(t, err)
+
+		srv.Ping.Return(nil)
+		srv.Mock.On("GenerateKey", mock.Anything, crypto.ECDSA_P256, "").Return(key, nil)
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(args.Get(1).(flowsdk.Address).String())
+			account.Contracts = map[string][]byte{contractName: []byte(contractCode)}
+			srv.GetAccount.Return(account, nil)
+		})
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Contains(t, string(script.Code), "import Tutorial from 0x0000000000000001")
+		}).Return(tests.NewTransaction(), &flowsdk.TransactionResult{BlockHeight: 3}, nil)
+		srv.GetEvents.Run(func(args mock.Arguments) {
+			assert.Equal(t, []string{"A.0000000000000001.Tutorial.GreetingChanged"}, args.Get(1))
+			assert.Equal(t, uint64(3), args.Get(2))
+		}).Return([]flowsdk.BlockEvents{{Events: []flowsdk.Event{{}}}}, nil)
+
+		var saved []int
+		result, err := runSteps(
+			&tutorial{flow: srv.Mock, state: state},
+			steps,
+			0,
+			util.NoLogger,
+			func() bool { return true },
+			func(step int) error {
+				saved = append(saved, step)
+				return nil
+			},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, saved)
+		assert.Equal(t, "5 of 5 tutorial steps completed", result.Oneliner())
+
+		account, err := state.Accounts().ByName(accountName)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000001", account.Address.Hex())
+	})
+
+	testSteps := func(ran *[]int) []step {
+		testSteps := make([]step, 3)
+		for i := range testSteps {
+			number := i + 1
+			testSteps[i] = step{
+				title:   fmt.Sprintf("Step %d", number),
+				command: func(*tutorial) string { return "flow" },
+				run: func(*tutorial) (string, error) {
+					*ran = append(*ran, number)
+					return fmt.Sprintf("ran %d", number), nil
+				},
+			}
+		}
+		return testSteps
+	}
+
+	t.Run("Success resume", func(t *testing.T) {
+		var ran, saved []int
+		result, err := runSteps(
+			&tutorial{},
+			testSteps(&ran),
+			2,
+			util.NoLogger,
+			func() bool { return true },
+			func(step int) error {
+				saved = append(saved, step)
+				return nil
+			},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, ran)
+		assert.Equal(t, []int{3}, saved)
+		assert.Equal(t, stepReplayed, result.steps[0].status)
+		assert.Equal(t, stepCompleted, result.steps[2].status)
+		assert.Equal(t, "Step\tTitle\tStatus\t\tDetails\n"+
+			"1\tStep 1\treplayed\tran 1\n"+
+			"2\tStep 2\treplayed\tran 2\n"+
+			"3\tStep 3\tcompleted\tran 3\n", result.String())
+	})
+
+	t.Run("Success stop", func(t *testing.T) {
+		var ran, saved []int
+		prompts := 0
+		result, err := runSteps(
+			&tutorial{},
+			testSteps(&ran),
+			0,
+			util.NoLogger,
+			func() bool {
+				prompts++
+				return prompts < 2
+			},
+			func(step int) error {
+				saved = append(saved, step)
+				return nil
+			},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, ran)
+		assert.Equal(t, []int{1}, saved)
+		assert.Equal(t, "1 of 3 tutorial steps completed", result.Oneliner())
+		assert.Equal(t, stepPending, result.steps[2].status)
+	})
+
+	t.Run("Fail step", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Ping.Return(fmt.Errorf("connection refused"))
+
+		result, err := runSteps(
+			&tutorial{flow: srv.Mock, state: state},
+			steps,
+			0,
+			util.NoLogger,
+			func() bool { return true },
+			func(int) error { return nil },
+		)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "tutorial step 1 'Start the emulator' failed: emulator is not responding: connection refused")
+	})
+}
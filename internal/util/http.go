@@ -0,0 +1,220 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"strconv"
+	"time"
+)
+
+// HTTPCAFileEnv is the environment variable with the path of additional PEM encoded CA certificates
+// trusted by the HTTP client, needed behind proxies intercepting TLS connections.
+const HTTPCAFileEnv = "FLOW_HTTP_CA_FILE"
+
+// httpMaxRetryWait caps the wait requested by a server in the Retry-After header.
+const httpMaxRetryWait = 30 * time.Second
+
+// HTTPOptions configures the HTTP client used for requests to external services.
+type HTTPOptions struct {
+	// Timeout of each request attempt.
+	Timeout time.Duration
+	// Retries is how many times a request failing with a transient error is repeated.
+	Retries int
+	// Backoff is the wait before the first retry, it's doubled on each following retry.
+	Backoff            time.Duration
+	InsecureSkipVerify bool
+	// CheckRedirect optionally replaces the default redirect policy of following up to 10 redirects.
+	CheckRedirect func(request *http.Request, via []*http.Request) error
+}
+
+// DefaultHTTPOptions are the options used for requests to external services.
+var DefaultHTTPOptions = HTTPOptions{
+	Timeout: 30 * time.Second,
+	Retries: 3,
+	Backoff: 500 * time.Millisecond,
+}
+
+// HTTPClient sends requests to external services and retries them on transient failures.
+//
+// Proxies are used as configured by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+type HTTPClient struct {
+	client  *http.Client
+	retries int
+	backoff time.Duration
+}
+
+// NewHTTPClient returns a client with the options, failing if the additional CA certificates can't be loaded.
+func NewHTTPClient(options HTTPOptions) (*HTTPClient, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: options.InsecureSkipVerify}
+
+	if caFile := os.Getenv(HTTPCAFileEnv); caFile != "" {
+		certs, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificates from %s: %w", caFile, err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(certs) {
+			return nil, fmt.Errorf("no valid PEM encoded CA certificates found in %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.TLSClientConfig = tlsConfig
+
+	return &HTTPClient{
+		client: &http.Client{
+			Timeout:       options.Timeout,
+			Transport:     transport,
+			CheckRedirect: options.CheckRedirect,
+		},
+		retries: options.Retries,
+		backoff: options.Backoff,
+	}, nil
+}
+
+// Do sends the request, retrying it if it fails with a transient error.
+//
+// Requests with a body are only retried if the body can be read again, which is the case for
+// requests created by http.NewRequest with a bytes or strings reader. Requests that aren't
+// idempotent, like POST requests, are only retried if no connection to the server was made,
+// since the server might have handled a request which failed afterwards.
+func (c *HTTPClient) Do(request *http.Request) (*http.Response, error) {
+	wait := c.backoff
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+
+		connected := false
+		trace := &httptrace.ClientTrace{GotConn: func(httptrace.GotConnInfo) { connected = true }}
+		res, err := c.client.Do(request.WithContext(httptrace.WithClientTrace(request.Context(), trace)))
+
+		replayable := request.Body == nil || request.GetBody != nil
+		if attempt >= c.retries || !replayable || !retryable(request, res, err, connected) {
+			return res, err
+		}
+
+		delay := wait
+		if res != nil {
+			if after, ok := retryAfter(res); ok {
+				delay = after
+			}
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(delay):
+		}
+		wait *= 2
+	}
+}
+
+// Get sends a GET request to the URL.
+func (c *HTTPClient) Get(url string) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(request)
+}
+
+// Post sends a POST request with the body to the URL.
+func (c *HTTPClient) Post(url string, contentType string, body io.Reader) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", contentType)
+
+	return c.Do(request)
+}
+
+// retryable checks whether the request failed with an error that might not occur again.
+//
+// Rate limited requests are only retried if the server says when to retry.
+func retryable(request *http.Request, res *http.Response, err error, connected bool) bool {
+	if !idempotent(request.Method) {
+		return err != nil && !connected && request.Context().Err() == nil
+	}
+
+	if err != nil {
+		return request.Context().Err() == nil && !certificateError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusTooManyRequests:
+		_, ok := retryAfter(res)
+		return ok
+	}
+
+	return false
+}
+
+// idempotent checks whether sending a request with the method more than once has the same effect as sending it once.
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// certificateError checks whether the error is caused by an untrusted server certificate, which retrying doesn't fix.
+func certificateError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+
+	return errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid)
+}
+
+// retryAfter returns the wait requested in the Retry-After header in seconds.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	wait := time.Duration(seconds) * time.Second
+	if wait > httpMaxRetryWait {
+		wait = httpMaxRetryWait
+	}
+
+	return wait, true
+}
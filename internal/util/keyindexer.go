@@ -0,0 +1,99 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// KeyIndexerURLs are the addresses of the public key indexer by network.
+var KeyIndexerURLs = map[string]string{
+	config.MainnetNetwork.Name: "https://key-indexer.production.flow.onflow.org",
+	config.TestnetNetwork.Name: "https://key-indexer.staging.flow.onflow.org",
+}
+
+// IndexedKey is an account key found by the public key indexer.
+type IndexedKey struct {
+	Address  flowsdk.Address
+	Index    int
+	Weight   int
+	SigAlgo  crypto.SignatureAlgorithm
+	HashAlgo crypto.HashAlgorithm
+	Revoked  bool
+}
+
+type keyIndexerResponse struct {
+	Accounts []struct {
+		Address   string `json:"address"`
+		KeyID     int    `json:"keyId"`
+		Weight    int    `json:"weight"`
+		SigAlgo   int    `json:"sigAlgo"`
+		HashAlgo  int    `json:"hashAlgo"`
+		IsRevoked bool   `json:"isRevoked"`
+	} `json:"accounts"`
+}
+
+// LookupPublicKey returns the account keys matching the public key in hex from the key indexer.
+func LookupPublicKey(indexerURL string, publicKey string) ([]IndexedKey, error) {
+	url := fmt.Sprintf("%s/key/%s", strings.TrimSuffix(indexerURL, "/"), publicKey)
+	client, err := NewHTTPClient(DefaultHTTPOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("could not reach the key indexer: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return []IndexedKey{}, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the key indexer failed with status %d", res.StatusCode)
+	}
+
+	var indexed keyIndexerResponse
+	if err := json.NewDecoder(res.Body).Decode(&indexed); err != nil {
+		return nil, fmt.Errorf("invalid key indexer response: %w", err)
+	}
+
+	found := make([]IndexedKey, 0, len(indexed.Accounts))
+	for _, account := range indexed.Accounts {
+		found = append(found, IndexedKey{
+			Address:  flowsdk.HexToAddress(account.Address),
+			Index:    account.KeyID,
+			Weight:   account.Weight,
+			SigAlgo:  crypto.SignatureAlgorithm(account.SigAlgo),
+			HashAlgo: crypto.HashAlgorithm(account.HashAlgo),
+			Revoked:  account.IsRevoked,
+		})
+	}
+
+	return found, nil
+}
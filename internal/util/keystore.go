@@ -0,0 +1,70 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+)
+
+// ReadPasswordFile returns the passphrase in the file without the trailing line break.
+func ReadPasswordFile(readerWriter flowkit.ReaderWriter, passwordFile string) (string, error) {
+	password, err := readerWriter.ReadFile(passwordFile)
+	if err != nil {
+		return "", fmt.Errorf("could not read the password file %s: %w", passwordFile, err)
+	}
+
+	return strings.TrimRight(string(password), "\r\n"), nil
+}
+
+// KeystorePassword returns the keystore passphrase from the password file if provided,
+// the environment, or a prompt asking to repeat it if confirm is set.
+func KeystorePassword(readerWriter flowkit.ReaderWriter, passwordFile string, confirm bool) (string, error) {
+	if passwordFile != "" {
+		return ReadPasswordFile(readerWriter, passwordFile)
+	}
+	if password, ok := os.LookupEnv(accounts.KeystorePasswordEnv); ok {
+		return password, nil
+	}
+
+	return PasswordPrompt(confirm), nil
+}
+
+// KeychainReplacePrompt confirms replacing the key stored in an existing keychain item.
+var KeychainReplacePrompt = ReplaceKeychainItemPrompt
+
+// StoreKeychainKey stores the private key in the keychain item, a key already stored in the item
+// is only replaced if replace is set or the user confirms it.
+func StoreKeychainKey(name string, privateKey crypto.PrivateKey, replace bool) error {
+	exists, err := accounts.KeychainKeyExists(name)
+	if err != nil {
+		return err
+	}
+	if exists && !replace && !KeychainReplacePrompt(name) {
+		return fmt.Errorf("the keychain item %s already stores a key, use the --replace flag to replace it", name)
+	}
+
+	return accounts.StoreKeychainKey(name, privateKey)
+}
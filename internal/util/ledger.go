@@ -0,0 +1,182 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// LedgerSigner is the account name selecting the connected Ledger device as signer.
+const LedgerSigner = "ledger"
+
+// LedgerPublicKey reads the public key of the Ledger key from the device.
+var LedgerPublicKey = func(key *accounts.LedgerKey) (crypto.PublicKey, error) {
+	return key.PublicKey()
+}
+
+// FindLedgerKeys returns the public key of the Ledger key and the account keys
+// with that public key found by the key indexer of the network.
+func FindLedgerKeys(key *accounts.LedgerKey, network string) (crypto.PublicKey, []IndexedKey, error) {
+	indexerURL, ok := KeyIndexerURLs[network]
+	if !ok {
+		return nil, nil, fmt.Errorf("accounts can't be discovered on the %s network, add the Ledger account with 'flow accounts ledger --add <name> --address <address>'", network)
+	}
+
+	publicKey, err := LedgerPublicKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found, err := LookupPublicKey(indexerURL, fmt.Sprintf("%x", publicKey.Encode()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return publicKey, found, nil
+}
+
+// LedgerAccountKey returns the active key of the account with the public key of the Ledger,
+// at the key index or, if it's negative, the first matching key. The signature and hash algorithms
+// of the Ledger key must be the ones of the on-chain key, a mismatch is refused.
+func LedgerAccountKey(
+	flow flowkit.Services,
+	address flowsdk.Address,
+	index int,
+	publicKey crypto.PublicKey,
+	hashAlgo crypto.HashAlgorithm,
+) (*flowsdk.AccountKey, error) {
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	var key *flowsdk.AccountKey
+	for _, accountKey := range account.Keys {
+		if accountKey.Revoked || !accountKey.PublicKey.Equals(publicKey) {
+			continue
+		}
+		if index < 0 || accountKey.Index == index {
+			key = accountKey
+			break
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("account 0x%s has no active key with the Ledger public key", address)
+	}
+
+	if key.SigAlgo != publicKey.Algorithm() {
+		return nil, fmt.Errorf(
+			"key %d of account 0x%s uses the %s signature algorithm, the Ledger key uses %s",
+			key.Index,
+			address,
+			key.SigAlgo,
+			publicKey.Algorithm(),
+		)
+	}
+	if hashAlgo != crypto.UnknownHashAlgorithm && key.HashAlgo != hashAlgo {
+		return nil, fmt.Errorf(
+			"key %d of account 0x%s uses the %s hash algorithm, not %s",
+			key.Index,
+			address,
+			key.HashAlgo,
+			hashAlgo,
+		)
+	}
+
+	return key, nil
+}
+
+// DiscoverLedgerAccount returns the account holding the Ledger key at the default derivation path
+// on the network of flow, with the algorithms of its on-chain key.
+func DiscoverLedgerAccount(flow flowkit.Services) (*accounts.Account, error) {
+	network := flow.Network().Name
+	key, err := accounts.NewLedgerKey(config.DefaultDerivationPath, 0, crypto.ECDSA_P256, crypto.SHA3_256)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, found, err := FindLedgerKeys(key, network)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]IndexedKey, 0, len(found))
+	for _, k := range found {
+		// the indexer may not report the algorithms, the on-chain key is checked below
+		if k.Revoked || (k.SigAlgo != crypto.UnknownSignatureAlgorithm && k.SigAlgo != publicKey.Algorithm()) {
+			continue
+		}
+		active = append(active, k)
+	}
+
+	if len(active) == 0 {
+		return nil, fmt.Errorf("no %s account has the Ledger public key %x", network, publicKey.Encode())
+	}
+	if len(active) > 1 {
+		return nil, fmt.Errorf(
+			"the Ledger public key is used by %d %s accounts, add one with 'flow accounts ledger --add <name> --address <address>'",
+			len(active),
+			network,
+		)
+	}
+
+	accountKey, err := LedgerAccountKey(flow, active[0].Address, active[0].Index, publicKey, crypto.UnknownHashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err = accounts.NewLedgerKey(config.DefaultDerivationPath, accountKey.Index, accountKey.SigAlgo, accountKey.HashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accounts.Account{
+		Name:    LedgerSigner,
+		Address: active[0].Address,
+		Key:     key,
+	}, nil
+}
+
+// ledgerAccount is the account of the connected Ledger resolved for the running command,
+// it's kept out of the state so it's never saved to the configuration.
+var ledgerAccount *accounts.Account
+
+// SetLedgerAccount sets the Ledger account resolved for the running command, nil clears it.
+func SetLedgerAccount(account *accounts.Account) {
+	ledgerAccount = account
+}
+
+// AccountByName returns the configured account with the name, or the resolved Ledger account
+// if the name is the Ledger signer and no configured account has that name.
+func AccountByName(state *flowkit.State, name string) (*accounts.Account, error) {
+	account, err := state.Accounts().ByName(name)
+	if err != nil && name == LedgerSigner && ledgerAccount != nil {
+		return ledgerAccount, nil
+	}
+
+	return account, err
+}
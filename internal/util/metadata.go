@@ -0,0 +1,134 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// metadataDelimiter starts and ends the metadata block in the leading comment of a Cadence file.
+const metadataDelimiter = "---"
+
+// Metadata describes a transaction or script, it is declared in YAML or JSON between two --- lines
+// in the comment the Cadence file starts with:
+//
+//	// ---
+//	// name: Transfer FLOW
+//	// description: Transfer FLOW tokens to another account
+//	// parameters:
+//	//   amount: Amount of FLOW to transfer
+//	//   to: Address of the recipient
+//	// signers: [sender]
+//	// ---
+type Metadata struct {
+	Name        string            `yaml:"name" json:"name,omitempty"`
+	Description string            `yaml:"description" json:"description,omitempty"`
+	Parameters  map[string]string `yaml:"parameters" json:"parameters,omitempty"`
+	Signers     []string          `yaml:"signers" json:"signers,omitempty"`
+}
+
+// ParseMetadata parses the metadata declared in the leading comment of the code,
+// it returns nil if the code doesn't declare metadata.
+func ParseMetadata(code []byte) (*Metadata, error) {
+	lines := leadingComment(code)
+
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) != metadataDelimiter {
+			continue
+		}
+		if start == -1 {
+			start = i
+			continue
+		}
+
+		var metadata Metadata
+		err := yaml.Unmarshal([]byte(strings.Join(lines[start+1:i], "\n")), &metadata)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata: %w", err)
+		}
+		return &metadata, nil
+	}
+
+	if start != -1 {
+		return nil, fmt.Errorf("invalid metadata: missing closing %s line", metadataDelimiter)
+	}
+	return nil, nil
+}
+
+// leadingComment returns the lines of the line or block comment the code starts with, without the comment markers.
+func leadingComment(code []byte) []string {
+	code = bytes.TrimSpace(code)
+
+	if bytes.HasPrefix(code, []byte("/*")) {
+		end := bytes.Index(code, []byte("*/"))
+		if end == -1 {
+			return nil
+		}
+
+		lines := strings.Split(string(code[2:end]), "\n")
+		for i, line := range lines {
+			trimmed := strings.TrimLeft(line, " \t")
+			if strings.HasPrefix(trimmed, "*") {
+				line = strings.TrimPrefix(strings.TrimPrefix(trimmed, "*"), " ")
+			}
+			lines[i] = strings.TrimRight(line, " \t\r")
+		}
+		return lines
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(string(code), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "//") {
+			break
+		}
+		trimmed = strings.TrimLeft(trimmed, "/")
+		lines = append(lines, strings.TrimPrefix(trimmed, " "))
+	}
+	return lines
+}
+
+// Comment returns the metadata as comment lines with the prefix, for annotating code generated from the file.
+func (m *Metadata) Comment(prefix string, parameterNames []string) string {
+	var b strings.Builder
+	if m.Name != "" {
+		_, _ = fmt.Fprintf(&b, "%s %s\n", prefix, m.Name)
+	}
+	if m.Description != "" {
+		_, _ = fmt.Fprintf(&b, "%s %s\n", prefix, m.Description)
+	}
+	if len(m.Parameters) > 0 {
+		_, _ = fmt.Fprintf(&b, "%s\n%s Parameters:\n", prefix, prefix)
+		for _, name := range parameterNames {
+			if doc, ok := m.Parameters[name]; ok {
+				_, _ = fmt.Fprintf(&b, "%s   %s: %s\n", prefix, name, doc)
+			}
+		}
+	}
+	if len(m.Signers) > 0 {
+		_, _ = fmt.Fprintf(&b, "%s\n%s Signers: %s\n", prefix, prefix, strings.Join(m.Signers, ", "))
+	}
+
+	return b.String()
+}
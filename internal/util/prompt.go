@@ -33,6 +33,7 @@ import (
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
+	"github.com/onflow/flow-cli/flowkit/arguments"
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
 )
@@ -183,6 +184,46 @@ func AccountNamePrompt(accountNames []string) string {
 	return name
 }
 
+// PasswordPrompt asks for a passphrase without echoing it, entered twice if confirm is set.
+func PasswordPrompt(confirm bool) string {
+	passwordPrompt := promptui.Prompt{
+		Label: "Enter the keystore passphrase",
+		Mask:  '*',
+		Validate: func(s string) error {
+			if len(s) < 1 {
+				return fmt.Errorf("invalid passphrase")
+			}
+			return nil
+		},
+	}
+
+	password, err := passwordPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+	if !confirm {
+		return password
+	}
+
+	confirmPrompt := promptui.Prompt{
+		Label: "Repeat the keystore passphrase",
+		Mask:  '*',
+		Validate: func(s string) error {
+			if s != password {
+				return fmt.Errorf("the passphrases don't match")
+			}
+			return nil
+		},
+	}
+
+	_, err = confirmPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return password
+}
+
 func secureNetworkKeyPrompt() string {
 	networkKeyPrompt := promptui.Prompt{
 		Label: "Enter a valid host network key or leave blank",
@@ -278,6 +319,12 @@ type AccountData struct {
 	HashAlgo string
 	Key      string
 	KeyIndex string
+	// KMSResourceID is the Google Cloud KMS key signing for the account instead of the private key.
+	KMSResourceID string
+	// KMSARN is the AWS KMS key signing for the account instead of the private key.
+	KMSARN string
+	// Keychain is the operating system keychain item the private key is stored in instead of the configuration.
+	Keychain string
 }
 
 func NewAccountPrompt() *AccountData {
@@ -579,6 +626,17 @@ func RemoveContractFromFlowJSONPrompt(contractName string) bool {
 	return chosen == 0
 }
 
+// ReplaceKeychainItemPrompt asks whether to replace the private key already stored in the keychain item.
+func ReplaceKeychainItemPrompt(name string) bool {
+	prompt := promptui.Select{
+		Label: fmt.Sprintf("The keychain item %s already stores a key, do you want to replace it?", name),
+		Items: []string{"Yes", "No"},
+	}
+	chosen, _, _ := prompt.Run()
+
+	return chosen == 0
+}
+
 func RemoveNetworkPrompt(networks config.Networks) string {
 	networkNames := make([]string, 0)
 
@@ -630,6 +688,20 @@ func CreateAccountNetworkPrompt() (string, config.Network) {
 	return selectedNetwork, networkMap[selectedNetwork]
 }
 
+// TutorialStepPrompt asks whether to run the next tutorial step or stop the tutorial to resume it later.
+func TutorialStepPrompt() bool {
+	prompt := promptui.Select{
+		Label: "Ready for this step?",
+		Items: []string{"Run the step", "Stop, I'll continue later"},
+	}
+	chosen, _, err := prompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return chosen == 0
+}
+
 func WantToUseMainnetVersionPrompt() bool {
 	useMainnetVersionPrompt := promptui.Select{
 		Label: "Do you wish to use Mainnet version instead? (y/n)",
@@ -643,6 +715,38 @@ func WantToUseMainnetVersionPrompt() bool {
 	return useMainnetVersion == "Yes"
 }
 
+// ArgumentsPrompt asks for a value of each parameter and validates the value is of the parameter type.
+// The descriptions of the parameters by name, declared in the file metadata, are shown with the parameter.
+func ArgumentsPrompt(parameters []arguments.Parameter, descriptions map[string]string) ([]string, error) {
+	values := make([]string, 0, len(parameters))
+	for _, parameter := range parameters {
+		parameter := parameter
+		label := fmt.Sprintf("Enter %s (%s)", parameter.Name, parameter.Type)
+		if description := descriptions[parameter.Name]; description != "" {
+			label = fmt.Sprintf("%s - %s", label, description)
+		}
+		argumentPrompt := promptui.Prompt{
+			Label: label,
+			Validate: func(s string) error {
+				_, err := parameter.Parse(s)
+				return err
+			},
+		}
+
+		value, err := argumentPrompt.Run()
+		if err == promptui.ErrInterrupt {
+			os.Exit(-1)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read argument %s: %w", parameter.Name, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
 const CancelInstall = 1
 
 const AlreadyInstalled = 2
@@ -0,0 +1,175 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// errorLocation matches a location of a deployed contract in a Cadence error, e.g. "--> f8d6e0586b0a20c7.Foo:12:8".
+var errorLocation = regexp.MustCompile(`--> (?:A\.)?(?:0x)?([0-9a-fA-F]{16})\.(\w+):(\d+):(\d+)`)
+
+// snippetContext is the number of lines shown before and after the line of an error location.
+const snippetContext = 2
+
+// SourceMap maps locations of contracts deployed on a network back to the local project sources.
+//
+// Deployed code only differs from the local source by the replaced imports, which keep the lines
+// of the source, so positions in deployed contracts are positions in the local sources.
+type SourceMap struct {
+	sources      map[string]string
+	readerWriter flowkit.ReaderWriter
+}
+
+// NewSourceMap creates a source map from the contracts deployed or aliased on the network in the project,
+// a nil state creates an empty source map.
+func NewSourceMap(state *flowkit.State, network config.Network) *SourceMap {
+	sourceMap := &SourceMap{sources: make(map[string]string)}
+	if state == nil {
+		return sourceMap
+	}
+	sourceMap.readerWriter = state.ReaderWriter()
+
+	for _, contract := range *state.Contracts() {
+		if alias := contract.Aliases.ByNetwork(network.Name); alias != nil {
+			sourceMap.sources[sourceKey(alias.Address.Hex(), contract.Name)] = contract.Location
+		}
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return sourceMap
+	}
+	for _, contract := range contracts {
+		sourceMap.sources[sourceKey(contract.AccountAddress.Hex(), contract.Name)] = contract.Location()
+	}
+
+	return sourceMap
+}
+
+func sourceKey(address string, name string) string {
+	return fmt.Sprintf("%s.%s", strings.ToLower(address), name)
+}
+
+// Annotate appends the local source and a code snippet of each deployed contract location referenced
+// by the Cadence error message, locations without a local source are left as they are.
+func (m *SourceMap) Annotate(message string) string {
+	annotations := m.annotations(message)
+	if annotations == "" {
+		return message
+	}
+	return strings.TrimRight(message, "\n") + annotations
+}
+
+// AnnotateError annotates the error message with the local sources, the annotated error wraps the error.
+func (m *SourceMap) AnnotateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	annotations := m.annotations(err.Error())
+	if annotations == "" {
+		return err
+	}
+	return fmt.Errorf("%w%s", err, annotations)
+}
+
+func (m *SourceMap) annotations(message string) string {
+	if m == nil || m.readerWriter == nil || len(m.sources) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	seen := make(map[string]bool)
+	for _, match := range errorLocation.FindAllStringSubmatch(message, -1) {
+		location := strings.TrimPrefix(match[0], "--> ")
+		if seen[location] {
+			continue
+		}
+		seen[location] = true
+
+		source, ok := m.sources[sourceKey(match[1], match[2])]
+		if !ok {
+			continue
+		}
+		line, _ := strconv.Atoi(match[3])
+		column, _ := strconv.Atoi(match[4])
+
+		snippet, err := m.snippet(source, line, column)
+		if err != nil {
+			continue
+		}
+		_, _ = fmt.Fprintf(&b, "\n--> %s:%d:%d (deployed as %s)\n%s", source, line, column, location, snippet)
+	}
+
+	if b.Len() == 0 {
+		return ""
+	}
+	return "\n\nLocal sources of the error locations:\n" + b.String()
+}
+
+// snippet returns the lines of the source around the line, with a marker below the column of the line.
+func (m *SourceMap) snippet(source string, line int, column int) (string, error) {
+	code, err := m.readerWriter.ReadFile(source)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(code), "\r\n", "\n"), "\n")
+	if line < 1 || line > len(lines) {
+		return "", fmt.Errorf("line %d is outside of the source %s", line, source)
+	}
+
+	first, last := line-snippetContext, line+snippetContext
+	if first < 1 {
+		first = 1
+	}
+	if last > len(lines) {
+		last = len(lines)
+	}
+	width := len(strconv.Itoa(last))
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "%s |\n", strings.Repeat(" ", width))
+	for number := first; number <= last; number++ {
+		_, _ = fmt.Fprintf(&b, "%*d | %s\n", width, number, lines[number-1])
+		if number == line {
+			// keep tabs in the indentation so the marker lines up with the code
+			indent := []rune(lines[number-1])
+			if column > len(indent) {
+				column = len(indent)
+			}
+			marker := make([]rune, column)
+			for i := range marker {
+				marker[i] = ' '
+				if indent[i] == '\t' {
+					marker[i] = '\t'
+				}
+			}
+			_, _ = fmt.Fprintf(&b, "%s | %s^\n", strings.Repeat(" ", width), string(marker))
+		}
+	}
+
+	return b.String(), nil
+}
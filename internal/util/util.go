@@ -20,17 +20,27 @@ package util
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
 )
 
 const EnvPrefix = "FLOW"
@@ -83,6 +93,220 @@ func GetAddressNetwork(address flowsdk.Address) (flowsdk.ChainID, error) {
 	return "", fmt.Errorf("address not valid for any known chain: %s", address)
 }
 
+// ResolveAddress resolves the value of an address flag or argument on the network, the value is either an address,
+// the name of an account in the configuration or the name of a contract aliased or deployed on the network.
+//
+// Names are resolved only if the state is loaded, and values matching more than one address are ambiguous.
+func ResolveAddress(value string, state *flowkit.State, network config.Network) (flowsdk.Address, error) {
+	address := flowsdk.HexToAddress(value)
+	isAddress := isHexAddress(value)
+
+	var names []string
+	var addresses []flowsdk.Address
+	if state != nil {
+		if account, err := state.Accounts().ByName(value); err == nil {
+			// the address can't be checked on networks with an unknown chain
+			chain, _ := GetAddressNetwork(account.Address)
+			if networkChain, ok := KnownNetworkChain(network); ok && chain != networkChain {
+				return flowsdk.EmptyAddress, fmt.Errorf(
+					"account %s has the address 0x%s which can't be used on the %s network",
+					value,
+					account.Address.Hex(),
+					network.Name,
+				)
+			}
+			names = append(names, fmt.Sprintf("account %s", value))
+			addresses = append(addresses, account.Address)
+		}
+
+		if contractAddress, ok := contractAddress(value, state, network); ok {
+			names = append(names, fmt.Sprintf("contract %s", value))
+			addresses = append(addresses, contractAddress)
+		}
+	}
+
+	switch {
+	case isAddress && len(names) > 0:
+		return flowsdk.EmptyAddress, fmt.Errorf("%s is ambiguous, it's an address and the name of %s", value, strings.Join(names, " and "))
+	case isAddress:
+		return address, nil
+	case len(names) == 0:
+		return flowsdk.EmptyAddress, fmt.Errorf("%s is not an address, or the name of an account or a contract in the configuration", value)
+	}
+
+	for _, other := range addresses[1:] {
+		if other != addresses[0] {
+			return flowsdk.EmptyAddress, fmt.Errorf(
+				"%s is ambiguous, it's the name of %s on different addresses",
+				value,
+				strings.Join(names, " and "),
+			)
+		}
+	}
+	return addresses[0], nil
+}
+
+// isHexAddress returns whether the value is an address in hex, optionally prefixed with 0x.
+func isHexAddress(value string) bool {
+	value = strings.TrimPrefix(value, "0x")
+	if value == "" || len(value) > 2*flowsdk.AddressLength {
+		return false
+	}
+	for _, c := range value {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// contractAddress returns the address the contract is aliased to or deployed to on the network.
+func contractAddress(name string, state *flowkit.State, network config.Network) (flowsdk.Address, bool) {
+	contract, err := state.Contracts().ByName(name)
+	if err == nil && contract.IsAliased() {
+		if alias := contract.Aliases.ByNetwork(network.Name); alias != nil {
+			return alias.Address, true
+		}
+	}
+
+	for _, deployment := range state.Deployments().ByNetwork(network.Name) {
+		for _, c := range deployment.Contracts {
+			if c.Name != name {
+				continue
+			}
+			account, err := state.Accounts().ByName(deployment.Account)
+			if err != nil {
+				return flowsdk.EmptyAddress, false
+			}
+			return account.Address, true
+		}
+	}
+
+	return flowsdk.EmptyAddress, false
+}
+
+// KnownNetworkChain returns the chain of the network from its name or the host of a default network,
+// and whether the chain is known. The chain of custom networks, like a local fork of mainnet, is unknown.
+func KnownNetworkChain(network config.Network) (flowsdk.ChainID, bool) {
+	switch {
+	case network.Name == config.MainnetNetwork.Name || network.Host == config.MainnetNetwork.Host:
+		return flowsdk.Mainnet, true
+	case network.Name == config.TestnetNetwork.Name || network.Host == config.TestnetNetwork.Host:
+		return flowsdk.Testnet, true
+	case network.Name == config.EmulatorNetwork.Name:
+		return flowsdk.Emulator, true
+	default:
+		return "", false
+	}
+}
+
+// NetworkChain returns the chain of the network used to resolve core contract and account addresses,
+// networks with an unknown chain are considered an emulator.
+func NetworkChain(network config.Network) flowsdk.ChainID {
+	if chain, ok := KnownNetworkChain(network); ok {
+		return chain
+	}
+
+	return flowsdk.Emulator
+}
+
+// EnvFromNetwork returns the core contracts template environment for the network.
+func EnvFromNetwork(network flowsdk.ChainID) tmpl.Environment {
+	if network == flowsdk.Mainnet {
+		return tmpl.Environment{
+			IDTableAddress:       "8624b52f9ddcd04a",
+			FungibleTokenAddress: "f233dcee88fe0abe",
+			FlowTokenAddress:     "1654653399040a61",
+			LockedTokensAddress:  "8d0e87b65159ae63",
+			StakingProxyAddress:  "62430cf28c26d095",
+			FlowFeesAddress:      "f919ee77447b7497",
+			StorageFeesAddress:   "e467b9dd11fa00df",
+		}
+	}
+
+	if network == flowsdk.Testnet {
+		return tmpl.Environment{
+			IDTableAddress:       "9eca2b38b18b5dfe",
+			FungibleTokenAddress: "9a0766d93b6608b7",
+			FlowTokenAddress:     "7e60df042a9c0868",
+			LockedTokensAddress:  "95e019a17d0e23d7",
+			StakingProxyAddress:  "7aad92e5a0715d21",
+			FlowFeesAddress:      "912d5440f7e3769e",
+			StorageFeesAddress:   "8c5303eaa26202d6",
+		}
+	}
+
+	if network == flowsdk.Emulator {
+		return tmpl.Environment{
+			FungibleTokenAddress:  "ee82856bf20e2aa6",
+			FlowTokenAddress:      "0ae53cb6e3f42a79",
+			ServiceAccountAddress: "f8d6e0586b0a20c7",
+			FlowFeesAddress:       "e5a8b7f23e8b548f",
+			StorageFeesAddress:    "f8d6e0586b0a20c7",
+		}
+	}
+
+	return tmpl.Environment{}
+}
+
+// LoadOptional loads the project state for commands that also run without a project configuration,
+// returning a nil state if the configuration doesn't exist and the error for any other failure.
+func LoadOptional(configPaths []string, readerWriter flowkit.ReaderWriter) (*flowkit.State, error) {
+	state, err := flowkit.Load(configPaths, readerWriter)
+	if errors.Is(err, config.ErrDoesNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// ProjectDir returns the directory of the project configuration, which is the last of the configuration paths.
+func ProjectDir(configPaths []string) string {
+	if len(configPaths) == 0 {
+		return "."
+	}
+
+	return filepath.Dir(configPaths[len(configPaths)-1])
+}
+
+// ConfirmedRange limits the end of the height range to blocks that are at least the number
+// of confirmations below the latest sealed block, so only data that can't change is processed.
+func ConfirmedRange(
+	flow flowkit.Services,
+	logger output.Logger,
+	start uint64,
+	end uint64,
+	confirmations uint64,
+) (uint64, error) {
+	if confirmations == 0 {
+		return end, nil
+	}
+
+	latest, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{Latest: true})
+	if err != nil {
+		return 0, err
+	}
+	if latest.Height < confirmations || latest.Height-confirmations < start {
+		return 0, fmt.Errorf(
+			"no blocks from height %d have %d confirmations yet, latest sealed height is %d",
+			start,
+			confirmations,
+			latest.Height,
+		)
+	}
+
+	confirmed := latest.Height - confirmations
+	if end > confirmed {
+		logger.Info(fmt.Sprintf("Processing blocks up to height %d which have %d confirmations", confirmed, confirmations))
+		return confirmed, nil
+	}
+
+	return end, nil
+}
+
 func CreateTabWriter(b *bytes.Buffer) *tabwriter.Writer {
 	return tabwriter.NewWriter(b, 0, 8, 1, '\t', tabwriter.AlignRight)
 }
@@ -102,6 +326,43 @@ func ValidateECDSAP256Pub(key string) error {
 	return nil
 }
 
+// ParseArgsJSON parses arguments in the JSON-Cadence format provided by the args JSON flag, the value is
+// either the JSON itself, a file path prefixed with @ or - to read the arguments from the standard input.
+func ParseArgsJSON(value string, readerWriter flowkit.ReaderWriter) ([]cadence.Value, error) {
+	var data []byte
+	var err error
+	switch {
+	case value == "-":
+		data, err = io.ReadAll(os.Stdin)
+	case strings.HasPrefix(value, "@"):
+		data, err = readerWriter.ReadFile(strings.TrimPrefix(value, "@"))
+	default:
+		data = []byte(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON arguments: %w", err)
+	}
+
+	return arguments.ParseJSON(string(data))
+}
+
+// StdinFilename is the code filename reading the code from the standard input.
+const StdinFilename = "-"
+
+// ReadCode reads the Cadence code from the file, or from the standard input if the filename is -.
+//
+// The code and the arguments provided by the args JSON flag can't both be read from the standard input.
+func ReadCode(filename string, argsJSON string, readerWriter flowkit.ReaderWriter) ([]byte, error) {
+	if filename != StdinFilename {
+		return readerWriter.ReadFile(filename)
+	}
+
+	if argsJSON == "-" {
+		return nil, fmt.Errorf("the code and the JSON arguments can not both be read from the standard input, provide the arguments from a file with --args-json @file")
+	}
+	return io.ReadAll(os.Stdin)
+}
+
 func removeFromStringArray(s []string, el string) []string {
 	for i, v := range s {
 		if v == el {
@@ -112,3 +373,28 @@ func removeFromStringArray(s []string, el string) []string {
 
 	return s
 }
+
+// CadenceFiles returns the sorted paths of the Cadence files in the directory.
+func CadenceFiles(dir string, readerWriter flowkit.ReaderWriter) ([]string, error) {
+	dirReader, ok := readerWriter.(interface {
+		ReadDir(dirname string) ([]os.FileInfo, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("reading directories is not supported")
+	}
+
+	entries, err := dirReader.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	files := make([]string, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".cdc") {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}